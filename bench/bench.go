@@ -0,0 +1,204 @@
+// Package bench holds the benchmark corpus and the timing harness shared by
+// the Go benchmarks and "htmlint bench". Results can be saved and compared
+// against a baseline so performance work is measured on the same inputs.
+package bench
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/toba/go-html-validate/linter"
+	"github.com/toba/go-html-validate/parser"
+	"github.com/toba/go-html-validate/rules"
+)
+
+//go:embed corpus
+var corpusFS embed.FS
+
+// largePageRepeat is how often the article of page-article is repeated to
+// build page-large.
+const largePageRepeat = 40
+
+// Case is one corpus input.
+type Case struct {
+	// Name identifies the case, e.g. "page-article".
+	Name string
+	// Kind is "fragment", "page", or "template".
+	Kind    string
+	Content []byte
+}
+
+// Corpus returns the bundled inputs: small fragments, full pages (one of
+// them synthesized to be large), and template-heavy files.
+func Corpus() []Case {
+	entries, err := corpusFS.ReadDir("corpus")
+	if err != nil {
+		panic(err) // embedded at build time
+	}
+	var cases []Case
+	for _, e := range entries {
+		content, err := corpusFS.ReadFile(path.Join("corpus", e.Name()))
+		if err != nil {
+			panic(err)
+		}
+		name := strings.TrimSuffix(e.Name(), path.Ext(e.Name()))
+		kind, _, _ := strings.Cut(name, "-")
+		cases = append(cases, Case{Name: name, Kind: kind, Content: content})
+		if name == "page-article" {
+			cases = append(cases, Case{Name: "page-large", Kind: kind, Content: largePage(content)})
+		}
+	}
+	return cases
+}
+
+// largePage repeats the <article> of page to build a page of a few
+// hundred kilobytes.
+func largePage(page []byte) []byte {
+	start := bytes.Index(page, []byte("<article>"))
+	end := bytes.Index(page, []byte("</article>")) + len("</article>")
+	var b bytes.Buffer
+	b.Write(page[:start])
+	for range largePageRepeat {
+		b.Write(page[start:end])
+		b.WriteByte('\n')
+	}
+	b.Write(page[end:])
+	return b.Bytes()
+}
+
+// Stage is the part of the pipeline being measured.
+type Stage string
+
+const (
+	// StagePreprocess measures Go template preprocessing.
+	StagePreprocess Stage = "preprocess"
+	// StageParse measures preprocessing and parsing.
+	StageParse Stage = "parse"
+	// StageLint measures LintContent with every rule enabled.
+	StageLint Stage = "lint"
+)
+
+// Stages lists every stage in pipeline order.
+var Stages = []Stage{StagePreprocess, StageParse, StageLint}
+
+// ParseStage validates a stage name.
+func ParseStage(s string) (Stage, error) {
+	for _, stage := range Stages {
+		if string(stage) == s {
+			return stage, nil
+		}
+	}
+	return "", fmt.Errorf("invalid stage %q (supported: preprocess, parse, lint)", s)
+}
+
+// LintConfig returns the default config with every rule enabled, opt-in
+// rules included, so StageLint exercises the full rule set.
+func LintConfig() *linter.Config {
+	cfg := linter.DefaultConfig()
+	for _, rule := range rules.NewRegistry().All() {
+		cfg.EnabledRules = append(cfg.EnabledRules, rule.Name())
+	}
+	return cfg
+}
+
+// Op returns one iteration of stage over c. cfg configures StageLint; nil
+// means LintConfig.
+func Op(stage Stage, c Case, cfg *linter.Config) func() error {
+	filename := c.Name + ".html"
+	switch stage {
+	case StagePreprocess:
+		prep := parser.NewPreprocessor()
+		return func() error {
+			_, _, err := prep.Process(c.Content)
+			return err
+		}
+	case StageParse:
+		return func() error {
+			_, err := parser.ParseWithMode(filename, c.Content, parser.ModeAuto)
+			return err
+		}
+	default:
+		if cfg == nil {
+			cfg = LintConfig()
+		}
+		l := linter.New(cfg)
+		return func() error {
+			_, err := l.LintContent(filename, c.Content)
+			return err
+		}
+	}
+}
+
+// Result is the measurement of one stage over one case.
+type Result struct {
+	// Name is "<stage>/<case>".
+	Name        string  `json:"name"`
+	Bytes       int     `json:"bytes"`
+	NsPerOp     int64   `json:"nsPerOp"`
+	AllocsPerOp int64   `json:"allocsPerOp"`
+	BytesPerOp  int64   `json:"bytesPerOp"`
+	MBPerSec    float64 `json:"mbPerSec"`
+}
+
+// Measure benchmarks stage over c for about a second.
+func Measure(stage Stage, c Case, cfg *linter.Config) (Result, error) {
+	op := Op(stage, c, cfg)
+	var opErr error
+	br := testing.Benchmark(func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(c.Content)))
+		for b.Loop() {
+			if err := op(); err != nil && opErr == nil {
+				opErr = err
+			}
+		}
+	})
+	if opErr != nil {
+		return Result{}, fmt.Errorf("%s/%s: %w", stage, c.Name, opErr)
+	}
+	r := Result{
+		Name:        string(stage) + "/" + c.Name,
+		Bytes:       len(c.Content),
+		NsPerOp:     br.NsPerOp(),
+		AllocsPerOp: br.AllocsPerOp(),
+		BytesPerOp:  br.AllocedBytesPerOp(),
+	}
+	if r.NsPerOp > 0 {
+		r.MBPerSec = float64(r.Bytes) / float64(r.NsPerOp) * 1e3
+	}
+	return r, nil
+}
+
+// Regression is a result slower than its baseline by more than the
+// allowed threshold.
+type Regression struct {
+	Name              string
+	Baseline, Current int64 // ns/op
+	Change            float64
+}
+
+// Compare returns the results of current whose ns/op exceeds that of the
+// same-named baseline result by more than threshold (0.1 is 10%). Results
+// missing from either side are ignored.
+func Compare(baseline, current []Result, threshold float64) []Regression {
+	base := make(map[string]int64, len(baseline))
+	for _, r := range baseline {
+		base[r.Name] = r.NsPerOp
+	}
+	var regressions []Regression
+	for _, r := range current {
+		b, ok := base[r.Name]
+		if !ok || b <= 0 {
+			continue
+		}
+		change := float64(r.NsPerOp-b) / float64(b)
+		if change > threshold {
+			regressions = append(regressions, Regression{Name: r.Name, Baseline: b, Current: r.NsPerOp, Change: change})
+		}
+	}
+	return regressions
+}
@@ -0,0 +1,65 @@
+package bench_test
+
+import (
+	"testing"
+
+	"github.com/toba/go-html-validate/bench"
+)
+
+func benchmarkStage(b *testing.B, stage bench.Stage) {
+	for _, c := range bench.Corpus() {
+		b.Run(c.Name, func(b *testing.B) {
+			op := bench.Op(stage, c, nil)
+			b.ReportAllocs()
+			b.SetBytes(int64(len(c.Content)))
+			for b.Loop() {
+				if err := op(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkPreprocess(b *testing.B) { benchmarkStage(b, bench.StagePreprocess) }
+func BenchmarkParse(b *testing.B)      { benchmarkStage(b, bench.StageParse) }
+func BenchmarkLint(b *testing.B)       { benchmarkStage(b, bench.StageLint) }
+
+func TestCorpus(t *testing.T) {
+	kinds := make(map[string]int)
+	for _, c := range bench.Corpus() {
+		kinds[c.Kind]++
+		for _, stage := range bench.Stages {
+			if err := bench.Op(stage, c, nil)(); err != nil {
+				t.Errorf("%s/%s: %v", stage, c.Name, err)
+			}
+		}
+	}
+	for _, kind := range []string{"fragment", "page", "template"} {
+		if kinds[kind] == 0 {
+			t.Errorf("corpus has no %s cases", kind)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	baseline := []bench.Result{
+		{Name: "lint/a", NsPerOp: 1000},
+		{Name: "lint/b", NsPerOp: 1000},
+		{Name: "lint/c", NsPerOp: 1000},
+	}
+	current := []bench.Result{
+		{Name: "lint/a", NsPerOp: 1050}, // within threshold
+		{Name: "lint/b", NsPerOp: 1200}, // regressed
+		{Name: "lint/c", NsPerOp: 500},  // faster
+		{Name: "lint/d", NsPerOp: 9000}, // no baseline
+	}
+
+	got := bench.Compare(baseline, current, 0.1)
+	if len(got) != 1 || got[0].Name != "lint/b" || got[0].Baseline != 1000 || got[0].Current != 1200 {
+		t.Fatalf("Compare() = %+v, want only lint/b", got)
+	}
+	if got[0].Change < 0.19 || got[0].Change > 0.21 {
+		t.Errorf("Change = %v, want 0.2", got[0].Change)
+	}
+}
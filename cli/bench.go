@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/toba/go-html-validate/bench"
+)
+
+// runBench implements the hidden "htmlint bench" command: it times the
+// bundled corpus and, given a baseline, fails on regressions.
+func runBench(args []string, opts Options) int {
+	var (
+		stages    stringSlice
+		filter    string
+		format    string
+		save      string
+		baseline  string
+		threshold float64
+	)
+	flags := flag.NewFlagSet("htmlint bench", flag.ContinueOnError)
+	flags.Var(&stages, "stage", "Stage to run: preprocess, parse, lint (default all)")
+	flags.StringVar(&filter, "case", "", "Only run corpus cases whose name contains this")
+	flags.StringVar(&format, "format", "text", "Output format: text, json")
+	flags.StringVar(&save, "save", "", "Write results as JSON to this file")
+	flags.StringVar(&baseline, "baseline", "", "Compare against results saved with --save")
+	flags.Float64Var(&threshold, "threshold", 0.1, "Allowed slowdown against the baseline (0.1 is 10%)")
+	flags.Usage = func() {
+		fmt.Fprint(os.Stderr, `htmlint bench - time htmlint on the bundled corpus
+
+Usage:
+  htmlint bench [--stage NAME]... [--case TEXT] [--save FILE]
+                [--baseline FILE] [--threshold 0.1] [--format text|json]
+
+Measures preprocessing, parsing, and linting with every rule enabled over
+small fragments, large pages, and template-heavy files. With --baseline,
+exits 1 when a result is slower than its baseline by more than --threshold.
+`)
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	run := bench.Stages
+	if len(stages) > 0 {
+		run = nil
+		for _, s := range stages {
+			stage, err := bench.ParseStage(s)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				return 2
+			}
+			run = append(run, stage)
+		}
+	}
+
+	var base []bench.Result
+	if baseline != "" {
+		data, err := os.ReadFile(baseline) //nolint:gosec // user-specified baseline path is intentional
+		if err == nil {
+			err = json.Unmarshal(data, &base)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: reading baseline: %v\n", err)
+			return 1
+		}
+	}
+
+	cfg := bench.LintConfig()
+	cfg.Packs = opts.Packs
+	var results []bench.Result
+	for _, stage := range run {
+		for _, c := range bench.Corpus() {
+			if !strings.Contains(c.Name, filter) {
+				continue
+			}
+			r, err := bench.Measure(stage, c, cfg)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				return 1
+			}
+			results = append(results, r)
+		}
+	}
+
+	if format == "json" {
+		data, _ := json.MarshalIndent(results, "", "  ")
+		fmt.Println(string(data))
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "benchmark\tbytes\tns/op\tMB/s\tallocs/op\tB/op\t")
+		for _, r := range results {
+			fmt.Fprintf(w, "%s\t%d\t%d\t%.2f\t%d\t%d\t\n", r.Name, r.Bytes, r.NsPerOp, r.MBPerSec, r.AllocsPerOp, r.BytesPerOp)
+		}
+		_ = w.Flush()
+	}
+
+	if save != "" {
+		data, _ := json.MarshalIndent(results, "", "  ")
+		if err := os.WriteFile(save, append(data, '\n'), 0o600); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			return 1
+		}
+	}
+
+	regressions := bench.Compare(base, results, threshold)
+	for _, r := range regressions {
+		fmt.Fprintf(os.Stderr, "regression: %s %d ns/op -> %d ns/op (+%.1f%%)\n", r.Name, r.Baseline, r.Current, r.Change*100)
+	}
+	if len(regressions) > 0 {
+		return 1
+	}
+	return 0
+}
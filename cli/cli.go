@@ -0,0 +1,613 @@
+// Package cli implements the htmlint command. It is shared by the default
+// binary and custom builds that bundle rule packs (see "htmlint custom").
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"slices"
+	"strings"
+
+	"github.com/toba/go-html-validate/config"
+	"github.com/toba/go-html-validate/linter"
+	"github.com/toba/go-html-validate/messages"
+	"github.com/toba/go-html-validate/parser"
+	"github.com/toba/go-html-validate/reporter"
+	"github.com/toba/go-html-validate/rules"
+)
+
+// Options customizes a build of the htmlint command.
+type Options struct {
+	// Version is reported by --version; build info is used when empty.
+	Version string
+	// Packs are rule packs bundled into the binary.
+	Packs []rules.Pack
+}
+
+type stringSlice []string
+
+func (s *stringSlice) String() string { return strings.Join(*s, ",") }
+func (s *stringSlice) Set(v string) error {
+	*s = append(*s, v)
+	return nil
+}
+
+// Run executes the htmlint command with args (excluding the program name)
+// and returns the process exit code.
+func Run(args []string, opts Options) int {
+	if len(args) > 0 && args[0] == "custom" {
+		return runCustom(args[1:], opts)
+	}
+	if err := checkPacks(opts.Packs); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return 1
+	}
+	if len(args) > 0 && args[0] == "bench" {
+		return runBench(args[1:], opts)
+	}
+	if len(args) > 0 && args[0] == "render" {
+		return runRender(args[1:], opts)
+	}
+	// "htmlint metrics" lints like htmlint but reports aggregate counts
+	metrics := len(args) > 0 && args[0] == "metrics"
+	if metrics {
+		args = args[1:]
+	}
+	// "htmlint fix" is htmlint --fix; "htmlint fix -i" asks about each fix
+	fixCommand := len(args) > 0 && args[0] == "fix"
+	if fixCommand {
+		args = args[1:]
+	}
+
+	var (
+		format       string
+		quiet        bool
+		noColor      bool
+		ignoreFlags  stringSlice
+		disableFlags stringSlice
+		showHelp     bool
+		showVersion  bool
+		listRules    bool
+		configPath   string
+		noConfig     bool
+		printConfig  bool
+		includeGen   bool
+		branches     bool
+		dialect      string
+		fix          bool
+		dryRun       bool
+		patchPath    string
+		interactive  bool
+		profiles     string
+		locale       string
+		pathMode     string
+		maxMemory    string
+		streamSize   string
+		codeOwners   bool
+		groupBy      string
+		dirDepth     int
+	)
+
+	flags := flag.NewFlagSet("htmlint", flag.ContinueOnError)
+
+	flags.StringVar(&format, "format", "text", "Output format: text, json")
+	flags.StringVar(&format, "f", "text", "Output format (shorthand)")
+	flags.BoolVar(&quiet, "quiet", false, "Only show errors")
+	flags.BoolVar(&quiet, "q", false, "Only show errors (shorthand)")
+	flags.BoolVar(&noColor, "no-color", false, "Disable colored output")
+	flags.Var(&ignoreFlags, "ignore", "Glob pattern to ignore")
+	flags.Var(&disableFlags, "disable", "Rule to disable")
+	flags.BoolVar(&showHelp, "help", false, "Show help")
+	flags.BoolVar(&showHelp, "h", false, "Show help (shorthand)")
+	flags.BoolVar(&showVersion, "version", false, "Show version")
+	flags.BoolVar(&showVersion, "v", false, "Show version (shorthand)")
+	flags.BoolVar(&listRules, "list-rules", false, "List available rules")
+	flags.StringVar(&configPath, "config", "", "Path to config file")
+	flags.BoolVar(&noConfig, "no-config", false, "Disable config file loading")
+	flags.BoolVar(&printConfig, "print-config", false, "Print resolved configuration")
+	flags.BoolVar(&includeGen, "include-generated", false, "Lint generated files")
+	flags.BoolVar(&branches, "template-branches", true, "Lint each template if/else branch (=false: only the if-branch)")
+	flags.StringVar(&dialect, "template-dialect", "", "Template syntax: go, jet, pongo2, handlebars")
+	flags.BoolVar(&fix, "fix", false, "Apply automatic fixes")
+	flags.BoolVar(&dryRun, "dry-run", false, "With --fix, print fixes as a diff instead of applying them")
+	flags.StringVar(&patchPath, "patch", "", "With --fix, write fixes to this patch file instead of applying them")
+	flags.BoolVar(&interactive, "interactive", false, "With fix, ask about each fix")
+	flags.BoolVar(&interactive, "i", false, "With fix, ask about each fix (shorthand)")
+	flags.StringVar(&locale, "locale", "", "Message language")
+	flags.StringVar(&pathMode, "path-mode", "", "How filenames are reported: absolute, relative, repo-relative")
+	flags.StringVar(&maxMemory, "max-memory", "", "Memory limit, e.g. 512MiB (default: $GOMEMLIMIT)")
+	flags.StringVar(&streamSize, "stream-threshold", "", "Stream files larger than this, e.g. 16MiB, checking token rules only")
+	flags.BoolVar(&codeOwners, "codeowners", false, "Attribute findings to owners from CODEOWNERS")
+	flags.StringVar(&groupBy, "group-by", "", "Summarize findings by: owner")
+	flags.IntVar(&dirDepth, "dir-depth", reporter.DefaultDirDepth, "Directory components kept in metrics labels")
+	flags.StringVar(&profiles, "profile", os.Getenv("HTMLINT_PROFILE"), "Comma-separated config profiles to apply")
+
+	flags.Usage = usage
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if metrics {
+		formatSet := false
+		flags.Visit(func(f *flag.Flag) { formatSet = formatSet || f.Name == "format" || f.Name == "f" })
+		if !formatSet {
+			format = "prometheus"
+		}
+		if format != "prometheus" && format != "json" {
+			fmt.Fprintf(os.Stderr, "error: invalid metrics format %q (supported: prometheus, json)\n", format)
+			return 2
+		}
+	}
+
+	if showHelp {
+		usage()
+		return 0
+	}
+
+	if showVersion {
+		fmt.Println(getVersion(opts))
+		for _, pack := range opts.Packs {
+			fmt.Printf("  with %s %s\n", pack.Name, pack.Version)
+		}
+		return 0
+	}
+
+	if listRules {
+		printRules(opts.Packs)
+		return 0
+	}
+
+	args = flags.Args()
+
+	// Go-style "dir/..." patterns name the directory, which is linted
+	// recursively anyway
+	for i, arg := range args {
+		if arg == "..." {
+			args[i] = "."
+		} else if dir, ok := strings.CutSuffix(arg, "/..."); ok {
+			args[i] = dir
+		}
+	}
+
+	// Determine search directory for config
+	searchDir := "."
+	if len(args) > 0 {
+		if info, err := os.Stat(args[0]); err == nil && info.IsDir() {
+			searchDir = args[0]
+		} else if err == nil {
+			searchDir = filepath.Dir(args[0])
+		}
+	}
+
+	// Load config file
+	var fileCfg *config.FileConfig
+	var loadedConfigPath string
+	if !noConfig {
+		var err error
+		if fileCfg, loadedConfigPath, err = loadConfig(configPath, searchDir); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			return 1
+		}
+	}
+
+	var memLimit int64
+	if maxMemory != "" {
+		var err error
+		if memLimit, err = linter.ParseMemorySize(maxMemory); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			return 1
+		}
+		debug.SetMemoryLimit(memLimit)
+	}
+
+	var streamThreshold int64
+	if streamSize != "" {
+		var err error
+		if streamThreshold, err = linter.ParseMemorySize(streamSize); err != nil {
+			fmt.Fprintf(os.Stderr, "error: --stream-threshold: %v\n", err)
+			return 1
+		}
+	}
+
+	fix = fix || fixCommand
+	if interactive && !fixCommand {
+		fmt.Fprintln(os.Stderr, "error: -i is only valid with htmlint fix")
+		return 2
+	}
+	var fixFilter func(string, []byte, rules.Result) bool
+	if interactive {
+		fixFilter = newFixPrompter(os.Stdin, os.Stderr).accept
+	}
+
+	// --dry-run and --patch divert fixes into a unified diff
+	var fixDiff io.Writer
+	if (dryRun || patchPath != "") && !fix {
+		fmt.Fprintln(os.Stderr, "error: --dry-run and --patch require --fix")
+		return 2
+	}
+	if dryRun && format != "text" {
+		// the diff shares stdout with the report
+		fmt.Fprintln(os.Stderr, "error: --dry-run requires --format=text; use --patch with other formats")
+		return 2
+	}
+	if dryRun {
+		fixDiff = os.Stdout
+	}
+	if patchPath != "" {
+		f, err := os.Create(patchPath) //nolint:gosec // user-specified file path is intentional
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: --patch: %v\n", err)
+			return 1
+		}
+		defer f.Close()
+		if fixDiff != nil {
+			fixDiff = io.MultiWriter(fixDiff, f)
+		} else {
+			fixDiff = f
+		}
+	}
+
+	var templateDialect parser.Dialect
+	if dialect != "" {
+		var err error
+		if templateDialect, err = parser.LookupDialect(dialect); err != nil {
+			fmt.Fprintf(os.Stderr, "error: --template-dialect: %v\n", err)
+			return 1
+		}
+	}
+
+	// Load ignore patterns
+	ignorePatterns, err := config.LoadIgnorePatterns(searchDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: error loading ignore file: %v\n", err)
+	}
+
+	// --template-branches=false overrides the config only when given
+	branchesSet := false
+	flags.Visit(func(f *flag.Flag) { branchesSet = branchesSet || f.Name == "template-branches" })
+
+	// buildConfig converts a file config to a linter config and applies
+	// the CLI flags on top.
+	buildConfig := func(fc *config.FileConfig, path string, ignorePatterns []string) (*linter.Config, error) {
+		cfg := config.ToLinterConfig(fc, path)
+
+		// Add ignore patterns from ignore file
+		cfg.IgnorePatterns = append(cfg.IgnorePatterns, ignorePatterns...)
+
+		// CLI flags override config file
+		cfg.DisabledRules = append(cfg.DisabledRules, disableFlags...)
+		cfg.IgnorePatterns = append(cfg.IgnorePatterns, ignoreFlags...)
+
+		if quiet {
+			cfg.ErrorsOnly()
+		}
+		if includeGen {
+			cfg.Generated.Include = true
+		}
+		if branchesSet {
+			cfg.TemplateBranches = branches
+		}
+		if dialect != "" {
+			cfg.TemplateDialect = templateDialect
+		}
+		cfg.MaxMemory = memLimit
+		cfg.StreamThreshold = streamThreshold
+		if fix {
+			cfg.Fix = true
+			cfg.FixDiff = fixDiff
+			cfg.FixFilter = fixFilter
+		}
+		if locale != "" {
+			lang, ok := messages.Normalize(locale)
+			if !ok {
+				return nil, fmt.Errorf("unsupported locale %q (supported: %s)", locale, strings.Join(messages.Locales(), ", "))
+			}
+			cfg.Locale = lang
+		}
+		var err error
+		if cfg.PathMode, err = linter.ParsePathMode(pathMode); err != nil {
+			return nil, err
+		}
+		for name := range strings.SplitSeq(profiles, ",") {
+			if name = strings.TrimSpace(name); name != "" && !cfg.ApplyProfile(name) {
+				return nil, fmt.Errorf("unknown profile %q", name)
+			}
+		}
+		cfg.Packs = append(cfg.Packs, opts.Packs...)
+		return cfg, nil
+	}
+
+	cfg, err := buildConfig(fileCfg, loadedConfigPath, ignorePatterns)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return 1
+	}
+
+	// Print config and exit if requested
+	if printConfig {
+		printResolvedConfig(cfg, loadedConfigPath)
+		return 0
+	}
+
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "error: no files or directories specified")
+		fmt.Fprintln(os.Stderr, "usage: htmlint [options] <files or directories>")
+		return 1
+	}
+
+	// Create linter; a config listing workspace projects lints each
+	// project with its own config
+	var l runner = linter.New(cfg)
+	if fileCfg != nil && len(fileCfg.Workspace) > 0 {
+		projects, err := config.WorkspaceProjects(fileCfg, loadedConfigPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			return 1
+		}
+		linterProjects := make([]linter.Project, 0, len(projects))
+		for _, p := range projects {
+			projectIgnore, err := config.LoadIgnorePatterns(p.Dir)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "warning: error loading ignore file: %v\n", err)
+			}
+			projectCfg, err := buildConfig(p.Config, p.ConfigPath, append(slices.Clone(ignorePatterns), projectIgnore...))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				return 1
+			}
+			linterProjects = append(linterProjects, linter.Project{Dir: p.Dir, Config: projectCfg})
+		}
+		l = linter.NewWorkspace(cfg, linterProjects)
+	}
+
+	// Attribute findings to owners; grouping by owner implies it
+	if groupBy, err = reporter.ParseGroupBy(groupBy); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return 1
+	}
+	if codeOwners || groupBy == reporter.GroupByOwner {
+		owners, err := linter.LoadCodeOwners(searchDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			return 1
+		}
+		l.Use(owners.Middleware())
+	}
+
+	// Set reporter
+	var rep linter.Reporter
+	switch {
+	case metrics:
+		metricsRep := reporter.NewMetrics(format)
+		metricsRep.DirDepth = dirDepth
+		rep = metricsRep
+	case format == "json":
+		jsonRep := reporter.NewJSON()
+		jsonRep.GroupBy = groupBy
+		rep = jsonRep
+	default:
+		textRep := reporter.NewText()
+		textRep.NoColor = noColor
+		textRep.GroupBy = groupBy
+		rep = textRep
+	}
+	l.SetReporter(rep)
+
+	// Run linting
+	errorCount, err := l.Run(args)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return 1
+	}
+
+	// Metrics runs record debt rather than gate on it
+	if errorCount > 0 && !metrics {
+		return 1
+	}
+	return 0
+}
+
+// runner lints paths and reports the results: a Linter, or a Workspace
+// of per-project linters.
+type runner interface {
+	SetReporter(linter.Reporter)
+	Use(mw ...linter.Middleware)
+	Run(paths []string) (int, error)
+}
+
+// checkPacks verifies that pack rules follow the "<pack>/<rule>" naming
+// convention and do not collide with other rules.
+func checkPacks(packs []rules.Pack) error {
+	seen := make(map[string]string) // rule name -> pack
+	for _, rule := range rules.NewRegistry().All() {
+		seen[rule.Name()] = "htmlint"
+	}
+	for _, pack := range packs {
+		if pack.Name == config.CustomRulesPack {
+			return fmt.Errorf("pack name %q is reserved for custom-rules", pack.Name)
+		}
+		for _, rule := range pack.Rules() {
+			if !pack.PackRuleName(rule.Name()) {
+				return fmt.Errorf("pack %s: rule %q must be named %s/<rule>", pack.Name, rule.Name(), pack.Name)
+			}
+			if owner, ok := seen[rule.Name()]; ok {
+				return fmt.Errorf("pack %s: rule %q is already defined by %s", pack.Name, rule.Name(), owner)
+			}
+			seen[rule.Name()] = pack.Name
+		}
+	}
+	return nil
+}
+
+// loadConfig loads the config file at configPath with its extends
+// resolved, or else the config found from searchDir, and returns it with
+// its path. Both are empty when there is none.
+func loadConfig(configPath, searchDir string) (*config.FileConfig, string, error) {
+	if configPath == "" {
+		fileCfg, path, err := config.Resolve(searchDir)
+		if err != nil {
+			return nil, "", fmt.Errorf("loading config: %w", err)
+		}
+		return fileCfg, path, nil
+	}
+	fileCfg, err := config.LoadFile(configPath)
+	if err != nil {
+		return nil, "", err
+	}
+	fileCfg, err = config.ResolveExtends(fileCfg, filepath.Dir(configPath))
+	if err != nil {
+		return nil, "", err
+	}
+	return fileCfg, configPath, nil
+}
+
+func printResolvedConfig(cfg *linter.Config, configPath string) {
+	output := struct {
+		ConfigFile     string            `json:"configFile,omitempty"`
+		DisabledRules  []string          `json:"disabledRules,omitempty"`
+		RuleSeverities map[string]string `json:"ruleSeverities,omitempty"`
+		IgnorePatterns []string          `json:"ignorePatterns,omitempty"`
+	}{
+		ConfigFile:     configPath,
+		DisabledRules:  cfg.DisabledRules,
+		IgnorePatterns: cfg.IgnorePatterns,
+	}
+
+	if len(cfg.RuleSeverity) > 0 {
+		output.RuleSeverities = make(map[string]string)
+		for name, sev := range cfg.RuleSeverity {
+			output.RuleSeverities[name] = sev.String()
+		}
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(output)
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `htmlint - HTML accessibility linter for Go templates
+
+Usage:
+  htmlint [options] <files or directories>
+
+Options:
+  -f, --format      Output format: text, json (default: text)
+  -q, --quiet       Only show errors, not warnings
+  --no-color        Disable colored output
+  --ignore PATTERN  Glob pattern to ignore (can be repeated)
+  --disable RULE    Disable specific rule (can be repeated)
+  --config PATH     Path to config file (.htmlvalidate.json)
+  --no-config       Disable config file loading
+  --print-config    Print resolved configuration and exit
+  --include-generated
+                    Lint files marked as generated (skipped by default)
+  --template-branches=false
+                    Lint only the {{if}} branch of {{if}}/{{else}} blocks,
+                    not each branch (default: each branch)
+  --template-dialect NAME
+                    Template syntax of the linted files: go (default), jet, pongo2,
+                    or handlebars
+  --fix             Apply automatic fixes and report remaining problems
+  --dry-run         With --fix, print the fixes as a unified diff instead of
+                    changing files, and report every problem (text format only)
+  --patch FILE      With --fix, write the fixes to FILE as a patch for git
+                    apply instead of changing files, and report every problem
+  --profile NAMES   Apply comma-separated config profiles to every file
+                    (default: $HTMLINT_PROFILE)
+  --locale LANG     Message language: en, de, ja (default: en)
+  --path-mode MODE  Report filenames as absolute, relative (to the working
+                    directory), or repo-relative (to the git repository root)
+  --codeowners      Append the owners of each file, from CODEOWNERS, to its
+                    findings (JSON: "meta": {"owner": ...})
+  --group-by owner  Summarize findings per CODEOWNERS owner (implies
+                    --codeowners)
+  --max-memory SIZE Soft memory limit, e.g. 512MiB (default: $GOMEMLIMIT);
+                    large projects keep a compact template index instead of
+                    every file's source
+  --stream-threshold SIZE
+                    Stream files larger than SIZE, e.g. 16MiB, instead of
+                    parsing them; only token rules (duplicate-id,
+                    no-dup-attr, no-inline-style) check streamed files
+  --list-rules      List available rules
+  -v, --version     Show version
+  -h, --help        Show this help
+
+Config files:
+  htmlint looks for .htmlvalidate.json in the target directory and parent
+  directories. Use .htmlvalidateignore for gitignore-style file patterns.
+
+Metrics:
+  htmlint metrics [options] <files or directories>
+                    Report finding counts by rule, severity, and directory,
+                    files scanned, and duration instead of findings, in the
+                    Prometheus text format (--format=prometheus, default)
+                    or as JSON (--format=json); always exits 0 unless the
+                    run fails. --dir-depth N (default 2) sets how many
+                    directory components label each count
+
+Fixing:
+  htmlint fix [-i] [options] <files or directories>
+                    Same as --fix; with -i (--interactive), show each fix
+                    with the code around it and ask whether to apply it:
+                    y (yes), n (no), a (all for this rule), d (none for
+                    this rule), or q (quit)
+
+Rendering:
+  htmlint render --data FILE [options] <template> [templates...]
+                    Execute a Go template with JSON or YAML test data and
+                    lint the output, reporting lines of the output; the
+                    other templates are available to {{template}} calls
+
+Rule packs:
+  htmlint custom [--config PATH]
+                    Build a binary bundling the rule packs listed in
+                    .htmlint-custom.json (requires the Go toolchain)
+
+Workspaces:
+  A config with "workspace": ["apps/*"] lints each project with its own
+  config; "htmlint ./..." lints the whole workspace.
+
+Examples:
+  htmlint web/
+  htmlint -q web/**/*.html
+  htmlint --format=json web/ > lint-results.json
+  htmlint --disable=prefer-aria web/
+  HTMLINT_PROFILE=ci htmlint web/
+  htmlint --group-by=owner --format=json web/
+  htmlint fix -i web/
+  htmlint render --data=testdata/user.json web/profile.html web/partials/*.html
+  htmlint metrics web/ | curl --data-binary @- http://pushgateway:9091/metrics/job/htmlint
+`)
+}
+
+func printRules(packs []rules.Pack) {
+	registry := rules.NewRegistry()
+	fmt.Println("Available rules:")
+	fmt.Println()
+	for _, rule := range registry.All() {
+		fmt.Printf("  %-30s %s\n", rule.Name(), rule.Description())
+	}
+	for _, pack := range packs {
+		fmt.Println()
+		fmt.Printf("Rules from pack %s %s:\n", pack.Name, pack.Version)
+		fmt.Println()
+		for _, rule := range pack.Rules() {
+			fmt.Printf("  %-30s %s\n", rule.Name(), rule.Description())
+		}
+	}
+}
+
+func getVersion(opts Options) string {
+	if opts.Version != "" {
+		return opts.Version
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	return "dev"
+}
@@ -0,0 +1,85 @@
+package cli_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/toba/go-html-validate/cli"
+)
+
+// runCLI runs htmlint with args and returns what it wrote to stdout.
+func runCLI(t *testing.T, args ...string) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	done := make(chan []byte)
+	go func() {
+		out, _ := io.ReadAll(r)
+		done <- out
+	}()
+	cli.Run(args, cli.Options{Version: "test"})
+	w.Close()
+	return string(<-done)
+}
+
+func TestRun_ConfigExtends(t *testing.T) {
+	tests := []struct {
+		name    string
+		org     string
+		page    string
+		want    string
+		wantNot string
+	}{
+		{
+			name:    "rules",
+			org:     `{"rules": {"no-inline-style": "off"}}`,
+			page:    `<p style="color: red">Hi</p>`,
+			wantNot: "no-inline-style",
+		},
+		{
+			name: "banned",
+			org:  `{"banned": [{"element": "b", "message": "use strong"}]}`,
+			page: `<p><b>Hi</b></p>`,
+			want: "banned-markup",
+		},
+		{
+			name: "required",
+			org:  `{"required": [{"selector": "img", "attributes": ["loading"]}]}`,
+			page: `<img src="a.png" alt="A">`,
+			want: "required-attributes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			files := map[string]string{
+				"org.json":  tt.org,
+				"proj.json": `{"extends": ["./org.json"]}`,
+				"page.html": tt.page,
+			}
+			for name, content := range files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			out := runCLI(t, "--config", filepath.Join(dir, "proj.json"), "--no-color", filepath.Join(dir, "page.html"))
+			if tt.want != "" && !strings.Contains(out, tt.want) {
+				t.Errorf("output does not contain %q:\n%s", tt.want, out)
+			}
+			if tt.wantNot != "" && strings.Contains(out, tt.wantNot) {
+				t.Errorf("output contains %q:\n%s", tt.wantNot, out)
+			}
+		})
+	}
+}
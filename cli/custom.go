@@ -0,0 +1,229 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// CustomConfigFile is the default build configuration for "htmlint custom".
+const CustomConfigFile = ".htmlint-custom.json"
+
+// modulePath is the module path of htmlint itself.
+const modulePath = "github.com/toba/go-html-validate"
+
+// CustomConfig describes a custom htmlint binary bundling rule packs.
+type CustomConfig struct {
+	// Version of htmlint to build against; defaults to the running version.
+	Version string `json:"version"`
+	// Path is a local htmlint checkout to build against instead of Version.
+	Path string `json:"path"`
+	// Name of the binary (default "htmlint-custom").
+	Name string `json:"name"`
+	// Destination directory of the binary (default ".").
+	Destination string `json:"destination"`
+	// Packs lists the rule packs to bundle.
+	Packs []CustomPack `json:"packs"`
+}
+
+// CustomPack is a rule pack module to bundle.
+type CustomPack struct {
+	// Module is the Go module path of the pack.
+	Module string `json:"module"`
+	// Import is the package exporting Metadata and Rules (default Module).
+	Import string `json:"import"`
+	// Version of the module, e.g. "v1.2.0".
+	Version string `json:"version"`
+	// Path is a local checkout used instead of Version.
+	Path string `json:"path"`
+}
+
+// LoadCustomConfig reads and validates a custom build configuration.
+// Relative paths are resolved against the file's directory.
+func LoadCustomConfig(path string) (*CustomConfig, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // user-specified config path is intentional
+	if err != nil {
+		return nil, err
+	}
+	var cfg CustomConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if len(cfg.Packs) == 0 {
+		return nil, fmt.Errorf("%s: no packs listed", path)
+	}
+
+	dir := filepath.Dir(path)
+	resolve := func(p string) string {
+		if p == "" || filepath.IsAbs(p) {
+			return p
+		}
+		return filepath.Join(dir, p)
+	}
+	cfg.Path = resolve(cfg.Path)
+	cfg.Destination = resolve(cfg.Destination)
+	if cfg.Destination == "" {
+		cfg.Destination = dir
+	}
+	if cfg.Name == "" {
+		cfg.Name = "htmlint-custom"
+	}
+	for i := range cfg.Packs {
+		p := &cfg.Packs[i]
+		if p.Module == "" {
+			return nil, fmt.Errorf("%s: pack %d has no module", path, i+1)
+		}
+		if p.Version == "" && p.Path == "" {
+			return nil, fmt.Errorf("%s: pack %s needs a version or path", path, p.Module)
+		}
+		if p.Import == "" {
+			p.Import = p.Module
+		}
+		p.Path = resolve(p.Path)
+	}
+	return &cfg, nil
+}
+
+// GenerateMain returns the source of the main package of a custom build.
+func GenerateMain(cfg *CustomConfig) []byte {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by htmlint custom. DO NOT EDIT.\n\n")
+	b.WriteString("package main\n\nimport (\n\t\"os\"\n\n")
+	fmt.Fprintf(&b, "\t%q\n\t%q\n", modulePath+"/cli", modulePath+"/rules")
+	for i, p := range cfg.Packs {
+		fmt.Fprintf(&b, "\tpack%d %q\n", i, p.Import)
+	}
+	b.WriteString(")\n\n// version is set by ldflags.\nvar version = \"\"\n\n")
+	b.WriteString("func main() {\n\tos.Exit(cli.Run(os.Args[1:], cli.Options{\n\t\tVersion: version,\n\t\tPacks: []rules.Pack{\n")
+	for i, p := range cfg.Packs {
+		fmt.Fprintf(&b, "\t\t\t{PackMetadata: pack%d.Metadata, Version: %q, Rules: pack%d.Rules},\n", i, packVersion(p), i)
+	}
+	b.WriteString("\t\t},\n\t}))\n}\n")
+	if src, err := format.Source(b.Bytes()); err == nil {
+		return src
+	}
+	return b.Bytes()
+}
+
+func packVersion(p CustomPack) string {
+	if p.Version != "" {
+		return p.Version
+	}
+	return "(devel)"
+}
+
+// BuildCustom builds the binary described by cfg in a temporary module
+// and returns its path. htmlintVersion is used when cfg.Version is empty.
+func BuildCustom(cfg *CustomConfig, htmlintVersion string) (string, error) {
+	work, err := os.MkdirTemp("", "htmlint-custom-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(work)
+
+	if err := os.WriteFile(filepath.Join(work, "main.go"), GenerateMain(cfg), 0o600); err != nil {
+		return "", err
+	}
+
+	goCmd := func(args ...string) error {
+		cmd := exec.Command("go", args...) //nolint:gosec // arguments come from the build config
+		cmd.Dir = work
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("go %s: %w\n%s", strings.Join(args, " "), err, stderr.String())
+		}
+		return nil
+	}
+
+	steps := [][]string{{"mod", "init", "htmlint-custom"}}
+	version := cfg.Version
+	if version == "" {
+		version = htmlintVersion
+	}
+	switch {
+	case cfg.Path != "":
+		abs, err := filepath.Abs(cfg.Path)
+		if err != nil {
+			return "", err
+		}
+		steps = append(steps, []string{"mod", "edit", "-replace", modulePath + "=" + abs})
+	case version != "" && version != "dev" && version != "(devel)":
+		steps = append(steps, []string{"get", modulePath + "@" + version})
+	default:
+		return "", errors.New("set \"version\" or \"path\" for htmlint: this binary has no release version")
+	}
+	for _, p := range cfg.Packs {
+		if p.Path != "" {
+			abs, err := filepath.Abs(p.Path)
+			if err != nil {
+				return "", err
+			}
+			steps = append(steps, []string{"mod", "edit", "-replace", p.Module + "=" + abs})
+		} else {
+			steps = append(steps, []string{"get", p.Module + "@" + p.Version})
+		}
+	}
+
+	out, err := filepath.Abs(filepath.Join(cfg.Destination, cfg.Name))
+	if err != nil {
+		return "", err
+	}
+	steps = append(steps,
+		[]string{"mod", "tidy"},
+		[]string{"build", "-o", out, "-ldflags", "-X main.version=" + customVersion(version)},
+	)
+	for _, step := range steps {
+		if err := goCmd(step...); err != nil {
+			return "", err
+		}
+	}
+	return out, nil
+}
+
+// customVersion marks the version of a custom build.
+func customVersion(version string) string {
+	if version == "" || version == "(devel)" {
+		version = "dev"
+	}
+	return version + "-custom"
+}
+
+// runCustom implements "htmlint custom".
+func runCustom(args []string, opts Options) int {
+	flags := flag.NewFlagSet("htmlint custom", flag.ContinueOnError)
+	configPath := flags.String("config", CustomConfigFile, "Path to custom build config")
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, `htmlint custom - build htmlint with rule packs
+
+Usage:
+  htmlint custom [--config PATH]
+
+Reads %s (or --config) and builds a binary bundling the listed
+rule packs. Requires the Go toolchain.
+`, CustomConfigFile)
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	cfg, err := LoadCustomConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return 1
+	}
+	out, err := BuildCustom(cfg, getVersion(opts))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return 1
+	}
+	fmt.Println("built " + out)
+	return 0
+}
@@ -0,0 +1,116 @@
+package cli_test
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/toba/go-html-validate/cli"
+)
+
+func TestLoadCustomConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "no packs",
+			content: `{"name": "htmlint-acme"}`,
+			wantErr: "no packs listed",
+		},
+		{
+			name:    "pack without module",
+			content: `{"packs": [{"version": "v1.0.0"}]}`,
+			wantErr: "pack 1 has no module",
+		},
+		{
+			name:    "pack without version or path",
+			content: `{"packs": [{"module": "example.com/acme"}]}`,
+			wantErr: "needs a version or path",
+		},
+		{
+			name:    "invalid JSON",
+			content: `{"packs": [`,
+			wantErr: "parsing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), cli.CustomConfigFile)
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			_, err := cli.LoadCustomConfig(path)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadCustomConfig() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadCustomConfig_Defaults(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, cli.CustomConfigFile)
+	content := `{
+		"destination": "bin",
+		"packs": [
+			{"module": "example.com/acme", "version": "v1.2.0"},
+			{"module": "example.com/local", "import": "example.com/local/rules", "path": "../local"}
+		]
+	}`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := cli.LoadCustomConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Name != "htmlint-custom" {
+		t.Errorf("Name = %q, want htmlint-custom", cfg.Name)
+	}
+	if want := filepath.Join(dir, "bin"); cfg.Destination != want {
+		t.Errorf("Destination = %q, want %q", cfg.Destination, want)
+	}
+	if cfg.Packs[0].Import != "example.com/acme" {
+		t.Errorf("Import = %q, want module path", cfg.Packs[0].Import)
+	}
+	if want := filepath.Join(filepath.Dir(dir), "local"); cfg.Packs[1].Path != want {
+		t.Errorf("Path = %q, want %q", cfg.Packs[1].Path, want)
+	}
+}
+
+func TestGenerateMain(t *testing.T) {
+	cfg := &cli.CustomConfig{Packs: []cli.CustomPack{
+		{Module: "example.com/acme", Import: "example.com/acme", Version: "v1.2.0"},
+		{Module: "example.com/local", Import: "example.com/local/rules", Path: "/src/local"},
+	}}
+	src := generateMainSource(t, cfg)
+
+	for _, want := range []string{
+		`pack0 "example.com/acme"`,
+		`pack1 "example.com/local/rules"`,
+		`{PackMetadata: pack0.Metadata, Version: "v1.2.0", Rules: pack0.Rules}`,
+		`{PackMetadata: pack1.Metadata, Version: "(devel)", Rules: pack1.Rules}`,
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("generated main missing %q:\n%s", want, src)
+		}
+	}
+}
+
+// generateMainSource returns the generated main for cfg after checking
+// that it parses.
+func generateMainSource(t *testing.T, cfg *cli.CustomConfig) string {
+	t.Helper()
+	src := cli.GenerateMain(cfg)
+	if _, err := parser.ParseFile(token.NewFileSet(), "main.go", src, 0); err != nil {
+		t.Fatalf("generated main does not parse: %v\n%s", err, src)
+	}
+	return string(src)
+}
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/toba/go-html-validate/linter"
+	"github.com/toba/go-html-validate/rules"
+)
+
+// fixPrompter asks about each fix in turn for "htmlint fix -i", in the
+// manner of git add -p.
+type fixPrompter struct {
+	in  *bufio.Reader
+	out io.Writer
+	// rules maps a rule to the answer given for all its remaining fixes
+	rules map[string]bool
+	quit  bool
+}
+
+func newFixPrompter(in io.Reader, out io.Writer) *fixPrompter {
+	return &fixPrompter{in: bufio.NewReader(in), out: out, rules: make(map[string]bool)}
+}
+
+const fixPromptHelp = `y - apply this fix
+n - skip this fix
+a - apply this fix and all remaining fixes for this rule
+d - skip this fix and all remaining fixes for this rule
+q - quit; skip this fix and all remaining ones
+? - print help
+`
+
+// accept shows the finding and its proposed edit and reports whether the
+// user accepts it. It is a linter.Config.FixFilter.
+func (p *fixPrompter) accept(path string, content []byte, r rules.Result) bool {
+	if p.quit {
+		return false
+	}
+	if answer, ok := p.rules[r.Rule]; ok {
+		return answer
+	}
+
+	fixed, _ := linter.ApplyFixes(content, []rules.Result{r})
+	fmt.Fprintf(p.out, "%s:%d:%d: %s (%s)\n", path, r.Line, r.Col, r.Message, r.Rule)
+	// the diff header repeats the path
+	diff := string(linter.UnifiedDiff(path, content, fixed))
+	if _, hunk, ok := strings.Cut(diff, "\n@@"); ok {
+		diff = "@@" + hunk
+	}
+	fmt.Fprint(p.out, diff)
+
+	for {
+		fmt.Fprint(p.out, "Apply this fix [y,n,a,d,q,?]? ")
+		line, err := p.in.ReadString('\n')
+		if err != nil && line == "" {
+			// input closed: keep the fixes already accepted
+			fmt.Fprintln(p.out)
+			p.quit = true
+			return false
+		}
+		switch strings.TrimSpace(line) {
+		case "y":
+			return true
+		case "n":
+			return false
+		case "a":
+			p.rules[r.Rule] = true
+			return true
+		case "d":
+			p.rules[r.Rule] = false
+			return false
+		case "q":
+			p.quit = true
+			return false
+		default:
+			fmt.Fprint(p.out, fixPromptHelp)
+		}
+	}
+}
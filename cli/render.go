@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/toba/go-html-validate/config"
+	"github.com/toba/go-html-validate/linter"
+	"github.com/toba/go-html-validate/reporter"
+	"github.com/toba/go-html-validate/rules"
+)
+
+// runRender implements "htmlint render": it executes a Go template with
+// test data and lints the output, catching what the placeholders of the
+// static preprocessing hide, such as duplicate IDs built from data.
+func runRender(args []string, opts Options) int {
+	var (
+		dataPath     string
+		outputPath   string
+		format       string
+		quiet        bool
+		noColor      bool
+		disableFlags stringSlice
+		configPath   string
+		noConfig     bool
+	)
+	flags := flag.NewFlagSet("htmlint render", flag.ContinueOnError)
+	flags.StringVar(&dataPath, "data", "", "JSON or YAML file with the template data")
+	flags.StringVar(&outputPath, "output", "", "Also write the rendered HTML to this file")
+	flags.StringVar(&format, "format", "text", "Output format: text, json")
+	flags.StringVar(&format, "f", "text", "Output format (shorthand)")
+	flags.BoolVar(&quiet, "quiet", false, "Only show errors")
+	flags.BoolVar(&quiet, "q", false, "Only show errors (shorthand)")
+	flags.BoolVar(&noColor, "no-color", false, "Disable colored output")
+	flags.Var(&disableFlags, "disable", "Rule to disable")
+	flags.StringVar(&configPath, "config", "", "Path to config file")
+	flags.BoolVar(&noConfig, "no-config", false, "Disable config file loading")
+	flags.Usage = func() {
+		fmt.Fprint(os.Stderr, `htmlint render - lint a Go template as rendered with test data
+
+Usage:
+  htmlint render [--data FILE] [--output FILE] [options] <template> [templates...]
+
+Executes <template> with html/template on the data in FILE (JSON, or YAML
+for .yaml and .yml files) and lints the output instead of the source. The
+other templates are parsed alongside for {{template}} calls. Functions
+the templates call are stubbed to return their last argument. Findings
+report lines of the rendered output; --output saves it for reference.
+-f, -q, --no-color, --disable, --config, and --no-config work as they do
+for htmlint.
+`)
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	files := flags.Args()
+	if len(files) == 0 {
+		fmt.Fprintln(os.Stderr, "error: no template specified")
+		fmt.Fprintln(os.Stderr, "usage: htmlint render [--data FILE] [options] <template> [templates...]")
+		return 2
+	}
+
+	var fileCfg *config.FileConfig
+	var loadedConfigPath string
+	if !noConfig {
+		var err error
+		if fileCfg, loadedConfigPath, err = loadConfig(configPath, filepath.Dir(files[0])); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			return 1
+		}
+	}
+	cfg := config.ToLinterConfig(fileCfg, loadedConfigPath)
+	cfg.DisabledRules = append(cfg.DisabledRules, disableFlags...)
+	if quiet {
+		cfg.ErrorsOnly()
+	}
+	cfg.Packs = append(cfg.Packs, opts.Packs...)
+
+	var data any
+	if dataPath != "" {
+		var err error
+		if data, err = linter.ReadTemplateData(dataPath); err != nil {
+			fmt.Fprintf(os.Stderr, "error: --data: %v\n", err)
+			return 1
+		}
+	}
+	rendered, err := linter.RenderTemplate(files, data, cfg.TemplateFuncs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: rendering %s: %v\n", files[0], err)
+		return 1
+	}
+	if outputPath != "" {
+		if err := os.WriteFile(outputPath, rendered, 0o644); err != nil { //nolint:gosec // rendered HTML is not secret
+			fmt.Fprintf(os.Stderr, "error: --output: %v\n", err)
+			return 1
+		}
+	}
+
+	results, err := linter.New(cfg).LintContent(files[0], rendered)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return 1
+	}
+	// fixes would edit the output, not the template
+	for i := range results {
+		results[i].Fix = nil
+	}
+
+	var rep linter.Reporter
+	if format == "json" {
+		rep = reporter.NewJSON()
+	} else {
+		textRep := reporter.NewText()
+		textRep.NoColor = noColor
+		rep = textRep
+	}
+	if err := rep.Report(results); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return 1
+	}
+	for _, r := range results {
+		if r.Severity == rules.Error {
+			return 1
+		}
+	}
+	return 0
+}
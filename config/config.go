@@ -2,14 +2,20 @@
 package config
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/toba/go-html-validate/linter"
+	"github.com/toba/go-html-validate/parser"
 	"github.com/toba/go-html-validate/rules"
 )
 
@@ -26,8 +32,170 @@ type FrameworkConfig struct {
 	// HTMXCustomEvents lists custom event names that should not trigger
 	// "unknown event" warnings in hx-on:* validation (e.g., SSE-pushed events).
 	HTMXCustomEvents []string `json:"htmx-custom-events"`
+	// Allowed lists other frontend frameworks the project uses ("angular",
+	// "vue", "alpine", "turbo", or "htmx"); framework-remnants flags the
+	// attributes of the rest.
+	Allowed []string `json:"allowed"`
 }
 
+// GeneratedConfig configures detection of generated files, which are skipped.
+type GeneratedConfig struct {
+	// Markers are substrings that identify a generated file
+	// (default: "Code generated", "DO NOT EDIT").
+	Markers []string `json:"markers"`
+	// Lines is how many leading lines are searched for a marker (default: 5).
+	Lines int `json:"lines"`
+}
+
+// ProfileConfig is a named set of rule overrides that files select with
+// <!-- htmlint-config: profile=name -->.
+type ProfileConfig struct {
+	// Rules configures rule severity within the profile.
+	Rules map[string]RuleConfig `json:"rules"`
+}
+
+// NewFilesConfig holds stricter settings for files new to the project,
+// those added after Since or missing from the Baseline file.
+type NewFilesConfig struct {
+	// Since is a date ("2006-01-02") or RFC 3339 time; files first
+	// committed after it, or uncommitted files modified after it, are new.
+	// It is ignored in shallow git clones, where only Baseline applies.
+	Since string `json:"since"`
+	// Baseline is a file listing the legacy files, one path per line
+	// relative to the baseline file, with blank lines and # comments
+	// ignored; every other file is new. It is resolved relative to the
+	// config file.
+	Baseline string `json:"baseline"`
+	// Escalate raises info findings in new files to warnings and warnings
+	// to errors, except for rules configured in Rules.
+	Escalate bool `json:"escalate"`
+	// Rules configures rule severity in new files.
+	Rules map[string]RuleConfig `json:"rules"`
+
+	legacy []string // absolute paths read from Baseline by LoadFile
+}
+
+// CustomRuleConfig declares a rule in configuration: elements matching
+// Selector are reported, or only those missing a Require attribute or
+// carrying a Forbid attribute when either is set.
+type CustomRuleConfig struct {
+	Selector string   `json:"selector"`
+	Require  []string `json:"require"`
+	Forbid   []string `json:"forbid"`
+	Message  string   `json:"message"`
+	// Severity is "error" (default), "warn", or "info".
+	Severity string `json:"severity"`
+}
+
+// PageTypeConfig declares a page type: pages matching Files must contain
+// elements matching each Require selector, exactly one element matching
+// each ExactlyOne selector, and none matching a Forbid selector. Files get
+// the first page type that matches them, so a type without requirements
+// exempts its files.
+type PageTypeConfig struct {
+	Name       string   `json:"name"`
+	Files      []string `json:"files"`
+	Require    []string `json:"require"`
+	ExactlyOne []string `json:"exactly-one"`
+	Forbid     []string `json:"forbid"`
+}
+
+// PageType converts c to the rules representation.
+func (c PageTypeConfig) PageType() rules.PageType {
+	return rules.PageType{
+		Name:       c.Name,
+		Files:      c.Files,
+		Require:    c.Require,
+		ExactlyOne: c.ExactlyOne,
+		Forbid:     c.Forbid,
+	}
+}
+
+// BanConfig bans an element, an attribute, or an attribute value for the
+// banned-markup rule: Element alone bans the tag, Attribute bans the
+// attribute (on Element only, when set), and Value bans only that value of
+// Attribute. Files matching Exclude are exempt.
+type BanConfig struct {
+	Element   string   `json:"element"`
+	Attribute string   `json:"attribute"`
+	Value     string   `json:"value"`
+	Message   string   `json:"message"`
+	Exclude   []string `json:"exclude"`
+}
+
+// Ban converts c to the rules representation.
+func (c BanConfig) Ban() rules.Ban {
+	return rules.Ban{
+		Element:   c.Element,
+		Attribute: c.Attribute,
+		Value:     c.Value,
+		Message:   c.Message,
+		Exclude:   c.Exclude,
+	}
+}
+
+// RequirementConfig requires attributes on elements matching Selector for
+// the required-attributes rule. Attributes are conditions in CSS attribute
+// selector syntax without brackets, such as "rel~=noopener". Files
+// matching Exclude are exempt.
+type RequirementConfig struct {
+	Selector   string   `json:"selector"`
+	Attributes []string `json:"attributes"`
+	Message    string   `json:"message"`
+	Exclude    []string `json:"exclude"`
+}
+
+// Requirement converts c to the rules representation.
+func (c RequirementConfig) Requirement() rules.Requirement {
+	return rules.Requirement{
+		Selector:   c.Selector,
+		Attributes: c.Attributes,
+		Message:    c.Message,
+		Exclude:    c.Exclude,
+	}
+}
+
+// ComponentConfig names a component template the prefer-component rule
+// suggests for markup matching Selector. Files matching Exclude are exempt.
+type ComponentConfig struct {
+	Selector string   `json:"selector"`
+	Template string   `json:"template"`
+	Message  string   `json:"message"`
+	Exclude  []string `json:"exclude"`
+}
+
+// Component converts c to the rules representation.
+func (c ComponentConfig) Component() rules.Component {
+	return rules.Component{
+		Selector: c.Selector,
+		Template: c.Template,
+		Message:  c.Message,
+		Exclude:  c.Exclude,
+	}
+}
+
+// TemplateFuncConfig declares a template function: its number of
+// arguments (the minimum when Variadic) and the kind of value it returns,
+// "string" (the default), "html", "url", or "attr".
+type TemplateFuncConfig struct {
+	Args     int    `json:"args"`
+	Variadic bool   `json:"variadic"`
+	Returns  string `json:"returns"`
+}
+
+// TemplateFunc converts f to the rules representation.
+func (f TemplateFuncConfig) TemplateFunc() rules.TemplateFunc {
+	return rules.TemplateFunc{
+		Args:     f.Args,
+		Variadic: f.Variadic,
+		Returns:  f.Returns,
+	}
+}
+
+// CustomRulesPack is the pack holding rules from "custom-rules"; their
+// names are prefixed with "custom/".
+const CustomRulesPack = "custom"
+
 // FileConfig represents the JSON structure of .htmlvalidate.json.
 type FileConfig struct {
 	// Schema is the JSON schema URL (ignored, but allowed for IDE support).
@@ -40,6 +208,51 @@ type FileConfig struct {
 	Rules map[string]RuleConfig `json:"rules"`
 	// Frameworks configures framework-specific attribute handling.
 	Frameworks FrameworkConfig `json:"frameworks"`
+	// Documents lists glob patterns for files always parsed as full documents.
+	Documents []string `json:"documents"`
+	// Fragments lists glob patterns for files always parsed as fragments.
+	Fragments []string `json:"fragments"`
+	// Partials lists glob patterns for htmx partial responses, which are
+	// parsed as fragments and checked with partial rules instead of
+	// full-document rules.
+	Partials []string `json:"partials"`
+	// Generated configures detection of generated files.
+	Generated GeneratedConfig `json:"generated"`
+	// Profiles defines named rule overrides selectable per file.
+	Profiles map[string]ProfileConfig `json:"profiles"`
+	// NewFiles holds stricter rule severities for files new to the
+	// project.
+	NewFiles NewFilesConfig `json:"new-files"`
+	// TemplateBranches lints each {{if}}/{{else}} branch separately; nil
+	// keeps the default, on.
+	TemplateBranches *bool `json:"template-branches"`
+	// TemplateDialect names the template syntax of the linted files, "go"
+	// (the default) or "jet".
+	TemplateDialect string `json:"template-dialect"`
+	// ConditionalComments is "skip" (the default) to lint pages as modern
+	// browsers render IE conditional comments, or "branch" to also lint
+	// the markup legacy IE renders in them.
+	ConditionalComments string `json:"conditional-comments"`
+	// TemplateFunctions declares the functions of the project's Go
+	// template FuncMap, keyed by name.
+	TemplateFunctions map[string]TemplateFuncConfig `json:"template-functions"`
+	// CustomRules declares selector-based rules, keyed by name.
+	CustomRules map[string]CustomRuleConfig `json:"custom-rules"`
+	// PageTypes declares structural requirements per page type, checked by
+	// the page-structure rule.
+	PageTypes []PageTypeConfig `json:"page-types"`
+	// Banned lists elements, attributes, and attribute values reported by
+	// the banned-markup rule.
+	Banned []BanConfig `json:"banned"`
+	// Required lists attributes that elements matching a selector must
+	// carry, reported by the required-attributes rule.
+	Required []RequirementConfig `json:"required"`
+	// Components lists design-system templates suggested by the
+	// prefer-component rule in place of the markup they render.
+	Components []ComponentConfig `json:"components"`
+	// Workspace lists project directories (or globs) of a monorepo, each
+	// linted with its own config; see WorkspaceProjects.
+	Workspace []string `json:"workspace"`
 }
 
 // StringOrStrings handles JSON that can be either a string or array of strings.
@@ -62,10 +275,15 @@ func (s *StringOrStrings) UnmarshalJSON(data []byte) error {
 }
 
 // RuleConfig holds configuration for a single rule.
-// Supports both simple ("error") and array (["error", {}]) formats.
+// Supports simple ("error"), array (["error", {}]), and object
+// ({"severity": "error", "exclude": ["emails/**"]}) formats.
 type RuleConfig struct {
 	Severity string
 	Options  map[string]any
+	// Include limits the rule to files matching these globs.
+	Include []string
+	// Exclude suppresses the rule for files matching these globs.
+	Exclude []string
 }
 
 func (r *RuleConfig) UnmarshalJSON(data []byte) error {
@@ -79,23 +297,47 @@ func (r *RuleConfig) UnmarshalJSON(data []byte) error {
 	// Try as number: 0, 1, 2
 	var num int
 	if err := json.Unmarshal(data, &num); err == nil {
-		switch num {
-		case 0:
-			r.Severity = "off"
-		case 1:
-			r.Severity = "warn"
-		case 2:
-			r.Severity = "error"
-		default:
-			return fmt.Errorf("invalid severity number: %d (must be 0, 1, or 2)", num)
+		sev, err := severityFromNumber(num)
+		if err != nil {
+			return err
 		}
+		r.Severity = sev
+		return nil
+	}
+
+	// Try as object: {"severity": ..., "options": {...}, "include": [...], "exclude": [...]}
+	var obj struct {
+		Severity json.RawMessage `json:"severity"`
+		Options  map[string]any  `json:"options"`
+		Include  []string        `json:"include"`
+		Exclude  []string        `json:"exclude"`
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&obj); err == nil {
+		if len(obj.Severity) > 0 {
+			if err := json.Unmarshal(obj.Severity, &str); err == nil {
+				r.Severity = str
+			} else if err := json.Unmarshal(obj.Severity, &num); err == nil {
+				sev, err := severityFromNumber(num)
+				if err != nil {
+					return err
+				}
+				r.Severity = sev
+			} else {
+				return errors.New("rule config severity must be a string or number")
+			}
+		}
+		r.Options = obj.Options
+		r.Include = obj.Include
+		r.Exclude = obj.Exclude
 		return nil
 	}
 
 	// Try as array: ["error", {...}]
 	var arr []json.RawMessage
 	if err := json.Unmarshal(data, &arr); err != nil {
-		return fmt.Errorf("rule config must be string, number, or array")
+		return fmt.Errorf("rule config must be string, number, object, or array")
 	}
 	if len(arr) == 0 {
 		return errors.New("rule config array cannot be empty")
@@ -105,16 +347,11 @@ func (r *RuleConfig) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(arr[0], &str); err == nil {
 		r.Severity = str
 	} else if err := json.Unmarshal(arr[0], &num); err == nil {
-		switch num {
-		case 0:
-			r.Severity = "off"
-		case 1:
-			r.Severity = "warn"
-		case 2:
-			r.Severity = "error"
-		default:
-			return fmt.Errorf("invalid severity number: %d", num)
+		sev, err := severityFromNumber(num)
+		if err != nil {
+			return err
 		}
+		r.Severity = sev
 	} else {
 		return errors.New("first element of rule config must be severity")
 	}
@@ -130,6 +367,20 @@ func (r *RuleConfig) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// severityFromNumber converts a numeric severity (0, 1, 2) to its name.
+func severityFromNumber(num int) (string, error) {
+	switch num {
+	case 0:
+		return "off", nil
+	case 1:
+		return "warn", nil
+	case 2:
+		return "error", nil
+	default:
+		return "", fmt.Errorf("invalid severity number: %d (must be 0, 1, or 2)", num)
+	}
+}
+
 // Load searches for and loads .htmlvalidate.json from dir upward.
 // Returns nil config if no config file is found.
 func Load(dir string) (*FileConfig, string, error) {
@@ -155,10 +406,99 @@ func LoadFile(path string) (*FileConfig, error) {
 	if err := json.Unmarshal(data, &cfg); err != nil {
 		return nil, fmt.Errorf("parsing %s: %w", path, err)
 	}
+	for name, rc := range cfg.CustomRules {
+		if _, err := rc.Rule(name); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	for _, pt := range cfg.PageTypes {
+		if pt.Name == "" || len(pt.Files) == 0 {
+			return nil, fmt.Errorf("%s: page types need a name and files", path)
+		}
+		if err := pt.PageType().Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	for _, b := range cfg.Banned {
+		if err := b.Ban().Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	for _, q := range cfg.Required {
+		if err := q.Requirement().Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	for _, c := range cfg.Components {
+		if err := c.Component().Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	if _, err := parser.LookupDialect(cfg.TemplateDialect); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if _, err := linter.ParseConditionalMode(cfg.ConditionalComments); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for name, f := range cfg.TemplateFunctions {
+		if err := f.TemplateFunc().Validate(); err != nil {
+			return nil, fmt.Errorf("%s: template function %q: %w", path, name, err)
+		}
+	}
+	if _, err := parseSince(cfg.NewFiles.Since); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if cfg.NewFiles.Baseline != "" {
+		if !filepath.IsAbs(cfg.NewFiles.Baseline) {
+			cfg.NewFiles.Baseline = filepath.Join(filepath.Dir(path), cfg.NewFiles.Baseline)
+		}
+		legacy, err := readBaseline(cfg.NewFiles.Baseline)
+		if err != nil {
+			return nil, fmt.Errorf("%s: new-files baseline: %w", path, err)
+		}
+		cfg.NewFiles.legacy = legacy
+	}
 
 	return &cfg, nil
 }
 
+// parseSince parses new-files.since, a date or an RFC 3339 time. An empty
+// value is the zero time.
+func parseSince(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("new-files since %q must be a date (2006-01-02) or RFC 3339 time", s)
+	}
+	return t, nil
+}
+
+// readBaseline reads a new-files baseline, returning its entries as
+// absolute paths. The result is non-nil even for an empty baseline.
+func readBaseline(path string) ([]string, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // baseline path comes from the config file
+	if err != nil {
+		return nil, err
+	}
+	legacy := []string{}
+	for line := range strings.Lines(string(data)) {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !filepath.IsAbs(line) {
+			line = filepath.Join(filepath.Dir(path), filepath.FromSlash(line))
+		}
+		legacy = append(legacy, line)
+	}
+	return legacy, nil
+}
+
 // FindConfigFile searches for .htmlvalidate.json from dir upward.
 // Returns empty string if no config file is found.
 func FindConfigFile(dir string) (string, error) {
@@ -192,7 +532,7 @@ func Resolve(dir string) (*FileConfig, string, error) {
 		return nil, "", nil
 	}
 
-	resolved, err := resolveExtends(cfg, filepath.Dir(path))
+	resolved, err := ResolveExtends(cfg, filepath.Dir(path))
 	if err != nil {
 		return nil, path, err
 	}
@@ -200,8 +540,9 @@ func Resolve(dir string) (*FileConfig, string, error) {
 	return resolved, path, nil
 }
 
-// resolveExtends merges extended configs into the base config.
-func resolveExtends(cfg *FileConfig, baseDir string) (*FileConfig, error) {
+// ResolveExtends merges the configs cfg extends, presets or files relative
+// to baseDir, under cfg, as Resolve does for a discovered config.
+func ResolveExtends(cfg *FileConfig, baseDir string) (*FileConfig, error) {
 	if len(cfg.Extends) == 0 {
 		return cfg, nil
 	}
@@ -228,7 +569,7 @@ func resolveExtends(cfg *FileConfig, baseDir string) (*FileConfig, error) {
 				return nil, fmt.Errorf("loading extended config %q: %w", ext, err)
 			}
 			// Recursively resolve extends
-			extCfg, err = resolveExtends(extCfg, filepath.Dir(extPath))
+			extCfg, err = ResolveExtends(extCfg, filepath.Dir(extPath))
 			if err != nil {
 				return nil, err
 			}
@@ -275,6 +616,101 @@ func merge(base, overlay *FileConfig) *FileConfig {
 	if len(overlay.Frameworks.HTMXCustomEvents) > 0 {
 		result.Frameworks.HTMXCustomEvents = overlay.Frameworks.HTMXCustomEvents
 	}
+	if len(overlay.Frameworks.Allowed) > 0 {
+		result.Frameworks.Allowed = overlay.Frameworks.Allowed
+	}
+
+	// Parse mode globs (overlay replaces base when set)
+	result.Documents = base.Documents
+	if len(overlay.Documents) > 0 {
+		result.Documents = overlay.Documents
+	}
+	result.Fragments = base.Fragments
+	if len(overlay.Fragments) > 0 {
+		result.Fragments = overlay.Fragments
+	}
+	result.Partials = base.Partials
+	if len(overlay.Partials) > 0 {
+		result.Partials = overlay.Partials
+	}
+
+	// Merge generated-file detection (overlay takes precedence)
+	result.Generated = base.Generated
+	if len(overlay.Generated.Markers) > 0 {
+		result.Generated.Markers = overlay.Generated.Markers
+	}
+	if overlay.Generated.Lines > 0 {
+		result.Generated.Lines = overlay.Generated.Lines
+	}
+
+	result.TemplateBranches = base.TemplateBranches
+	if overlay.TemplateBranches != nil {
+		result.TemplateBranches = overlay.TemplateBranches
+	}
+	result.TemplateDialect = base.TemplateDialect
+	if overlay.TemplateDialect != "" {
+		result.TemplateDialect = overlay.TemplateDialect
+	}
+
+	result.ConditionalComments = base.ConditionalComments
+	if overlay.ConditionalComments != "" {
+		result.ConditionalComments = overlay.ConditionalComments
+	}
+
+	result.Workspace = base.Workspace
+	if len(overlay.Workspace) > 0 {
+		result.Workspace = overlay.Workspace
+	}
+
+	result.PageTypes = base.PageTypes
+	if len(overlay.PageTypes) > 0 {
+		result.PageTypes = overlay.PageTypes
+	}
+
+	// Bans, requirements, and components accumulate, so a project can add
+	// to an organization's lists
+	result.Banned = append(slices.Clone(base.Banned), overlay.Banned...)
+	result.Required = append(slices.Clone(base.Required), overlay.Required...)
+	result.Components = append(slices.Clone(base.Components), overlay.Components...)
+
+	// Merge template functions (overlay replaces functions with the same name)
+	if len(base.TemplateFunctions) > 0 || len(overlay.TemplateFunctions) > 0 {
+		result.TemplateFunctions = make(map[string]TemplateFuncConfig)
+		maps.Copy(result.TemplateFunctions, base.TemplateFunctions)
+		maps.Copy(result.TemplateFunctions, overlay.TemplateFunctions)
+	}
+
+	// Merge custom rules (overlay replaces rules with the same name)
+	if len(base.CustomRules) > 0 || len(overlay.CustomRules) > 0 {
+		result.CustomRules = make(map[string]CustomRuleConfig)
+		maps.Copy(result.CustomRules, base.CustomRules)
+		maps.Copy(result.CustomRules, overlay.CustomRules)
+	}
+
+	// Merge new-file settings (overlay takes precedence, rule by rule)
+	result.NewFiles = base.NewFiles
+	if overlay.NewFiles.Since != "" {
+		result.NewFiles.Since = overlay.NewFiles.Since
+	}
+	if overlay.NewFiles.Baseline != "" {
+		result.NewFiles.Baseline = overlay.NewFiles.Baseline
+		result.NewFiles.legacy = overlay.NewFiles.legacy
+	}
+	if overlay.NewFiles.Escalate {
+		result.NewFiles.Escalate = true
+	}
+	if len(base.NewFiles.Rules) > 0 || len(overlay.NewFiles.Rules) > 0 {
+		result.NewFiles.Rules = make(map[string]RuleConfig)
+		maps.Copy(result.NewFiles.Rules, base.NewFiles.Rules)
+		maps.Copy(result.NewFiles.Rules, overlay.NewFiles.Rules)
+	}
+
+	// Merge profiles (overlay replaces profiles with the same name)
+	if len(base.Profiles) > 0 || len(overlay.Profiles) > 0 {
+		result.Profiles = make(map[string]ProfileConfig)
+		maps.Copy(result.Profiles, base.Profiles)
+		maps.Copy(result.Profiles, overlay.Profiles)
+	}
 
 	return result
 }
@@ -288,14 +724,22 @@ func ToLinterConfig(fc *FileConfig, configPath string) *linter.Config {
 		return cfg
 	}
 
+	cfg.DisabledRules, cfg.RuleSeverity = ruleOverrides(fc.Rules)
 	for name, ruleCfg := range fc.Rules {
-		switch ruleCfg.Severity {
-		case "off", "0":
-			cfg.DisabledRules = append(cfg.DisabledRules, name)
-		case "error", "2":
-			cfg.RuleSeverity[name] = rules.Error
-		case "warn", "warning", "1":
-			cfg.RuleSeverity[name] = rules.Warning
+		if len(ruleCfg.Options) > 0 {
+			if cfg.RuleOptions == nil {
+				cfg.RuleOptions = make(map[string]map[string]any)
+			}
+			cfg.RuleOptions[name] = ruleCfg.Options
+		}
+		if len(ruleCfg.Include) > 0 || len(ruleCfg.Exclude) > 0 {
+			if cfg.RuleScopes == nil {
+				cfg.RuleScopes = make(map[string]linter.RuleScope)
+			}
+			cfg.RuleScopes[name] = linter.RuleScope{
+				Include: ruleCfg.Include,
+				Exclude: ruleCfg.Exclude,
+			}
 		}
 	}
 
@@ -304,11 +748,121 @@ func ToLinterConfig(fc *FileConfig, configPath string) *linter.Config {
 		HTMX:             fc.Frameworks.HTMX,
 		HTMXVersion:      fc.Frameworks.HTMXVersion,
 		HTMXCustomEvents: fc.Frameworks.HTMXCustomEvents,
+		Allowed:          fc.Frameworks.Allowed,
+	}
+
+	cfg.DocumentPatterns = fc.Documents
+	cfg.FragmentPatterns = fc.Fragments
+	cfg.PartialPatterns = fc.Partials
+	cfg.Generated.Markers = fc.Generated.Markers
+	cfg.Generated.Lines = fc.Generated.Lines
+	if fc.TemplateBranches != nil {
+		cfg.TemplateBranches = *fc.TemplateBranches
+	}
+	cfg.TemplateDialect, _ = parser.LookupDialect(fc.TemplateDialect) // validated by LoadFile
+	cfg.ConditionalComments, _ = linter.ParseConditionalMode(fc.ConditionalComments)
+
+	for _, pt := range fc.PageTypes {
+		cfg.PageTypes = append(cfg.PageTypes, pt.PageType())
+	}
+	for _, b := range fc.Banned {
+		cfg.Bans = append(cfg.Bans, b.Ban())
+	}
+	for _, q := range fc.Required {
+		cfg.Requirements = append(cfg.Requirements, q.Requirement())
+	}
+	for _, c := range fc.Components {
+		cfg.Components = append(cfg.Components, c.Component())
+	}
+	if len(fc.TemplateFunctions) > 0 {
+		cfg.TemplateFuncs = make(map[string]rules.TemplateFunc, len(fc.TemplateFunctions))
+		for name, f := range fc.TemplateFunctions {
+			cfg.TemplateFuncs[name] = f.TemplateFunc()
+		}
+	}
+
+	if len(fc.CustomRules) > 0 {
+		cfg.Packs = append(cfg.Packs, customRulesPack(fc.CustomRules))
+	}
+
+	cfg.NewFiles.Since, _ = parseSince(fc.NewFiles.Since) // validated by LoadFile
+	cfg.NewFiles.Baseline = fc.NewFiles.legacy
+	cfg.NewFiles.Escalate = fc.NewFiles.Escalate
+	_, cfg.NewFiles.RuleSeverity = ruleOverrides(fc.NewFiles.Rules)
+
+	if len(fc.Profiles) > 0 {
+		cfg.Profiles = make(map[string]linter.Profile, len(fc.Profiles))
+		for name, profile := range fc.Profiles {
+			disabled, severities := ruleOverrides(profile.Rules)
+			cfg.Profiles[name] = linter.Profile{
+				DisabledRules: disabled,
+				RuleSeverity:  severities,
+			}
+		}
 	}
 
 	return cfg
 }
 
+// Rule builds the rule declared by c, named "custom/<name>".
+func (c CustomRuleConfig) Rule(name string) (*rules.SelectorRule, error) {
+	rule, err := rules.NewSelectorRule(CustomRulesPack+"/"+name, c.Selector)
+	if err != nil {
+		return nil, err
+	}
+	if len(c.Require) == 0 && len(c.Forbid) == 0 && c.Message == "" {
+		return nil, fmt.Errorf("custom rule %q: message is required when no attributes are asserted", name)
+	}
+	if c.Severity != "" {
+		sev, err := ParseSeverity(c.Severity)
+		if err != nil || c.Severity == "off" || c.Severity == "0" {
+			return nil, fmt.Errorf("custom rule %q: invalid severity %q", name, c.Severity)
+		}
+		rule.Severity = sev
+	}
+	rule.Require = c.Require
+	rule.Forbid = c.Forbid
+	rule.Message = c.Message
+	return rule, nil
+}
+
+// customRulesPack wraps custom rules in a pack, in name order. Invalid
+// rules were rejected by LoadFile.
+func customRulesPack(customRules map[string]CustomRuleConfig) rules.Pack {
+	return rules.Pack{
+		PackMetadata: rules.PackMetadata{
+			Name:        CustomRulesPack,
+			Description: "rules declared in custom-rules",
+		},
+		Rules: func() []rules.Rule {
+			var list []rules.Rule
+			for _, name := range slices.Sorted(maps.Keys(customRules)) {
+				if rule, err := customRules[name].Rule(name); err == nil {
+					list = append(list, rule)
+				}
+			}
+			return list
+		},
+	}
+}
+
+// ruleOverrides splits rule configs into disabled rules and severity overrides.
+func ruleOverrides(ruleCfgs map[string]RuleConfig) ([]string, map[string]rules.Severity) {
+	var disabled []string
+	severities := make(map[string]rules.Severity)
+	for name, ruleCfg := range ruleCfgs {
+		switch ruleCfg.Severity {
+		case "off", "0":
+			disabled = append(disabled, name)
+		case "error", "2":
+			severities[name] = rules.Error
+		case "warn", "warning", "1":
+			severities[name] = rules.Warning
+		}
+	}
+	return disabled, severities
+}
+
 // ParseSeverity converts a severity string to rules.Severity.
 func ParseSeverity(s string) (rules.Severity, error) {
 	switch s {
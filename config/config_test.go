@@ -1,12 +1,17 @@
 package config_test
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"slices"
+	"strings"
 	"testing"
 
 	"github.com/toba/go-html-validate/config"
+	"github.com/toba/go-html-validate/linter"
+	"github.com/toba/go-html-validate/parser"
 	"github.com/toba/go-html-validate/rules"
 )
 
@@ -114,6 +119,17 @@ func TestRuleConfigUnmarshal(t *testing.T) {
 			ruleName:     "img-alt",
 			wantSeverity: "warn",
 		},
+		{
+			name:         "object format",
+			content:      `{"rules": {"img-alt": {"severity": "error", "exclude": ["emails/**"]}}}`,
+			ruleName:     "img-alt",
+			wantSeverity: "error",
+		},
+		{
+			name:     "object without severity",
+			content:  `{"rules": {"require-lang": {"include": ["pages/**"]}}}`,
+			ruleName: "require-lang",
+		},
 	}
 
 	for _, tt := range tests {
@@ -233,6 +249,118 @@ func TestToLinterConfig_HTMXCustomEvents(t *testing.T) {
 	}
 }
 
+func TestToLinterConfig_ParseModePatterns(t *testing.T) {
+	fileCfg := &config.FileConfig{
+		Documents: []string{"layouts/*.gohtml"},
+		Fragments: []string{"partials/"},
+		Partials:  []string{"htmx/**"},
+	}
+
+	linterCfg := config.ToLinterConfig(fileCfg, "")
+
+	if !slices.Equal(linterCfg.DocumentPatterns, fileCfg.Documents) {
+		t.Errorf("DocumentPatterns = %v, want %v", linterCfg.DocumentPatterns, fileCfg.Documents)
+	}
+	if !slices.Equal(linterCfg.FragmentPatterns, fileCfg.Fragments) {
+		t.Errorf("FragmentPatterns = %v, want %v", linterCfg.FragmentPatterns, fileCfg.Fragments)
+	}
+	if !slices.Equal(linterCfg.PartialPatterns, fileCfg.Partials) {
+		t.Errorf("PartialPatterns = %v, want %v", linterCfg.PartialPatterns, fileCfg.Partials)
+	}
+}
+
+func TestToLinterConfig_RuleOptions(t *testing.T) {
+	fileCfg := &config.FileConfig{
+		Rules: map[string]config.RuleConfig{
+			rules.RuleDOMSize: {Severity: "warn", Options: map[string]any{"max-depth": float64(40)}},
+			rules.RuleImgAlt:  {Severity: "error"},
+		},
+	}
+
+	linterCfg := config.ToLinterConfig(fileCfg, "")
+
+	if got := linterCfg.RuleOptions[rules.RuleDOMSize]["max-depth"]; got != float64(40) {
+		t.Errorf("max-depth option = %v, want 40", got)
+	}
+	if _, ok := linterCfg.RuleOptions[rules.RuleImgAlt]; ok {
+		t.Errorf("expected no options for %s", rules.RuleImgAlt)
+	}
+	if !linterCfg.IsOptedIn(rules.RuleDOMSize) {
+		t.Errorf("expected %s to be opted in", rules.RuleDOMSize)
+	}
+}
+
+func TestToLinterConfig_RuleScopes(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, config.ConfigFileName)
+	content := `{"rules": {
+		"no-inline-style": {"exclude": ["emails/**"]},
+		"require-lang": {"severity": 2, "include": ["pages/**"]}
+	}}`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	fileCfg, err := config.LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	linterCfg := config.ToLinterConfig(fileCfg, path)
+
+	inline := linterCfg.RuleScopes[rules.RuleNoInlineStyle]
+	if inline.Matches("emails/welcome.html") || !inline.Matches("pages/index.html") {
+		t.Errorf("no-inline-style scope = %+v, want emails/** excluded", inline)
+	}
+	lang := linterCfg.RuleScopes[rules.RuleRequireLang]
+	if !lang.Matches("pages/index.html") || lang.Matches("partials/nav.html") {
+		t.Errorf("require-lang scope = %+v, want only pages/** included", lang)
+	}
+	if linterCfg.RuleSeverity[rules.RuleRequireLang] != rules.Error {
+		t.Errorf("require-lang severity = %v, want error", linterCfg.RuleSeverity[rules.RuleRequireLang])
+	}
+	if _, ok := linterCfg.RuleSeverity[rules.RuleNoInlineStyle]; ok {
+		t.Error("expected no severity override for no-inline-style")
+	}
+}
+
+func TestLoadFile_InvalidRuleObject(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, config.ConfigFileName)
+	if err := os.WriteFile(path, []byte(`{"rules": {"img-alt": {"sevrity": "off"}}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := config.LoadFile(path); err == nil {
+		t.Error("expected error for unknown rule config key")
+	}
+}
+
+func TestToLinterConfig_Profiles(t *testing.T) {
+	fileCfg := &config.FileConfig{
+		Profiles: map[string]config.ProfileConfig{
+			"email": {Rules: map[string]config.RuleConfig{
+				rules.RuleNoInlineStyle: {Severity: "off"},
+				rules.RuleImgAlt:        {Severity: "warn"},
+			}},
+		},
+	}
+
+	linterCfg := config.ToLinterConfig(fileCfg, "")
+
+	profile, ok := linterCfg.Profile("email")
+	if !ok {
+		t.Fatal("expected email profile")
+	}
+	if !slices.Contains(profile.DisabledRules, rules.RuleNoInlineStyle) {
+		t.Errorf("expected %s disabled in profile, got %v", rules.RuleNoInlineStyle, profile.DisabledRules)
+	}
+	if profile.RuleSeverity[rules.RuleImgAlt] != rules.Warning {
+		t.Errorf("expected %s severity warning, got %v", rules.RuleImgAlt, profile.RuleSeverity[rules.RuleImgAlt])
+	}
+	if _, ok := linterCfg.Profile("embed"); !ok {
+		t.Error("expected builtin embed profile to remain available")
+	}
+}
+
 func TestLoadFile_HTMXCustomEvents(t *testing.T) {
 	dir := t.TempDir()
 	content := `{
@@ -266,6 +394,77 @@ func TestLoadFile_HTMXCustomEvents(t *testing.T) {
 	}
 }
 
+func TestLoadFile_TemplateDialect(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    parser.Dialect
+		wantErr bool
+	}{
+		{name: "default", content: `{}`, want: parser.DialectGo},
+		{name: "jet", content: `{"template-dialect": "jet"}`, want: parser.DialectJet},
+		{name: "pongo2", content: `{"template-dialect": "pongo2"}`, want: parser.DialectPongo2},
+		{name: "handlebars", content: `{"template-dialect": "handlebars"}`, want: parser.DialectHandlebars},
+		{name: "unknown", content: `{"template-dialect": "razor"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), config.ConfigFileName)
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			fc, err := config.LoadFile(path)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error for unknown dialect")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := config.ToLinterConfig(fc, path).TemplateDialect; got != tt.want {
+				t.Errorf("TemplateDialect = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolve_TemplateBranches(t *testing.T) {
+	tests := []struct {
+		name        string
+		base, child string
+		want        bool
+	}{
+		{name: "default", base: `{}`, child: `{"extends": ["./base.json"]}`, want: true},
+		{name: "off", base: `{}`, child: `{"extends": ["./base.json"], "template-branches": false}`, want: false},
+		{name: "inherited off", base: `{"template-branches": false}`, child: `{"extends": ["./base.json"]}`, want: false},
+		{name: "back on", base: `{"template-branches": false}`, child: `{"extends": ["./base.json"], "template-branches": true}`, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "base.json"), []byte(tt.base), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, config.ConfigFileName), []byte(tt.child), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			fc, path, err := config.Resolve(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := config.ToLinterConfig(fc, path).TemplateBranches; got != tt.want {
+				t.Errorf("TemplateBranches = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestResolveWithPreset(t *testing.T) {
 	dir := t.TempDir()
 	content := `{
@@ -297,3 +496,632 @@ func TestResolveWithPreset(t *testing.T) {
 		t.Errorf("expected prefer-tbody severity to be off from preset, got %+v", cfg.Rules["prefer-tbody"])
 	}
 }
+
+func TestResolveWithStrictPreset(t *testing.T) {
+	dir := t.TempDir()
+	content := `{"extends": ["html-validate:a11y-strict"]}`
+	path := filepath.Join(dir, config.ConfigFileName)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	fc, _, err := config.Resolve(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rule := fc.Rules[rules.RuleImgAlt]; rule.Severity != "error" {
+		t.Errorf("img-alt severity = %q, want error from the a11y preset", rule.Severity)
+	}
+
+	l := linter.New(config.ToLinterConfig(fc, path))
+	results, err := l.LintContent("page.html", []byte(`<meta name="viewport" content="width=device-width, maximum-scale=1">`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, r := range results {
+		found = found || r.Rule == rules.RuleTextResize
+	}
+	if !found {
+		t.Errorf("opt-in text-resize not enabled by a11y-strict: %v", results)
+	}
+}
+
+func TestLoadFile_CustomRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{
+			name:    "valid rule",
+			content: `{"custom-rules": {"img-cdn": {"selector": "img:not([data-cdn])", "message": "images must use the CDN helper"}}}`,
+		},
+		{
+			name:    "missing selector",
+			content: `{"custom-rules": {"img-cdn": {"require": ["data-cdn"]}}}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid selector",
+			content: `{"custom-rules": {"img-cdn": {"selector": "img:hover", "require": ["data-cdn"]}}}`,
+			wantErr: true,
+		},
+		{
+			name:    "no assertion or message",
+			content: `{"custom-rules": {"img-cdn": {"selector": "img"}}}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid severity",
+			content: `{"custom-rules": {"img-cdn": {"selector": "img", "require": ["data-cdn"], "severity": "off"}}}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), config.ConfigFileName)
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			_, err := config.LoadFile(path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestToLinterConfig_CustomRules(t *testing.T) {
+	fileCfg := &config.FileConfig{
+		Rules: map[string]config.RuleConfig{
+			"custom/no-onclick": {Severity: "warn"},
+		},
+		CustomRules: map[string]config.CustomRuleConfig{
+			"img-cdn": {
+				Selector: "img:not([data-cdn])",
+				Message:  "images must use the CDN helper",
+			},
+			"no-onclick": {
+				Selector: "button",
+				Require:  []string{"type"},
+				Forbid:   []string{"onclick"},
+			},
+		},
+	}
+	html := `<div>
+<img src="a.png" alt="A" data-cdn>
+<img src="b.png" alt="B">
+<button type="button" onclick="go()">Go</button>
+</div>`
+
+	results, err := linter.New(config.ToLinterConfig(fileCfg, "")).LintContent("test.html", []byte(html))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []rules.Result
+	for _, r := range results {
+		if strings.HasPrefix(r.Rule, "custom/") {
+			got = append(got, r)
+		}
+	}
+	want := []struct {
+		rule     string
+		line     int
+		message  string
+		severity rules.Severity
+	}{
+		{"custom/img-cdn", 3, "images must use the CDN helper", rules.Error},
+		{"custom/no-onclick", 4, `<button> must not have attribute "onclick"`, rules.Warning},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d custom findings, want %d: %v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		if got[i].Rule != w.rule || got[i].Line != w.line || got[i].Message != w.message || got[i].Severity != w.severity {
+			t.Errorf("finding %d = %+v, want %+v", i, got[i], w)
+		}
+	}
+}
+
+func TestLoadFile_PageTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{
+			name:    "valid page types",
+			content: `{"page-types": [{"name": "email", "files": ["emails/**"]}, {"name": "page", "files": ["pages/**"], "require": ["main", "nav"], "exactly-one": ["h1"], "forbid": ["marquee"]}]}`,
+		},
+		{
+			name:    "missing files",
+			content: `{"page-types": [{"name": "page", "require": ["main"]}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid selector",
+			content: `{"page-types": [{"name": "page", "files": ["pages/**"], "require": ["main:hover"]}]}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), config.ConfigFileName)
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			_, err := config.LoadFile(path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestToLinterConfig_PageTypes(t *testing.T) {
+	dir := t.TempDir()
+	fileCfg := &config.FileConfig{
+		PageTypes: []config.PageTypeConfig{
+			{Name: "email", Files: []string{"pages/emails/**"}},
+			{Name: "page", Files: []string{"pages/**"}, Require: []string{"main", "nav"}, ExactlyOne: []string{"h1"}, Forbid: []string{"marquee"}},
+		},
+	}
+	files := map[string]string{
+		"pages/ok.html":             `<nav><a href="/">Home</a></nav><main><h1>Title</h1></main>`,
+		"pages/bad.html":            "<main><h1>One</h1>\n<h1>Two</h1><marquee>Hi</marquee></main>",
+		"pages/emails/welcome.html": `<p>Welcome</p>`,
+		"other/free.html":           `<p>Anything</p>`,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	l := linter.New(config.ToLinterConfig(fileCfg, filepath.Join(dir, config.ConfigFileName)))
+	for name := range files {
+		results, err := l.LintFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, r := range results {
+			if r.Rule == rules.RulePageStructure {
+				got = append(got, fmt.Sprintf("%d:%s", r.Line, r.Message))
+			}
+		}
+		var want []string
+		if name == "pages/bad.html" {
+			want = []string{
+				`1:page page: missing required "nav"`,
+				`2:page page: "h1" must appear exactly once, found 2`,
+				`2:page page: "marquee" is not allowed`,
+			}
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("%s: page-structure findings = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestLoadFile_Banned(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{
+			name:    "valid bans",
+			content: `{"banned": [{"element": "b", "message": "use <strong>"}, {"attribute": "style", "exclude": ["emails/**"]}, {"element": "a", "attribute": "target", "value": "_blank"}]}`,
+		},
+		{
+			name:    "nothing banned",
+			content: `{"banned": [{"message": "no"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "value without attribute",
+			content: `{"banned": [{"element": "a", "value": "_blank"}]}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), config.ConfigFileName)
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			_, err := config.LoadFile(path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestToLinterConfig_Banned(t *testing.T) {
+	dir := t.TempDir()
+	fileCfg := &config.FileConfig{
+		Banned: []config.BanConfig{
+			{Element: "b", Message: "use <strong>"},
+			{Attribute: "style", Exclude: []string{"emails/**"}},
+			{Attribute: "target", Value: "_blank", Message: "open links in the same tab"},
+		},
+	}
+	files := map[string]string{
+		"pages/bad.html":      "<p><b>Bold</b></p>\n<p style=\"color: red\"><a href=\"/\" target=\"_BLANK\">Home</a> <a href=\"/\" target=\"_self\">Up</a></p>",
+		"emails/welcome.html": `<p style="color: red">Welcome</p>`,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	l := linter.New(config.ToLinterConfig(fileCfg, filepath.Join(dir, config.ConfigFileName)))
+	for name := range files {
+		results, err := l.LintFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, r := range results {
+			if r.Rule == rules.RuleBannedMarkup {
+				got = append(got, fmt.Sprintf("%d:%d:%s", r.Line, r.Col, r.Message))
+			}
+		}
+		var want []string
+		if name == "pages/bad.html" {
+			want = []string{
+				"1:4:<b> is banned: use <strong>",
+				"2:4:style attribute on <p> is banned",
+				`2:35:target="_BLANK" on <a> is banned: open links in the same tab`,
+			}
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("%s: banned-markup findings = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestLoadFile_Required(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{
+			name:    "valid requirements",
+			content: `{"required": [{"selector": "img", "attributes": ["data-src-set-by"]}, {"selector": "a[href^=http]", "attributes": ["rel~=noopener"], "exclude": ["emails/**"]}]}`,
+		},
+		{
+			name:    "missing attributes",
+			content: `{"required": [{"selector": "img"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid selector",
+			content: `{"required": [{"selector": "a:hover", "attributes": ["rel"]}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid attribute condition",
+			content: `{"required": [{"selector": "a", "attributes": ["rel~"]}]}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), config.ConfigFileName)
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			_, err := config.LoadFile(path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestToLinterConfig_Required(t *testing.T) {
+	dir := t.TempDir()
+	fileCfg := &config.FileConfig{
+		Required: []config.RequirementConfig{
+			{Selector: "img", Attributes: []string{"data-src-set-by"}, Exclude: []string{"emails/**"}},
+			{Selector: "a[href^=http]", Attributes: []string{"rel~=noopener"}, Message: "external links must not expose window.opener"},
+			{Selector: "[hx-boost] form", Attributes: []string{"novalidate"}},
+		},
+	}
+	files := map[string]string{
+		"pages/bad.html": "<img src=\"a.png\" alt=\"\">\n" +
+			"<a href=\"https://example.com\" rel=\"external\">x</a> <a href=\"https://example.com\" rel=\"noopener external\">y</a> <a href=\"/\">z</a>\n" +
+			"<div hx-boost=\"true\"><form action=\"/s\"></form></div><form action=\"/t\"></form>",
+		"emails/welcome.html": `<img src="a.png" alt="">`,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	l := linter.New(config.ToLinterConfig(fileCfg, filepath.Join(dir, config.ConfigFileName)))
+	for name := range files {
+		results, err := l.LintFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, r := range results {
+			if r.Rule == rules.RuleRequiredAttributes {
+				got = append(got, fmt.Sprintf("%d:%d:%s", r.Line, r.Col, r.Message))
+			}
+		}
+		var want []string
+		if name == "pages/bad.html" {
+			want = []string{
+				`1:1:<img> matching "img" requires data-src-set-by`,
+				`2:31:<a> matching "a[href^=http]" requires rel~=noopener: external links must not expose window.opener`,
+				`3:22:<form> matching "[hx-boost] form" requires novalidate`,
+			}
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("%s: required-attributes findings = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestToLinterConfig_Components(t *testing.T) {
+	dir := t.TempDir()
+	fileCfg := &config.FileConfig{
+		Components: []config.ComponentConfig{
+			{Selector: "button.btn", Template: "ui/button"},
+			{Selector: "svg.icon", Template: "ui/icon", Message: "icons come from the sprite", Exclude: []string{"emails/**"}},
+		},
+	}
+	files := map[string]string{
+		"pages/bad.html":      "<button class=\"btn primary\" type=\"button\">Save</button>\n<button type=\"button\">Plain</button><svg class=\"icon\"></svg>",
+		"pages/list.hbs":      `<button class="btn" type="button">Save</button>`,
+		"partials.html":       `{{define "ui/button"}}<button class="btn" type="button">{{.}}</button>{{end}}`,
+		"ui/icon.gohtml":      `<svg class="icon"></svg>`,
+		"emails/welcome.html": `<svg class="icon"></svg>`,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	l := linter.New(config.ToLinterConfig(fileCfg, filepath.Join(dir, config.ConfigFileName)))
+	for name := range files {
+		results, err := l.LintFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, r := range results {
+			if r.Rule == rules.RulePreferComponent {
+				got = append(got, fmt.Sprintf("%d:%d:%s", r.Line, r.Col, r.Message))
+			}
+		}
+		var want []string
+		switch name {
+		case "pages/bad.html":
+			want = []string{
+				`1:1:<button> matching "button.btn" should use {{template "ui/button"}}`,
+				`2:37:<svg> matching "svg.icon" should use {{template "ui/icon"}}: icons come from the sprite`,
+			}
+		case "pages/list.hbs":
+			want = []string{`1:1:<button> matching "button.btn" should use {{> ui/button}}`}
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("%s: prefer-component findings = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestLoadFile_Components(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{
+			name:    "valid components",
+			content: `{"components": [{"selector": "button.btn", "template": "ui/button", "exclude": ["emails/**"]}]}`,
+		},
+		{
+			name:    "missing template",
+			content: `{"components": [{"selector": "button.btn"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid selector",
+			content: `{"components": [{"selector": "button:hover", "template": "ui/button"}]}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), config.ConfigFileName)
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			_, err := config.LoadFile(path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadFile_NewFiles(t *testing.T) {
+	dir := t.TempDir()
+	baseline := "# legacy pages\nold.html\n\nsub/older.html\n"
+	if err := os.WriteFile(filepath.Join(dir, "legacy.txt"), []byte(baseline), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		content      string
+		wantBaseline []string
+		wantErr      bool
+	}{
+		{
+			name:    "since and rules",
+			content: `{"new-files": {"since": "2026-03-01", "escalate": true, "rules": {"no-inline-style": "error"}}}`,
+		},
+		{
+			name:         "baseline",
+			content:      `{"new-files": {"baseline": "legacy.txt"}}`,
+			wantBaseline: []string{filepath.Join(dir, "old.html"), filepath.Join(dir, "sub", "older.html")},
+		},
+		{name: "bad since", content: `{"new-files": {"since": "March"}}`, wantErr: true},
+		{name: "missing baseline", content: `{"new-files": {"baseline": "nope.txt"}}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, config.ConfigFileName)
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			fc, err := config.LoadFile(path)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			nf := config.ToLinterConfig(fc, path).NewFiles
+			if !slices.Equal(nf.Baseline, tt.wantBaseline) {
+				t.Errorf("Baseline = %v, want %v", nf.Baseline, tt.wantBaseline)
+			}
+			if tt.wantBaseline == nil {
+				if !nf.Escalate || nf.Since.Format("2006-01-02") != "2026-03-01" {
+					t.Errorf("NewFiles = %+v, want escalation since 2026-03-01", nf)
+				}
+				if nf.RuleSeverity[rules.RuleNoInlineStyle] != rules.Error {
+					t.Errorf("RuleSeverity = %v, want no-inline-style error", nf.RuleSeverity)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadFile_ConditionalComments(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    linter.ConditionalMode
+		wantErr bool
+	}{
+		{name: "default", content: `{}`, want: linter.ConditionalSkip},
+		{name: "skip", content: `{"conditional-comments": "skip"}`, want: linter.ConditionalSkip},
+		{name: "branch", content: `{"conditional-comments": "branch"}`, want: linter.ConditionalBranch},
+		{name: "unknown", content: `{"conditional-comments": "parse"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), config.ConfigFileName)
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			fc, err := config.LoadFile(path)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error for unknown mode")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := config.ToLinterConfig(fc, path).ConditionalComments; got != tt.want {
+				t.Errorf("ConditionalComments = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadFile_TemplateFunctions(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    map[string]rules.TemplateFunc
+		wantErr string
+	}{
+		{name: "none", content: `{}`},
+		{
+			name:    "declared",
+			content: `{"template-functions": {"safeURL": {"args": 1, "returns": "url"}, "t": {"args": 1, "variadic": true}}}`,
+			want: map[string]rules.TemplateFunc{
+				"safeURL": {Args: 1, Returns: rules.FuncReturnsURL},
+				"t":       {Args: 1, Variadic: true},
+			},
+		},
+		{
+			name:    "unknown return kind",
+			content: `{"template-functions": {"safeJS": {"args": 1, "returns": "js"}}}`,
+			wantErr: `template function "safeJS": unknown return kind "js"`,
+		},
+		{
+			name:    "negative args",
+			content: `{"template-functions": {"f": {"args": -1}}}`,
+			wantErr: "args must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), config.ConfigFileName)
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			fc, err := config.LoadFile(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadFile() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := config.ToLinterConfig(fc, path).TemplateFuncs; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TemplateFuncs = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,12 +1,17 @@
 package config
 
-import "github.com/toba/go-html-validate/rules"
+import (
+	"maps"
+
+	"github.com/toba/go-html-validate/rules"
+)
 
 // Presets contains built-in configuration presets.
 var Presets = map[string]*FileConfig{
 	"html-validate:recommended": recommendedPreset(),
 	"html-validate:standard":    standardPreset(),
 	"html-validate:a11y":        a11yPreset(),
+	"html-validate:a11y-strict": a11yStrictPreset(),
 }
 
 // recommendedPreset returns the recommended preset with all rules at default severity.
@@ -77,6 +82,7 @@ func a11yPreset() *FileConfig {
 			rules.RuleDeprecated:                  {Severity: "off"},
 			rules.RuleNoDeprecatedAttr:            {Severity: "off"},
 			rules.RuleNoConditionalComment:        {Severity: "off"},
+			rules.RuleNoXHTMLSyntax:               {Severity: "off"},
 			rules.RuleElementName:                 {Severity: "off"},
 			rules.RuleScriptType:                  {Severity: "off"},
 			rules.RuleAttributeAllowedValues:      {Severity: "off"},
@@ -90,3 +96,16 @@ func a11yPreset() *FileConfig {
 		},
 	}
 }
+
+// a11yStrictPreset returns the accessibility preset with the opt-in
+// accessibility rules enabled as well, for projects that accept more
+// findings that need a human to confirm.
+func a11yStrictPreset() *FileConfig {
+	preset := a11yPreset()
+	maps.Copy(preset.Rules, map[string]RuleConfig{
+		rules.RuleNavSemantics: {Severity: "warn"},
+		rules.RuleMotionSafety: {Severity: "warn"},
+		rules.RuleTextResize:   {Severity: "warn"},
+	})
+	return preset
+}
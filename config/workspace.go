@@ -0,0 +1,106 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/toba/go-html-validate/rules"
+)
+
+// Project is a workspace project: files under Dir are linted with Config.
+type Project struct {
+	// Dir is the absolute project directory.
+	Dir string
+	// Config is the project's resolved configuration. Projects without a
+	// config file of their own use the workspace config.
+	Config *FileConfig
+	// ConfigPath is the file Config was loaded from.
+	ConfigPath string
+}
+
+// WorkspaceProjects expands the "workspace" entries of fc, which was
+// loaded from configPath, into projects. Entries are directories or glob
+// patterns relative to the config file; each project's config is found by
+// the usual upward search from its directory. A relative asset-exists or
+// svg-use-reference "root" in a project config resolves against that
+// config's directory, so every project keeps its own asset root when
+// linted from the workspace.
+func WorkspaceProjects(fc *FileConfig, configPath string) ([]Project, error) {
+	base := filepath.Dir(configPath)
+	var dirs []string
+	for _, entry := range fc.Workspace {
+		pattern := entry
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(base, entry)
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("workspace entry %q: %w", entry, err)
+		}
+		if len(matches) == 0 && !strings.ContainsAny(entry, "*?[") {
+			return nil, fmt.Errorf("workspace project %q not found", entry)
+		}
+		for _, m := range matches {
+			if info, err := os.Stat(m); err != nil || !info.IsDir() {
+				continue
+			}
+			abs, err := filepath.Abs(m)
+			if err != nil {
+				return nil, err
+			}
+			if !slices.Contains(dirs, abs) {
+				dirs = append(dirs, abs)
+			}
+		}
+	}
+	slices.Sort(dirs)
+
+	absConfig, err := filepath.Abs(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	projects := make([]Project, 0, len(dirs))
+	for _, dir := range dirs {
+		cfg, path, err := Resolve(dir)
+		if err != nil {
+			return nil, fmt.Errorf("project %s: %w", dir, err)
+		}
+		if abs, _ := filepath.Abs(path); cfg == nil || abs == absConfig {
+			cfg, path = fc, configPath
+		} else {
+			cfg = withProjectAssetRoot(cfg, filepath.Dir(path))
+		}
+		projects = append(projects, Project{Dir: dir, Config: cfg, ConfigPath: path})
+	}
+	return projects, nil
+}
+
+// withProjectAssetRoot returns fc with the relative "root" option of the
+// rules resolving asset URLs joined to dir.
+func withProjectAssetRoot(fc *FileConfig, dir string) *FileConfig {
+	out := fc
+	for _, name := range []string{rules.RuleAssetExists, rules.RuleSVGUseReference} {
+		rc, ok := fc.Rules[name]
+		if !ok {
+			continue
+		}
+		root, ok := rc.Options["root"].(string)
+		if !ok || root == "" || filepath.IsAbs(root) {
+			continue
+		}
+		if out == fc {
+			clone := *fc
+			clone.Rules = maps.Clone(fc.Rules)
+			out = &clone
+		}
+		rc.Options = maps.Clone(rc.Options)
+		rc.Options["root"] = filepath.Join(dir, root)
+		out.Rules[name] = rc
+	}
+	return out
+}
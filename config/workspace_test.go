@@ -0,0 +1,73 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/toba/go-html-validate/config"
+	"github.com/toba/go-html-validate/rules"
+)
+
+func TestWorkspaceProjects(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(config.ConfigFileName, `{"workspace": ["apps/*", "docs"]}`)
+	write("apps/web/"+config.ConfigFileName, `{
+		"frameworks": {"htmx": true},
+		"rules": {"asset-exists": ["error", {"root": "public"}]}
+	}`)
+	write("apps/admin/index.html", `<p>Hi</p>`)
+	write("apps/README.md", `not a project`)
+	write("docs/index.html", `<p>Hi</p>`)
+
+	root := filepath.Join(dir, config.ConfigFileName)
+	fc, err := config.LoadFile(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	projects, err := config.WorkspaceProjects(fc, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"apps/admin", "apps/web", "docs"}
+	if len(projects) != len(want) {
+		t.Fatalf("got %d projects, want %d: %+v", len(projects), len(want), projects)
+	}
+	for i, p := range projects {
+		if p.Dir != filepath.Join(dir, want[i]) {
+			t.Errorf("project %d dir = %s, want %s", i, p.Dir, want[i])
+		}
+	}
+
+	if projects[0].Config != fc || projects[0].ConfigPath != root {
+		t.Errorf("apps/admin should use the workspace config, got %s", projects[0].ConfigPath)
+	}
+	web := projects[1]
+	if !web.Config.Frameworks.HTMX {
+		t.Error("apps/web should use its own config")
+	}
+	gotRoot := web.Config.Rules[rules.RuleAssetExists].Options["root"]
+	if wantRoot := filepath.Join(dir, "apps/web/public"); gotRoot != wantRoot {
+		t.Errorf("asset-exists root = %v, want %s", gotRoot, wantRoot)
+	}
+}
+
+func TestWorkspaceProjects_Missing(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, config.ConfigFileName)
+	fc := &config.FileConfig{Workspace: []string{"apps/web"}}
+	if _, err := config.WorkspaceProjects(fc, root); err == nil {
+		t.Error("expected error for missing project directory")
+	}
+}
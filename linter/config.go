@@ -1,8 +1,15 @@
 package linter
 
 import (
+	"bytes"
+	"fmt"
+	"io"
+	"maps"
+	"path/filepath"
 	"slices"
+	"strings"
 
+	"github.com/toba/go-html-validate/parser"
 	"github.com/toba/go-html-validate/rules"
 )
 
@@ -16,6 +23,121 @@ type FrameworkConfig struct {
 	// HTMXCustomEvents lists custom event names that should not trigger
 	// "unknown event" warnings in hx-on:* validation.
 	HTMXCustomEvents []string
+	// Allowed lists other frontend frameworks the project uses ("angular",
+	// "vue", "alpine", "turbo", or "htmx"); framework-remnants flags the
+	// attributes of the rest.
+	Allowed []string
+}
+
+// DefaultGeneratedMarkers identify generated files when no markers are configured.
+var DefaultGeneratedMarkers = []string{"Code generated", "DO NOT EDIT"}
+
+// DefaultGeneratedLines is how many leading lines are searched for a generated marker.
+const DefaultGeneratedLines = 5
+
+// GeneratedConfig configures detection and skipping of generated files.
+type GeneratedConfig struct {
+	// Markers are substrings that identify a generated file.
+	// Defaults to DefaultGeneratedMarkers when empty.
+	Markers []string
+	// Lines is how many leading lines are searched for a marker.
+	// Defaults to DefaultGeneratedLines when zero.
+	Lines int
+	// Include lints generated files instead of skipping them.
+	Include bool
+}
+
+// RuleScope limits where a rule reports findings by file path.
+type RuleScope struct {
+	// Include lists glob patterns; when set, only matching files are reported
+	Include []string
+	// Exclude lists glob patterns for files that are never reported
+	Exclude []string
+}
+
+// Matches reports whether findings in path are in scope. Paths should be
+// relative to the config file directory (see Config.ScopePath).
+func (s RuleScope) Matches(path string) bool {
+	for _, pattern := range s.Exclude {
+		if matchIgnorePattern(path, pattern) {
+			return false
+		}
+	}
+	if len(s.Include) == 0 {
+		return true
+	}
+	for _, pattern := range s.Include {
+		if matchIgnorePattern(path, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// ConditionalMode selects how markup in IE conditional comments is linted.
+type ConditionalMode string
+
+const (
+	// ConditionalSkip lints pages as browsers other than IE render them:
+	// markup hidden from them in conditional comments is not checked.
+	ConditionalSkip ConditionalMode = ""
+	// ConditionalBranch also lints pages as legacy IE renders them (see
+	// parser.ConditionalVariant), as one more variant whose findings are
+	// merged like those of template branches.
+	ConditionalBranch ConditionalMode = "branch"
+)
+
+// ParseConditionalMode validates a conditional-comments setting, "skip"
+// or "branch".
+func ParseConditionalMode(s string) (ConditionalMode, error) {
+	switch s {
+	case "", "skip":
+		return ConditionalSkip, nil
+	case string(ConditionalBranch):
+		return ConditionalBranch, nil
+	}
+	return "", fmt.Errorf("invalid conditional-comments mode %q (supported: skip, branch)", s)
+}
+
+// Profile is a named set of rule overrides that a file can opt into with an
+// htmlint-config directive (e.g. <!-- htmlint-config: profile=email -->).
+type Profile struct {
+	// DisabledRules lists rules to disable
+	DisabledRules []string
+	// RuleSeverity overrides severity for specific rules
+	RuleSeverity map[string]rules.Severity
+}
+
+// BuiltinProfiles are available to every file without configuration.
+// Profiles in Config.Profiles with the same name replace them.
+var BuiltinProfiles = map[string]Profile{
+	// email templates rely on inline styles and legacy presentational markup
+	"email": {
+		DisabledRules: []string{
+			rules.RuleNoInlineStyle,
+			rules.RuleNoStyleTag,
+			rules.RuleDeprecated,
+			rules.RuleNoDeprecatedAttr,
+			rules.RulePreferSemantic,
+			rules.RulePreferTbody,
+			rules.RuleNoConditionalComment,
+		},
+	},
+	// embeds are injected into host pages that own the document structure
+	"embed": {
+		DisabledRules: []string{
+			rules.RuleRequireLang,
+			rules.RuleMissingDoctype,
+			rules.RuleNoMultipleMain,
+			rules.RuleHeadingLevel,
+		},
+	},
+	// strict enables opt-in hardening checks
+	"strict": {
+		RuleSeverity: map[string]rules.Severity{
+			rules.RuleIframeRequireSandbox: rules.Warning,
+		},
+	},
 }
 
 // Config holds linter configuration options.
@@ -26,14 +148,94 @@ type Config struct {
 	DisabledRules []string
 	// RuleSeverity overrides severity for specific rules
 	RuleSeverity map[string]rules.Severity
+	// RuleOptions holds options for specific rules, keyed by rule name
+	RuleOptions map[string]map[string]any
+	// Packs add rules from rule packs after the built-in rules; a pack rule
+	// whose name is already taken is ignored
+	Packs []rules.Pack
+	// URLRewriters map local asset URLs to file paths for asset-exists,
+	// after any rewrites from its options
+	URLRewriters []rules.URLRewriter
+	// RuleScopes limits specific rules to matching files, keyed by rule name
+	RuleScopes map[string]RuleScope
+	// PageTypes declare structure required of matching pages, checked by
+	// page-structure; a file gets the first type whose patterns match it
+	// relative to the config file (see ScopePath)
+	PageTypes []rules.PageType
+	// Bans list markup reported by banned-markup; Exclude patterns match
+	// relative to the config file (see ScopePath)
+	Bans []rules.Ban
+	// Requirements list attributes checked by required-attributes; Exclude
+	// patterns match like those of Bans
+	Requirements []rules.Requirement
+	// Components list templates suggested by prefer-component; Exclude
+	// patterns match like those of Bans
+	Components []rules.Component
+	// TemplateFuncs declares the functions of the project's Go template
+	// FuncMap, keyed by name, for template-syntax-valid,
+	// template-escaping-context, and RenderTemplate
+	TemplateFuncs map[string]rules.TemplateFunc
 	// MinSeverity filters results to this severity or higher
 	MinSeverity rules.Severity
 	// IgnorePatterns are glob patterns for files to skip
 	IgnorePatterns []string
+	// DocumentPatterns are glob patterns for files always parsed as full documents
+	DocumentPatterns []string
+	// FragmentPatterns are glob patterns for files always parsed as fragments
+	FragmentPatterns []string
+	// PartialPatterns are glob patterns for htmx partial responses: parsed
+	// as fragments, with full-document rules skipped
+	PartialPatterns []string
 	// ConfigPath is the path to the loaded config file (for debugging)
 	ConfigPath string
 	// Frameworks configures framework-specific attribute handling.
 	Frameworks FrameworkConfig
+	// Generated configures skipping of generated files.
+	Generated GeneratedConfig
+	// Profiles are named rule overrides selectable per file by directive.
+	Profiles map[string]Profile
+	// NewFiles holds stricter severities for new files
+	NewFiles NewFiles
+	// Locale selects the language of cataloged messages (see package
+	// messages); empty means English
+	Locale string
+	// PathMode selects how Run reports filenames
+	PathMode PathMode
+	// Fix applies automatic fixes to linted files and reports only the
+	// findings that remain
+	Fix bool
+	// FixDiff, when set with Fix, receives the fixes as a unified diff
+	// (see UnifiedDiff); files are left unchanged, so every finding is
+	// still reported
+	FixDiff io.Writer
+	// FixFilter, when set with Fix, is asked about each fixable finding of
+	// a file in source order; fixes it declines are not applied and their
+	// findings are reported
+	FixFilter func(path string, content []byte, r rules.Result) bool
+	// TemplateBranches lints each {{if}}/{{else}} branch as a separate
+	// variant, merging their findings, instead of only the if-branch; on in
+	// DefaultConfig
+	TemplateBranches bool
+	// TemplateDialect is the template syntax the preprocessor and the
+	// template rules expect (parser.DialectGo when zero); files whose
+	// extension implies a dialect use it instead (see parser.Dialect.ForFile)
+	TemplateDialect parser.Dialect
+	// ConditionalComments selects how markup in IE conditional comments is
+	// linted; ConditionalSkip when zero
+	ConditionalComments ConditionalMode
+	// MaxBranchVariants bounds the variants linted per file when
+	// TemplateBranches is set (parser.DefaultMaxBranchVariants when zero)
+	MaxBranchVariants int
+	// MaxMemory is the memory limit in bytes that LintFiles works within
+	// (GOMEMLIMIT when zero); see Config.projectBudget
+	MaxMemory int64
+	// StreamThreshold is the file size in bytes above which files are
+	// linted from their token stream by rules.TokenRule rules only, instead
+	// of being parsed into a tree; zero streams nothing
+	StreamThreshold int64
+
+	newFile bool     // the config of a file NewFiles selects
+	optedIn []string // opt-in rules enabled by an htmlint-config directive
 }
 
 // DefaultConfig returns a configuration with all rules enabled.
@@ -44,7 +246,81 @@ func DefaultConfig() *Config {
 		RuleSeverity:   make(map[string]rules.Severity),
 		MinSeverity:    rules.Info, // Show everything by default
 		IgnorePatterns: nil,
+
+		TemplateBranches: true,
+	}
+}
+
+// Clone returns a copy of the config that can be modified independently.
+func (c *Config) Clone() *Config {
+	clone := *c
+	clone.EnabledRules = slices.Clone(c.EnabledRules)
+	clone.DisabledRules = slices.Clone(c.DisabledRules)
+	clone.optedIn = slices.Clone(c.optedIn)
+	clone.RuleSeverity = maps.Clone(c.RuleSeverity)
+	if clone.RuleSeverity == nil {
+		clone.RuleSeverity = make(map[string]rules.Severity)
+	}
+	return &clone
+}
+
+// Profile returns the named profile from Profiles or BuiltinProfiles.
+func (c *Config) Profile(name string) (Profile, bool) {
+	if p, ok := c.Profiles[name]; ok {
+		return p, true
 	}
+	p, ok := BuiltinProfiles[name]
+	return p, ok
+}
+
+// ApplyProfile applies the named profile's overrides to c. A rule given a
+// severity by the profile is re-enabled if c disabled it. Returns false if
+// no profile has that name.
+func (c *Config) ApplyProfile(name string) bool {
+	profile, ok := c.Profile(name)
+	if !ok {
+		return false
+	}
+	if c.RuleSeverity == nil {
+		c.RuleSeverity = make(map[string]rules.Severity)
+	}
+	for rule, sev := range profile.RuleSeverity {
+		c.RuleSeverity[rule] = sev
+		c.DisabledRules = slices.DeleteFunc(c.DisabledRules, func(name string) bool { return name == rule })
+	}
+	c.DisabledRules = append(c.DisabledRules, profile.DisabledRules...)
+	return true
+}
+
+// IsGenerated reports whether content carries a generated-file marker
+// within its leading lines.
+func (g GeneratedConfig) IsGenerated(content []byte) bool {
+	markers := g.Markers
+	if len(markers) == 0 {
+		markers = DefaultGeneratedMarkers
+	}
+	lines := g.Lines
+	if lines <= 0 {
+		lines = DefaultGeneratedLines
+	}
+
+	head := content
+	for i, n := 0, 0; i < len(content); i++ {
+		if content[i] == '\n' {
+			n++
+			if n == lines {
+				head = content[:i]
+				break
+			}
+		}
+	}
+
+	for _, marker := range markers {
+		if marker != "" && bytes.Contains(head, []byte(marker)) {
+			return true
+		}
+	}
+	return false
 }
 
 // IsRuleEnabled checks if a rule should be run.
@@ -63,6 +339,40 @@ func (c *Config) IsRuleEnabled(name string) bool {
 	return true
 }
 
+// ScopePath returns path relative to the directory of the loaded config
+// file, for matching against RuleScopes. Paths outside that directory, or
+// any path when no config file was loaded, are returned unchanged.
+func (c *Config) ScopePath(path string) string {
+	if c.ConfigPath == "" {
+		return path
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(filepath.Dir(c.ConfigPath), abs)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// matchScoped reports whether path, made relative to the config file
+// directory, matches a glob pattern.
+func (c *Config) matchScoped(path, pattern string) bool {
+	return matchIgnorePattern(c.ScopePath(path), pattern)
+}
+
+// IsOptedIn reports whether an opt-in rule has been explicitly enabled,
+// by name in EnabledRules, with a severity override, or by an
+// htmlint-config directive.
+func (c *Config) IsOptedIn(name string) bool {
+	if _, ok := c.RuleSeverity[name]; ok {
+		return true
+	}
+	return slices.Contains(c.EnabledRules, name) || slices.Contains(c.optedIn, name)
+}
+
 // ErrorsOnly configures the linter to only report errors.
 func (c *Config) ErrorsOnly() *Config {
 	c.MinSeverity = rules.Error
@@ -0,0 +1,204 @@
+package linter
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines around each hunk.
+const diffContext = 3
+
+// UnifiedDiff returns a unified diff turning before into after for the
+// file at path, with a/ and b/ prefixes so git apply accepts it. Absolute
+// paths are made relative to the working directory when they are inside
+// it. It returns nil when the contents are equal.
+func UnifiedDiff(path string, before, after []byte) []byte {
+	if bytes.Equal(before, after) {
+		return nil
+	}
+	a, b := splitLines(before), splitLines(after)
+	ops := diffLines(a, b)
+
+	name := strings.TrimPrefix(filepath.ToSlash(diffPath(path)), "/")
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "--- a/%s\n+++ b/%s\n", name, name)
+
+	for start := 0; start < len(ops); {
+		// find the next change and the end of its hunk, merging changes
+		// whose context would overlap
+		first := start
+		for first < len(ops) && ops[first].kind == ' ' {
+			first++
+		}
+		if first == len(ops) {
+			break
+		}
+		last := first
+		for i := first; i < len(ops); i++ {
+			if ops[i].kind != ' ' {
+				last = i
+			} else if i-last > 2*diffContext {
+				break
+			}
+		}
+		lo, hi := max(first-diffContext, start), min(last+diffContext+1, len(ops))
+		writeHunk(&out, ops[lo:hi])
+		start = hi
+	}
+
+	return out.Bytes()
+}
+
+// diffPath returns path relative to the working directory when it is
+// absolute and inside it, and cleaned otherwise.
+func diffPath(path string) string {
+	path = filepath.Clean(path)
+	if !filepath.IsAbs(path) {
+		return path
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(wd, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return rel
+}
+
+// lineOp is one line of an edit script: kept (' '), removed ('-'), or
+// added ('+'). oldLine and newLine are the 0-based positions the line
+// occupies, or would occupy, in each file.
+type lineOp struct {
+	kind             byte
+	text             string
+	oldLine, newLine int
+}
+
+// writeHunk writes ops, which start and end with context, as one hunk.
+func writeHunk(out *bytes.Buffer, ops []lineOp) {
+	var oldCount, newCount int
+	for _, op := range ops {
+		if op.kind != '+' {
+			oldCount++
+		}
+		if op.kind != '-' {
+			newCount++
+		}
+	}
+	// an empty range is numbered by the line before it
+	oldStart, newStart := ops[0].oldLine+1, ops[0].newLine+1
+	if oldCount == 0 {
+		oldStart--
+	}
+	if newCount == 0 {
+		newStart--
+	}
+	fmt.Fprintf(out, "@@ -%s +%s @@\n", hunkRange(oldStart, oldCount), hunkRange(newStart, newCount))
+	for _, op := range ops {
+		out.WriteByte(op.kind)
+		out.WriteString(op.text)
+		if !strings.HasSuffix(op.text, "\n") {
+			out.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+// hunkRange formats a hunk header range, omitting a count of one.
+func hunkRange(start, count int) string {
+	if count == 1 {
+		return fmt.Sprint(start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+// splitLines splits content after each newline, keeping the newlines.
+func splitLines(content []byte) []string {
+	var lines []string
+	for len(content) > 0 {
+		i := bytes.IndexByte(content, '\n') + 1
+		if i == 0 {
+			i = len(content)
+		}
+		lines = append(lines, string(content[:i]))
+		content = content[i:]
+	}
+	return lines
+}
+
+// diffLines returns a shortest edit script turning a into b, using Myers'
+// algorithm. Fixes touch few lines, so the O((N+M)D) search stays cheap
+// and the trace, O(D²), small.
+func diffLines(a, b []string) []lineOp {
+	n, m := len(a), len(b)
+	// v[k+off] is the furthest x reached on diagonal k = x-y
+	off := n + m + 1
+	v := make([]int, 2*off+1)
+	var trace [][]int // trace[d] holds v[-d..d] before round d
+	for d := 0; d <= n+m; d++ {
+		trace = append(trace, append([]int(nil), v[off-d:off+d+1]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[off+k-1] < v[off+k+1]) {
+				x = v[off+k+1]
+			} else {
+				x = v[off+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[off+k] = x
+			if x >= n && y >= m {
+				return backtrack(a, b, trace)
+			}
+		}
+	}
+	return nil
+}
+
+// backtrack walks the trace of diffLines back from the end of both inputs.
+func backtrack(a, b []string, trace [][]int) []lineOp {
+	var ops []lineOp
+	x, y := len(a), len(b)
+	for d := len(trace) - 1; d >= 0; d-- {
+		at := func(k int) int { return trace[d][k+d] }
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := 0
+		if d > 0 {
+			prevX = at(prevK)
+		}
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			ops = append(ops, lineOp{' ', a[x], x, y})
+		}
+		if d == 0 {
+			break
+		}
+		if x == prevX {
+			ops = append(ops, lineOp{'+', b[prevY], x, prevY})
+		} else {
+			ops = append(ops, lineOp{'-', a[prevX], prevX, y})
+		}
+		x, y = prevX, prevY
+	}
+
+	// reverse into source order
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops
+}
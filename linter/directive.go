@@ -0,0 +1,119 @@
+package linter
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+
+	"github.com/toba/go-html-validate/rules"
+	"golang.org/x/net/html"
+)
+
+// RuleConfigDirective is the rule name reported for malformed
+// htmlint-config directives.
+const RuleConfigDirective = "config-directive"
+
+// applyDirective returns the config and rules in effect for a single file.
+// A leading <!-- htmlint-config: ... --> comment, preceded by nothing but
+// whitespace, a byte order mark, and a doctype, adjusts the linter config
+// for that file only. Supported keys (values are comma-separated):
+//
+//	disable=rule-a,rule-b   disable rules
+//	enable=rule-a           enable rules disabled by config or opt-in
+//	profile=email           apply a named profile
+//
+// Problems with the directive, including unknown rule names, are returned
+// as config-directive warnings.
+func (l *Linter) applyDirective(filename string, content []byte) (*Config, []rules.Rule, []rules.Result) {
+	directive, offset, ok := leadingDirective(content)
+	if !ok {
+		return l.config, l.rules, nil
+	}
+
+	cfg := l.config.Clone()
+	line := 1 + bytes.Count(content[:offset], []byte("\n"))
+	var results []rules.Result
+	warn := func(id string, params map[string]any) {
+		results = append(results, rules.Result{
+			Rule:      RuleConfigDirective,
+			Message:   catalogMessage(id, params),
+			MessageID: id,
+			Params:    params,
+			Filename:  filename,
+			Line:      line,
+			Col:       1,
+			Severity:  rules.Warning,
+		})
+	}
+
+	checkNames := func(names []string) {
+		for _, name := range names {
+			if !slices.ContainsFunc(l.all, func(r rules.Rule) bool { return r.Name() == name }) {
+				warn("config-directive.unknown-rule", map[string]any{"rule": name})
+			}
+		}
+	}
+
+	for field := range strings.FieldsSeq(directive) {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok || value == "" {
+			warn("config-directive.entry", map[string]any{"entry": field})
+			continue
+		}
+		names := strings.Split(value, ",")
+
+		switch key {
+		case "disable":
+			checkNames(names)
+			cfg.DisabledRules = append(cfg.DisabledRules, names...)
+		case "enable":
+			checkNames(names)
+			cfg.DisabledRules = slices.DeleteFunc(cfg.DisabledRules, func(name string) bool {
+				return slices.Contains(names, name)
+			})
+			if len(cfg.EnabledRules) > 0 {
+				cfg.EnabledRules = append(cfg.EnabledRules, names...)
+			}
+			cfg.optedIn = append(cfg.optedIn, names...)
+		case "profile":
+			for _, name := range names {
+				if !cfg.ApplyProfile(name) {
+					warn("config-directive.unknown-profile", map[string]any{"profile": name})
+				}
+			}
+		default:
+			warn("config-directive.unknown-key", map[string]any{"key": key})
+		}
+	}
+
+	return cfg, enabledRules(cfg, l.all), results
+}
+
+// leadingDirective returns the text after "htmlint-config:" of the comment
+// that starts content and its byte offset, skipping a byte order mark,
+// whitespace, and a doctype. It returns false if content starts with
+// anything else.
+func leadingDirective(content []byte) (string, int, bool) {
+	offset := 0
+	if bytes.HasPrefix(content, []byte("\uFEFF")) {
+		offset = len("\uFEFF")
+	}
+	z := html.NewTokenizer(bytes.NewReader(content[offset:]))
+	for {
+		tt := z.Next()
+		start := offset
+		offset += len(z.Raw())
+		switch tt {
+		case html.TextToken:
+			if len(bytes.TrimSpace(z.Raw())) == 0 {
+				continue
+			}
+		case html.DoctypeToken:
+			continue
+		case html.CommentToken:
+			text, ok := strings.CutPrefix(strings.TrimSpace(string(z.Text())), "htmlint-config:")
+			return text, start, ok
+		}
+		return "", 0, false
+	}
+}
@@ -0,0 +1,46 @@
+package linter
+
+import (
+	"bytes"
+	"slices"
+
+	"github.com/toba/go-html-validate/rules"
+)
+
+// ApplyFixes applies the fixes attached to results to content. Fixes are
+// applied in source order; a fix overlapping one already applied is
+// skipped. It returns the fixed content and the results whose fixes were
+// not applied, including results without a fix.
+func ApplyFixes(content []byte, results []rules.Result) ([]byte, []rules.Result) {
+	var fixable, remaining []rules.Result
+	for _, r := range results {
+		if r.Fix != nil && r.Fix.Start >= 0 && r.Fix.Start <= r.Fix.End && r.Fix.End <= len(content) {
+			fixable = append(fixable, r)
+		} else {
+			remaining = append(remaining, r)
+		}
+	}
+	if len(fixable) == 0 {
+		return content, results
+	}
+
+	slices.SortStableFunc(fixable, func(a, b rules.Result) int {
+		return a.Fix.Start - b.Fix.Start
+	})
+
+	var out bytes.Buffer
+	out.Grow(len(content))
+	prev := 0
+	for _, r := range fixable {
+		if r.Fix.Start < prev {
+			remaining = append(remaining, r)
+			continue
+		}
+		out.Write(content[prev:r.Fix.Start])
+		out.WriteString(r.Fix.Text)
+		prev = r.Fix.End
+	}
+	out.Write(content[prev:])
+
+	return out.Bytes(), remaining
+}
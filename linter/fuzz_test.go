@@ -0,0 +1,45 @@
+package linter_test
+
+import (
+	"testing"
+
+	"github.com/toba/go-html-validate/linter"
+	"github.com/toba/go-html-validate/rules"
+)
+
+// FuzzLintContent runs every rule, opt-in ones included, over arbitrary
+// input. Parse failures are fine; panics and hangs are not.
+func FuzzLintContent(f *testing.F) {
+	for _, seed := range []string{
+		`<!DOCTYPE html><html lang="en"><head><title>T</title></head><body><img src="a.png"></body></html>`,
+		`<form action="{{.URL}}"><input name="q" {{if .R}}required{{end}}><button>Go</button></form>`,
+		`{{define "row"}}<tr><td>{{.}}</td></tr>{{end}}`,
+		`<a href="javascript:void(0)" hx-get="http://localhost/x" onclick="f()">x</a>`,
+		`<!-- <div><p>old</p></div> --><svg><![CDATA[x]]></svg><?xml?>`,
+		`<p>{{if .A}}<b>{{else}}<i>{{end}}</p>`,
+	} {
+		f.Add([]byte(seed))
+	}
+
+	cfg := linter.DefaultConfig()
+	for _, rule := range rules.NewRegistry().All() {
+		cfg.EnabledRules = append(cfg.EnabledRules, rule.Name())
+	}
+	cfg.TemplateBranches = true
+	l := linter.New(cfg)
+
+	f.Fuzz(func(t *testing.T, content []byte) {
+		if len(content) > 64<<10 {
+			return
+		}
+		results, err := l.LintContent("fuzz.html", content)
+		if err != nil {
+			return
+		}
+		for _, r := range results {
+			if r.Line < 1 || r.Col < 1 {
+				t.Fatalf("%s reported invalid position %d:%d", r.Rule, r.Line, r.Col)
+			}
+		}
+	})
+}
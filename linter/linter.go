@@ -2,19 +2,32 @@
 package linter
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/toba/go-html-validate/messages"
 	"github.com/toba/go-html-validate/parser"
 	"github.com/toba/go-html-validate/rules"
 )
 
 // Linter coordinates HTML template accessibility checking.
 type Linter struct {
-	rules    []rules.Rule
-	config   *Config
-	reporter Reporter
+	rules      []rules.Rule
+	all        []rules.Rule // every configured rule, for per-file overrides
+	config     *Config
+	reporter   Reporter
+	middleware []Middleware
+	files      int // files linted by LintFiles, for StatsReporter
+
+	// git repository lookups for NewFiles.Since (see addedDate)
+	roots map[string]string
+	added map[string]map[string]time.Time
 }
 
 // Reporter defines the interface for outputting lint results.
@@ -22,6 +35,14 @@ type Reporter interface {
 	Report(results []rules.Result) error
 }
 
+// StatsReporter is a Reporter that also reports on the run itself. Run
+// calls ReportStats with the number of files linted (including generated
+// files it skipped) and the elapsed time before calling Report.
+type StatsReporter interface {
+	Reporter
+	ReportStats(files int, elapsed time.Duration)
+}
+
 // New creates a new Linter with the given configuration.
 func New(cfg *Config) *Linter {
 	if cfg == nil {
@@ -29,109 +50,450 @@ func New(cfg *Config) *Linter {
 	}
 
 	registry := rules.NewRegistry()
-	enabledRules := make([]rules.Rule, 0)
-
-	for _, rule := range registry.All() {
-		if cfg.IsRuleEnabled(rule.Name()) {
-			// Configure htmx-aware rules
-			if htmxRule, ok := rule.(rules.HTMXConfigurable); ok {
-				htmxRule.Configure(cfg.Frameworks.HTMX, cfg.Frameworks.HTMXVersion)
+	allRules := slices.Clone(registry.All())
+	for _, pack := range cfg.Packs {
+		for _, rule := range pack.Rules() {
+			if !slices.ContainsFunc(allRules, func(r rules.Rule) bool { return r.Name() == rule.Name() }) {
+				allRules = append(allRules, rule)
 			}
-			if customRule, ok := rule.(rules.HTMXCustomEventsConfigurable); ok {
-				customRule.ConfigureCustomEvents(cfg.Frameworks.HTMXCustomEvents)
-			}
-			enabledRules = append(enabledRules, rule)
+		}
+	}
+
+	for _, rule := range allRules {
+		// Configure htmx-aware rules
+		if htmxRule, ok := rule.(rules.HTMXConfigurable); ok {
+			htmxRule.Configure(cfg.Frameworks.HTMX, cfg.Frameworks.HTMXVersion)
+		}
+		if customRule, ok := rule.(rules.HTMXCustomEventsConfigurable); ok {
+			customRule.ConfigureCustomEvents(cfg.Frameworks.HTMXCustomEvents)
+		}
+		if frameworksRule, ok := rule.(rules.FrameworksConfigurable); ok {
+			frameworksRule.ConfigureFrameworks(cfg.Frameworks.Allowed)
+		}
+		if rewriteRule, ok := rule.(rules.URLRewriterConfigurable); ok {
+			rewriteRule.ConfigureRewriters(cfg.URLRewriters)
+		}
+		if pageRule, ok := rule.(rules.PageTypesConfigurable); ok {
+			pageRule.ConfigurePageTypes(cfg.PageTypes)
+		}
+		if banRule, ok := rule.(rules.BansConfigurable); ok {
+			banRule.ConfigureBans(cfg.Bans, cfg.matchScoped)
+		}
+		if reqRule, ok := rule.(rules.RequirementsConfigurable); ok {
+			reqRule.ConfigureRequirements(cfg.Requirements, cfg.matchScoped)
+		}
+		if compRule, ok := rule.(rules.ComponentsConfigurable); ok {
+			compRule.ConfigureComponents(cfg.Components, cfg.matchScoped)
+		}
+		if optsRule, ok := rule.(rules.OptionsConfigurable); ok {
+			optsRule.ConfigureOptions(cfg.RuleOptions[rule.Name()])
+		}
+		if dialectRule, ok := rule.(rules.DialectConfigurable); ok {
+			dialectRule.ConfigureDialect(cfg.TemplateDialect)
+		}
+		if funcsRule, ok := rule.(rules.TemplateFuncsConfigurable); ok {
+			funcsRule.ConfigureTemplateFuncs(cfg.TemplateFuncs)
 		}
 	}
 
 	return &Linter{
-		rules:  enabledRules,
+		rules:  enabledRules(cfg, allRules),
+		all:    allRules,
 		config: cfg,
 	}
 }
 
+// enabledRules returns the rules cfg enables, in registry order.
+func enabledRules(cfg *Config, all []rules.Rule) []rules.Rule {
+	enabled := make([]rules.Rule, 0, len(all))
+	for _, rule := range all {
+		if !cfg.IsRuleEnabled(rule.Name()) {
+			continue
+		}
+		if _, ok := rule.(rules.OptInRule); ok && !cfg.IsOptedIn(rule.Name()) {
+			continue
+		}
+		enabled = append(enabled, rule)
+	}
+	return enabled
+}
+
 // SetReporter sets the output reporter.
 func (l *Linter) SetReporter(r Reporter) {
 	l.reporter = r
 }
 
-// LintFile checks a single file and returns any violations.
+// LintFile checks a single file and returns any violations. Files larger
+// than Config.StreamThreshold are streamed (see streamFile).
 func (l *Linter) LintFile(path string) ([]rules.Result, error) {
+	if l.streams(path) {
+		return l.streamFile(path)
+	}
+
 	content, err := os.ReadFile(path) //nolint:gosec // user-specified file path is intentional
 	if err != nil {
 		return nil, err
 	}
 
-	return l.LintContent(path, content)
+	// Skip generated files unless explicitly included
+	if !l.config.Generated.Include && l.config.Generated.IsGenerated(content) {
+		return nil, nil
+	}
+
+	results, err := l.LintContent(path, content)
+	if err != nil || !l.config.Fix {
+		return results, err
+	}
+
+	if l.config.FixFilter != nil {
+		results = l.filterFixes(path, content, results)
+	}
+	fixed, remaining := ApplyFixes(content, results)
+	if bytes.Equal(fixed, content) {
+		return results, nil
+	}
+	if l.config.FixDiff != nil {
+		if _, err := l.config.FixDiff.Write(UnifiedDiff(path, content, fixed)); err != nil {
+			return nil, err
+		}
+		return results, nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, fixed, info.Mode().Perm()); err != nil {
+		return nil, err
+	}
+	return remaining, nil
+}
+
+// filterFixes asks Config.FixFilter about each fixable result in source
+// order, dropping the fixes it declines. Fixes overlapping an accepted
+// one cannot be applied, so they are not asked about.
+func (l *Linter) filterFixes(path string, content []byte, results []rules.Result) []rules.Result {
+	results = slices.Clone(results)
+	order := make([]int, 0, len(results))
+	for i, r := range results {
+		if r.Fix != nil {
+			order = append(order, i)
+		}
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return results[a].Fix.Start - results[b].Fix.Start
+	})
+	accepted := -1 // end of the last accepted fix
+	for _, i := range order {
+		switch fix := results[i].Fix; {
+		case fix.Start < accepted:
+		case l.config.FixFilter(path, content, results[i]):
+			accepted = fix.End
+		default:
+			results[i].Fix = nil
+		}
+	}
+	return results
 }
 
 // LintContent checks HTML content and returns any violations.
+// Rules implementing rules.RawRule see the original bytes before template
+// preprocessing; all rules then check the parsed document. Raw rules are
+// skipped for templ files, whose original bytes are mostly Go code.
+// Content in UTF-16 or windows-1252 is decoded to UTF-8 first (see
+// parser.Decode); its findings carry no fixes, since their offsets are
+// into the decoded text.
 func (l *Linter) LintContent(filename string, content []byte) ([]rules.Result, error) {
-	doc, err := parser.ParseFragment(filename, content)
+	decoded, encoding := parser.Decode(content)
+	results, err := l.lintDecoded(filename, decoded, encoding)
+	if err == nil && !bytes.Equal(decoded, content) {
+		for i := range results {
+			results[i].Fix = nil
+		}
+	}
+	return results, err
+}
+
+// lintDecoded is LintContent for content decoded from encoding.
+func (l *Linter) lintDecoded(filename string, content []byte, encoding string) ([]rules.Result, error) {
+	cfg, ruleSet, directiveResults := l.applyDirective(filename, content)
+	cfg, ruleSet = l.newFileConfig(filename, cfg, ruleSet)
+	allResults := appendResults(cfg, nil, directiveResults)
+	if skipsStyleRules(ruleSet, content) {
+		ruleSet = slices.DeleteFunc(slices.Clone(ruleSet), func(rule rules.Rule) bool {
+			return minifiedStyleRules[rule.Name()]
+		})
+	}
+
+	for _, rule := range ruleSet {
+		if rawRule, ok := rule.(rules.RawRule); ok && !parser.IsTempl(filename) {
+			allResults = appendResults(cfg, allResults, guard(rule.Name(), filename, func() []rules.Result {
+				return rawRule.CheckRaw(filename, content)
+			}))
+		}
+	}
+
+	mode := l.parseMode(filename, content)
+	partial := l.isPartial(filename)
+	pageType := l.pageType(filename)
+	if partial {
+		ruleSet = slices.DeleteFunc(slices.Clone(ruleSet), func(rule rules.Rule) bool {
+			return fullDocumentRules[rule.Name()]
+		})
+	}
+
+	dialect := cfg.TemplateDialect.ForFile(filename)
+	setup := func(doc *parser.Document) {
+		doc.IsPartial = partial
+		doc.PageType = pageType
+		doc.Encoding = encoding
+	}
+	// the markup legacy IE renders in conditional comments is one more
+	// variant, parsed in the mode detected for the file
+	ieContent, ieVariant := []byte(nil), false
+	if cfg.ConditionalComments == ConditionalBranch && !parser.IsTempl(filename) {
+		ieContent, ieVariant = parser.ConditionalVariant(content, dialect)
+		if ieVariant && mode == parser.ModeAuto {
+			mode = parser.DetectMode(content)
+		}
+	}
+
+	if cfg.TemplateBranches {
+		// Variants are checked as they are parsed, so only one tree is
+		// alive at a time.
+		variants := newVariantChecker(ruleSet)
+		check := func(doc *parser.Document) {
+			setup(doc)
+			variants.check(doc)
+		}
+		if err := parser.EachBranch(filename, content, mode, dialect, cfg.MaxBranchVariants, check); err != nil {
+			return nil, err
+		}
+		if ieVariant {
+			if err := parser.EachBranch(filename, ieContent, mode, dialect, cfg.MaxBranchVariants, check); err != nil {
+				return nil, err
+			}
+		}
+		return appendResults(cfg, allResults, variants.results), nil
+	}
+
+	doc, err := parser.ParseWithDialect(filename, content, mode, dialect)
 	if err != nil {
 		return nil, err
 	}
+	setup(doc)
 
-	var allResults []rules.Result
-	for _, rule := range l.rules {
-		// Check if rule implements RawRule interface for pre-parse checks
-		if rawRule, ok := rule.(rules.RawRule); ok {
-			rawResults := rawRule.CheckRaw(filename, content)
-			for _, r := range rawResults {
-				if severity, ok := l.config.RuleSeverity[r.Rule]; ok {
-					r.Severity = severity
-				}
-				if r.Severity <= l.config.MinSeverity {
-					allResults = append(allResults, r)
-				}
-			}
+	if ieVariant {
+		ieDoc, err := parser.ParseWithDialect(filename, ieContent, mode, dialect)
+		if err != nil {
+			return nil, err
+		}
+		setup(ieDoc)
+		variants := newVariantChecker(ruleSet)
+		variants.check(doc)
+		variants.check(ieDoc)
+		return appendResults(cfg, allResults, variants.results), nil
+	}
+
+	for _, rule := range ruleSet {
+		allResults = appendResults(cfg, allResults, guard(rule.Name(), filename, func() []rules.Result {
+			return rule.Check(doc)
+		}))
+	}
+
+	return allResults, nil
+}
+
+// fullDocumentRules check parts of a page that htmx partial responses
+// never contain, and are skipped for files matching PartialPatterns.
+var fullDocumentRules = map[string]bool{
+	rules.RuleRequireLang:    true,
+	rules.RuleDoctypeHTML:    true,
+	rules.RuleMissingDoctype: true,
+	rules.RuleNoQuirksMode:   true,
+	rules.RuleEmptyTitle:     true,
+	rules.RuleLongTitle:      true,
+}
+
+// minifiedStyleRules check formatting or optional markup that minifiers
+// remove on purpose. They are skipped for minified files when the
+// minified-file rule sets skip-style-rules.
+var minifiedStyleRules = map[string]bool{
+	rules.RulePreformattedIndent:     true,
+	rules.RulePreferTbody:            true,
+	rules.RuleNoImplicitInputType:    true,
+	rules.RuleTemplateWhitespaceTrim: true,
+}
+
+// skipsStyleRules reports whether the minified-file rule in ruleSet asks
+// to skip minifiedStyleRules for content.
+func skipsStyleRules(ruleSet []rules.Rule, content []byte) bool {
+	for _, rule := range ruleSet {
+		if m, ok := rule.(*rules.MinifiedFile); ok {
+			return m.SkipStyleRules && m.Minified(content)
 		}
+	}
+	return false
+}
 
-		results := rule.Check(doc)
-		for _, r := range results {
-			// Apply severity override from config
-			if severity, ok := l.config.RuleSeverity[r.Rule]; ok {
-				r.Severity = severity
+// variantChecker runs the rules over the branch variants of a file,
+// reporting a finding shared by several variants once.
+type variantChecker struct {
+	ruleSet []rules.Rule
+	seen    map[variantFinding]bool
+	results []rules.Result
+}
+
+type variantFinding struct {
+	rule, message string
+	line, col     int
+}
+
+func newVariantChecker(ruleSet []rules.Rule) *variantChecker {
+	return &variantChecker{ruleSet: ruleSet, seen: make(map[variantFinding]bool)}
+}
+
+// check runs the rules over one variant.
+func (v *variantChecker) check(doc *parser.Document) {
+	for _, rule := range v.ruleSet {
+		for _, r := range guard(rule.Name(), doc.Filename, func() []rules.Result { return rule.Check(doc) }) {
+			key := variantFinding{r.Rule, r.Message, r.Line, r.Col}
+			if !v.seen[key] {
+				v.seen[key] = true
+				v.results = append(v.results, r)
 			}
-			// Filter by minimum severity
-			if r.Severity <= l.config.MinSeverity {
-				allResults = append(allResults, r)
+		}
+	}
+}
+
+// guard runs one rule check, turning a panic into an error finding for that
+// rule so a rule bug on unusual input cannot abort the whole run.
+func guard(rule, filename string, check func() []rules.Result) (results []rules.Result) {
+	defer func() {
+		if r := recover(); r != nil {
+			params := map[string]any{"error": fmt.Sprint(r)}
+			results = []rules.Result{{
+				Rule:      rule,
+				Message:   catalogMessage("internal-error", params),
+				MessageID: "internal-error",
+				Params:    params,
+				Filename:  filename,
+				Line:      1,
+				Col:       1,
+				Severity:  rules.Error,
+			}}
+		}
+	}()
+	return check()
+}
+
+// appendResults applies rule scopes, configured severity overrides,
+// new-file escalation, and the minimum severity filter, appending the
+// surviving results to dst in the configured locale.
+func appendResults(cfg *Config, dst, results []rules.Result) []rules.Result {
+	for _, r := range results {
+		if scope, ok := cfg.RuleScopes[r.Rule]; ok && !scope.Matches(cfg.ScopePath(r.Filename)) {
+			continue
+		}
+		if severity, ok := cfg.RuleSeverity[r.Rule]; ok {
+			r.Severity = severity
+		}
+		r.Severity = cfg.escalate(r)
+		if r.Severity > cfg.MinSeverity {
+			continue
+		}
+		if r.MessageID != "" && cfg.Locale != "" {
+			if msg, ok := messages.Render(cfg.Locale, r.MessageID, r.Params); ok {
+				r.Message = msg
 			}
 		}
+		dst = append(dst, r)
 	}
+	return dst
+}
 
-	return allResults, nil
+// catalogMessage renders catalog message id in the default locale;
+// appendResults re-renders it for Config.Locale.
+func catalogMessage(id string, params map[string]any) string {
+	msg, _ := messages.Render(messages.DefaultLocale, id, params)
+	return msg
 }
 
-// LintFiles checks multiple files and returns all violations.
+// LintFiles checks multiple files and returns all violations. Rules
+// implementing rules.ProjectRule then check the linted files together.
 func (l *Linter) LintFiles(paths []string) ([]rules.Result, error) {
 	var allResults []rules.Result
 
+	var projectRules []rules.ProjectRule
+	for _, rule := range l.rules {
+		if pr, ok := rule.(rules.ProjectRule); ok {
+			projectRules = append(projectRules, pr)
+		}
+	}
+	var projectFiles []rules.SourceFile
+	budget, retained := l.config.projectBudget(), int64(0)
+
 	for _, path := range paths {
 		// Skip ignored patterns
 		if l.shouldIgnore(path) {
 			continue
 		}
+		l.files++
 
 		results, err := l.LintFile(path)
 		if err != nil {
 			// Report error but continue with other files
-			allResults = append(allResults, rules.Result{
-				Rule:     "parse-error",
-				Message:  err.Error(),
-				Filename: path,
-				Line:     1,
-				Col:      1,
-				Severity: rules.Error,
-			})
+			allResults = appendResults(l.config, allResults, []rules.Result{parseErrorResult(path, err)})
 			continue
 		}
 		allResults = append(allResults, results...)
+
+		if len(projectRules) > 0 && !l.streams(path) {
+			content, err := os.ReadFile(path) //nolint:gosec // user-specified file path is intentional
+			if err == nil && (l.config.Generated.Include || !l.config.Generated.IsGenerated(content)) {
+				content, _ = parser.Decode(content)
+				f := rules.SourceFile{Filename: path, Content: content}
+				if budget > 0 && retained+int64(len(content)) > budget {
+					f = f.Compact()
+				} else {
+					retained += int64(len(content))
+				}
+				projectFiles = append(projectFiles, f)
+			}
+		}
+	}
+
+	for _, rule := range projectRules {
+		allResults = appendResults(l.config, allResults, guard(rule.Name(), "", func() []rules.Result {
+			return rule.CheckProject(projectFiles)
+		}))
 	}
 
 	return allResults, nil
 }
 
+// parseErrorResult reports a file that could not be linted, at the
+// position of a parser.ParseError when it has one.
+func parseErrorResult(path string, err error) rules.Result {
+	r := rules.Result{
+		Rule:      "parse-error",
+		MessageID: "parse-error",
+		Filename:  path,
+		Line:      1,
+		Col:       1,
+		Severity:  rules.Error,
+	}
+	var perr *parser.ParseError
+	if errors.As(err, &perr) {
+		err = perr.Err
+		if perr.Line > 0 {
+			r.Line, r.Col = perr.Line, perr.Col
+		}
+	}
+	r.Params = map[string]any{"error": err.Error()}
+	r.Message = catalogMessage(r.MessageID, r.Params)
+	return r
+}
+
 // LintDir recursively checks all HTML files in a directory.
 func (l *Linter) LintDir(dir string) ([]rules.Result, error) {
 	var files []string
@@ -158,6 +520,8 @@ func (l *Linter) LintDir(dir string) ([]rules.Result, error) {
 // Run executes linting and reports results.
 func (l *Linter) Run(paths []string) (int, error) {
 	var allResults []rules.Result
+	start := time.Now()
+	l.files = 0
 
 	for _, path := range paths {
 		info, err := os.Stat(path)
@@ -177,15 +541,24 @@ func (l *Linter) Run(paths []string) (int, error) {
 		allResults = append(allResults, results...)
 	}
 
-	if l.reporter != nil {
-		if err := l.reporter.Report(allResults); err != nil {
+	l.config.reportPaths(allResults)
+	return report(l.reporter, applyMiddleware(l.middleware, allResults), l.files, time.Since(start))
+}
+
+// report passes results to rep, if set, and returns the number of errors.
+func report(rep Reporter, results []rules.Result, files int, elapsed time.Duration) (int, error) {
+	if sr, ok := rep.(StatsReporter); ok {
+		sr.ReportStats(files, elapsed)
+	}
+	if rep != nil {
+		if err := rep.Report(results); err != nil {
 			return 0, err
 		}
 	}
 
 	// Count errors (not warnings)
 	errorCount := 0
-	for _, r := range allResults {
+	for _, r := range results {
 		if r.Severity == rules.Error {
 			errorCount++
 		}
@@ -194,6 +567,53 @@ func (l *Linter) Run(paths []string) (int, error) {
 	return errorCount, nil
 }
 
+// parseMode selects document or fragment parsing for a file.
+// An in-file directive takes precedence over configured globs,
+// which take precedence over content detection.
+func (l *Linter) parseMode(path string, content []byte) parser.Mode {
+	if mode, ok := parser.ModeDirective(content); ok {
+		return mode
+	}
+	for _, pattern := range l.config.DocumentPatterns {
+		if matchIgnorePattern(path, pattern) {
+			return parser.ModeDocument
+		}
+	}
+	for _, pattern := range l.config.FragmentPatterns {
+		if matchIgnorePattern(path, pattern) {
+			return parser.ModeFragment
+		}
+	}
+	if l.isPartial(path) {
+		return parser.ModeFragment
+	}
+	return parser.ModeAuto
+}
+
+// isPartial reports whether path matches a configured partial pattern.
+func (l *Linter) isPartial(path string) bool {
+	for _, pattern := range l.config.PartialPatterns {
+		if matchIgnorePattern(path, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// pageType returns the name of the first configured page type matching
+// path, or "".
+func (l *Linter) pageType(path string) string {
+	scoped := l.config.ScopePath(path)
+	for _, t := range l.config.PageTypes {
+		for _, pattern := range t.Files {
+			if matchIgnorePattern(scoped, pattern) {
+				return t.Name
+			}
+		}
+	}
+	return ""
+}
+
 func (l *Linter) shouldIgnore(path string) bool {
 	for _, pattern := range l.config.IgnorePatterns {
 		if matchIgnorePattern(path, pattern) {
@@ -269,5 +689,6 @@ func matchDoublestar(path, pattern string) bool {
 
 func isHTMLFile(path string) bool {
 	ext := strings.ToLower(filepath.Ext(path))
-	return ext == ".html" || ext == ".htm" || ext == ".gohtml" || ext == ".tmpl"
+	return ext == ".html" || ext == ".htm" || ext == ".gohtml" || ext == ".tmpl" || ext == ".templ" ||
+		slices.Contains(parser.Extensions(), ext)
 }
@@ -160,6 +160,81 @@ func TestLintContent_HeadingLevel(t *testing.T) {
 	}
 }
 
+func TestLintContent_HeadingAnchor(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		optIn    bool
+		options  map[string]any
+		wantRule string
+	}{
+		{
+			name: "disabled by default",
+			html: `<main><h2>Setup</h2></main>`,
+		},
+		{
+			name:     "heading in main without id",
+			html:     `<main><h2>Setup</h2></main>`,
+			optIn:    true,
+			wantRule: rules.RuleHeadingAnchor,
+		},
+		{
+			name:  "heading outside article and main",
+			html:  `<header><h1>Site</h1></header>`,
+			optIn: true,
+		},
+		{
+			name:  "slug id",
+			html:  `<article><h2 id="getting-started">Getting started</h2></article>`,
+			optIn: true,
+		},
+		{
+			name:     "id not a slug",
+			html:     `<article><h2 id="Getting_Started">Getting started</h2></article>`,
+			optIn:    true,
+			wantRule: rules.RuleHeadingAnchor,
+		},
+		{
+			name:  "template id",
+			html:  `<article><h2 id="{{.Slug}}">{{.Title}}</h2></article>`,
+			optIn: true,
+		},
+		{
+			name:     "duplicate heading id",
+			html:     `<main><h2 id="usage">Usage</h2><h3 id="usage">Usage</h3></main>`,
+			optIn:    true,
+			wantRule: rules.RuleHeadingAnchor,
+		},
+		{
+			name:     "heading id used by another element",
+			html:     `<div id="faq"></div><main><h2 id="faq">FAQ</h2></main>`,
+			optIn:    true,
+			wantRule: rules.RuleHeadingAnchor,
+		},
+		{
+			name:    "custom slug pattern",
+			html:    `<main><h2 id="Getting_Started">Getting started</h2></main>`,
+			optIn:   true,
+			options: map[string]any{"slug-pattern": `^[A-Za-z_]+$`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			if tt.optIn {
+				cfg.EnabledRules = []string{rules.RuleHeadingAnchor}
+			}
+			cfg.RuleOptions = map[string]map[string]any{rules.RuleHeadingAnchor: tt.options}
+			results, err := linter.New(cfg).LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleHeadingAnchor, tt.wantRule)
+		})
+	}
+}
+
 func TestLintContent_ButtonType(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -725,3 +800,543 @@ func TestLintContent_WcagH63(t *testing.T) {
 		})
 	}
 }
+
+func TestLintContent_FallbackContent(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		wantRule string
+	}{
+		{
+			name:     "empty canvas",
+			html:     `<canvas id="chart"></canvas>`,
+			wantRule: rules.RuleFallbackContent,
+		},
+		{
+			name: "canvas with fallback text",
+			html: `<canvas id="chart"><p>Sales rose 10% in March.</p></canvas>`,
+		},
+		{
+			name: "canvas with aria-label",
+			html: `<canvas aria-label="Sales by month"></canvas>`,
+		},
+		{
+			name: "decorative canvas",
+			html: `<canvas aria-hidden="true"></canvas>`,
+		},
+		{
+			name:     "object with only params",
+			html:     `<object data="movie.swf"><param name="quality" value="high"></object>`,
+			wantRule: rules.RuleFallbackContent,
+		},
+		{
+			name: "object with fallback image",
+			html: `<object data="chart.svg"><img src="chart.png" alt="Sales chart"></object>`,
+		},
+		{
+			name:     "embed without name",
+			html:     `<embed src="intro.mp4">`,
+			wantRule: rules.RuleFallbackContent,
+		},
+		{
+			name: "embed with title",
+			html: `<embed src="intro.mp4" title="Product introduction">`,
+		},
+		{
+			name: "embed as object fallback",
+			html: `<object data="doc.pdf"><embed src="doc.pdf"><a href="doc.pdf">Download the PDF</a></object>`,
+		},
+	}
+
+	l := linter.New(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := l.LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleFallbackContent, tt.wantRule)
+		})
+	}
+}
+
+func TestLintContent_LinkPurpose(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		options  map[string]any
+		wantRule string
+	}{
+		{
+			name: "descriptive links",
+			html: `<a href="/pricing">See pricing</a> <a href="/docs">Documentation</a>`,
+		},
+		{
+			name:     "hash href",
+			html:     `<a href="#" onclick="toggle()">Menu</a>`,
+			wantRule: rules.RuleLinkPurpose,
+		},
+		{
+			name:     "empty href",
+			html:     `<a href="">Menu</a>`,
+			wantRule: rules.RuleLinkPurpose,
+		},
+		{
+			name: "fragment link",
+			html: `<a href="#main">Skip to content</a>`,
+		},
+		{
+			name:     "click here",
+			html:     `<p>To download the report, <a href="/report.pdf">click here</a>.</p>`,
+			wantRule: rules.RuleLinkPurpose,
+		},
+		{
+			name:     "read more with arrow",
+			html:     `<a href="/posts/1">Read more »</a>`,
+			wantRule: rules.RuleLinkPurpose,
+		},
+		{
+			name: "read more with aria-label",
+			html: `<a href="/posts/1" aria-label="Read more about the spring release">Read more</a>`,
+		},
+		{
+			name:     "custom generic text",
+			html:     `<a href="/posts/1">Weiterlesen</a>`,
+			options:  map[string]any{"generic-text": []any{"weiterlesen"}},
+			wantRule: rules.RuleLinkPurpose,
+		},
+		{
+			name:    "custom list replaces defaults",
+			html:    `<a href="/posts/1">Read more</a>`,
+			options: map[string]any{"generic-text": []any{"weiterlesen"}},
+		},
+		{
+			name:     "same text different URLs",
+			html:     `<a href="/a/report">Annual report</a> <a href="/b/report">Annual report</a>`,
+			wantRule: rules.RuleLinkPurpose,
+		},
+		{
+			name: "same text same URL",
+			html: `<a href="/report">Annual report</a> <a href="/report">Annual report</a>`,
+		},
+		{
+			name: "template href",
+			html: `<a href="{{.URL}}">Annual report</a> <a href="/report">Annual report</a>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			cfg.RuleOptions = map[string]map[string]any{rules.RuleLinkPurpose: tt.options}
+			results, err := linter.New(cfg).LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleLinkPurpose, tt.wantRule)
+		})
+	}
+}
+
+func TestLintContent_NavSemantics(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		wantRule string
+	}{
+		{
+			name: "valid breadcrumb",
+			html: `<nav aria-label="Breadcrumb"><ol><li><a href="/">Home</a></li><li><a href="/docs" aria-current="page">Docs</a></li></ol></nav>`,
+		},
+		{
+			name: "breadcrumb class on list inside labelled nav",
+			html: `<nav aria-label="You are here"><ol class="breadcrumb"><li><a href="/">Home</a></li><li aria-current="page">Docs</li></ol></nav>`,
+		},
+		{
+			name:     "breadcrumb without nav",
+			html:     `<div class="breadcrumbs"><ol><li><a href="/">Home</a></li><li aria-current="page">Docs</li></ol></div>`,
+			wantRule: rules.RuleNavSemantics,
+		},
+		{
+			name:     "breadcrumb nav without label",
+			html:     `<nav class="breadcrumb"><ol><li><a href="/">Home</a></li><li aria-current="page">Docs</li></ol></nav>`,
+			wantRule: rules.RuleNavSemantics,
+		},
+		{
+			name:     "breadcrumb unordered list",
+			html:     `<nav aria-label="Breadcrumb"><ul><li><a href="/">Home</a></li><li aria-current="page">Docs</li></ul></nav>`,
+			wantRule: rules.RuleNavSemantics,
+		},
+		{
+			name:     "breadcrumb last item not current",
+			html:     `<nav aria-label="Breadcrumb"><ol><li><a href="/">Home</a></li><li><a href="/docs">Docs</a></li></ol></nav>`,
+			wantRule: rules.RuleNavSemantics,
+		},
+		{
+			name:     "breadcrumb current on earlier item",
+			html:     `<nav aria-label="Breadcrumb"><ol><li aria-current="page"><a href="/">Home</a></li><li>Docs</li></ol></nav>`,
+			wantRule: rules.RuleNavSemantics,
+		},
+		{
+			name: "valid pagination",
+			html: `<nav aria-label="Pagination"><ul><li><a href="?page=1">1</a></li><li><a href="?page=2" aria-current="page">2</a></li></ul></nav>`,
+		},
+		{
+			name:     "pagination without current page",
+			html:     `<nav class="pagination" aria-label="Results pages"><a href="?page=1">1</a> <a href="?page=2">2</a></nav>`,
+			wantRule: rules.RuleNavSemantics,
+		},
+		{
+			name:     "pagination without nav",
+			html:     `<ul class="pager"><li><a href="?page=1" aria-current="page">1</a></li></ul>`,
+			wantRule: rules.RuleNavSemantics,
+		},
+		{
+			name: "template aria-current",
+			html: `<nav aria-label="Pagination">{{range .Pages}}<a href="{{.URL}}" aria-current="{{.Current}}">{{.N}}</a>{{end}}</nav>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			cfg.EnabledRules = []string{rules.RuleNavSemantics}
+			results, err := linter.New(cfg).LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleNavSemantics, tt.wantRule)
+		})
+	}
+}
+
+func TestLintContent_CompositeWidget(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		wantRule string
+	}{
+		{
+			name: "tablist with roving tabindex",
+			html: `<div role="tablist"><button role="tab" aria-selected="true">One</button><button role="tab" tabindex="-1">Two</button></div>`,
+		},
+		{
+			name:     "tablist with every tab tabbable",
+			html:     `<div role="tablist"><button role="tab">One</button><button role="tab">Two</button></div>`,
+			wantRule: rules.RuleCompositeWidget,
+		},
+		{
+			name:     "tablist without tabs",
+			html:     `<div role="tablist"><button>One</button></div>`,
+			wantRule: rules.RuleCompositeWidget,
+		},
+		{
+			name: "listbox with aria-activedescendant",
+			html: `<ul role="listbox" tabindex="0" aria-activedescendant="o1"><li role="option" id="o1">One</li><li role="option">Two</li></ul>`,
+		},
+		{
+			name:     "menu of links",
+			html:     `<ul role="menu"><li role="none"><a role="menuitem" href="/a">A</a></li><li role="none"><a role="menuitem" href="/b">B</a></li></ul>`,
+			wantRule: rules.RuleCompositeWidget,
+		},
+		{
+			name: "radiogroup of native radios",
+			html: `<div role="radiogroup" aria-label="Size"><label><input type="radio" name="s"> S</label><label><input type="radio" name="s"> M</label></div>`,
+		},
+		{
+			name: "grid with implicit rows",
+			html: `<table role="grid"><tr><td tabindex="0">1</td><td tabindex="-1">2</td></tr></table>`,
+		},
+		{
+			name: "aria-owns",
+			html: `<div role="tree" aria-owns="items"></div>`,
+		},
+		{
+			name: "template tabindex",
+			html: `<div role="tablist">{{range .Tabs}}<button role="tab" tabindex="{{.TabIndex}}">{{.Name}}</button>{{end}}<button role="tab" tabindex="{{$.Extra}}">More</button></div>`,
+		},
+	}
+
+	l := linter.New(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := l.LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleCompositeWidget, tt.wantRule)
+		})
+	}
+}
+
+func TestLintContent_AriaRelationship(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		wantRule string
+	}{
+		{
+			name: "tab controls tabpanel",
+			html: `<div role="tablist"><button role="tab" id="t1" aria-controls="p1">One</button></div><div role="tabpanel" id="p1" aria-labelledby="t1">One</div>`,
+		},
+		{
+			name:     "tab controls plain div",
+			html:     `<div role="tablist"><button role="tab" aria-controls="p1">One</button></div><div id="p1">One</div>`,
+			wantRule: rules.RuleAriaRelationship,
+		},
+		{
+			name: "button controls region",
+			html: `<button aria-expanded="false" aria-controls="menu">Menu</button><ul id="menu"><li>A</li></ul>`,
+		},
+		{
+			name:     "self reference",
+			html:     `<button id="b" aria-controls="b">Toggle</button>`,
+			wantRule: rules.RuleAriaRelationship,
+		},
+		{
+			name: "aria-owns single owner",
+			html: `<div role="tree" aria-owns="n1"></div><div role="treeitem" id="n1">Node</div>`,
+		},
+		{
+			name:     "aria-owns multiple owners",
+			html:     `<div role="list" aria-owns="i1"></div><div role="list" aria-owns="i1"></div><div role="listitem" id="i1">Item</div>`,
+			wantRule: rules.RuleAriaRelationship,
+		},
+		{
+			name:     "aria-owns ancestor",
+			html:     `<div id="outer"><div aria-owns="outer">Inner</div></div>`,
+			wantRule: rules.RuleAriaRelationship,
+		},
+		{
+			name: "template reference",
+			html: `<button role="tab" aria-controls="panel-{{.ID}}">One</button><div id="panel-1">One</div>`,
+		},
+	}
+
+	l := linter.New(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := l.LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleAriaRelationship, tt.wantRule)
+		})
+	}
+}
+
+func TestLintContent_MotionSafety(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		wantRule string
+		wantWCAG string
+	}{
+		{
+			name: "finite animation",
+			html: `<div style="animation: fade-in 300ms ease-out">Hi</div>`,
+		},
+		{
+			name:     "inline infinite animation",
+			html:     `<div style="animation: scroll-left 10s linear infinite">News</div>`,
+			wantRule: rules.RuleMotionSafety,
+			wantWCAG: "2.2.2",
+		},
+		{
+			name:     "style sheet infinite animation",
+			html:     `<style>.ticker { animation-name: slide; animation-iteration-count: infinite; }</style><p class="ticker">News</p>`,
+			wantRule: rules.RuleMotionSafety,
+			wantWCAG: "2.2.2",
+		},
+		{
+			name: "guarded style sheet",
+			html: `<style>.spinner { animation: spin 1s infinite; } @media (prefers-reduced-motion: reduce) { .spinner { animation: none; } }</style>`,
+		},
+		{
+			name:     "global smooth scrolling",
+			html:     `<style>/* page */ html { scroll-behavior: smooth; }</style>`,
+			wantRule: rules.RuleMotionSafety,
+			wantWCAG: "2.3.3",
+		},
+		{
+			name: "smooth scrolling in no-preference query",
+			html: `<style>@media (prefers-reduced-motion: no-preference) { :root { scroll-behavior: smooth } }</style>`,
+		},
+		{
+			name: "smooth scrolling on a container",
+			html: `<style>.carousel { scroll-behavior: smooth }</style>`,
+		},
+		{
+			name:     "smooth scrolling on body style attribute",
+			html:     `<!DOCTYPE html><html lang="en"><head><title>T</title></head><body style="scroll-behavior: smooth"><p>Hi</p></body></html>`,
+			wantRule: rules.RuleMotionSafety,
+			wantWCAG: "2.3.3",
+		},
+		{
+			name:     "autoplaying video",
+			html:     `<video autoplay muted loop src="hero.mp4"></video>`,
+			wantRule: rules.RuleMotionSafety,
+			wantWCAG: "2.2.2",
+		},
+		{
+			name: "autoplaying video with guard",
+			html: `<style>@media (prefers-reduced-motion: reduce) { .hero { display: none } }</style><video class="hero" autoplay muted src="hero.mp4"></video>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			cfg.EnabledRules = []string{rules.RuleMotionSafety}
+			results, err := linter.New(cfg).LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleMotionSafety, tt.wantRule)
+			for _, r := range results {
+				if r.Rule == rules.RuleMotionSafety && r.Meta[rules.MetaWCAG] != tt.wantWCAG {
+					t.Errorf("Meta[wcag] = %q, want %q", r.Meta[rules.MetaWCAG], tt.wantWCAG)
+				}
+			}
+		})
+	}
+}
+
+func TestLintContent_TargetSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		wantRule string
+	}{
+		{
+			name:     "small icon button",
+			html:     `<button type="button" aria-label="Close" style="width: 16px; height: 16px"><svg aria-hidden="true" focusable="false"></svg></button>`,
+			wantRule: rules.RuleTargetSize,
+		},
+		{
+			name: "button at minimum size",
+			html: `<button type="button" aria-label="Close" style="width:24px;height:24px">×</button>`,
+		},
+		{
+			name:     "short link in rem",
+			html:     `<a href="/next" style="display:inline-block; height: 1rem">Next</a>`,
+			wantRule: rules.RuleTargetSize,
+		},
+		{
+			name:     "image input attributes",
+			html:     `<input type="image" src="go.png" alt="Go" width="20" height="20">`,
+			wantRule: rules.RuleTargetSize,
+		},
+		{
+			name: "min-height overrides height",
+			html: `<button type="button" style="height: 16px; min-height: 44px">Save</button>`,
+		},
+		{
+			name: "width only from image child",
+			html: `<a href="/"><img src="logo.png" alt="Home" width="16" height="16"></a>`,
+		},
+		{
+			name: "percentage width",
+			html: `<button type="button" style="width: 10%">Save</button>`,
+		},
+		{
+			name: "template width",
+			html: `<button type="button" style="width: {{.Size}}px">Save</button>`,
+		},
+		{
+			name: "disabled button",
+			html: `<button type="button" disabled style="width:16px;height:16px">×</button>`,
+		},
+		{
+			name:     "role button",
+			html:     `<span role="button" tabindex="0" aria-label="Remove" style="width:12px;height:12px"></span>`,
+			wantRule: rules.RuleTargetSize,
+		},
+		{
+			name: "non-interactive element",
+			html: `<span style="width:12px;height:12px"></span>`,
+		},
+	}
+
+	l := linter.New(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := l.LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleTargetSize, tt.wantRule)
+			for _, r := range results {
+				if r.Rule == rules.RuleTargetSize && r.Meta[rules.MetaWCAG] != "2.5.8" {
+					t.Errorf("Meta[wcag] = %q, want %q", r.Meta[rules.MetaWCAG], "2.5.8")
+				}
+			}
+		})
+	}
+}
+
+func TestLintContent_TextResize(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		wantRule string
+	}{
+		{
+			name:     "maximum-scale below 2",
+			html:     `<meta name="viewport" content="width=device-width, initial-scale=1, maximum-scale=1">`,
+			wantRule: rules.RuleTextResize,
+		},
+		{
+			name:     "user-scalable no",
+			html:     `<meta name="viewport" content="width=device-width, user-scalable=no">`,
+			wantRule: rules.RuleTextResize,
+		},
+		{
+			name: "zoomable viewport",
+			html: `<meta name="viewport" content="width=device-width, initial-scale=1, maximum-scale=5">`,
+		},
+		{
+			name:     "fixed height with hidden overflow",
+			html:     `<div style="height: 40px; overflow: hidden">Terms and conditions apply.</div>`,
+			wantRule: rules.RuleTextResize,
+		},
+		{
+			name:     "max-height in points with clipped y overflow",
+			html:     `<p style="max-height:30pt;overflow:visible clip">Long description</p>`,
+			wantRule: rules.RuleTextResize,
+		},
+		{
+			name: "height in em scales with text",
+			html: `<div style="height: 3em; overflow: hidden">Terms</div>`,
+		},
+		{
+			name: "visible overflow",
+			html: `<div style="height: 40px">Terms</div>`,
+		},
+		{
+			name: "no text",
+			html: `<div style="height: 40px; overflow: hidden"><img src="a.png" alt=""></div>`,
+		},
+		{
+			name: "hidden overflow overridden",
+			html: `<div style="height: 40px; overflow: hidden; overflow: auto">Terms</div>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			cfg.EnabledRules = []string{rules.RuleTextResize}
+			results, err := linter.New(cfg).LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleTextResize, tt.wantRule)
+			for _, r := range results {
+				if r.Rule == rules.RuleTextResize && r.Meta[rules.MetaWCAG] != "1.4.4" {
+					t.Errorf("Meta[wcag] = %q, want %q", r.Meta[rules.MetaWCAG], "1.4.4")
+				}
+			}
+		})
+	}
+}
@@ -173,6 +173,33 @@ func TestLintContent_NoConditionalComment(t *testing.T) {
 			html:     `<!--[if lt IE 9]><script src="html5shiv.js"></script><![endif]-->`,
 			wantRule: rules.RuleNoConditionalComment,
 		},
+		{
+			name:     "downlevel-revealed conditional",
+			html:     `<![if !IE]><p>Not IE</p><![endif]>`,
+			wantRule: rules.RuleNoConditionalComment,
+		},
+		{
+			name:     "X-UA-Compatible meta",
+			html:     `<meta http-equiv="X-UA-Compatible" content="IE=edge">`,
+			wantRule: rules.RuleNoConditionalComment,
+		},
+		{
+			name: "other http-equiv meta",
+			html: `<meta http-equiv="refresh" content="30">`,
+		},
+		{
+			name:     "document.all sniff",
+			html:     `<script>if (document.all) { legacy(); }</script>`,
+			wantRule: rules.RuleNoConditionalComment,
+		},
+		{
+			name: "document.allowed is not a sniff",
+			html: `<script>if (document.allowed) { run(); }</script>`,
+		},
+		{
+			name: "bracketed comment text",
+			html: `<!-- [note] -->`,
+		},
 	}
 
 	l := linter.New(nil)
@@ -187,6 +214,143 @@ func TestLintContent_NoConditionalComment(t *testing.T) {
 	}
 }
 
+func TestLintContent_ConditionalComments(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		mode     linter.ConditionalMode
+		branches bool
+		want     int // img-alt findings
+		line     int
+	}{
+		{
+			name: "skip leaves downlevel-hidden markup unchecked",
+			html: "<p>\n<!--[if lt IE 9]><img src=\"a.png\"><![endif]-->\n</p>",
+			want: 0,
+		},
+		{
+			name: "branch checks downlevel-hidden markup",
+			html: "<p>\n<!--[if lt IE 9]><img src=\"a.png\"><![endif]-->\n</p>",
+			mode: linter.ConditionalBranch,
+			want: 1,
+			line: 2,
+		},
+		{
+			name:     "branch checks downlevel-hidden markup with template branches",
+			html:     "<p>\n<!--[if lt IE 9]><img src=\"a.png\"><![endif]-->\n</p>",
+			mode:     linter.ConditionalBranch,
+			branches: true,
+			want:     1,
+			line:     2,
+		},
+		{
+			name:     "downlevel-revealed markup is reported once",
+			html:     `<![if !IE]><img src="a.png"><![endif]>`,
+			mode:     linter.ConditionalBranch,
+			branches: true,
+			want:     1,
+			line:     1,
+		},
+		{
+			name: "markup hidden from IE is still checked",
+			html: `<!--[if !IE]><!--><img src="a.png"><!--<![endif]-->`,
+			mode: linter.ConditionalBranch,
+			want: 1,
+			line: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			cfg.ConditionalComments = tt.mode
+			cfg.TemplateBranches = tt.branches
+			results, err := linter.New(cfg).LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			var got []rules.Result
+			for _, r := range results {
+				if r.Rule == rules.RuleImgAlt {
+					got = append(got, r)
+				}
+			}
+			if len(got) != tt.want {
+				t.Fatalf("got %d img-alt findings, want %d: %v", len(got), tt.want, got)
+			}
+			// the variant keeps the positions of the original
+			if tt.want == 1 && got[0].Line != tt.line {
+				t.Errorf("img-alt on line %d, want %d", got[0].Line, tt.line)
+			}
+		})
+	}
+}
+
+func TestLintContent_NoXHTMLSyntax(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		wantRule string
+	}{
+		{
+			name: "html5 doctype",
+			html: `<!DOCTYPE html><html lang="en"><body></body></html>`,
+		},
+		{
+			name:     "xhtml doctype",
+			html:     `<!DOCTYPE html PUBLIC "-//W3C//DTD XHTML 1.0 Strict//EN" "http://www.w3.org/TR/xhtml1/DTD/xhtml1-strict.dtd"><html lang="en"></html>`,
+			wantRule: rules.RuleNoXHTMLSyntax,
+		},
+		{
+			name:     "html 4.01 doctype",
+			html:     `<!DOCTYPE HTML PUBLIC "-//W3C//DTD HTML 4.01//EN" "http://www.w3.org/TR/html4/strict.dtd"><html lang="en"></html>`,
+			wantRule: rules.RuleNoXHTMLSyntax,
+		},
+		{
+			name: "legacy-compat doctype",
+			html: `<!DOCTYPE html SYSTEM "about:legacy-compat"><html lang="en"></html>`,
+		},
+		{
+			name:     "xmlns on html",
+			html:     `<!DOCTYPE html><html xmlns="http://www.w3.org/1999/xhtml" lang="en"></html>`,
+			wantRule: rules.RuleNoXHTMLSyntax,
+		},
+		{
+			name:     "self-closing div",
+			html:     `<div class="spacer" /><p>Text</p>`,
+			wantRule: rules.RuleNoXHTMLSyntax,
+		},
+		{
+			name:     "self-closing span without space",
+			html:     `<span/>`,
+			wantRule: rules.RuleNoXHTMLSyntax,
+		},
+		{
+			name: "self-closing void elements",
+			html: `<br /><img src="a.png" alt="" /><input type="text" aria-label="x"/>`,
+		},
+		{
+			name: "self-closing svg content",
+			html: `<svg viewBox="0 0 10 10"><path d="M0 0"/><use href="#i" /></svg>`,
+		},
+		{
+			name: "self-closing mathml content",
+			html: `<math><mspace width="1em"/></math>`,
+		},
+	}
+
+	l := linter.New(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := l.LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleNoXHTMLSyntax, tt.wantRule)
+		})
+	}
+}
+
 func TestLintContent_NoRedundantFor(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,1529 @@
+package linter_test
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/toba/go-html-validate/linter"
+	"github.com/toba/go-html-validate/rules"
+)
+
+// writeFile writes content to name inside dir and returns the full path.
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLintFile_Generated(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		cfg      func(*linter.Config)
+		wantRule string
+	}{
+		{
+			name:     "regular file is linted",
+			content:  `<img src="a.png">`,
+			wantRule: rules.RuleImgAlt,
+		},
+		{
+			name:    "code generated marker skips file",
+			content: "<!-- Code generated by bundler. -->\n<img src=\"a.png\">",
+		},
+		{
+			name:    "do not edit marker skips file",
+			content: "<!--\n  DO NOT EDIT\n-->\n<img src=\"a.png\">",
+		},
+		{
+			name:     "marker beyond leading lines is ignored",
+			content:  "<div>\n</div>\n<p></p>\n<p></p>\n<p></p>\n<!-- DO NOT EDIT -->\n<img src=\"a.png\">",
+			wantRule: rules.RuleImgAlt,
+		},
+		{
+			name:     "include generated lints marked files",
+			content:  "<!-- Code generated. DO NOT EDIT. -->\n<img src=\"a.png\">",
+			cfg:      func(c *linter.Config) { c.Generated.Include = true },
+			wantRule: rules.RuleImgAlt,
+		},
+		{
+			name:    "custom marker",
+			content: "<!-- @generated -->\n<img src=\"a.png\">",
+			cfg:     func(c *linter.Config) { c.Generated.Markers = []string{"@generated"} },
+		},
+		{
+			name:     "custom markers replace defaults",
+			content:  "<!-- DO NOT EDIT -->\n<img src=\"a.png\">",
+			cfg:      func(c *linter.Config) { c.Generated.Markers = []string{"@generated"} },
+			wantRule: rules.RuleImgAlt,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			if tt.cfg != nil {
+				tt.cfg(cfg)
+			}
+			l := linter.New(cfg)
+
+			path := writeFile(t, t.TempDir(), "page.html", tt.content)
+			results, err := l.LintFile(path)
+			if err != nil {
+				t.Fatalf("LintFile() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleImgAlt, tt.wantRule)
+		})
+	}
+}
+
+func TestLintContent_ConfigDirective(t *testing.T) {
+	const inline = `<div style="color: red">Text</div>`
+	tests := []struct {
+		name     string
+		html     string
+		cfg      func(*linter.Config)
+		rule     string
+		wantRule string
+	}{
+		{
+			name:     "no directive",
+			html:     inline,
+			rule:     rules.RuleNoInlineStyle,
+			wantRule: rules.RuleNoInlineStyle,
+		},
+		{
+			name: "disable rule",
+			html: "<!-- htmlint-config: disable=no-inline-style -->\n" + inline,
+			rule: rules.RuleNoInlineStyle,
+		},
+		{
+			name: "disable multiple rules",
+			html: "<!-- htmlint-config: disable=img-alt,no-inline-style -->\n" + inline,
+			rule: rules.RuleNoInlineStyle,
+		},
+		{
+			name: "builtin email profile",
+			html: "<!-- htmlint-config: profile=email -->\n" + inline,
+			rule: rules.RuleNoInlineStyle,
+		},
+		{
+			name:     "enable rule disabled by config",
+			html:     "<!-- htmlint-config: enable=no-inline-style -->\n" + inline,
+			cfg:      func(c *linter.Config) { c.DisabledRules = []string{rules.RuleNoInlineStyle} },
+			rule:     rules.RuleNoInlineStyle,
+			wantRule: rules.RuleNoInlineStyle,
+		},
+		{
+			name:     "enable opt-in rule",
+			html:     "<!-- htmlint-config: enable=motion-safety -->\n" + `<div style="animation: spin 1s infinite">Text</div>`,
+			rule:     rules.RuleMotionSafety,
+			wantRule: rules.RuleMotionSafety,
+		},
+		{
+			name: "opt-in rule stays off without directive",
+			html: `<div style="animation: spin 1s infinite">Text</div>`,
+			rule: rules.RuleMotionSafety,
+		},
+		{
+			name: "configured profile",
+			html: "<!-- htmlint-config: profile=legacy -->\n" + inline,
+			cfg: func(c *linter.Config) {
+				c.Profiles = map[string]linter.Profile{
+					"legacy": {DisabledRules: []string{rules.RuleNoInlineStyle}},
+				}
+			},
+			rule: rules.RuleNoInlineStyle,
+		},
+		{
+			name: "directive after a byte order mark and doctype",
+			html: "\uFEFF<!DOCTYPE html>\n<!-- htmlint-config: disable=no-inline-style -->\n" + inline,
+			rule: rules.RuleNoInlineStyle,
+		},
+		{
+			name:     "directive later in the file is ignored",
+			html:     inline + "\n<script type=\"text/plain\"><!-- htmlint-config: disable=no-inline-style --></script>",
+			rule:     rules.RuleNoInlineStyle,
+			wantRule: rules.RuleNoInlineStyle,
+		},
+		{
+			name:     "comment before the directive",
+			html:     "<!-- header -->\n<!-- htmlint-config: disable=no-inline-style -->\n" + inline,
+			rule:     rules.RuleNoInlineStyle,
+			wantRule: rules.RuleNoInlineStyle,
+		},
+		{
+			name:     "unknown rule is reported",
+			html:     "<!-- htmlint-config: disable=no-such-rule -->\n" + inline,
+			rule:     linter.RuleConfigDirective,
+			wantRule: linter.RuleConfigDirective,
+		},
+		{
+			name:     "unknown profile is reported",
+			html:     "<!-- htmlint-config: profile=nope -->\n" + inline,
+			rule:     linter.RuleConfigDirective,
+			wantRule: linter.RuleConfigDirective,
+		},
+		{
+			name:     "unknown key is reported",
+			html:     "<!-- htmlint-config: silence=all -->\n" + inline,
+			rule:     linter.RuleConfigDirective,
+			wantRule: linter.RuleConfigDirective,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			if tt.cfg != nil {
+				tt.cfg(cfg)
+			}
+			l := linter.New(cfg)
+
+			results, err := l.LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, tt.rule, tt.wantRule)
+
+			// Directives must not leak into other files
+			if tt.cfg == nil {
+				results, err = l.LintContent("other.html", []byte(inline))
+				if err != nil {
+					t.Fatalf("LintContent() error = %v", err)
+				}
+				checkRule(t, results, rules.RuleNoInlineStyle, rules.RuleNoInlineStyle)
+			}
+		})
+	}
+}
+
+func TestConfig_ApplyProfile(t *testing.T) {
+	cfg := linter.DefaultConfig()
+	cfg.DisabledRules = []string{rules.RuleNoInlineStyle}
+	cfg.Profiles = map[string]linter.Profile{
+		"ci": {
+			DisabledRules: []string{rules.RulePreferAria},
+			RuleSeverity:  map[string]rules.Severity{rules.RuleNoInlineStyle: rules.Error},
+		},
+	}
+
+	if cfg.ApplyProfile("nope") {
+		t.Error("ApplyProfile(nope) = true, want false")
+	}
+	if !cfg.ApplyProfile("ci") {
+		t.Fatal("ApplyProfile(ci) = false, want true")
+	}
+	if !slices.Equal(cfg.DisabledRules, []string{rules.RulePreferAria}) {
+		t.Errorf("DisabledRules = %v, want [%s]", cfg.DisabledRules, rules.RulePreferAria)
+	}
+
+	results, err := linter.New(cfg).LintContent("test.html", []byte(`<div style="color: red">Text</div>`))
+	if err != nil {
+		t.Fatalf("LintContent() error = %v", err)
+	}
+	checkRule(t, results, rules.RuleNoInlineStyle, rules.RuleNoInlineStyle)
+	for _, r := range results {
+		if r.Rule == rules.RuleNoInlineStyle && r.Severity != rules.Error {
+			t.Errorf("severity = %v, want error", r.Severity)
+		}
+	}
+}
+
+func TestApplyFixes(t *testing.T) {
+	content := []byte("a & b &x; c")
+	results := []rules.Result{
+		{Rule: "second", Fix: &rules.Fix{Start: 6, End: 7, Text: "&amp;"}},
+		{Rule: "no-fix"},
+		{Rule: "first", Fix: &rules.Fix{Start: 2, End: 3, Text: "&amp;"}},
+		{Rule: "overlap", Fix: &rules.Fix{Start: 2, End: 5, Text: "and"}},
+	}
+
+	fixed, remaining := linter.ApplyFixes(content, results)
+	if want := "a &amp; b &amp;x; c"; string(fixed) != want {
+		t.Errorf("fixed = %q, want %q", fixed, want)
+	}
+	var names []string
+	for _, r := range remaining {
+		names = append(names, r.Rule)
+	}
+	if want := []string{"no-fix", "overlap"}; !slices.Equal(names, want) {
+		t.Errorf("remaining = %v, want %v", names, want)
+	}
+}
+
+func TestLintFile_Fix(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "page.html", "<p>Fish &chips;</p>\n<img src=\"a.png\">")
+
+	cfg := linter.DefaultConfig()
+	cfg.Fix = true
+	results, err := linter.New(cfg).LintFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "<p>Fish &amp;chips;</p>\n<img src=\"a.png\">"; string(got) != want {
+		t.Errorf("file = %q, want %q", got, want)
+	}
+	checkRule(t, results, rules.RuleUnrecognizedCharRef, "")
+	checkRule(t, results, rules.RuleImgAlt, rules.RuleImgAlt)
+}
+
+func TestLintFile_FixDiff(t *testing.T) {
+	dir := t.TempDir()
+	original := "<p>Fish &chips;</p>\n<img src=\"a.png\">"
+	path := writeFile(t, dir, "page.html", original)
+
+	var diff strings.Builder
+	cfg := linter.DefaultConfig()
+	cfg.Fix = true
+	cfg.FixDiff = &diff
+	results, err := linter.New(cfg).LintFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != original {
+		t.Errorf("file = %q, want it unchanged", got)
+	}
+	name := strings.TrimPrefix(filepath.ToSlash(path), "/")
+	want := "--- a/" + name + "\n+++ b/" + name + "\n" +
+		"@@ -1,2 +1,2 @@\n" +
+		"-<p>Fish &chips;</p>\n" +
+		"+<p>Fish &amp;chips;</p>\n" +
+		" <img src=\"a.png\">\n\\ No newline at end of file\n"
+	if diff.String() != want {
+		t.Errorf("diff =\n%s\nwant\n%s", diff.String(), want)
+	}
+	// nothing was fixed, so the fixable finding is still reported
+	checkRule(t, results, rules.RuleUnrecognizedCharRef, rules.RuleUnrecognizedCharRef)
+	checkRule(t, results, rules.RuleImgAlt, rules.RuleImgAlt)
+}
+
+func TestLintFile_FixDiffRelativePath(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := writeFile(t, filepath.Join(dir, "web"), "page.html", "<p>Fish &chips;</p>\n")
+	t.Chdir(dir)
+	if !filepath.IsAbs(path) {
+		t.Fatalf("path %q is not absolute", path)
+	}
+
+	var diff strings.Builder
+	cfg := linter.DefaultConfig()
+	cfg.Fix = true
+	cfg.FixDiff = &diff
+	if _, err := linter.New(cfg).LintFile(path); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "--- a/web/page.html\n+++ b/web/page.html\n"; !strings.HasPrefix(diff.String(), want) {
+		t.Errorf("diff =\n%s\nwant headers\n%s", diff.String(), want)
+	}
+}
+
+func TestLintFile_FixFilter(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "page.html", "<p>&a; &b; &c;</p>")
+
+	var asked []int
+	cfg := linter.DefaultConfig()
+	cfg.Fix = true
+	cfg.FixFilter = func(_ string, _ []byte, r rules.Result) bool {
+		asked = append(asked, r.Col)
+		return r.Col != 8
+	}
+	results, err := linter.New(cfg).LintFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "<p>&amp;a; &b; &amp;c;</p>"; string(got) != want {
+		t.Errorf("file = %q, want %q", got, want)
+	}
+	if want := []int{4, 8, 12}; !slices.Equal(asked, want) {
+		t.Errorf("asked about columns %v, want %v", asked, want)
+	}
+	if len(results) != 1 || results[0].Col != 8 {
+		t.Errorf("results = %v, want the declined fix at column 8", results)
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	lines := func(from, to int) string {
+		var b strings.Builder
+		for i := from; i <= to; i++ {
+			fmt.Fprintf(&b, "line %d\n", i)
+		}
+		return b.String()
+	}
+
+	tests := []struct {
+		name          string
+		before, after string
+		want          string
+	}{
+		{
+			name:   "equal",
+			before: "a\n",
+			after:  "a\n",
+			want:   "",
+		},
+		{
+			name:   "separate hunks",
+			before: lines(1, 20),
+			after:  strings.Replace(strings.Replace(lines(1, 20), "line 2\n", "LINE 2\n", 1), "line 18\n", "", 1),
+			want: "--- a/page.html\n+++ b/page.html\n" +
+				"@@ -1,5 +1,5 @@\n line 1\n-line 2\n+LINE 2\n line 3\n line 4\n line 5\n" +
+				"@@ -15,6 +15,5 @@\n line 15\n line 16\n line 17\n-line 18\n line 19\n line 20\n",
+		},
+		{
+			name:   "merged hunk",
+			before: lines(1, 8),
+			after:  strings.Replace(lines(1, 8), "line 7\n", "line 7\nline 7b\n", 1)[len("line 1\n"):],
+			want: "--- a/page.html\n+++ b/page.html\n" +
+				"@@ -1,8 +1,8 @@\n-line 1\n line 2\n line 3\n line 4\n line 5\n line 6\n line 7\n+line 7b\n line 8\n",
+		},
+		{
+			name:   "into empty file",
+			before: "",
+			after:  "a\n",
+			want:   "--- a/page.html\n+++ b/page.html\n@@ -0,0 +1 @@\n+a\n",
+		},
+		{
+			name:   "final newline",
+			before: "a",
+			after:  "a\n",
+			want:   "--- a/page.html\n+++ b/page.html\n@@ -1 +1 @@\n-a\n\\ No newline at end of file\n+a\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(linter.UnifiedDiff("./page.html", []byte(tt.before), []byte(tt.after)))
+			if got != tt.want {
+				t.Errorf("UnifiedDiff() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLintFiles_RuleScopes(t *testing.T) {
+	dir := t.TempDir()
+	email := writeFile(t, dir, "emails/welcome.html", `<p style="color: red">Hi</p>`)
+	page := writeFile(t, dir, "pages/index.html", `<p style="color: red">Hi</p>`)
+
+	cfg := linter.DefaultConfig()
+	cfg.ConfigPath = filepath.Join(dir, ".htmlvalidate.json")
+	cfg.RuleScopes = map[string]linter.RuleScope{
+		rules.RuleNoInlineStyle: {Exclude: []string{"emails/**"}},
+	}
+	l := linter.New(cfg)
+
+	results, err := l.LintFile(email)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkRule(t, results, rules.RuleNoInlineStyle, "")
+
+	results, err = l.LintFile(page)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkRule(t, results, rules.RuleNoInlineStyle, rules.RuleNoInlineStyle)
+}
+
+func TestLintFile_NewFiles(t *testing.T) {
+	dir := t.TempDir()
+	content := `<p style="color: red">Hi</p>`
+	legacy := writeFile(t, dir, "legacy.html", content)
+	added := writeFile(t, dir, "added.html", content)
+	old := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(legacy, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		newFiles   linter.NewFiles
+		wantLegacy rules.Severity
+		wantAdded  rules.Severity
+	}{
+		{
+			name:       "baseline escalates unlisted files",
+			newFiles:   linter.NewFiles{Baseline: []string{legacy}, Escalate: true},
+			wantLegacy: rules.Info,
+			wantAdded:  rules.Warning,
+		},
+		{
+			name:       "since escalates files modified after it outside git",
+			newFiles:   linter.NewFiles{Since: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Escalate: true},
+			wantLegacy: rules.Info,
+			wantAdded:  rules.Warning,
+		},
+		{
+			name: "rule severity wins over escalation",
+			newFiles: linter.NewFiles{
+				Baseline:     []string{legacy},
+				Escalate:     true,
+				RuleSeverity: map[string]rules.Severity{rules.RuleNoInlineStyle: rules.Error},
+			},
+			wantLegacy: rules.Info,
+			wantAdded:  rules.Error,
+		},
+		{
+			name:       "empty baseline makes every file new",
+			newFiles:   linter.NewFiles{Baseline: []string{}, Escalate: true},
+			wantLegacy: rules.Warning,
+			wantAdded:  rules.Warning,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			cfg.NewFiles = tt.newFiles
+			l := linter.New(cfg)
+			for path, want := range map[string]rules.Severity{legacy: tt.wantLegacy, added: tt.wantAdded} {
+				results, err := l.LintFile(path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				checkRule(t, results, rules.RuleNoInlineStyle, rules.RuleNoInlineStyle)
+				for _, r := range results {
+					if r.Rule == rules.RuleNoInlineStyle && r.Severity != want {
+						t.Errorf("%s: severity = %v, want %v", filepath.Base(path), r.Severity, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestLintFile_NewFilesGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	content := `<p style="color: red">Hi</p>`
+	repo := t.TempDir()
+	git := func(dir, date string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=t", "-c", "user.email=t@example.com"}, args...)...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git(repo, "", "init", "-q")
+	writeFile(t, repo, "old.html", content)
+	git(repo, "2020-01-01T00:00:00Z", "add", "old.html")
+	git(repo, "2020-01-01T00:00:00Z", "commit", "-q", "-m", "old")
+	writeFile(t, repo, "new.html", content)
+	git(repo, "2025-01-01T00:00:00Z", "add", "new.html")
+	git(repo, "2025-01-01T00:00:00Z", "commit", "-q", "-m", "new")
+
+	shallow := filepath.Join(t.TempDir(), "shallow")
+	git(repo, "", "clone", "-q", "--depth", "1", "file://"+repo, shallow)
+
+	tests := []struct {
+		name    string
+		dir     string
+		wantOld rules.Severity
+		wantNew rules.Severity
+	}{
+		{"full history dates files by their first commit", repo, rules.Info, rules.Warning},
+		// every file looks added in the tip commit, so since is ignored
+		{"shallow clone falls back to the baseline", shallow, rules.Info, rules.Info},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			cfg.NewFiles = linter.NewFiles{Since: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Escalate: true}
+			l := linter.New(cfg)
+			for name, want := range map[string]rules.Severity{"old.html": tt.wantOld, "new.html": tt.wantNew} {
+				results, err := l.LintFile(filepath.Join(tt.dir, name))
+				if err != nil {
+					t.Fatal(err)
+				}
+				checkRule(t, results, rules.RuleNoInlineStyle, rules.RuleNoInlineStyle)
+				for _, r := range results {
+					if r.Rule == rules.RuleNoInlineStyle && r.Severity != want {
+						t.Errorf("%s: severity = %v, want %v", name, r.Severity, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestLintFiles_ParseError(t *testing.T) {
+	dir := t.TempDir()
+	deep := writeFile(t, dir, "deep.html", "<p>ok</p>\n\n"+strings.Repeat("<div>", 600))
+	ok := writeFile(t, dir, "ok.html", `<p>ok</p>`)
+
+	results, err := linter.New(linter.DefaultConfig()).LintFiles([]string{deep, ok})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var found bool
+	for _, r := range results {
+		if r.Rule != "parse-error" {
+			continue
+		}
+		found = true
+		if r.Filename != deep || r.Line != 3 || !strings.Contains(r.Message, "nested too deeply") {
+			t.Errorf("got %s:%d %q, want a nesting error on line 3 of deep.html", r.Filename, r.Line, r.Message)
+		}
+	}
+	if !found {
+		t.Error("no parse-error result for deep.html")
+	}
+}
+
+func TestLintFile_AssetExists(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "public/css/site.css", "")
+	writeFile(t, dir, "public/js/app.3f9a2c.js", "")
+	writeFile(t, dir, "templates/logo.png", "")
+
+	tests := []struct {
+		name     string
+		html     string
+		options  map[string]any
+		hook     rules.URLRewriter
+		wantRule string
+	}{
+		{
+			name:    "root-relative asset",
+			html:    `<link rel="stylesheet" href="/css/site.css">`,
+			options: map[string]any{"root": filepath.Join(dir, "public")},
+		},
+		{
+			name:     "missing asset",
+			html:     `<link rel="stylesheet" href="/css/missing.css">`,
+			options:  map[string]any{"root": filepath.Join(dir, "public")},
+			wantRule: rules.RuleAssetExists,
+		},
+		{
+			name:    "file-relative asset",
+			html:    `<img src="logo.png?v=2" alt="Logo">`,
+			options: map[string]any{"root": filepath.Join(dir, "public")},
+		},
+		{
+			name:     "fingerprinted asset without rewrite",
+			html:     `<script src="/static/js/app.js"></script>`,
+			options:  map[string]any{"root": filepath.Join(dir, "public")},
+			wantRule: rules.RuleAssetExists,
+		},
+		{
+			name: "fingerprinted asset with rewrites",
+			html: `<script src="/static/js/app.js"></script>`,
+			options: map[string]any{
+				"root": filepath.Join(dir, "public"),
+				"rewrites": []any{
+					map[string]any{"pattern": "^/static/", "replace": "/"},
+					map[string]any{"pattern": `\.js$`, "replace": ".*.js"},
+				},
+			},
+		},
+		{
+			name:    "fingerprinted asset with Go rewriter",
+			html:    `<script src="/js/app.js"></script>`,
+			options: map[string]any{"root": filepath.Join(dir, "public")},
+			hook: func(path string) string {
+				return strings.TrimSuffix(path, ".js") + ".*.js"
+			},
+		},
+		{
+			name:    "external and templated URLs",
+			html:    `<script src="https://cdn.example.com/x.js"></script><img src="{{.Avatar}}" alt="">`,
+			options: map[string]any{"root": filepath.Join(dir, "public")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeFile(t, dir, "templates/page.html", tt.html)
+			cfg := linter.DefaultConfig()
+			cfg.EnabledRules = []string{rules.RuleAssetExists}
+			cfg.RuleOptions = map[string]map[string]any{rules.RuleAssetExists: tt.options}
+			if tt.hook != nil {
+				cfg.URLRewriters = []rules.URLRewriter{tt.hook}
+			}
+			results, err := linter.New(cfg).LintFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkRule(t, results, rules.RuleAssetExists, tt.wantRule)
+		})
+	}
+}
+
+func TestLintFile_SVGUseReference(t *testing.T) {
+	dir := t.TempDir()
+	public := filepath.Join(dir, "public")
+	writeFile(t, dir, "public/img/icons.svg", `<svg xmlns="http://www.w3.org/2000/svg">
+<symbol id="icon-cart" viewBox="0 0 16 16"><path d="M0 0h16v16H0z"/></symbol>
+<symbol id="icon-user" viewBox="0 0 16 16"><path d="M0 0h16v16H0z"/></symbol>
+</svg>`)
+
+	tests := []struct {
+		name     string
+		html     string
+		options  map[string]any
+		wantRule string
+	}{
+		{
+			name: "symbol in the document",
+			html: `<svg hidden><symbol id="icon-x"></symbol></svg><svg><use href="#icon-x"></use></svg>`,
+		},
+		{
+			name:     "missing symbol in the document",
+			html:     `<svg hidden><symbol id="icon-x"></symbol></svg><svg><use href="#icon-y"></use></svg>`,
+			wantRule: rules.RuleSVGUseReference,
+		},
+		{
+			name:     "xlink:href",
+			html:     `<svg hidden><symbol id="icon-x"></symbol></svg><svg><use xlink:href="#icon-gone"></use></svg>`,
+			wantRule: rules.RuleSVGUseReference,
+		},
+		{
+			name: "partial without sprites",
+			html: `<button><svg><use href="#icon-cart"></use></svg></button>`,
+		},
+		{
+			name:    "configured sprite",
+			html:    `<button><svg><use href="#icon-cart"></use></svg></button>`,
+			options: map[string]any{"root": public, "sprites": []any{"/img/icons.svg"}},
+		},
+		{
+			name:     "symbol missing from configured sprite",
+			html:     `<button><svg><use href="#icon-trash"></use></svg></button>`,
+			options:  map[string]any{"root": public, "sprites": []any{"/img/icons.svg"}},
+			wantRule: rules.RuleSVGUseReference,
+		},
+		{
+			name:    "external sprite",
+			html:    `<svg><use href="/img/icons.svg#icon-user"></use></svg>`,
+			options: map[string]any{"root": public},
+		},
+		{
+			name:     "symbol missing from external sprite",
+			html:     `<svg><use href="/img/icons.svg#icon-trash"></use></svg>`,
+			options:  map[string]any{"root": public},
+			wantRule: rules.RuleSVGUseReference,
+		},
+		{
+			name:    "missing external sprite is left to asset-exists",
+			html:    `<svg><use href="/img/old.svg#icon-user"></use></svg>`,
+			options: map[string]any{"root": public},
+		},
+		{
+			name: "templated reference",
+			html: `<svg hidden><symbol id="icon-x"></symbol></svg><svg><use href="#{{.Icon}}"></use></svg>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeFile(t, dir, "templates/page.html", tt.html)
+			cfg := linter.DefaultConfig()
+			cfg.RuleOptions = map[string]map[string]any{rules.RuleSVGUseReference: tt.options}
+			results, err := linter.New(cfg).LintFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkRule(t, results, rules.RuleSVGUseReference, tt.wantRule)
+		})
+	}
+
+	// asset-exists reports the missing sprite file itself.
+	path := writeFile(t, dir, "templates/page.html", `<svg><use href="/img/old.svg#icon-user"></use></svg>`)
+	cfg := linter.DefaultConfig()
+	cfg.EnabledRules = []string{rules.RuleAssetExists}
+	cfg.RuleOptions = map[string]map[string]any{rules.RuleAssetExists: {"root": public}}
+	results, err := linter.New(cfg).LintFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkRule(t, results, rules.RuleAssetExists, rules.RuleAssetExists)
+}
+
+func TestLintContent_Locale(t *testing.T) {
+	cfg := linter.DefaultConfig()
+	cfg.EnabledRules = []string{rules.RuleImgAlt, rules.RuleNoInlineStyle}
+	cfg.Locale = "de"
+
+	results, err := linter.New(cfg).LintContent("test.html", []byte(`<img src="a.png" style="color: red">`))
+	if err != nil {
+		t.Fatalf("LintContent() error = %v", err)
+	}
+	for _, r := range results {
+		switch r.Rule {
+		case rules.RuleImgAlt:
+			if r.MessageID != "img-alt.missing" || r.Message != "img-Element fehlt das alt-Attribut" {
+				t.Errorf("img-alt result = %q (%s), want German catalog message", r.Message, r.MessageID)
+			}
+		case rules.RuleNoInlineStyle:
+			// not translated into German: falls back to English
+			if r.MessageID != "no-inline-style.style" || r.Message != "avoid inline style attribute; use CSS classes instead" {
+				t.Errorf("no-inline-style result = %q (%s), want English catalog message", r.Message, r.MessageID)
+			}
+		}
+	}
+}
+
+func TestLintContent_LocaleDirective(t *testing.T) {
+	cfg := linter.DefaultConfig()
+	cfg.Locale = "de"
+
+	results, err := linter.New(cfg).LintContent("test.html", []byte(`<!-- htmlint-config: profile=nope -->`))
+	if err != nil {
+		t.Fatalf("LintContent() error = %v", err)
+	}
+	for _, r := range results {
+		if r.Rule != linter.RuleConfigDirective {
+			continue
+		}
+		if r.MessageID != "config-directive.unknown-profile" || r.Message != "unbekanntes htmlint-config-Profil: nope" {
+			t.Errorf("config-directive result = %q (%s), want German catalog message", r.Message, r.MessageID)
+		}
+		return
+	}
+	t.Error("no config-directive result")
+}
+
+func TestLintFiles_NoDupScript(t *testing.T) {
+	dir := t.TempDir()
+	layout := writeFile(t, dir, "layout.html", `{{define "layout"}}<!DOCTYPE html>
+<html lang="en"><head><title>{{.Title}}</title>{{template "scripts" .}}</head>
+<body>{{template "content" .}}</body></html>{{end}}`)
+	scripts := writeFile(t, dir, "partials/scripts.html", `{{define "scripts"}}
+<script src="/js/htmx.min.js"></script>
+<script src="https://plausible.io/js/script.js" defer></script>
+{{end}}`)
+	home := writeFile(t, dir, "home.html", `{{define "content"}}<main><h1>Home</h1></main>{{end}}`)
+	search := writeFile(t, dir, "search.html", `{{define "content"}}<main>
+<h1>Search</h1>
+<script src="/js/htmx.min.js"></script>
+</main>{{end}}`)
+	widget := writeFile(t, dir, "widget.html", `<div>{{template "scripts"}}{{template "scripts"}}</div>`)
+
+	l := linter.New(nil)
+
+	results, err := l.LintFiles([]string{layout, scripts, home})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkRule(t, results, rules.RuleNoDupScript, "")
+
+	results, err = l.LintFiles([]string{layout, scripts, home, search})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var found []rules.Result
+	for _, r := range results {
+		if r.Rule == rules.RuleNoDupScript {
+			found = append(found, r)
+		}
+	}
+	if len(found) != 1 || found[0].Filename != search || found[0].Line != 3 {
+		t.Fatalf("want one no-dup-script finding at %s:3, got %v", search, found)
+	}
+	if !strings.Contains(found[0].Message, "partials/scripts.html:2") {
+		t.Errorf("message %q should point at the first include", found[0].Message)
+	}
+
+	results, err = l.LintFiles([]string{scripts, widget})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkRule(t, results, rules.RuleNoDupScript, rules.RuleNoDupScript)
+}
+
+func TestLintFiles_ComposedPages(t *testing.T) {
+	dir := t.TempDir()
+	layout := writeFile(t, dir, "layout.html", `{{define "layout"}}<!DOCTYPE html>
+<html lang="en"><head><title>{{.Title}}</title></head>
+<body><h1>{{.Title}}</h1>{{template "content" .}}</body></html>{{end}}`)
+	form := writeFile(t, dir, "partials/form.html", `{{define "form"}}<form>
+<label for="q">Search</label>{{template "field" .}}
+<label for="missing">Missing</label>
+</form>{{end}}`)
+	field := writeFile(t, dir, "partials/field.html", `{{define "field"}}<input id="q" name="q">{{end}}`)
+	home := writeFile(t, dir, "home.html", `{{template "layout" .}}
+{{define "content"}}<h2>Find</h2>{{template "form" .}}
+<h4>Skipped</h4>{{end}}`)
+
+	results, err := linter.New(nil).LintFiles([]string{layout, form, field, home})
+	if err != nil {
+		t.Fatal(err)
+	}
+	type finding struct {
+		rule, file string
+		line       int
+	}
+	var got []finding
+	for _, r := range results {
+		if r.Rule == rules.RuleNoMissingReferences || r.Rule == rules.RuleHeadingLevel {
+			got = append(got, finding{r.Rule, filepath.Base(r.Filename), r.Line})
+		}
+	}
+	slices.SortFunc(got, func(a, b finding) int { return strings.Compare(a.rule, b.rule) })
+	want := []finding{
+		{rules.RuleHeadingLevel, "home.html", 3},
+		{rules.RuleNoMissingReferences, "form.html", 3},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findings = %v, want %v", got, want)
+	}
+
+	// a partial linted without its page is checked alone
+	results, err = linter.New(nil).LintFiles([]string{form})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var missing int
+	for _, r := range results {
+		if r.Rule == rules.RuleNoMissingReferences {
+			missing++
+		}
+	}
+	if missing != 2 {
+		t.Errorf("no-missing-references findings for the lone partial = %d, want 2", missing)
+	}
+}
+
+func TestLintFiles_HeadingAnchorComposed(t *testing.T) {
+	dir := t.TempDir()
+	layout := writeFile(t, dir, "layout.html", `{{define "layout"}}<main>
+<h1 id="overview">Overview</h1>
+{{template "content" .}}</main>{{end}}`)
+	page := writeFile(t, dir, "page.html", `{{template "layout" .}}
+{{define "content"}}<h2 id="install">Install</h2>
+<h2 id="overview">Overview again</h2>{{end}}`)
+
+	cfg := linter.DefaultConfig()
+	cfg.EnabledRules = []string{rules.RuleHeadingAnchor}
+	results, err := linter.New(cfg).LintFiles([]string{layout, page})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, r := range results {
+		if r.Rule == rules.RuleHeadingAnchor {
+			got = append(got, fmt.Sprintf("%s:%d", filepath.Base(r.Filename), r.Line))
+		}
+	}
+	if want := []string{"page.html:3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("heading-anchor findings = %v, want %v", got, want)
+	}
+}
+
+func TestLintFiles_AriaRelationship(t *testing.T) {
+	dir := t.TempDir()
+	tabs := writeFile(t, dir, "partials/tabs.html", `<div role="tablist">
+<button role="tab" aria-controls="panel-a">A</button>
+<button role="tab" aria-controls="panel-b" tabindex="-1">B</button>
+</div>`)
+	panels := writeFile(t, dir, "partials/panels.html", `<div role="tabpanel" id="panel-a">A</div>
+<div id="panel-b">B</div>`)
+
+	results, err := linter.New(nil).LintFiles([]string{tabs, panels})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var found []rules.Result
+	for _, r := range results {
+		if r.Rule == rules.RuleAriaRelationship {
+			found = append(found, r)
+		}
+	}
+	if len(found) != 1 || found[0].Filename != tabs || found[0].Line != 3 {
+		t.Fatalf("want one aria-relationship finding at %s:3, got %v", tabs, found)
+	}
+	if !strings.Contains(found[0].Message, "partials/panels.html on line 2") {
+		t.Errorf("message %q should point at the target", found[0].Message)
+	}
+}
+
+func TestLintFile_StreamThreshold(t *testing.T) {
+	dir := t.TempDir()
+	big := writeFile(t, dir, "export.html", `<!DOCTYPE html>
+<html><body>
+<p id="row" class="a" class="b">One</p>
+<img src="chart.png">
+<template><p id="row">Stamped</p></template>
+<p id="row" style="color: red">Two</p>
+</body></html>`)
+	generated := writeFile(t, dir, "gen.html", "<!-- Code generated by export; DO NOT EDIT. -->\n<p id=x></p><p id=x></p>")
+
+	cfg := linter.DefaultConfig()
+	cfg.StreamThreshold = 16
+	l := linter.New(cfg)
+
+	results, err := l.LintFile(big)
+	if err != nil {
+		t.Fatal(err)
+	}
+	type finding struct {
+		rule string
+		line int
+	}
+	var got []finding
+	for _, r := range results {
+		got = append(got, finding{r.Rule, r.Line})
+	}
+	want := []finding{
+		{rules.RuleDuplicateID, 6},
+		{rules.RuleNoInlineStyle, 6},
+		{rules.RuleNoDupAttr, 3},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("streamed findings = %v, want %v (tree rules such as img-alt must not run)", got, want)
+	}
+
+	if results, err := l.LintFile(generated); err != nil || len(results) != 0 {
+		t.Errorf("generated streamed file: got %v, %v; want no findings", results, err)
+	}
+}
+
+func TestLintFiles_MaxMemory(t *testing.T) {
+	dir := t.TempDir()
+	files := []string{
+		writeFile(t, dir, "layout.html", `{{define "layout"}}<!DOCTYPE html>
+<html lang="en"><head><title>{{.Title}}</title>{{template "scripts" .}}</head>
+<body>{{template "content" .}}{{template "footer"}}</body></html>{{end}}`),
+		writeFile(t, dir, "partials/scripts.html", `{{define "scripts"}}
+<script src="/js/htmx.min.js"></script>
+{{end}}`),
+		writeFile(t, dir, "search.html", `{{define "content"}}<main>
+<h1>Search</h1>
+<script src="/js/htmx.min.js"></script>
+{{template "card" .User}}{{template "card" .}}
+</main>{{end}}`),
+		writeFile(t, dir, "partials/card.html", `{{define "card"}}<p>{{.Name}}</p>{{end}}`),
+		writeFile(t, dir, "plain.html", `<p>No templates here</p>`),
+		writeFile(t, dir, "tabs.html", `<div role="tablist"><button role="tab" aria-controls="panel-1">One</button></div>`),
+		writeFile(t, dir, "panels.html", `<section id="panel-1">One</section>`),
+	}
+	cfg := linter.DefaultConfig()
+	cfg.EnabledRules = []string{rules.RuleNoDupScript, rules.RuleTemplateReferences, rules.RuleTemplateCallData}
+
+	want, err := linter.New(cfg).LintFiles(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(want) < 4 {
+		t.Fatalf("fixture should trigger every project rule, got %v", want)
+	}
+
+	// A limit this small compacts every file.
+	cfg.MaxMemory = 64
+	got, err := linter.New(cfg).LintFiles(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("compacted results differ:\n got %v\nwant %v", got, want)
+	}
+}
+
+func TestParseMemorySize(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{in: "1048576", want: 1 << 20},
+		{in: "512MiB", want: 512 << 20},
+		{in: "2GiB", want: 2 << 30},
+		{in: "1.5GiB", wantErr: true},
+		{in: "500MB", want: 500e6},
+		{in: "64 KiB", want: 64 << 10},
+		{in: "100B", want: 100},
+		{in: "0", wantErr: true},
+		{in: "lots", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := linter.ParseMemorySize(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseMemorySize(%q) = %d, %v; want %d, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestLintFiles_TemplateReferences(t *testing.T) {
+	dir := t.TempDir()
+	layout := writeFile(t, dir, "layout.html", `{{define "layout"}}<main>{{template "content" .}}{{template "footer" .}}</main>{{end}}`)
+	page := writeFile(t, dir, "page.html", `{{define "content"}}<h1>Hi</h1>{{block "sidebar" .}}<aside></aside>{{end}}{{end}}`)
+	unused := writeFile(t, dir, "partials/old.html", `{{define "old-banner"}}<div>Sale</div>{{end}}`)
+
+	tests := []struct {
+		name         string
+		files        []string
+		opts         map[string]any
+		wantMessages []string
+	}{
+		{
+			name:         "undefined call and unused layout",
+			files:        []string{layout, page},
+			wantMessages: []string{`template "footer" is not defined`, `template "layout" is defined but never called`},
+		},
+		{
+			name:         "entrypoints option",
+			files:        []string{layout, page, unused},
+			opts:         map[string]any{"entrypoints": []any{"lay*"}},
+			wantMessages: []string{`template "footer" is not defined`, `template "old-banner" is defined but never called`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			cfg.EnabledRules = []string{rules.RuleTemplateReferences}
+			if tt.opts != nil {
+				cfg.RuleOptions = map[string]map[string]any{rules.RuleTemplateReferences: tt.opts}
+			}
+			results, err := linter.New(cfg).LintFiles(tt.files)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, r := range results {
+				if r.Rule == rules.RuleTemplateReferences {
+					got = append(got, r.Message)
+				}
+			}
+			if len(got) != len(tt.wantMessages) {
+				t.Fatalf("got %q, want %d findings", got, len(tt.wantMessages))
+			}
+			for i, want := range tt.wantMessages {
+				if !strings.Contains(got[i], want) {
+					t.Errorf("finding %d = %q, want %q", i, got[i], want)
+				}
+			}
+		})
+	}
+
+	results, err := linter.New(nil).LintFiles([]string{layout, page})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkRule(t, results, rules.RuleTemplateReferences, "")
+}
+
+func TestLintFiles_TemplateCallData(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		want    []string
+		wantSev []rules.Severity
+	}{
+		{
+			name: "consistent calls",
+			files: map[string]string{
+				"row.html":  `{{define "row"}}<tr><td>{{.Name}}</td></tr>{{end}}`,
+				"list.html": `<table>{{range .Users}}{{template "row" .}}{{end}}</table>`,
+				"one.html":  `<table>{{template "row" .}}</table>`,
+			},
+		},
+		{
+			name: "call without data",
+			files: map[string]string{
+				"card.html": `{{define "card"}}<div>{{.Title}}</div>{{end}}`,
+				"home.html": `<main>{{template "card" .Featured}}</main>`,
+				"side.html": `<aside>{{template "card"}}</aside>`,
+			},
+			want:    []string{`template "card" is called without data`},
+			wantSev: []rules.Severity{rules.Warning},
+		},
+		{
+			name: "dot where fields are passed",
+			files: map[string]string{
+				"card.html": `{{define "card"}}<div>{{.Title}}</div>{{end}}`,
+				"a.html":    `<main>{{template "card" .Featured}}</main>`,
+				"b.html":    `<main>{{template "card" .Latest}}</main>`,
+				"c.html":    `<main>{{template "card" .}}</main>`,
+			},
+			want:    []string{`template "card" is called with "." here but with ".Featured"`},
+			wantSev: []rules.Severity{rules.Info},
+		},
+		{
+			name: "function arguments are not compared",
+			files: map[string]string{
+				"card.html": `{{define "card"}}<div>{{.Title}}</div>{{end}}`,
+				"a.html":    `<main>{{template "card" .}}</main>`,
+				"b.html":    `<main>{{template "card" (dict "Title" "x")}}</main>`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			var paths []string
+			for name, content := range tt.files {
+				paths = append(paths, writeFile(t, dir, name, content))
+			}
+			slices.Sort(paths)
+
+			results, err := linter.New(nil).LintFiles(paths)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []rules.Result
+			for _, r := range results {
+				if r.Rule == rules.RuleTemplateCallData {
+					got = append(got, r)
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %d findings", got, len(tt.want))
+			}
+			for i, want := range tt.want {
+				if !strings.Contains(got[i].Message, want) || got[i].Severity != tt.wantSev[i] {
+					t.Errorf("finding %d = %q (%v), want %q (%v)", i, got[i].Message, got[i].Severity, want, tt.wantSev[i])
+				}
+			}
+		})
+	}
+}
+
+// captureReporter records the results passed to Report.
+type captureReporter struct {
+	results []rules.Result
+}
+
+func (c *captureReporter) Report(results []rules.Result) error {
+	c.results = results
+	return nil
+}
+
+// statsReporter also records run statistics.
+type statsReporter struct {
+	captureReporter
+	files   int
+	elapsed time.Duration
+}
+
+func (s *statsReporter) ReportStats(files int, elapsed time.Duration) {
+	s.files, s.elapsed = files, elapsed
+}
+
+func TestRun_Stats(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "web/index.html", `<img src="a.png">`)
+	writeFile(t, dir, "web/clean.html", `<p>Hello</p>`)
+	writeFile(t, dir, "web/skip/old.html", `<p>Old</p>`)
+	writeFile(t, dir, "web/notes.txt", `not HTML`)
+
+	cfg := linter.DefaultConfig()
+	cfg.IgnorePatterns = []string{"skip/"}
+	l := linter.New(cfg)
+	rep := &statsReporter{}
+	l.SetReporter(rep)
+	if _, err := l.Run([]string{dir}); err != nil {
+		t.Fatal(err)
+	}
+	if rep.files != 2 {
+		t.Errorf("files = %d, want 2", rep.files)
+	}
+	if rep.elapsed <= 0 {
+		t.Errorf("elapsed = %v, want > 0", rep.elapsed)
+	}
+	if len(rep.results) == 0 {
+		t.Error("expected findings to be reported too")
+	}
+}
+
+func TestRun_PathMode(t *testing.T) {
+	repo, err := filepath.EvalSymlinks(t.TempDir()) // match os.Getwd on macOS
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(repo, ".git"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, repo, "web/pages/index.html", `<img src="a.png">`)
+	t.Chdir(filepath.Join(repo, "web"))
+
+	tests := []struct {
+		mode linter.PathMode
+		want string
+	}{
+		{linter.PathModeDefault, filepath.Join("pages", "index.html")},
+		{linter.PathModeAbsolute, filepath.Join(repo, "web", "pages", "index.html")},
+		{linter.PathModeRelative, "pages/index.html"},
+		{linter.PathModeRepoRelative, "web/pages/index.html"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			cfg.PathMode = tt.mode
+			l := linter.New(cfg)
+			rep := &captureReporter{}
+			l.SetReporter(rep)
+			if _, err := l.Run([]string{"pages"}); err != nil {
+				t.Fatal(err)
+			}
+			if len(rep.results) == 0 {
+				t.Fatal("expected findings")
+			}
+			for _, r := range rep.results {
+				if r.Filename != tt.want {
+					t.Errorf("Filename = %q, want %q", r.Filename, tt.want)
+				}
+			}
+		})
+	}
+
+	if _, err := linter.ParsePathMode("home"); err == nil {
+		t.Error("expected error for unknown path mode")
+	}
+}
+
+func TestRun_Middleware(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "pages/index.html", `<img src="a.png">`)
+	writeFile(t, dir, "legacy/old.html", `<img src="b.png"><img src="c.png">`)
+
+	l := linter.New(nil)
+	rep := &captureReporter{}
+	l.SetReporter(rep)
+	// Drop findings in legacy/, then downgrade and tag what is left.
+	l.Use(func(results []rules.Result) []rules.Result {
+		return slices.DeleteFunc(results, func(r rules.Result) bool {
+			return strings.Contains(filepath.ToSlash(r.Filename), "/legacy/")
+		})
+	}, func(results []rules.Result) []rules.Result {
+		for i := range results {
+			results[i].Severity = rules.Warning
+			results[i].Meta = map[string]string{"owner": "@web-team"}
+		}
+		return results
+	})
+
+	errorCount, err := l.Run([]string{dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errorCount != 0 {
+		t.Errorf("errorCount = %d, want 0 after severity remapping", errorCount)
+	}
+	if len(rep.results) == 0 {
+		t.Fatal("expected findings in pages/")
+	}
+	for _, r := range rep.results {
+		if strings.Contains(filepath.ToSlash(r.Filename), "/legacy/") {
+			t.Errorf("finding in suppressed file: %+v", r)
+		}
+		if r.Severity != rules.Warning || r.Meta["owner"] != "@web-team" {
+			t.Errorf("%s severity = %v, owner = %q; want warning and @web-team", r.Rule, r.Severity, r.Meta["owner"])
+		}
+	}
+}
+
+func TestCodeOwners(t *testing.T) {
+	owners := linter.ParseCodeOwners("/repo", []byte(`# Default owners
+*                 @org/platform
+*.gohtml          @org/templates
+docs/             @org/docs
+/web/             @org/web
+/web/admin/**     @org/admin @alice  # admin pages
+/web/vendor/
+apps/*/mail.html  @org/mail
+docs/*            @org/writers
+`))
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"index.html", "@org/platform"},
+		{"web/index.html", "@org/web"},
+		{"web/partials/nav.gohtml", "@org/web"},
+		{"lib/nav.gohtml", "@org/templates"},
+		{"web/admin/users/list.html", "@org/admin @alice"},
+		{"guide/docs/intro.html", "@org/docs"},
+		{"web/vendor/widget.html", ""},
+		{"apps/shop/mail.html", "@org/mail"},
+		{"apps/shop/sub/mail.html", "@org/platform"},
+		{"docs/index.html", "@org/writers"},
+		{"docs/api/index.html", "@org/docs"},
+		{"/repo/web/index.html", "@org/web"},
+		{"/elsewhere/web/index.html", ""},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(owners.Owners(tt.path), " "); got != tt.want {
+			t.Errorf("Owners(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestRun_CodeOwners(t *testing.T) {
+	repo, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(repo, ".git"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, repo, ".github/CODEOWNERS", "/web/ @org/web\n")
+	writeFile(t, repo, "web/pages/index.html", `<img src="a.png">`)
+	writeFile(t, repo, "other.html", `<img src="b.png">`)
+	t.Chdir(filepath.Join(repo, "web"))
+
+	owners, err := linter.LoadCodeOwners(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if owners.Root != repo {
+		t.Errorf("Root = %q, want %q", owners.Root, repo)
+	}
+
+	cfg := linter.DefaultConfig()
+	cfg.PathMode = linter.PathModeRepoRelative
+	l := linter.New(cfg)
+	rep := &captureReporter{}
+	l.SetReporter(rep)
+	l.Use(owners.Middleware())
+	if _, err := l.Run([]string{"pages", filepath.Join(repo, "other.html")}); err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string]string)
+	for _, r := range rep.results {
+		got[r.Filename] = r.Meta[rules.MetaOwner]
+	}
+	want := map[string]string{"web/pages/index.html": "@org/web", "other.html": ""}
+	if len(got) != len(want) {
+		t.Fatalf("owners = %v, want %v", got, want)
+	}
+	for file, owner := range want {
+		if got[file] != owner {
+			t.Errorf("%s owner = %q, want %q", file, got[file], owner)
+		}
+	}
+
+	if _, err := linter.LoadCodeOwners(t.TempDir()); err == nil {
+		t.Error("expected error outside a repository")
+	}
+}
+
+func TestWorkspace_Run(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "apps/web/index.html", `<img src="a.png">`)
+	writeFile(t, dir, "apps/web/legacy/old.html", `<img src="b.png">`)
+	writeFile(t, dir, "apps/admin/index.html", `<img src="c.png">`)
+	writeFile(t, dir, "shared/footer.html", `<img src="d.png">`)
+
+	// apps/web disables img-alt except in its nested legacy project
+	web := linter.DefaultConfig()
+	web.DisabledRules = []string{rules.RuleImgAlt}
+	legacy := linter.DefaultConfig()
+	admin := linter.DefaultConfig()
+	admin.RuleSeverity = map[string]rules.Severity{rules.RuleImgAlt: rules.Warning}
+
+	ws := linter.NewWorkspace(linter.DefaultConfig(), []linter.Project{
+		{Dir: filepath.Join(dir, "apps/web"), Config: web},
+		{Dir: filepath.Join(dir, "apps/web/legacy"), Config: legacy},
+		{Dir: filepath.Join(dir, "apps/admin"), Config: admin},
+	})
+	rep := &captureReporter{}
+	ws.SetReporter(rep)
+	errorCount, err := ws.Run([]string{dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string]rules.Severity)
+	for _, r := range rep.results {
+		if r.Rule == rules.RuleImgAlt {
+			rel, _ := filepath.Rel(dir, r.Filename)
+			got[filepath.ToSlash(rel)] = r.Severity
+		}
+	}
+	want := map[string]rules.Severity{
+		"apps/web/legacy/old.html": rules.Error,
+		"apps/admin/index.html":    rules.Warning,
+		"shared/footer.html":       rules.Error,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("img-alt findings = %v, want %v", got, want)
+	}
+	for file, sev := range want {
+		if got[file] != sev {
+			t.Errorf("%s severity = %v, want %v", file, got[file], sev)
+		}
+	}
+	if errorCount == 0 {
+		t.Error("expected errors to be counted across projects")
+	}
+}
+
+func TestLintDir_Templ(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "card.templ", `package views
+
+import "fmt"
+
+templ Card(title string, n int) {
+	{{ label := fmt.Sprint(n) }}
+	<div class="card">
+		<h2>{ title }</h2>
+		<img src="/card.png"/>
+		<a href={ templ.SafeURL("/x") }>{ label }</a>
+	</div>
+}
+`)
+
+	results, err := linter.New(nil).LintDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var img bool
+	for _, r := range results {
+		switch r.Rule {
+		case rules.RuleImgAlt:
+			img = true
+			if r.Line != 9 || r.Col != 3 {
+				t.Errorf("img-alt at %d:%d, want 9:3", r.Line, r.Col)
+			}
+		case rules.RuleTemplateSyntaxValid, "parse-error":
+			t.Errorf("unexpected %s finding on templ file: %s", r.Rule, r.Message)
+		}
+	}
+	if !img {
+		t.Errorf("img-alt not reported for templ file; got %v", results)
+	}
+}
@@ -382,6 +382,18 @@ func TestLintContent_HTMXTarget(t *testing.T) {
 			wantRule:   rules.RuleHTMXAttributes,
 			wantSubstr: "invalid hx-target keyword",
 		},
+		{
+			name:       "invalid selector after closest",
+			html:       `<div hx-get="/api" hx-target="closest [data-row">content</div>`,
+			wantRule:   rules.RuleHTMXAttributes,
+			wantSubstr: "unclosed bracket",
+		},
+		{
+			name:       "invalid single selector",
+			html:       `<div hx-get="/api" hx-target="#row>">content</div>`,
+			wantRule:   rules.RuleHTMXAttributes,
+			wantSubstr: "ends with combinator",
+		},
 	}
 
 	cfg := linter.DefaultConfig()
@@ -767,6 +779,14 @@ func TestLintContent_HTMXInclude(t *testing.T) {
 			name: "valid pseudo-class",
 			html: `<div hx-post="/api" hx-include=":not(.hidden)">content</div>`,
 		},
+		{
+			name: "valid structural selector",
+			html: `<div hx-post="/api" hx-include="closest tr:has(input:checked)">content</div>`,
+		},
+		{
+			name: "valid dynamic pseudo-class",
+			html: `<div hx-post="/api" hx-include="input:focus">content</div>`,
+		},
 		{
 			name: "empty hx-include",
 			html: `<div hx-post="/api" hx-include="">content</div>`,
@@ -1306,3 +1326,192 @@ func TestLintContent_HTMXCustomEvents(t *testing.T) {
 
 	runHTMXTests(t, l, tests)
 }
+
+func TestLintContent_HTMXAttributePosition(t *testing.T) {
+	cfg := linter.DefaultConfig()
+	cfg.Frameworks.HTMX = true
+	l := linter.New(cfg)
+
+	content := "<div>\n  <button type=\"button\" hx-get=\"/api\"\n          hx-swap=\"sideways\">Go</button>\n</div>"
+	results, err := l.LintContent("test.html", []byte(content))
+	if err != nil {
+		t.Fatalf("LintContent() error = %v", err)
+	}
+
+	for _, r := range results {
+		if r.Rule != rules.RuleHTMXAttributes {
+			continue
+		}
+		if r.Line != 3 || r.Col != 11 {
+			t.Errorf("expected finding at hx-swap (3:11), got %d:%d", r.Line, r.Col)
+		}
+		return
+	}
+	t.Errorf("expected htmx-attributes result, got %v", results)
+}
+
+func TestLintContent_HTMXPartial(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		html     string
+		rule     string
+		wantRule string
+	}{
+		{
+			name:     "single root",
+			filename: "partials/row.html",
+			html:     `<tr id="row-{{.ID}}"><td>{{.Name}}</td></tr>`,
+			rule:     rules.RuleHTMXPartial,
+		},
+		{
+			name:     "list item needs no list ancestor in a partial",
+			filename: "partials/item.html",
+			html:     `<li>One</li>`,
+			rule:     rules.RuleElementRequiredAncestor,
+		},
+		{
+			name:     "multiple roots",
+			filename: "partials/items.html",
+			html:     `<li>One</li><li>Two</li>`,
+			rule:     rules.RuleHTMXPartial,
+			wantRule: rules.RuleHTMXPartial,
+		},
+		{
+			name:     "out-of-band root with id",
+			filename: "partials/cart.html",
+			html:     `<div id="cart">Cart</div><span id="count" hx-swap-oob="true">3</span>`,
+			rule:     rules.RuleHTMXPartial,
+		},
+		{
+			name:     "out-of-band root with selector",
+			filename: "partials/cart.html",
+			html:     `<div id="cart">Cart</div><span hx-swap-oob="innerHTML:#count">3</span>`,
+			rule:     rules.RuleHTMXPartial,
+		},
+		{
+			name:     "out-of-band root without id",
+			filename: "partials/cart.html",
+			html:     `<div id="cart">Cart</div><span hx-swap-oob="true">3</span>`,
+			rule:     rules.RuleHTMXPartial,
+			wantRule: rules.RuleHTMXPartial,
+		},
+		{
+			name:     "full-document rules skipped",
+			filename: "partials/page.html",
+			html:     `<html><head><title></title></head><body><p>x</p></body></html>`,
+			rule:     rules.RuleRequireLang,
+		},
+		{
+			name:     "files outside partials are unaffected",
+			filename: "pages/items.html",
+			html:     `<li>One</li><li>Two</li>`,
+			rule:     rules.RuleHTMXPartial,
+		},
+	}
+
+	cfg := linter.DefaultConfig()
+	cfg.Frameworks.HTMX = true
+	cfg.PartialPatterns = []string{"partials/**"}
+	l := linter.New(cfg)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := l.LintContent(tt.filename, []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, tt.rule, tt.wantRule)
+		})
+	}
+}
+
+func TestLintContent_FrameworkRemnants(t *testing.T) {
+	tests := []struct {
+		name       string
+		html       string
+		optIn      bool
+		htmx       bool
+		allowed    []string
+		wantRule   string
+		wantSubstr string
+	}{
+		{
+			name: "disabled by default",
+			html: `<div ng-if="x">A</div>`,
+		},
+		{
+			name:       "angularjs attribute",
+			html:       `<div ng-if="x" ng-class="y">A</div>`,
+			optIn:      true,
+			htmx:       true,
+			wantRule:   rules.RuleFrameworkRemnants,
+			wantSubstr: "Angular attributes (ng-if, ng-class)",
+		},
+		{
+			name:       "vue directive",
+			html:       `<li v-for="item in items">{{.Name}}</li>`,
+			optIn:      true,
+			wantRule:   rules.RuleFrameworkRemnants,
+			wantSubstr: "Vue attributes",
+		},
+		{
+			name:       "turbo attribute",
+			html:       `<a href="/a" data-turbo-frame="main">A</a>`,
+			optIn:      true,
+			wantRule:   rules.RuleFrameworkRemnants,
+			wantSubstr: "Turbo",
+		},
+		{
+			name:       "htmx without frameworks.htmx",
+			html:       `<button type="button" hx-post="/like">Like</button>`,
+			optIn:      true,
+			wantRule:   rules.RuleFrameworkRemnants,
+			wantSubstr: "htmx",
+		},
+		{
+			name:  "htmx declared",
+			html:  `<button type="button" hx-post="/like">Like</button>`,
+			optIn: true,
+			htmx:  true,
+		},
+		{
+			name:    "alpine allowed",
+			html:    `<div x-data="{open: false}" :class="open" @click="open = true">A</div>`,
+			optIn:   true,
+			allowed: []string{"alpine"},
+		},
+		{
+			name:       "shorthand without vue or alpine",
+			html:       `<div @click="open = true">A</div>`,
+			optIn:      true,
+			wantRule:   rules.RuleFrameworkRemnants,
+			wantSubstr: "Vue or Alpine.js attributes (@click)",
+		},
+		{
+			name:  "plain data attributes",
+			html:  `<div data-id="1" data-x="2" aria-label="A">A</div>`,
+			optIn: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			if tt.optIn {
+				cfg.EnabledRules = []string{rules.RuleFrameworkRemnants}
+			}
+			cfg.Frameworks.HTMX = tt.htmx
+			cfg.Frameworks.Allowed = tt.allowed
+			results, err := linter.New(cfg).LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleFrameworkRemnants, tt.wantRule)
+			for _, r := range results {
+				if r.Rule == rules.RuleFrameworkRemnants && !strings.Contains(r.Message, tt.wantSubstr) {
+					t.Errorf("message %q does not contain %q", r.Message, tt.wantSubstr)
+				}
+			}
+		})
+	}
+}
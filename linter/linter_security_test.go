@@ -241,3 +241,537 @@ func TestLintContent_AreaAlt(t *testing.T) {
 		})
 	}
 }
+
+func TestLintContent_TemplateEscapingContext(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		optIn    bool
+		wantRule string
+	}{
+		{
+			name: "disabled by default",
+			html: `<script>var id = {{.ID}};</script>`,
+		},
+		{
+			name:     "value in script",
+			html:     `<script>var id = {{.ID}};</script>`,
+			optIn:    true,
+			wantRule: rules.RuleTemplateEscapingContext,
+		},
+		{
+			name:     "value in module script",
+			html:     `<script type="module">init({{.Config}});</script>`,
+			optIn:    true,
+			wantRule: rules.RuleTemplateEscapingContext,
+		},
+		{
+			name:  "value in JSON data block",
+			html:  `<script type="application/json">{{.Config}}</script>`,
+			optIn: true,
+		},
+		{
+			name:     "value in event handler",
+			html:     `<button type="button" onclick="remove({{.ID}})">Remove</button>`,
+			optIn:    true,
+			wantRule: rules.RuleTemplateEscapingContext,
+		},
+		{
+			name:     "value in htmx event handler",
+			html:     `<div hx-on:click="alert('{{.Name}}')">x</div>`,
+			optIn:    true,
+			wantRule: rules.RuleTemplateEscapingContext,
+		},
+		{
+			name:     "value in style attribute",
+			html:     `<div style="color: {{.Color}}">x</div>`,
+			optIn:    true,
+			wantRule: rules.RuleTemplateEscapingContext,
+		},
+		{
+			name:     "value in style element",
+			html:     `<style>.x { width: {{.Width}}px; }</style>`,
+			optIn:    true,
+			wantRule: rules.RuleTemplateEscapingContext,
+		},
+		{
+			name:  "value in data attribute",
+			html:  `<button type="button" data-id="{{.ID}}" onclick="remove(this.dataset.id)">Remove</button>`,
+			optIn: true,
+		},
+		{
+			name:  "control flow in script",
+			html:  `<script>{{if .Debug}}console.log("debug");{{end}}</script>`,
+			optIn: true,
+		},
+		{
+			name:  "value in text",
+			html:  `<p>{{.Name}}</p>`,
+			optIn: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			if tt.optIn {
+				cfg.RuleSeverity = map[string]rules.Severity{rules.RuleTemplateEscapingContext: rules.Info}
+			}
+			results, err := linter.New(cfg).LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleTemplateEscapingContext, tt.wantRule)
+		})
+	}
+}
+
+func TestLintContent_CSPCompatible(t *testing.T) {
+	const strict = "default-src 'self'; script-src 'self' 'nonce-abc' https://cdn.example.com; img-src 'self' https://*.example.com data:"
+	tests := []struct {
+		name     string
+		html     string
+		policy   string
+		wantRule string
+	}{
+		{
+			name: "no policy configured",
+			html: `<script>alert(1)</script>`,
+		},
+		{
+			name:     "inline script without nonce",
+			html:     `<script>alert(1)</script>`,
+			policy:   strict,
+			wantRule: rules.RuleCSPCompatible,
+		},
+		{
+			name:   "inline script with nonce placeholder",
+			html:   `<script nonce="{{.Nonce}}">alert(1)</script>`,
+			policy: strict,
+		},
+		{
+			name:   "inline script with unsafe-inline",
+			html:   `<script>alert(1)</script>`,
+			policy: "script-src 'self' 'unsafe-inline'",
+		},
+		{
+			name:     "unsafe-inline ignored alongside a nonce",
+			html:     `<script>alert(1)</script>`,
+			policy:   "script-src 'unsafe-inline' 'nonce-abc'",
+			wantRule: rules.RuleCSPCompatible,
+		},
+		{
+			name:     "inline style falls back to default-src",
+			html:     `<style>p { color: red; }</style>`,
+			policy:   strict,
+			wantRule: rules.RuleCSPCompatible,
+		},
+		{
+			name:     "style attribute",
+			html:     `<p style="color: red">x</p>`,
+			policy:   strict,
+			wantRule: rules.RuleCSPCompatible,
+		},
+		{
+			name:     "event handler attribute",
+			html:     `<button type="button" onclick="go()">Go</button>`,
+			policy:   strict,
+			wantRule: rules.RuleCSPCompatible,
+		},
+		{
+			name:     "javascript URL",
+			html:     `<a href="javascript:go()">Go</a>`,
+			policy:   strict,
+			wantRule: rules.RuleCSPCompatible,
+		},
+		{
+			name:   "same-origin script",
+			html:   `<script src="/app.js"></script>`,
+			policy: strict,
+		},
+		{
+			name:   "listed script origin",
+			html:   `<script src="https://cdn.example.com/lib.js"></script>`,
+			policy: strict,
+		},
+		{
+			name:     "unlisted script origin",
+			html:     `<script src="https://evil.example.net/lib.js"></script>`,
+			policy:   strict,
+			wantRule: rules.RuleCSPCompatible,
+		},
+		{
+			name:   "image from wildcard host",
+			html:   `<img src="https://img.example.com/a.png" alt="a">`,
+			policy: strict,
+		},
+		{
+			name:   "data image",
+			html:   `<img src="data:image/png;base64,AAAA" alt="a">`,
+			policy: strict,
+		},
+		{
+			name:     "stylesheet from unlisted origin",
+			html:     `<link rel="stylesheet" href="https://fonts.example.org/css">`,
+			policy:   strict,
+			wantRule: rules.RuleCSPCompatible,
+		},
+		{
+			name:   "unrestricted directive",
+			html:   `<iframe src="https://video.example.org/embed" title="Video"></iframe>`,
+			policy: "script-src 'self'",
+		},
+		{
+			name:   "template URL (skip)",
+			html:   `<script src="{{.CDN}}/app.js"></script>`,
+			policy: strict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			if tt.policy != "" {
+				cfg.RuleOptions = map[string]map[string]any{rules.RuleCSPCompatible: {"policy": tt.policy}}
+			}
+			results, err := linter.New(cfg).LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleCSPCompatible, tt.wantRule)
+		})
+	}
+}
+
+func TestLintContent_FormCSRFToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		optIn    bool
+		options  map[string]any
+		wantRule string
+	}{
+		{
+			name: "disabled by default",
+			html: `<form method="post" action="/save"><button>Save</button></form>`,
+		},
+		{
+			name:     "POST form without token",
+			html:     `<form method="post" action="/save"><button>Save</button></form>`,
+			optIn:    true,
+			wantRule: rules.RuleFormCSRFToken,
+		},
+		{
+			name:  "GET form without token",
+			html:  `<form method="get" action="/search"><button>Search</button></form>`,
+			optIn: true,
+		},
+		{
+			name:  "hidden token input",
+			html:  `<form method="POST" action="/save"><input type="hidden" name="csrf_token" value="{{.CSRF}}"><button>Save</button></form>`,
+			optIn: true,
+		},
+		{
+			name:  "template field helper",
+			html:  `<form method="post" action="/save">{{ .csrfField }}<button>Save</button></form>`,
+			optIn: true,
+		},
+		{
+			name:     "visible token-like input",
+			html:     `<form method="post" action="/save"><input type="text" name="csrf_token"><button>Save</button></form>`,
+			optIn:    true,
+			wantRule: rules.RuleFormCSRFToken,
+		},
+		{
+			name:     "hx-post without token",
+			html:     `<button type="button" hx-post="/like">Like</button>`,
+			optIn:    true,
+			wantRule: rules.RuleFormCSRFToken,
+		},
+		{
+			name:  "hx-post with inherited hx-headers",
+			html:  `<div hx-headers='{"X-CSRF-Token": "{{.CSRF}}"}'><button type="button" hx-post="/like">Like</button></div>`,
+			optIn: true,
+		},
+		{
+			name:  "hx-delete inside form with token",
+			html:  `<form method="post" action="/items"><input type="hidden" name="gorilla.csrf.Token" value="x"><button type="button" hx-delete="/items/1">Delete</button></form>`,
+			optIn: true,
+		},
+		{
+			name:  "hx-post with meta token",
+			html:  `<!DOCTYPE html><html lang="en"><head><meta name="csrf-token" content="x"><title>x</title></head><body><button type="button" hx-post="/like">Like</button></body></html>`,
+			optIn: true,
+		},
+		{
+			name:  "hx-get without token",
+			html:  `<button type="button" hx-get="/items">Load</button>`,
+			optIn: true,
+		},
+		{
+			name:    "custom token pattern",
+			html:    `<form method="post" action="/save"><input type="hidden" name="_token" value="x"></form>`,
+			optIn:   true,
+			options: map[string]any{"token-pattern": `^_token$`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			if tt.optIn {
+				cfg.EnabledRules = []string{rules.RuleFormCSRFToken}
+			}
+			cfg.RuleOptions = map[string]map[string]any{rules.RuleFormCSRFToken: tt.options}
+			results, err := linter.New(cfg).LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleFormCSRFToken, tt.wantRule)
+		})
+	}
+}
+
+func TestLintContent_SensitiveURLData(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		options  map[string]any
+		wantRule string
+	}{
+		{
+			name:     "password in GET form",
+			html:     `<form method="get" action="/login"><input type="password" name="pw"></form>`,
+			wantRule: rules.RuleSensitiveURLData,
+		},
+		{
+			name:     "password in form without method",
+			html:     `<form action="/login"><input type="password" name="pw"></form>`,
+			wantRule: rules.RuleSensitiveURLData,
+		},
+		{
+			name: "password in POST form",
+			html: `<form method="post" action="/login"><input type="password" name="pw"></form>`,
+		},
+		{
+			name: "password in form submitted with hx-post",
+			html: `<form hx-post="/login"><input type="password" name="pw"></form>`,
+		},
+		{
+			name:     "token in GET form",
+			html:     `<form method="get" action="/reset"><input type="hidden" name="reset_token" value="x"></form>`,
+			wantRule: rules.RuleSensitiveURLData,
+		},
+		{
+			name:     "ssn in hx-get form",
+			html:     `<form hx-get="/lookup"><input type="text" name="ssn"></form>`,
+			wantRule: rules.RuleSensitiveURLData,
+		},
+		{
+			name:     "hx-get on sensitive input",
+			html:     `<input type="text" name="api_key" hx-get="/validate" hx-trigger="change">`,
+			wantRule: rules.RuleSensitiveURLData,
+		},
+		{
+			name: "search form",
+			html: `<form method="get" action="/search"><input type="search" name="q"></form>`,
+		},
+		{
+			name:    "custom pattern",
+			html:    `<form method="get" action="/search"><input type="text" name="reset_token"></form>`,
+			options: map[string]any{"pattern": `(?i)^pin$`},
+		},
+		{
+			name:     "custom pattern match",
+			html:     `<form method="get" action="/unlock"><input type="text" name="pin"></form>`,
+			options:  map[string]any{"pattern": `(?i)^pin$`},
+			wantRule: rules.RuleSensitiveURLData,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			cfg.RuleOptions = map[string]map[string]any{rules.RuleSensitiveURLData: tt.options}
+			results, err := linter.New(cfg).LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleSensitiveURLData, tt.wantRule)
+		})
+	}
+}
+
+func TestLintContent_IframeSandbox(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		wantRule string
+	}{
+		{
+			name: "valid sandbox tokens",
+			html: `<iframe src="/embed" title="Embed" sandbox="allow-scripts allow-forms"></iframe>`,
+		},
+		{
+			name: "empty sandbox",
+			html: `<iframe src="/embed" title="Embed" sandbox></iframe>`,
+		},
+		{
+			name:     "unknown sandbox token",
+			html:     `<iframe src="/embed" title="Embed" sandbox="allow-everything"></iframe>`,
+			wantRule: rules.RuleIframeSandbox,
+		},
+		{
+			name:     "scripts with same origin",
+			html:     `<iframe src="/embed" title="Embed" sandbox="allow-scripts allow-same-origin"></iframe>`,
+			wantRule: rules.RuleIframeSandbox,
+		},
+		{
+			name:     "conflicting top navigation tokens",
+			html:     `<iframe src="/embed" title="Embed" sandbox="allow-top-navigation allow-top-navigation-by-user-activation"></iframe>`,
+			wantRule: rules.RuleIframeSandbox,
+		},
+		{
+			name: "valid allow",
+			html: `<iframe src="https://video.example.com/embed" title="Video" allow="autoplay; fullscreen 'self' https://video.example.com; picture-in-picture *"></iframe>`,
+		},
+		{
+			name:     "unknown allow feature",
+			html:     `<iframe src="/embed" title="Embed" allow="teleport"></iframe>`,
+			wantRule: rules.RuleIframeSandbox,
+		},
+		{
+			name:     "header syntax in allow",
+			html:     `<iframe src="/embed" title="Embed" allow="camera=(self)"></iframe>`,
+			wantRule: rules.RuleIframeSandbox,
+		},
+		{
+			name:     "invalid allowlist entry",
+			html:     `<iframe src="/embed" title="Embed" allow="camera self"></iframe>`,
+			wantRule: rules.RuleIframeSandbox,
+		},
+	}
+
+	l := linter.New(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := l.LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleIframeSandbox, tt.wantRule)
+		})
+	}
+}
+
+func TestLintContent_IframeRequireSandbox(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		wantRule string
+	}{
+		{
+			name: "disabled by default",
+			html: `<iframe src="/embed" title="Embed"></iframe>`,
+		},
+		{
+			name:     "strict profile without sandbox",
+			html:     "<!-- htmlint-config: profile=strict -->\n<iframe src=\"/embed\" title=\"Embed\"></iframe>",
+			wantRule: rules.RuleIframeRequireSandbox,
+		},
+		{
+			name: "strict profile with sandbox",
+			html: "<!-- htmlint-config: profile=strict -->\n<iframe src=\"/embed\" title=\"Embed\" sandbox=\"allow-scripts\"></iframe>",
+		},
+	}
+
+	l := linter.New(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := l.LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleIframeRequireSandbox, tt.wantRule)
+		})
+	}
+}
+
+func TestLintContent_NoEnvironmentURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		options  map[string]any
+		wantRule string
+	}{
+		{
+			name:     "localhost link",
+			html:     `<a href="http://localhost:3000/admin">Admin</a>`,
+			wantRule: rules.RuleNoEnvironmentURL,
+		},
+		{
+			name:     "loopback script",
+			html:     `<script src="http://127.0.0.1:8080/app.js"></script>`,
+			wantRule: rules.RuleNoEnvironmentURL,
+		},
+		{
+			name:     "private address form action",
+			html:     `<form action="https://192.168.1.20/submit" method="post"></form>`,
+			wantRule: rules.RuleNoEnvironmentURL,
+		},
+		{
+			name:     "internal TLD in hx-post",
+			html:     `<button type="button" hx-post="http://api.internal/items">Add</button>`,
+			wantRule: rules.RuleNoEnvironmentURL,
+		},
+		{
+			name:     "staging host in srcset",
+			html:     `<img src="/a.png" srcset="/a.png 1x, https://cdn-staging.example.com/a@2x.png 2x" alt="A">`,
+			wantRule: rules.RuleNoEnvironmentURL,
+		},
+		{
+			name:     "URL in hx-vals JSON",
+			html:     `<div hx-get="/items" hx-vals='{"callback": "http://localhost/cb"}'></div>`,
+			wantRule: rules.RuleNoEnvironmentURL,
+		},
+		{
+			name: "production host",
+			html: `<a href="https://example.com/stage/docs">Docs</a>`,
+		},
+		{
+			name: "relative URL",
+			html: `<a href="/localhost">Local</a>`,
+		},
+		{
+			name: "templated host",
+			html: `<a href="https://{{.Host}}/admin">Admin</a>`,
+		},
+		{
+			name: "text content is ignored",
+			html: `<p>Run the server at http://localhost:3000</p>`,
+		},
+		{
+			name:     "configured host",
+			html:     `<a href="https://beta.example.com/">Beta</a>`,
+			options:  map[string]any{"hosts": []any{"*.example.com"}},
+			wantRule: rules.RuleNoEnvironmentURL,
+		},
+		{
+			name:    "allowed host",
+			html:    `<a href="https://uat.partner.com/">Partner test site</a>`,
+			options: map[string]any{"allow-hosts": []any{"uat.partner.com"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			cfg.RuleOptions = map[string]map[string]any{rules.RuleNoEnvironmentURL: tt.options}
+			results, err := linter.New(cfg).LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleNoEnvironmentURL, tt.wantRule)
+		})
+	}
+}
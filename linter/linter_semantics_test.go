@@ -484,3 +484,187 @@ func TestLintContent_DetailsAndFieldsetContent(t *testing.T) {
 		})
 	}
 }
+
+func TestLintContent_PreformattedIndent(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		wantRule string
+	}{
+		{
+			name: "flush pre",
+			html: "<div>\n  <pre>\nline one\n  line two\n</pre>\n</div>",
+		},
+		{
+			name:     "reindented pre",
+			html:     "<div>\n  <pre>\n    line one\n      line two\n  </pre>\n</div>",
+			wantRule: rules.RulePreformattedIndent,
+		},
+		{
+			name:     "reindented pre with code",
+			html:     "<pre><code>\n    fmt.Println()\n    return\n</code></pre>",
+			wantRule: rules.RulePreformattedIndent,
+		},
+		{
+			name: "pre starting on tag line",
+			html: "<pre>func main() {\n    run()\n}</pre>",
+		},
+		{
+			name: "single line pre",
+			html: "<pre>    indented</pre>",
+		},
+		{
+			name:     "reindented textarea",
+			html:     "<label>Bio\n  <textarea name=\"bio\">\n    {{.Bio}}\n    more\n  </textarea>\n</label>",
+			wantRule: rules.RulePreformattedIndent,
+		},
+		{
+			name:     "reindented plain text script",
+			html:     "<script type=\"text/plain\">\n\tHello\n\tWorld\n</script>",
+			wantRule: rules.RulePreformattedIndent,
+		},
+		{
+			name: "indented javascript",
+			html: "<script>\n    init();\n    run();\n</script>",
+		},
+	}
+
+	l := linter.New(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := l.LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RulePreformattedIndent, tt.wantRule)
+		})
+	}
+}
+
+func TestLintContent_InlineWhitespace(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		wantRule string
+	}{
+		{
+			name: "spaced links",
+			html: `<p><a href="/">Home</a> <a href="/about">About</a></p>`,
+		},
+		{
+			name:     "adjacent links",
+			html:     `<p><a href="/">Home</a><a href="/about">About</a></p>`,
+			wantRule: rules.RuleInlineWhitespace,
+		},
+		{
+			name:     "link then button",
+			html:     `<p><a href="/">Edit</a><!-- sep --><button type="button">Delete</button></p>`,
+			wantRule: rules.RuleInlineWhitespace,
+		},
+		{
+			name: "separator text",
+			html: `<p><a href="/">Home</a>|<a href="/about">About</a></p>`,
+		},
+		{
+			name: "icon buttons",
+			html: `<div><button type="button" aria-label="Bold"><svg aria-hidden="true"></svg></button><button type="button" aria-label="Italic"><svg aria-hidden="true"></svg></button></div>`,
+		},
+		{
+			name:     "trim markers remove whitespace",
+			html:     "<p><a href=\"/\">Home</a>\n  {{- if .More -}}\n  <a href=\"/more\">More</a>{{end}}</p>",
+			wantRule: rules.RuleInlineWhitespace,
+		},
+		{
+			name: "trim marker on one side",
+			html: "<p><strong>Total</strong>\n  {{- if .Paid}}\n  <em>paid</em>{{end}}</p>",
+		},
+		{
+			name: "value between",
+			html: "<p><span>a</span> {{- .Sep -}} <span>b</span></p>",
+		},
+		{
+			name: "trim markers between blocks",
+			html: "<div><p>a</p> {{- if .B -}} <p>b</p>{{end}}</div>",
+		},
+	}
+
+	l := linter.New(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := l.LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleInlineWhitespace, tt.wantRule)
+		})
+	}
+}
+
+func TestLintContent_DirConsistency(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		wantRule string
+	}{
+		{
+			name: "bdo with dir",
+			html: `<p><bdo dir="rtl">abc</bdo></p>`,
+		},
+		{
+			name:     "bdo without dir",
+			html:     `<p><bdo>abc</bdo></p>`,
+			wantRule: rules.RuleDirConsistency,
+		},
+		{
+			name:     "bdo with auto dir",
+			html:     `<p><bdo dir="auto">abc</bdo></p>`,
+			wantRule: rules.RuleDirConsistency,
+		},
+		{
+			name: "bdi isolates user text",
+			html: `<p>User <bdi>{{.Name}}</bdi> posted</p>`,
+		},
+		{
+			name:     "physical margin in rtl",
+			html:     `<div dir="rtl"><p style="margin-left: 1em">نص</p></div>`,
+			wantRule: rules.RuleDirConsistency,
+		},
+		{
+			name:     "text-align right in rtl",
+			html:     `<p dir="rtl" style="text-align: right">نص</p>`,
+			wantRule: rules.RuleDirConsistency,
+		},
+		{
+			name: "logical properties in rtl",
+			html: `<div dir="rtl"><p style="margin-inline-start: 1em; text-align: start">نص</p></div>`,
+		},
+		{
+			name: "physical margin in ltr",
+			html: `<p style="margin-left: 1em">text</p>`,
+		},
+		{
+			name: "ltr island inside rtl",
+			html: `<div dir="rtl"><p dir="ltr" style="padding-right: 2px">Next →</p></div>`,
+		},
+		{
+			name:     "arrow glyph in rtl",
+			html:     `<div dir="rtl"><a href="/next">التالي →</a></div>`,
+			wantRule: rules.RuleDirConsistency,
+		},
+		{
+			name: "arrow glyph in ltr",
+			html: `<a href="/next">Next →</a>`,
+		},
+	}
+
+	l := linter.New(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := l.LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleDirConsistency, tt.wantRule)
+		})
+	}
+}
@@ -1,9 +1,14 @@
 package linter_test
 
 import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/toba/go-html-validate/linter"
+	"github.com/toba/go-html-validate/parser"
 	"github.com/toba/go-html-validate/rules"
 )
 
@@ -368,3 +373,802 @@ content
 		})
 	}
 }
+
+func TestLintContent_TemplateActionPlacement(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		wantRule string
+	}{
+		{
+			name: "conditional attribute value",
+			html: `<div class="{{if .Active}}active{{else}}idle{{end}}"></div>`,
+		},
+		{
+			name: "conditional attributes within tag",
+			html: `<input type="text" aria-label="q" {{if .Required}}required{{end}}>`,
+		},
+		{
+			name: "conditional elements",
+			html: `{{if .Show}}<p>{{.Text}}</p>{{else}}<p>None</p>{{end}}`,
+		},
+		{
+			name: "quotes and brackets inside actions",
+			html: `<a href="{{printf "%s>" .URL}}">{{if gt .N 1}}many{{end}}</a>`,
+		},
+		{
+			name: "conditional attribute after tag name",
+			html: `<ul><li{{if .Active}} class="active"{{end}}>Home</li></ul>`,
+		},
+		{
+			name: "conditional boolean attribute after tag name",
+			html: `<select><option{{if .Sel}} selected{{end}}>One</option></select>`,
+		},
+		{
+			name:     "conditional spans from attribute to text",
+			html:     `<div class="{{if .Active}}active">On{{else}}idle">Off{{end}}</div>`,
+			wantRule: rules.RuleTemplateActionPlacement,
+		},
+		{
+			name:     "conditional spans two tags",
+			html:     `<ul><li {{if .First}}class="first"></li><li {{end}}>Item</li></ul>`,
+			wantRule: rules.RuleTemplateActionPlacement,
+		},
+		{
+			name:     "dynamic tag name",
+			html:     `<{{.Tag}} class="title">Hi</{{.Tag}}>`,
+			wantRule: rules.RuleTemplateActionPlacement,
+		},
+		{
+			name:     "conditional tag name suffix",
+			html:     `<h{{if .Big}}1{{else}}2{{end}}>Title</h2>`,
+			wantRule: rules.RuleTemplateActionPlacement,
+		},
+		{
+			name:     "tag name suffix",
+			html:     `<h{{.Level}}>Title</h{{.Level}}>`,
+			wantRule: rules.RuleTemplateActionPlacement,
+		},
+	}
+
+	l := linter.New(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := l.LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleTemplateActionPlacement, tt.wantRule)
+		})
+	}
+}
+
+func TestLintContent_TemplateBranches(t *testing.T) {
+	content := []byte(`<img src="a.png" {{if .Alt}}alt="{{.Alt}}"{{else}}title="photo"{{end}}>
+{{if .Swap}}<div hx-get="/a" hx-swap="outerHTML"></div>{{else}}
+<div hx-get="/b" hx-swap="sideways"></div>{{end}}`)
+
+	tests := []struct {
+		name     string
+		branches bool
+		wantImg  bool
+		wantSwap bool
+	}{
+		{name: "if-branch only"},
+		{name: "all branches", branches: true, wantImg: true, wantSwap: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			cfg.Frameworks.HTMX = true
+			cfg.TemplateBranches = tt.branches
+			l := linter.New(cfg)
+			results, err := l.LintContent("test.html", content)
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+
+			var gotImg, gotSwap bool
+			for _, r := range results {
+				switch r.Rule {
+				case rules.RuleImgAlt:
+					gotImg = true
+				case rules.RuleHTMXAttributes:
+					gotSwap = true
+					if r.Line != 3 {
+						t.Errorf("htmx-attributes reported on line %d, want 3", r.Line)
+					}
+				}
+			}
+			if gotImg != tt.wantImg {
+				t.Errorf("img-alt reported = %v, want %v", gotImg, tt.wantImg)
+			}
+			if gotSwap != tt.wantSwap {
+				t.Errorf("htmx-attributes reported = %v, want %v: %v", gotSwap, tt.wantSwap, results)
+			}
+		})
+	}
+}
+
+func TestLintContent_JetDialect(t *testing.T) {
+	content := `{{ extends "layout.jet" }}
+{* page body *}
+{{ block body() }}
+	{{ try }}
+		<img src="{{ user.Avatar }}">
+	{{ catch err }}
+		<p>{{ err }}</p>
+	{{ end }}
+{{ end }}`
+
+	tests := []struct {
+		name        string
+		dialect     parser.Dialect
+		wantSyntax  bool
+		wantImgLine int
+	}{
+		{name: "go", dialect: parser.DialectGo, wantSyntax: true},
+		{name: "jet", dialect: parser.DialectJet, wantImgLine: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			cfg.TemplateDialect = tt.dialect
+			results, err := linter.New(cfg).LintContent("page.html", []byte(content))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			var syntax bool
+			imgLine := 0
+			for _, r := range results {
+				switch r.Rule {
+				case rules.RuleTemplateSyntaxValid:
+					syntax = true
+				case rules.RuleImgAlt:
+					imgLine = r.Line
+				}
+			}
+			if syntax != tt.wantSyntax {
+				t.Errorf("template-syntax-valid reported = %v, want %v: %v", syntax, tt.wantSyntax, results)
+			}
+			if tt.wantImgLine != 0 && imgLine != tt.wantImgLine {
+				t.Errorf("img-alt line = %d, want %d: %v", imgLine, tt.wantImgLine, results)
+			}
+		})
+	}
+}
+
+func TestLintContent_Pongo2Dialect(t *testing.T) {
+	content := `{% extends "base.html" %}
+{# page body #}
+{% block content %}
+	{% for user in users %}
+		<img src="{{ user.avatar }}">
+	{% empty %}
+		<p>{% trans "No users" %}</p>
+	{% endfor %}
+{% endblock %}`
+
+	tests := []struct {
+		name        string
+		filename    string
+		dialect     parser.Dialect
+		content     string
+		wantSyntax  []string
+		wantImgLine int
+	}{
+		{name: "configured", filename: "page.html", dialect: parser.DialectPongo2, content: content, wantImgLine: 5},
+		{name: "by extension", filename: "page.pongo2", content: content, wantImgLine: 5},
+		{
+			name:       "mismatched end tag",
+			filename:   "page.django",
+			content:    "{% if user %}\n<p>{{ user.name }}</p>\n{% endfor %}",
+			wantSyntax: []string{"3:1:'{% endfor %}' closes '{% if %}' - expected '{% endif %}'"},
+		},
+		{
+			name:       "unclosed block",
+			filename:   "page.django",
+			content:    "{% comment %}{% if %}{% endcomment %}\n{% for x in xs %}<p>{{ x }}</p>",
+			wantSyntax: []string{"2:1:unclosed '{% for %}' - missing '{% endfor %}'"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			cfg.TemplateDialect = tt.dialect
+			results, err := linter.New(cfg).LintContent(tt.filename, []byte(tt.content))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			var syntax []string
+			imgLine := 0
+			for _, r := range results {
+				switch r.Rule {
+				case rules.RuleTemplateSyntaxValid:
+					syntax = append(syntax, fmt.Sprintf("%d:%d:%s", r.Line, r.Col, r.Message))
+				case rules.RuleImgAlt:
+					imgLine = r.Line
+				}
+			}
+			if !slices.Equal(syntax, tt.wantSyntax) {
+				t.Errorf("template-syntax-valid = %q, want %q", syntax, tt.wantSyntax)
+			}
+			if imgLine != tt.wantImgLine {
+				t.Errorf("img-alt line = %d, want %d: %v", imgLine, tt.wantImgLine, results)
+			}
+		})
+	}
+}
+
+func TestLintContent_HandlebarsDialect(t *testing.T) {
+	content := `{{!-- user list --}}
+{{> header}}
+<ul>
+	{{#each users}}
+		<li><img src="{{avatar}}"></li>
+	{{else}}
+		<li>{{{emptyMessage}}}</li>
+	{{/each}}
+</ul>`
+
+	tests := []struct {
+		name        string
+		filename    string
+		dialect     parser.Dialect
+		content     string
+		wantSyntax  []string
+		wantImgLine int
+	}{
+		{name: "configured", filename: "users.html", dialect: parser.DialectHandlebars, content: content, wantImgLine: 5},
+		{name: "by extension", filename: "users.hbs", content: content, wantImgLine: 5},
+		{
+			name:       "mismatched close",
+			filename:   "users.hbs",
+			content:    "{{#if user}}\n<p>{{user.name}}</p>\n{{/each}}",
+			wantSyntax: []string{"3:1:'{{/each}}' closes '{{#if}}' - expected '{{/if}}'"},
+		},
+		{
+			name:       "unclosed section",
+			filename:   "users.mustache",
+			content:    "{{! {{#if}} }}\n{{^items}}<p>none</p>",
+			wantSyntax: []string{"2:1:unclosed '{{^items}}' - missing '{{/items}}'"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			cfg.TemplateDialect = tt.dialect
+			results, err := linter.New(cfg).LintContent(tt.filename, []byte(tt.content))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			var syntax []string
+			imgLine := 0
+			for _, r := range results {
+				switch r.Rule {
+				case rules.RuleTemplateSyntaxValid:
+					syntax = append(syntax, fmt.Sprintf("%d:%d:%s", r.Line, r.Col, r.Message))
+				case rules.RuleImgAlt:
+					imgLine = r.Line
+				}
+			}
+			if !slices.Equal(syntax, tt.wantSyntax) {
+				t.Errorf("template-syntax-valid = %q, want %q", syntax, tt.wantSyntax)
+			}
+			if imgLine != tt.wantImgLine {
+				t.Errorf("img-alt line = %d, want %d: %v", imgLine, tt.wantImgLine, results)
+			}
+		})
+	}
+}
+
+func TestLintContent_NoHardcodedText(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		optIn    bool
+		options  map[string]any
+		wantRule string
+	}{
+		{
+			name: "disabled by default",
+			html: `<p>Welcome back</p>`,
+		},
+		{
+			name:     "literal text",
+			html:     `<p>Welcome back</p>`,
+			optIn:    true,
+			wantRule: rules.RuleNoHardcodedText,
+		},
+		{
+			name:  "translated text",
+			html:  `<p>{{T "home.welcome"}}</p>`,
+			optIn: true,
+		},
+		{
+			name:  "translated text with data",
+			html:  `<p>{{i18n "greeting" .User.Name}}: {{.Count}}</p>`,
+			optIn: true,
+		},
+		{
+			name:     "literal text around data",
+			html:     `<p>Hello {{.Name}}</p>`,
+			optIn:    true,
+			wantRule: rules.RuleNoHardcodedText,
+		},
+		{
+			name:     "untranslated string literal",
+			html:     `<p>{{printf "%d items" .Count}}</p>`,
+			optIn:    true,
+			wantRule: rules.RuleNoHardcodedText,
+		},
+		{
+			name:  "symbols and references only",
+			html:  `<p>{{T "a"}} &middot; {{T "b"}} | 2024 &copy;</p>`,
+			optIn: true,
+		},
+		{
+			name:     "literal alt text",
+			html:     `<img src="logo.png" alt="Company logo">`,
+			optIn:    true,
+			wantRule: rules.RuleNoHardcodedText,
+		},
+		{
+			name:  "translated placeholder",
+			html:  `<input type="search" placeholder="{{T "search.placeholder"}}">`,
+			optIn: true,
+		},
+		{
+			name:  "code element",
+			html:  `<p>{{T "run"}} <code>make build</code></p>`,
+			optIn: true,
+		},
+		{
+			name:  "script content",
+			html:  `<script>console.log("ready")</script>`,
+			optIn: true,
+		},
+		{
+			name:     "japanese text",
+			html:     `<p>保存</p>`,
+			optIn:    true,
+			wantRule: rules.RuleNoHardcodedText,
+		},
+		{
+			name:    "custom translate pattern",
+			html:    `<p>{{msg "home.welcome"}}</p>`,
+			optIn:   true,
+			options: map[string]any{"translate-pattern": `^\{\{-?\s*msg\b`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			if tt.optIn {
+				cfg.EnabledRules = []string{rules.RuleNoHardcodedText}
+			}
+			cfg.RuleOptions = map[string]map[string]any{rules.RuleNoHardcodedText: tt.options}
+			results, err := linter.New(cfg).LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleNoHardcodedText, tt.wantRule)
+		})
+	}
+}
+
+func TestLintContent_NoDebugArtifacts(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		opts     map[string]any
+		wantRule string
+	}{
+		{
+			name:     "TODO in HTML comment",
+			html:     `<div><!-- TODO: remove before launch --><p>Hi</p></div>`,
+			wantRule: rules.RuleNoDebugArtifacts,
+		},
+		{
+			name:     "FIXME in HTML comment",
+			html:     `<div><!-- FIXME wrong price --><p>Hi</p></div>`,
+			wantRule: rules.RuleNoDebugArtifacts,
+		},
+		{
+			name: "TODO in template comment",
+			html: `<div>{{/* TODO: paginate */}}<p>Hi</p></div>`,
+		},
+		{
+			name: "lowercase word in comment",
+			html: `<div><!-- todo list widget --><p>Hi</p></div>`,
+		},
+		{
+			name:     "debug block comments",
+			html:     `<div><!-- debug --><pre>{{.}}</pre><!-- /debug --></div>`,
+			wantRule: rules.RuleNoDebugArtifacts,
+		},
+		{
+			name: "comment mentioning debugging later",
+			html: `<div><!-- Layout for the debugger docs --><p>Hi</p></div>`,
+		},
+		{
+			name:     "debug class",
+			html:     `<div class="panel debug-grid"><p>Hi</p></div>`,
+			wantRule: rules.RuleNoDebugArtifacts,
+		},
+		{
+			name:     "test-only class",
+			html:     `<button type="button" class="test-only">Reset</button>`,
+			wantRule: rules.RuleNoDebugArtifacts,
+		},
+		{
+			name: "similar class",
+			html: `<div class="debugger-panel"><p>Hi</p></div>`,
+		},
+		{
+			name:     "printf Go-syntax dump",
+			html:     `<pre>{{printf "%#v" .User}}</pre>`,
+			wantRule: rules.RuleNoDebugArtifacts,
+		},
+		{
+			name:     "piped printf dump",
+			html:     `<pre>{{- . | printf "%#v" -}}</pre>`,
+			wantRule: rules.RuleNoDebugArtifacts,
+		},
+		{
+			name: "printf formatting",
+			html: `<p>{{printf "%.2f" .Price}}</p>`,
+		},
+		{
+			name:     "custom marker",
+			html:     `<div><!-- XXX check copy --><p>Hi</p></div>`,
+			opts:     map[string]any{"markers": []any{"XXX"}},
+			wantRule: rules.RuleNoDebugArtifacts,
+		},
+		{
+			name: "custom classes replace defaults",
+			html: `<div class="debug"><p>Hi</p></div>`,
+			opts: map[string]any{"classes": []any{"qa-*"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			cfg.EnabledRules = []string{rules.RuleNoDebugArtifacts}
+			if tt.opts != nil {
+				cfg.RuleOptions = map[string]map[string]any{rules.RuleNoDebugArtifacts: tt.opts}
+			}
+			results, err := linter.New(cfg).LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleNoDebugArtifacts, tt.wantRule)
+		})
+	}
+}
+
+func TestLintContent_NoUnrenderedPlaceholder(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		wantRule string
+	}{
+		{
+			name: "rendered page",
+			html: `<title>Order 1042</title><meta name="description" content="Your order has shipped"><p>Hello Ada</p>`,
+		},
+		{
+			name:     "template action in text",
+			html:     `<p>Hello {{.Name}}</p>`,
+			wantRule: rules.RuleNoUnrenderedPlaceholder,
+		},
+		{
+			name:     "unclosed action in title",
+			html:     `<title>{{ .Title</title>`,
+			wantRule: rules.RuleNoUnrenderedPlaceholder,
+		},
+		{
+			name:     "undefined in meta description",
+			html:     `<meta name="description" content="undefined">`,
+			wantRule: rules.RuleNoUnrenderedPlaceholder,
+		},
+		{
+			name:     "object string",
+			html:     `<span>[object Object]</span>`,
+			wantRule: rules.RuleNoUnrenderedPlaceholder,
+		},
+		{
+			name:     "Go format error",
+			html:     `<p>Total: %!d(string=12)</p>`,
+			wantRule: rules.RuleNoUnrenderedPlaceholder,
+		},
+		{
+			name: "words containing undefined",
+			html: `<p>The value is undefinedness itself.</p>`,
+		},
+		{
+			name: "script content",
+			html: `<script>if (x === undefined) { x = "{{" }</script>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			cfg.EnabledRules = []string{rules.RuleNoUnrenderedPlaceholder}
+			results, err := linter.New(cfg).LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleNoUnrenderedPlaceholder, tt.wantRule)
+		})
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	dir := t.TempDir()
+	page := writeFile(t, dir, "page.html", `<ul>{{range .Items}}<li id="item-{{.ID | slug}}">{{.Name}}</li>{{end}}</ul>{{template "footer.html" .}}{{if eq .Count 2}}<p>two</p>{{end}}{{.Missing}}`)
+	footer := writeFile(t, dir, "footer.html", `<footer>{{.Footer}}</footer>`)
+	data := map[string]any{
+		"Items":  []any{map[string]any{"ID": 1, "Name": "A"}, map[string]any{"ID": 1, "Name": "B & C"}},
+		"Count":  2,
+		"Footer": "end",
+	}
+
+	out, err := linter.RenderTemplate([]string{page, footer}, data, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<ul><li id="item-1">A</li><li id="item-1">B &amp; C</li></ul><footer>end</footer><p>two</p>`
+	if string(out) != want {
+		t.Errorf("RenderTemplate() = %q, want %q", out, want)
+	}
+
+	results, err := linter.New(linter.DefaultConfig()).LintContent(page, out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.ContainsFunc(results, func(r rules.Result) bool { return r.Rule == rules.RuleDuplicateID }) {
+		t.Errorf("expected %s in the rendered output, got %v", rules.RuleDuplicateID, results)
+	}
+
+	link := writeFile(t, dir, "link.html", `<a href="{{safeURL .URL}}">x</a>`)
+	linkData := map[string]any{"URL": "javascript:go()"}
+	for _, tt := range []struct {
+		funcs map[string]rules.TemplateFunc
+		want  string
+	}{
+		{nil, `<a href="#ZgotmplZ">x</a>`},
+		{map[string]rules.TemplateFunc{"safeURL": {Args: 1, Returns: rules.FuncReturnsURL}}, `<a href="javascript:go%28%29">x</a>`},
+	} {
+		out, err := linter.RenderTemplate([]string{link}, linkData, tt.funcs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(out) != tt.want {
+			t.Errorf("RenderTemplate() with %v = %q, want %q", tt.funcs, out, tt.want)
+		}
+	}
+
+	bad := writeFile(t, dir, "bad.html", `{{if}}`)
+	if _, err := linter.RenderTemplate([]string{bad}, nil, nil); err == nil {
+		t.Error("expected an error for an invalid template")
+	}
+}
+
+func TestReadTemplateData(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    any
+		wantErr string
+	}{
+		{
+			name:    "json",
+			file:    "data.json",
+			content: `{"Title": "Home", "Count": 3, "Ratio": 0.5, "Tags": ["a"]}`,
+			want:    map[string]any{"Title": "Home", "Count": 3, "Ratio": 0.5, "Tags": []any{"a"}},
+		},
+		{
+			name: "yaml mappings and sequences",
+			file: "data.yaml",
+			content: `---
+# page data
+Title: Home # trailing comment
+Count: 3
+Draft: false
+Owner: ~
+Items:
+  - ID: 1
+    Name: "A #1"
+  - ID: 2
+    Name: 'it''s'
+Tags:
+- a
+- b
+Flow: {"x": [1, 2]}
+`,
+			want: map[string]any{
+				"Title": "Home",
+				"Count": 3,
+				"Draft": false,
+				"Owner": nil,
+				"Items": []any{
+					map[string]any{"ID": 1, "Name": "A #1"},
+					map[string]any{"ID": 2, "Name": "it's"},
+				},
+				"Tags": []any{"a", "b"},
+				"Flow": map[string]any{"x": []any{1, 2}},
+			},
+		},
+		{
+			name:    "yaml nested mapping",
+			file:    "data.yml",
+			content: "User:\n  Name: Ann\n  Roles:\n    - admin\n",
+			want:    map[string]any{"User": map[string]any{"Name": "Ann", "Roles": []any{"admin"}}},
+		},
+		{
+			name:    "yaml block scalar",
+			file:    "data.yaml",
+			content: "Body: |\n  text\n",
+			wantErr: "block scalars are not supported",
+		},
+		{
+			name:    "yaml bad indentation",
+			file:    "data.yaml",
+			content: "A: 1\n    B: 2\n",
+			wantErr: "line 2: unexpected indentation",
+		},
+		{
+			name:    "invalid json",
+			file:    "data.json",
+			content: `{"Title": }`,
+			wantErr: "data.json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeFile(t, t.TempDir(), tt.file, tt.content)
+			got, err := linter.ReadTemplateData(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ReadTemplateData() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadTemplateData() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLintContent_TemplateFunctions(t *testing.T) {
+	funcs := map[string]rules.TemplateFunc{
+		"safeURL":  {Args: 1, Returns: rules.FuncReturnsURL},
+		"markdown": {Args: 1, Returns: rules.FuncReturnsHTML},
+		"attrs":    {Args: 1, Returns: rules.FuncReturnsAttr},
+		"t":        {Args: 1, Variadic: true},
+		"upper":    {Args: 1},
+	}
+
+	tests := []struct {
+		name     string
+		html     string
+		funcs    map[string]rules.TemplateFunc
+		wantRule string
+		wantMsg  string
+	}{
+		{
+			name:  "undeclared function without declarations",
+			html:  `<p>{{ shout .Name }}</p>`,
+			funcs: map[string]rules.TemplateFunc{},
+		},
+		{
+			name: "declared and builtin functions",
+			html: `<p>{{ t "greeting" .Name }} {{ .Name | upper }} {{ len .Items | printf "%d" }}</p>`,
+		},
+		{
+			name:     "undeclared function",
+			html:     `<p>{{ shout .Name }}</p>`,
+			wantRule: rules.RuleTemplateSyntaxValid,
+			wantMsg:  `function "shout" is not declared`,
+		},
+		{
+			name:     "undeclared function in pipeline",
+			html:     `{{ if .Items }}<p>{{ .Name | shout }}</p>{{ end }}`,
+			wantRule: rules.RuleTemplateSyntaxValid,
+			wantMsg:  `function "shout" is not declared`,
+		},
+		{
+			name:     "wrong argument count",
+			html:     `<p>{{ upper .First .Last }}</p>`,
+			wantRule: rules.RuleTemplateSyntaxValid,
+			wantMsg:  `function "upper" takes 1 argument(s), called with 2`,
+		},
+		{
+			name:     "piped value counts as argument",
+			html:     `<p>{{ .Name | upper .Prefix }}</p>`,
+			wantRule: rules.RuleTemplateSyntaxValid,
+			wantMsg:  "called with 2",
+		},
+		{
+			name:     "too few variadic arguments",
+			html:     `<p>{{ t }}</p>`,
+			wantRule: rules.RuleTemplateSyntaxValid,
+			wantMsg:  `function "t" takes at least 1 argument(s), called with 0`,
+		},
+		{
+			name:     "url helper in href",
+			html:     `<a href="{{ safeURL .Link }}">Link</a>`,
+			wantRule: rules.RuleTemplateEscapingContext,
+			wantMsg:  "safeURL returns template.URL",
+		},
+		{
+			name: "url helper outside url attribute",
+			html: `<p title="{{ safeURL .Link }}">Link</p>`,
+		},
+		{
+			name:     "attr helper inside attribute value",
+			html:     `<div class="{{ attrs .Extra }}"></div>`,
+			wantRule: rules.RuleTemplateEscapingContext,
+			wantMsg:  "attrs returns template.HTMLAttr",
+		},
+		{
+			name:     "html helper inside attribute value",
+			html:     `<img src="a.png" alt="{{ .Body | markdown }}">`,
+			wantRule: rules.RuleTemplateEscapingContext,
+			wantMsg:  "markdown returns template.HTML",
+		},
+		{
+			name: "html helper in text",
+			html: `<div>{{ markdown .Body }}</div>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			cfg.TemplateFuncs = funcs
+			if tt.funcs != nil {
+				cfg.TemplateFuncs = tt.funcs
+			}
+			cfg.RuleSeverity[rules.RuleTemplateEscapingContext] = rules.Info
+			l := linter.New(cfg)
+
+			results, err := l.LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []rules.Result
+			for _, r := range results {
+				if r.Rule == rules.RuleTemplateSyntaxValid || r.Rule == rules.RuleTemplateEscapingContext {
+					got = append(got, r)
+				}
+			}
+
+			if tt.wantRule == "" {
+				if len(got) > 0 {
+					t.Errorf("expected no findings, got %v", got)
+				}
+				return
+			}
+			if !slices.ContainsFunc(got, func(r rules.Result) bool {
+				return r.Rule == tt.wantRule && strings.Contains(r.Message, tt.wantMsg)
+			}) {
+				t.Errorf("expected %s finding containing %q, got %v", tt.wantRule, tt.wantMsg, got)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/toba/go-html-validate/linter"
+	"github.com/toba/go-html-validate/parser"
 	"github.com/toba/go-html-validate/rules"
 )
 
@@ -79,10 +80,49 @@ func TestLintContent_ValidID(t *testing.T) {
 	}
 }
 
+func TestLintContent_NoQuirksMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		wantRule string
+	}{
+		{
+			name: "html5 doctype",
+			html: `<!DOCTYPE html><html lang="en"><head><title>T</title></head><body></body></html>`,
+		},
+		{
+			name: "missing doctype is left to missing-doctype",
+			html: `<html lang="en"><head><title>T</title></head><body></body></html>`,
+		},
+		{
+			name:     "html 4.01 transitional",
+			html:     `<!DOCTYPE HTML PUBLIC "-//W3C//DTD HTML 4.01 Transitional//EN"><html lang="en"><head><title>T</title></head><body></body></html>`,
+			wantRule: rules.RuleNoQuirksMode,
+		},
+		{
+			name:     "xhtml 1.0 transitional",
+			html:     `<!DOCTYPE html PUBLIC "-//W3C//DTD XHTML 1.0 Transitional//EN" "http://www.w3.org/TR/xhtml1/DTD/xhtml1-transitional.dtd"><html lang="en"><head><title>T</title></head><body></body></html>`,
+			wantRule: rules.RuleNoQuirksMode,
+		},
+		{
+			name: "html 4.01 strict",
+			html: `<!DOCTYPE HTML PUBLIC "-//W3C//DTD HTML 4.01//EN" "http://www.w3.org/TR/html4/strict.dtd"><html lang="en"><head><title>T</title></head><body></body></html>`,
+		},
+	}
+
+	l := linter.New(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := l.LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleNoQuirksMode, tt.wantRule)
+		})
+	}
+}
+
 func TestLintContent_RequireLang(t *testing.T) {
-	// Note: LintContent uses ParseFragment which doesn't preserve html element structure.
-	// The require-lang rule is tested via LintFile in integration tests for full documents.
-	// These tests verify the rule doesn't flag fragments.
 	tests := []struct {
 		name     string
 		html     string
@@ -96,6 +136,24 @@ func TestLintContent_RequireLang(t *testing.T) {
 			name: "fragment with main content (no flag)",
 			html: `<main>Content</main>`,
 		},
+		{
+			name:     "document without lang",
+			html:     `<!DOCTYPE html><html><head><title>T</title></head><body></body></html>`,
+			wantRule: rules.RuleRequireLang,
+		},
+		{
+			name: "document with lang",
+			html: `<!DOCTYPE html><html lang="en"><head><title>T</title></head><body></body></html>`,
+		},
+		{
+			name:     "document directive on fragment content",
+			html:     `<!-- htmlint:document --><div>Content</div>`,
+			wantRule: rules.RuleRequireLang,
+		},
+		{
+			name: "fragment directive on document content",
+			html: `<!-- htmlint:fragment --><html><body>Content</body></html>`,
+		},
 	}
 
 	l := linter.New(nil)
@@ -110,6 +168,51 @@ func TestLintContent_RequireLang(t *testing.T) {
 	}
 }
 
+func TestLintContent_ParseModePatterns(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		html     string
+		wantRule string
+	}{
+		{
+			name:     "document glob forces document parsing",
+			filename: "layouts/base.gohtml",
+			html:     `<div>Content</div>`,
+			wantRule: rules.RuleRequireLang,
+		},
+		{
+			name:     "fragment glob forces fragment parsing",
+			filename: "partials/head.gohtml",
+			html:     `<html><body>Content</body></html>`,
+		},
+		{
+			name:     "directive overrides glob",
+			filename: "layouts/base.gohtml",
+			html:     `<!-- htmlint:fragment --><div>Content</div>`,
+		},
+		{
+			name:     "unmatched file uses detection",
+			filename: "pages/index.html",
+			html:     `<div>Content</div>`,
+		},
+	}
+
+	cfg := linter.DefaultConfig()
+	cfg.DocumentPatterns = []string{"layouts/**"}
+	cfg.FragmentPatterns = []string{"partials/"}
+	l := linter.New(cfg)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := l.LintContent(tt.filename, []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleRequireLang, tt.wantRule)
+		})
+	}
+}
+
 func TestLintContent_ElementName(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -390,6 +493,268 @@ func TestLintContent_AllowedLinks(t *testing.T) {
 	}
 }
 
+func TestLintContent_AllowedLinksOptions(t *testing.T) {
+	options := map[string]any{
+		"deny-protocols": []any{"ftp:", "sms"},
+		"https-hosts":    []any{"example.com", "*.example.org"},
+		"own-domains":    []any{"mysite.com"},
+	}
+	tests := []struct {
+		name     string
+		html     string
+		options  map[string]any
+		wantRule string
+	}{
+		{
+			name: "ftp allowed by default",
+			html: `<a href="ftp://files.example.net/a.zip">Download</a>`,
+		},
+		{
+			name:     "denied protocol",
+			html:     `<a href="ftp://files.example.net/a.zip">Download</a>`,
+			options:  options,
+			wantRule: rules.RuleAllowedLinks,
+		},
+		{
+			name:     "denied protocol case insensitive",
+			html:     `<a href="SMS:+15550100">Text us</a>`,
+			options:  options,
+			wantRule: rules.RuleAllowedLinks,
+		},
+		{
+			name:    "mailto not denied",
+			html:    `<a href="mailto:hi@example.com">Mail</a>`,
+			options: options,
+		},
+		{
+			name:     "protocol outside allowlist",
+			html:     `<a href="tel:+15550100">Call</a>`,
+			options:  map[string]any{"allow-protocols": []any{"https", "mailto"}},
+			wantRule: rules.RuleAllowedLinks,
+		},
+		{
+			name:    "relative link with allowlist",
+			html:    `<a href="/about">About</a>`,
+			options: map[string]any{"allow-protocols": []any{"https"}},
+		},
+		{
+			name:     "http to https host",
+			html:     `<a href="http://example.com/docs">Docs</a>`,
+			options:  options,
+			wantRule: rules.RuleAllowedLinks,
+		},
+		{
+			name:     "http to https subdomain",
+			html:     `<a href="http://api.example.org/">API</a>`,
+			options:  options,
+			wantRule: rules.RuleAllowedLinks,
+		},
+		{
+			name:    "https to https host",
+			html:    `<a href="https://example.com/docs">Docs</a>`,
+			options: options,
+		},
+		{
+			name:    "http to other host",
+			html:    `<a href="http://other.net/">Other</a>`,
+			options: options,
+		},
+		{
+			name:     "absolute link to own domain",
+			html:     `<a href="https://mysite.com/about">About</a>`,
+			options:  options,
+			wantRule: rules.RuleAllowedLinks,
+		},
+		{
+			name:     "protocol-relative link to own domain",
+			html:     `<a href="//mysite.com/about">About</a>`,
+			options:  options,
+			wantRule: rules.RuleAllowedLinks,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			cfg.RuleOptions = map[string]map[string]any{rules.RuleAllowedLinks: tt.options}
+			results, err := linter.New(cfg).LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleAllowedLinks, tt.wantRule)
+		})
+	}
+}
+
+func TestLintContent_ValidContactLink(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		wantRule string
+	}{
+		{
+			name: "global tel number",
+			html: `<a href="tel:+1-201-555-0123">Call</a>`,
+		},
+		{
+			name: "local tel number with phone-context",
+			html: `<a href="tel:7042;phone-context=example.com">Call</a>`,
+		},
+		{
+			name:     "tel with spaces",
+			html:     `<a href="tel:+1 201 555 0123">Call</a>`,
+			wantRule: rules.RuleValidContactLink,
+		},
+		{
+			name:     "tel with parentheses",
+			html:     `<a href="tel:+1(201)555-0123">Call</a>`,
+			wantRule: rules.RuleValidContactLink,
+		},
+		{
+			name:     "tel with letters",
+			html:     `<a href="tel:+1-800-FLOWERS">Call</a>`,
+			wantRule: rules.RuleValidContactLink,
+		},
+		{
+			name:     "local tel number",
+			html:     `<a href="tel:555-0123">Call</a>`,
+			wantRule: rules.RuleValidContactLink,
+		},
+		{
+			name: "simple mailto",
+			html: `<a href="mailto:hi@example.com">Mail</a>`,
+		},
+		{
+			name: "mailto with encoded query",
+			html: `<a href="mailto:a@example.com,b@example.com?subject=Hello%20there&amp;body=Hi%21">Mail</a>`,
+		},
+		{
+			name: "mailto with to header only",
+			html: `<a href="mailto:?to=hi@example.com&amp;subject=Hi">Mail</a>`,
+		},
+		{
+			name: "mailto share link without address",
+			html: `<a href="mailto:?subject=Look&amp;body=https%3A%2F%2Fexample.com%2F">Share</a>`,
+		},
+		{
+			name:     "mailto with unencoded subject",
+			html:     `<a href="mailto:hi@example.com?subject=Hello there">Mail</a>`,
+			wantRule: rules.RuleValidContactLink,
+		},
+		{
+			name:     "mailto with bad percent escape",
+			html:     `<a href="mailto:hi@example.com?subject=100%">Mail</a>`,
+			wantRule: rules.RuleValidContactLink,
+		},
+		{
+			name:     "mailto with invalid address",
+			html:     `<a href="mailto:hi.example.com">Mail</a>`,
+			wantRule: rules.RuleValidContactLink,
+		},
+		{
+			name:     "mailto without address",
+			html:     `<a href="mailto:">Mail</a>`,
+			wantRule: rules.RuleValidContactLink,
+		},
+		{
+			name:     "mailto with malformed query",
+			html:     `<a href="mailto:hi@example.com?Hello">Mail</a>`,
+			wantRule: rules.RuleValidContactLink,
+		},
+		{
+			name: "template address (skip)",
+			html: `<a href="mailto:{{.Email}}">Mail</a>`,
+		},
+	}
+
+	l := linter.New(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := l.LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleValidContactLink, tt.wantRule)
+		})
+	}
+}
+
+func TestLintContent_URLEncoding(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		wantRule string
+	}{
+		{
+			name: "encoded URL",
+			html: `<a href="/search?q=a%20b&amp;page=2">Search</a>`,
+		},
+		{
+			name: "surrounding whitespace",
+			html: `<a href=" /about ">About</a>`,
+		},
+		{
+			name:     "unencoded space",
+			html:     `<a href="/my page.html">Page</a>`,
+			wantRule: rules.RuleURLEncoding,
+		},
+		{
+			name:     "raw double quote",
+			html:     `<img src='/img/"x".png' alt="x">`,
+			wantRule: rules.RuleURLEncoding,
+		},
+		{
+			name:     "ampersand before entity name",
+			html:     `<a href="/list?sort=1&copy=2">List</a>`,
+			wantRule: rules.RuleURLEncoding,
+		},
+		{
+			name: "ampersand before unknown name",
+			html: `<a href="/list?sort=1&page=2">List</a>`,
+		},
+		{
+			name:     "template value in query string",
+			html:     `<a href="/search?q={{.Query}}">Search</a>`,
+			wantRule: rules.RuleURLEncoding,
+		},
+		{
+			name: "template value piped through urlquery",
+			html: `<a href="/search?q={{.Query | urlquery}}">Search</a>`,
+		},
+		{
+			name: "template value in path",
+			html: `<a href="/users/{{.ID}}">User</a>`,
+		},
+		{
+			name: "template control flow in query string",
+			html: `<a href="/list?{{if .Sort}}sort=1{{end}}">List</a>`,
+		},
+	}
+
+	l := linter.New(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := l.LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleURLEncoding, tt.wantRule)
+		})
+	}
+}
+
+func TestLintContent_URLEncodingFix(t *testing.T) {
+	content := []byte(`<a href="/list?sort=1&copy=2">List</a>`)
+	results, err := linter.New(nil).LintContent("test.html", content)
+	if err != nil {
+		t.Fatalf("LintContent() error = %v", err)
+	}
+	fixed, _ := linter.ApplyFixes(content, results)
+	if want := `<a href="/list?sort=1&amp;copy=2">List</a>`; string(fixed) != want {
+		t.Errorf("ApplyFixes() = %q, want %q", fixed, want)
+	}
+}
+
 func TestLintContent_LongTitle(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -702,3 +1067,1468 @@ func TestLintContent_UnrecognizedCharRef(t *testing.T) {
 		})
 	}
 }
+
+func TestLintContent_UnrecognizedCharRefOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		options  map[string]any
+		wantRule string
+	}{
+		{
+			name: "bare ampersand allowed by default",
+			html: `<p>Tom & Jerry</p><a href="?a=1&b=2">x</a>`,
+		},
+		{
+			name:     "bare ampersand in text",
+			html:     `<p>Tom & Jerry</p>`,
+			options:  map[string]any{"bare-ampersand": true},
+			wantRule: rules.RuleUnrecognizedCharRef,
+		},
+		{
+			name:     "bare ampersand in attribute",
+			html:     `<a href="?a=1&b=2">x</a>`,
+			options:  map[string]any{"bare-ampersand": true},
+			wantRule: rules.RuleUnrecognizedCharRef,
+		},
+		{
+			name:    "escaped ampersands",
+			html:    `<p title="a &amp; b">&#38; &#x26; &amp;</p>`,
+			options: map[string]any{"bare-ampersand": true},
+		},
+		{
+			name:    "ampersands in script, comments, and actions",
+			html:    `<script>if (a && b) {}</script><!-- a & b --><p>{{if and .A .B}}x{{end}}{{"&"}}</p>`,
+			options: map[string]any{"bare-ampersand": true, "bare-less-than": true},
+		},
+		{
+			name: "bare less-than allowed by default",
+			html: `<p>1 < 2</p>`,
+		},
+		{
+			name:     "bare less-than in text",
+			html:     `<p>1 < 2</p>`,
+			options:  map[string]any{"bare-less-than": true},
+			wantRule: rules.RuleUnrecognizedCharRef,
+		},
+		{
+			name:    "less-than in attribute",
+			html:    `<p title="1 < 2">x</p>`,
+			options: map[string]any{"bare-less-than": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			cfg.RuleOptions = map[string]map[string]any{rules.RuleUnrecognizedCharRef: tt.options}
+			results, err := linter.New(cfg).LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleUnrecognizedCharRef, tt.wantRule)
+		})
+	}
+}
+
+func TestLintContent_UnrecognizedCharRefFix(t *testing.T) {
+	content := []byte("<p>Q&A &bogus; 1 < 2</p>")
+	cfg := linter.DefaultConfig()
+	cfg.RuleOptions = map[string]map[string]any{
+		rules.RuleUnrecognizedCharRef: {"bare-ampersand": true, "bare-less-than": true},
+	}
+	results, err := linter.New(cfg).LintContent("test.html", content)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fixed, _ := linter.ApplyFixes(content, results)
+	if want := "<p>Q&amp;A &amp;bogus; 1 &lt; 2</p>"; string(fixed) != want {
+		t.Errorf("fixed = %q, want %q", fixed, want)
+	}
+}
+
+func TestLintContent_TypographicChars(t *testing.T) {
+	tests := []struct {
+		name      string
+		html      string
+		style     string
+		wantFixed string // "" when nothing is reported
+	}{
+		{
+			name:      "literal style converts references",
+			html:      `<p title="it&rsquo;s">don&#8217;t &ndash; wait&hellip;&nbsp;ok &amp; &copy;</p>`,
+			wantFixed: "<p title=\"it’s\">don’t – wait…\u00a0ok &amp; &copy;</p>",
+		},
+		{
+			name: "literal style accepts literal characters",
+			html: "<p>“Hi” — it’s fine…</p>",
+		},
+		{
+			name:      "entity style converts characters and numeric references",
+			html:      "<p title=\"it’s\">“Hi” &#x2014; ok\u00a0now</p>",
+			style:     rules.TypographicEntity,
+			wantFixed: `<p title="it&rsquo;s">&ldquo;Hi&rdquo; &mdash; ok&nbsp;now</p>`,
+		},
+		{
+			name:  "entity style accepts named references",
+			html:  `<p>&ldquo;Hi&rdquo; &mdash; it&rsquo;s&nbsp;fine</p>`,
+			style: rules.TypographicEntity,
+		},
+		{
+			name:  "script, comments, and template actions are skipped",
+			html:  "<script>s = \"it’s\";</script><!-- “x” --><p>{{\"it’s\"}}</p>",
+			style: rules.TypographicEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			cfg.RuleSeverity[rules.RuleTypographicChars] = rules.Warning
+			if tt.style != "" {
+				cfg.RuleOptions = map[string]map[string]any{rules.RuleTypographicChars: {"style": tt.style}}
+			}
+			content := []byte(tt.html)
+			results, err := linter.New(cfg).LintContent("test.html", content)
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			if tt.wantFixed == "" {
+				checkRule(t, results, rules.RuleTypographicChars, "")
+				return
+			}
+			checkRule(t, results, rules.RuleTypographicChars, rules.RuleTypographicChars)
+			var fixes []rules.Result
+			for _, r := range results {
+				if r.Rule == rules.RuleTypographicChars {
+					fixes = append(fixes, r)
+				}
+			}
+			if fixed, _ := linter.ApplyFixes(content, fixes); string(fixed) != tt.wantFixed {
+				t.Errorf("fixed = %q, want %q", fixed, tt.wantFixed)
+			}
+		})
+	}
+}
+
+func TestLintContent_DOMSize(t *testing.T) {
+	deep := strings.Repeat("<div>", 6) + "x" + strings.Repeat("</div>", 6)
+	tests := []struct {
+		name     string
+		html     string
+		optIn    bool
+		options  map[string]any
+		wantRule string
+	}{
+		{
+			name: "disabled by default",
+			html: deep,
+		},
+		{
+			name:  "within default limits",
+			html:  deep,
+			optIn: true,
+		},
+		{
+			name:     "exceeds max depth",
+			html:     deep,
+			optIn:    true,
+			options:  map[string]any{"max-depth": float64(5)},
+			wantRule: rules.RuleDOMSize,
+		},
+		{
+			name:    "at max depth",
+			html:    deep,
+			optIn:   true,
+			options: map[string]any{"max-depth": float64(6)},
+		},
+		{
+			name:     "exceeds max elements",
+			html:     strings.Repeat("<p>x</p>", 10),
+			optIn:    true,
+			options:  map[string]any{"max-elements": float64(9)},
+			wantRule: rules.RuleDOMSize,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			if tt.optIn {
+				cfg.RuleSeverity[rules.RuleDOMSize] = rules.Warning
+			}
+			if tt.options != nil {
+				cfg.RuleOptions = map[string]map[string]any{rules.RuleDOMSize: tt.options}
+			}
+			l := linter.New(cfg)
+			results, err := l.LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleDOMSize, tt.wantRule)
+		})
+	}
+}
+
+func TestLintContent_DOMSizeChain(t *testing.T) {
+	cfg := linter.DefaultConfig()
+	cfg.EnabledRules = []string{rules.RuleDOMSize}
+	cfg.RuleOptions = map[string]map[string]any{rules.RuleDOMSize: {"max-depth": float64(3)}}
+
+	l := linter.New(cfg)
+	results, err := l.LintContent("test.html", []byte("<main>\n<section><ul><li>x</li></ul></section></main>"))
+	if err != nil {
+		t.Fatalf("LintContent() error = %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d: %v", len(results), results)
+	}
+	want := "element nesting depth 4 exceeds 3: main > section > ul > li"
+	if results[0].Message != want {
+		t.Errorf("Message = %q, want %q", results[0].Message, want)
+	}
+	if results[0].Line != 2 || results[0].Col != 14 {
+		t.Errorf("position = %d:%d, want 2:14", results[0].Line, results[0].Col)
+	}
+}
+
+func TestLintContent_InputValueFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		wantRule string
+	}{
+		{
+			name: "ISO date",
+			html: `<input type="date" value="2024-12-31" min="2024-01-01" max="2025-12-31">`,
+		},
+		{
+			name:     "locale date",
+			html:     `<input type="date" value="31/12/2024">`,
+			wantRule: rules.RuleInputValueFormat,
+		},
+		{
+			name:     "impossible date",
+			html:     `<input type="date" max="2024-02-30">`,
+			wantRule: rules.RuleInputValueFormat,
+		},
+		{
+			name: "datetime-local",
+			html: `<input type="datetime-local" value="2024-12-31T18:30" min="2024-01-01 00:00:00.5">`,
+		},
+		{
+			name:     "datetime-local with timezone",
+			html:     `<input type="datetime-local" value="2024-12-31T18:30Z">`,
+			wantRule: rules.RuleInputValueFormat,
+		},
+		{
+			name: "month and week",
+			html: `<input type="month" value="2024-12"><input type="week" value="2024-W52">`,
+		},
+		{
+			name:     "12-hour time",
+			html:     `<input type="time" value="6:30 PM">`,
+			wantRule: rules.RuleInputValueFormat,
+		},
+		{
+			name: "number",
+			html: `<input type="number" value="1234.5" min="-10" max="1e3">`,
+		},
+		{
+			name:     "number with decimal comma",
+			html:     `<input type="number" value="1,5">`,
+			wantRule: rules.RuleInputValueFormat,
+		},
+		{
+			name: "template value (skip)",
+			html: `<input type="date" value="{{.Date}}" min="{{.Year}}-01-01">`,
+		},
+		{
+			name: "text input",
+			html: `<input type="text" value="31/12/2024">`,
+		},
+	}
+
+	l := linter.New(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := l.LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleInputValueFormat, tt.wantRule)
+		})
+	}
+}
+
+func TestLintContent_HandlerSyntax(t *testing.T) {
+	tests := []struct {
+		name    string
+		html    string
+		wantMsg string
+	}{
+		{
+			name: "valid handler",
+			html: `<button onclick="if (confirm('Delete?')) { remove(this.closest('li')) }">x</button>`,
+		},
+		{
+			name: "valid hx-on",
+			html: `<form hx-on:htmx:after-request="this.reset(); document.querySelector('#n').textContent = ` + "`${event.detail.xhr.status}`" + `">`,
+		},
+		{
+			name: "regex and division",
+			html: `<input oninput="this.value = this.value.replace(/[^0-9/]/g, ''); half = total / 2">`,
+		},
+		{
+			name: "comment and braces in strings",
+			html: `<a onclick="track('a)b{'); /* done } */ return false">x</a>`,
+		},
+		{
+			name: "template value",
+			html: `<button onclick="select({{.ID}})">x</button>`,
+		},
+		{
+			name:    "unclosed paren",
+			html:    `<button onclick="alert('hi'">x</button>`,
+			wantMsg: "onclick handler has a syntax error: unclosed '('",
+		},
+		{
+			name:    "extra brace",
+			html:    `<div hx-on::after-request="if (ok) { done() }}"></div>`,
+			wantMsg: "unexpected '}'",
+		},
+		{
+			name:    "mismatched bracket",
+			html:    `<button onclick="items[0).focus()">x</button>`,
+			wantMsg: "unexpected ')', expected ']'",
+		},
+		{
+			name:    "stray quote",
+			html:    `<button onclick="alert('it's')">x</button>`,
+			wantMsg: "unterminated string",
+		},
+		{
+			name:    "unterminated template literal",
+			html:    "<button onclick=\"log(`a ${b}`)`\">x</button>",
+			wantMsg: "unterminated template literal",
+		},
+		{
+			name: "not a handler",
+			html: `<div data-onclick="alert(" title="on("></div>`,
+		},
+	}
+
+	cfg := linter.DefaultConfig()
+	cfg.RuleSeverity[rules.RuleHandlerSyntax] = rules.Error
+	l := linter.New(cfg)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := l.LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			var msgs []string
+			for _, r := range results {
+				if r.Rule == rules.RuleHandlerSyntax {
+					msgs = append(msgs, r.Message)
+				}
+			}
+			if tt.wantMsg == "" {
+				if len(msgs) > 0 {
+					t.Errorf("unexpected %s results: %v", rules.RuleHandlerSyntax, msgs)
+				}
+				return
+			}
+			if len(msgs) != 1 || !strings.Contains(msgs[0], tt.wantMsg) {
+				t.Errorf("got %v, want one message containing %q", msgs, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestLintContent_DataJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		html    string
+		wantMsg string
+	}{
+		{
+			name: "valid object",
+			html: `<div data-config='{"page": 1, "tags": ["a", "b"]}'></div>`,
+		},
+		{
+			name: "valid scalar",
+			html: `<div data-props="42"></div>`,
+		},
+		{
+			name: "unlisted attribute",
+			html: `<div data-other="{oops}"></div>`,
+		},
+		{
+			name: "template value",
+			html: `<div data-props='{{.Props}}'></div>`,
+		},
+		{
+			name:    "single quotes",
+			html:    `<div data-config="{'page': 1}"></div>`,
+			wantMsg: "data-config contains invalid JSON: syntax error at position 2",
+		},
+		{
+			name:    "trailing comma",
+			html:    `<div DATA-PROPS='{"a": 1,}'></div>`,
+			wantMsg: "data-props contains invalid JSON",
+		},
+		{
+			name:    "empty",
+			html:    `<div data-config=""></div>`,
+			wantMsg: "data-config is empty",
+		},
+	}
+
+	cfg := linter.DefaultConfig()
+	cfg.RuleOptions = map[string]map[string]any{
+		rules.RuleDataJSON: {"attributes": []any{"data-config", "data-props"}},
+	}
+	l := linter.New(cfg)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := l.LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			var msgs []string
+			for _, r := range results {
+				if r.Rule == rules.RuleDataJSON {
+					msgs = append(msgs, r.Message)
+				}
+			}
+			if tt.wantMsg == "" {
+				if len(msgs) > 0 {
+					t.Errorf("unexpected %s results: %v", rules.RuleDataJSON, msgs)
+				}
+				return
+			}
+			if len(msgs) != 1 || !strings.Contains(msgs[0], tt.wantMsg) {
+				t.Errorf("got %v, want one message containing %q", msgs, tt.wantMsg)
+			}
+		})
+	}
+
+	t.Run("no attributes configured", func(t *testing.T) {
+		results, err := linter.New(nil).LintContent("test.html", []byte(`<div data-config="{oops}"></div>`))
+		if err != nil {
+			t.Fatalf("LintContent() error = %v", err)
+		}
+		checkRule(t, results, rules.RuleDataJSON, "")
+	})
+}
+
+func TestLintContent_MathML(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		rule     string
+		optIn    bool
+		wantRule string
+	}{
+		{
+			name: "MathML elements are known",
+			html: `<p><math><mrow><mi>x</mi><mo>=</mo><mfrac><mn>1</mn><mn>2</mn></mfrac></mrow></math></p>`,
+			rule: rules.RuleElementName,
+		},
+		{
+			name:     "unknown MathML element",
+			html:     `<p><math><mrow><mvar>x</mvar></mrow></math></p>`,
+			rule:     rules.RuleElementName,
+			wantRule: rules.RuleElementName,
+		},
+		{
+			name: "structure rule disabled by default",
+			html: `<p><math><mfrac><mn>1</mn></mfrac></math></p>`,
+			rule: rules.RuleMathMLStructure,
+		},
+		{
+			name:  "well-formed expression",
+			html:  `<p><math><msup><mi>x</mi><mn>2</mn></msup><mo>+</mo><msqrt><mi>y</mi></msqrt></math></p>`,
+			rule:  rules.RuleMathMLStructure,
+			optIn: true,
+		},
+		{
+			name:     "fraction with one child",
+			html:     `<p><math><mfrac><mn>1</mn></mfrac></math></p>`,
+			rule:     rules.RuleMathMLStructure,
+			optIn:    true,
+			wantRule: rules.RuleMathMLStructure,
+		},
+		{
+			name:     "layout inside token element",
+			html:     `<p><math><mi><mrow><mi>x</mi></mrow></mi></math></p>`,
+			rule:     rules.RuleMathMLStructure,
+			optIn:    true,
+			wantRule: rules.RuleMathMLStructure,
+		},
+		{
+			name:     "bare text in mrow",
+			html:     `<p><math><mrow>x + 1</mrow></math></p>`,
+			rule:     rules.RuleMathMLStructure,
+			optIn:    true,
+			wantRule: rules.RuleMathMLStructure,
+		},
+		{
+			name:     "mtd outside mtr",
+			html:     `<p><math><mtable><mtd><mn>1</mn></mtd></mtable></math></p>`,
+			rule:     rules.RuleMathMLStructure,
+			optIn:    true,
+			wantRule: rules.RuleMathMLStructure,
+		},
+		{
+			name:  "annotation with encoding",
+			html:  `<p><math><semantics><mi>x</mi><annotation-xml encoding="application/mathml-content+xml"><ci>x</ci></annotation-xml></semantics></math></p>`,
+			rule:  rules.RuleMathMLStructure,
+			optIn: true,
+		},
+		{
+			name:     "annotation without encoding",
+			html:     `<p><math><semantics><mi>x</mi><annotation-xml><ci>x</ci></annotation-xml></semantics></math></p>`,
+			rule:     rules.RuleMathMLStructure,
+			optIn:    true,
+			wantRule: rules.RuleMathMLStructure,
+		},
+		{
+			name:     "annotation outside semantics",
+			html:     `<p><math><mi>x</mi><annotation encoding="application/x-tex">x</annotation></math></p>`,
+			rule:     rules.RuleMathMLStructure,
+			optIn:    true,
+			wantRule: rules.RuleMathMLStructure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			if tt.optIn {
+				cfg.RuleSeverity = map[string]rules.Severity{rules.RuleMathMLStructure: rules.Error}
+			}
+			results, err := linter.New(cfg).LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, tt.rule, tt.wantRule)
+		})
+	}
+}
+
+func TestLintContent_WebComponents(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		rule     string
+		wantRule string
+	}{
+		{
+			name: "template ids are a separate scope",
+			html: `<div id="row"></div><template><div id="row"></div></template>`,
+			rule: rules.RuleDuplicateID,
+		},
+		{
+			name:     "duplicate ids within one template",
+			html:     `<template><div id="row"></div><div id="row"></div></template>`,
+			rule:     rules.RuleDuplicateID,
+			wantRule: rules.RuleDuplicateID,
+		},
+		{
+			name: "valid shadowrootmode",
+			html: `<my-card><template shadowrootmode="closed"><slot></slot></template></my-card>`,
+			rule: rules.RuleAttributeAllowedValues,
+		},
+		{
+			name:     "invalid shadowrootmode",
+			html:     `<my-card><template shadowrootmode="shared"><slot></slot></template></my-card>`,
+			rule:     rules.RuleAttributeAllowedValues,
+			wantRule: rules.RuleAttributeAllowedValues,
+		},
+		{
+			name: "slot matches named slot",
+			html: `<my-card><template shadowrootmode="open"><slot name="title"></slot><slot></slot></template><h2 slot="title">Hi</h2><p>Body</p></my-card>`,
+			rule: rules.RuleSlotName,
+		},
+		{
+			name:     "slot has no matching named slot",
+			html:     `<my-card><template shadowrootmode="open"><slot name="title"></slot></template><h2 slot="heading">Hi</h2></my-card>`,
+			rule:     rules.RuleSlotName,
+			wantRule: rules.RuleSlotName,
+		},
+		{
+			name:     "duplicate slot names",
+			html:     `<my-card><template shadowrootmode="open"><slot name="a"></slot><slot name="a"></slot></template></my-card>`,
+			rule:     rules.RuleSlotName,
+			wantRule: rules.RuleSlotName,
+		},
+		{
+			name: "shadow root not in file",
+			html: `<my-card><h2 slot="title">Hi</h2></my-card>`,
+			rule: rules.RuleSlotName,
+		},
+		{
+			name: "template slot value",
+			html: `<my-card><template shadowrootmode="open"><slot name="a"></slot></template><p slot="{{.Slot}}">x</p></my-card>`,
+			rule: rules.RuleSlotName,
+		},
+	}
+
+	l := linter.New(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := l.LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, tt.rule, tt.wantRule)
+		})
+	}
+}
+
+func TestLintContent_PictureSource(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		wantRule string
+	}{
+		{
+			name: "valid picture",
+			html: `<picture><source srcset="a.avif" type="image/avif"><source srcset="wide.jpg" media="(min-width: 800px) and (orientation: landscape)"><source srcset="mid.jpg" media="screen and (400px <= width < 800px), print"><img src="a.jpg" alt="A"></picture>`,
+		},
+		{
+			name:     "source without srcset",
+			html:     `<picture><source src="a.webp" type="image/webp"><img src="a.jpg" alt="A"></picture>`,
+			wantRule: rules.RulePictureSource,
+		},
+		{
+			name:     "unparseable media query",
+			html:     `<picture><source srcset="a.jpg" media="(min-width: 800px"><img src="a.jpg" alt="A"></picture>`,
+			wantRule: rules.RulePictureSource,
+		},
+		{
+			name:     "unknown media feature",
+			html:     `<picture><source srcset="a.jpg" media="(min-wdth: 800px)"><img src="a.jpg" alt="A"></picture>`,
+			wantRule: rules.RulePictureSource,
+		},
+		{
+			name:     "non-image type",
+			html:     `<picture><source srcset="a.mp4" type="video/mp4"><img src="a.jpg" alt="A"></picture>`,
+			wantRule: rules.RulePictureSource,
+		},
+		{
+			name:     "missing img fallback",
+			html:     `<picture><source srcset="a.webp" type="image/webp"></picture>`,
+			wantRule: rules.RulePictureSource,
+		},
+		{
+			name:     "source after img",
+			html:     `<picture><img src="a.jpg" alt="A"><source srcset="a.webp" type="image/webp"></picture>`,
+			wantRule: rules.RulePictureSource,
+		},
+		{
+			name:     "repeated media query",
+			html:     `<picture><source srcset="a.jpg" media="(min-width: 800px)"><source srcset="b.jpg" media="(min-width:  800px)"><img src="c.jpg" alt="C"></picture>`,
+			wantRule: rules.RulePictureSource,
+		},
+		{
+			name: "same media with different types",
+			html: `<picture><source srcset="a.avif" media="(min-width: 800px)" type="image/avif"><source srcset="a.jpg" media="(min-width: 800px)"><img src="c.jpg" alt="C"></picture>`,
+		},
+		{
+			name: "template media value",
+			html: `<picture><source srcset="a.jpg" media="{{.Media}}"><img src="c.jpg" alt="C"></picture>`,
+		},
+	}
+
+	l := linter.New(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := l.LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RulePictureSource, tt.wantRule)
+		})
+	}
+}
+
+func TestLintContent_MediaQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		wantRule string
+	}{
+		{
+			name: "valid media queries",
+			html: `<link rel="stylesheet" href="a.css" media="screen and (min-width: 40em)"><style media="(prefers-color-scheme: dark)">p{}</style><video><source src="a.mp4" type="video/mp4" media="(orientation: landscape)"></video>`,
+		},
+		{
+			name:     "unbalanced parentheses on link",
+			html:     `<link rel="stylesheet" href="a.css" media="(min-width: 40em">`,
+			wantRule: rules.RuleMediaQuery,
+		},
+		{
+			name:     "unknown media type on style",
+			html:     `<style media="printer">p{}</style>`,
+			wantRule: rules.RuleMediaQuery,
+		},
+		{
+			name:     "deprecated media type",
+			html:     `<link rel="stylesheet" href="tv.css" media="tv">`,
+			wantRule: rules.RuleMediaQuery,
+		},
+		{
+			name: "picture sources are left to picture-source",
+			html: `<picture><source srcset="a.jpg" media="(min-width: 800px"><img src="a.jpg" alt="A"></picture>`,
+		},
+		{
+			name: "template media value",
+			html: `<link rel="stylesheet" href="a.css" media="{{.Media}}">`,
+		},
+		{
+			name: "print stylesheet loads in the background",
+			html: `<!DOCTYPE html><html lang="en"><head><title>T</title><link rel="stylesheet" href="print.css" media="print"></head><body></body></html>`,
+		},
+		{
+			name:     "print stylesheet with blocking render",
+			html:     `<!DOCTYPE html><html lang="en"><head><title>T</title><link rel="stylesheet" href="print.css" media="print" blocking="render"></head><body></body></html>`,
+			wantRule: rules.RuleMediaQuery,
+		},
+		{
+			name:     "print stylesheet with style preload",
+			html:     `<!DOCTYPE html><html lang="en"><head><title>T</title><link rel="preload" as="style" href="print.css"><link rel="stylesheet" href="print.css" media="only print"></head><body></body></html>`,
+			wantRule: rules.RuleMediaQuery,
+		},
+		{
+			name: "screen stylesheet with high priority",
+			html: `<!DOCTYPE html><html lang="en"><head><title>T</title><link rel="stylesheet" href="a.css" media="screen, print" fetchpriority="high"></head><body></body></html>`,
+		},
+	}
+
+	l := linter.New(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := l.LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleMediaQuery, tt.wantRule)
+		})
+	}
+}
+
+func TestLintContent_MediaSource(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		wantRule string
+	}{
+		{
+			name: "sources with codecs",
+			html: `<video controls><source src="a.webm" type="video/webm"><source src="a.mp4" type='video/mp4; codecs="avc1.42E01E"'><track kind="captions" src="a.vtt" srclang="en"></video>`,
+		},
+		{
+			name: "src only",
+			html: `<audio controls src="a.mp3"></audio>`,
+		},
+		{
+			name: "no source set by script",
+			html: `<video id="player"></video>`,
+		},
+		{
+			name:     "src and source children",
+			html:     `<audio controls src="a.mp3"><source src="a.ogg" type="audio/ogg"></audio>`,
+			wantRule: rules.RuleMediaSource,
+		},
+		{
+			name:     "source without src",
+			html:     `<audio controls><source type="audio/ogg"></audio>`,
+			wantRule: rules.RuleMediaSource,
+		},
+		{
+			name:     "image type in video",
+			html:     `<video controls><source src="a.webm" type="image/webp"></video>`,
+			wantRule: rules.RuleMediaSource,
+		},
+		{
+			name:     "type is not a MIME type",
+			html:     `<video controls><source src="a.mp4" type="mp4"></video>`,
+			wantRule: rules.RuleMediaSource,
+		},
+		{
+			name:     "only legacy candidates",
+			html:     `<video controls><source src="a.flv" type="video/x-flv"><source src="a.wmv"></video>`,
+			wantRule: rules.RuleMediaSource,
+		},
+		{
+			name: "legacy with modern fallback",
+			html: `<video controls><source src="a.mp4" type="video/mp4"><source src="a.flv" type="video/x-flv"></video>`,
+		},
+		{
+			name:     "legacy src",
+			html:     `<audio controls src="song.wma"></audio>`,
+			wantRule: rules.RuleMediaSource,
+		},
+		{
+			name: "template type",
+			html: `<video controls><source src="{{.URL}}" type="{{.Type}}"></video>`,
+		},
+	}
+
+	l := linter.New(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := l.LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleMediaSource, tt.wantRule)
+		})
+	}
+}
+
+func TestLintContent_SrcsetDescriptors(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		wantRule string
+	}{
+		{
+			name: "width descriptors with sizes",
+			html: `<img src="a-480.jpg" srcset="a-480.jpg 480w, a-960.jpg 960w" sizes="(min-width: 600px) 50vw, 100vw" alt="A">`,
+		},
+		{
+			name: "density descriptors",
+			html: `<img src="a.jpg" srcset="a.jpg, a@2x.jpg 2x, a@3x.jpg 3X" alt="A">`,
+		},
+		{
+			name: "data URL with commas",
+			html: `<img src="a.jpg" srcset="data:image/png;base64,iVBORw0KGgo= 1x, a.jpg 2x" alt="A">`,
+		},
+		{
+			name:     "mixed width and density",
+			html:     `<img src="a.jpg" srcset="a.jpg 480w, a@2x.jpg 2x" sizes="100vw" alt="A">`,
+			wantRule: rules.RuleSrcsetDescriptors,
+		},
+		{
+			name:     "sizes without width descriptors",
+			html:     `<img src="a.jpg" srcset="a.jpg 1x, a@2x.jpg 2x" sizes="50vw" alt="A">`,
+			wantRule: rules.RuleSrcsetDescriptors,
+		},
+		{
+			name:     "src not among candidates",
+			html:     `<img src="fallback.jpg" srcset="a-480.jpg 480w, a-960.jpg 960w" sizes="100vw" alt="A">`,
+			wantRule: rules.RuleSrcsetDescriptors,
+		},
+		{
+			name:     "malformed descriptor",
+			html:     `<picture><source srcset="a.webp 2dppx" type="image/webp"><img src="a.jpg" alt="A"></picture>`,
+			wantRule: rules.RuleSrcsetDescriptors,
+		},
+		{
+			name: "template values",
+			html: `<img src="{{.Src}}" srcset="{{.Small}} {{.W}}, {{.Large}} 960w" sizes="100vw" alt="A">`,
+		},
+	}
+
+	l := linter.New(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := l.LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleSrcsetDescriptors, tt.wantRule)
+		})
+	}
+}
+
+func TestLintContent_ResourceHints(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		opts     map[string]any
+		wantRule string
+	}{
+		{
+			name: "well-formed hints",
+			html: `<!DOCTYPE html><html lang="en"><head><title>T</title>
+<link rel="preconnect" href="https://fonts.gstatic.com" crossorigin>
+<link rel="stylesheet" href="https://fonts.googleapis.com/css2?family=Inter">
+<link rel="preload" href="/fonts/inter.woff2" as="font" type="font/woff2" crossorigin>
+<link rel="dns-prefetch" href="//cdn.example.com">
+<link rel="stylesheet" href="/app.css"><link rel="stylesheet" href="/print.css" media="print">
+</head><body><img src="https://cdn.example.com/a.png" alt="A"></body></html>`,
+		},
+		{
+			name: "font preload without crossorigin",
+			html: `<!DOCTYPE html><html lang="en"><head><title>T</title>
+<link rel="preload" href="/fonts/inter.woff2" as="font" type="font/woff2">
+</head><body></body></html>`,
+			wantRule: rules.RuleResourceHints,
+		},
+		{
+			name: "font origin preconnect without crossorigin",
+			html: `<!DOCTYPE html><html lang="en"><head><title>T</title>
+<link rel="preconnect" href="https://fonts.gstatic.com">
+<link rel="stylesheet" href="https://fonts.googleapis.com/css2?family=Inter">
+</head><body></body></html>`,
+			wantRule: rules.RuleResourceHints,
+		},
+		{
+			name: "too many blocking stylesheets",
+			html: `<!DOCTYPE html><html lang="en"><head><title>T</title>
+<link rel="stylesheet" href="/a.css"><link rel="stylesheet" href="/b.css">
+<link rel="stylesheet" href="/c.css"><link rel="stylesheet" href="/d.css">
+</head><body></body></html>`,
+			wantRule: rules.RuleResourceHints,
+		},
+		{
+			name: "blocking stylesheet limit option",
+			html: `<!DOCTYPE html><html lang="en"><head><title>T</title>
+<link rel="stylesheet" href="/a.css"><link rel="stylesheet" href="/b.css">
+<link rel="stylesheet" href="/c.css"><link rel="stylesheet" href="/d.css">
+</head><body></body></html>`,
+			opts: map[string]any{"max-blocking-stylesheets": 4},
+		},
+		{
+			name: "unused preconnect origin",
+			html: `<!DOCTYPE html><html lang="en"><head><title>T</title>
+<link rel="preconnect" href="https://api.example.com">
+</head><body><img src="/a.png" alt="A"></body></html>`,
+			wantRule: rules.RuleResourceHints,
+		},
+		{
+			name: "origin used in script",
+			html: `<!DOCTYPE html><html lang="en"><head><title>T</title>
+<link rel="preconnect" href="https://api.example.com">
+</head><body><script>fetch("https://api.example.com/items")</script></body></html>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			cfg.EnabledRules = []string{rules.RuleResourceHints}
+			if tt.opts != nil {
+				cfg.RuleOptions = map[string]map[string]any{rules.RuleResourceHints: tt.opts}
+			}
+			results, err := linter.New(cfg).LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleResourceHints, tt.wantRule)
+		})
+	}
+}
+
+// noMarqueeRule is a pack rule used by TestLintContent_Packs.
+type noMarqueeRule struct{ name string }
+
+func (r *noMarqueeRule) Name() string        { return r.name }
+func (r *noMarqueeRule) Description() string { return "marquee is not allowed" }
+
+func (r *noMarqueeRule) Check(doc *parser.Document) []rules.Result {
+	var results []rules.Result
+	for _, n := range doc.QuerySelectorAll("marquee") {
+		results = append(results, rules.Result{
+			Rule:     r.name,
+			Message:  "marquee is not allowed",
+			Line:     n.Line,
+			Col:      n.Col,
+			Severity: rules.Error,
+		})
+	}
+	return results
+}
+
+func TestLintContent_Packs(t *testing.T) {
+	pack := rules.Pack{
+		PackMetadata: rules.PackMetadata{Name: "acme"},
+		Version:      "v1.0.0",
+		Rules: func() []rules.Rule {
+			return []rules.Rule{
+				&noMarqueeRule{name: "acme/no-marquee"},
+				&noMarqueeRule{name: rules.RuleRequireLang}, // taken by a built-in rule
+			}
+		},
+	}
+	html := []byte(`<div><marquee>Sale</marquee></div>`)
+
+	cfg := linter.DefaultConfig()
+	cfg.Packs = []rules.Pack{pack}
+	results, err := linter.New(cfg).LintContent("test.html", html)
+	if err != nil {
+		t.Fatalf("LintContent() error = %v", err)
+	}
+	checkRule(t, results, "acme/no-marquee", "acme/no-marquee")
+	for _, r := range results {
+		if r.Rule == rules.RuleRequireLang && r.Message == "marquee is not allowed" {
+			t.Errorf("pack rule replaced built-in rule %s", rules.RuleRequireLang)
+		}
+	}
+
+	cfg = linter.DefaultConfig()
+	cfg.Packs = []rules.Pack{pack}
+	cfg.DisabledRules = []string{"acme/no-marquee"}
+	results, err = linter.New(cfg).LintContent("test.html", html)
+	if err != nil {
+		t.Fatalf("LintContent() error = %v", err)
+	}
+	checkRule(t, results, "acme/no-marquee", "")
+}
+
+// panicRule is a pack rule that panics, used by TestLintContent_RulePanic.
+type panicRule struct{}
+
+func (r *panicRule) Name() string                            { return "acme/panic" }
+func (r *panicRule) Description() string                     { return "always panics" }
+func (r *panicRule) Check(_ *parser.Document) []rules.Result { panic("boom") }
+
+func TestLintContent_RulePanic(t *testing.T) {
+	cfg := linter.DefaultConfig()
+	cfg.Packs = []rules.Pack{{
+		PackMetadata: rules.PackMetadata{Name: "acme"},
+		Rules:        func() []rules.Rule { return []rules.Rule{&panicRule{}} },
+	}}
+	for _, branches := range []bool{false, true} {
+		cfg.TemplateBranches = branches
+		results, err := linter.New(cfg).LintContent("test.html", []byte(`<p>{{if .X}}a{{else}}b{{end}}</p>`))
+		if err != nil {
+			t.Fatalf("LintContent() error = %v", err)
+		}
+		var found int
+		for _, r := range results {
+			if r.Rule == "acme/panic" {
+				found++
+				if r.Severity != rules.Error || !strings.Contains(r.Message, "internal error: boom") {
+					t.Errorf("got %+v, want an internal error finding", r)
+				}
+			}
+		}
+		if found != 1 {
+			t.Errorf("branches=%v: got %d acme/panic findings, want 1", branches, found)
+		}
+	}
+}
+
+func TestLintContent_NoCommentedMarkup(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		opts     map[string]any
+		wantRule string
+	}{
+		{
+			name: "commented-out block",
+			html: `<main>
+<!--
+<section class="promo">
+  <h2>Summer sale</h2>
+  <p>Everything 20% off</p>
+</section>
+-->
+<p>Hi</p>
+</main>`,
+			wantRule: rules.RuleNoCommentedMarkup,
+		},
+		{
+			name: "short commented-out element",
+			html: `<main><!-- <p>Old intro</p> --><p>Hi</p></main>`,
+		},
+		{
+			name: "long prose comment",
+			html: `<main>
+<!--
+  The sidebar is rendered by the layout.
+  Keep this section free of forms so
+  the cached variant stays valid and
+  the partial can be swapped by htmx.
+-->
+<p>Hi</p>
+</main>`,
+		},
+		{
+			name: "IE conditional comment",
+			html: `<head>
+<!--[if lt IE 9]>
+<script src="html5shiv.js"></script>
+<script src="respond.js"></script>
+<link rel="stylesheet" href="ie.css">
+<![endif]-->
+</head>`,
+		},
+		{
+			name: "raised line threshold",
+			html: `<main>
+<!--
+<section>
+  <h2>Summer sale</h2>
+  <p>Everything 20% off</p>
+</section>
+-->
+</main>`,
+			opts: map[string]any{"max-lines": 10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			if tt.opts != nil {
+				cfg.RuleOptions = map[string]map[string]any{rules.RuleNoCommentedMarkup: tt.opts}
+			}
+			results, err := linter.New(cfg).LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleNoCommentedMarkup, tt.wantRule)
+		})
+	}
+}
+
+func TestLintContent_MinifiedFile(t *testing.T) {
+	row := `<tr><td><input name="q"></td></tr>`
+	minified := `<!DOCTYPE html><html lang="en"><head><title>T</title></head><body><table>` +
+		strings.Repeat(row, 400) + `</table></body></html>`
+
+	tests := []struct {
+		name      string
+		html      string
+		opts      map[string]any
+		wantRule  string
+		wantStyle bool // whether no-implicit-input-type still runs
+	}{
+		{
+			name:      "formatted file",
+			html:      strings.ReplaceAll(minified, "</tr>", "</tr>\n"),
+			wantStyle: true,
+		},
+		{
+			name:      "minified file",
+			html:      minified,
+			wantRule:  rules.RuleMinifiedFile,
+			wantStyle: true,
+		},
+		{
+			name:      "raised threshold",
+			html:      minified,
+			opts:      map[string]any{"max-line-kb": 100},
+			wantStyle: true,
+		},
+		{
+			name:     "skip style rules",
+			html:     minified,
+			opts:     map[string]any{"skip-style-rules": true},
+			wantRule: rules.RuleMinifiedFile,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := linter.DefaultConfig()
+			if tt.opts != nil {
+				cfg.RuleOptions = map[string]map[string]any{rules.RuleMinifiedFile: tt.opts}
+			}
+			results, err := linter.New(cfg).LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleMinifiedFile, tt.wantRule)
+			wantStyle := ""
+			if tt.wantStyle {
+				wantStyle = rules.RuleNoImplicitInputType
+			}
+			checkRule(t, results, rules.RuleNoImplicitInputType, wantStyle)
+		})
+	}
+}
+
+func TestLintContent_CommentSyntax(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		wantRule string
+		wantMsg  string
+	}{
+		{
+			name: "well-formed comments",
+			html: `<div><!-- nav --><p>Hi</p><!-- a - b --></div>`,
+		},
+		{
+			name:     "unclosed comment",
+			html:     "<div><!-- start of promo\n<p>Hi</p></div>",
+			wantRule: rules.RuleCommentSyntax,
+			wantMsg:  "never closed",
+		},
+		{
+			name:     "nested comment",
+			html:     `<div><!-- outer <!-- inner --> still outer? --><p>Hi</p></div>`,
+			wantRule: rules.RuleCommentSyntax,
+			wantMsg:  "nested",
+		},
+		{
+			name:     "double hyphen",
+			html:     `<div><!-- ------ section ------ --><p>Hi</p></div>`,
+			wantRule: rules.RuleCommentSyntax,
+			wantMsg:  `"--" inside`,
+		},
+		{
+			name:     "incorrectly closed comment",
+			html:     `<div><!-- note --!><p>Hi</p></div>`,
+			wantRule: rules.RuleCommentSyntax,
+			wantMsg:  "--!>",
+		},
+		{
+			name:     "abruptly closed comment",
+			html:     `<div><!-->hidden?<p>Hi</p></div>`,
+			wantRule: rules.RuleCommentSyntax,
+			wantMsg:  "closed immediately",
+		},
+		{
+			name:     "CDATA in HTML",
+			html:     `<div><![CDATA[ x < y ]]><p>Hi</p></div>`,
+			wantRule: rules.RuleCommentSyntax,
+			wantMsg:  "CDATA",
+		},
+		{
+			name: "CDATA in SVG",
+			html: `<svg viewBox="0 0 10 10"><style><![CDATA[ rect { fill: red; } ]]></style><text><![CDATA[ x < y ]]></text></svg>`,
+		},
+		{
+			name:     "processing instruction",
+			html:     `<?xml version="1.0" encoding="UTF-8"?><div><p>Hi</p></div>`,
+			wantRule: rules.RuleCommentSyntax,
+			wantMsg:  "processing instruction",
+		},
+		{
+			name: "double hyphen in template action",
+			html: `<div><!-- {{- .Note -}} --><p>Hi</p></div>`,
+		},
+		{
+			name: "IE downlevel-revealed conditional",
+			html: `<div><![if !IE]><p>Hi</p><![endif]></div>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := linter.New(nil).LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleCommentSyntax, tt.wantRule)
+			for _, r := range results {
+				if r.Rule == rules.RuleCommentSyntax && !strings.Contains(r.Message, tt.wantMsg) {
+					t.Errorf("message = %q, want it to contain %q", r.Message, tt.wantMsg)
+				}
+			}
+		})
+	}
+}
+
+func TestLintContent_NoMojibake(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		wantRule string
+	}{
+		{
+			name: "accented text",
+			html: `<p>Café crème, São Paulo, Ärger, naïve — don’t «quote»</p>`,
+		},
+		{
+			name:     "double-encoded e acute",
+			html:     `<p>CafÃ© menu</p>`,
+			wantRule: rules.RuleNoMojibake,
+		},
+		{
+			name:     "double-encoded apostrophe",
+			html:     `<p>We donâ€™t ship on Sundays.</p>`,
+			wantRule: rules.RuleNoMojibake,
+		},
+		{
+			name:     "replacement character",
+			html:     `<p>Price: 10 � per month</p>`,
+			wantRule: rules.RuleNoMojibake,
+		},
+		{
+			name: "script content",
+			html: `<script>const s = "CafÃ©";</script>`,
+		},
+		{
+			name: "template action",
+			html: `<p>{{T "CafÃ©"}}</p>`,
+		},
+	}
+
+	l := linter.New(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := l.LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleNoMojibake, tt.wantRule)
+		})
+	}
+
+	t.Run("position", func(t *testing.T) {
+		results, err := l.LintContent("test.html", []byte("<p>\n  Menu: CafÃ©</p>"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, r := range results {
+			if r.Rule == rules.RuleNoMojibake {
+				if r.Line != 2 || r.Col != 12 || !strings.Contains(r.Message, `"é"`) {
+					t.Errorf("got %d:%d %q, want 2:12 suggesting é", r.Line, r.Col, r.Message)
+				}
+				return
+			}
+		}
+		t.Error("no mojibake result")
+	})
+}
+
+func TestLintContent_RequireUTF8(t *testing.T) {
+	utf16 := []byte{0xFF, 0xFE}
+	for _, r := range `<p>café</p><img src="a.png"><a href="a b.html">x</a>` {
+		utf16 = append(utf16, byte(r), byte(r>>8))
+	}
+
+	tests := []struct {
+		name     string
+		content  []byte
+		wantRule string
+	}{
+		{
+			name:    "utf-8",
+			content: []byte(`<meta charset="utf-8"><p>café</p>`),
+		},
+		{
+			name:     "windows-1252",
+			content:  []byte("<meta charset=\"windows-1252\"><p>caf\xE9</p>"),
+			wantRule: rules.RuleRequireUTF8,
+		},
+		{
+			name:     "utf-16 byte order mark",
+			content:  utf16,
+			wantRule: rules.RuleRequireUTF8,
+		},
+		{
+			name:     "unsupported encoding",
+			content:  []byte(`<meta charset="shift_jis"><p>x</p>`),
+			wantRule: rules.RuleRequireUTF8,
+		},
+		{
+			name:    "template charset",
+			content: []byte(`<meta charset="{{.Charset}}"><p>x</p>`),
+		},
+	}
+
+	l := linter.New(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := l.LintContent("test.html", tt.content)
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleRequireUTF8, tt.wantRule)
+			// decoded text is not mistaken for mojibake
+			checkRule(t, results, rules.RuleNoMojibake, "")
+		})
+	}
+
+	t.Run("decoded before linting", func(t *testing.T) {
+		results, err := l.LintContent("test.html", utf16)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkRule(t, results, rules.RuleImgAlt, rules.RuleImgAlt)
+		checkRule(t, results, rules.RuleURLEncoding, rules.RuleURLEncoding)
+		for _, r := range results {
+			if r.Fix != nil {
+				t.Errorf("%s result has a fix for transcoded content", r.Rule)
+			}
+		}
+	})
+}
+
+func TestLintContent_AriaAllowedValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		wantRule string
+		wantMsg  string
+	}{
+		{
+			name: "valid tokens",
+			html: `<a href="/" aria-current="page">Home</a><button aria-haspopup="menu" aria-expanded="false">Menu</button><div role="tab" aria-selected="true">Tab</div>`,
+		},
+		{
+			name: "case-insensitive",
+			html: `<a href="/" aria-current="Page">Home</a>`,
+		},
+		{
+			name: "empty value",
+			html: `<a href="/" aria-current="">Home</a>`,
+		},
+		{
+			name: "template value",
+			html: `<a href="/" aria-current="{{.Current}}">Home</a>`,
+		},
+		{
+			name:     "aria-current yes",
+			html:     `<a href="/" aria-current="yes">Home</a>`,
+			wantRule: rules.RuleAriaAllowedValues,
+			wantMsg:  `invalid aria-current value "yes" (allowed: page, step, location, date, time, true, false)`,
+		},
+		{
+			name:     "aria-haspopup popup",
+			html:     `<button aria-haspopup="popup">Menu</button>`,
+			wantRule: rules.RuleAriaAllowedValues,
+		},
+		{
+			name:     "aria-selected selected",
+			html:     `<div role="option" aria-selected="selected">One</div>`,
+			wantRule: rules.RuleAriaAllowedValues,
+		},
+		{
+			name:     "aria-orientation",
+			html:     `<div role="slider" aria-orientation="row"></div>`,
+			wantRule: rules.RuleAriaAllowedValues,
+		},
+		{
+			name: "token list",
+			html: `<div aria-live="polite" aria-relevant="additions text"></div>`,
+		},
+		{
+			name:     "token list with unknown token",
+			html:     `<div aria-live="polite" aria-relevant="additions changes"></div>`,
+			wantRule: rules.RuleAriaAllowedValues,
+		},
+	}
+
+	l := linter.New(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := l.LintContent("test.html", []byte(tt.html))
+			if err != nil {
+				t.Fatalf("LintContent() error = %v", err)
+			}
+			checkRule(t, results, rules.RuleAriaAllowedValues, tt.wantRule)
+			if tt.wantMsg == "" {
+				return
+			}
+			for _, r := range results {
+				if r.Rule == rules.RuleAriaAllowedValues && r.Message != tt.wantMsg {
+					t.Errorf("message = %q, want %q", r.Message, tt.wantMsg)
+				}
+			}
+		})
+	}
+}
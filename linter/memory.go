@@ -0,0 +1,53 @@
+package linter
+
+import (
+	"fmt"
+	"math"
+	"runtime/debug"
+	"strconv"
+	"strings"
+)
+
+// projectShare is the fraction (1/projectShare) of the memory limit that
+// project rule sources may occupy before files are compacted.
+const projectShare = 4
+
+// projectBudget returns how many bytes of source LintFiles keeps in full
+// for project rules, or 0 for no bound. Past the budget, files are kept as
+// rules.SourceFile.Compact indexes instead, bounding memory on large
+// projects. The limit is MaxMemory, or the runtime limit set by GOMEMLIMIT
+// or debug.SetMemoryLimit.
+func (c *Config) projectBudget() int64 {
+	limit := c.MaxMemory
+	if limit <= 0 {
+		if l := debug.SetMemoryLimit(-1); l != math.MaxInt64 {
+			limit = l
+		}
+	}
+	return limit / projectShare
+}
+
+// ParseMemorySize parses a --max-memory value: a byte count with an
+// optional B, KiB, MiB, GiB, KB, MB, or GB suffix, as accepted by
+// GOMEMLIMIT.
+func ParseMemorySize(s string) (int64, error) {
+	units := []struct {
+		suffix string
+		size   int64
+	}{
+		{"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30},
+		{"KB", 1e3}, {"MB", 1e6}, {"GB", 1e9}, {"B", 1},
+	}
+	num, mult := strings.TrimSpace(s), int64(1)
+	for _, u := range units {
+		if n, ok := strings.CutSuffix(num, u.suffix); ok {
+			num, mult = strings.TrimSpace(n), u.size
+			break
+		}
+	}
+	n, err := strconv.ParseInt(num, 10, 64)
+	if err != nil || n <= 0 || n > math.MaxInt64/mult {
+		return 0, fmt.Errorf("invalid memory size %q (e.g. 512MiB, 2GiB)", s)
+	}
+	return n * mult, nil
+}
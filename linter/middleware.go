@@ -0,0 +1,30 @@
+package linter
+
+import "github.com/toba/go-html-validate/rules"
+
+// Middleware post-processes the findings of a run before they are
+// reported: it may drop, add, or modify results and returns the new slice.
+// Use it for suppression logic, remapping severity by path, or attaching
+// owner metadata. Results reach it with filenames already rewritten for
+// the configured PathMode.
+type Middleware func([]rules.Result) []rules.Result
+
+// Use adds middlewares applied by Run, in order, before results reach the
+// reporter. The error count Run returns reflects their output.
+func (l *Linter) Use(mw ...Middleware) {
+	l.middleware = append(l.middleware, mw...)
+}
+
+// Use adds middlewares applied by Run, in order, to the merged results of
+// all projects before they reach the reporter.
+func (w *Workspace) Use(mw ...Middleware) {
+	w.middleware = append(w.middleware, mw...)
+}
+
+// applyMiddleware passes results through each middleware in turn.
+func applyMiddleware(mws []Middleware, results []rules.Result) []rules.Result {
+	for _, mw := range mws {
+		results = mw(results)
+	}
+	return results
+}
@@ -0,0 +1,161 @@
+package linter
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/toba/go-html-validate/rules"
+)
+
+// NewFiles holds stricter severities for files added to a project after a
+// date or missing from a baseline of legacy files, so new markup can be
+// held to a higher standard while existing files are brought up to it
+// ("ratchet" adoption) under a single config.
+type NewFiles struct {
+	// Since makes files first added to their git repository after this
+	// time new. Files outside a repository, or not yet committed, are
+	// dated by their modification time. Since is ignored in shallow clones,
+	// such as CI checkouts of depth 1, whose truncated history shows every
+	// file as added in the tip commit; only Baseline applies there
+	Since time.Time
+	// Baseline lists the legacy files; when non-nil, every other file is
+	// new. Relative paths are resolved against the working directory
+	Baseline []string
+	// Escalate raises the severity of findings in new files by one level,
+	// info to warning and warning to error, unless RuleSeverity sets it
+	Escalate bool
+	// RuleSeverity overrides severity in new files; like a profile, it
+	// enables the rules it names
+	RuleSeverity map[string]rules.Severity
+}
+
+// Enabled reports whether any file can be new.
+func (n NewFiles) Enabled() bool {
+	return !n.Since.IsZero() || n.Baseline != nil
+}
+
+// newFileConfig returns cfg and the rules it enables adjusted for path
+// when path is a new file, or cfg and ruleSet unchanged.
+func (l *Linter) newFileConfig(path string, cfg *Config, ruleSet []rules.Rule) (*Config, []rules.Rule) {
+	if !cfg.NewFiles.Enabled() || !l.isNewFile(path) {
+		return cfg, ruleSet
+	}
+	cfg = cfg.Clone()
+	cfg.newFile = true
+	for rule, sev := range cfg.NewFiles.RuleSeverity {
+		cfg.RuleSeverity[rule] = sev
+		cfg.DisabledRules = slices.DeleteFunc(cfg.DisabledRules, func(name string) bool { return name == rule })
+	}
+	return cfg, enabledRules(cfg, l.all)
+}
+
+// escalate applies NewFiles.Escalate to a finding in a new file.
+func (c *Config) escalate(r rules.Result) rules.Severity {
+	if !c.newFile || !c.NewFiles.Escalate || r.Severity == rules.Error {
+		return r.Severity
+	}
+	if _, ok := c.NewFiles.RuleSeverity[r.Rule]; ok {
+		return r.Severity
+	}
+	return r.Severity - 1
+}
+
+// isNewFile reports whether path is missing from NewFiles.Baseline or was
+// added after NewFiles.Since. Paths that cannot be found are not new.
+func (l *Linter) isNewFile(path string) bool {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	nf := l.config.NewFiles
+	if nf.Baseline != nil && !slices.ContainsFunc(nf.Baseline, func(legacy string) bool {
+		legacyAbs, err := filepath.Abs(legacy)
+		return err == nil && legacyAbs == abs
+	}) {
+		return true
+	}
+	if nf.Since.IsZero() {
+		return false
+	}
+	added, ok := l.addedDate(abs)
+	return ok && added.After(nf.Since)
+}
+
+// addedDate returns when the file at abs was first committed to its git
+// repository, following renames, or else its modification time.
+func (l *Linter) addedDate(abs string) (time.Time, bool) {
+	if l.roots == nil {
+		l.roots = make(map[string]string)
+		l.added = make(map[string]map[string]time.Time)
+	}
+	if root := repoRoot(filepath.Dir(abs), l.roots); root != "" {
+		dates, ok := l.added[root]
+		if !ok {
+			dates = gitAddedDates(root)
+			l.added[root] = dates
+		}
+		if dates == nil {
+			return time.Time{}, false // shallow clone: history cannot date files
+		}
+		if rel, err := filepath.Rel(root, abs); err == nil {
+			if added, ok := dates[filepath.ToSlash(rel)]; ok {
+				return added, true
+			}
+		}
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return info.ModTime(), true
+}
+
+// gitAddedDates returns the commit time at which each file of the git
+// repository at root was added, keyed by slash-separated path relative to
+// root. A renamed file keeps the date of its original. The map is empty
+// if git is unavailable, and nil in a shallow clone.
+func gitAddedDates(root string) map[string]time.Time {
+	if isShallowRepo(root) {
+		return nil
+	}
+	dates := make(map[string]time.Time)
+	out, err := exec.Command("git", "-C", root, "-c", "core.quotePath=false", //nolint:gosec // root is a repository directory
+		"log", "--reverse", "-M", "--diff-filter=AR", "--name-status", "--format=%x01%ct").Output()
+	if err != nil {
+		return dates
+	}
+
+	var commitTime time.Time
+	for line := range strings.SplitSeq(string(out), "\n") {
+		if ts, ok := strings.CutPrefix(line, "\x01"); ok {
+			if sec, err := strconv.ParseInt(ts, 10, 64); err == nil {
+				commitTime = time.Unix(sec, 0)
+			}
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		switch {
+		case len(fields) == 2 && fields[0] == "A":
+			dates[fields[1]] = commitTime
+		case len(fields) == 3 && strings.HasPrefix(fields[0], "R"):
+			if added, ok := dates[fields[1]]; ok {
+				dates[fields[2]] = added
+			} else {
+				dates[fields[2]] = commitTime
+			}
+		}
+	}
+	return dates
+}
+
+// isShallowRepo reports whether the git repository at root is a shallow
+// clone.
+func isShallowRepo(root string) bool {
+	out, err := exec.Command("git", "-C", root, "rev-parse", "--is-shallow-repository").Output() //nolint:gosec // root is a repository directory
+	return err == nil && strings.TrimSpace(string(out)) == "true"
+}
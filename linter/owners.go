@@ -0,0 +1,178 @@
+package linter
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/toba/go-html-validate/rules"
+)
+
+// codeOwnersLocations are where GitHub and GitLab look for CODEOWNERS,
+// relative to the repository root, in order.
+var codeOwnersLocations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS", ".gitlab/CODEOWNERS"}
+
+// CodeOwners maps repository files to their owners. Patterns follow the
+// CODEOWNERS format: gitignore-style globs relative to Root, where the
+// last matching line wins and a line without owners leaves its files
+// unowned.
+type CodeOwners struct {
+	Root  string
+	rules []ownerRule
+}
+
+type ownerRule struct {
+	pattern *regexp.Regexp
+	owners  []string
+}
+
+// LoadCodeOwners finds the repository enclosing dir (the nearest
+// directory with a .git entry) and loads its CODEOWNERS file.
+func LoadCodeOwners(dir string) (*CodeOwners, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	root := repoRoot(abs, make(map[string]string))
+	if root == "" {
+		return nil, errors.New("CODEOWNERS: " + dir + " is not in a git repository")
+	}
+	for _, loc := range codeOwnersLocations {
+		data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(loc))) //nolint:gosec // repository file
+		if err == nil {
+			return ParseCodeOwners(root, data), nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return nil, errors.New("no CODEOWNERS file in " + root)
+}
+
+// ParseCodeOwners parses CODEOWNERS content for the repository at root.
+// Section headers ("[Section]") and unparseable patterns are skipped.
+func ParseCodeOwners(root string, data []byte) *CodeOwners {
+	c := &CodeOwners{Root: root}
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line, _, _ := strings.Cut(sc.Text(), " #")
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") || strings.HasPrefix(fields[0], "[") {
+			continue
+		}
+		re, err := regexp.Compile(ownerPattern(fields[0]))
+		if err != nil {
+			continue
+		}
+		c.rules = append(c.rules, ownerRule{pattern: re, owners: fields[1:]})
+	}
+	return c
+}
+
+// ownerPattern converts a CODEOWNERS glob to a regular expression over
+// slash-separated paths relative to the root. A pattern naming a
+// directory (a trailing slash, or a last segment without wildcards)
+// matches everything under it; "docs/*" matches only direct children.
+func ownerPattern(glob string) string {
+	// A leading or inner slash anchors the pattern at the root; otherwise
+	// it matches at any depth.
+	dir := strings.HasSuffix(glob, "/")
+	anchored := strings.Contains(strings.TrimSuffix(glob, "/"), "/")
+	glob = strings.TrimPrefix(strings.TrimSuffix(glob, "/"), "/")
+	last := glob[strings.LastIndex(glob, "/")+1:]
+	dir = dir || !strings.ContainsAny(last, "*?")
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; {
+		case strings.HasPrefix(glob[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	if dir {
+		b.WriteString("(?:/.*)?")
+	}
+	b.WriteString("$")
+	return b.String()
+}
+
+// Owners returns the owners of path, which is relative to Root (with
+// either separator) or absolute, or nil if no rule assigns any.
+func (c *CodeOwners) Owners(path string) []string {
+	if filepath.IsAbs(path) {
+		rel, err := filepath.Rel(c.Root, path)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return nil
+		}
+		path = rel
+	}
+	path = filepath.ToSlash(path)
+	for i := len(c.rules) - 1; i >= 0; i-- {
+		if c.rules[i].pattern.MatchString(path) {
+			return c.rules[i].owners
+		}
+	}
+	return nil
+}
+
+// Middleware returns a Middleware that sets Meta[rules.MetaOwner] on every
+// result whose file has owners. Relative filenames are resolved against
+// the working directory, or Root when no such file exists there, so every
+// PathMode works.
+func (c *CodeOwners) Middleware() Middleware {
+	return func(results []rules.Result) []rules.Result {
+		cache := make(map[string]string) // filename -> owners
+		for i := range results {
+			name := results[i].Filename
+			if name == "" {
+				continue
+			}
+			owner, ok := cache[name]
+			if !ok {
+				owner = strings.Join(c.Owners(c.absPath(name)), " ")
+				cache[name] = owner
+			}
+			if owner == "" {
+				continue
+			}
+			if results[i].Meta == nil {
+				results[i].Meta = make(map[string]string)
+			}
+			results[i].Meta[rules.MetaOwner] = owner
+		}
+		return results
+	}
+}
+
+// absPath resolves a reported filename to an absolute path.
+func (c *CodeOwners) absPath(name string) string {
+	if filepath.IsAbs(name) {
+		return name
+	}
+	if _, err := os.Stat(name); err != nil {
+		return filepath.Join(c.Root, filepath.FromSlash(name))
+	}
+	if abs, err := filepath.Abs(name); err == nil {
+		return abs
+	}
+	return name
+}
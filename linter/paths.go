@@ -0,0 +1,86 @@
+package linter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/toba/go-html-validate/rules"
+)
+
+// PathMode selects how Run reports filenames. Mixed styles break editor
+// jump-to-location and tools that resolve reported paths against a root.
+type PathMode string
+
+const (
+	// PathModeDefault reports paths as they were given or found.
+	PathModeDefault PathMode = ""
+	// PathModeAbsolute reports absolute paths.
+	PathModeAbsolute PathMode = "absolute"
+	// PathModeRelative reports paths relative to the working directory.
+	PathModeRelative PathMode = "relative"
+	// PathModeRepoRelative reports paths relative to the root of the
+	// enclosing git repository (the nearest directory with a .git entry),
+	// falling back to the working directory outside a repository.
+	PathModeRepoRelative PathMode = "repo-relative"
+)
+
+// ParsePathMode validates a --path-mode value.
+func ParsePathMode(s string) (PathMode, error) {
+	switch mode := PathMode(s); mode {
+	case PathModeDefault, PathModeAbsolute, PathModeRelative, PathModeRepoRelative:
+		return mode, nil
+	}
+	return "", fmt.Errorf("invalid path mode %q (supported: absolute, relative, repo-relative)", s)
+}
+
+// reportPaths rewrites result filenames for the configured PathMode.
+// Relative paths use forward slashes on every platform.
+func (c *Config) reportPaths(results []rules.Result) {
+	if c.PathMode == PathModeDefault {
+		return
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return
+	}
+	roots := make(map[string]string) // directory -> repository root
+	for i := range results {
+		if results[i].Filename == "" {
+			continue
+		}
+		abs, err := filepath.Abs(results[i].Filename)
+		if err != nil {
+			continue
+		}
+		base := wd
+		switch c.PathMode {
+		case PathModeAbsolute:
+			results[i].Filename = abs
+			continue
+		case PathModeRepoRelative:
+			if root := repoRoot(filepath.Dir(abs), roots); root != "" {
+				base = root
+			}
+		}
+		if rel, err := filepath.Rel(base, abs); err == nil {
+			results[i].Filename = filepath.ToSlash(rel)
+		}
+	}
+}
+
+// repoRoot returns the nearest ancestor of dir containing .git, or "".
+// Lookups are cached in roots.
+func repoRoot(dir string, roots map[string]string) string {
+	if root, ok := roots[dir]; ok {
+		return root
+	}
+	var root string
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+		root = dir
+	} else if parent := filepath.Dir(dir); parent != dir {
+		root = repoRoot(parent, roots)
+	}
+	roots[dir] = root
+	return root
+}
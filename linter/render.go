@@ -0,0 +1,128 @@
+package linter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/toba/go-html-validate/rules"
+)
+
+// RenderTemplate executes the Go template in files[0] on data with
+// html/template, as a server would, and returns the output. The other
+// files are parsed alongside it for {{template}} calls. Functions the
+// templates call that Go does not define are stubbed: a stub returns its
+// last argument, so piped values pass through, or "" without one, as the
+// type funcs declares it to return (template.HTML for "html", and so on).
+// Missing map keys render as their zero value.
+func RenderTemplate(files []string, data any, funcs map[string]rules.TemplateFunc) ([]byte, error) {
+	if len(files) == 0 {
+		return nil, errors.New("no template to render")
+	}
+
+	stubs := template.FuncMap{}
+	for _, path := range files {
+		content, err := os.ReadFile(path) //nolint:gosec // user-specified file path is intentional
+		if err != nil {
+			return nil, err
+		}
+		names, err := rules.TemplateFuncNames(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		for _, name := range names {
+			stubs[name] = stubFunc(funcs[name].Returns)
+		}
+	}
+
+	name := filepath.Base(files[0])
+	tmpl, err := template.New(name).Funcs(stubs).Option("missingkey=zero").ParseFiles(files...)
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&out, name, data); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// stubFunc returns a stand-in for a function the templates call but do
+// not define, returning the given kind.
+func stubFunc(kind string) any {
+	last := func(args []any) any {
+		if len(args) == 0 {
+			return ""
+		}
+		return args[len(args)-1]
+	}
+	text := func(args []any) string {
+		if v := last(args); v != nil {
+			return fmt.Sprint(v)
+		}
+		return ""
+	}
+	switch kind {
+	case rules.FuncReturnsHTML:
+		return func(args ...any) template.HTML { return template.HTML(text(args)) } //nolint:gosec // renders test data
+	case rules.FuncReturnsURL:
+		return func(args ...any) template.URL { return template.URL(text(args)) } //nolint:gosec // renders test data
+	case rules.FuncReturnsAttr:
+		return func(args ...any) template.HTMLAttr { return template.HTMLAttr(text(args)) } //nolint:gosec // renders test data
+	case rules.FuncReturnsString:
+		return func(args ...any) string { return text(args) }
+	}
+	return func(args ...any) any { return last(args) }
+}
+
+// ReadTemplateData reads data for RenderTemplate from a JSON file, or from
+// a YAML file (.yaml or .yml) in the subset readYAML accepts. Integral
+// numbers are read as int, so templates can compare them with literals
+// like {{if eq .Count 1}}.
+func ReadTemplateData(path string) (any, error) {
+	content, err := os.ReadFile(path) //nolint:gosec // user-specified file path is intentional
+	if err != nil {
+		return nil, err
+	}
+
+	var data any
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		data, err = readYAML(content)
+	default:
+		dec := json.NewDecoder(bytes.NewReader(content))
+		dec.UseNumber()
+		err = dec.Decode(&data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return normalizeNumbers(data), nil
+}
+
+// normalizeNumbers converts json.Number values to int when integral and
+// float64 otherwise.
+func normalizeNumbers(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return int(i)
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]any:
+		for k, child := range v {
+			v[k] = normalizeNumbers(child)
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = normalizeNumbers(child)
+		}
+	}
+	return v
+}
@@ -0,0 +1,114 @@
+package linter
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/toba/go-html-validate/parser"
+	"github.com/toba/go-html-validate/rules"
+)
+
+// streamHeadSize is how much of a streamed file is read ahead to find
+// generated-file markers and an htmlint-config directive.
+const streamHeadSize = 64 << 10
+
+// streams reports whether path is large enough to be linted in streaming
+// mode. templ files always need ProcessTempl and are never streamed.
+func (l *Linter) streams(path string) bool {
+	if l.config.StreamThreshold <= 0 || parser.IsTempl(path) {
+		return false
+	}
+	info, err := os.Stat(path)
+	return err == nil && info.Size() > l.config.StreamThreshold
+}
+
+// streamFile lints a file from its token stream, so memory stays bounded
+// however large the file is. Only rules implementing rules.TokenRule run;
+// raw, tree, and project rules and fixes are skipped. Generated markers
+// and a config directive are honored within the first streamHeadSize
+// bytes.
+func (l *Linter) streamFile(path string) ([]rules.Result, error) {
+	f, err := os.Open(path) //nolint:gosec // user-specified file path is intentional
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	r := bufio.NewReaderSize(f, streamHeadSize)
+	head, err := r.Peek(streamHeadSize)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if !l.config.Generated.Include && l.config.Generated.IsGenerated(head) {
+		return nil, nil
+	}
+
+	cfg, ruleSet, directiveResults := l.applyDirective(path, head)
+	cfg, ruleSet = l.newFileConfig(path, cfg, ruleSet)
+	allResults := appendResults(cfg, nil, directiveResults)
+
+	var checkers []*streamChecker
+	for _, rule := range ruleSet {
+		if tokenRule, ok := rule.(rules.TokenRule); ok {
+			checkers = append(checkers, &streamChecker{name: rule.Name(), checker: tokenRule.NewTokenChecker(path)})
+		}
+	}
+	if len(checkers) == 0 {
+		return allResults, nil
+	}
+
+	err = parser.Stream(path, r, func(tok *parser.Token) {
+		for _, c := range checkers {
+			c.token(path, tok)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range checkers {
+		allResults = appendResults(cfg, allResults, c.results(path))
+	}
+	return allResults, nil
+}
+
+// streamChecker runs one rule's TokenChecker. Like guard, it turns a
+// panic into an error finding for that rule, after which the rule sees no
+// more tokens.
+type streamChecker struct {
+	name    string
+	checker rules.TokenChecker
+	failed  []rules.Result
+}
+
+func (c *streamChecker) token(filename string, tok *parser.Token) {
+	if c.failed != nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			params := map[string]any{"error": fmt.Sprint(r)}
+			c.failed = []rules.Result{{
+				Rule:      c.name,
+				Message:   catalogMessage("internal-error", params),
+				MessageID: "internal-error",
+				Params:    params,
+				Filename:  filename,
+				Line:      tok.Line,
+				Col:       tok.Col,
+				Severity:  rules.Error,
+			}}
+		}
+	}()
+	c.checker.Token(tok)
+}
+
+func (c *streamChecker) results(filename string) []rules.Result {
+	if c.failed != nil {
+		return c.failed
+	}
+	return guard(c.name, filename, c.checker.Results)
+}
@@ -0,0 +1,118 @@
+package linter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/toba/go-html-validate/rules"
+)
+
+// Project is a workspace project: files under Dir are linted with Config.
+type Project struct {
+	Dir    string
+	Config *Config
+}
+
+// Workspace lints a monorepo whose projects each have their own
+// configuration. Every file is linted by the project with the deepest Dir
+// containing it, or with the workspace's own config when none does, and
+// the findings of all projects are reported together.
+type Workspace struct {
+	config     *Config
+	projects   []Project
+	reporter   Reporter
+	middleware []Middleware
+}
+
+// NewWorkspace creates a Workspace. cfg lints files outside every project.
+func NewWorkspace(cfg *Config, projects []Project) *Workspace {
+	if cfg == nil {
+		cfg = DefaultConfig()
+	}
+	return &Workspace{config: cfg, projects: projects}
+}
+
+// SetReporter sets the output reporter.
+func (w *Workspace) SetReporter(r Reporter) {
+	w.reporter = r
+}
+
+// Run lints paths with their projects' configs, reports the merged
+// results, and returns the number of errors.
+func (w *Workspace) Run(paths []string) (int, error) {
+	start := time.Now()
+	// Files grouped by owning project; index len(w.projects) is the
+	// workspace itself.
+	groups := make([][]string, len(w.projects)+1)
+	add := func(path string) {
+		i := w.owner(path)
+		groups[i] = append(groups[i], path)
+	}
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return 0, err
+		}
+		if !info.IsDir() {
+			add(path)
+			continue
+		}
+		err = filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() && isHTMLFile(p) {
+				add(p)
+			}
+			return nil
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	var allResults []rules.Result
+	linted := 0
+	for i, files := range groups {
+		if len(files) == 0 {
+			continue
+		}
+		cfg := w.config
+		if i < len(w.projects) {
+			cfg = w.projects[i].Config
+		}
+		l := New(cfg)
+		results, err := l.LintFiles(files)
+		if err != nil {
+			return 0, err
+		}
+		linted += l.files
+		cfg.reportPaths(results)
+		allResults = append(allResults, results...)
+	}
+
+	return report(w.reporter, applyMiddleware(w.middleware, allResults), linted, time.Since(start))
+}
+
+// owner returns the index of the project with the deepest Dir containing
+// path, or len(w.projects) when no project does.
+func (w *Workspace) owner(path string) int {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return len(w.projects)
+	}
+	best, bestLen := len(w.projects), -1
+	for i, p := range w.projects {
+		dir, err := filepath.Abs(p.Dir)
+		if err != nil {
+			continue
+		}
+		if (abs == dir || strings.HasPrefix(abs, dir+string(filepath.Separator))) && len(dir) > bestLen {
+			best, bestLen = i, len(dir)
+		}
+	}
+	return best
+}
@@ -0,0 +1,244 @@
+package linter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// yamlLine is a line of YAML without its indentation and comment.
+type yamlLine struct {
+	num    int
+	indent int
+	text   string
+}
+
+// readYAML reads the YAML subset test data is written in: block mappings
+// and sequences, plain, single-quoted, and double-quoted scalars, and flow
+// collections written as JSON. Anchors, tags, multi-document streams, and
+// block scalars (| and >) are not supported. Numbers are returned as
+// json.Number.
+func readYAML(content []byte) (any, error) {
+	var lines []yamlLine
+	for i, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimRight(stripYAMLComment(line), " \t\r")
+		trimmed := strings.TrimLeft(line, " ")
+		if trimmed == "" || (i == 0 && trimmed == "---") {
+			continue
+		}
+		if trimmed[0] == '\t' {
+			return nil, fmt.Errorf("line %d: tabs are not allowed in indentation", i+1)
+		}
+		lines = append(lines, yamlLine{num: i + 1, indent: len(line) - len(trimmed), text: trimmed})
+	}
+	if len(lines) == 0 {
+		return nil, nil
+	}
+
+	v, next, err := parseYAMLBlock(lines, 0, lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if next < len(lines) {
+		return nil, fmt.Errorf("line %d: unexpected indentation", lines[next].num)
+	}
+	return v, nil
+}
+
+// stripYAMLComment removes a # comment outside quotes.
+func stripYAMLComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// parseYAMLBlock parses the mapping or sequence starting at lines[i] with
+// the given indentation, returning it and the index of the next line.
+func parseYAMLBlock(lines []yamlLine, i, indent int) (any, int, error) {
+	if isYAMLSeqItem(lines[i].text) {
+		return parseYAMLSeq(lines, i, indent)
+	}
+	return parseYAMLMap(lines, i, indent)
+}
+
+func isYAMLSeqItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+func parseYAMLSeq(lines []yamlLine, i, indent int) (any, int, error) {
+	seq := []any{}
+	for i < len(lines) && lines[i].indent == indent && isYAMLSeqItem(lines[i].text) {
+		rest := strings.TrimLeft(lines[i].text[1:], " ")
+		switch {
+		case rest == "":
+			var v any
+			if i+1 < len(lines) && lines[i+1].indent > indent {
+				var err error
+				if v, i, err = parseYAMLBlock(lines, i+1, lines[i+1].indent); err != nil {
+					return nil, i, err
+				}
+			} else {
+				i++
+			}
+			seq = append(seq, v)
+		case isYAMLSeqItem(rest) || isYAMLMapEntry(rest):
+			// the item's content starts a nested block on the same line
+			itemIndent := indent + len(lines[i].text) - len(rest)
+			lines[i] = yamlLine{num: lines[i].num, indent: itemIndent, text: rest}
+			v, next, err := parseYAMLBlock(lines, i, itemIndent)
+			if err != nil {
+				return nil, next, err
+			}
+			seq = append(seq, v)
+			i = next
+		default:
+			v, err := yamlScalar(rest)
+			if err != nil {
+				return nil, i, fmt.Errorf("line %d: %w", lines[i].num, err)
+			}
+			seq = append(seq, v)
+			i++
+		}
+	}
+	if i < len(lines) && lines[i].indent > indent {
+		return nil, i, fmt.Errorf("line %d: unexpected indentation", lines[i].num)
+	}
+	return seq, i, nil
+}
+
+func parseYAMLMap(lines []yamlLine, i, indent int) (any, int, error) {
+	m := map[string]any{}
+	for i < len(lines) && lines[i].indent == indent && !isYAMLSeqItem(lines[i].text) {
+		key, value, ok := splitYAMLKey(lines[i].text)
+		if !ok {
+			return nil, i, fmt.Errorf("line %d: expected \"key: value\"", lines[i].num)
+		}
+		if value != "" {
+			v, err := yamlScalar(value)
+			if err != nil {
+				return nil, i, fmt.Errorf("line %d: %w", lines[i].num, err)
+			}
+			m[key] = v
+			i++
+			continue
+		}
+
+		i++
+		switch {
+		case i < len(lines) && lines[i].indent > indent:
+			v, next, err := parseYAMLBlock(lines, i, lines[i].indent)
+			if err != nil {
+				return nil, next, err
+			}
+			m[key], i = v, next
+		case i < len(lines) && lines[i].indent == indent && isYAMLSeqItem(lines[i].text):
+			// a sequence may share its key's indentation
+			v, next, err := parseYAMLSeq(lines, i, indent)
+			if err != nil {
+				return nil, next, err
+			}
+			m[key], i = v, next
+		default:
+			m[key] = nil
+		}
+	}
+	if i < len(lines) && lines[i].indent > indent {
+		return nil, i, fmt.Errorf("line %d: unexpected indentation", lines[i].num)
+	}
+	return m, i, nil
+}
+
+func isYAMLMapEntry(text string) bool {
+	_, _, ok := splitYAMLKey(text)
+	return ok
+}
+
+// splitYAMLKey splits "key: value" or "key:" into its key and value.
+func splitYAMLKey(text string) (key, value string, ok bool) {
+	rest := text
+	if text[0] == '"' || text[0] == '\'' {
+		end := strings.IndexByte(text[1:], text[0])
+		if end < 0 {
+			return "", "", false
+		}
+		key, rest = text[1:end+1], text[end+2:]
+		if !strings.HasPrefix(rest, ":") {
+			return "", "", false
+		}
+		rest = rest[1:]
+	} else {
+		colon := strings.Index(text, ": ")
+		switch {
+		case colon >= 0:
+			key, rest = text[:colon], text[colon+1:]
+		case strings.HasSuffix(text, ":"):
+			key, rest = text[:len(text)-1], ""
+		default:
+			return "", "", false
+		}
+		if key == "" || strings.ContainsAny(key[:1], "[{") {
+			return "", "", false
+		}
+	}
+	if rest != "" && rest[0] != ' ' {
+		return "", "", false
+	}
+	return strings.TrimSpace(key), strings.TrimSpace(rest), true
+}
+
+// yamlScalar parses a scalar or a flow collection written as JSON.
+func yamlScalar(s string) (any, error) {
+	switch s[0] {
+	case '"':
+		v, err := strconv.Unquote(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid double-quoted string %s", s)
+		}
+		return v, nil
+	case '\'':
+		if len(s) < 2 || s[len(s)-1] != '\'' {
+			return nil, fmt.Errorf("invalid single-quoted string %s", s)
+		}
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'"), nil
+	case '[', '{':
+		var v any
+		dec := json.NewDecoder(bytes.NewReader([]byte(s)))
+		dec.UseNumber()
+		if err := dec.Decode(&v); err != nil {
+			return nil, fmt.Errorf("flow collections must be written as JSON: %s", s)
+		}
+		return v, nil
+	case '|', '>':
+		return nil, errors.New("block scalars are not supported")
+	case '&', '*', '!':
+		return nil, errors.New("anchors, aliases, and tags are not supported")
+	}
+
+	switch s {
+	case "null", "Null", "NULL", "~":
+		return nil, nil
+	case "true", "True", "TRUE":
+		return true, nil
+	case "false", "False", "FALSE":
+		return false, nil
+	}
+	if json.Valid([]byte(s)) {
+		// the other JSON values are handled above
+		return json.Number(s), nil
+	}
+	return s, nil
+}
@@ -0,0 +1,25 @@
+package messages
+
+// de is the German catalog.
+var de = Catalog{
+	"button-name.missing":              "button-Element hat keinen zugänglichen Namen",
+	"config-directive.entry":           "Eintrag {{.entry}} der htmlint-config-Direktive muss key=value sein",
+	"config-directive.unknown-key":     "unbekannter Schlüssel in htmlint-config-Direktive: {{.key}}",
+	"config-directive.unknown-profile": "unbekanntes htmlint-config-Profil: {{.profile}}",
+	"config-directive.unknown-rule":    "unbekannte Regel in htmlint-config-Direktive: {{.rule}}",
+	"doctype.not-html5":                "DOCTYPE sollte html (HTML5) sein",
+	"doctype.missing":                  "Dokument hat keine DOCTYPE-Deklaration",
+	"doctype.limited-quirks":           "DOCTYPE versetzt das Dokument in den eingeschränkten Quirks-Modus; verwenden Sie <!DOCTYPE html>",
+	"doctype.quirks":                   "DOCTYPE versetzt das Dokument in den Quirks-Modus; verwenden Sie <!DOCTYPE html>",
+	"duplicate-id.repeat":              `doppelte id {{printf "%q" .id}} (zuerst definiert in Zeile {{.line}})`,
+	"empty-title.empty":                "<title> darf nicht leer sein und muss Text enthalten",
+	"heading-level.skip":               "Überschriftenebene von h{{.from}} auf h{{.to}} übersprungen",
+	"img-alt.missing":                  "img-Element fehlt das alt-Attribut",
+	"input-label.missing":              "{{.element}}-Element hat keine zugängliche Beschriftung",
+	"internal-error":                   "interner Fehler: {{.error}}",
+	"link-name.missing":                "Link-Element hat keinen zugänglichen Namen",
+	"no-dup-attr.repeat":               "doppeltes Attribut: {{.attr}}",
+	"parse-error":                      "Datei konnte nicht geparst werden: {{.error}}",
+	"require-lang.missing":             `<html>-Element muss ein lang-Attribut haben; für deutsche Inhalte lang="de" angeben`,
+	"require-lang.empty":               `lang-Attribut darf nicht leer sein; BCP-47-Code wie "de" oder "de-DE" verwenden`,
+}
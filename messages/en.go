@@ -0,0 +1,517 @@
+package messages
+
+// en is the English catalog, the source of every message ID.
+var en = Catalog{
+	"allowed-links.data":       "data: URLs in links are discouraged",
+	"allowed-links.empty":      "empty href is often a mistake; use # for placeholder links",
+	"allowed-links.https":      "links to {{.host}} must use https",
+	"allowed-links.javascript": "javascript: URLs are not allowed",
+	"allowed-links.own-domain": "absolute link to own domain {{.host}} should be relative",
+	"allowed-links.protocol":   "{{.scheme}}: links are not allowed",
+	"allowed-links.vbscript":   "vbscript: URLs are not allowed",
+
+	"area-alt.empty":   "area element has empty alt attribute",
+	"area-alt.missing": "area element with href must have alt attribute",
+
+	"aria-allowed-values.invalid": `invalid {{.attr}} value {{printf "%q" .value}} (allowed: {{.allowed}})`,
+
+	"aria-hidden-body.hidden": "aria-hidden on body hides entire page from assistive technology",
+
+	"aria-label-misuse.no-role": "{{.attr}} not allowed on <{{.element}}> without role attribute",
+
+	"aria-relationship.cycle":              `aria-owns {{printf "%q" .id}} references an ancestor, creating an ownership cycle`,
+	"aria-relationship.owned":              `{{printf "%q" .id}} is already owned by the <{{.owner}}> on line {{.line}}; an element can have only one aria-owns owner`,
+	"aria-relationship.self":               `{{.attr}} references the element itself ({{printf "%q" .id}})`,
+	"aria-relationship.tab-controls":       `tab aria-controls {{printf "%q" .id}} should point at a role="tabpanel" element, not the <{{.element}}> in {{.file}} on line {{.line}}`,
+	"aria-relationship.tab-controls-local": `tab aria-controls {{printf "%q" .id}} should point at a role="tabpanel" element, not <{{.element}}>`,
+
+	"asset-exists.not-found": `asset "{{.asset}}" not found at {{.path}}`,
+
+	"attribute-allowed-values.a-rel":          "invalid anchor rel value: {{.value}}",
+	"attribute-allowed-values.button-type":    "invalid button type: {{.value}}",
+	"attribute-allowed-values.crossorigin":    "invalid crossorigin value: {{.value}}",
+	"attribute-allowed-values.decoding":       "invalid decoding value: {{.value}}",
+	"attribute-allowed-values.dir":            "invalid dir value: {{.value}}",
+	"attribute-allowed-values.form-enctype":   "invalid form enctype: {{.value}}",
+	"attribute-allowed-values.form-method":    "invalid form method: {{.value}}",
+	"attribute-allowed-values.input-type":     "invalid input type: {{.value}}",
+	"attribute-allowed-values.link-rel":       "invalid link rel value: {{.value}}",
+	"attribute-allowed-values.loading":        "invalid loading value: {{.value}}",
+	"attribute-allowed-values.referrerpolicy": "invalid referrerpolicy value: {{.value}}",
+	"attribute-allowed-values.shadowrootmode": `invalid shadowrootmode: "{{.value}}" (use open or closed)`,
+	"attribute-allowed-values.th-scope":       "invalid th scope value: {{.value}}",
+
+	"attribute-misuse.invalid": "attribute '{{.attr}}' is not valid on <{{.element}}>",
+
+	"banned-markup.attribute": "{{.attr}} attribute on <{{.element}}> is banned{{with .message}}: {{.}}{{end}}",
+	"banned-markup.element":   "<{{.element}}> is banned{{with .message}}: {{.}}{{end}}",
+	"banned-markup.value":     `{{.attr}}={{printf "%q" .value}} on <{{.element}}> is banned{{with .message}}: {{.}}{{end}}`,
+
+	"button-name.missing": "button element missing accessible name",
+
+	"button-type.missing": "button missing type attribute (defaults to submit)",
+
+	"class-pattern.mismatch": `class "{{.class}}" does not match naming convention`,
+
+	"comment-syntax.abrupt":                 `comment is closed immediately by "{{.raw}}"; the text after it is rendered`,
+	"comment-syntax.bang-close":             `comment closed with "--!>"; use "-->"`,
+	"comment-syntax.cdata":                  "CDATA sections are only allowed in SVG and MathML; here the parser turns it into a comment and its content is not rendered",
+	"comment-syntax.double-dash":            `"--" inside a comment is invalid in XML and older HTML; use a different separator`,
+	"comment-syntax.nested":                 `nested "<!--" inside a comment; the first "-->" closes the outer comment too`,
+	"comment-syntax.processing-instruction": "processing instructions are not supported in HTML and are parsed as a comment; remove it",
+	"comment-syntax.unclosed":               "comment is never closed and hides the rest of the file",
+
+	"composite-widget.no-items":  "{{.role}} has no descendant with role {{.items}}",
+	"composite-widget.tab-stops": `{{.role}} has {{.count}} tabbable items; keep one tab stop with a roving tabindex (tabindex="0" on the active item, "-1" on the rest) or aria-activedescendant`,
+
+	"config-directive.entry":           "htmlint-config directive entry {{.entry}} must be key=value",
+	"config-directive.unknown-key":     "unknown htmlint-config directive key: {{.key}}",
+	"config-directive.unknown-profile": "unknown htmlint-config profile: {{.profile}}",
+	"config-directive.unknown-rule":    "unknown rule in htmlint-config directive: {{.rule}}",
+
+	"csp-compatible.handler":        "{{.attr}} handler violates CSP {{.directive}}; attach the listener from a script instead",
+	"csp-compatible.inline-script":  "inline script violates CSP {{.directive}}; add a nonce or move it to an external file",
+	"csp-compatible.inline-style":   "inline style violates CSP {{.directive}}; add a nonce or move it to an external file",
+	"csp-compatible.javascript-url": "javascript: URL violates CSP {{.directive}}",
+	"csp-compatible.style-attr":     "style attribute violates CSP {{.directive}}; use a class instead",
+	"csp-compatible.url":            `"{{.url}}" is not allowed by CSP {{.directive}}`,
+
+	"data-json.empty":   "{{.attr}} is empty, which JSON.parse rejects",
+	"data-json.invalid": "{{.attr}} contains invalid JSON: {{.error}}",
+
+	"deprecated.element": "element <{{.element}}> is deprecated; {{.suggestion}}",
+
+	"dir-consistency.arrow":          "arrow glyph in right-to-left content points a fixed direction; use CSS or mirrored icons",
+	"dir-consistency.bdo-invalid":    `<bdo> dir must be "ltr" or "rtl"`,
+	"dir-consistency.bdo-missing":    "<bdo> requires a dir attribute",
+	"dir-consistency.physical-style": "inline style in right-to-left content uses left/right; use logical properties such as margin-inline-start",
+
+	"doctype.limited-quirks": "DOCTYPE puts the document in limited-quirks mode; use <!DOCTYPE html>",
+	"doctype.missing":        "document is missing DOCTYPE declaration",
+	"doctype.not-html5":      "DOCTYPE should be html (HTML5)",
+	"doctype.quirks":         "DOCTYPE puts the document in quirks mode; use <!DOCTYPE html>",
+
+	"dom-size.depth":    "element nesting depth {{.depth}} exceeds {{.max}}: {{.chain}}",
+	"dom-size.elements": "document has {{.count}} elements, exceeds {{.max}}",
+
+	"duplicate-id.repeat": `duplicate id {{printf "%q" .id}} (first defined at line {{.line}})`,
+
+	"element-name.invalid":        "invalid element name: {{.element}}",
+	"element-name.unknown":        "unknown element: {{.element}}",
+	"element-name.unknown-mathml": "unknown MathML element: {{.element}}",
+
+	"element-permitted-content.child":      "<{{.child}}> is not permitted as child of <{{.element}}>",
+	"element-permitted-content.descendant": "<{{.element}}> must not contain <{{.forbidden}}>",
+
+	"element-permitted-occurrences.repeat": "<{{.element}}> must only appear once within <{{.context}}>",
+
+	"element-permitted-order.caption":  "<caption> must be first child of <table>",
+	"element-permitted-order.colgroup": "<colgroup> must come before <thead>, <tbody>, <tfoot>, and <tr>",
+	"element-permitted-order.head":     "<head> must come before <body>",
+	"element-permitted-order.legend":   "<legend> must be first child of <fieldset>",
+	"element-permitted-order.summary":  "<summary> must be first child of <details>",
+	"element-permitted-order.thead":    "<thead> must come before <tbody> and <tr>",
+
+	"element-permitted-parent.parent": "<{{.element}}> must be child of {{.parents}}, not <{{.parent}}>",
+
+	"element-required-ancestor.missing": "<{{.element}}> requires ancestor: {{.ancestors}}",
+
+	"element-required-attributes.missing": "<{{.element}}> requires attribute: {{.attr}}",
+
+	"element-required-content.missing": "<{{.element}}> requires child element: <{{.child}}>",
+
+	"empty-title.empty": "<title> cannot be empty, must have text content",
+
+	"fallback-content.canvas": "<canvas> has no fallback content or accessible name; describe what it shows inside the element or with aria-label",
+	"fallback-content.embed":  "<embed> cannot have fallback content; give it an accessible name with aria-label or title, or use <object> with fallback content",
+	"fallback-content.object": "<object> has no fallback content; add text or an image with alt inside it for when the resource cannot be shown",
+
+	"form-csrf-token.form": "POST form has no CSRF token field",
+	"form-csrf-token.htmx": "{{.attr}} request has no CSRF token in hx-headers, hx-vals, or an enclosing form",
+
+	"form-dup-name.repeat": "duplicate form control name: {{.name}}",
+
+	"form-submit.missing": "form has no submit button",
+
+	"framework-remnants.undeclared": "<{{.element}}> has {{.framework}} attributes ({{.attrs}}), but {{.framework}} {{if gt .count 1}}are{{else}}is{{end}} not among the configured frameworks",
+
+	"handler-syntax.error": "{{.attr}} handler has a syntax error: {{.error}}",
+
+	"heading-anchor.duplicate": `heading id "{{.id}}" is already used in this page`,
+	"heading-anchor.empty":     "<{{.element}}> has an empty id",
+	"heading-anchor.missing":   "<{{.element}}> in article or main content has no id to link to",
+	"heading-anchor.slug":      `heading id "{{.id}}" is not a valid slug`,
+
+	"heading-content.empty": "<{{.element}}> element has no text content",
+
+	"heading-level.skip": "heading level skipped from h{{.from}} to h{{.to}}",
+
+	"hidden-focusable.focusable": "{{.element}} is focusable but inside aria-hidden container",
+
+	"htmx-attributes.append-v4":                 ":append suffix is only available in htmx 4",
+	"htmx-attributes.event-action":              "unknown htmx 4 event action '{{.action}}' in '{{.event}}'",
+	"htmx-attributes.event-format":              "invalid htmx event format '{{.event}}'",
+	"htmx-attributes.event-phase":               "unknown htmx 4 event phase '{{.phase}}' in '{{.event}}'",
+	"htmx-attributes.event-unknown":             "unknown htmx event '{{.event}}'",
+	"htmx-attributes.include-selector":          "hx-include contains {{.error}}",
+	"htmx-attributes.inherited-append-v4":       ":inherited:append suffix is only available in htmx 4",
+	"htmx-attributes.inherited-v4":              ":inherited suffix is only available in htmx 4",
+	"htmx-attributes.json":                      "{{.attr}} contains invalid JSON: {{.error}}",
+	"htmx-attributes.on-no-event":               "hx-on:* requires an event name",
+	"htmx-attributes.on-unknown-event":          "unknown event '{{.event}}' in hx-on:*; if this is a custom event, ignore this warning",
+	"htmx-attributes.status-no-code":            "hx-status:* requires a status code (e.g., hx-status:404, hx-status:2xx)",
+	"htmx-attributes.status-pattern":            "invalid hx-status pattern: {{.error}}",
+	"htmx-attributes.status-v4":                 "hx-status:* attributes are only available in htmx 4",
+	"htmx-attributes.submit-bypass":             "{{.attr}} on submit button inside form may bypass form validation; consider moving to the form element",
+	"htmx-attributes.swap-focus-scroll":         "hx-swap focus-scroll modifier should be 'true' or 'false'",
+	"htmx-attributes.swap-invalid":              "invalid hx-swap value '{{.value}}'",
+	"htmx-attributes.swap-modifier-colon":       "invalid hx-swap modifier '{{.modifier}}' (missing colon)",
+	"htmx-attributes.swap-modifier-scroll":      "hx-swap {{.modifier}} modifier value should be 'top', 'bottom', or a selector",
+	"htmx-attributes.swap-modifier-time":        "hx-swap {{.modifier}} modifier requires a time value (e.g., '1s', '500ms')",
+	"htmx-attributes.swap-modifier-unknown":     "unknown hx-swap modifier '{{.modifier}}'",
+	"htmx-attributes.swap-strip":                "hx-swap strip modifier should be 'true' or 'false'",
+	"htmx-attributes.swap-transition":           "hx-swap transition modifier should be 'true' or 'false'",
+	"htmx-attributes.swap-v4":                   "hx-swap value '{{.value}}' is only available in htmx 4",
+	"htmx-attributes.target-keyword":            "invalid hx-target keyword '{{.keyword}}'; expected 'this', 'closest', 'find', 'findAll', 'next', 'previous', or a CSS selector",
+	"htmx-attributes.target-selector":           "hx-target contains {{.error}}",
+	"htmx-attributes.trigger-every-invalid":     "hx-trigger 'every' requires a valid time value (e.g., '1s', '500ms')",
+	"htmx-attributes.trigger-every-missing":     "hx-trigger 'every' requires a time value (e.g., 'every 1s')",
+	"htmx-attributes.trigger-intersect-unknown": "unknown intersect modifier '{{.modifier}}'",
+	"htmx-attributes.trigger-modifier-time":     "hx-trigger {{.modifier}} requires a time value (e.g., '1s', '500ms')",
+	"htmx-attributes.trigger-modifier-unknown":  "unknown hx-trigger modifier '{{.modifier}}'",
+	"htmx-attributes.trigger-queue":             "hx-trigger queue mode should be 'first', 'last', 'all', or 'none'",
+	"htmx-attributes.trigger-revealed-unknown":  "unknown revealed modifier '{{.modifier}}'",
+
+	"htmx-partial.multiple-roots": "partial has more than one root element; wrap them, or mark out-of-band elements with hx-swap-oob",
+	"htmx-partial.oob-id":         "out-of-band <{{.element}}> needs an id matching the element it replaces",
+
+	"id-pattern.mismatch": `id "{{.id}}" does not match naming convention`,
+
+	"iframe-require-sandbox.missing": "iframe should have a sandbox attribute restricting the embedded content",
+
+	"iframe-sandbox.allowlist":       `invalid allowlist entry "{{.origin}}" for {{.feature}}`,
+	"iframe-sandbox.escape":          "sandbox with both allow-scripts and allow-same-origin lets same-origin content remove its own sandbox",
+	"iframe-sandbox.feature-name":    `invalid allow feature name "{{.feature}}"`,
+	"iframe-sandbox.header-syntax":   `allow uses Permissions-Policy header syntax; write directives as "feature 'self' https://example.com" separated by ;`,
+	"iframe-sandbox.top-navigation":  "sandbox must not combine allow-top-navigation with allow-top-navigation-by-user-activation",
+	"iframe-sandbox.unknown-feature": `unknown allow feature "{{.feature}}"`,
+	"iframe-sandbox.unknown-token":   `unknown sandbox token "{{.token}}"`,
+
+	"img-alt.missing": "img element missing alt attribute",
+
+	"inline-whitespace.adjacent": "<{{.element}}> directly follows <{{.previous}}> with no whitespace between them, so their text runs together",
+	"inline-whitespace.trimmed":  "trim markers remove the only whitespace between two inline elements, so their text runs together; drop the marker on the side of the whitespace",
+
+	"input-attributes.htmx-deprecated": "htmx attribute '{{.attr}}' is deprecated in htmx 4",
+	"input-attributes.htmx-disabled":   "htmx attribute '{{.attr}}' used but htmx not enabled in config",
+	"input-attributes.htmx-unknown":    "unknown htmx attribute '{{.attr}}'",
+	"input-attributes.htmx-v4":         "htmx attribute '{{.attr}}' is only available in htmx 4",
+	"input-attributes.invalid":         `attribute '{{.attr}}' not valid for input type="{{.type}}"`,
+
+	"input-label.missing": "{{.element}} element missing accessible label",
+
+	"input-value-format.invalid": `{{.attr}} "{{.value}}" is not valid for type="{{.type}}"; use {{.example}}`,
+
+	"internal-error": "internal error: {{.error}}",
+
+	"link-name.missing": "link element missing accessible name",
+
+	"link-purpose.ambiguous": `link text {{printf "%q" .text}} also links to {{.href}} on line {{.line}}; links with the same text should go to the same place`,
+	"link-purpose.generic":   `link text {{printf "%q" .text}} does not describe the destination; say where the link goes`,
+	"link-purpose.nowhere":   `link with href={{printf "%q" .href}} goes nowhere; use <button type="button"> for actions`,
+
+	"long-title.long": "title text is {{.length}} characters, should be at most {{.max}}",
+
+	"map-dup-name.repeat": "duplicate area name: {{.name}}",
+
+	"map-id-name.mismatch":     `map id="{{.id}}" and name="{{.name}}" should match`,
+	"map-id-name.missing-name": "map element must have name attribute",
+
+	"mathml-structure.bare-text":        "text in <{{.element}}> must be wrapped in <mi>, <mn>, <mo>, or <mtext>",
+	"mathml-structure.child-count":      "<{{.element}}> requires exactly {{.want}} child elements, found {{.count}}",
+	"mathml-structure.encoding-html":    `<annotation-xml> containing HTML must use encoding "text/html" or "application/xhtml+xml"`,
+	"mathml-structure.encoding-missing": `<annotation-xml> should have an encoding attribute, e.g. "application/mathml-content+xml"`,
+	"mathml-structure.mtable-child":     "<mtable> children must be <mtr> elements",
+	"mathml-structure.parent":           "<{{.element}}> must be a child of <{{.parents}}>",
+	"mathml-structure.token-child":      "<{{.element}}> holds text; move <{{.child}}> outside it",
+
+	"media-query.invalid":        `invalid media query "{{.media}}" on <{{.element}}>: {{.problem}}`,
+	"media-query.print-blocking": "print stylesheet is loaded ahead of first render by {{.by}}; print styles are only needed when printing",
+
+	"media-source.legacy-sources": "<{{.element}}> only offers legacy plugin formats that browsers cannot play; add an MP4, WebM, or other supported <source>",
+	"media-source.legacy-src":     "<{{.element}}> src is a legacy plugin format that browsers cannot play; use MP4, WebM, or another supported format",
+	"media-source.source-src":     "<source> in <{{.element}}> requires src",
+	"media-source.src-and-source": "<{{.element}}> has both src and <source> children; the <source> elements are ignored",
+	"media-source.type-empty":     "empty type on <source>",
+	"media-source.type-not-media": "<source> type in media element must be an audio or video MIME type: {{.type}}",
+	"media-source.type-syntax":    "<source> type is not a MIME type: {{.type}}",
+	"media-source.type-unknown":   "unknown media type: {{.type}}",
+
+	"meta-refresh.refresh": "meta refresh causes automatic page change, disorienting users",
+
+	"minified-file.minified": "file looks minified (line {{.line}} is {{.kb}} KB long); lint the unminified source or exclude build output",
+
+	"motion-safety.animation":            `{{printf "%q" .selector}} runs an infinite animation without a prefers-reduced-motion media query (WCAG 2.2.2)`,
+	"motion-safety.autoplay":             "autoplaying <video> without a prefers-reduced-motion guard in the page's CSS; pause it for users who prefer reduced motion and provide controls (WCAG 2.2.2)",
+	"motion-safety.inline-animation":     "inline style runs an infinite animation that cannot honor prefers-reduced-motion; move it to a style sheet with a reduced-motion guard and provide a way to pause it (WCAG 2.2.2)",
+	"motion-safety.inline-smooth-scroll": "scroll-behavior: smooth on <{{.element}}> animates every in-page jump and cannot honor prefers-reduced-motion inline (WCAG 2.3.3)",
+	"motion-safety.smooth-scroll":        `{{printf "%q" .selector}} sets scroll-behavior: smooth for the whole page without a prefers-reduced-motion media query (WCAG 2.3.3)`,
+
+	"multiple-labeled-controls.multiple": "label is associated with multiple controls",
+
+	"name-pattern.mismatch": `name "{{.name}}" does not match naming convention`,
+
+	"nav-semantics.breadcrumb-current":      `last breadcrumb item should have aria-current="page"`,
+	"nav-semantics.breadcrumb-current-last": `only the last breadcrumb item, the current page, should have aria-current="page"`,
+	"nav-semantics.breadcrumb-list":         "breadcrumb should list its links in an <ol>",
+	"nav-semantics.breadcrumb-nav":          `breadcrumb should be a <nav aria-label="Breadcrumb"> so it is announced as a navigation landmark`,
+	"nav-semantics.breadcrumb-ol":           "breadcrumb trail is ordered; use <ol> instead of <ul>",
+	"nav-semantics.pagination-current":      `pagination should mark the current page with aria-current="page"`,
+	"nav-semantics.pagination-nav":          `pagination should be a <nav aria-label="Pagination"> so it is announced as a navigation landmark`,
+
+	"no-abstract-role.abstract": `abstract role {{printf "%q" .role}} must not be used`,
+
+	"no-autoplay.autoplay": "{{.element}} element has autoplay attribute",
+
+	"no-commented-markup.markup": "comment holds {{.lines}} lines of commented-out markup ({{.tags}} tags); delete it and rely on version control",
+
+	"no-conditional-comment.conditional":        "IE conditional comments are deprecated and not supported in modern browsers",
+	"no-conditional-comment.document-all":       "document.all is a legacy IE sniff; use feature detection instead",
+	"no-conditional-comment.downlevel-revealed": "downlevel-revealed IE conditional comments are deprecated and not supported in modern browsers",
+	"no-conditional-comment.x-ua-compatible":    "X-UA-Compatible meta only affects legacy Internet Explorer and can be removed",
+
+	"no-debug-artifacts.class":   `element with class "{{.class}}" is meant for debugging or tests`,
+	"no-debug-artifacts.comment": "debug comment block should be removed",
+	"no-debug-artifacts.marker":  `HTML comment contains {{.marker}} and is sent to the browser; resolve it or use a {{"{{/* */}}"}} template comment`,
+	"no-debug-artifacts.printf":  `printf "%#v" dumps Go values into the page; remove the debug output`,
+
+	"no-deprecated-attr.element": `attribute "{{.attr}}" on <{{.element}}> is deprecated; {{.suggestion}}`,
+	"no-deprecated-attr.global":  `attribute "{{.attr}}" is deprecated; {{.suggestion}}`,
+
+	"no-dup-attr.repeat": "duplicate attribute: {{.attr}}",
+
+	"no-dup-class.repeat": "duplicate class name: {{.class}}",
+
+	"no-dup-script.duplicate":     `script {{printf "%q" .src}} is included more than once in the composed page (first at {{.file}}:{{.line}})`,
+	"no-dup-script.repeated-call": `script {{printf "%q" .src}} is included more than once in the composed page because template {{printf "%q" .template}} is called more than once`,
+
+	"no-environment-url.host": `{{.attr}} points to {{.reason}} {{printf "%q" .host}}; use a relative URL or a configured base URL`,
+
+	"no-hardcoded-text.literal-attr": "literal text in {{.attr}}; use a translation function",
+	"no-hardcoded-text.literal-text": "literal text in text; use a translation function",
+	"no-hardcoded-text.string-attr":  "untranslated string literal in {{.attr}}; use a translation function",
+	"no-hardcoded-text.string-text":  "untranslated string literal in text; use a translation function",
+
+	"no-implicit-input-type.missing": `input should have explicit type attribute (defaults to "text")`,
+
+	"no-inline-style.style": "avoid inline style attribute; use CSS classes instead",
+
+	"no-missing-references.aria-controls":    "aria-controls references non-existent id: {{.id}}",
+	"no-missing-references.aria-describedby": "aria-describedby references non-existent id: {{.id}}",
+	"no-missing-references.aria-labelledby":  "aria-labelledby references non-existent id: {{.id}}",
+	"no-missing-references.aria-owns":        "aria-owns references non-existent id: {{.id}}",
+	"no-missing-references.for":              `for="{{.id}}" references non-existent id`,
+	"no-missing-references.headers":          "headers references non-existent id: {{.id}}",
+	"no-missing-references.list":             `list="{{.id}}" references non-existent datalist`,
+	"no-missing-references.usemap":           `usemap="{{.usemap}}" may reference non-existent map`,
+
+	"no-mojibake.garbled":     `"{{.text}}" looks like "{{.original}}" garbled by reading UTF-8 as Windows-1252; retype the text or re-save it as UTF-8`,
+	"no-mojibake.replacement": "replacement character U+FFFD in text; the original character was lost in an encoding conversion",
+
+	"no-multiple-main.multiple": "document has multiple visible <main> elements; remove this <main> or add hidden attribute",
+
+	"no-redundant-aria-label.duplicate": "aria-label duplicates visible text content",
+
+	"no-redundant-for.redundant": `label for="{{.id}}" is redundant when label wraps the control`,
+
+	"no-redundant-role.redundant": `<{{.element}}> has implicit role {{printf "%q" .role}}, explicit role is redundant`,
+
+	"no-style-tag.style": "inline <style> tags are discouraged; use external stylesheets",
+
+	"no-unrendered-placeholder.action":      "{{.where}} contains an unrendered template action; check the template or script that renders it",
+	"no-unrendered-placeholder.format":      "{{.where}} contains a Go formatting error ({{.match}}...); check the template or script that renders it",
+	"no-unrendered-placeholder.placeholder": `{{.where}} contains "{{.match}}"; check the template or script that renders it`,
+
+	"no-xhtml-syntax.doctype":      "obsolete {{.doctype}} doctype; use <!DOCTYPE html>",
+	"no-xhtml-syntax.self-closing": "<{{.element}} /> is not self-closing in HTML; use <{{.element}}></{{.element}}>",
+	"no-xhtml-syntax.xmlns":        "xmlns attribute on <html> is unnecessary in HTML documents",
+
+	"page-structure.exactly-one-many":    `{{.page}} page: {{printf "%q" .selector}} must appear exactly once, found {{.count}}`,
+	"page-structure.exactly-one-missing": `{{.page}} page: missing {{printf "%q" .selector}}, which must appear exactly once`,
+	"page-structure.forbid":              `{{.page}} page: {{printf "%q" .selector}} is not allowed`,
+	"page-structure.require":             `{{.page}} page: missing required {{printf "%q" .selector}}`,
+
+	"parse-error": "{{.error}}",
+
+	"picture-source.after-img":       "<source> after the <img> fallback is ignored",
+	"picture-source.media-duplicate": `duplicate media query "{{.media}}" never matches`,
+	"picture-source.media-invalid":   `invalid media query "{{.media}}": {{.problem}}`,
+	"picture-source.no-img":          "<picture> needs an <img> fallback",
+	"picture-source.srcset":          "<source> in <picture> requires srcset",
+	"picture-source.type-empty":      "empty type on <source>",
+	"picture-source.type-not-image":  "<source> type in <picture> must be an image MIME type: {{.type}}",
+	"picture-source.type-unknown":    "unknown image type: {{.type}}",
+	"picture-source.unreachable":     "unreachable <source>: an earlier source has no media or type and always matches",
+
+	"prefer-aria.replace": "{{.attr}} should use {{.aria}} instead",
+	"prefer-aria.state":   "{{.attr}} appears to indicate state; consider using {{.aria}}",
+
+	"prefer-button.input": `prefer <button> over <input type={{printf "%q" .type}}>`,
+
+	"prefer-component.component": `<{{.element}}> matching {{printf "%q" .selector}} should use {{.call}}{{if .message}}: {{.message}}{{end}}`,
+
+	"prefer-native-element.role": `use {{.native}} element instead of <{{.element}} role="{{.role}}">; native elements have better accessibility support`,
+
+	"prefer-semantic.click": "{{.element}} with click handler should be a <button> element",
+	"prefer-semantic.role":  `{{.element}} with role="button" should be a <button> element`,
+
+	"prefer-tbody.missing": "table should use explicit <tbody> element",
+
+	"preformatted-indent.indented": "every line inside <{{.element}}> is indented by {{.count}} whitespace characters, which is rendered; remove the indentation",
+
+	"require-csp-nonce.script": "inline script should have nonce attribute for CSP",
+	"require-csp-nonce.style":  "inline style should have nonce attribute for CSP",
+
+	"require-lang.empty":   `lang attribute must not be empty; use BCP 47 code like "en" or "en-US"`,
+	"require-lang.missing": `<html> element must have a lang attribute; add lang="en" for English content`,
+
+	"require-sri.script":     "external script missing integrity attribute for SRI",
+	"require-sri.stylesheet": "external stylesheet missing integrity attribute for SRI",
+
+	"require-utf8.bom":     "file is encoded as {{.encoding}}; save it as UTF-8 without a byte order mark",
+	"require-utf8.declare": `file is encoded as {{.encoding}}; save it as UTF-8 and declare <meta charset="utf-8">`,
+
+	"required-attributes.missing": `<{{.element}}> matching {{printf "%q" .selector}} requires {{.attr}}{{if .message}}: {{.message}}{{end}}`,
+
+	"resource-hints.blocking":               "more than {{.max}} render-blocking stylesheets in <head>; combine them or load non-critical CSS asynchronously",
+	"resource-hints.font-crossorigin":       "font preload needs crossorigin, or the font is downloaded twice",
+	"resource-hints.preconnect-crossorigin": "preconnect to font origin {{.host}} needs crossorigin to be reused for font requests",
+	"resource-hints.unused":                 "{{.rel}} hint for {{.host}}, which is not used elsewhere in the document",
+
+	"script-element.async-defer":     "script should not have both async and defer",
+	"script-element.async-no-src":    "async attribute requires src attribute",
+	"script-element.defer-no-src":    "defer attribute requires src attribute",
+	"script-element.nomodule-module": "nomodule attribute should not be on module scripts",
+	"script-element.src-content":     "script with src should not have inline content",
+	"script-element.unknown-type":    "unknown script type: {{.type}}",
+
+	"script-type.invalid": "invalid script type: {{.type}}",
+
+	"selector-rule.forbid":  `<{{.element}}> must not have attribute {{printf "%q" .attr}}`,
+	"selector-rule.match":   `<{{.element}}> matches {{printf "%q" .selector}}`,
+	"selector-rule.message": "{{.message}}",
+	"selector-rule.require": `<{{.element}}> is missing required attribute {{printf "%q" .attr}}`,
+
+	"sensitive-url-data.get": `sensitive field "{{.name}}" is sent in the URL by {{.by}}; use POST`,
+
+	"slot-name.missing": `no <slot name="{{.name}}"> in the shadow root of <{{.element}}>`,
+	"slot-name.repeat":  "duplicate slot name: {{.name}}",
+
+	"srcset-descriptors.invalid": `invalid srcset descriptor "{{.descriptor}}" for {{.url}}`,
+	"srcset-descriptors.mixed":   "srcset mixes width (w) and density (x) descriptors; use one kind",
+	"srcset-descriptors.sizes":   "sizes has no effect without width (w) descriptors in srcset",
+	"srcset-descriptors.src":     `src "{{.src}}" is not one of the srcset candidates`,
+
+	"svg-focusable.missing": `SVG inside interactive element should have focusable="false"`,
+
+	"svg-use-reference.file":          `<use> references "{{.id}}", which is not defined in {{.file}}`,
+	"svg-use-reference.local":         `<use> references "#{{.id}}", which is not defined in this document`,
+	"svg-use-reference.local-sprites": `<use> references "#{{.id}}", which is not defined in this document or the configured sprites`,
+
+	"tabindex-no-positive.positive": "positive tabindex disrupts natural tab order; use 0 or -1",
+
+	"target-size.small": "<{{.element}}> is {{.size}}; pointer targets should be at least {{.min}}×{{.min}} (WCAG 2.5.8)",
+
+	"tel-non-breaking.spaces": "tel: link text contains regular spaces; use &nbsp; or CSS white-space: nowrap",
+
+	"template-action-placement.split-comment": "template action breaks HTML structure: {{.action}} of {{.open}} is in a different comment than its opening action",
+	"template-action-placement.split-name":    "template action breaks HTML structure: action splits a tag name",
+	"template-action-placement.split-tag":     "template action breaks HTML structure: {{.action}} of {{.open}} is in a different tag than its opening action",
+	"template-action-placement.split-text":    "template action breaks HTML structure: {{.action}} of {{.open}} is in a different text run than its opening action",
+
+	"template-call-data.no-data": `template {{printf "%q" .template}} is called without data, so dot is nil, but with {{printf "%q" .arg}} at {{.where}}`,
+	"template-call-data.shape":   `template {{printf "%q" .template}} is called with {{printf "%q" .arg}} here but with {{printf "%q" .other}} at {{.where}}; check the template expects this data`,
+
+	"template-escaping-context.attr-func": "{{.func}} returns template.HTMLAttr, which html/template trusts only as whole attributes; inside a value it is escaped as text",
+	"template-escaping-context.handler":   "template value inside an event handler attribute is JavaScript-escaped; pass it in a data- attribute and read it from the handler",
+	"template-escaping-context.html-func": "{{.func}} returns template.HTML, whose tags html/template strips inside an attribute value",
+	"template-escaping-context.script":    `template value inside <script> is JavaScript-escaped; pass data through a data- attribute or a <script type="application/json"> block`,
+	"template-escaping-context.style":     "template value inside CSS is replaced with ZgotmplZ unless known safe; prefer toggling a class or setting a CSS custom property from a data- attribute",
+	"template-escaping-context.url-func":  "{{.func}} returns template.URL, which html/template does not sanitize; make sure it cannot return a javascript: URL",
+
+	"template-references.undefined": `template "{{.name}}" is not defined in any linted file`,
+	"template-references.unused":    `template "{{.name}}" is defined but never called`,
+
+	"template-syntax-valid.func-args":             `function {{printf "%q" .func}} takes {{.args}} argument(s), called with {{.count}}`,
+	"template-syntax-valid.func-min-args":         `function {{printf "%q" .func}} takes at least {{.args}} argument(s), called with {{.count}}`,
+	"template-syntax-valid.mismatched-end":        "'{{.action}}' closes '{{.open}}' - expected '{{.end}}'",
+	"template-syntax-valid.trim-close":            "trim marker '{{.marker}}' must be preceded by whitespace",
+	"template-syntax-valid.trim-open":             "trim marker '{{.marker}}' must be followed by whitespace",
+	"template-syntax-valid.unclosed":              "unclosed '{{.action}}' - missing '{{.end}}'",
+	"template-syntax-valid.undeclared-func":       `function "{{.func}}" is not declared in template-functions`,
+	"template-syntax-valid.unexpected-block-else": "unexpected '{{.action}}' - no enclosing block",
+	"template-syntax-valid.unexpected-block-end":  "unexpected '{{.action}}' - no open block for it to close",
+	"template-syntax-valid.unexpected-catch":      "unexpected '{{.action}}' - no matching 'try'",
+	"template-syntax-valid.unexpected-else":       "unexpected '{{.action}}' - no matching 'if' or 'with'",
+	"template-syntax-valid.unexpected-end":        "unexpected '{{.action}}' - no matching control structure",
+	"template-syntax-valid.unmatched-close":       "unmatched '{{.close}}' - missing opening '{{.open}}'",
+	"template-syntax-valid.unmatched-open":        "unmatched '{{.open}}' - missing closing '{{.close}}'",
+
+	"template-whitespace-trim.blank-line": "control flow action alone on line should use trailing trim marker (-}}) to prevent blank lines",
+
+	"text-content.summary": "summary element must have accessible text content",
+
+	"text-resize.clipped":       "<{{.element}}> has a fixed height of {{.height}} with its overflow hidden, so resized text is cut off (WCAG 1.4.4)",
+	"text-resize.maximum-scale": "viewport maximum-scale={{.value}} keeps text from being zoomed to 200% (WCAG 1.4.4)",
+	"text-resize.user-scalable": "viewport user-scalable={{.value}} disables zooming (WCAG 1.4.4)",
+
+	"typographic-chars.entity":     "write the {{.name}} as {{.entity}}",
+	"typographic-chars.entity-ref": "write the {{.name}} as {{.entity}} instead of {{.ref}}",
+	"typographic-chars.literal":    "write the {{.name}} as a literal character instead of {{.ref}}",
+
+	"unique-landmark.duplicate": `duplicate {{printf "%q" .role}} landmark name {{printf "%q" .name}} (first at line {{.line}})`,
+	"unique-landmark.unlabeled": `multiple {{printf "%q" .role}} landmarks, this one needs aria-label to distinguish it`,
+
+	"unrecognized-char-ref.bare-ampersand": "bare & should be escaped as &amp;",
+	"unrecognized-char-ref.bare-less-than": "< in text content should be escaped as &lt;",
+	"unrecognized-char-ref.unrecognized":   "unrecognized character reference &{{.name}};",
+
+	"url-encoding.ampersand":  `& before "{{.name}}" in {{.attr}} URL may be read as a character reference; escape it as &amp;`,
+	"url-encoding.quote":      "{{.attr}} URL contains a raw double quote; use %22",
+	"url-encoding.urlquery":   "template value in {{.attr}} query string should be piped through urlquery",
+	"url-encoding.whitespace": "{{.attr}} URL contains unencoded whitespace; use %20",
+
+	"valid-autocomplete.invalid": "invalid autocomplete token: {{.token}}",
+
+	"valid-contact-link.mailto-address":  "mailto: link has an invalid address: {{.address}}",
+	"valid-contact-link.mailto-empty":    "mailto: link has no address",
+	"valid-contact-link.mailto-percent":  "mailto: link contains an invalid percent-encoding",
+	"valid-contact-link.mailto-query":    "mailto: query must be name=value pairs separated by &: {{.field}}",
+	"valid-contact-link.mailto-spaces":   "mailto: link contains spaces; percent-encode them as %20",
+	"valid-contact-link.tel-chars":       "tel: link contains invalid characters: {{.number}}",
+	"valid-contact-link.tel-empty":       "tel: link has no phone number",
+	"valid-contact-link.tel-global":      "tel: link should use the global +<country code> format",
+	"valid-contact-link.tel-parentheses": "tel: link must not contain parentheses; use - as a separator",
+	"valid-contact-link.tel-spaces":      "tel: link must not contain spaces; use - as a separator",
+
+	"valid-for.hidden-input":  "label for attribute references hidden input",
+	"valid-for.non-labelable": "label for attribute references non-labelable element <{{.element}}>",
+
+	"valid-id.digit":      `id "{{.id}}" starts with digit; prefix with letter to avoid CSS selector issues`,
+	"valid-id.empty":      "id attribute must not be empty; provide a unique identifier or remove the attribute",
+	"valid-id.whitespace": `id "{{.id}}" contains whitespace; use hyphens or underscores instead of spaces`,
+
+	"void-content.children": "void element <{{.element}}> must not have child elements",
+	"void-content.text":     "void element <{{.element}}> must not have text content",
+
+	"wcag/h36.empty":   `input type="image" has empty alt attribute`,
+	"wcag/h36.missing": `input type="image" must have alt attribute`,
+
+	"wcag/h63.invalid": "th scope attribute has invalid value: {{.scope}}",
+	"wcag/h63.missing": "th element should have scope attribute (col, row, colgroup, or rowgroup)",
+
+	"wcag/h67.role":  `decorative image (alt="") should have role="presentation" or role="none"`,
+	"wcag/h67.title": `decorative image (alt="") should not have title attribute`,
+
+	"wcag/h71.missing": "fieldset element must contain a legend element",
+}
@@ -0,0 +1,25 @@
+package messages
+
+// ja is the Japanese catalog.
+var ja = Catalog{
+	"button-name.missing":              "button 要素にアクセシブルな名前がありません",
+	"config-directive.entry":           "htmlint-config ディレクティブの項目 {{.entry}} は key=value 形式で指定してください",
+	"config-directive.unknown-key":     "htmlint-config ディレクティブの不明なキー: {{.key}}",
+	"config-directive.unknown-profile": "不明な htmlint-config プロファイル: {{.profile}}",
+	"config-directive.unknown-rule":    "htmlint-config ディレクティブの不明なルール: {{.rule}}",
+	"doctype.not-html5":                "DOCTYPE は html (HTML5) にしてください",
+	"doctype.missing":                  "ドキュメントに DOCTYPE 宣言がありません",
+	"doctype.limited-quirks":           "DOCTYPE によりドキュメントが準標準モードになります。<!DOCTYPE html> を使用してください",
+	"doctype.quirks":                   "DOCTYPE によりドキュメントが互換モードになります。<!DOCTYPE html> を使用してください",
+	"duplicate-id.repeat":              `id {{printf "%q" .id}} が重複しています (最初の定義は {{.line}} 行目)`,
+	"empty-title.empty":                "<title> を空にすることはできません。テキストを含めてください",
+	"heading-level.skip":               "見出しレベルが h{{.from}} から h{{.to}} に飛んでいます",
+	"img-alt.missing":                  "img 要素に alt 属性がありません",
+	"input-label.missing":              "{{.element}} 要素にアクセシブルなラベルがありません",
+	"internal-error":                   "内部エラー: {{.error}}",
+	"link-name.missing":                "リンク要素にアクセシブルな名前がありません",
+	"no-dup-attr.repeat":               "属性が重複しています: {{.attr}}",
+	"parse-error":                      "ファイルを解析できません: {{.error}}",
+	"require-lang.missing":             `<html> 要素には lang 属性が必要です。日本語のコンテンツには lang="ja" を指定してください`,
+	"require-lang.empty":               `lang 属性を空にすることはできません。"ja" や "ja-JP" のような BCP 47 コードを使用してください`,
+}
@@ -0,0 +1,82 @@
+// Package messages holds the catalog of rule messages and their
+// translations. Messages are keyed by stable IDs (e.g. "img-alt.missing")
+// so tooling can match findings without depending on their wording, and
+// are text/template strings rendered with per-finding parameters.
+package messages
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// DefaultLocale is the locale rules report messages in.
+const DefaultLocale = "en"
+
+// Catalog maps message IDs to message templates.
+type Catalog map[string]string
+
+// catalogs holds every supported locale. Locales other than
+// DefaultLocale may omit messages; they fall back to English.
+var catalogs = map[string]Catalog{
+	"en": en,
+	"de": de,
+	"ja": ja,
+}
+
+// parsed caches compiled templates by locale and ID.
+var parsed sync.Map
+
+// Locales returns the supported locales in sorted order.
+func Locales() []string {
+	return slices.Sorted(maps.Keys(catalogs))
+}
+
+// IDs returns the message IDs a locale's catalog defines, sorted.
+func IDs(locale string) []string {
+	lang, _ := Normalize(locale)
+	return slices.Sorted(maps.Keys(catalogs[lang]))
+}
+
+// Normalize reduces a locale such as "de-DE" or "de_DE.UTF-8" to its
+// language code, returning false if it is not supported.
+func Normalize(locale string) (string, bool) {
+	lang := strings.ToLower(locale)
+	if i := strings.IndexAny(lang, "-_."); i >= 0 {
+		lang = lang[:i]
+	}
+	_, ok := catalogs[lang]
+	return lang, ok
+}
+
+// Render renders message id in locale with params. It falls back to the
+// English message when the locale lacks a translation, and returns false
+// when the ID is unknown or the template fails to render.
+func Render(locale, id string, params map[string]any) (string, bool) {
+	lang, _ := Normalize(locale)
+	text, ok := catalogs[lang][id]
+	if !ok {
+		lang = DefaultLocale
+		if text, ok = en[id]; !ok {
+			return "", false
+		}
+	}
+
+	key := lang + "\x00" + id
+	tmpl, cached := parsed.Load(key)
+	if !cached {
+		t, err := template.New(id).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return "", false
+		}
+		tmpl, _ = parsed.LoadOrStore(key, t)
+	}
+
+	var b strings.Builder
+	if err := tmpl.(*template.Template).Execute(&b, params); err != nil {
+		return "", false
+	}
+	return b.String(), true
+}
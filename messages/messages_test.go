@@ -0,0 +1,100 @@
+package messages_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/toba/go-html-validate/messages"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name   string
+		locale string
+		id     string
+		params map[string]any
+		want   string
+		wantOK bool
+	}{
+		{
+			name:   "english",
+			locale: "en",
+			id:     "img-alt.missing",
+			want:   "img element missing alt attribute",
+			wantOK: true,
+		},
+		{
+			name:   "parameters",
+			locale: "en",
+			id:     "duplicate-id.repeat",
+			params: map[string]any{"id": "main", "line": 3},
+			want:   `duplicate id "main" (first defined at line 3)`,
+			wantOK: true,
+		},
+		{
+			name:   "translated with region",
+			locale: "de-AT",
+			id:     "heading-level.skip",
+			params: map[string]any{"from": 1, "to": 3},
+			want:   "Überschriftenebene von h1 auf h3 übersprungen",
+			wantOK: true,
+		},
+		{
+			name:   "unsupported locale falls back to english",
+			locale: "fr",
+			id:     "img-alt.missing",
+			want:   "img element missing alt attribute",
+			wantOK: true,
+		},
+		{
+			name:   "unknown id",
+			locale: "en",
+			id:     "nope.missing",
+		},
+		{
+			name:   "missing parameter",
+			locale: "en",
+			id:     "no-dup-attr.repeat",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := messages.Render(tt.locale, tt.id, tt.params)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("Render() = %q, %v; want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		locale string
+		want   string
+		wantOK bool
+	}{
+		{"en", "en", true},
+		{"ja-JP", "ja", true},
+		{"de_DE.UTF-8", "de", true},
+		{"DE", "de", true},
+		{"fr", "fr", false},
+	}
+	for _, tt := range tests {
+		got, ok := messages.Normalize(tt.locale)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("Normalize(%q) = %q, %v; want %q, %v", tt.locale, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestCatalogs(t *testing.T) {
+	english := messages.IDs(messages.DefaultLocale)
+	for _, locale := range messages.Locales() {
+		for _, id := range messages.IDs(locale) {
+			if !slices.Contains(english, id) {
+				t.Errorf("%s catalog defines %q, which the English catalog lacks", locale, id)
+			}
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package parser
+
+// DefaultMaxBranchVariants bounds how many variants ProcessBranches
+// produces when no limit is given.
+const DefaultMaxBranchVariants = 16
+
+// ProcessBranches is like Process, but expands blocks with {{else}}
+// branches (if, with, range) into separate variants so else-branches are
+// linted too. The first variant keeps every if-branch, matching Process;
+// each following variant keeps one other branch of one block (and the
+// branches enclosing it). At most limit variants are returned
+// (DefaultMaxBranchVariants when limit <= 0).
+func (p *Preprocessor) ProcessBranches(input []byte, limit int) []*SourceMap {
+	if limit <= 0 {
+		limit = DefaultMaxBranchVariants
+	}
+
+	actions := p.Dialect.Actions(input)
+	variants := []*SourceMap{p.expand(input, actions, nil)}
+
+	blocks := scanBlocks(actions)
+	for id, b := range blocks {
+		for branch := 1; branch < b.branches; branch++ {
+			if len(variants) >= limit {
+				return variants
+			}
+			keep := map[int]int{id: branch}
+			for c := b; c.parent >= 0; c = blocks[c.parent] {
+				keep[c.parent] = c.parentBranch
+			}
+			variants = append(variants, p.expand(input, actions, keep))
+		}
+	}
+	return variants
+}
+
+// blockInfo describes a block action, numbered in the order blocks open.
+type blockInfo struct {
+	branches     int // 1 plus the number of {{else}} actions
+	parent       int // enclosing block number, or -1
+	parentBranch int // branch of the parent containing this block
+}
+
+// scanBlocks numbers the block actions and records how they nest.
+func scanBlocks(actions []Action) []blockInfo {
+	var blocks []blockInfo
+	var stack []int
+	for _, a := range actions {
+		switch {
+		case a.Kind == ActionOpen:
+			b := blockInfo{branches: 1, parent: -1}
+			if len(stack) > 0 {
+				b.parent = stack[len(stack)-1]
+				b.parentBranch = blocks[b.parent].branches - 1
+			}
+			stack = append(stack, len(blocks))
+			blocks = append(blocks, b)
+		case a.Kind == ActionElse && len(stack) > 0:
+			blocks[stack[len(stack)-1]].branches++
+		case a.Kind == ActionEnd && len(stack) > 0:
+			stack = stack[:len(stack)-1]
+		}
+	}
+	return blocks
+}
+
+// ParseBranches parses every branch variant of content produced by
+// ProcessBranches, returning one Document per variant.
+// A templ file has a single variant, processed by ProcessTempl.
+// Errors are *ParseError values.
+func ParseBranches(filename string, content []byte, mode Mode, limit int) ([]*Document, error) {
+	var docs []*Document
+	err := EachBranch(filename, content, mode, DialectGo, limit, func(doc *Document) {
+		docs = append(docs, doc)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// EachBranch is like ParseBranches but passes each variant to fn as soon
+// as it is parsed, so only one variant tree needs to be alive at a time,
+// and preprocesses templates in dialect. Errors are *ParseError values.
+func EachBranch(filename string, content []byte, mode Mode, dialect Dialect, limit int, fn func(*Document)) error {
+	content, encoding := Decode(content)
+	if mode == ModeAuto {
+		mode = DetectMode(content)
+	}
+
+	variants, err := processBranches(filename, content, dialect, limit)
+	if err != nil {
+		return err
+	}
+	for i, sourceMap := range variants {
+		doc, err := parseVariant(filename, sourceMap, mode, encoding)
+		if err != nil {
+			return err
+		}
+		variants[i] = nil // the document keeps its own reference
+		fn(doc)
+	}
+	return nil
+}
+
+func processBranches(filename string, content []byte, dialect Dialect, limit int) (variants []*SourceMap, err error) {
+	defer recoverParse(filename, &err)
+	if IsTempl(filename) {
+		return []*SourceMap{ProcessTempl(content)}, nil
+	}
+	return (&Preprocessor{Dialect: dialect}).ProcessBranches(content, limit), nil
+}
+
+func parseVariant(filename string, sourceMap *SourceMap, mode Mode, encoding string) (doc *Document, err error) {
+	defer recoverParse(filename, &err)
+	if mode == ModeDocument {
+		return parseDocument(filename, sourceMap, encoding)
+	}
+	return parseFragment(filename, sourceMap, encoding)
+}
@@ -0,0 +1,67 @@
+package parser_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/toba/go-html-validate/parser"
+)
+
+func TestProcessBranches(t *testing.T) {
+	input := []byte("{{if .A}}a{{else}}b{{end}}\n{{if .B}}\nc\n{{else}}\nd\n{{end}}")
+
+	variants := parser.NewPreprocessor().ProcessBranches(input, 0)
+	want := []string{
+		"a\n\nc\n\n\n",
+		"b\n\nc\n\n\n",
+		"a\n\n\n\nd\n",
+	}
+	if len(variants) != len(want) {
+		t.Fatalf("got %d variants, want %d", len(variants), len(want))
+	}
+	for i, sm := range variants {
+		if string(sm.Processed) != want[i] {
+			t.Errorf("variant %d = %q, want %q", i, sm.Processed, want[i])
+		}
+		if string(sm.Original) != string(input) {
+			t.Errorf("variant %d Original = %q, want input", i, sm.Original)
+		}
+	}
+
+	if got := len(parser.NewPreprocessor().ProcessBranches(input, 2)); got != 2 {
+		t.Errorf("with limit 2 got %d variants, want 2", got)
+	}
+}
+
+func TestParseBranches(t *testing.T) {
+	docs, err := parser.ParseBranches("test.html", []byte("<p>\n{{if .A}}<b>x</b>{{else}}\n<i>y</i>{{end}}</p>"), parser.ModeAuto, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 2 {
+		t.Fatalf("got %d documents, want 2", len(docs))
+	}
+	if got := docs[0].QuerySelectorAll("b"); len(got) != 1 {
+		t.Errorf("if variant has %d <b>, want 1", len(got))
+	}
+	italics := docs[1].QuerySelectorAll("i")
+	if len(italics) != 1 {
+		t.Fatalf("else variant has %d <i>, want 1", len(italics))
+	}
+	if italics[0].Line != 3 || italics[0].Col != 1 {
+		t.Errorf("<i> at %d:%d, want 3:1", italics[0].Line, italics[0].Col)
+	}
+}
+
+func TestProcessBranches_Nested(t *testing.T) {
+	input := []byte(`{{if .A}}a{{else}}{{if .B}}b{{else}}c{{end}}{{end}}{{range .}}r{{else}}e{{end}}`)
+
+	var got []string
+	for _, sm := range parser.NewPreprocessor().ProcessBranches(input, 0) {
+		got = append(got, string(sm.Processed))
+	}
+	want := []string{"ar", "br", "cr", "ae"}
+	if !slices.Equal(got, want) {
+		t.Errorf("variants = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,77 @@
+package parser
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+// Conditional comment forms. Downlevel-hidden markup is a comment to every
+// browser but legacy IE; downlevel-revealed markup is visible to every
+// browser, and IE drops it when the condition is false. The second form of
+// downlevel-revealed wraps its markers in comments to stay valid HTML.
+var (
+	// <!--[if IE]> markup <![endif]-->, or <!--[if !IE]><!--> markup <!--<![endif]-->
+	conditionalHiddenPattern = regexp.MustCompile(`(?is)<!--\[if\s([^\]]*)\]>(.*?)<!\[endif\]-->`)
+	// <![if !IE]> markup <![endif]>
+	conditionalRevealedPattern = regexp.MustCompile(`(?is)<!\[if\s([^\]]*)\]>(.*?)<!\[endif\]>`)
+)
+
+// ConditionalVariant returns content as legacy Internet Explorer renders
+// its conditional comments, and whether that differs from content:
+// downlevel-hidden markup is uncommented, and downlevel-revealed markup
+// under a condition of !IE is blanked. Any other condition is taken to hold
+// in some IE version. Replaced bytes become spaces, keeping newlines and
+// the template actions of dialect, so positions in the variant are those
+// of content.
+func ConditionalVariant(content []byte, dialect Dialect) ([]byte, bool) {
+	if !bytes.Contains(content, []byte("[if")) {
+		return content, false
+	}
+
+	variant := bytes.Clone(content)
+	inAction := make([]bool, len(content))
+	for _, a := range dialect.Actions(content) {
+		for i := a.Start; i < a.End; i++ {
+			inAction[i] = true
+		}
+	}
+	changed := false
+	blank := func(start, end int) {
+		for i := start; i < end; i++ {
+			if variant[i] == '\n' || inAction[i] {
+				continue
+			}
+			variant[i] = ' '
+			changed = true
+		}
+	}
+
+	for _, m := range conditionalHiddenPattern.FindAllSubmatchIndex(content, -1) {
+		inIE := conditionHoldsInIE(string(content[m[2]:m[3]]))
+		if body := content[m[4]:m[5]]; bytes.HasPrefix(body, []byte("<!-->")) {
+			// valid-HTML downlevel-revealed: shown unless IE drops it
+			if !inIE {
+				blank(m[0], m[1])
+			}
+			continue
+		}
+		if inIE {
+			blank(m[0], m[4])
+			blank(m[5], m[1])
+		}
+	}
+	for _, m := range conditionalRevealedPattern.FindAllSubmatchIndex(content, -1) {
+		if !conditionHoldsInIE(string(content[m[2]:m[3]])) {
+			blank(m[0], m[1])
+		}
+	}
+
+	return variant, changed
+}
+
+// conditionHoldsInIE reports whether a conditional comment condition holds
+// in some version of IE; only a plain !IE never does.
+func conditionHoldsInIE(condition string) bool {
+	return !strings.EqualFold(strings.Join(strings.Fields(condition), ""), "!IE")
+}
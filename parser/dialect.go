@@ -0,0 +1,537 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template/parse"
+)
+
+// Dialect selects the template syntax the preprocessor understands.
+type Dialect int
+
+const (
+	// DialectGo is text/template and html/template syntax.
+	DialectGo Dialect = iota
+	// DialectJet is Jet (github.com/CloudyKit/jet) syntax: {{ }} actions
+	// with extends/import/include/yield, try/catch, and {* *} comments.
+	DialectJet
+	// DialectPongo2 is Pongo2 (github.com/flosch/pongo2) syntax, modeled on
+	// Django: {{ var }} output, {% tag %} statements closed by {% endtag %},
+	// and {# comments #}.
+	DialectPongo2
+	// DialectHandlebars is Handlebars (github.com/aymerick/raymond) and
+	// Mustache syntax: {{#block}} and {{^inverse}} sections closed by
+	// {{/block}}, {{> partials}}, {{{triple-stash}}} output, and {{! }}
+	// comments.
+	DialectHandlebars
+)
+
+// dialectSpec describes how a dialect's actions are found and classified.
+// Adding a dialect means adding a Dialect constant and its spec.
+type dialectSpec struct {
+	name string
+	// extensions lists file extensions that imply the dialect
+	extensions []string
+	// pattern matches every action, tag, and comment, unless scan is set
+	pattern *regexp.Regexp
+	// scan returns the [start, end) offsets of every action, tag, and
+	// comment, for syntax a regular expression cannot match exactly
+	scan func(content []byte) [][]int
+	// classify returns the body and kind of an action matched by pattern
+	classify func(raw []byte) (body []byte, kind ActionKind)
+	// include is the action that renders the named template in place
+	include string
+}
+
+// dialectSpecs holds the spec of each Dialect, indexed by its value.
+var dialectSpecs = [...]dialectSpec{
+	DialectGo: {
+		name: "go",
+		scan: goActions,
+		classify: func(raw []byte) ([]byte, ActionKind) {
+			body := trimDelims(raw, 2, "-")
+			return body, DialectGo.Classify(body)
+		},
+		include: `{{template "%s"}}`,
+	},
+	DialectJet: {
+		name:       "jet",
+		extensions: []string{".jet"},
+		scan:       func(content []byte) [][]int { return scanActions(content, true) },
+		classify: func(raw []byte) ([]byte, ActionKind) {
+			if raw[1] == '*' {
+				return nil, ActionSilent
+			}
+			body := trimDelims(raw, 2, "-")
+			return body, DialectJet.Classify(body)
+		},
+		include: `{{ include "%s" }}`,
+	},
+	DialectPongo2: {
+		name:       "pongo2",
+		extensions: []string{".pongo2", ".django"},
+		// {% comment %} blocks match whole, before single tags
+		pattern: regexp.MustCompile(`\{%-?\s*comment\s*-?%\}[\s\S]*?\{%-?\s*endcomment\s*-?%\}|\{\{[\s\S]*?\}\}|\{%[\s\S]*?%\}|\{#[\s\S]*?#\}`),
+		classify: func(raw []byte) ([]byte, ActionKind) {
+			body := trimDelims(raw, 2, "-")
+			switch raw[1] {
+			case '{':
+				return body, ActionOutput
+			case '%':
+				if string(keywordPattern.Find(body)) != "comment" {
+					return body, ClassifyTag(body)
+				}
+			}
+			return nil, ActionSilent
+		},
+		include: `{%% include "%s" %%}`,
+	},
+	DialectHandlebars: {
+		name:       "handlebars",
+		extensions: []string{".hbs", ".handlebars", ".mustache"},
+		// comments with dashes and triple-stash output may contain "}}"
+		pattern: regexp.MustCompile(`\{\{~?!--[\s\S]*?--~?\}\}|\{\{\{[\s\S]*?\}\}\}|\{\{[\s\S]*?\}\}`),
+		classify: func(raw []byte) ([]byte, ActionKind) {
+			if bytes.HasPrefix(raw, []byte("{{{")) {
+				return trimDelims(raw, 3, "~"), ActionOutput
+			}
+			body := trimDelims(raw, 2, "~")
+			return body, DialectHandlebars.Classify(body)
+		},
+		include: `{{> %s}}`,
+	},
+}
+
+// goActions returns the offsets of the actions in Go template content.
+// Content that parses is split by its parse tree: every "{{" and "}}"
+// outside the tree's text, strings, character constants, and comments is
+// a delimiter. Content that does not parse falls back to scanActions.
+func goActions(content []byte) [][]int {
+	literals, ok := templateLiterals(string(content))
+	if !ok {
+		return scanActions(content, false)
+	}
+
+	var matches [][]int
+	start := -1
+	for i := 0; i+1 < len(content); {
+		if len(literals) > 0 && i >= literals[0][0] {
+			i = max(i, literals[0][1])
+			literals = literals[1:]
+			continue
+		}
+		switch {
+		case start < 0 && content[i] == '{' && content[i+1] == '{':
+			start = i
+			i = templateNameEnd(content, i+2)
+		case start >= 0 && content[i] == '}' && content[i+1] == '}':
+			matches = append(matches, []int{start, i + 2})
+			start = -1
+			i += 2
+		default:
+			i++
+		}
+	}
+	return matches
+}
+
+// templateLiterals parses content as a text/template and returns the
+// [start, end) offsets of its text, strings, character constants, and
+// comments in source order, or false if it does not parse.
+func templateLiterals(content string) ([][]int, bool) {
+	tree := parse.New("")
+	tree.Mode = parse.ParseComments | parse.SkipFuncCheck
+	trees := make(map[string]*parse.Tree)
+	if _, err := tree.Parse(content, "", "", trees); err != nil {
+		return nil, false
+	}
+
+	var literals [][]int
+	add := func(pos parse.Pos, text string) {
+		literals = append(literals, []int{int(pos), int(pos) + len(text)})
+	}
+	var walk func(parse.Node)
+	walkBranch := func(b *parse.BranchNode) {
+		walk(b.Pipe)
+		walk(b.List)
+		walk(b.ElseList)
+	}
+	walk = func(node parse.Node) {
+		switch n := node.(type) {
+		case *parse.ListNode:
+			if n != nil {
+				for _, child := range n.Nodes {
+					walk(child)
+				}
+			}
+		case *parse.TextNode:
+			add(n.Pos, string(n.Text))
+		case *parse.CommentNode:
+			add(n.Pos, n.Text)
+		case *parse.StringNode:
+			add(n.Pos, n.Quoted)
+		case *parse.NumberNode:
+			add(n.Pos, n.Text)
+		case *parse.ActionNode:
+			walk(n.Pipe)
+		case *parse.IfNode:
+			walkBranch(&n.BranchNode)
+		case *parse.RangeNode:
+			walkBranch(&n.BranchNode)
+		case *parse.WithNode:
+			walkBranch(&n.BranchNode)
+		case *parse.TemplateNode:
+			walk(n.Pipe)
+		case *parse.PipeNode:
+			if n != nil {
+				for _, cmd := range n.Cmds {
+					walk(cmd)
+				}
+			}
+		case *parse.CommandNode:
+			for _, arg := range n.Args {
+				walk(arg)
+			}
+		case *parse.ChainNode:
+			walk(n.Node)
+		}
+	}
+	// defined templates and blocks are trees of their own, positioned in
+	// the same content
+	walk(tree.Root)
+	for _, t := range trees {
+		if t != tree {
+			walk(t.Root)
+		}
+	}
+	slices.SortFunc(literals, func(a, b []int) int { return a[0] - b[0] })
+	return literals, true
+}
+
+// templateNameEnd returns the offset just past the quoted name of a
+// define, template, or block action whose body starts at i, or i for other
+// actions. The parse tree keeps these names but not their positions.
+func templateNameEnd(content []byte, i int) int {
+	rest := bytes.TrimLeft(bytes.TrimPrefix(content[i:], []byte("- ")), " \t\r\n")
+	switch string(keywordPattern.Find(rest)) {
+	case "define", "template", "block":
+	default:
+		return i
+	}
+	rest = bytes.TrimLeft(bytes.TrimLeft(rest, "abcdefghijklmnopqrstuvwxyz"), " \t\r\n")
+	name, err := strconv.QuotedPrefix(string(rest))
+	if err != nil {
+		return i
+	}
+	return len(content) - len(rest) + len(name)
+}
+
+// scanActions returns the offsets of the {{ }} actions in content, and
+// with jet of {* *} comments too. It serves Jet, and Go templates that do
+// not parse. Actions are lexed the way text/template lexes them: "}}"
+// inside a quoted string, raw string, character constant, or
+// {{/* comment */}} does not close the action, and an action without a
+// closing "}}" is skipped. A string left open at the end of its line ends
+// there, so one typo does not swallow the rest of the file.
+func scanActions(content []byte, jet bool) [][]int {
+	var matches [][]int
+	for i := 0; i < len(content); {
+		next := bytes.IndexByte(content[i:], '{')
+		if next < 0 {
+			break
+		}
+		start := i + next
+		i = start + 1
+		if i >= len(content) {
+			break
+		}
+		switch {
+		case jet && content[i] == '*':
+			if end := bytes.Index(content[i+1:], []byte("*}")); end >= 0 {
+				i += 1 + end + 2
+				matches = append(matches, []int{start, i})
+			}
+		case content[i] == '{':
+			if end := actionEnd(content, start+2); end >= 0 {
+				i = end
+				matches = append(matches, []int{start, end})
+			}
+		}
+	}
+	return matches
+}
+
+// actionEnd returns the offset just past the "}}" closing the action whose
+// body starts at i, or -1 when it is not closed.
+func actionEnd(content []byte, i int) int {
+	// a comment must directly follow the delimiter and any trim marker
+	body := i
+	if bytes.HasPrefix(content[body:], []byte("- ")) {
+		body += 2
+	}
+	if bytes.HasPrefix(content[body:], []byte("/*")) {
+		end := bytes.Index(content[body+2:], []byte("*/"))
+		if end < 0 {
+			return -1
+		}
+		i = body + 2 + end + 2
+	}
+
+	for i < len(content) {
+		switch c := content[i]; c {
+		case '}':
+			if i+1 < len(content) && content[i+1] == '}' {
+				return i + 2
+			}
+			i++
+		case '"', '\'', '`':
+			i = quoteEnd(content, i+1, c)
+		default:
+			i++
+		}
+	}
+	return -1
+}
+
+// quoteEnd returns the offset just past the quote closing a string opened
+// by quote before i. Interpreted strings and character constants honor
+// backslash escapes and end at a newline when unterminated.
+func quoteEnd(content []byte, i int, quote byte) int {
+	for i < len(content) {
+		switch c := content[i]; {
+		case c == quote:
+			return i + 1
+		case c == '\\' && quote != '`':
+			i += 2
+		case c == '\n' && quote != '`':
+			return i
+		default:
+			i++
+		}
+	}
+	return i
+}
+
+// trimDelims returns the body of raw: n delimiter bytes removed from each
+// end, then surrounding space and trim markers.
+func trimDelims(raw []byte, n int, trim string) []byte {
+	body := bytes.TrimSpace(raw[n : len(raw)-n])
+	return bytes.TrimSpace(bytes.TrimSuffix(bytes.TrimPrefix(body, []byte(trim)), []byte(trim)))
+}
+
+func (d Dialect) spec() *dialectSpec {
+	if d < 0 || int(d) >= len(dialectSpecs) {
+		return &dialectSpecs[DialectGo]
+	}
+	return &dialectSpecs[d]
+}
+
+func (d Dialect) String() string {
+	return d.spec().name
+}
+
+// LookupDialect returns the dialect named s: "go", "jet", "pongo2", or
+// "handlebars". An empty name is DialectGo.
+func LookupDialect(s string) (Dialect, error) {
+	if s == "" {
+		return DialectGo, nil
+	}
+	names := make([]string, len(dialectSpecs))
+	for i := range dialectSpecs {
+		if dialectSpecs[i].name == s {
+			return Dialect(i), nil
+		}
+		names[i] = dialectSpecs[i].name
+	}
+	return DialectGo, fmt.Errorf("unknown template dialect %q (want one of %s)", s, strings.Join(names, ", "))
+}
+
+// ForFile returns the dialect implied by filename's extension (.jet;
+// .pongo2 and .django; .hbs, .handlebars, and .mustache), or d for other
+// files.
+func (d Dialect) ForFile(filename string) Dialect {
+	ext := strings.ToLower(filepath.Ext(filename))
+	for i := range dialectSpecs {
+		if slices.Contains(dialectSpecs[i].extensions, ext) {
+			return Dialect(i)
+		}
+	}
+	return d
+}
+
+// IncludeCall returns the action that renders the template named name in
+// place, such as {{template "name"}} or {{> name}}.
+func (d Dialect) IncludeCall(name string) string {
+	return fmt.Sprintf(d.spec().include, name)
+}
+
+// Action is a template action, tag, or comment located in source.
+type Action struct {
+	Start, End int
+	// Body is the content between the delimiters, trim markers removed;
+	// nil for comments
+	Body []byte
+	Kind ActionKind
+}
+
+// Actions returns every action, tag, and comment of the dialect in
+// content, in source order.
+func (d Dialect) Actions(content []byte) []Action {
+	spec := d.spec()
+	var matches [][]int
+	if spec.scan != nil {
+		matches = spec.scan(content)
+	} else {
+		matches = spec.pattern.FindAllIndex(content, -1)
+	}
+	actions := make([]Action, 0, len(matches))
+	for _, m := range matches {
+		body, kind := spec.classify(content[m[0]:m[1]])
+		actions = append(actions, Action{Start: m[0], End: m[1], Body: body, Kind: kind})
+	}
+	return actions
+}
+
+// Extensions returns the file extensions that imply a dialect.
+func Extensions() []string {
+	var exts []string
+	for i := range dialectSpecs {
+		exts = append(exts, dialectSpecs[i].extensions...)
+	}
+	return exts
+}
+
+// ActionKind classifies a template action.
+type ActionKind int
+
+const (
+	// ActionOutput renders a value, e.g. {{ .Name }}.
+	ActionOutput ActionKind = iota
+	// ActionSilent renders nothing in place: comments, template inclusion,
+	// loop control, and in Jet extends, import, and assignments.
+	ActionSilent
+	// ActionOpen opens a block closed by {{ end }}.
+	ActionOpen
+	// ActionElse starts another branch of the enclosing block: {{ else }},
+	// or {{ catch }} in Jet.
+	ActionElse
+	// ActionEnd closes the enclosing block.
+	ActionEnd
+)
+
+// jetAssignment matches a Jet variable declaration or assignment.
+var jetAssignment = regexp.MustCompile(`^[A-Za-z_]\w*(\s*,\s*[A-Za-z_]\w*)*\s*:?=[^=]`)
+
+// Classify returns the kind of an action given its body, the content
+// between the delimiters with trim markers removed. Pongo2 {{ }} actions
+// only render values, so they are always ActionOutput; see ClassifyTag.
+// Handlebars bodies keep their sigil, as in "#if cond" or "/if".
+func (d Dialect) Classify(body []byte) ActionKind {
+	switch d {
+	case DialectPongo2:
+		return ActionOutput
+	case DialectHandlebars:
+		return classifyHandlebars(body)
+	}
+	keyword := string(keywordPattern.Find(body))
+	switch keyword {
+	case "end":
+		return ActionEnd
+	case "else":
+		return ActionElse
+	case "if", "range", "block":
+		return ActionOpen
+	}
+
+	if d == DialectJet {
+		switch keyword {
+		case "try":
+			return ActionOpen
+		case "catch":
+			return ActionElse
+		case "yield":
+			// {{ yield name() content }}...{{ end }} passes a block; a
+			// plain yield renders one
+			if f := bytes.Fields(body); len(f) > 2 && string(f[len(f)-1]) == "content" {
+				return ActionOpen
+			}
+			return ActionSilent
+		case "extends", "import", "include", "return":
+			return ActionSilent
+		}
+		if jetAssignment.Match(body) {
+			return ActionSilent
+		}
+		return ActionOutput
+	}
+
+	switch {
+	case keyword == "with", keyword == "define":
+		return ActionOpen
+	case bytes.HasPrefix(body, []byte("/*")),
+		keyword == "template" && bytes.HasPrefix(body, []byte("template ")),
+		keyword == "break" && len(body) == len(keyword),
+		keyword == "continue" && len(body) == len(keyword):
+		return ActionSilent
+	}
+	return ActionOutput
+}
+
+// pongo2Blocks are the Pongo2 tags closed by a matching {% endtag %}.
+var pongo2Blocks = map[string]bool{
+	"if": true, "for": true, "block": true, "with": true, "macro": true,
+	"autoescape": true, "filter": true, "spaceless": true, "verbatim": true,
+	"ifchanged": true, "ifequal": true, "ifnotequal": true,
+}
+
+// ClassifyTag returns the kind of a Pongo2 {% %} tag given its body. Tags
+// that only define, import, or include templates are silent; other tags
+// that are not blocks, such as url, now, and cycle, render output.
+func ClassifyTag(body []byte) ActionKind {
+	keyword := string(keywordPattern.Find(body))
+	switch {
+	case keyword == "else", keyword == "elif", keyword == "empty":
+		return ActionElse
+	case pongo2Blocks[keyword]:
+		return ActionOpen
+	case strings.HasPrefix(keyword, "end") && pongo2Blocks[keyword[3:]]:
+		return ActionEnd
+	}
+	switch keyword {
+	case "extends", "include", "import", "set", "comment", "endcomment":
+		return ActionSilent
+	}
+	return ActionOutput
+}
+
+// classifyHandlebars returns the kind of a Handlebars or Mustache action
+// given its body.
+func classifyHandlebars(body []byte) ActionKind {
+	if len(body) == 0 {
+		return ActionOutput
+	}
+	switch body[0] {
+	case '!', '>':
+		// comments and partials; {{> partial}} renders a file not linted here
+		return ActionSilent
+	case '#':
+		return ActionOpen
+	case '^':
+		// {{^}} is the else of a section, {{^name}} an inverted section
+		if len(bytes.TrimSpace(body[1:])) == 0 {
+			return ActionElse
+		}
+		return ActionOpen
+	case '/':
+		return ActionEnd
+	}
+	if string(keywordPattern.Find(body)) == "else" {
+		return ActionElse
+	}
+	return ActionOutput
+}
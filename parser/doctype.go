@@ -0,0 +1,199 @@
+package parser
+
+import (
+	"bytes"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Doctype is a parsed DOCTYPE declaration.
+type Doctype struct {
+	// Name is the lowercased root element name, "html" for HTML5
+	Name string
+	// PublicID and SystemID are the legacy identifiers, such as
+	// "-//W3C//DTD HTML 4.01//EN"; HasPublicID and HasSystemID tell an
+	// empty identifier from a missing one
+	PublicID, SystemID       string
+	HasPublicID, HasSystemID bool
+	Line, Col                int
+}
+
+// QuirksMode is the rendering mode a browser picks from the doctype.
+type QuirksMode int
+
+const (
+	// NoQuirks is standards mode.
+	NoQuirks QuirksMode = iota
+	// LimitedQuirks is "almost standards" mode, which only changes the
+	// height of lines holding images in table cells.
+	LimitedQuirks
+	// Quirks emulates legacy browser layout and CSS parsing.
+	Quirks
+)
+
+// String returns the mode's name in the HTML standard: "no-quirks",
+// "limited-quirks", or "quirks".
+func (m QuirksMode) String() string {
+	switch m {
+	case LimitedQuirks:
+		return "limited-quirks"
+	case Quirks:
+		return "quirks"
+	default:
+		return "no-quirks"
+	}
+}
+
+// Doctype returns the document's DOCTYPE declaration, or nil if it has none.
+// Fragments never have one.
+func (d *Document) Doctype() *Doctype {
+	if d.Root == nil {
+		return nil
+	}
+	for _, n := range d.Root.Children {
+		if n.Type != html.DoctypeNode {
+			continue
+		}
+		dt := &Doctype{Name: strings.ToLower(n.Data), Line: n.Line, Col: n.Col}
+		for _, attr := range n.Attr {
+			switch attr.Key {
+			case "public":
+				dt.PublicID, dt.HasPublicID = attr.Val, true
+			case "system":
+				dt.SystemID, dt.HasSystemID = attr.Val, true
+			}
+		}
+		return dt
+	}
+	return nil
+}
+
+// QuirksMode returns the mode a browser would render the document in.
+// Full documents without a doctype render in quirks mode; fragments report
+// NoQuirks, since the page that includes them decides.
+func (d *Document) QuirksMode() QuirksMode {
+	if !d.IsFullDocument {
+		return NoQuirks
+	}
+	dt := d.Doctype()
+	if dt == nil {
+		return Quirks
+	}
+	return dt.QuirksMode()
+}
+
+// QuirksMode returns the mode the doctype selects, following the HTML
+// standard's "initial" insertion mode.
+func (dt *Doctype) QuirksMode() QuirksMode {
+	public := strings.ToLower(dt.PublicID)
+	system := strings.ToLower(dt.SystemID)
+
+	switch {
+	case dt.Name != "html",
+		public == "-//w3o//dtd w3 html strict 3.0//en//",
+		public == "-/w3c/dtd html 4.0 transitional/en",
+		public == "html",
+		system == "http://www.ibm.com/data/dtd/v11/ibmxhtml1-transitional.dtd",
+		hasAnyPrefix(public, quirksPublicPrefixes...),
+		!dt.HasSystemID && hasAnyPrefix(public, html401Prefixes...):
+		return Quirks
+	case hasAnyPrefix(public, "-//w3c//dtd xhtml 1.0 frameset//", "-//w3c//dtd xhtml 1.0 transitional//"),
+		dt.HasSystemID && hasAnyPrefix(public, html401Prefixes...):
+		return LimitedQuirks
+	}
+	return NoQuirks
+}
+
+// html401Prefixes select quirks mode without a system identifier and
+// limited-quirks mode with one.
+var html401Prefixes = []string{
+	"-//w3c//dtd html 4.01 frameset//",
+	"-//w3c//dtd html 4.01 transitional//",
+}
+
+// quirksPublicPrefixes are the lowercased public identifier prefixes that
+// always select quirks mode.
+var quirksPublicPrefixes = []string{
+	"+//silmaril//dtd html pro v0r11 19970101//",
+	"-//as//dtd html 3.0 aswedit + extensions//",
+	"-//advasoft ltd//dtd html 3.0 aswedit + extensions//",
+	"-//ietf//dtd html 2.0 level 1//",
+	"-//ietf//dtd html 2.0 level 2//",
+	"-//ietf//dtd html 2.0 strict level 1//",
+	"-//ietf//dtd html 2.0 strict level 2//",
+	"-//ietf//dtd html 2.0 strict//",
+	"-//ietf//dtd html 2.0//",
+	"-//ietf//dtd html 2.1e//",
+	"-//ietf//dtd html 3.0//",
+	"-//ietf//dtd html 3.2 final//",
+	"-//ietf//dtd html 3.2//",
+	"-//ietf//dtd html 3//",
+	"-//ietf//dtd html level 0//",
+	"-//ietf//dtd html level 1//",
+	"-//ietf//dtd html level 2//",
+	"-//ietf//dtd html level 3//",
+	"-//ietf//dtd html strict level 0//",
+	"-//ietf//dtd html strict level 1//",
+	"-//ietf//dtd html strict level 2//",
+	"-//ietf//dtd html strict level 3//",
+	"-//ietf//dtd html strict//",
+	"-//ietf//dtd html//",
+	"-//metrius//dtd metrius presentational//",
+	"-//microsoft//dtd internet explorer 2.0 html strict//",
+	"-//microsoft//dtd internet explorer 2.0 html//",
+	"-//microsoft//dtd internet explorer 2.0 tables//",
+	"-//microsoft//dtd internet explorer 3.0 html strict//",
+	"-//microsoft//dtd internet explorer 3.0 html//",
+	"-//microsoft//dtd internet explorer 3.0 tables//",
+	"-//netscape comm. corp.//dtd html//",
+	"-//netscape comm. corp.//dtd strict html//",
+	"-//o'reilly and associates//dtd html 2.0//",
+	"-//o'reilly and associates//dtd html extended 1.0//",
+	"-//o'reilly and associates//dtd html extended relaxed 1.0//",
+	"-//sq//dtd html 2.0 hotmetal + extensions//",
+	"-//softquad software//dtd hotmetal pro 6.0::19990601::extensions to html 4.0//",
+	"-//softquad//dtd hotmetal pro 4.0::19971010::extensions to html 4.0//",
+	"-//spyglass//dtd html 2.0 extended//",
+	"-//sun microsystems corp.//dtd hotjava html//",
+	"-//sun microsystems corp.//dtd hotjava strict html//",
+	"-//w3c//dtd html 3 1995-03-24//",
+	"-//w3c//dtd html 3.2 draft//",
+	"-//w3c//dtd html 3.2 final//",
+	"-//w3c//dtd html 3.2//",
+	"-//w3c//dtd html 3.2s draft//",
+	"-//w3c//dtd html 4.0 frameset//",
+	"-//w3c//dtd html 4.0 transitional//",
+	"-//w3c//dtd html experimental 19960712//",
+	"-//w3c//dtd html experimental 970421//",
+	"-//w3c//dtd w3 html//",
+	"-//w3o//dtd w3 html 3.0//",
+	"-//webtechs//dtd mozilla html 2.0//",
+	"-//webtechs//dtd mozilla html//",
+}
+
+// doctypeOffset returns the offset of the first DOCTYPE token in content,
+// or -1 if there is none.
+func doctypeOffset(content []byte) int {
+	z := html.NewTokenizer(bytes.NewReader(content))
+	offset := 0
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			return -1
+		case html.DoctypeToken:
+			return offset
+		}
+		offset += len(z.Raw())
+	}
+}
+
+func hasAnyPrefix(s string, prefixes ...string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
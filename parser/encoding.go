@@ -0,0 +1,133 @@
+package parser
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Encoding names returned by Decode.
+const (
+	EncodingUTF8        = "utf-8"
+	EncodingUTF16LE     = "utf-16le"
+	EncodingUTF16BE     = "utf-16be"
+	EncodingWindows1252 = "windows-1252"
+)
+
+// encodingLabels maps the WHATWG labels of the encodings Decode converts to
+// their names. Latin-1 and ASCII labels mean windows-1252, as in browsers.
+var encodingLabels = map[string]string{
+	"unicode-1-1-utf-8": EncodingUTF8, "unicode11utf8": EncodingUTF8,
+	"unicode20utf8": EncodingUTF8, "utf-8": EncodingUTF8, "utf8": EncodingUTF8,
+	"x-unicode20utf8": EncodingUTF8,
+
+	"csunicode": EncodingUTF16LE, "iso-10646-ucs-2": EncodingUTF16LE,
+	"ucs-2": EncodingUTF16LE, "unicode": EncodingUTF16LE,
+	"unicodefeff": EncodingUTF16LE, "utf-16": EncodingUTF16LE, "utf-16le": EncodingUTF16LE,
+	"unicodefffe": EncodingUTF16BE, "utf-16be": EncodingUTF16BE,
+
+	"ansi_x3.4-1968": EncodingWindows1252, "ascii": EncodingWindows1252,
+	"cp1252": EncodingWindows1252, "cp819": EncodingWindows1252,
+	"csisolatin1": EncodingWindows1252, "ibm819": EncodingWindows1252,
+	"iso-8859-1": EncodingWindows1252, "iso-ir-100": EncodingWindows1252,
+	"iso8859-1": EncodingWindows1252, "iso88591": EncodingWindows1252,
+	"iso_8859-1": EncodingWindows1252, "iso_8859-1:1987": EncodingWindows1252,
+	"l1": EncodingWindows1252, "latin1": EncodingWindows1252,
+	"us-ascii": EncodingWindows1252, "windows-1252": EncodingWindows1252,
+	"x-cp1252": EncodingWindows1252,
+}
+
+// windows1252High holds the characters of bytes 0x80-0x9F in
+// windows-1252; the rest of the range is Latin-1 and maps to itself.
+var windows1252High = [32]rune{
+	'€', 0x81, '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', 0x8D, 'Ž', 0x8F,
+	0x90, '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', 0x9D, 'ž', 'Ÿ',
+}
+
+// metaCharsetPattern finds a charset in <meta charset> or in the content
+// of <meta http-equiv="Content-Type">.
+var metaCharsetPattern = regexp.MustCompile(`(?i)<meta\s[^>]*?charset\s*=\s*["']?\s*([^\s"';>/{]+)`)
+
+// prescanLimit is how far into the content browsers look for a <meta>
+// charset declaration.
+const prescanLimit = 1024
+
+// Decode returns content as UTF-8, along with the name of the encoding it
+// was sniffed as: a UTF-16 byte order mark, or else a <meta> charset
+// declaration in the first 1024 bytes, as browsers do. UTF-16 and
+// windows-1252 (and its Latin-1 and ASCII labels) are converted, dropping
+// a UTF-16 BOM; declared windows-1252 content that is already valid UTF-8
+// is left alone, so decoding twice is harmless. Other encodings are named
+// by their lowercase label and returned unchanged.
+func Decode(content []byte) (decoded []byte, encoding string) {
+	switch {
+	case bytes.HasPrefix(content, []byte{0xFF, 0xFE}):
+		return decodeUTF16(content[2:], false), EncodingUTF16LE
+	case bytes.HasPrefix(content, []byte{0xFE, 0xFF}):
+		return decodeUTF16(content[2:], true), EncodingUTF16BE
+	}
+
+	label := DeclaredCharset(content)
+	encoding = encodingLabels[label]
+	switch {
+	case label == "":
+		return content, EncodingUTF8
+	case encoding == "":
+		return content, label
+	case encoding == EncodingUTF16LE || encoding == EncodingUTF16BE:
+		// a declaration readable as ASCII means the bytes are not UTF-16
+		return content, EncodingUTF8
+	case encoding == EncodingWindows1252 && !utf8.Valid(content):
+		return decodeWindows1252(content), encoding
+	}
+	return content, encoding
+}
+
+// DeclaredCharset returns the lowercase charset label of the first <meta>
+// charset declaration in the first 1024 bytes of content, or "".
+func DeclaredCharset(content []byte) string {
+	m := metaCharsetPattern.FindSubmatch(content[:min(len(content), prescanLimit)])
+	if m == nil {
+		return ""
+	}
+	return strings.ToLower(string(m[1]))
+}
+
+// decodeUTF16 converts UTF-16 to UTF-8. A trailing odd byte and unpaired
+// surrogates become U+FFFD.
+func decodeUTF16(content []byte, bigEndian bool) []byte {
+	units := make([]uint16, len(content)/2)
+	for i := range units {
+		lo, hi := content[2*i], content[2*i+1]
+		if bigEndian {
+			lo, hi = hi, lo
+		}
+		units[i] = uint16(hi)<<8 | uint16(lo)
+	}
+	out := make([]byte, 0, len(content))
+	for _, r := range utf16.Decode(units) {
+		out = utf8.AppendRune(out, r)
+	}
+	if len(content)%2 == 1 {
+		out = utf8.AppendRune(out, utf8.RuneError)
+	}
+	return out
+}
+
+// decodeWindows1252 converts windows-1252 to UTF-8.
+func decodeWindows1252(content []byte) []byte {
+	out := make([]byte, 0, len(content)+len(content)/8)
+	for _, b := range content {
+		switch {
+		case b < 0x80:
+			out = append(out, b)
+		case b < 0xA0:
+			out = utf8.AppendRune(out, windows1252High[b-0x80])
+		default:
+			out = utf8.AppendRune(out, rune(b))
+		}
+	}
+	return out
+}
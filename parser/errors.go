@@ -0,0 +1,125 @@
+package parser
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// MaxNestingDepth bounds element nesting, matching the limit of the
+// underlying HTML parser. Real pages stay far below it; deeper input is
+// hostile and would make every recursive rule expensive.
+const MaxNestingDepth = 512
+
+// ErrNestingTooDeep is wrapped by a ParseError when elements nest deeper
+// than MaxNestingDepth.
+var ErrNestingTooDeep = errors.New("elements nested too deeply")
+
+// ErrInternal is wrapped by a ParseError when the parser recovers from a
+// panic on malformed input.
+var ErrInternal = errors.New("internal parser error")
+
+// ParseError is returned by the Parse functions for input they cannot
+// handle. They never panic: panics become a ParseError wrapping ErrInternal.
+type ParseError struct {
+	Filename string
+	// Line and Col locate the problem in the original source; zero when
+	// unknown.
+	Line, Col int
+	Err       error
+}
+
+func (e *ParseError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d:%d: %v", e.Filename, e.Line, e.Col, e.Err)
+	}
+	return fmt.Sprintf("%s: %v", e.Filename, e.Err)
+}
+
+func (e *ParseError) Unwrap() error { return e.Err }
+
+// nestingError is panicked by the tree builder and converted to a
+// ParseError by recoverParse.
+type nestingError struct{ line, col int }
+
+// recoverParse converts a panic or error in a Parse function into a
+// ParseError. It must be deferred directly.
+func recoverParse(filename string, err *error) {
+	if r := recover(); r != nil {
+		if n, ok := r.(nestingError); ok {
+			*err = nestingTooDeep(filename, n.line, n.col)
+			return
+		}
+		*err = &ParseError{Filename: filename, Err: fmt.Errorf("%w: %v", ErrInternal, r)}
+		return
+	}
+	if *err != nil {
+		var perr *ParseError
+		if !errors.As(*err, &perr) {
+			*err = &ParseError{Filename: filename, Err: *err}
+		}
+	}
+}
+
+func nestingTooDeep(filename string, line, col int) *ParseError {
+	return &ParseError{Filename: filename, Line: line, Col: col,
+		Err: fmt.Errorf("%w (more than %d levels)", ErrNestingTooDeep, MaxNestingDepth)}
+}
+
+// htmlParseError converts an error from the HTML parser. Its nesting limit
+// reports no position, so the offending tag is located by re-scanning.
+func htmlParseError(filename string, sm *SourceMap, err error) error {
+	if !strings.Contains(err.Error(), "open stack of elements exceeds") {
+		return &ParseError{Filename: filename, Err: err}
+	}
+	line, col := nestingPosition(sm)
+	return nestingTooDeep(filename, line, col)
+}
+
+// voidElements never open a nesting level.
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true,
+	"hr": true, "img": true, "input": true, "link": true, "meta": true,
+	"source": true, "track": true, "wbr": true,
+}
+
+// nestingPosition approximates where the HTML parser gave up: the first
+// start tag opening more than MaxNestingDepth elements, counting the html
+// and body elements it always opens. Only the common implied end tags are
+// modelled, so the position is a best effort; 0, 0 when none is found.
+func nestingPosition(sm *SourceMap) (line, col int) {
+	z := html.NewTokenizer(bytes.NewReader(sm.Processed))
+	var open []string
+	offset := 0
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			return 0, 0
+		}
+		start := offset
+		offset += len(z.Raw())
+
+		name, _ := z.TagName()
+		switch tt {
+		case html.StartTagToken:
+			if voidElements[string(name)] {
+				continue
+			}
+			open = append(closeImplied(open, string(name)), string(name))
+			if len(open)+2 > MaxNestingDepth {
+				line, col = newLineIndex(sm.Processed).position(start)
+				return sm.OriginalPosition(line, col)
+			}
+		case html.EndTagToken:
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] == string(name) {
+					open = open[:i]
+					break
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package parser_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/toba/go-html-validate/parser"
+)
+
+// maxFuzzInput skips inputs too large to mutate usefully.
+const maxFuzzInput = 64 << 10
+
+// fuzzSeeds are hostile inputs: unterminated and unbalanced actions,
+// deep nesting, long entities, and odd markup.
+var fuzzSeeds = []string{
+	`<div class="{{.Class}}">{{if .X}}<p>{{.Y}}</p>{{else}}<span></span>{{end}}</div>`,
+	`{{define "x"}}<li>{{range .}}{{.}}{{end}}</li>{{end}}`,
+	`<p>{{if .A}}{{if .B}}`,
+	`{{end}}{{else}}{{end}}</p>`,
+	`<a href="{{`,
+	`{{/* unterminated comment`,
+	`<div title="}}{{">x</div>`,
+	strings.Repeat("<div>", 300),
+	strings.Repeat("<b><i>", 200),
+	"&" + strings.Repeat("a", 1000) + ";",
+	"&#" + strings.Repeat("9", 100) + ";",
+	`<!DOCTYPE html><html><head><title>{{.T}}</title></head><body></body></html>`,
+	`<svg><![CDATA[<]]><math><mi>{{.X}}</mi></math></svg>`,
+	`<table><tr><td>{{range .}}<tr>{{end}}</table>`,
+	"\x00<\x00div\xff>",
+}
+
+func FuzzParse(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, content []byte) {
+		if len(content) > maxFuzzInput {
+			return
+		}
+		for _, mode := range []parser.Mode{parser.ModeAuto, parser.ModeDocument, parser.ModeFragment} {
+			doc, err := parser.ParseWithMode("fuzz.html", content, mode)
+			if err != nil {
+				var perr *parser.ParseError
+				if !errors.As(err, &perr) {
+					t.Fatalf("ParseWithMode(%v) error %v is not a *ParseError", mode, err)
+				}
+				continue
+			}
+			doc.Walk(func(n *parser.Node) bool {
+				_ = n.TextContent()
+				if n.Line < 1 || n.Col < 1 {
+					t.Fatalf("node %q at invalid position %d:%d", n.Data, n.Line, n.Col)
+				}
+				return true
+			})
+			_ = doc.QuerySelectorAll("div > p, a[href]:not(.x)")
+		}
+	})
+}
+
+func FuzzParseBranches(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, content []byte) {
+		if len(content) > maxFuzzInput {
+			return
+		}
+		docs, err := parser.ParseBranches("fuzz.html", content, parser.ModeAuto, parser.DefaultMaxBranchVariants)
+		if err != nil {
+			var perr *parser.ParseError
+			if !errors.As(err, &perr) {
+				t.Fatalf("ParseBranches error %v is not a *ParseError", err)
+			}
+			return
+		}
+		if len(docs) == 0 || len(docs) > parser.DefaultMaxBranchVariants {
+			t.Fatalf("got %d variants", len(docs))
+		}
+	})
+}
+
+func FuzzPreprocess(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, content []byte) {
+		if len(content) > maxFuzzInput {
+			return
+		}
+		processed, sm, err := parser.NewPreprocessor().Process(content)
+		if err != nil {
+			return
+		}
+		if strings.Contains(string(processed), "{{") && !strings.Contains(string(content), "{{") {
+			t.Fatal("preprocessing introduced template delimiters")
+		}
+		line, col := sm.OriginalPosition(1, 1)
+		if line < 1 || col < 1 {
+			t.Fatalf("OriginalPosition(1, 1) = %d:%d", line, col)
+		}
+	})
+}
+
+// templFuzzSeeds are templ component files with unterminated blocks,
+// expressions, and strings.
+var templFuzzSeeds = []string{
+	"package v\n\ntempl A(x string) {\n\t<p class={ x } if x != \"\" { title=\"t\" }>{ x }</p>\n}\n",
+	"templ A() {\n\tif x {\n\t\t<b>",
+	"templ A() {\n\tswitch x {\n\tcase 1:\n\t\t<i>\n\tdefault:",
+	"templ A() {<p title={ \"}\" }>@B() {</p>",
+	"templ A() {<script>{ `",
+	"templ A() {<a href={\n",
+}
+
+func FuzzProcessTempl(f *testing.F) {
+	for _, seed := range append(templFuzzSeeds, fuzzSeeds...) {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, content []byte) {
+		if len(content) > maxFuzzInput {
+			return
+		}
+		sm := parser.ProcessTempl(content)
+		line, col := sm.OriginalPosition(1, 1)
+		if line < 1 || col < 1 {
+			t.Fatalf("OriginalPosition(1, 1) = %d:%d", line, col)
+		}
+		if _, err := parser.ParseWithMode("fuzz.templ", content, parser.ModeAuto); err != nil {
+			var perr *parser.ParseError
+			if !errors.As(err, &perr) {
+				t.Fatalf("ParseWithMode error %v is not a *ParseError", err)
+			}
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package parser
+
+import (
+	"bytes"
+	"regexp"
+)
+
+// Mode selects whether content is parsed as a full document or a fragment.
+type Mode int
+
+const (
+	// ModeAuto detects the mode from directives and document markers.
+	ModeAuto Mode = iota
+	// ModeDocument parses content as a complete HTML document.
+	ModeDocument
+	// ModeFragment parses content as a body fragment (template partial).
+	ModeFragment
+)
+
+func (m Mode) String() string {
+	switch m {
+	case ModeDocument:
+		return "document"
+	case ModeFragment:
+		return "fragment"
+	default:
+		return "auto"
+	}
+}
+
+// modeDirectivePattern matches <!-- htmlint:document --> and <!-- htmlint:fragment -->.
+var modeDirectivePattern = regexp.MustCompile(`<!--\s*htmlint:(document|fragment)\s*-->`)
+
+// documentMarkerPattern matches a doctype or an opening <html> tag.
+var documentMarkerPattern = regexp.MustCompile(`(?i)<!doctype\s|<html[\s>]`)
+
+// ModeDirective returns the mode requested by an in-file directive comment.
+// The second return value is false when the file has no directive.
+func ModeDirective(content []byte) (Mode, bool) {
+	m := modeDirectivePattern.FindSubmatch(content)
+	if m == nil {
+		return ModeAuto, false
+	}
+	if bytes.Equal(m[1], []byte("document")) {
+		return ModeDocument, true
+	}
+	return ModeFragment, true
+}
+
+// DetectMode decides how content should be parsed.
+// A directive comment wins; otherwise content containing a doctype or
+// <html> tag is treated as a full document and anything else as a fragment.
+func DetectMode(content []byte) Mode {
+	if mode, ok := ModeDirective(content); ok {
+		return mode
+	}
+	if documentMarkerPattern.Match(content) {
+		return ModeDocument
+	}
+	return ModeFragment
+}
+
+// ParseWithMode parses content as a document or fragment.
+// ModeAuto resolves the mode with DetectMode.
+func ParseWithMode(filename string, content []byte, mode Mode) (*Document, error) {
+	if mode == ModeAuto {
+		decoded, _ := Decode(content)
+		mode = DetectMode(decoded)
+	}
+	if mode == ModeDocument {
+		return Parse(filename, content)
+	}
+	return ParseFragment(filename, content)
+}
+
+// ParseWithDialect is like ParseWithMode for templates in dialect.
+// Errors are *ParseError values.
+func ParseWithDialect(filename string, content []byte, mode Mode, dialect Dialect) (doc *Document, err error) {
+	defer recoverParse(filename, &err)
+
+	content, encoding := Decode(content)
+	if mode == ModeAuto {
+		mode = DetectMode(content)
+	}
+	sourceMap, err := preprocess(filename, content, dialect)
+	if err != nil {
+		return nil, err
+	}
+	if mode == ModeDocument {
+		return parseDocument(filename, sourceMap, encoding)
+	}
+	return parseFragment(filename, sourceMap, encoding)
+}
@@ -15,8 +15,67 @@ type Document struct {
 	Filename string
 	// IsTemplateFragment indicates file starts with {{define - a Go template partial
 	IsTemplateFragment bool
+	// IsFullDocument indicates the content was parsed as a complete document
+	// rather than a body fragment
+	IsFullDocument bool
+	// IsPartial indicates the file is configured as an htmx partial
+	// response; set by the linter, not the parser
+	IsPartial bool
+	// PageType names the configured page type the file belongs to; set by
+	// the linter, not the parser
+	PageType string
+	// Encoding names the character encoding the source was decoded from
+	// (see Decode), such as "utf-8", "utf-16le", or "windows-1252"
+	Encoding string
 	// sourceMap for converting positions back to original
 	sourceMap *SourceMap
+	// sourceLines indexes the original source, built on first use
+	sourceLines *lineIndex
+}
+
+// Source returns the original file content before template preprocessing.
+func (d *Document) Source() []byte {
+	if d.sourceMap == nil {
+		return nil
+	}
+	return d.sourceMap.Original
+}
+
+// SourceLine returns the content of a 1-indexed line of the original source,
+// without its trailing newline. Returns nil if the line does not exist.
+func (d *Document) SourceLine(line int) []byte {
+	src := d.Source()
+	li := d.lineIndex()
+	if line < 1 || line > len(li.starts) {
+		return nil
+	}
+	start := li.starts[line-1]
+	end := len(src)
+	if line < len(li.starts) {
+		end = li.starts[line] - 1
+	}
+	return bytes.TrimSuffix(src[start:end], []byte("\r"))
+}
+
+// SourceRange returns the original source between two 1-indexed positions.
+// The start position is inclusive and the end position exclusive; both are
+// clamped to the source bounds.
+func (d *Document) SourceRange(startLine, startCol, endLine, endCol int) []byte {
+	src := d.Source()
+	li := d.lineIndex()
+	start := li.offset(startLine, startCol, len(src))
+	end := li.offset(endLine, endCol, len(src))
+	if end < start {
+		return nil
+	}
+	return src[start:end]
+}
+
+func (d *Document) lineIndex() *lineIndex {
+	if d.sourceLines == nil {
+		d.sourceLines = newLineIndex(d.Source())
+	}
+	return d.sourceLines
 }
 
 // Node wraps html.Node with source location and traversal helpers.
@@ -26,6 +85,8 @@ type Node struct {
 	Col      int
 	Parent   *Node
 	Children []*Node
+	// attrPos maps lowercase attribute names to their source positions
+	attrPos map[string]position
 }
 
 // HasAttr checks if the node has an attribute with the given name.
@@ -78,6 +139,106 @@ func (n *Node) IsElement(tag string) bool {
 	return n.Type == html.ElementNode && strings.EqualFold(n.Data, tag)
 }
 
+// ClosestAncestor returns the nearest ancestor element matching any of the
+// given tag names (case-insensitive), or nil if none is found.
+func (n *Node) ClosestAncestor(tags ...string) *Node {
+	for p := n.Parent; p != nil; p = p.Parent {
+		if p.Type != html.ElementNode {
+			continue
+		}
+		for _, tag := range tags {
+			if strings.EqualFold(p.Data, tag) {
+				return p
+			}
+		}
+	}
+	return nil
+}
+
+// Ancestors returns the element ancestors of the node, nearest first.
+func (n *Node) Ancestors() []*Node {
+	var ancestors []*Node
+	for p := n.Parent; p != nil; p = p.Parent {
+		if p.Type == html.ElementNode {
+			ancestors = append(ancestors, p)
+		}
+	}
+	return ancestors
+}
+
+// ChildElements returns the element children of the node, skipping text
+// and comments.
+func (n *Node) ChildElements() []*Node {
+	var children []*Node
+	for _, child := range n.Children {
+		if child.Type == html.ElementNode {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// NextElementSibling returns the next sibling element node, skipping text
+// and comments, or nil if there is none.
+func (n *Node) NextElementSibling() *Node {
+	if n.Parent == nil {
+		return nil
+	}
+	siblings := n.Parent.Children
+	for i, sib := range siblings {
+		if sib != n {
+			continue
+		}
+		for _, next := range siblings[i+1:] {
+			if next.Type == html.ElementNode {
+				return next
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+// PreviousElementSibling returns the previous sibling element node, skipping
+// text and comments, or nil if there is none.
+func (n *Node) PreviousElementSibling() *Node {
+	if n.Parent == nil {
+		return nil
+	}
+	var prev *Node
+	for _, sib := range n.Parent.Children {
+		if sib == n {
+			return prev
+		}
+		if sib.Type == html.ElementNode {
+			prev = sib
+		}
+	}
+	return nil
+}
+
+// Depth returns the number of ancestors above the node.
+// The document root has depth 0 and top-level elements have depth 1.
+func (n *Node) Depth() int {
+	depth := 0
+	for p := n.Parent; p != nil; p = p.Parent {
+		depth++
+	}
+	return depth
+}
+
+// Comments returns the comment nodes of the document in source order.
+func (d *Document) Comments() []*Node {
+	var comments []*Node
+	d.Walk(func(n *Node) bool {
+		if n.Type == html.CommentNode {
+			comments = append(comments, n)
+		}
+		return true
+	})
+	return comments
+}
+
 // WalkFunc is called for each node during tree traversal.
 // Return false to stop traversal.
 type WalkFunc func(*Node) bool
@@ -102,42 +263,74 @@ func (n *Node) walk(fn WalkFunc) bool {
 }
 
 // Parse parses HTML content and returns a Document with line tracking.
-func Parse(filename string, content []byte) (*Document, error) {
-	// Preprocess to handle Go template syntax
-	prep := NewPreprocessor()
-	processed, sourceMap, err := prep.Process(content)
+// Errors are *ParseError values.
+func Parse(filename string, content []byte) (doc *Document, err error) {
+	defer recoverParse(filename, &err)
+
+	content, encoding := Decode(content)
+	// Preprocess to handle Go template or templ syntax
+	sourceMap, err := preprocess(filename, content, DialectGo)
 	if err != nil {
 		return nil, err
 	}
+	return parseDocument(filename, sourceMap, encoding)
+}
 
-	// Parse the processed HTML
-	root, err := html.Parse(bytes.NewReader(processed))
+// preprocess turns content into HTML for parsing, with ProcessTempl for
+// templ files and Process in dialect for everything else.
+func preprocess(filename string, content []byte, dialect Dialect) (*SourceMap, error) {
+	if IsTempl(filename) {
+		return ProcessTempl(content), nil
+	}
+	_, sourceMap, err := (&Preprocessor{Dialect: dialect}).Process(content)
+	return sourceMap, err
+}
+
+// parseDocument parses preprocessed content, decoded from encoding, as a
+// complete document.
+func parseDocument(filename string, sourceMap *SourceMap, encoding string) (*Document, error) {
+	processed := sourceMap.Processed
+
+	// Parse the processed HTML with position markers
+	annotated, tags, comments := annotatePositions(processed)
+	root, err := html.Parse(bytes.NewReader(annotated))
 	if err != nil {
-		return nil, err
+		return nil, htmlParseError(filename, sourceMap, err)
 	}
 
 	doc := &Document{
-		Filename:  filename,
-		sourceMap: sourceMap,
+		Filename:           filename,
+		IsTemplateFragment: isTemplateDefine(sourceMap.Original),
+		IsFullDocument:     true,
+		Encoding:           encoding,
+		sourceMap:          sourceMap,
 	}
 
 	// Build our node tree
-	doc.Root = buildNodeTree(root, nil)
+	b := newTreeBuilder(tags, comments, sourceMap)
+	doc.Root = b.buildNodeTree(root, nil)
 
 	return doc, nil
 }
 
 // ParseFragment parses an HTML fragment (like a template partial).
-func ParseFragment(filename string, content []byte) (*Document, error) {
-	// Detect Go template fragments (files starting with {{define)
-	isTemplateFragment := bytes.HasPrefix(bytes.TrimSpace(content), []byte("{{define"))
+// Errors are *ParseError values.
+func ParseFragment(filename string, content []byte) (doc *Document, err error) {
+	defer recoverParse(filename, &err)
 
-	// Preprocess to handle Go template syntax
-	prep := NewPreprocessor()
-	processed, sourceMap, err := prep.Process(content)
+	content, encoding := Decode(content)
+	// Preprocess to handle Go template or templ syntax
+	sourceMap, err := preprocess(filename, content, DialectGo)
 	if err != nil {
 		return nil, err
 	}
+	return parseFragment(filename, sourceMap, encoding)
+}
+
+// parseFragment parses preprocessed content, decoded from encoding, as a
+// body fragment.
+func parseFragment(filename string, sourceMap *SourceMap, encoding string) (*Document, error) {
+	processed := sourceMap.Processed
 
 	// Create a context element for fragment parsing
 	context := &html.Node{
@@ -146,15 +339,17 @@ func ParseFragment(filename string, content []byte) (*Document, error) {
 		DataAtom: atom.Body,
 	}
 
-	// Parse as fragment
-	nodes, err := html.ParseFragment(bytes.NewReader(processed), context)
+	// Parse as fragment with position markers
+	annotated, tags, comments := annotatePositions(processed)
+	nodes, err := html.ParseFragment(bytes.NewReader(annotated), context)
 	if err != nil {
-		return nil, err
+		return nil, htmlParseError(filename, sourceMap, err)
 	}
 
 	doc := &Document{
 		Filename:           filename,
-		IsTemplateFragment: isTemplateFragment,
+		IsTemplateFragment: isTemplateDefine(sourceMap.Original),
+		Encoding:           encoding,
 		sourceMap:          sourceMap,
 	}
 
@@ -165,8 +360,9 @@ func ParseFragment(filename string, content []byte) (*Document, error) {
 		Col:  1,
 	}
 
+	b := newTreeBuilder(tags, comments, sourceMap)
 	for _, n := range nodes {
-		child := buildNodeTree(n, syntheticRoot)
+		child := b.buildNodeTree(n, syntheticRoot)
 		syntheticRoot.Children = append(syntheticRoot.Children, child)
 	}
 
@@ -174,10 +370,33 @@ func ParseFragment(filename string, content []byte) (*Document, error) {
 	return doc, nil
 }
 
+// isTemplateDefine detects Go template fragments (files starting with {{define).
+func isTemplateDefine(content []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(content), []byte("{{define"))
+}
+
+// treeBuilder converts html.Node trees into Node trees with source positions.
+type treeBuilder struct {
+	depth       int // element nesting of the node being built
+	tags        []tagOffsets
+	comments    []commentOffset
+	nextComment int // index of the first comment not yet matched to a node
+	sourceMap   *SourceMap
+}
+
+func newTreeBuilder(tags []tagOffsets, comments []commentOffset, sm *SourceMap) *treeBuilder {
+	return &treeBuilder{
+		tags:      tags,
+		comments:  comments,
+		sourceMap: sm,
+	}
+}
+
 // buildNodeTree converts html.Node tree to our Node tree.
-// Note: golang.org/x/net/html doesn't provide source positions,
-// so all nodes have line=1, col=1 as placeholders.
-func buildNodeTree(n *html.Node, parent *Node) *Node {
+// Elements get the position of their start tag and comments the position
+// of their "<!--"; other nodes (text, implicit elements) inherit their
+// parent's position.
+func (b *treeBuilder) buildNodeTree(n *html.Node, parent *Node) *Node {
 	line, col := 1, 1
 	if parent != nil {
 		line = parent.Line
@@ -191,20 +410,53 @@ func buildNodeTree(n *html.Node, parent *Node) *Node {
 		Col:    col,
 	}
 
+	if n.Type == html.CommentNode {
+		if offset, ok := b.takeComment(n.Data); ok {
+			node.Line, node.Col = b.position(offset)
+		}
+	}
+
+	if n.Type == html.DoctypeNode {
+		if offset := doctypeOffset(b.sourceMap.Processed); offset >= 0 {
+			node.Line, node.Col = b.position(offset)
+		}
+	}
+
+	if n.Type == html.ElementNode {
+		if tag, ok := takePosition(n, b.tags); ok {
+			node.Line, node.Col = b.position(tag.start)
+			node.attrPos = make(map[string]position, len(tag.attrs))
+			for name, off := range tag.attrs {
+				l, c := b.position(off)
+				node.attrPos[name] = position{line: l, col: c}
+			}
+		}
+		b.depth++
+		defer func() { b.depth-- }()
+		if b.depth > MaxNestingDepth {
+			panic(nestingError{node.Line, node.Col})
+		}
+	}
+
 	// Process children
 	for c := n.FirstChild; c != nil; c = c.NextSibling {
-		child := buildNodeTree(c, node)
+		child := b.buildNodeTree(c, node)
 		node.Children = append(node.Children, child)
 	}
 
 	return node
 }
 
+// position converts a processed-content offset to an original line/column.
+func (b *treeBuilder) position(offset int) (line, col int) {
+	return b.sourceMap.offsetPosition(offset)
+}
+
 // ParseReader parses HTML from an io.Reader.
 func ParseReader(filename string, r io.Reader) (*Document, error) {
 	content, err := io.ReadAll(r)
 	if err != nil {
-		return nil, err
+		return nil, &ParseError{Filename: filename, Err: err}
 	}
 	return Parse(filename, content)
 }
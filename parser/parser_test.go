@@ -0,0 +1,567 @@
+package parser_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+func TestNode_TraversalHelpers(t *testing.T) {
+	doc, err := parser.ParseFragment("test.html", []byte(
+		`<form><fieldset><label>Name <input id="name"></label></fieldset></form>`+
+			`<ul><li id="a">A</li> <!-- note --> <li id="b">B</li>text<li id="c">C</li></ul>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	input := doc.QuerySelectorAll("#name")[0]
+	if got := input.ClosestAncestor("form"); got == nil || !got.IsElement("form") {
+		t.Errorf("ClosestAncestor(form) = %v, want <form>", got)
+	}
+	if got := input.ClosestAncestor("FIELDSET", "form"); got == nil || !got.IsElement("fieldset") {
+		t.Errorf("ClosestAncestor(fieldset, form) = %v, want nearest <fieldset>", got)
+	}
+	if got := input.ClosestAncestor("table"); got != nil {
+		t.Errorf("ClosestAncestor(table) = %v, want nil", got)
+	}
+	if got := input.Depth(); got != 4 {
+		t.Errorf("Depth() = %d, want 4", got)
+	}
+	if got := doc.Root.Depth(); got != 0 {
+		t.Errorf("root Depth() = %d, want 0", got)
+	}
+
+	a := doc.QuerySelectorAll("#a")[0]
+	b := doc.QuerySelectorAll("#b")[0]
+	c := doc.QuerySelectorAll("#c")[0]
+
+	if got := a.NextElementSibling(); got != b {
+		t.Errorf("a.NextElementSibling() = %v, want #b", got)
+	}
+	if got := b.NextElementSibling(); got != c {
+		t.Errorf("b.NextElementSibling() = %v, want #c", got)
+	}
+	if got := c.NextElementSibling(); got != nil {
+		t.Errorf("c.NextElementSibling() = %v, want nil", got)
+	}
+	if got := c.PreviousElementSibling(); got != b {
+		t.Errorf("c.PreviousElementSibling() = %v, want #b", got)
+	}
+	if got := a.PreviousElementSibling(); got != nil {
+		t.Errorf("a.PreviousElementSibling() = %v, want nil", got)
+	}
+	if got := doc.Root.NextElementSibling(); got != nil {
+		t.Errorf("root NextElementSibling() = %v, want nil", got)
+	}
+
+	var tags []string
+	for _, p := range input.Ancestors() {
+		tags = append(tags, p.Data)
+	}
+	if want := []string{"label", "fieldset", "form"}; !reflect.DeepEqual(tags, want) {
+		t.Errorf("Ancestors() = %v, want %v", tags, want)
+	}
+	if got := doc.Root.Ancestors(); len(got) != 0 {
+		t.Errorf("root Ancestors() = %v, want none", got)
+	}
+
+	ul := a.Parent
+	if got := ul.ChildElements(); !reflect.DeepEqual(got, []*parser.Node{a, b, c}) {
+		t.Errorf("ChildElements() = %v, want #a, #b, #c", got)
+	}
+	if got := a.ChildElements(); len(got) != 0 {
+		t.Errorf("a.ChildElements() = %v, want none", got)
+	}
+}
+
+func TestNode_Positions(t *testing.T) {
+	content := "<div>\n  <input type=\"text\"\n         autocomplete=\"bogus\"\n         id=x>\n</div>\n<table><tr><td class=c>1</td></tr></table>"
+	doc, err := parser.ParseFragment("test.html", []byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	input := doc.QuerySelectorAll("input")[0]
+	if input.Line != 2 || input.Col != 3 {
+		t.Errorf("input position = %d:%d, want 2:3", input.Line, input.Col)
+	}
+	if input.HasAttr("data-htmlint-pos") {
+		t.Error("position marker attribute should be stripped")
+	}
+
+	tests := []struct {
+		attr     string
+		line     int
+		col      int
+		fallback bool
+	}{
+		{attr: "type", line: 2, col: 10},
+		{attr: "AUTOCOMPLETE", line: 3, col: 10},
+		{attr: "id", line: 4, col: 10},
+		{attr: "missing", line: 2, col: 3, fallback: true},
+	}
+	for _, tt := range tests {
+		line, col := input.AttrPos(tt.attr)
+		if line != tt.line || col != tt.col {
+			t.Errorf("AttrPos(%q) = %d:%d, want %d:%d", tt.attr, line, col, tt.line, tt.col)
+		}
+	}
+
+	// Implicit tbody inherits its parent's position; td keeps its own.
+	td := doc.QuerySelectorAll("td")[0]
+	if td.Line != 6 || td.Col != 12 {
+		t.Errorf("td position = %d:%d, want 6:12", td.Line, td.Col)
+	}
+	tbody := td.ClosestAncestor("tbody")
+	if tbody == nil || tbody.Line != 6 || tbody.Col != 1 {
+		t.Errorf("implicit tbody should inherit table position 6:1, got %v", tbody)
+	}
+}
+
+func TestDocument_Comments(t *testing.T) {
+	content := "<!-- top -->\n<!DOCTYPE html>\n<html><head><title>{{.T}}</title></head>\n<body>\n  <p>a <!-- same --> b</p>\n  {{if .X}}<!-- same -->{{end}}\n</body></html>\n<!-- after -->"
+	doc, err := parser.ParseWithDialect("test.html", []byte(content), parser.ModeDocument, parser.DialectGo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type comment struct {
+		data      string
+		line, col int
+	}
+	var got []comment
+	for _, n := range doc.Comments() {
+		got = append(got, comment{n.Data, n.Line, n.Col})
+	}
+	want := []comment{
+		{" top ", 1, 1},
+		{" same ", 5, 8},
+		{" same ", 6, 12},
+		{" after ", 8, 1},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Comments() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("comment %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDocument_Doctype(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		mode     parser.Mode
+		want     *parser.Doctype
+		wantMode parser.QuirksMode
+	}{
+		{
+			name:     "html5",
+			content:  "<!-- x -->\n<!doctype html>\n<html><head><title>t</title></head></html>",
+			mode:     parser.ModeDocument,
+			want:     &parser.Doctype{Name: "html", Line: 2, Col: 1},
+			wantMode: parser.NoQuirks,
+		},
+		{
+			name:     "missing",
+			content:  "<html><head><title>t</title></head></html>",
+			mode:     parser.ModeDocument,
+			wantMode: parser.Quirks,
+		},
+		{
+			name:     "html 4.01 transitional without system id",
+			content:  `<!DOCTYPE HTML PUBLIC "-//W3C//DTD HTML 4.01 Transitional//EN"><html></html>`,
+			mode:     parser.ModeDocument,
+			want:     &parser.Doctype{Name: "html", PublicID: "-//W3C//DTD HTML 4.01 Transitional//EN", HasPublicID: true, Line: 1, Col: 1},
+			wantMode: parser.Quirks,
+		},
+		{
+			name:     "html 4.01 transitional with system id",
+			content:  `<!DOCTYPE HTML PUBLIC "-//W3C//DTD HTML 4.01 Transitional//EN" "http://www.w3.org/TR/html4/loose.dtd"><html></html>`,
+			mode:     parser.ModeDocument,
+			want:     &parser.Doctype{Name: "html", PublicID: "-//W3C//DTD HTML 4.01 Transitional//EN", SystemID: "http://www.w3.org/TR/html4/loose.dtd", HasPublicID: true, HasSystemID: true, Line: 1, Col: 1},
+			wantMode: parser.LimitedQuirks,
+		},
+		{
+			name:     "html 4.01 strict",
+			content:  `<!DOCTYPE HTML PUBLIC "-//W3C//DTD HTML 4.01//EN" "http://www.w3.org/TR/html4/strict.dtd"><html></html>`,
+			mode:     parser.ModeDocument,
+			want:     &parser.Doctype{Name: "html", PublicID: "-//W3C//DTD HTML 4.01//EN", SystemID: "http://www.w3.org/TR/html4/strict.dtd", HasPublicID: true, HasSystemID: true, Line: 1, Col: 1},
+			wantMode: parser.NoQuirks,
+		},
+		{
+			name:     "fragment",
+			content:  "<p>x</p>",
+			mode:     parser.ModeFragment,
+			wantMode: parser.NoQuirks,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parser.ParseWithMode("test.html", []byte(tt.content), tt.mode)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := doc.Doctype(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Doctype() = %+v, want %+v", got, tt.want)
+			}
+			if got := doc.QuirksMode(); got != tt.wantMode {
+				t.Errorf("QuirksMode() = %v, want %v", got, tt.wantMode)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	utf16le := []byte{0xFF, 0xFE}
+	for _, r := range "<p>é</p>" {
+		utf16le = append(utf16le, byte(r), byte(r>>8))
+	}
+
+	tests := []struct {
+		name         string
+		content      []byte
+		want         string
+		wantEncoding string
+	}{
+		{
+			name:         "undeclared",
+			content:      []byte("<p>é</p>"),
+			want:         "<p>é</p>",
+			wantEncoding: parser.EncodingUTF8,
+		},
+		{
+			name:         "utf-16le bom",
+			content:      utf16le,
+			want:         "<p>é</p>",
+			wantEncoding: parser.EncodingUTF16LE,
+		},
+		{
+			name:         "utf-16be bom",
+			content:      []byte{0xFE, 0xFF, 0x00, '<', 0x00, 'b', 0x00, '>', 0x20, 0x14},
+			want:         "<b>\u2014",
+			wantEncoding: parser.EncodingUTF16BE,
+		},
+		{
+			name:         "meta windows-1252",
+			content:      []byte("<meta charset=\"windows-1252\"><p>caf\xE9 \x93q\x94</p>"),
+			want:         "<meta charset=\"windows-1252\"><p>café “q”</p>",
+			wantEncoding: parser.EncodingWindows1252,
+		},
+		{
+			name:         "http-equiv latin-1 label",
+			content:      []byte(`<meta http-equiv="Content-Type" content="text/html; charset=ISO-8859-1"><p>x</p>`),
+			want:         `<meta http-equiv="Content-Type" content="text/html; charset=ISO-8859-1"><p>x</p>`,
+			wantEncoding: parser.EncodingWindows1252,
+		},
+		{
+			name:         "declared windows-1252 already utf-8",
+			content:      []byte(`<meta charset="windows-1252"><p>café</p>`),
+			want:         `<meta charset="windows-1252"><p>café</p>`,
+			wantEncoding: parser.EncodingWindows1252,
+		},
+		{
+			name:         "unsupported encoding",
+			content:      []byte(`<meta charset="Shift_JIS"><p>x</p>`),
+			want:         `<meta charset="Shift_JIS"><p>x</p>`,
+			wantEncoding: "shift_jis",
+		},
+		{
+			name:         "template charset",
+			content:      []byte(`<meta charset="{{.Charset}}">`),
+			want:         `<meta charset="{{.Charset}}">`,
+			wantEncoding: parser.EncodingUTF8,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, encoding := parser.Decode(tt.content)
+			if string(got) != tt.want || encoding != tt.wantEncoding {
+				t.Errorf("Decode() = %q, %q, want %q, %q", got, encoding, tt.want, tt.wantEncoding)
+			}
+			again, _ := parser.Decode(got)
+			if string(again) != string(got) {
+				t.Errorf("decoding twice = %q, want %q", again, got)
+			}
+		})
+	}
+
+	doc, err := parser.Parse("test.html", utf16le)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Encoding != parser.EncodingUTF16LE {
+		t.Errorf("Encoding = %q, want %q", doc.Encoding, parser.EncodingUTF16LE)
+	}
+	if p := doc.QuerySelectorAll("p"); len(p) != 1 || p[0].TextContent() != "é" {
+		t.Errorf("parsed UTF-16 content has <p> %v, want one with text é", p)
+	}
+}
+
+func TestDocument_Source(t *testing.T) {
+	content := "<p>{{ .Title }}</p>\r\n<a href=\"/x\">link</a>\n<br>"
+	doc, err := parser.ParseFragment("test.html", []byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := string(doc.Source()); got != content {
+		t.Errorf("Source() = %q, want original content", got)
+	}
+
+	lineTests := []struct {
+		line int
+		want string
+	}{
+		{1, "<p>{{ .Title }}</p>"},
+		{2, `<a href="/x">link</a>`},
+		{3, "<br>"},
+		{4, ""},
+		{0, ""},
+	}
+	for _, tt := range lineTests {
+		if got := string(doc.SourceLine(tt.line)); got != tt.want {
+			t.Errorf("SourceLine(%d) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+
+	rangeTests := []struct {
+		name                         string
+		startLine, startCol, endLine int
+		endCol                       int
+		want                         string
+	}{
+		{"template action", 1, 4, 1, 16, "{{ .Title }}"},
+		{"across lines", 1, 16, 2, 3, "</p>\r\n<a"},
+		{"attribute via AttrPos", 2, 4, 2, 13, `href="/x"`},
+		{"clamped end", 3, 1, 9, 9, "<br>"},
+		{"reversed", 2, 5, 1, 1, ""},
+	}
+	for _, tt := range rangeTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(doc.SourceRange(tt.startLine, tt.startCol, tt.endLine, tt.endCol))
+			if got != tt.want {
+				t.Errorf("SourceRange() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_NestingTooDeep(t *testing.T) {
+	content := []byte("<p>ok</p>\n" + strings.Repeat("<div>", parser.MaxNestingDepth+1))
+	for _, mode := range []parser.Mode{parser.ModeDocument, parser.ModeFragment} {
+		_, err := parser.ParseWithMode("deep.html", content, mode)
+		var perr *parser.ParseError
+		if !errors.As(err, &perr) {
+			t.Fatalf("mode %v: got error %v, want *ParseError", mode, err)
+		}
+		if !errors.Is(err, parser.ErrNestingTooDeep) {
+			t.Errorf("mode %v: error %v does not wrap ErrNestingTooDeep", mode, err)
+		}
+		if perr.Filename != "deep.html" || perr.Line != 2 {
+			t.Errorf("mode %v: got %s:%d, want deep.html:2", mode, perr.Filename, perr.Line)
+		}
+	}
+
+	if _, err := parser.ParseFragment("ok.html", []byte(strings.Repeat("<div>", parser.MaxNestingDepth-10))); err != nil {
+		t.Errorf("nesting below the limit: %v", err)
+	}
+}
+
+func TestStream(t *testing.T) {
+	content := "<!DOCTYPE html>\n<ul class=\"a\"\n    ID=one id=two>\n  <li>A &amp; B</li>\n</ul><br/>"
+	type seen struct {
+		data      string
+		line, col int
+		open      string
+	}
+	var got []seen
+	var attrs []parser.TokenAttr
+	err := parser.Stream("big.html", strings.NewReader(content), func(tok *parser.Token) {
+		if tok.Type == html.TextToken && strings.TrimSpace(tok.Data) == "" {
+			return
+		}
+		got = append(got, seen{tok.Data, tok.Line, tok.Col, strings.Join(tok.Open, ">")})
+		if tok.Data == "ul" && tok.Type == html.StartTagToken {
+			attrs = append(attrs, tok.Attrs...)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []seen{
+		{"html", 1, 1, ""},
+		{"ul", 2, 1, ""},
+		{"li", 4, 3, "ul"},
+		{"A & B", 4, 7, "ul>li"},
+		{"li", 4, 16, "ul>li"},
+		{"ul", 5, 1, "ul"},
+		{"br", 5, 6, ""},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("tokens = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("token %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	wantAttrs := []parser.TokenAttr{
+		{Name: "class", Value: "a", Line: 2, Col: 5},
+		{Name: "id", Value: "one", Line: 3, Col: 5},
+		{Name: "id", Value: "two", Line: 3, Col: 12},
+	}
+	if len(attrs) != len(wantAttrs) {
+		t.Fatalf("attrs = %v, want %v", attrs, wantAttrs)
+	}
+	for i := range wantAttrs {
+		if attrs[i] != wantAttrs[i] {
+			t.Errorf("attr %d = %+v, want %+v", i, attrs[i], wantAttrs[i])
+		}
+	}
+}
+
+func TestStream_NestingTooDeep(t *testing.T) {
+	content := strings.Repeat("<div>", parser.MaxNestingDepth+1)
+	err := parser.Stream("deep.html", strings.NewReader(content), func(*parser.Token) {})
+	if !errors.Is(err, parser.ErrNestingTooDeep) {
+		t.Fatalf("got error %v, want ErrNestingTooDeep", err)
+	}
+}
+
+func TestStream_ImpliedEndTags(t *testing.T) {
+	items := strings.Repeat("<li>item\n", 3000)
+	rows := strings.Repeat("<tr><td>a<td>b\n", 1000)
+	content := "<ul>" + items + "</ul><p>one<p>two<div>x</div><dl><dt>a<dd>b</dl>" +
+		"<select><option>a<option>b</select><table><tbody>" + rows + "</table><span>end</span>"
+
+	deepest := 0
+	var spanOpen string
+	err := parser.Stream("list.html", strings.NewReader(content), func(tok *parser.Token) {
+		deepest = max(deepest, len(tok.Open))
+		if tok.Type == html.StartTagToken && tok.Data == "span" {
+			spanOpen = strings.Join(tok.Open, ">")
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deepest > 4 {
+		t.Errorf("deepest Open = %d, want implied end tags to close siblings", deepest)
+	}
+	if spanOpen != "" {
+		t.Errorf("span Open = %q, want empty", spanOpen)
+	}
+}
+
+func TestTokens(t *testing.T) {
+	content := []byte("<DIV Class='a' class=\"b\"\n  hidden data-x=1 title=\"{{if .N}}{{\"a>b\"}}{{end}}\">x</Div>")
+	tokens := parser.Tokens("page.html", content)
+	if len(tokens) != 3 {
+		t.Fatalf("got %d tokens, want 3", len(tokens))
+	}
+
+	start, text, end := tokens[0], tokens[1], tokens[2]
+	if start.Type != html.StartTagToken || start.Name != "DIV" || start.Line != 1 || start.Col != 1 {
+		t.Errorf("start tag = %v %q at %d:%d", start.Type, start.Name, start.Line, start.Col)
+	}
+	if string(text.Raw(content)) != "x" || text.Line != 2 || text.Type != html.TextToken {
+		t.Errorf("text = %q at line %d", text.Raw(content), text.Line)
+	}
+	if end.Type != html.EndTagToken || end.Name != "Div" || end.End != len(content) {
+		t.Errorf("end tag = %v %q ending at %d", end.Type, end.Name, end.End)
+	}
+
+	type attr struct {
+		name, value string
+		hasValue    bool
+		quote       byte
+		line, col   int
+	}
+	want := []attr{
+		{"Class", "a", true, '\'', 1, 6},
+		{"class", "b", true, '"', 1, 16},
+		{"hidden", "", false, 0, 2, 3},
+		{"data-x", "1", true, 0, 2, 10},
+		{"title", "{{if .N}}{{\"a>b\"}}{{end}}", true, '"', 2, 19},
+	}
+	if len(start.Attrs) != len(want) {
+		t.Fatalf("got %d attrs, want %d", len(start.Attrs), len(want))
+	}
+	for i, w := range want {
+		a := start.Attrs[i]
+		got := attr{a.Name, a.Value, a.HasValue, a.Quote, a.Line, a.Col}
+		if got != w {
+			t.Errorf("attr %d = %+v, want %+v", i, got, w)
+		}
+		if string(content[a.NameStart:a.NameEnd]) != w.name {
+			t.Errorf("attr %d name offsets select %q", i, content[a.NameStart:a.NameEnd])
+		}
+		if a.HasValue && string(content[a.ValueStart:a.ValueEnd]) != w.value {
+			t.Errorf("attr %d value offsets select %q", i, content[a.ValueStart:a.ValueEnd])
+		}
+	}
+}
+
+func TestConditionalVariant(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		changed bool
+	}{
+		{
+			name:    "downlevel-hidden is uncommented",
+			content: "<!--[if lt IE 9]>\n<script src=\"shiv.js\"></script><![endif]-->",
+			want:    "                 \n<script src=\"shiv.js\"></script>            ",
+			changed: true,
+		},
+		{
+			name:    "downlevel-revealed for non-IE is blanked",
+			content: `<p>a</p><![if !IE]><p>b</p><![endif]>`,
+			want:    `<p>a</p>                             `,
+			changed: true,
+		},
+		{
+			name:    "valid-HTML downlevel-revealed for non-IE is blanked",
+			content: `<!--[if !IE]><!--><p>b</p><!--<![endif]-->`,
+			want:    `                                          `,
+			changed: true,
+		},
+		{
+			name:    "downlevel-revealed for IE is kept",
+			content: `<![if gte IE 9]><p>b</p><![endif]>`,
+			want:    `<![if gte IE 9]><p>b</p><![endif]>`,
+		},
+		{
+			name:    "template actions in blanked markup are kept",
+			content: `<![if !IE]>{{if .A}}<p>b</p>{{end}}<![endif]>`,
+			want:    `           {{if .A}}        {{end}}          `,
+			changed: true,
+		},
+		{
+			name:    "no conditional comments",
+			content: `<!-- [if] --><p>a</p>`,
+			want:    `<!-- [if] --><p>a</p>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed := parser.ConditionalVariant([]byte(tt.content), parser.DialectGo)
+			if string(got) != tt.want || changed != tt.changed {
+				t.Errorf("ConditionalVariant() = %q, %v, want %q, %v", got, changed, tt.want, tt.changed)
+			}
+		})
+	}
+}
@@ -0,0 +1,258 @@
+package parser
+
+import (
+	"bytes"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// positionAttr is a marker attribute injected into every start tag before
+// tree construction. Its value indexes the tag's recorded source offsets.
+// html.Parse does not expose token positions, and implicit or reordered
+// elements make matching tokens to nodes unreliable, so the marker travels
+// with the element through the tree builder and is stripped afterwards.
+const positionAttr = "data-htmlint-pos"
+
+// position is a 1-indexed line and column.
+type position struct {
+	line int
+	col  int
+}
+
+// tagOffsets records byte offsets of a start tag and its attributes.
+type tagOffsets struct {
+	start int
+	attrs map[string]int // lowercase attribute name -> offset of its name
+}
+
+// commentOffset records the offset and text of a comment token. Comments
+// cannot carry a marker, so the tree builder matches them to comment nodes
+// by text in document order, which the HTML parser preserves.
+type commentOffset struct {
+	start int
+	data  string
+}
+
+// annotatePositions tokenizes content and returns a copy in which every
+// start tag carries a positionAttr marker, plus the offsets it refers to
+// and the offsets of comments.
+func annotatePositions(content []byte) ([]byte, []tagOffsets, []commentOffset) {
+	var tags []tagOffsets
+	var comments []commentOffset
+	var inserts []int // offsets where markers are inserted, one per tag
+
+	z := html.NewTokenizer(bytes.NewReader(content))
+	offset := 0
+	for {
+		tt := z.Next()
+		raw := z.Raw()
+		if tt == html.ErrorToken {
+			break
+		}
+		if tt == html.StartTagToken || tt == html.SelfClosingTagToken {
+			nameEnd, attrs := scanTag(raw)
+			tag := tagOffsets{start: offset, attrs: make(map[string]int, len(attrs))}
+			for name, off := range attrs {
+				tag.attrs[name] = offset + off
+			}
+			tags = append(tags, tag)
+			inserts = append(inserts, offset+nameEnd)
+		}
+		size := len(raw) // Text may reuse raw's buffer
+		if tt == html.CommentToken {
+			comments = append(comments, commentOffset{start: offset, data: string(z.Text())})
+		}
+		offset += size
+	}
+
+	if len(inserts) == 0 {
+		return content, nil, comments
+	}
+
+	out := make([]byte, 0, len(content)+len(inserts)*24)
+	prev := 0
+	for i, at := range inserts {
+		out = append(out, content[prev:at]...)
+		out = append(out, ' ')
+		out = append(out, positionAttr...)
+		out = append(out, `="`...)
+		out = strconv.AppendInt(out, int64(i), 10)
+		out = append(out, '"')
+		prev = at
+	}
+	out = append(out, content[prev:]...)
+	return out, tags, comments
+}
+
+// scanTag finds the end of the tag name in a raw start tag and the
+// offsets of each attribute name. The first occurrence of a name wins,
+// matching how the HTML parser resolves duplicate attributes.
+func scanTag(raw []byte) (nameEnd int, attrs map[string]int) {
+	nameEnd, list := ScanTagAttrs(raw)
+	attrs = make(map[string]int, len(list))
+	for _, attr := range list {
+		if _, seen := attrs[attr.Name]; !seen {
+			attrs[attr.Name] = attr.NameStart
+		}
+	}
+	return nameEnd, attrs
+}
+
+// RawAttr locates an attribute within a raw start tag. Offsets are
+// relative to the start of the tag.
+type RawAttr struct {
+	Name       string // lowercase attribute name
+	NameStart  int    // offset of the name
+	NameEnd    int    // offset after the name
+	ValueStart int    // offset of the value, excluding quotes; -1 if none
+	ValueEnd   int    // offset after the value, excluding quotes
+	Quote      byte   // '"', '\'', or 0 for unquoted values
+}
+
+// ScanTagAttrs parses a raw start tag, as returned by html.Tokenizer.Raw,
+// into the end offset of its tag name and its attributes in source order,
+// including duplicates.
+func ScanTagAttrs(raw []byte) (nameEnd int, attrs []RawAttr) {
+	i := 1 // skip '<'
+	for i < len(raw) && !IsSpace(raw[i]) && raw[i] != '/' && raw[i] != '>' {
+		i++
+	}
+	nameEnd = i
+
+	for i < len(raw) {
+		for i < len(raw) && (IsSpace(raw[i]) || raw[i] == '/') {
+			i++
+		}
+		if i >= len(raw) || raw[i] == '>' {
+			break
+		}
+
+		start := i
+		i++ // the first character may be '=' per the tokenizer spec
+		for i < len(raw) && !IsSpace(raw[i]) && raw[i] != '/' && raw[i] != '>' && raw[i] != '=' {
+			i++
+		}
+		attr := RawAttr{
+			Name:       strings.ToLower(string(raw[start:i])),
+			NameStart:  start,
+			NameEnd:    i,
+			ValueStart: -1,
+		}
+
+		for i < len(raw) && IsSpace(raw[i]) {
+			i++
+		}
+		if i >= len(raw) || raw[i] != '=' {
+			attrs = append(attrs, attr)
+			continue
+		}
+		i++
+		for i < len(raw) && IsSpace(raw[i]) {
+			i++
+		}
+		if i < len(raw) && (raw[i] == '"' || raw[i] == '\'') {
+			attr.Quote = raw[i]
+			i++
+			attr.ValueStart = i
+			for i < len(raw) && raw[i] != attr.Quote {
+				i++
+			}
+			attr.ValueEnd = i
+			i++
+		} else {
+			attr.ValueStart = i
+			for i < len(raw) && !IsSpace(raw[i]) && raw[i] != '>' {
+				i++
+			}
+			attr.ValueEnd = i
+		}
+		attrs = append(attrs, attr)
+	}
+
+	return nameEnd, attrs
+}
+
+// IsSpace reports whether c is HTML whitespace: space, tab, line feed,
+// carriage return, or form feed.
+func IsSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+// lineIndex converts byte offsets into 1-indexed line/column positions.
+type lineIndex struct {
+	starts []int // offset of the first byte of each line
+}
+
+func newLineIndex(content []byte) *lineIndex {
+	starts := []int{0}
+	for i, c := range content {
+		if c == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	return &lineIndex{starts: starts}
+}
+
+// position returns the line and column of a byte offset.
+func (li *lineIndex) position(offset int) (line, col int) {
+	i := sort.Search(len(li.starts), func(i int) bool { return li.starts[i] > offset }) - 1
+	return i + 1, offset - li.starts[i] + 1
+}
+
+// offset returns the byte offset of a 1-indexed line and column, clamped
+// to the bounds of content of the given length.
+func (li *lineIndex) offset(line, col, length int) int {
+	if line < 1 {
+		return 0
+	}
+	if line > len(li.starts) {
+		return length
+	}
+	off := li.starts[line-1] + max(col, 1) - 1
+	if line < len(li.starts) {
+		off = min(off, li.starts[line]-1) // stay on this line
+	}
+	return min(off, length)
+}
+
+// takePosition removes the positionAttr marker from n and returns the
+// tag offsets it refers to.
+func takePosition(n *html.Node, tags []tagOffsets) (tagOffsets, bool) {
+	for i, attr := range n.Attr {
+		if attr.Key != positionAttr {
+			continue
+		}
+		n.Attr = append(n.Attr[:i:i], n.Attr[i+1:]...)
+		idx, err := strconv.Atoi(attr.Val)
+		if err != nil || idx < 0 || idx >= len(tags) {
+			return tagOffsets{}, false
+		}
+		return tags[idx], true
+	}
+	return tagOffsets{}, false
+}
+
+// takeComment returns the offset of the next recorded comment with text
+// data, consuming it and any comments skipped before it.
+func (b *treeBuilder) takeComment(data string) (int, bool) {
+	for i := b.nextComment; i < len(b.comments); i++ {
+		if b.comments[i].data == data {
+			b.nextComment = i + 1
+			return b.comments[i].start, true
+		}
+	}
+	return 0, false
+}
+
+// AttrPos returns the source line and column of the named attribute.
+// Falls back to the element's position when the attribute is absent or
+// its position is unknown.
+func (n *Node) AttrPos(name string) (line, col int) {
+	if pos, ok := n.attrPos[strings.ToLower(name)]; ok {
+		return pos.line, pos.col
+	}
+	return n.Line, n.Col
+}
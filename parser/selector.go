@@ -0,0 +1,912 @@
+package parser
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+// ErrUnsupportedSelector is wrapped by CompileSelector errors for valid CSS
+// that cannot be matched against static markup: user-action pseudo-classes
+// such as :hover and pseudo-elements such as ::before. Callers checking
+// selectors a browser will evaluate can accept these.
+var ErrUnsupportedSelector = errors.New("unsupported selector")
+
+// Selector is a compiled CSS selector list that can be matched against nodes.
+//
+// Supported syntax:
+//   - type, universal (*), #id, .class, with CSS escapes (e.g. .md\:flex)
+//   - attributes: [a], [a=v], [a~=v], [a|=v], [a^=v], [a$=v], [a*=v], optional " i" flag
+//   - structural pseudo-classes: :first-child, :last-child, :only-child,
+//     :nth-child(An+B [of list]), :nth-last-child(), :first-of-type,
+//     :last-of-type, :only-of-type, :nth-of-type(), :nth-last-of-type(),
+//     :empty, :root
+//   - logical pseudo-classes: :not(list), :is(list), :where(list), :has(relative list)
+//   - form and link pseudo-classes: :checked, :disabled, :enabled,
+//     :required, :optional, :link, :any-link
+//   - combinators: descendant (space), child (>), adjacent (+), general sibling (~)
+//   - selector lists separated by commas
+type Selector struct {
+	groups []complexSelector
+}
+
+// complexSelector is a chain of compound selectors joined by combinators.
+// combinators[i] joins parts[i] and parts[i+1].
+type complexSelector struct {
+	parts       []compoundSelector
+	combinators []byte
+}
+
+// compoundSelector is a sequence of simple selectors that all apply to one element.
+type compoundSelector struct {
+	tag     string // lowercase, empty or "*" matches any element
+	ids     []string
+	classes []string
+	attrs   []attrSelector
+	pseudos []pseudoSelector
+}
+
+type attrSelector struct {
+	name       string
+	op         string // "", "=", "~=", "|=", "^=", "$=", "*="
+	value      string
+	ignoreCase bool
+}
+
+type pseudoSelector struct {
+	name     string
+	list     *Selector          // argument of :not, :is, :where, and the "of" list of :nth-child
+	relative []relativeSelector // argument of :has
+	a, b     int                // An+B of the :nth-* pseudo-classes
+}
+
+// relativeSelector is a complex selector in :has(), related to the
+// element being matched by a leading combinator (descendant by default).
+type relativeSelector struct {
+	combinator byte
+	complex    complexSelector
+}
+
+// simplePseudoClasses take no argument.
+var simplePseudoClasses = map[string]bool{
+	"first-child": true, "last-child": true, "only-child": true,
+	"first-of-type": true, "last-of-type": true, "only-of-type": true,
+	"empty": true, "root": true,
+	"checked": true, "disabled": true, "enabled": true, "required": true, "optional": true,
+	"link": true, "any-link": true,
+}
+
+// dynamicPseudoClasses depend on user interaction or browser state, so
+// static markup never matches them.
+var dynamicPseudoClasses = map[string]bool{
+	"hover": true, "active": true, "focus": true, "focus-within": true, "focus-visible": true,
+	"visited": true, "target": true, "target-within": true, "default": true,
+	"indeterminate": true, "valid": true, "invalid": true, "in-range": true, "out-of-range": true,
+	"placeholder-shown": true, "autofill": true, "read-only": true, "read-write": true,
+	"user-valid": true, "user-invalid": true, "fullscreen": true, "modal": true,
+	"popover-open": true, "defined": true, "playing": true, "paused": true,
+}
+
+// CompileSelector parses a CSS selector list.
+func CompileSelector(sel string) (*Selector, error) {
+	p := &selectorParser{src: sel}
+	s, err := p.parseList()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if !p.eof() {
+		return nil, p.unexpected()
+	}
+	return s, nil
+}
+
+// MustCompileSelector is like CompileSelector but panics on error.
+// Intended for package-level selectors in rules.
+func MustCompileSelector(sel string) *Selector {
+	s, err := CompileSelector(sel)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Match reports whether the node matches any selector in the list.
+func (s *Selector) Match(n *Node) bool {
+	if n == nil || n.Node == nil || n.Type != html.ElementNode {
+		return false
+	}
+	for i := range s.groups {
+		if s.groups[i].match(len(s.groups[i].parts)-1, n, nil) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchAll returns all nodes under root (excluding root itself) that match,
+// in document order.
+func (s *Selector) MatchAll(root *Node) []*Node {
+	var matches []*Node
+	if root == nil {
+		return nil
+	}
+	for _, child := range root.Children {
+		child.walk(func(n *Node) bool {
+			if s.Match(n) {
+				matches = append(matches, n)
+			}
+			return true
+		})
+	}
+	return matches
+}
+
+// QuerySelectorAll returns all elements matching the CSS selector in document order.
+// Returns nil if the selector is invalid.
+func (d *Document) QuerySelectorAll(sel string) []*Node {
+	s, err := CompileSelector(sel)
+	if err != nil || d.Root == nil {
+		return nil
+	}
+	if s.Match(d.Root) {
+		return append([]*Node{d.Root}, s.MatchAll(d.Root)...)
+	}
+	return s.MatchAll(d.Root)
+}
+
+// QuerySelector returns the first element matching the CSS selector in
+// document order, or nil if none does or the selector is invalid.
+func (d *Document) QuerySelector(sel string) *Node {
+	if matches := d.QuerySelectorAll(sel); len(matches) > 0 {
+		return matches[0]
+	}
+	return nil
+}
+
+// QuerySelectorAll returns all descendant elements matching the CSS selector.
+// Returns nil if the selector is invalid.
+func (n *Node) QuerySelectorAll(sel string) []*Node {
+	s, err := CompileSelector(sel)
+	if err != nil {
+		return nil
+	}
+	return s.MatchAll(n)
+}
+
+// QuerySelector returns the first descendant element matching the CSS
+// selector, or nil if none does or the selector is invalid.
+func (n *Node) QuerySelector(sel string) *Node {
+	if matches := n.QuerySelectorAll(sel); len(matches) > 0 {
+		return matches[0]
+	}
+	return nil
+}
+
+// Matches reports whether the node matches the CSS selector.
+// Returns false if the selector is invalid.
+func (n *Node) Matches(sel string) bool {
+	s, err := CompileSelector(sel)
+	if err != nil {
+		return false
+	}
+	return s.Match(n)
+}
+
+// Closest returns the node or its nearest ancestor matching the CSS
+// selector, like Element.closest, or nil if none does or the selector is
+// invalid.
+func (n *Node) Closest(sel string) *Node {
+	s, err := CompileSelector(sel)
+	if err != nil {
+		return nil
+	}
+	for ; n != nil; n = n.Parent {
+		if s.Match(n) {
+			return n
+		}
+	}
+	return nil
+}
+
+// match reports whether n matches parts[idx] and the parts before it. For
+// relative selectors, anchor checks the element matching parts[0].
+func (c *complexSelector) match(idx int, n *Node, anchor func(*Node) bool) bool {
+	if !c.parts[idx].match(n) {
+		return false
+	}
+	if idx == 0 {
+		return anchor == nil || anchor(n)
+	}
+
+	switch c.combinators[idx-1] {
+	case '>':
+		return n.Parent != nil && c.match(idx-1, n.Parent, anchor)
+	case '+':
+		prev := n.PreviousElementSibling()
+		return prev != nil && c.match(idx-1, prev, anchor)
+	case '~':
+		for prev := n.PreviousElementSibling(); prev != nil; prev = prev.PreviousElementSibling() {
+			if c.match(idx-1, prev, anchor) {
+				return true
+			}
+		}
+		return false
+	default: // descendant
+		for p := n.Parent; p != nil; p = p.Parent {
+			if c.match(idx-1, p, anchor) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func (c *compoundSelector) match(n *Node) bool {
+	if n == nil || n.Node == nil || n.Type != html.ElementNode {
+		return false
+	}
+	if c.tag != "" && c.tag != "*" && !strings.EqualFold(n.Data, c.tag) {
+		return false
+	}
+	for _, id := range c.ids {
+		if n.GetAttr("id") != id {
+			return false
+		}
+	}
+	if len(c.classes) > 0 {
+		classes := strings.Fields(n.GetAttr("class"))
+		for _, want := range c.classes {
+			if !slices.Contains(classes, want) {
+				return false
+			}
+		}
+	}
+	for _, a := range c.attrs {
+		if !a.match(n) {
+			return false
+		}
+	}
+	for _, p := range c.pseudos {
+		if !p.match(n) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *attrSelector) match(n *Node) bool {
+	if !n.HasAttr(a.name) {
+		return false
+	}
+	if a.op == "" {
+		return true
+	}
+
+	val, want := n.GetAttr(a.name), a.value
+	if a.ignoreCase {
+		val, want = strings.ToLower(val), strings.ToLower(want)
+	}
+
+	switch a.op {
+	case "=":
+		return val == want
+	case "~=":
+		return slices.Contains(strings.Fields(val), want)
+	case "|=":
+		return val == want || strings.HasPrefix(val, want+"-")
+	case "^=":
+		return want != "" && strings.HasPrefix(val, want)
+	case "$=":
+		return want != "" && strings.HasSuffix(val, want)
+	case "*=":
+		return want != "" && strings.Contains(val, want)
+	}
+	return false
+}
+
+func (p *pseudoSelector) match(n *Node) bool {
+	switch p.name {
+	case "first-child":
+		return n.PreviousElementSibling() == nil
+	case "last-child":
+		return n.NextElementSibling() == nil
+	case "only-child":
+		return n.PreviousElementSibling() == nil && n.NextElementSibling() == nil
+	case "first-of-type":
+		return siblingIndex(n, false, sameType(n)) == 1
+	case "last-of-type":
+		return siblingIndex(n, true, sameType(n)) == 1
+	case "only-of-type":
+		return siblingIndex(n, false, sameType(n)) == 1 && siblingIndex(n, true, sameType(n)) == 1
+	case "nth-child", "nth-last-child":
+		if p.list != nil && !p.list.Match(n) {
+			return false
+		}
+		match := func(*Node) bool { return true }
+		if p.list != nil {
+			match = p.list.Match
+		}
+		return nthMatches(p.a, p.b, siblingIndex(n, p.name == "nth-last-child", match))
+	case "nth-of-type", "nth-last-of-type":
+		return nthMatches(p.a, p.b, siblingIndex(n, p.name == "nth-last-of-type", sameType(n)))
+	case "empty":
+		for _, child := range n.Children {
+			if child.Type == html.ElementNode || (child.Type == html.TextNode && child.Data != "") {
+				return false
+			}
+		}
+		return true
+	case "root":
+		return n.Parent == nil || n.Parent.Type != html.ElementNode
+	case "not":
+		return !p.list.Match(n)
+	case "is", "where":
+		return p.list.Match(n)
+	case "has":
+		return slices.ContainsFunc(p.relative, func(r relativeSelector) bool { return r.matchFrom(n) })
+	case "checked":
+		switch {
+		case n.IsElement("input"):
+			t := strings.ToLower(n.GetAttr("type"))
+			return (t == "checkbox" || t == "radio") && n.HasAttr("checked")
+		case n.IsElement("option"):
+			return n.HasAttr("selected")
+		}
+		return false
+	case "disabled", "enabled":
+		if !slices.Contains(disableableElements, strings.ToLower(n.Data)) {
+			return false
+		}
+		return isDisabled(n) == (p.name == "disabled")
+	case "required", "optional":
+		if !n.IsElement("input") && !n.IsElement("select") && !n.IsElement("textarea") {
+			return false
+		}
+		return n.HasAttr("required") == (p.name == "required")
+	case "link", "any-link":
+		return (n.IsElement("a") || n.IsElement("area")) && n.HasAttr("href")
+	}
+	return false
+}
+
+// disableableElements are the elements :disabled and :enabled apply to.
+var disableableElements = []string{"button", "input", "select", "textarea", "optgroup", "option", "fieldset"}
+
+// isDisabled reports whether a form element is disabled by its own
+// attribute or by a disabled <fieldset> ancestor.
+func isDisabled(n *Node) bool {
+	if n.HasAttr("disabled") {
+		return true
+	}
+	for p := n.Parent; p != nil; p = p.Parent {
+		if p.IsElement("fieldset") && p.HasAttr("disabled") {
+			return true
+		}
+	}
+	return false
+}
+
+// matchFrom reports whether an element related to scope by the leading
+// combinator matches the relative selector.
+func (r *relativeSelector) matchFrom(scope *Node) bool {
+	var anchor func(*Node) bool
+	switch r.combinator {
+	case '>':
+		anchor = func(m *Node) bool { return m.Parent == scope }
+	case '+':
+		anchor = func(m *Node) bool { return m.PreviousElementSibling() == scope }
+	case '~':
+		anchor = func(m *Node) bool {
+			for prev := m.PreviousElementSibling(); prev != nil; prev = prev.PreviousElementSibling() {
+				if prev == scope {
+					return true
+				}
+			}
+			return false
+		}
+	default:
+		anchor = func(m *Node) bool {
+			for p := m.Parent; p != nil; p = p.Parent {
+				if p == scope {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	// candidates are the descendants of scope, and for sibling
+	// combinators the following siblings and their descendants
+	roots := []*Node{scope}
+	if r.combinator == '+' || r.combinator == '~' {
+		roots = nil
+		for next := scope.NextElementSibling(); next != nil; next = next.NextElementSibling() {
+			roots = append(roots, next)
+		}
+	}
+	last := len(r.complex.parts) - 1
+	found := false
+	for _, root := range roots {
+		root.walk(func(m *Node) bool {
+			if m != scope && r.complex.match(last, m, anchor) {
+				found = true
+			}
+			return !found
+		})
+		if found {
+			return true
+		}
+	}
+	return false
+}
+
+// sameType matches elements with the tag name of n.
+func sameType(n *Node) func(*Node) bool {
+	return func(m *Node) bool { return strings.EqualFold(m.Data, n.Data) }
+}
+
+// siblingIndex returns the 1-based position of n among its element
+// siblings accepted by match, counting from the end when fromEnd is set.
+func siblingIndex(n *Node, fromEnd bool, match func(*Node) bool) int {
+	index := 1
+	next := (*Node).PreviousElementSibling
+	if fromEnd {
+		next = (*Node).NextElementSibling
+	}
+	for s := next(n); s != nil; s = next(s) {
+		if match(s) {
+			index++
+		}
+	}
+	return index
+}
+
+// nthMatches reports whether a 1-based index is An+B for some n >= 0.
+func nthMatches(a, b, index int) bool {
+	if a == 0 {
+		return index == b
+	}
+	diff := index - b
+	return diff%a == 0 && diff/a >= 0
+}
+
+// selectorParser is a small recursive-descent parser for CSS selectors.
+type selectorParser struct {
+	src string
+	pos int
+}
+
+func (p *selectorParser) eof() bool  { return p.pos >= len(p.src) }
+func (p *selectorParser) peek() byte { return p.src[p.pos] }
+
+func (p *selectorParser) syntaxError(msg string) error {
+	return errors.New("invalid selector '" + p.src + "': " + msg)
+}
+
+// unsupported returns an error wrapping ErrUnsupportedSelector.
+func (p *selectorParser) unsupported(msg string) error {
+	return errors.Join(p.syntaxError(msg), ErrUnsupportedSelector)
+}
+
+// unexpected reports the character at the current position.
+func (p *selectorParser) unexpected() error {
+	switch c := p.peek(); c {
+	case ']', ')':
+		return p.syntaxError("unbalanced brackets: unexpected '" + string(c) + "'")
+	default:
+		return p.syntaxError("invalid character '" + string(c) + "'")
+	}
+}
+
+func (p *selectorParser) skipSpace() bool {
+	start := p.pos
+	for !p.eof() && isSelectorSpace(p.peek()) {
+		p.pos++
+	}
+	return p.pos > start
+}
+
+func (p *selectorParser) parseList() (*Selector, error) {
+	s := &Selector{}
+	for {
+		p.skipSpace()
+		c, err := p.parseComplex()
+		if err != nil {
+			return nil, err
+		}
+		s.groups = append(s.groups, c)
+		p.skipSpace()
+		if p.eof() || p.peek() != ',' {
+			return s, nil
+		}
+		p.pos++ // consume ','
+	}
+}
+
+// parseRelativeList parses the argument of :has, whose selectors may start
+// with a combinator.
+func (p *selectorParser) parseRelativeList() ([]relativeSelector, error) {
+	var list []relativeSelector
+	for {
+		p.skipSpace()
+		r := relativeSelector{combinator: ' '}
+		if !p.eof() && strings.IndexByte(">+~", p.peek()) >= 0 {
+			r.combinator = p.peek()
+			p.pos++
+			p.skipSpace()
+		}
+		c, err := p.parseComplex()
+		if err != nil {
+			return nil, err
+		}
+		r.complex = c
+		list = append(list, r)
+		p.skipSpace()
+		if p.eof() || p.peek() != ',' {
+			return list, nil
+		}
+		p.pos++ // consume ','
+	}
+}
+
+func (p *selectorParser) parseComplex() (complexSelector, error) {
+	var c complexSelector
+
+	if !p.eof() && strings.IndexByte(">+~", p.peek()) >= 0 {
+		return c, p.syntaxError("starts with combinator '" + string(p.peek()) + "'")
+	}
+	first, err := p.parseCompound()
+	if err != nil {
+		return c, err
+	}
+	c.parts = append(c.parts, first)
+
+	for {
+		hadSpace := p.skipSpace()
+		if p.eof() || p.peek() == ',' || p.peek() == ')' {
+			return c, nil
+		}
+
+		comb := byte(' ')
+		switch p.peek() {
+		case '>', '+', '~':
+			comb = p.peek()
+			p.pos++
+			p.skipSpace()
+			if p.eof() || p.peek() == ',' || p.peek() == ')' {
+				return c, p.syntaxError("ends with combinator '" + string(comb) + "'")
+			}
+		default:
+			if !hadSpace {
+				return c, p.unexpected()
+			}
+		}
+
+		next, err := p.parseCompound()
+		if err != nil {
+			return c, err
+		}
+		c.combinators = append(c.combinators, comb)
+		c.parts = append(c.parts, next)
+	}
+}
+
+func (p *selectorParser) parseCompound() (compoundSelector, error) {
+	var c compoundSelector
+	start := p.pos
+
+	if !p.eof() && p.peek() == '*' {
+		c.tag = "*"
+		p.pos++
+	} else if name := p.parseIdent(); name != "" {
+		c.tag = strings.ToLower(name)
+	}
+
+	for !p.eof() {
+		switch p.peek() {
+		case '#':
+			p.pos++
+			id := p.parseIdent()
+			if id == "" {
+				return c, p.syntaxError("expected id after '#'")
+			}
+			c.ids = append(c.ids, id)
+		case '.':
+			p.pos++
+			class := p.parseIdent()
+			if class == "" {
+				return c, p.syntaxError("expected class name after '.'")
+			}
+			c.classes = append(c.classes, class)
+		case '[':
+			attr, err := p.parseAttr()
+			if err != nil {
+				return c, err
+			}
+			c.attrs = append(c.attrs, attr)
+		case ':':
+			pseudo, err := p.parsePseudo()
+			if err != nil {
+				return c, err
+			}
+			c.pseudos = append(c.pseudos, pseudo)
+		default:
+			if p.pos == start {
+				return c, p.unexpected()
+			}
+			return c, nil
+		}
+	}
+
+	if p.pos == start {
+		return c, p.syntaxError("expected selector")
+	}
+	return c, nil
+}
+
+func (p *selectorParser) parseAttr() (attrSelector, error) {
+	var a attrSelector
+	p.pos++ // consume '['
+	p.skipSpace()
+
+	a.name = strings.ToLower(p.parseIdent())
+	if a.name == "" {
+		if !p.eof() && p.peek() == ']' {
+			return a, p.syntaxError("empty attribute selector")
+		}
+		if p.eof() {
+			return a, p.syntaxError("unclosed bracket '['")
+		}
+		return a, p.syntaxError("expected attribute name")
+	}
+	p.skipSpace()
+	if p.eof() {
+		return a, p.syntaxError("unclosed bracket '['")
+	}
+
+	if p.peek() != ']' {
+		switch {
+		case p.peek() == '=':
+			a.op = "="
+			p.pos++
+		case strings.IndexByte("~|^$*", p.peek()) >= 0 && p.pos+1 < len(p.src) && p.src[p.pos+1] == '=':
+			a.op = p.src[p.pos : p.pos+2]
+			p.pos += 2
+		default:
+			return a, p.syntaxError("unexpected '" + string(p.peek()) + "' in attribute selector")
+		}
+
+		p.skipSpace()
+		val, err := p.parseValue()
+		if err != nil {
+			return a, err
+		}
+		a.value = val
+		p.skipSpace()
+
+		if !p.eof() && (p.peek() == 'i' || p.peek() == 'I') {
+			a.ignoreCase = true
+			p.pos++
+			p.skipSpace()
+		}
+	}
+
+	if p.eof() || p.peek() != ']' {
+		return a, p.syntaxError("unclosed bracket '['")
+	}
+	p.pos++
+	return a, nil
+}
+
+func (p *selectorParser) parseValue() (string, error) {
+	if p.eof() {
+		return "", p.syntaxError("expected attribute value")
+	}
+	quote := p.peek()
+	if quote != '"' && quote != '\'' {
+		val := p.parseIdent()
+		if val == "" {
+			return "", p.syntaxError("expected attribute value")
+		}
+		return val, nil
+	}
+
+	p.pos++
+	end := strings.IndexByte(p.src[p.pos:], quote)
+	if end < 0 {
+		return "", p.syntaxError("unterminated string")
+	}
+	val := p.src[p.pos : p.pos+end]
+	p.pos += end + 1
+	return val, nil
+}
+
+func (p *selectorParser) parsePseudo() (pseudoSelector, error) {
+	var ps pseudoSelector
+	p.pos++ // consume ':'
+	if !p.eof() && p.peek() == ':' {
+		p.pos++
+		return ps, p.unsupported("pseudo-element '::" + p.parseIdent() + "' never matches an element")
+	}
+
+	ps.name = strings.ToLower(p.parseIdent())
+	switch {
+	case simplePseudoClasses[ps.name]:
+		return ps, nil
+	case ps.name == "not" || ps.name == "is" || ps.name == "where":
+		if err := p.open(ps.name); err != nil {
+			return ps, err
+		}
+		inner, err := p.parseList()
+		if err != nil {
+			return ps, err
+		}
+		ps.list = inner
+		return ps, p.close(ps.name)
+	case ps.name == "has":
+		if err := p.open(ps.name); err != nil {
+			return ps, err
+		}
+		rel, err := p.parseRelativeList()
+		if err != nil {
+			return ps, err
+		}
+		ps.relative = rel
+		return ps, p.close(ps.name)
+	case strings.HasPrefix(ps.name, "nth-") && slices.Contains([]string{"nth-child", "nth-last-child", "nth-of-type", "nth-last-of-type"}, ps.name):
+		return ps, p.parseNth(&ps)
+	case dynamicPseudoClasses[ps.name]:
+		return ps, p.unsupported("pseudo-class ':" + ps.name + "' depends on user interaction or browser state")
+	case ps.name == "":
+		return ps, p.syntaxError("expected pseudo-class after ':'")
+	default:
+		return ps, p.syntaxError("unknown pseudo-class ':" + ps.name + "'")
+	}
+}
+
+// open consumes the '(' after a functional pseudo-class.
+func (p *selectorParser) open(name string) error {
+	if p.eof() || p.peek() != '(' {
+		return p.syntaxError("expected '(' after :" + name)
+	}
+	p.pos++
+	return nil
+}
+
+// close consumes the ')' ending a functional pseudo-class.
+func (p *selectorParser) close(name string) error {
+	p.skipSpace()
+	if p.eof() || p.peek() != ')' {
+		return p.syntaxError("unclosed :" + name + "(")
+	}
+	p.pos++
+	return nil
+}
+
+// parseNth parses the (An+B [of list]) argument of an :nth-* pseudo-class.
+// Only :nth-child and :nth-last-child take an "of" list.
+func (p *selectorParser) parseNth(ps *pseudoSelector) error {
+	if err := p.open(ps.name); err != nil {
+		return err
+	}
+	end := strings.IndexByte(p.src[p.pos:], ')')
+	if end < 0 {
+		return p.syntaxError("unclosed :" + ps.name + "(")
+	}
+	expr := p.src[p.pos : p.pos+end]
+	of := strings.Index(expr, " of ")
+	if of >= 0 {
+		expr = expr[:of]
+	}
+	a, b, ok := parseAnPlusB(expr)
+	if !ok {
+		return p.syntaxError("invalid :" + ps.name + " argument '" + strings.TrimSpace(expr) + "'")
+	}
+	ps.a, ps.b = a, b
+	if of < 0 {
+		p.pos += end
+		return p.close(ps.name)
+	}
+	if ps.name != "nth-child" && ps.name != "nth-last-child" {
+		return p.syntaxError(":" + ps.name + " does not take an 'of' list")
+	}
+	p.pos += of + len(" of ")
+	list, err := p.parseList()
+	if err != nil {
+		return err
+	}
+	ps.list = list
+	return p.close(ps.name)
+}
+
+// parseAnPlusB parses the An+B microsyntax, including odd and even.
+func parseAnPlusB(expr string) (a, b int, ok bool) {
+	s := strings.ToLower(strings.Join(strings.Fields(expr), ""))
+	switch s {
+	case "odd":
+		return 2, 1, true
+	case "even":
+		return 2, 0, true
+	case "":
+		return 0, 0, false
+	}
+	coef, rest, hasN := strings.Cut(s, "n")
+	if !hasN {
+		b, err := strconv.Atoi(s)
+		return 0, b, err == nil
+	}
+	switch coef {
+	case "", "+":
+		a = 1
+	case "-":
+		a = -1
+	default:
+		var err error
+		if a, err = strconv.Atoi(coef); err != nil {
+			return 0, 0, false
+		}
+	}
+	if rest == "" {
+		return a, 0, true
+	}
+	if rest[0] != '+' && rest[0] != '-' {
+		return 0, 0, false
+	}
+	b, err := strconv.Atoi(rest)
+	return a, b, err == nil
+}
+
+// parseIdent parses an identifier, resolving CSS escapes: a backslash
+// followed by up to six hex digits (and an optional space) or by any other
+// character.
+func (p *selectorParser) parseIdent() string {
+	var b strings.Builder
+	for !p.eof() {
+		c := p.peek()
+		switch {
+		case c == '\\' && p.pos+1 < len(p.src):
+			p.pos++
+			hex := 0
+			for hex < 6 && p.pos+hex < len(p.src) && isHexDigit(p.src[p.pos+hex]) {
+				hex++
+			}
+			if hex == 0 {
+				r, size := utf8.DecodeRuneInString(p.src[p.pos:])
+				b.WriteRune(r)
+				p.pos += size
+				continue
+			}
+			code, _ := strconv.ParseUint(p.src[p.pos:p.pos+hex], 16, 32)
+			b.WriteRune(rune(code))
+			p.pos += hex
+			if !p.eof() && isSelectorSpace(p.peek()) {
+				p.pos++
+			}
+		case isIdentChar(c):
+			b.WriteByte(c)
+			p.pos++
+		default:
+			return b.String()
+		}
+	}
+	return b.String()
+}
+
+func isIdentChar(c byte) bool {
+	return c == '-' || c == '_' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') ||
+		c >= 0x80
+}
+
+func isHexDigit(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func isSelectorSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
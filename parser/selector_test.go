@@ -0,0 +1,214 @@
+package parser_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/toba/go-html-validate/parser"
+)
+
+const selectorFixture = `<nav class="main nav" aria-label="Primary">
+<ul>
+<li id="first"><a href="/">Home</a></li>
+<li><a href="https://example.com/about" lang="en-US">About</a></li>
+<li class="active"><a href="#top" data-x="">Top</a></li>
+</ul>
+</nav>
+<main><p>Text</p><p class="lead"></p><span>After</span></main>`
+
+func TestDocument_QuerySelectorAll(t *testing.T) {
+	doc, err := parser.ParseFragment("test.html", []byte(selectorFixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		sel  string
+		want int
+	}{
+		{"li", 3},
+		{"*", 12},
+		{"#first", 1},
+		{".nav.main", 1},
+		{".nav.missing", 0},
+		{"nav a", 3},
+		{"ul > a", 0},
+		{"li > a", 3},
+		{"li + li", 2},
+		{"#first ~ li", 2},
+		{"p + span", 1},
+		{"a[href]", 3},
+		{`a[href^="https:"]`, 1},
+		{`a[href$="about"]`, 1},
+		{`a[href*=example]`, 1},
+		{`a[lang|=en]`, 1},
+		{`nav[class~=nav]`, 1},
+		{`nav[aria-label=primary i]`, 1},
+		{`a[data-x=""]`, 1},
+		{"li:first-child", 1},
+		{"li:last-child", 1},
+		{"a:only-child", 3},
+		{"p:empty", 1},
+		{"li:not(.active)", 2},
+		{"li:not(#first, .active)", 1},
+		{"nav, main", 2},
+		{"LI", 3},
+		{"li:nth-child(2)", 1},
+		{"li:nth-child(odd)", 2},
+		{"li:nth-child(2n+1 of :not(.active))", 1},
+		{"li:nth-last-child(-n+2)", 2},
+		{"p:first-of-type", 1},
+		{"p:last-of-type", 1},
+		{"span:only-of-type", 1},
+		{"p:nth-of-type(even)", 1},
+		{"nav:root, main:root", 2},
+		{"li:is(#first, .active) a", 2},
+		{"li:where(.active)", 1},
+		{"li:has(> a[data-x])", 1},
+		{"li:has(+ li)", 2},
+		{"ul:has(a[lang])", 1},
+		{"main:has(p ~ span)", 1},
+		{"a:any-link", 3},
+		{`a[href=\2f]`, 1},
+		{`#\66 irst`, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sel, func(t *testing.T) {
+			got := doc.QuerySelectorAll(tt.sel)
+			if len(got) != tt.want {
+				t.Errorf("QuerySelectorAll(%q) returned %d nodes, want %d", tt.sel, len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileSelector_Invalid(t *testing.T) {
+	tests := []string{
+		"",
+		"a >",
+		"> a",
+		"[]",
+		"[href",
+		`[href="x]`,
+		"a:hover",
+		"a:not(",
+		"#",
+		".",
+		"a,",
+		"a!b",
+		"a:unknown",
+		"li:nth-child(2x)",
+		"p:nth-of-type(1 of .a)",
+		"a:has()",
+		"a]",
+	}
+
+	for _, sel := range tests {
+		t.Run(sel, func(t *testing.T) {
+			if _, err := parser.CompileSelector(sel); err == nil {
+				t.Errorf("CompileSelector(%q) expected error", sel)
+			}
+		})
+	}
+}
+
+func TestCompileSelector_Unsupported(t *testing.T) {
+	tests := []struct {
+		sel         string
+		unsupported bool
+	}{
+		{"a:hover", true},
+		{"input:focus-visible", true},
+		{"p::before", true},
+		{"a:unknown", false},
+		{"a[", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sel, func(t *testing.T) {
+			_, err := parser.CompileSelector(tt.sel)
+			if err == nil {
+				t.Fatalf("CompileSelector(%q) expected error", tt.sel)
+			}
+			if got := errors.Is(err, parser.ErrUnsupportedSelector); got != tt.unsupported {
+				t.Errorf("CompileSelector(%q) unsupported = %v, want %v: %v", tt.sel, got, tt.unsupported, err)
+			}
+		})
+	}
+}
+
+func TestNode_Matches(t *testing.T) {
+	doc, err := parser.ParseFragment("test.html", []byte(selectorFixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	links := doc.QuerySelectorAll("a")
+	if len(links) != 3 {
+		t.Fatalf("expected 3 links, got %d", len(links))
+	}
+	if !links[0].Matches(`nav li#first > a[href="/"]`) {
+		t.Error("expected first link to match")
+	}
+	if links[1].Matches("li.active a") {
+		t.Error("expected second link not to match")
+	}
+	if links[2].Matches("a[") {
+		t.Error("invalid selector should not match")
+	}
+}
+
+func TestNode_Closest(t *testing.T) {
+	doc, err := parser.ParseFragment("test.html", []byte(selectorFixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	link := doc.QuerySelector("li.active > a")
+	if link == nil {
+		t.Fatal("expected QuerySelector to find the active link")
+	}
+	if got := link.Closest("li"); got == nil || got.GetAttr("class") != "active" {
+		t.Errorf("Closest(li) = %v, want the active item", got)
+	}
+	if got := link.Closest("a"); got != link {
+		t.Error("Closest should match the node itself")
+	}
+	if got := link.Closest("main"); got != nil {
+		t.Error("Closest(main) should find no ancestor")
+	}
+	if nav := doc.QuerySelector("nav"); nav.QuerySelector("li:last-child a") != link {
+		t.Error("Node.QuerySelector should find the active link")
+	}
+}
+
+func TestSelector_FormPseudoClasses(t *testing.T) {
+	doc, err := parser.ParseFragment("test.html", []byte(`<form>
+<input type="checkbox" checked required>
+<input type="text">
+<fieldset disabled><button>Go</button><select><option selected>A</option></select></fieldset>
+</form>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		sel  string
+		want int
+	}{
+		{":checked", 2},
+		{"input:required", 1},
+		{"input:optional", 1},
+		{":disabled", 4},
+		{":enabled", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sel, func(t *testing.T) {
+			if got := doc.QuerySelectorAll(tt.sel); len(got) != tt.want {
+				t.Errorf("QuerySelectorAll(%q) returned %d nodes, want %d", tt.sel, len(got), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"golang.org/x/net/html"
+)
+
+// Token is a token read by Stream: a start tag, end tag, self-closing tag,
+// text, comment, or doctype.
+type Token struct {
+	Type html.TokenType
+	// Data is the lowercase tag name, the unescaped text, the comment, or
+	// the doctype.
+	Data string
+	// Attrs lists a tag's attributes in source order, including duplicates.
+	Attrs []TokenAttr
+	// Line and Col locate the start of the token.
+	Line, Col int
+	// Open lists the names of the elements enclosing the token, outermost
+	// first. It is kept from start and end tags plus the common implied end
+	// tags (a new <li>, <p>-closing block, <dt>/<dd>, <option>, or table row
+	// or cell closes its unclosed predecessor), not the full tree
+	// construction rules. For an end tag it still includes the element
+	// being closed.
+	Open []string
+}
+
+// TokenAttr is an attribute of a Token.
+type TokenAttr struct {
+	Name      string // lowercase
+	Value     string // unescaped
+	Line, Col int
+}
+
+// Attr returns the value of the first attribute named name.
+func (t *Token) Attr(name string) (string, bool) {
+	for _, a := range t.Attrs {
+		if a.Name == name {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
+
+// AttrPos returns the position of the first attribute named name, or the
+// token's position when there is none.
+func (t *Token) AttrPos(name string) (line, col int) {
+	for _, a := range t.Attrs {
+		if a.Name == name {
+			return a.Line, a.Col
+		}
+	}
+	return t.Line, t.Col
+}
+
+// Stream tokenizes HTML from r and calls fn with each token, without
+// building a tree or keeping the input, so memory stays bounded by the
+// largest token rather than the file. The token passed to fn is reused
+// after fn returns. Template actions are not preprocessed; streaming is
+// meant for large rendered output such as HTML exports. Errors are
+// *ParseError values.
+func Stream(filename string, r io.Reader, fn func(*Token)) error {
+	z := html.NewTokenizer(bufio.NewReader(r))
+	var tok Token
+	line, col := 1, 1
+
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			if err := z.Err(); !errors.Is(err, io.EOF) {
+				return &ParseError{Filename: filename, Line: line, Col: col, Err: err}
+			}
+			return nil
+		}
+		// TagName and TagAttr rewrite the buffer behind raw, so positions
+		// are taken first.
+		raw := z.Raw()
+		nextLine, nextCol := advance(line, col, raw)
+
+		tok.Type = tt
+		tok.Data = ""
+		tok.Attrs = tok.Attrs[:0]
+		tok.Line, tok.Col = line, col
+
+		switch tt {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			_, rawAttrs := ScanTagAttrs(raw)
+			for _, ra := range rawAttrs {
+				a := TokenAttr{Name: ra.Name}
+				a.Line, a.Col = advance(line, col, raw[:ra.NameStart])
+				tok.Attrs = append(tok.Attrs, a)
+			}
+			name, more := z.TagName()
+			tok.Data = string(name)
+			n := 0
+			for ; more; n++ {
+				var key, val []byte
+				key, val, more = z.TagAttr()
+				if n >= len(tok.Attrs) {
+					tok.Attrs = append(tok.Attrs, TokenAttr{Line: line, Col: col})
+				}
+				tok.Attrs[n].Name, tok.Attrs[n].Value = string(key), string(val)
+			}
+			tok.Attrs = tok.Attrs[:n]
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			tok.Data = string(name)
+		case html.TextToken, html.CommentToken, html.DoctypeToken:
+			tok.Data = string(z.Text())
+		}
+
+		if tt == html.EndTagToken {
+			fn(&tok)
+			for i := len(tok.Open) - 1; i >= 0; i-- {
+				if tok.Open[i] == tok.Data {
+					tok.Open = tok.Open[:i]
+					break
+				}
+			}
+		} else {
+			fn(&tok)
+			if tt == html.StartTagToken && !voidElements[tok.Data] {
+				tok.Open = append(closeImplied(tok.Open, tok.Data), tok.Data)
+				if len(tok.Open) > MaxNestingDepth {
+					return &ParseError{Filename: filename, Line: tok.Line, Col: tok.Col,
+						Err: fmt.Errorf("%w (more than %d levels)", ErrNestingTooDeep, MaxNestingDepth)}
+				}
+			}
+		}
+
+		line, col = nextLine, nextCol
+	}
+}
+
+// impliedEnd describes the elements a start tag closes when they are
+// still open: the nearest of closes, searched outward until one of stops.
+type impliedEnd struct {
+	closes, stops []string
+}
+
+var (
+	tableScope  = []string{"table", "template", "html"}
+	buttonScope = []string{"applet", "button", "caption", "html", "marquee", "object", "table", "td", "template", "th"}
+
+	impliedEnds = map[string]impliedEnd{
+		"li":       {closes: []string{"li"}, stops: []string{"ol", "ul", "menu", "template"}},
+		"dt":       {closes: []string{"dt", "dd"}, stops: []string{"dl", "template"}},
+		"dd":       {closes: []string{"dt", "dd"}, stops: []string{"dl", "template"}},
+		"option":   {closes: []string{"option"}, stops: []string{"select", "datalist", "optgroup", "template"}},
+		"optgroup": {closes: []string{"optgroup", "option"}, stops: []string{"select", "template"}},
+		"tr":       {closes: []string{"tr"}, stops: append([]string{"thead", "tbody", "tfoot"}, tableScope...)},
+		"td":       {closes: []string{"td", "th"}, stops: append([]string{"tr"}, tableScope...)},
+		"th":       {closes: []string{"td", "th"}, stops: append([]string{"tr"}, tableScope...)},
+		"thead":    {closes: []string{"thead", "tbody", "tfoot"}, stops: tableScope},
+		"tbody":    {closes: []string{"thead", "tbody", "tfoot"}, stops: tableScope},
+		"tfoot":    {closes: []string{"thead", "tbody", "tfoot"}, stops: tableScope},
+	}
+
+	// closesP lists the start tags that end an open <p>.
+	closesP = map[string]bool{
+		"address": true, "article": true, "aside": true, "blockquote": true,
+		"dd": true, "details": true, "dialog": true, "dir": true, "div": true,
+		"dl": true, "dt": true, "fieldset": true, "figcaption": true,
+		"figure": true, "footer": true, "form": true, "h1": true, "h2": true,
+		"h3": true, "h4": true, "h5": true, "h6": true, "header": true,
+		"hgroup": true, "hr": true, "li": true, "main": true, "menu": true,
+		"nav": true, "ol": true, "p": true, "pre": true, "search": true,
+		"section": true, "summary": true, "table": true, "ul": true,
+	}
+)
+
+// closeImplied returns open without the elements that a start tag named
+// name implicitly closes, along with everything opened inside them.
+func closeImplied(open []string, name string) []string {
+	if closesP[name] {
+		open = closeNearest(open, impliedEnd{closes: []string{"p"}, stops: buttonScope})
+	}
+	if ie, ok := impliedEnds[name]; ok {
+		open = closeNearest(open, ie)
+	}
+	return open
+}
+
+// closeNearest truncates open before the innermost element in ie.closes,
+// unless an element in ie.stops encloses the token first.
+func closeNearest(open []string, ie impliedEnd) []string {
+	for i := len(open) - 1; i >= 0; i-- {
+		if slices.Contains(ie.closes, open[i]) {
+			return open[:i]
+		}
+		if slices.Contains(ie.stops, open[i]) {
+			break
+		}
+	}
+	return open
+}
+
+// advance returns the position after b, starting at line and col.
+func advance(line, col int, b []byte) (int, int) {
+	if n := bytes.Count(b, []byte("\n")); n > 0 {
+		return line + n, len(b) - bytes.LastIndexByte(b, '\n')
+	}
+	return line, col + len(b)
+}
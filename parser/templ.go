@@ -0,0 +1,443 @@
+package parser
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+)
+
+// IsTempl reports whether filename is a templ (https://templ.guide)
+// component file.
+func IsTempl(filename string) bool {
+	return strings.EqualFold(filepath.Ext(filename), ".templ")
+}
+
+// ProcessTempl extracts the HTML of the components in a templ file so it
+// can be parsed, returning a source map to the original positions.
+//
+// Replacement strategies:
+//   - Go code outside templ components → dropped
+//   - { expr } in text content → "TMPL"
+//   - attr={ expr } → attr="TMPL"; attr?={ expr } → attr
+//   - { attrs... } spread attributes → dropped
+//   - @component(...) calls → dropped, children blocks kept
+//   - {{ go code }} blocks and Go comments → dropped
+//   - if/else and switch statements → first branch kept only
+//   - for loops → single iteration content
+//
+// Like Process, dropped content is replaced by its newlines. <script> and
+// <style> content is kept as written.
+func ProcessTempl(input []byte) *SourceMap {
+	s := &templScanner{in: input}
+	s.m.out.Grow(len(input))
+	s.file()
+	return &SourceMap{
+		Original:  input,
+		Processed: s.m.out.Bytes(),
+		segments:  s.m.segments,
+	}
+}
+
+// templScanner walks a templ file, writing component markup to m.
+type templScanner struct {
+	in  []byte
+	pos int
+	m   sourceMapBuilder
+	// dropping counts the enclosing branches that are not kept
+	dropping int
+}
+
+// keep writes the input up to end, or only its newlines inside a dropped
+// branch.
+func (s *templScanner) keep(end int) {
+	end = min(end, len(s.in))
+	if s.dropping > 0 {
+		s.m.replace(newlines(s.in[s.pos:end]), s.pos)
+	} else {
+		s.m.copy(s.in[s.pos:end], s.pos)
+	}
+	s.pos = end
+}
+
+// skip drops the input up to end, keeping its newlines.
+func (s *templScanner) skip(end int) {
+	end = min(end, len(s.in))
+	s.m.replace(newlines(s.in[s.pos:end]), s.pos)
+	s.pos = end
+}
+
+// put writes b in place of the input at the current position.
+func (s *templScanner) put(b string) {
+	if s.dropping == 0 {
+		s.m.replace([]byte(b), s.pos)
+	}
+}
+
+// closeBrace drops the } closing a block.
+func (s *templScanner) closeBrace() {
+	if s.pos < len(s.in) && s.in[s.pos] == '}' {
+		s.skip(s.pos + 1)
+	}
+}
+
+// file drops Go code line by line, processing the body of each line
+// starting a templ component.
+func (s *templScanner) file() {
+	for s.pos < len(s.in) {
+		if bytes.HasPrefix(s.in[s.pos:], []byte("templ ")) {
+			if open := s.goEnd(s.pos+len("templ "), "{"); open < len(s.in) {
+				s.skip(open + 1)
+				s.nodes(false)
+				s.closeBrace()
+				continue
+			}
+		}
+		end := len(s.in)
+		if i := bytes.IndexByte(s.in[s.pos:], '\n'); i >= 0 {
+			end = s.pos + i + 1
+		}
+		s.skip(end)
+	}
+}
+
+// nodes processes template content up to the } closing its block, or in a
+// switch up to the next case clause.
+func (s *templScanner) nodes(inSwitch bool) {
+	for s.pos < len(s.in) {
+		i := s.pos
+		for i < len(s.in) && isTemplSpace(s.in[i]) {
+			i++
+		}
+		s.keep(i)
+		if s.pos >= len(s.in) {
+			return
+		}
+
+		rest := s.in[s.pos:]
+		statement := s.atLineStart()
+		switch {
+		case rest[0] == '}':
+			return
+		case inSwitch && statement && (hasTemplKeyword(rest, "case") || hasTemplKeyword(rest, "default")):
+			return
+		case rest[0] == '<':
+			s.markup()
+		case bytes.HasPrefix(rest, []byte("{{")):
+			end := s.goEnd(s.pos+2, "}")
+			s.skip(end + 2)
+		case rest[0] == '{':
+			end := s.goEnd(s.pos+1, "}")
+			s.put("TMPL")
+			s.skip(end + 1)
+		case rest[0] == '@':
+			s.call()
+		case statement && bytes.HasPrefix(rest, []byte("//")):
+			end := len(s.in)
+			if i := bytes.IndexByte(rest, '\n'); i >= 0 {
+				end = s.pos + i
+			}
+			s.skip(end)
+		case statement && bytes.HasPrefix(rest, []byte("/*")):
+			end := len(s.in)
+			if i := bytes.Index(rest, []byte("*/")); i >= 0 {
+				end = s.pos + i + 2
+			}
+			s.skip(end)
+		case statement && hasTemplKeyword(rest, "if"):
+			s.block("if")
+			s.elseBranches(func() { s.nodes(false) })
+		case statement && hasTemplKeyword(rest, "for"):
+			s.block("for")
+		case statement && hasTemplKeyword(rest, "switch"):
+			s.switchStatement()
+		default:
+			s.text()
+		}
+	}
+}
+
+// atLineStart reports whether only indentation or a block's opening {
+// precedes the current position on its line, where templ recognizes
+// statements.
+func (s *templScanner) atLineStart() bool {
+	i := s.pos - 1
+	for i >= 0 && (s.in[i] == ' ' || s.in[i] == '\t' || s.in[i] == '\r') {
+		i--
+	}
+	return i < 0 || s.in[i] == '\n' || s.in[i] == '{'
+}
+
+// text keeps text content up to the next tag, expression, or line.
+func (s *templScanner) text() {
+	i := s.pos + 1
+	for i < len(s.in) && !strings.ContainsRune("<{}\n", rune(s.in[i])) {
+		i++
+	}
+	s.keep(i)
+}
+
+// block drops the header of an if or for statement and processes its body.
+func (s *templScanner) block(keyword string) {
+	s.skip(s.goEnd(s.pos+len(keyword), "{") + 1)
+	s.nodes(false)
+	s.closeBrace()
+}
+
+// elseBranches drops the else if and else branches following an if
+// statement, using body to find where each branch ends.
+func (s *templScanner) elseBranches(body func()) {
+	for {
+		i := s.pos
+		for i < len(s.in) && isTemplSpace(s.in[i]) {
+			i++
+		}
+		if !hasTemplKeyword(s.in[i:], "else") {
+			return
+		}
+		s.skip(s.goEnd(i+len("else"), "{") + 1)
+		s.dropping++
+		body()
+		s.dropping--
+		s.closeBrace()
+	}
+}
+
+// switchStatement keeps the first case clause of a switch statement and
+// drops the others.
+func (s *templScanner) switchStatement() {
+	s.skip(s.goEnd(s.pos+len("switch"), "{") + 1)
+	for clause := 0; ; clause++ {
+		s.nodes(true)
+		if s.pos >= len(s.in) || s.in[s.pos] == '}' {
+			break
+		}
+		s.skip(s.goEnd(s.pos, ":") + 1)
+		if clause > 0 {
+			s.dropping++
+		}
+		s.nodes(true)
+		if clause > 0 {
+			s.dropping--
+		}
+	}
+	s.closeBrace()
+}
+
+// call drops a component call and processes its children block, if any.
+func (s *templScanner) call() {
+	end := s.goEnd(s.pos+1, " \t\r\n{}<")
+	i := end
+	for i < len(s.in) && (s.in[i] == ' ' || s.in[i] == '\t') {
+		i++
+	}
+	if i < len(s.in) && s.in[i] == '{' {
+		s.skip(i + 1)
+		s.nodes(false)
+		s.closeBrace()
+		return
+	}
+	s.skip(end)
+}
+
+// markup keeps a tag, comment, or doctype, rewriting templ attributes.
+func (s *templScanner) markup() {
+	rest := s.in[s.pos:]
+	switch {
+	case bytes.HasPrefix(rest, []byte("<!--")):
+		end := len(s.in)
+		if i := bytes.Index(rest[4:], []byte("-->")); i >= 0 {
+			end = s.pos + 4 + i + 3
+		}
+		s.keep(end)
+		return
+	case len(rest) < 2 || !isTemplNameStart(rest[1]):
+		// end tags, doctypes, and a stray <
+		end := s.pos + 1
+		if len(rest) > 1 && !isTemplSpace(rest[1]) {
+			if i := bytes.IndexByte(rest, '>'); i >= 0 {
+				end = s.pos + i + 1
+			}
+		}
+		s.keep(end)
+		return
+	}
+
+	i := s.pos + 1
+	for i < len(s.in) && isTemplNameChar(s.in[i]) {
+		i++
+	}
+	name := strings.ToLower(string(s.in[s.pos+1 : i]))
+	s.keep(i)
+	if !s.attributes(false) {
+		return
+	}
+
+	if name == "script" || name == "style" {
+		end := len(s.in)
+		if i := bytes.Index(bytes.ToLower(s.in[s.pos:]), []byte("</"+name)); i >= 0 {
+			end = s.pos + i
+		}
+		s.keep(end)
+	}
+}
+
+// attributes processes a start tag's attributes up to its > and reports
+// whether the tag was closed without />. Inside a conditional attribute
+// block (inBlock) it stops before the block's }.
+func (s *templScanner) attributes(inBlock bool) bool {
+	for s.pos < len(s.in) {
+		i := s.pos
+		for i < len(s.in) && isTemplSpace(s.in[i]) {
+			i++
+		}
+		s.keep(i)
+		if s.pos >= len(s.in) {
+			return false
+		}
+
+		rest := s.in[s.pos:]
+		switch {
+		case rest[0] == '>':
+			s.keep(s.pos + 1)
+			return true
+		case bytes.HasPrefix(rest, []byte("/>")):
+			s.keep(s.pos + 2)
+			return false
+		case rest[0] == '}' && inBlock:
+			return false
+		case rest[0] == '{':
+			// spread attributes
+			s.skip(s.goEnd(s.pos+1, "}") + 1)
+		case hasTemplKeyword(rest, "if"):
+			s.skip(s.goEnd(s.pos+len("if"), "{") + 1)
+			s.attributes(true)
+			s.closeBrace()
+			s.elseBranches(func() { s.attributes(true) })
+		default:
+			s.attribute()
+		}
+	}
+	return false
+}
+
+// attribute processes one attribute and its value.
+func (s *templScanner) attribute() {
+	i := s.pos
+	for i < len(s.in) && !isTemplSpace(s.in[i]) && !strings.ContainsRune("=>{}\"'", rune(s.in[i])) &&
+		!bytes.HasPrefix(s.in[i:], []byte("?=")) && !bytes.HasPrefix(s.in[i:], []byte("/>")) {
+		i++
+	}
+	if i == s.pos {
+		i++
+	}
+	s.keep(i)
+
+	rest := s.in[s.pos:]
+	switch {
+	case bytes.HasPrefix(rest, []byte("?={")):
+		s.skip(s.goEnd(s.pos+3, "}") + 1)
+	case bytes.HasPrefix(rest, []byte("={")):
+		s.keep(s.pos + 1)
+		end := s.goEnd(s.pos+1, "}")
+		s.put(`"TMPL"`)
+		s.skip(end + 1)
+	case bytes.HasPrefix(rest, []byte(`="`)), bytes.HasPrefix(rest, []byte("='")):
+		end := len(s.in)
+		if j := bytes.IndexByte(rest[2:], rest[1]); j >= 0 {
+			end = s.pos + 2 + j + 1
+		}
+		s.keep(end)
+	case len(rest) > 0 && rest[0] == '=':
+		j := s.pos + 1
+		for j < len(s.in) && !isTemplSpace(s.in[j]) && s.in[j] != '>' {
+			j++
+		}
+		s.keep(j)
+	}
+}
+
+// goEnd returns the index of the first byte from i that is one of stops,
+// outside brackets, strings, and comments, or len(s.in) if there is none.
+func (s *templScanner) goEnd(i int, stops string) int {
+	depth := 0
+	for i < len(s.in) {
+		c := s.in[i]
+		if depth == 0 && strings.IndexByte(stops, c) >= 0 {
+			return i
+		}
+		switch c {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			if depth > 0 {
+				depth--
+			}
+		case '"', '\'', '`':
+			i = skipGoString(s.in, i)
+			continue
+		case '/':
+			if bytes.HasPrefix(s.in[i:], []byte("//")) {
+				if j := bytes.IndexByte(s.in[i:], '\n'); j >= 0 {
+					i += j
+					continue
+				}
+				return len(s.in)
+			}
+			if bytes.HasPrefix(s.in[i:], []byte("/*")) {
+				if j := bytes.Index(s.in[i+2:], []byte("*/")); j >= 0 {
+					i += 2 + j + 2
+					continue
+				}
+				return len(s.in)
+			}
+		}
+		i++
+	}
+	return len(s.in)
+}
+
+// skipGoString returns the index after the Go string or rune literal
+// starting at i. An unterminated interpreted literal ends at its line.
+func skipGoString(b []byte, i int) int {
+	quote := b[i]
+	for j := i + 1; j < len(b); j++ {
+		switch {
+		case b[j] == '\\' && quote != '`':
+			j++
+		case b[j] == quote:
+			return j + 1
+		case b[j] == '\n' && quote != '`':
+			return j
+		}
+	}
+	return len(b)
+}
+
+// hasTemplKeyword reports whether b starts with keyword followed by a
+// space or, for else and default, by { or :.
+func hasTemplKeyword(b []byte, keyword string) bool {
+	if !bytes.HasPrefix(b, []byte(keyword)) || len(b) == len(keyword) {
+		return false
+	}
+	switch b[len(keyword)] {
+	case ' ', '\t':
+		return true
+	case '{':
+		return keyword == "else"
+	case ':':
+		return keyword == "default"
+	}
+	return false
+}
+
+func isTemplSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n'
+}
+
+func isTemplNameStart(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isTemplNameChar(c byte) bool {
+	return isTemplNameStart(c) || c >= '0' && c <= '9' || c == '-' || c == ':' || c == '.' || c == '_'
+}
@@ -0,0 +1,111 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/toba/go-html-validate/parser"
+)
+
+func TestProcessTempl(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "expressions",
+			input: `templ A(p Post) {<p title={ p.Title }>{ p.Body }</p>}`,
+			want:  `<p title="TMPL">TMPL</p>`,
+		},
+		{
+			name:  "braces in expression",
+			input: `templ A() {<p>{ fmt.Sprint(map[string]int{"}": 1}) }</p>}`,
+			want:  `<p>TMPL</p>`,
+		},
+		{
+			name:  "boolean and spread attributes",
+			input: `templ A(off bool) {<input type="text" disabled?={ off } { attrs... }/>}`,
+			want:  `<input type="text" disabled />`,
+		},
+		{
+			name:  "conditional attributes",
+			input: `templ A(ok bool) {<p if ok { class="a" } else { class="b" }>x</p>}`,
+			want:  `<p  class="a" >x</p>`,
+		},
+		{
+			name:  "if else",
+			input: `templ A(ok bool) {if ok {<b>a</b>} else if !ok {<i>b</i>} else {<u>c</u>}}`,
+			want:  `<b>a</b>`,
+		},
+		{
+			name:  "for",
+			input: "templ A(xs []string) {<ul>\nfor _, x := range xs {<li>{ x }</li>}</ul>}",
+			want:  "<ul>\n<li>TMPL</li></ul>",
+		},
+		{
+			name:  "switch",
+			input: "templ A(n int) {\nswitch n {\ncase 1:\n<b>one</b>\ndefault:\n<i>other</i>\n}\n}",
+			want:  "\n\n\n<b>one</b>\n\n\n\n",
+		},
+		{
+			name:  "component calls",
+			input: `templ A() {@B("x")@Layout() {<main></main>}}`,
+			want:  `<main></main>`,
+		},
+		{
+			name:  "Go code and comments",
+			input: "package views\n\nfunc f() int { return 1 }\n\ntempl A() {\n// note\n{{ n := f() }}<p>x</p>\n}\n",
+			want:  "\n\n\n\n\n\n<p>x</p>\n\n",
+		},
+		{
+			name:  "script kept as written",
+			input: `templ A() {<script>if (a) { b({ c: 1 }) }</script>}`,
+			want:  `<script>if (a) { b({ c: 1 }) }</script>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sm := parser.ProcessTempl([]byte(tt.input))
+			if string(sm.Processed) != tt.want {
+				t.Errorf("ProcessTempl() = %q, want %q", sm.Processed, tt.want)
+			}
+			if string(sm.Original) != tt.input {
+				t.Errorf("Original = %q, want input", sm.Original)
+			}
+		})
+	}
+}
+
+func TestParse_TemplPositions(t *testing.T) {
+	content := `package views
+
+templ Card(title string, href string) {
+	<div class="card">
+		if title != "" {
+			<h2>{ title }</h2>
+		}
+		<a href={ templ.SafeURL(href) } title={ title }>more</a>
+	</div>
+}
+`
+	doc, err := parser.ParseWithMode("card.templ", []byte(content), parser.ModeAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h2 := doc.QuerySelectorAll("h2")[0]
+	if h2.Line != 6 || h2.Col != 4 {
+		t.Errorf("h2 position = %d:%d, want 6:4", h2.Line, h2.Col)
+	}
+	a := doc.QuerySelectorAll("a")[0]
+	if a.Line != 8 || a.Col != 3 {
+		t.Errorf("a position = %d:%d, want 8:3", a.Line, a.Col)
+	}
+	if line, col := a.AttrPos("title"); line != 8 || col != 35 {
+		t.Errorf("AttrPos(title) = %d:%d, want 8:35", line, col)
+	}
+	if got := a.GetAttr("href"); got != "TMPL" {
+		t.Errorf("href = %q, want TMPL", got)
+	}
+}
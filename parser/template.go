@@ -3,19 +3,13 @@ package parser
 
 import (
 	"bytes"
+	"fmt"
 	"regexp"
+	"sort"
 )
 
-// templatePattern matches Go template syntax: {{ ... }}
-// Uses non-greedy matching to handle nested braces correctly.
-var templatePattern = regexp.MustCompile(`\{\{[\s\S]*?\}\}`)
-
-// ifElseEndPattern matches {{if ...}}...{{else}}...{{end}} blocks including nested.
-// Captures: full match with if-branch content kept, else-branch removed.
-var ifElseEndPattern = regexp.MustCompile(`(?s)\{\{-?\s*if\s[^}]*\}\}(.*?)\{\{-?\s*else\s*-?\}\}.*?\{\{-?\s*end\s*-?\}\}`)
-
-// ifEndPattern matches {{if ...}}...{{end}} blocks without else.
-var ifEndPattern = regexp.MustCompile(`(?s)\{\{-?\s*if\s[^}]*\}\}(.*?)\{\{-?\s*end\s*-?\}\}`)
+// keywordPattern extracts the leading keyword of an action body.
+var keywordPattern = regexp.MustCompile(`^[a-z]+`)
 
 // SourceMap tracks the mapping between processed and original source positions.
 // Used to report errors at their original line/column locations.
@@ -24,17 +18,92 @@ type SourceMap struct {
 	Original []byte
 	// Processed content with templates replaced
 	Processed []byte
+
+	// segments map runs of Processed to Original, ordered by processed
+	// offset; without segments the two are assumed to align
+	segments  []segment
+	origLines *lineIndex
+	procLines *lineIndex
+}
+
+// segment maps processed content from proc up to the next segment's start.
+// Copied text maps byte for byte from orig; replacement text (placeholders
+// and the newlines standing in for actions and dropped branches) maps
+// entirely to orig, the start of what it replaced.
+type segment struct {
+	proc, orig int
+	copied     bool
 }
 
 // OriginalPosition converts a position in processed content to original position.
 func (sm *SourceMap) OriginalPosition(line, col int) (origLine, origCol int) {
-	// For now, line numbers are preserved since we only replace inline content
-	// Column offsets would need more sophisticated tracking for accuracy
-	return line, col
+	if sm.segments == nil {
+		return line, col
+	}
+	if sm.procLines == nil {
+		sm.procLines = newLineIndex(sm.Processed)
+	}
+	return sm.offsetPosition(sm.procLines.offset(line, col, len(sm.Processed)))
+}
+
+// offsetPosition converts a processed-content offset to an original
+// line and column.
+func (sm *SourceMap) offsetPosition(offset int) (line, col int) {
+	if sm.segments == nil {
+		if sm.procLines == nil {
+			sm.procLines = newLineIndex(sm.Processed)
+		}
+		return sm.procLines.position(offset)
+	}
+	if sm.origLines == nil {
+		sm.origLines = newLineIndex(sm.Original)
+	}
+	return sm.origLines.position(sm.originalOffset(offset))
 }
 
-// Preprocessor handles Go template syntax in HTML files.
-type Preprocessor struct{}
+// originalOffset converts a processed-content offset to an original one.
+func (sm *SourceMap) originalOffset(offset int) int {
+	i := sort.Search(len(sm.segments), func(i int) bool { return sm.segments[i].proc > offset }) - 1
+	if i < 0 {
+		return offset
+	}
+	seg := sm.segments[i]
+	if !seg.copied {
+		return seg.orig
+	}
+	return min(seg.orig+offset-seg.proc, len(sm.Original))
+}
+
+// sourceMapBuilder writes processed content while recording its segments.
+type sourceMapBuilder struct {
+	out      bytes.Buffer
+	segments []segment
+}
+
+// copy writes b, which is original content starting at orig.
+func (m *sourceMapBuilder) copy(b []byte, orig int) {
+	m.write(b, orig, true)
+}
+
+// replace writes b in place of original content starting at orig.
+func (m *sourceMapBuilder) replace(b []byte, orig int) {
+	m.write(b, orig, false)
+}
+
+func (m *sourceMapBuilder) write(b []byte, orig int, copied bool) {
+	if len(b) == 0 {
+		return
+	}
+	m.segments = append(m.segments, segment{proc: m.out.Len(), orig: orig, copied: copied})
+	m.out.Write(b)
+}
+
+// Preprocessor handles Go, Jet, Pongo2, or Handlebars template syntax in
+// HTML files.
+type Preprocessor struct {
+	// Dialect is the template syntax to expect (DialectGo when zero)
+	Dialect Dialect
+}
 
 // NewPreprocessor creates a new template preprocessor.
 func NewPreprocessor() *Preprocessor {
@@ -49,75 +118,108 @@ func NewPreprocessor() *Preprocessor {
 //   - {{ .Field }} in attribute values → "tmpl" (keeps attribute valid)
 //   - {{if}}...{{else}}...{{end}} blocks → content of if-branch kept only
 //   - {{if}}...{{end}} blocks → content kept
-//   - {{range}}...{{end}} → single iteration content
+//   - {{range}}...{{else}}...{{end}} → single iteration content
 //   - {{template "name"}} → empty (included template not available)
-func (p *Preprocessor) Process(input []byte) ([]byte, *SourceMap, error) {
-	sm := &SourceMap{
-		Original: input,
-	}
-
-	// First, handle {{if}}...{{else}}...{{end}} blocks - keep only if-branch
-	processed := ifElseEndPattern.ReplaceAll(input, []byte("$1"))
+//
+// With DialectJet, {{ try }}...{{ catch }}...{{ end }} and yield content
+// blocks are matched like if/else, and {* comments *}, extends, import,
+// include, yield, and assignments are replaced with nothing. With
+// DialectPongo2, {% if %}/{% elif %}/{% else %}, {% for %}/{% empty %}, and
+// other block tags are matched the same way, {{ values }} and output tags
+// such as {% url %} become placeholders, and {# comments #}, {% comment %}
+// blocks, extends, include, and set are replaced with nothing. With
+// DialectHandlebars, {{#each}}...{{else}}...{{/each}} and other sections are
+// matched the same way, {{{triple-stash}}} output becomes a placeholder, and
+// {{! comments }} and {{> partials}} are replaced with nothing.
+//
+// Blocks are matched with a stack, so nested blocks and {{- -}} trim
+// markers are handled. Dropped content is replaced by its newlines so line
+// numbers match the original source. A panic on malformed input is returned
+// as an error wrapping ErrInternal.
+func (p *Preprocessor) Process(input []byte) (processed []byte, sm *SourceMap, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			processed, sm, err = nil, nil, fmt.Errorf("%w: %v", ErrInternal, r)
+		}
+	}()
+
+	sm = p.expand(input, p.Dialect.Actions(input), nil)
+	return sm.Processed, sm, nil
+}
 
-	// Then handle {{if}}...{{end}} without else - keep content
-	processed = ifEndPattern.ReplaceAll(processed, []byte("$1"))
+// expand renders input with template actions replaced. Blocks are numbered
+// in the order they open; keep maps a block number to the branch that is
+// kept (default 0, the if-branch or loop body). Other branches are dropped.
+func (p *Preprocessor) expand(input []byte, actions []Action, keep map[int]int) *SourceMap {
+	type openBlock struct {
+		branch, keep int
+	}
+	var stack []openBlock
+	dropping := 0 // open blocks whose current branch is dropped
+	blocks := 0
+
+	var m sourceMapBuilder
+	m.out.Grow(len(input))
+	emit := func(start, end int) {
+		if dropping > 0 {
+			m.replace(newlines(input[start:end]), start)
+		} else {
+			m.copy(input[start:end], start)
+		}
+	}
 
-	// Replace remaining template expressions with appropriate placeholders
-	processed = templatePattern.ReplaceAllFunc(processed, func(match []byte) []byte {
-		return p.replaceTemplate(match)
-	})
+	prev := 0
+	for _, a := range actions {
+		emit(prev, a.Start)
+		prev = a.End
+		raw := input[a.Start:a.End]
+
+		switch a.Kind {
+		case ActionOpen:
+			stack = append(stack, openBlock{keep: keep[blocks]})
+			blocks++
+			if stack[len(stack)-1].keep != 0 {
+				dropping++
+			}
+		case ActionElse:
+			if len(stack) > 0 {
+				top := &stack[len(stack)-1]
+				if top.branch != top.keep {
+					dropping--
+				}
+				top.branch++
+				if top.branch != top.keep {
+					dropping++
+				}
+			}
+		case ActionEnd:
+			if len(stack) > 0 {
+				top := stack[len(stack)-1]
+				if top.branch != top.keep {
+					dropping--
+				}
+				stack = stack[:len(stack)-1]
+			}
+		case ActionOutput:
+			// Replace with placeholder that works in most contexts
+			if dropping == 0 {
+				m.replace([]byte("TMPL"), a.Start)
+			}
+		}
+		m.replace(newlines(raw), a.Start)
+	}
+	emit(prev, len(input))
 
-	sm.Processed = processed
-	return processed, sm, nil
+	return &SourceMap{
+		Original:  input,
+		Processed: m.out.Bytes(),
+		segments:  m.segments,
+	}
 }
 
-// replaceTemplate determines the appropriate replacement for a template expression.
-func (p *Preprocessor) replaceTemplate(match []byte) []byte {
-	content := bytes.TrimSpace(match[2 : len(match)-2]) // Remove {{ and }}
-
-	// Handle different template constructs
-	switch {
-	case bytes.HasPrefix(content, []byte("/*")):
-		// Template comment: {{/* comment */}} → empty
-		return nil
-
-	case bytes.HasPrefix(content, []byte("if ")),
-		bytes.HasPrefix(content, []byte("if(")),
-		bytes.Equal(content, []byte("else")),
-		bytes.HasPrefix(content, []byte("else if")):
-		// Control flow: keep structure, remove directive
-		return nil
-
-	case bytes.HasPrefix(content, []byte("end")):
-		// End of block
-		return nil
-
-	case bytes.HasPrefix(content, []byte("range ")):
-		// Range loop start
-		return nil
-
-	case bytes.HasPrefix(content, []byte("template ")),
-		bytes.HasPrefix(content, []byte("block ")):
-		// Template inclusion
-		return nil
-
-	case bytes.HasPrefix(content, []byte("define ")):
-		// Template definition
-		return nil
-
-	case bytes.HasPrefix(content, []byte("with ")):
-		// With block
-		return nil
-
-	case bytes.HasPrefix(content, []byte("-")):
-		// Whitespace trimming prefix
-		return nil
-
-	default:
-		// Variable or function call: {{ .Field }} or {{ func .arg }}
-		// Replace with placeholder that works in most contexts
-		return []byte("TMPL")
-	}
+// newlines returns the line breaks in b, so dropped text keeps its lines.
+func newlines(b []byte) []byte {
+	return bytes.Repeat([]byte("\n"), bytes.Count(b, []byte("\n")))
 }
 
 // ProcessFile reads a file and processes its template content.
@@ -0,0 +1,381 @@
+package parser_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/toba/go-html-validate/parser"
+)
+
+func TestPreprocessor_Process(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "value",
+			input: `<p title="{{.Title}}">{{ .Body }}</p>`,
+			want:  `<p title="TMPL">TMPL</p>`,
+		},
+		{
+			name:  "trimmed value",
+			input: `<p>{{- .Body -}}</p>`,
+			want:  `<p>TMPL</p>`,
+		},
+		{
+			name:  "if else",
+			input: `{{if .A}}<b>a</b>{{else}}<i>b</i>{{end}}`,
+			want:  `<b>a</b>`,
+		},
+		{
+			name:  "nested if in if-branch",
+			input: `{{if .A}}{{if .B}}ab{{else}}a{{end}}{{else}}none{{end}}!`,
+			want:  `ab!`,
+		},
+		{
+			name:  "nested if in else-branch",
+			input: `{{if .A}}a{{else}}{{if .B}}b{{else}}c{{end}}{{end}}!`,
+			want:  `a!`,
+		},
+		{
+			name:  "else if chain",
+			input: `{{if .A}}a{{else if .B}}b{{else}}c{{end}}`,
+			want:  `a`,
+		},
+		{
+			name:  "range with else",
+			input: `<ul>{{range .Items}}<li>{{.}}</li>{{else}}<li>empty</li>{{end}}</ul>`,
+			want:  `<ul><li>TMPL</li></ul>`,
+		},
+		{
+			name:  "with and trim markers",
+			input: `{{- with .User -}}<b>{{ .Name }}</b>{{- else -}}guest{{- end -}}`,
+			want:  `<b>TMPL</b>`,
+		},
+		{
+			name:  "define and template",
+			input: `{{define "row"}}<tr>{{template "cell" .}}</tr>{{end}}`,
+			want:  `<tr></tr>`,
+		},
+		{
+			name:  "comments and loop control",
+			input: `{{/* note */}}{{range .}}{{if .Skip}}{{continue}}{{end}}x{{end}}`,
+			want:  `x`,
+		},
+		{
+			name:  "closing delimiter in strings",
+			input: `<p title="{{printf "}}%s" .A}}">{{if eq .B ` + "`}}`" + ` '}'}}b{{end}}</p>`,
+			want:  `<p title="TMPL">b</p>`,
+		},
+		{
+			name:  "closing delimiter in comment",
+			input: `{{- /* {{if}} */ -}}<p>{{/* a }} b */}}</p>`,
+			want:  `<p></p>`,
+		},
+		{
+			name:  "escaped quote",
+			input: `<p>{{printf "\"}}" .A}}</p>`,
+			want:  `<p>TMPL</p>`,
+		},
+		{
+			name:  "unclosed action",
+			input: `<p>{{.A</p>`,
+			want:  `<p>{{.A</p>`,
+		},
+		{
+			name:  "dropped lines preserved",
+			input: "{{if .A}}a{{else}}\nb\n{{end}}\n<p>",
+			want:  "a\n\n\n<p>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, sm, err := parser.NewPreprocessor().Process([]byte(tt.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Process() = %q, want %q", got, tt.want)
+			}
+			if string(sm.Original) != tt.input {
+				t.Errorf("Original = %q, want input", sm.Original)
+			}
+		})
+	}
+}
+
+func TestPreprocessor_ProcessJet(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "value and pipeline",
+			input: `<p title="{{ user.Name | upper }}">{{ .Body }}</p>`,
+			want:  `<p title="TMPL">TMPL</p>`,
+		},
+		{
+			name:  "extends, import, and block",
+			input: `{{ extends "layout.jet" }}{{ import "forms.jet" }}{{ block body() }}<main></main>{{ end }}`,
+			want:  `<main></main>`,
+		},
+		{
+			name:  "try catch",
+			input: `{{ try }}<b>{{ risky() }}</b>{{ catch err }}<i>{{ err }}</i>{{ end }}`,
+			want:  `<b>TMPL</b>`,
+		},
+		{
+			name:  "yield with content",
+			input: `{{ yield card(title="x") content }}<p>body</p>{{ end }}{{ yield footer() }}`,
+			want:  `<p>body</p>`,
+		},
+		{
+			name:  "comments and assignments",
+			input: `{* {{ if *}{{ total := len(items) }}{{ i = i + 1 }}<p>{{ total == 1 ? "one" : "many" }}</p>`,
+			want:  `<p>TMPL</p>`,
+		},
+		{
+			name:  "include and range else",
+			input: `<ul>{{ range i, item := items }}{{ include "row.jet" item }}{{ else }}<li>none</li>{{ end }}</ul>`,
+			want:  `<ul></ul>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &parser.Preprocessor{Dialect: parser.DialectJet}
+			got, _, err := p.Process([]byte(tt.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Process() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPreprocessor_ProcessPongo2(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "variables and filters",
+			input: `<p title="{{ user.name|upper }}">{{ body|safe }}</p>`,
+			want:  `<p title="TMPL">TMPL</p>`,
+		},
+		{
+			name:  "extends and block",
+			input: `{% extends "base.html" %}{% block content %}<main></main>{% endblock %}`,
+			want:  `<main></main>`,
+		},
+		{
+			name:  "if elif else",
+			input: `{% if a %}<b>a</b>{% elif b %}<i>b</i>{% else %}<u>c</u>{% endif %}`,
+			want:  `<b>a</b>`,
+		},
+		{
+			name:  "for empty",
+			input: `<ul>{% for x in xs %}<li>{{ x }}</li>{% empty %}<li>none</li>{% endfor %}</ul>`,
+			want:  `<ul><li>TMPL</li></ul>`,
+		},
+		{
+			name:  "comments",
+			input: `{# {% if #}{% comment %}<p>{% if x %}</p>{% endcomment %}<p>ok</p>`,
+			want:  `<p>ok</p>`,
+		},
+		{
+			name:  "output tags and set",
+			input: `{% set n = 1 %}<a href="{% url "home" %}">{% include "icon.html" %}</a>`,
+			want:  `<a href="TMPL"></a>`,
+		},
+		{
+			name:  "go keywords are values",
+			input: `{{ end }}<p>{{ if }}</p>`,
+			want:  `TMPL<p>TMPL</p>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &parser.Preprocessor{Dialect: parser.DialectPongo2}
+			got, _, err := p.Process([]byte(tt.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Process() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPreprocessor_ProcessHandlebars(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "values and triple-stash",
+			input: `<p title="{{ user.name }}">{{{ body }}}</p>`,
+			want:  `<p title="TMPL">TMPL</p>`,
+		},
+		{
+			name:  "if else if else",
+			input: `{{#if a}}<b>a</b>{{else if b}}<i>b</i>{{else}}<u>c</u>{{/if}}`,
+			want:  `<b>a</b>`,
+		},
+		{
+			name:  "each with inverse",
+			input: `<ul>{{#each items as |item|}}<li>{{item}}</li>{{^}}<li>none</li>{{/each}}</ul>`,
+			want:  `<ul><li>TMPL</li></ul>`,
+		},
+		{
+			name:  "mustache inverted section",
+			input: `{{^items}}<p>none</p>{{/items}}`,
+			want:  `<p>none</p>`,
+		},
+		{
+			name:  "partials and comments",
+			input: `{{> header title="x"}}{{!-- {{#if}} --}}{{! note }}{{#> layout}}<main></main>{{/layout}}`,
+			want:  `<main></main>`,
+		},
+		{
+			name:  "whitespace control",
+			input: `{{~#if a~}}<b>{{~ name ~}}</b>{{~/if~}}`,
+			want:  `<b>TMPL</b>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &parser.Preprocessor{Dialect: parser.DialectHandlebars}
+			got, _, err := p.Process([]byte(tt.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Process() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDialect_ForFile(t *testing.T) {
+	tests := []struct {
+		filename string
+		want     parser.Dialect
+	}{
+		{"page.html", parser.DialectGo},
+		{"page.jet", parser.DialectJet},
+		{"page.pongo2", parser.DialectPongo2},
+		{"views/PAGE.DJANGO", parser.DialectPongo2},
+		{"partials/row.hbs", parser.DialectHandlebars},
+		{"card.mustache", parser.DialectHandlebars},
+	}
+	for _, tt := range tests {
+		if got := parser.DialectGo.ForFile(tt.filename); got != tt.want {
+			t.Errorf("ForFile(%q) = %v, want %v", tt.filename, got, tt.want)
+		}
+	}
+}
+
+func TestDialect_Actions(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "delimiters in strings and character constants",
+			input: `{{if eq .A "}}{{" ` + "`}}`" + ` '}'}}a{{end}}`,
+			want:  []string{`{{if eq .A "}}{{" ` + "`}}`" + ` '}'}}`, `{{end}}`},
+		},
+		{
+			name:  "delimiters in comments",
+			input: `{{- /* {{if}} */ -}}<p>{{/* a }} b */}}</p>`,
+			want:  []string{`{{- /* {{if}} */ -}}`, `{{/* a }} b */}}`},
+		},
+		{
+			name:  "template names",
+			input: `{{define "a}}"}}x{{end}}{{template "a}}" .}}`,
+			want:  []string{`{{define "a}}"}}`, `{{end}}`, `{{template "a}}" .}}`},
+		},
+		{
+			name:  "block and trimmed text",
+			input: `{{block "b" .}} {{- .X -}} ` + "\n" + `{{end}}`,
+			want:  []string{`{{block "b" .}}`, `{{- .X -}}`, `{{end}}`},
+		},
+		{
+			name:  "parse error falls back to the lexer",
+			input: `{{if .A}}<p>{{printf "}}" .B}}`,
+			want:  []string{`{{if .A}}`, `{{printf "}}" .B}}`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, a := range parser.DialectGo.Actions([]byte(tt.input)) {
+				got = append(got, tt.input[a.Start:a.End])
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Actions() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSourceMap_OriginalPosition(t *testing.T) {
+	// Processed: `<p class="TMPL">TMPL <b>x</b></p>` on line 1, and the
+	// else-branch on line 2 dropped.
+	input := `<p class="{{ .Class }}">{{ .Name }} <b>x</b></p>{{if .A}}<i>a</i>{{else}}` + "\n" + `<u>b</u>{{end}}<em>y</em>`
+	_, sm, err := parser.NewPreprocessor().Process([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name              string
+		line, col         int
+		wantLine, wantCol int
+	}{
+		{"before any action", 1, 1, 1, 1},
+		{"inside placeholder", 1, 12, 1, 11},
+		{"after placeholder", 1, 16, 1, 24},
+		{"text placeholder", 1, 17, 1, 25},
+		{"shifted element", 1, 22, 1, 37},
+		{"kept branch", 1, 34, 1, 58},
+		{"after dropped branch", 2, 1, 2, 16},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line, col := sm.OriginalPosition(tt.line, tt.col)
+			if line != tt.wantLine || col != tt.wantCol {
+				t.Errorf("OriginalPosition(%d, %d) = %d:%d, want %d:%d", tt.line, tt.col, line, col, tt.wantLine, tt.wantCol)
+			}
+		})
+	}
+}
+
+func TestParse_PositionsAfterTemplates(t *testing.T) {
+	content := `<div class="{{ .Class }}">{{ .Greeting }}, {{ .Name }}! <a href="/x" title="{{ .Title }}">go</a></div>`
+	doc, err := parser.ParseFragment("test.html", []byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := doc.QuerySelectorAll("a")[0]
+	if a.Line != 1 || a.Col != 57 {
+		t.Errorf("a position = %d:%d, want 1:57", a.Line, a.Col)
+	}
+	if line, col := a.AttrPos("title"); line != 1 || col != 70 {
+		t.Errorf("AttrPos(title) = %d:%d, want 1:70", line, col)
+	}
+}
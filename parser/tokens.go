@@ -0,0 +1,106 @@
+package parser
+
+import (
+	"bytes"
+
+	"golang.org/x/net/html"
+)
+
+// RawToken is a token of the original source as written: tag and
+// attribute names keep their case, values keep their quotes and character
+// references, and duplicate attributes are all listed. It suits rules that
+// check spelling the parser normalizes away, such as quote style or tag
+// case, the way RawRule.CheckRaw sees the content.
+type RawToken struct {
+	Type html.TokenType
+	// Start and End are the byte offsets of the token in the content.
+	Start, End int
+	// Line and Col locate Start.
+	Line, Col int
+	// Name is a start or end tag's name as written, e.g. "DIV".
+	Name string
+	// Attrs lists a start tag's attributes in source order.
+	Attrs []RawTokenAttr
+}
+
+// RawTokenAttr is an attribute of a RawToken. Offsets are into the
+// content, not the tag.
+type RawTokenAttr struct {
+	// Name is the attribute name as written, e.g. "onClick".
+	Name string
+	// Value is the value as written, without quotes or unescaping; it is
+	// empty when the attribute has none (see HasValue).
+	Value    string
+	HasValue bool
+	// Quote is '"', '\'', or 0 for unquoted and missing values.
+	Quote                byte
+	NameStart, NameEnd   int
+	ValueStart, ValueEnd int
+	Line, Col            int
+}
+
+// Raw returns the token's text in content.
+func (t *RawToken) Raw(content []byte) []byte {
+	return content[t.Start:t.End]
+}
+
+// Tokens splits content into tokens as written, with byte offsets. Template
+// actions of the dialect for filename (see Dialect.ForFile; Go by default)
+// are kept in the tokens' text but do not split them, so a ">" inside an
+// action does not end a tag. templ files are tokenized as they are.
+func Tokens(filename string, content []byte) []RawToken {
+	masked := content
+	if !IsTempl(filename) {
+		masked = bytes.Clone(content)
+		for _, a := range DialectGo.ForFile(filename).Actions(content) {
+			for i := a.Start; i < a.End; i++ {
+				if masked[i] != '\n' {
+					masked[i] = 'x'
+				}
+			}
+		}
+	}
+
+	var tokens []RawToken
+	z := html.NewTokenizer(bytes.NewReader(masked))
+	offset, line, col := 0, 1, 1
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			return tokens
+		}
+		start := offset
+		offset += len(z.Raw())
+		raw := content[start:offset]
+
+		tok := RawToken{Type: tt, Start: start, End: offset, Line: line, Col: col}
+		switch tt {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			nameEnd, attrs := ScanTagAttrs(masked[start:offset])
+			tok.Name = string(raw[1:nameEnd])
+			for _, ra := range attrs {
+				a := RawTokenAttr{
+					Name:      string(raw[ra.NameStart:ra.NameEnd]),
+					Quote:     ra.Quote,
+					NameStart: start + ra.NameStart,
+					NameEnd:   start + ra.NameEnd,
+				}
+				a.Line, a.Col = advance(line, col, raw[:ra.NameStart])
+				if ra.ValueStart >= 0 {
+					a.HasValue = true
+					a.Value = string(raw[ra.ValueStart:ra.ValueEnd])
+					a.ValueStart, a.ValueEnd = start+ra.ValueStart, start+ra.ValueEnd
+				}
+				tok.Attrs = append(tok.Attrs, a)
+			}
+		case html.EndTagToken:
+			end := 2
+			for end < len(raw) && !IsSpace(raw[end]) && raw[end] != '/' && raw[end] != '>' {
+				end++
+			}
+			tok.Name = string(raw[2:end])
+		}
+		tokens = append(tokens, tok)
+		line, col = advance(line, col, raw)
+	}
+}
@@ -12,6 +12,8 @@ import (
 type JSON struct {
 	Writer io.Writer
 	Pretty bool
+	// GroupBy adds per-group summaries; GroupByOwner is the only grouping
+	GroupBy string
 }
 
 // NewJSON creates a JSON reporter writing to stdout.
@@ -24,18 +26,23 @@ func NewJSON() *JSON {
 
 // JSONResult is the JSON representation of a lint result.
 type JSONResult struct {
-	Rule     string `json:"rule"`
-	Message  string `json:"message"`
-	Filename string `json:"filename"`
-	Line     int    `json:"line"`
-	Column   int    `json:"column"`
-	Severity string `json:"severity"`
+	Rule      string `json:"rule"`
+	Message   string `json:"message"`
+	MessageID string `json:"messageId,omitempty"`
+	Filename  string `json:"filename"`
+	Line      int    `json:"line"`
+	Column    int    `json:"column"`
+	Severity  string `json:"severity"`
+
+	Meta map[string]string `json:"meta,omitempty"`
 }
 
 // JSONOutput is the top-level JSON structure.
 type JSONOutput struct {
 	Results []JSONResult `json:"results"`
 	Summary Summary      `json:"summary"`
+	GroupBy string       `json:"groupBy,omitempty"`
+	Groups  []Group      `json:"groups,omitempty"`
 }
 
 // Summary contains aggregate counts.
@@ -46,6 +53,19 @@ type Summary struct {
 	Info     int `json:"info"`
 }
 
+// add counts a result of severity sev.
+func (s *Summary) add(sev rules.Severity) {
+	s.Total++
+	switch sev {
+	case rules.Error:
+		s.Errors++
+	case rules.Warning:
+		s.Warnings++
+	case rules.Info:
+		s.Info++
+	}
+}
+
 // Report outputs results as JSON.
 func (j *JSON) Report(results []rules.Result) error {
 	output := JSONOutput{
@@ -54,23 +74,20 @@ func (j *JSON) Report(results []rules.Result) error {
 
 	for _, r := range results {
 		output.Results = append(output.Results, JSONResult{
-			Rule:     r.Rule,
-			Message:  r.Message,
-			Filename: r.Filename,
-			Line:     r.Line,
-			Column:   r.Col,
-			Severity: r.Severity.String(),
+			Rule:      r.Rule,
+			Message:   r.Message,
+			MessageID: r.MessageID,
+			Filename:  r.Filename,
+			Line:      r.Line,
+			Column:    r.Col,
+			Severity:  r.Severity.String(),
+			Meta:      r.Meta,
 		})
-
-		output.Summary.Total++
-		switch r.Severity {
-		case rules.Error:
-			output.Summary.Errors++
-		case rules.Warning:
-			output.Summary.Warnings++
-		case rules.Info:
-			output.Summary.Info++
-		}
+		output.Summary.add(r.Severity)
+	}
+	if j.GroupBy != "" {
+		output.GroupBy = j.GroupBy
+		output.Groups = GroupResults(results)
 	}
 
 	encoder := json.NewEncoder(j.Writer)
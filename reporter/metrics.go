@@ -0,0 +1,151 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/toba/go-html-validate/rules"
+)
+
+// DefaultDirDepth is how many leading path components of a finding's
+// directory the metrics reporter keeps, bounding label cardinality.
+const DefaultDirDepth = 2
+
+// Metrics outputs aggregate counts for dashboards instead of individual
+// findings: findings by rule, severity, and directory, files scanned, and
+// duration, in the Prometheus text format or as JSON.
+type Metrics struct {
+	Writer io.Writer
+	// Format is "prometheus" (default) or "json"
+	Format string
+	// DirDepth truncates directories to this many components; zero or less
+	// keeps them whole
+	DirDepth int
+
+	files   int
+	elapsed time.Duration
+}
+
+// NewMetrics creates a metrics reporter writing to stdout.
+func NewMetrics(format string) *Metrics {
+	return &Metrics{
+		Writer:   os.Stdout,
+		Format:   format,
+		DirDepth: DefaultDirDepth,
+	}
+}
+
+// MetricsSeries counts findings with the same rule, severity, and
+// directory.
+type MetricsSeries struct {
+	Rule      string `json:"rule"`
+	Severity  string `json:"severity"`
+	Directory string `json:"directory"`
+	Count     int    `json:"count"`
+}
+
+// MetricsOutput is the JSON structure of the metrics reporter.
+type MetricsOutput struct {
+	Timestamp       string          `json:"timestamp"`
+	FilesScanned    int             `json:"filesScanned"`
+	DurationSeconds float64         `json:"durationSeconds"`
+	Summary         Summary         `json:"summary"`
+	Findings        []MetricsSeries `json:"findings"`
+}
+
+// ReportStats records the number of files linted and the run time.
+func (m *Metrics) ReportStats(files int, elapsed time.Duration) {
+	m.files = files
+	m.elapsed = elapsed
+}
+
+// Report outputs the aggregated metrics.
+func (m *Metrics) Report(results []rules.Result) error {
+	series := m.series(results)
+	if m.Format == "json" {
+		output := MetricsOutput{
+			Timestamp:       time.Now().UTC().Format(time.RFC3339),
+			FilesScanned:    m.files,
+			DurationSeconds: m.elapsed.Seconds(),
+			Findings:        series,
+		}
+		for _, r := range results {
+			output.Summary.add(r.Severity)
+		}
+		encoder := json.NewEncoder(m.Writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	}
+
+	var b strings.Builder
+	b.WriteString("# HELP htmlint_findings Lint findings by rule, severity, and directory.\n")
+	b.WriteString("# TYPE htmlint_findings gauge\n")
+	for _, s := range series {
+		fmt.Fprintf(&b, "htmlint_findings{rule=%s,severity=%s,directory=%s} %d\n",
+			promLabel(s.Rule), promLabel(s.Severity), promLabel(s.Directory), s.Count)
+	}
+	b.WriteString("# HELP htmlint_files_scanned Files linted.\n")
+	b.WriteString("# TYPE htmlint_files_scanned gauge\n")
+	fmt.Fprintf(&b, "htmlint_files_scanned %d\n", m.files)
+	b.WriteString("# HELP htmlint_duration_seconds Time taken by the lint run.\n")
+	b.WriteString("# TYPE htmlint_duration_seconds gauge\n")
+	fmt.Fprintf(&b, "htmlint_duration_seconds %g\n", m.elapsed.Seconds())
+	_, err := io.WriteString(m.Writer, b.String())
+	return err
+}
+
+// series counts results by rule, severity, and directory, sorted by those
+// labels.
+func (m *Metrics) series(results []rules.Result) []MetricsSeries {
+	counts := make(map[MetricsSeries]int)
+	for _, r := range results {
+		key := MetricsSeries{Rule: r.Rule, Severity: r.Severity.String(), Directory: m.directory(r.Filename)}
+		counts[key]++
+	}
+	series := make([]MetricsSeries, 0, len(counts))
+	for key, n := range counts {
+		key.Count = n
+		series = append(series, key)
+	}
+	sort.Slice(series, func(i, j int) bool {
+		a, b := series[i], series[j]
+		if a.Rule != b.Rule {
+			return a.Rule < b.Rule
+		}
+		if a.Severity != b.Severity {
+			return a.Severity < b.Severity
+		}
+		return a.Directory < b.Directory
+	})
+	return series
+}
+
+// directory returns the slash-separated directory of filename, truncated
+// to DirDepth components.
+func (m *Metrics) directory(filename string) string {
+	dir := filepath.ToSlash(filepath.Dir(filename))
+	if m.DirDepth <= 0 {
+		return dir
+	}
+	parts := strings.Split(dir, "/")
+	limit := m.DirDepth
+	if parts[0] == "" { // absolute path
+		limit++
+	}
+	if len(parts) > limit {
+		parts = parts[:limit]
+	}
+	return strings.Join(parts, "/")
+}
+
+// promLabel quotes a Prometheus label value.
+func promLabel(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
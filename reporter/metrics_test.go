@@ -0,0 +1,81 @@
+package reporter_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/toba/go-html-validate/reporter"
+	"github.com/toba/go-html-validate/rules"
+)
+
+var metricsResults = []rules.Result{
+	{Rule: "img-alt", Severity: rules.Error, Filename: "/srv/site/web/pages/index.html"},
+	{Rule: "img-alt", Severity: rules.Error, Filename: "/srv/site/web/about.html"},
+	{Rule: "img-alt", Severity: rules.Error, Filename: "web/pages/deep/home.html"},
+	{Rule: "no-inline-style", Severity: rules.Info, Filename: `odd"dir\name/page.html`},
+}
+
+func TestMetrics_Prometheus(t *testing.T) {
+	var b strings.Builder
+	m := &reporter.Metrics{Writer: &b, DirDepth: 2}
+	m.ReportStats(3, 1500*time.Millisecond)
+	if err := m.Report(metricsResults); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP htmlint_findings Lint findings by rule, severity, and directory.
+# TYPE htmlint_findings gauge
+htmlint_findings{rule="img-alt",severity="error",directory="/srv/site"} 2
+htmlint_findings{rule="img-alt",severity="error",directory="web/pages"} 1
+htmlint_findings{rule="no-inline-style",severity="info",directory="odd\"dir\\name"} 1
+# HELP htmlint_files_scanned Files linted.
+# TYPE htmlint_files_scanned gauge
+htmlint_files_scanned 3
+# HELP htmlint_duration_seconds Time taken by the lint run.
+# TYPE htmlint_duration_seconds gauge
+htmlint_duration_seconds 1.5
+`
+	if got := b.String(); got != want {
+		t.Errorf("Report() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestMetrics_JSON(t *testing.T) {
+	var b strings.Builder
+	m := &reporter.Metrics{Writer: &b, Format: "json", DirDepth: 2}
+	m.ReportStats(3, 1500*time.Millisecond)
+	if err := m.Report(metricsResults); err != nil {
+		t.Fatal(err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal([]byte(b.String()), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, b.String())
+	}
+	if ts, _ := got["timestamp"].(string); ts == "" {
+		t.Errorf("timestamp = %v, want an RFC 3339 time", got["timestamp"])
+	} else if _, err := time.Parse(time.RFC3339, ts); err != nil {
+		t.Errorf("timestamp %q: %v", ts, err)
+	}
+	delete(got, "timestamp")
+
+	var want map[string]any
+	if err := json.Unmarshal([]byte(`{
+		"filesScanned": 3,
+		"durationSeconds": 1.5,
+		"summary": {"total": 4, "errors": 3, "warnings": 0, "info": 1},
+		"findings": [
+			{"rule": "img-alt", "severity": "error", "directory": "/srv/site", "count": 2},
+			{"rule": "img-alt", "severity": "error", "directory": "web/pages", "count": 1},
+			{"rule": "no-inline-style", "severity": "info", "directory": "odd\"dir\\name", "count": 1}
+		]
+	}`), &want); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Report() =\n%s\nwant fields %v", b.String(), want)
+	}
+}
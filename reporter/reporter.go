@@ -2,6 +2,10 @@
 package reporter
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/toba/go-html-validate/rules"
 )
 
@@ -9,3 +13,61 @@ import (
 type Reporter interface {
 	Report(results []rules.Result) error
 }
+
+// GroupByOwner groups the summary by the owners in Result.Meta, as set by
+// linter.CodeOwners.
+const GroupByOwner = "owner"
+
+// Unowned is the group of results without owners.
+const Unowned = "(unowned)"
+
+// Group summarizes the results that share a key.
+type Group struct {
+	Key string `json:"key"`
+	Summary
+}
+
+// ParseGroupBy validates a --group-by value.
+func ParseGroupBy(s string) (string, error) {
+	if s == "" || s == GroupByOwner {
+		return s, nil
+	}
+	return "", fmt.Errorf("invalid group-by %q (supported: owner)", s)
+}
+
+// GroupResults summarizes results per owner. A result whose file has
+// several owners counts toward each of them. Groups with the most errors,
+// then warnings, come first.
+func GroupResults(results []rules.Result) []Group {
+	byKey := make(map[string]*Summary)
+	for _, r := range results {
+		owners := strings.Fields(r.Meta[rules.MetaOwner])
+		if len(owners) == 0 {
+			owners = []string{Unowned}
+		}
+		for _, owner := range owners {
+			s := byKey[owner]
+			if s == nil {
+				s = &Summary{}
+				byKey[owner] = s
+			}
+			s.add(r.Severity)
+		}
+	}
+
+	groups := make([]Group, 0, len(byKey))
+	for key, s := range byKey {
+		groups = append(groups, Group{Key: key, Summary: *s})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		a, b := groups[i], groups[j]
+		if a.Errors != b.Errors {
+			return a.Errors > b.Errors
+		}
+		if a.Warnings != b.Warnings {
+			return a.Warnings > b.Warnings
+		}
+		return a.Key < b.Key
+	})
+	return groups
+}
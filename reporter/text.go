@@ -6,23 +6,36 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
 
 	"github.com/toba/go-html-validate/rules"
 )
 
+// DefaultMaxMessageLen is the message length above which the text reporter
+// shortens messages.
+const DefaultMaxMessageLen = 300
+
 // Text outputs human-readable lint results.
 type Text struct {
 	Writer    io.Writer
 	NoColor   bool
 	ShowRules bool // Include rule name in output
+	// MaxMessageLen shortens longer messages, which can quote long runs of
+	// a minified file, in the middle; zero means no limit
+	MaxMessageLen int
+	// GroupBy adds per-group counts after the summary; GroupByOwner is the
+	// only grouping
+	GroupBy string
 }
 
 // NewText creates a text reporter writing to stdout.
 func NewText() *Text {
 	return &Text{
-		Writer:    os.Stdout,
-		NoColor:   false,
-		ShowRules: true,
+		Writer:        os.Stdout,
+		NoColor:       false,
+		ShowRules:     true,
+		MaxMessageLen: DefaultMaxMessageLen,
 	}
 }
 
@@ -85,22 +98,51 @@ func (t *Text) Report(results []rules.Result) error {
 		_, _ = fmt.Fprintf(t.Writer, "Found %s\n", strings.Join(parts, ", "))
 	}
 
+	if t.GroupBy != "" {
+		_, _ = fmt.Fprintf(t.Writer, "\nBy %s:\n", t.GroupBy)
+		w := tabwriter.NewWriter(t.Writer, 0, 0, 2, ' ', 0)
+		for _, g := range GroupResults(results) {
+			_, _ = fmt.Fprintf(w, "  %s\t%d error(s)\t%d warning(s)\t%d info\n", g.Key, g.Errors, g.Warnings, g.Info)
+		}
+		_ = w.Flush()
+	}
+
 	return nil
 }
 
 func (t *Text) formatResult(r rules.Result) string {
-	// Format: file:line:col: severity: message [rule]
+	// Format: file:line:col: severity: message [rule] (owners)
 	severity := r.Severity.String()
 	if !t.NoColor {
 		severity = t.colorize(severity, r.Severity)
 	}
 
+	message := shorten(r.Message, t.MaxMessageLen)
 	if t.ShowRules {
-		return fmt.Sprintf("%s:%d:%d: %s: %s [%s]",
-			r.Filename, r.Line, r.Col, severity, r.Message, r.Rule)
+		message += " [" + r.Rule + "]"
+	}
+	if owner := r.Meta[rules.MetaOwner]; owner != "" {
+		message += " (" + owner + ")"
 	}
 	return fmt.Sprintf("%s:%d:%d: %s: %s",
-		r.Filename, r.Line, r.Col, severity, r.Message)
+		r.Filename, r.Line, r.Col, severity, message)
+}
+
+// shorten replaces the middle of s with an ellipsis when s is longer than
+// maxLen bytes, cutting at rune boundaries.
+func shorten(s string, maxLen int) string {
+	if maxLen <= 0 || len(s) <= maxLen {
+		return s
+	}
+	head := maxLen / 2
+	tail := len(s) - (maxLen - head)
+	for head > 0 && !utf8.RuneStart(s[head]) {
+		head--
+	}
+	for tail < len(s) && !utf8.RuneStart(s[tail]) {
+		tail++
+	}
+	return s[:head] + fmt.Sprintf(" … (%d bytes omitted) … ", tail-head) + s[tail:]
 }
 
 func (t *Text) colorize(text string, severity rules.Severity) string {
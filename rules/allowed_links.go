@@ -1,6 +1,8 @@
 package rules
 
 import (
+	"net/url"
+	"slices"
 	"strings"
 
 	"github.com/toba/go-html-validate/parser"
@@ -8,7 +10,18 @@ import (
 )
 
 // AllowedLinks checks that link hrefs are valid.
-type AllowedLinks struct{}
+//
+// Options:
+//   - "allow-protocols": only these schemes are permitted in absolute links
+//   - "deny-protocols": schemes that are never permitted (e.g. "ftp", "sms")
+//   - "https-hosts": hosts that must be linked with https (e.g. "*.example.com")
+//   - "own-domains": the site's own hosts; absolute links to them should be relative
+type AllowedLinks struct {
+	AllowProtocols []string
+	DenyProtocols  []string
+	HTTPSHosts     []string
+	OwnDomains     []string
+}
 
 // Name returns the rule identifier.
 func (r *AllowedLinks) Name() string { return RuleAllowedLinks }
@@ -18,6 +31,23 @@ func (r *AllowedLinks) Description() string {
 	return "links must have valid href values"
 }
 
+// ConfigureOptions applies protocol and host options.
+func (r *AllowedLinks) ConfigureOptions(opts map[string]any) {
+	r.AllowProtocols = normalizeProtocols(StringsOption(opts, "allow-protocols", r.AllowProtocols))
+	r.DenyProtocols = normalizeProtocols(StringsOption(opts, "deny-protocols", r.DenyProtocols))
+	r.HTTPSHosts = StringsOption(opts, "https-hosts", r.HTTPSHosts)
+	r.OwnDomains = StringsOption(opts, "own-domains", r.OwnDomains)
+}
+
+// normalizeProtocols lowercases schemes and strips trailing colons.
+func normalizeProtocols(protocols []string) []string {
+	normalized := make([]string, 0, len(protocols))
+	for _, p := range protocols {
+		normalized = append(normalized, strings.TrimSuffix(strings.ToLower(strings.TrimSpace(p)), ":"))
+	}
+	return normalized
+}
+
 // Check examines the document for problematic link hrefs.
 func (r *AllowedLinks) Check(doc *parser.Document) []Result {
 	var results []Result
@@ -42,12 +72,13 @@ func (r *AllowedLinks) Check(doc *parser.Document) []Result {
 		// Check for javascript: protocol (security risk)
 		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(href)), "javascript:") {
 			results = append(results, Result{
-				Rule:     RuleAllowedLinks,
-				Message:  "javascript: URLs are not allowed",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Error,
+				Rule:      RuleAllowedLinks,
+				Message:   catalogMessage("allowed-links.javascript", nil),
+				MessageID: "allowed-links.javascript",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Error,
 			})
 			return true
 		}
@@ -55,12 +86,13 @@ func (r *AllowedLinks) Check(doc *parser.Document) []Result {
 		// Check for vbscript: protocol (security risk)
 		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(href)), "vbscript:") {
 			results = append(results, Result{
-				Rule:     RuleAllowedLinks,
-				Message:  "vbscript: URLs are not allowed",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Error,
+				Rule:      RuleAllowedLinks,
+				Message:   catalogMessage("allowed-links.vbscript", nil),
+				MessageID: "allowed-links.vbscript",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Error,
 			})
 			return true
 		}
@@ -68,12 +100,13 @@ func (r *AllowedLinks) Check(doc *parser.Document) []Result {
 		// Check for data: URLs in links (potential security risk)
 		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(href)), "data:") {
 			results = append(results, Result{
-				Rule:     RuleAllowedLinks,
-				Message:  "data: URLs in links are discouraged",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Warning,
+				Rule:      RuleAllowedLinks,
+				Message:   catalogMessage("allowed-links.data", nil),
+				MessageID: "allowed-links.data",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
 			})
 			return true
 		}
@@ -81,18 +114,79 @@ func (r *AllowedLinks) Check(doc *parser.Document) []Result {
 		// Check for empty href (common mistake)
 		if href == "" && n.HasAttr("href") {
 			results = append(results, Result{
-				Rule:     RuleAllowedLinks,
-				Message:  "empty href is often a mistake; use # for placeholder links",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Info,
+				Rule:      RuleAllowedLinks,
+				Message:   catalogMessage("allowed-links.empty", nil),
+				MessageID: "allowed-links.empty",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Info,
 			})
 			return true
 		}
 
+		if m, sev := r.checkURL(href); m.id != "" {
+			line, col := n.AttrPos("href")
+			results = append(results, Result{
+				Rule:      RuleAllowedLinks,
+				Message:   m.text(),
+				MessageID: m.id,
+				Params:    m.params,
+				Filename:  doc.Filename,
+				Line:      line,
+				Col:       col,
+				Severity:  sev,
+			})
+		}
+
 		return true
 	})
 
 	return results
 }
+
+// checkURL applies the configured protocol and host policies to href.
+func (r *AllowedLinks) checkURL(href string) (message, Severity) {
+	u, err := url.Parse(strings.TrimSpace(href))
+	if err != nil {
+		return message{}, Info
+	}
+	scheme := strings.ToLower(u.Scheme)
+
+	if scheme != "" {
+		if slices.Contains(r.DenyProtocols, scheme) ||
+			(len(r.AllowProtocols) > 0 && !slices.Contains(r.AllowProtocols, scheme)) {
+			return message{"allowed-links.protocol", map[string]any{"scheme": scheme}}, Error
+		}
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if host == "" {
+		return message{}, Info
+	}
+	if scheme == "http" && matchHost(host, r.HTTPSHosts) {
+		return message{"allowed-links.https", map[string]any{"host": host}}, Warning
+	}
+	if matchHost(host, r.OwnDomains) {
+		return message{"allowed-links.own-domain", map[string]any{"host": host}}, Warning
+	}
+	return message{}, Info
+}
+
+// matchHost reports whether host matches any pattern. A leading "*."
+// matches any subdomain.
+func matchHost(host string, patterns []string) bool {
+	for _, p := range patterns {
+		p = strings.ToLower(p)
+		if suffix, ok := strings.CutPrefix(p, "*."); ok {
+			if strings.HasSuffix(host, "."+suffix) {
+				return true
+			}
+			continue
+		}
+		if host == p {
+			return true
+		}
+	}
+	return false
+}
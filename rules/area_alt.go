@@ -34,12 +34,13 @@ func (r *AreaAlt) Check(doc *parser.Document) []Result {
 		// Check for alt attribute
 		if !n.HasAttr("alt") {
 			results = append(results, Result{
-				Rule:     RuleAreaAlt,
-				Message:  "area element with href must have alt attribute",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Error,
+				Rule:      RuleAreaAlt,
+				Message:   catalogMessage("area-alt.missing", nil),
+				MessageID: "area-alt.missing",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Error,
 			})
 			return true
 		}
@@ -49,12 +50,13 @@ func (r *AreaAlt) Check(doc *parser.Document) []Result {
 		if alt == "" || alt == TemplateExprPlaceholder {
 			// Empty alt is a warning - may be intentional for redundant areas
 			results = append(results, Result{
-				Rule:     RuleAreaAlt,
-				Message:  "area element has empty alt attribute",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Warning,
+				Rule:      RuleAreaAlt,
+				Message:   catalogMessage("area-alt.empty", nil),
+				MessageID: "area-alt.empty",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
 			})
 		}
 
@@ -0,0 +1,78 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// AriaAllowedValues checks that enumerated ARIA attributes such as
+// aria-current, aria-haspopup, and aria-selected use one of their allowed
+// tokens. Assistive technology ignores an unknown token, so
+// aria-current="yes" or aria-haspopup="popup" silently has no effect.
+type AriaAllowedValues struct{}
+
+// Name returns the rule identifier.
+func (r *AriaAllowedValues) Name() string { return RuleAriaAllowedValues }
+
+// Description returns what this rule checks.
+func (r *AriaAllowedValues) Description() string {
+	return "enumerated ARIA attributes must have allowed values"
+}
+
+// Check examines the document for enumerated ARIA attributes with values
+// outside ARIAAttributeValues. Empty values mean the default and template
+// values are unknown, so neither is reported.
+func (r *AriaAllowedValues) Check(doc *parser.Document) []Result {
+	var results []Result
+
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+
+		for _, attr := range n.Attr {
+			name := strings.ToLower(attr.Key)
+			allowed, ok := ARIAAttributeValues[name]
+			if !ok || IsTemplateExpr(attr.Val) {
+				continue
+			}
+			tokens := []string{strings.TrimSpace(attr.Val)}
+			if ARIATokenListAttributes[name] {
+				tokens = strings.Fields(attr.Val)
+			}
+			for _, tok := range tokens {
+				if tok == "" || containsFold(allowed, tok) {
+					continue
+				}
+				line, col := n.AttrPos(name)
+				params := map[string]any{"attr": name, "value": tok, "allowed": strings.Join(allowed, ", ")}
+				results = append(results, Result{
+					Rule:      RuleAriaAllowedValues,
+					Message:   catalogMessage("aria-allowed-values.invalid", params),
+					MessageID: "aria-allowed-values.invalid",
+					Params:    params,
+					Filename:  doc.Filename,
+					Line:      line,
+					Col:       col,
+					Severity:  Error,
+				})
+			}
+		}
+
+		return true
+	})
+
+	return results
+}
+
+// containsFold reports whether list contains s, ignoring ASCII case.
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -24,12 +24,13 @@ func (r *AriaHiddenBody) Check(doc *parser.Document) []Result {
 
 		if n.GetAttr("aria-hidden") == "true" {
 			results = append(results, Result{
-				Rule:     r.Name(),
-				Message:  "aria-hidden on body hides entire page from assistive technology",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Error,
+				Rule:      r.Name(),
+				Message:   catalogMessage("aria-hidden-body.hidden", nil),
+				MessageID: "aria-hidden-body.hidden",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Error,
 			})
 		}
 
@@ -1,7 +1,6 @@
 package rules
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/toba/go-html-validate/parser"
@@ -54,13 +53,16 @@ func (r *AriaLabelMisuse) Check(doc *parser.Document) []Result {
 			attr = "aria-labelledby"
 		}
 
+		params := map[string]any{"attr": attr, "element": tagName}
 		results = append(results, Result{
-			Rule:     r.Name(),
-			Message:  fmt.Sprintf("%s not allowed on <%s> without role attribute", attr, tagName),
-			Filename: doc.Filename,
-			Line:     n.Line,
-			Col:      n.Col,
-			Severity: Error,
+			Rule:      r.Name(),
+			Message:   catalogMessage("aria-label-misuse.no-role", params),
+			MessageID: "aria-label-misuse.no-role",
+			Params:    params,
+			Filename:  doc.Filename,
+			Line:      n.Line,
+			Col:       n.Col,
+			Severity:  Error,
 		})
 
 		return true
@@ -0,0 +1,229 @@
+package rules
+
+import (
+	"bytes"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// AriaRelationship checks what aria-controls and aria-owns point at, beyond
+// the existence check of no-missing-references: an element must not
+// reference itself, an element can be owned through aria-owns only once and
+// not by one of its own descendants, and a tab's aria-controls must point
+// at a tabpanel. Tab targets defined in another linted file, such as a
+// partial holding the panels, are resolved across the project.
+type AriaRelationship struct{}
+
+// Name returns the rule identifier.
+func (r *AriaRelationship) Name() string { return RuleAriaRelationship }
+
+// Description returns what this rule checks.
+func (r *AriaRelationship) Description() string {
+	return "aria-controls and aria-owns must reference appropriate elements"
+}
+
+// Check examines the references whose targets are in the document.
+func (r *AriaRelationship) Check(doc *parser.Document) []Result {
+	var results []Result
+	report := func(n *parser.Node, attr string, sev Severity, id string, params map[string]any) {
+		line, col := n.AttrPos(attr)
+		results = append(results, Result{
+			Rule:      RuleAriaRelationship,
+			Message:   catalogMessage(id, params),
+			MessageID: id,
+			Params:    params,
+			Filename:  doc.Filename,
+			Line:      line,
+			Col:       col,
+			Severity:  sev,
+		})
+	}
+
+	ids := make(map[string]*parser.Node)
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+		if id := n.GetAttr("id"); id != "" && ids[id] == nil {
+			ids[id] = n
+		}
+		return true
+	})
+
+	owners := make(map[string]*parser.Node) // owned id -> first owner
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+		id := n.GetAttr("id")
+
+		for _, attr := range []string{"aria-controls", "aria-owns"} {
+			for _, ref := range referenceIDs(n.GetAttr(attr)) {
+				if ref == id {
+					report(n, attr, Error, "aria-relationship.self", map[string]any{"attr": attr, "id": ref})
+					continue
+				}
+				target := ids[ref]
+				if attr == "aria-owns" {
+					if first, ok := owners[ref]; ok {
+						report(n, attr, Error, "aria-relationship.owned", map[string]any{"id": ref, "owner": first.Data, "line": first.Line})
+						continue
+					}
+					owners[ref] = n
+					if target != nil && isAncestor(target, n) {
+						report(n, attr, Error, "aria-relationship.cycle", map[string]any{"id": ref})
+					}
+				}
+				if attr == "aria-controls" && target != nil && explicitRole(n) == "tab" && explicitRole(target) != "tabpanel" {
+					report(n, attr, Warning, "aria-relationship.tab-controls-local", map[string]any{"id": ref, "element": target.Data})
+				}
+			}
+		}
+		return true
+	})
+
+	return results
+}
+
+// CheckProject resolves tab aria-controls targets that are not in the
+// tab's own file but are defined in exactly one other linted file.
+func (r *AriaRelationship) CheckProject(files []SourceFile) []Result {
+	type definition struct {
+		file string
+		el   ariaElement
+	}
+	defs := make(map[string][]definition)
+	elements := make([][]ariaElement, len(files))
+	for i, f := range files {
+		elements[i] = f.ariaElements()
+		seen := make(map[string]bool)
+		for _, el := range elements[i] {
+			if el.id != "" && !seen[el.id] {
+				seen[el.id] = true
+				defs[el.id] = append(defs[el.id], definition{file: f.Filename, el: el})
+			}
+		}
+	}
+
+	var results []Result
+	for i, f := range files {
+		local := make(map[string]bool)
+		for _, el := range elements[i] {
+			local[el.id] = true
+		}
+		for _, el := range elements[i] {
+			if el.role != "tab" {
+				continue
+			}
+			for _, ref := range el.controls {
+				if local[ref] || len(defs[ref]) != 1 {
+					continue
+				}
+				target := defs[ref][0]
+				if target.el.role == "tabpanel" {
+					continue
+				}
+				params := map[string]any{"id": ref, "element": target.el.tag, "file": target.file, "line": target.el.line}
+				results = append(results, Result{
+					Rule:      RuleAriaRelationship,
+					Message:   catalogMessage("aria-relationship.tab-controls", params),
+					MessageID: "aria-relationship.tab-controls",
+					Params:    params,
+					Filename:  f.Filename,
+					Line:      el.line,
+					Col:       el.col,
+					Severity:  Warning,
+				})
+			}
+		}
+	}
+	return results
+}
+
+// ariaElement is an element with an id or an aria-controls reference,
+// as recorded in the project index.
+type ariaElement struct {
+	tag       string
+	id        string
+	role      string
+	controls  []string
+	line, col int
+}
+
+func (f SourceFile) ariaElements() []ariaElement {
+	if f.index != nil {
+		return f.index.aria
+	}
+	return scanAriaElements(f.Content)
+}
+
+// scanAriaElements returns the elements of content with a literal id or
+// aria-controls. Template actions are masked so offsets match the source.
+func scanAriaElements(content []byte) []ariaElement {
+	var elements []ariaElement
+	var lines []int32
+	masked := maskTemplateActions(content)
+	z := html.NewTokenizer(bytes.NewReader(masked))
+	offset := 0
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		raw := z.Raw()
+		if tt == html.StartTagToken || tt == html.SelfClosingTagToken {
+			name, _ := z.TagName()
+			el := ariaElement{tag: string(name)}
+			_, attrs := parser.ScanTagAttrs(raw)
+			for _, a := range attrs {
+				if a.ValueStart < 0 {
+					continue
+				}
+				val := string(content[offset+a.ValueStart : offset+a.ValueEnd])
+				switch a.Name {
+				case "id":
+					if el.id == "" && !IsTemplateExpr(val) {
+						el.id = strings.TrimSpace(val)
+					}
+				case "role":
+					if fields := strings.Fields(strings.ToLower(val)); len(fields) > 0 {
+						el.role = fields[0]
+					}
+				case "aria-controls":
+					el.controls = referenceIDs(val)
+				}
+			}
+			if el.id != "" || len(el.controls) > 0 {
+				if lines == nil {
+					lines = lineStarts(content)
+				}
+				line := sort.Search(len(lines), func(i int) bool { return int(lines[i]) > offset })
+				el.line, el.col = line, offset-int(lines[line-1])+1
+				elements = append(elements, el)
+			}
+		}
+		offset += len(raw)
+	}
+	return elements
+}
+
+// referenceIDs splits an ID reference list, dropping template values.
+func referenceIDs(val string) []string {
+	var ids []string
+	val = templateActionBounds.ReplaceAllString(val, "TMPL")
+	for id := range strings.FieldsSeq(val) {
+		if !IsTemplateExpr(id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// isAncestor reports whether a is a proper ancestor of n.
+func isAncestor(a, n *parser.Node) bool {
+	return slices.Contains(n.Ancestors(), a)
+}
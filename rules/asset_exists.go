@@ -0,0 +1,185 @@
+package rules
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// AssetExists checks that local scripts, stylesheets, images, media, and
+// SVG sprites referenced by the markup exist on disk. Root-relative URLs resolve
+// against the "root" option (default: the working directory); other
+// relative URLs resolve against the file's directory. Absolute and
+// templated URLs are skipped.
+//
+// Bundlers often serve files from a different path than the one written
+// in templates. The "rewrites" option lists regular expression
+// replacements applied to the URL path in order, e.g.
+// {"pattern": "^/static/", "replace": "/dist/"}; a rewritten path
+// containing glob characters such as "app.*.js" passes when any file
+// matches. Go callers can add rewriters with linter.Config.URLRewriters.
+//
+// The rule is opt-in.
+type AssetExists struct {
+	Root      string
+	Rewriters []URLRewriter
+
+	optionRewriters []URLRewriter
+}
+
+// Name returns the rule identifier.
+func (r *AssetExists) Name() string { return RuleAssetExists }
+
+// Description returns what this rule checks.
+func (r *AssetExists) Description() string {
+	return "referenced local assets should exist"
+}
+
+// OptIn marks the rule as disabled unless explicitly enabled.
+func (r *AssetExists) OptIn() {}
+
+// ConfigureRewriters sets rewriters applied after those from options.
+func (r *AssetExists) ConfigureRewriters(rewriters []URLRewriter) {
+	r.Rewriters = rewriters
+}
+
+// ConfigureOptions applies the root and rewrites options. Rewrites with
+// an invalid pattern are skipped.
+func (r *AssetExists) ConfigureOptions(opts map[string]any) {
+	r.Root = StringOption(opts, "root", r.Root)
+	r.optionRewriters = nil
+
+	entries, _ := opts["rewrites"].([]any)
+	for _, entry := range entries {
+		m, ok := entry.(map[string]any)
+		if !ok {
+			continue
+		}
+		re, err := regexp.Compile(StringOption(m, "pattern", ""))
+		if err != nil {
+			continue
+		}
+		replace := StringOption(m, "replace", "")
+		r.optionRewriters = append(r.optionRewriters, func(path string) string {
+			return re.ReplaceAllString(path, replace)
+		})
+	}
+}
+
+// assetAttrs maps elements to their attributes that load a local asset.
+var assetAttrs = map[string][]string{
+	"script": {"src"},
+	"img":    {"src"},
+	"audio":  {"src"},
+	"video":  {"src", "poster"},
+	"track":  {"src"},
+	"source": {"src", "srcset"},
+	"input":  {"src"},
+	"embed":  {"src"},
+	"object": {"data"},
+	"use":    {"href"}, // SVG sprite file; the #fragment is checked by svg-use-reference
+}
+
+// assetLinkRels are link types whose href is fetched as an asset.
+var assetLinkRels = map[string]bool{
+	"stylesheet":       true,
+	"icon":             true,
+	"apple-touch-icon": true,
+	"manifest":         true,
+	"preload":          true,
+	"modulepreload":    true,
+}
+
+// Check examines asset references for files that do not exist.
+func (r *AssetExists) Check(doc *parser.Document) []Result {
+	var results []Result
+
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+
+		attrs := assetAttrs[Tag(n)]
+		if Tag(n) == "link" {
+			for rel := range strings.FieldsSeq(strings.ToLower(n.GetAttr("rel"))) {
+				if assetLinkRels[rel] {
+					attrs = []string{"href"}
+					break
+				}
+			}
+		}
+
+		for _, attr := range attrs {
+			value := n.GetAttr(attr)
+			if attr == "srcset" {
+				value, _, _ = strings.Cut(strings.TrimSpace(value), " ")
+			}
+			path, ok := r.localPath(doc.Filename, value)
+			if !ok || assetExists(path) {
+				continue
+			}
+			line, col := n.AttrPos(attr)
+			params := map[string]any{"asset": value, "path": filepath.ToSlash(path)}
+			results = append(results, Result{
+				Rule:      RuleAssetExists,
+				Message:   catalogMessage("asset-exists.not-found", params),
+				MessageID: "asset-exists.not-found",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      line,
+				Col:       col,
+				Severity:  Error,
+			})
+		}
+
+		return true
+	})
+
+	return results
+}
+
+// localPath resolves a URL to a rewritten file path, or returns false for
+// URLs that are not local files.
+func (r *AssetExists) localPath(filename, value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" || IsTemplateExpr(value) {
+		return "", false
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme != "" || u.Host != "" || u.Path == "" {
+		return "", false
+	}
+
+	path := u.Path
+	for _, rewrite := range r.optionRewriters {
+		path = rewrite(path)
+	}
+	for _, rewrite := range r.Rewriters {
+		path = rewrite(path)
+	}
+
+	if strings.HasPrefix(path, "/") {
+		root := r.Root
+		if root == "" {
+			root = "."
+		}
+		return filepath.Join(root, filepath.FromSlash(path)), true
+	}
+	return filepath.Join(filepath.Dir(filename), filepath.FromSlash(path)), true
+}
+
+// assetExists reports whether path names a file, or for glob patterns,
+// whether any file matches.
+func assetExists(path string) bool {
+	if strings.ContainsAny(path, "*?[") {
+		matches, err := filepath.Glob(path)
+		return err == nil && len(matches) > 0
+	}
+	_, err := os.Stat(path)
+	return err == nil
+}
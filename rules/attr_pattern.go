@@ -57,13 +57,16 @@ func (r *ClassPattern) Check(doc *parser.Document) []Result {
 				continue
 			}
 			if !pattern.MatchString(class) {
+				params := map[string]any{"class": class}
 				results = append(results, Result{
-					Rule:     RuleClassPattern,
-					Message:  "class \"" + class + "\" does not match naming convention",
-					Filename: doc.Filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Info,
+					Rule:      RuleClassPattern,
+					Message:   catalogMessage("class-pattern.mismatch", params),
+					MessageID: "class-pattern.mismatch",
+					Params:    params,
+					Filename:  doc.Filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Info,
 				})
 			}
 		}
@@ -112,13 +115,16 @@ func (r *IDPattern) Check(doc *parser.Document) []Result {
 		}
 
 		if !pattern.MatchString(id) {
+			params := map[string]any{"id": id}
 			results = append(results, Result{
-				Rule:     RuleIDPattern,
-				Message:  "id \"" + id + "\" does not match naming convention",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Info,
+				Rule:      RuleIDPattern,
+				Message:   catalogMessage("id-pattern.mismatch", params),
+				MessageID: "id-pattern.mismatch",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Info,
 			})
 		}
 
@@ -166,13 +172,16 @@ func (r *NamePattern) Check(doc *parser.Document) []Result {
 		}
 
 		if !pattern.MatchString(name) {
+			params := map[string]any{"name": name}
 			results = append(results, Result{
-				Rule:     RuleNamePattern,
-				Message:  "name \"" + name + "\" does not match naming convention",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Info,
+				Rule:      RuleNamePattern,
+				Message:   catalogMessage("name-pattern.mismatch", params),
+				MessageID: "name-pattern.mismatch",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Info,
 			})
 		}
 
@@ -45,6 +45,8 @@ func (r *AttributeAllowedValues) Check(doc *parser.Document) []Result {
 			results = append(results, r.checkThScope(n, doc)...)
 		case "img", "iframe":
 			results = append(results, r.checkLoadingDecoding(n, doc)...)
+		case "template":
+			results = append(results, r.checkShadowRootMode(n, doc)...)
 		}
 
 		// Check global attributes
@@ -58,20 +60,46 @@ func (r *AttributeAllowedValues) Check(doc *parser.Document) []Result {
 	return results
 }
 
+func (r *AttributeAllowedValues) checkShadowRootMode(n *parser.Node, doc *parser.Document) []Result {
+	if !n.HasAttr("shadowrootmode") {
+		return nil
+	}
+	val := strings.ToLower(n.GetAttr("shadowrootmode"))
+	if val == "open" || val == "closed" || IsTemplateExpr(val) {
+		return nil
+	}
+	line, col := n.AttrPos("shadowrootmode")
+	params := map[string]any{"value": val}
+	return []Result{{
+		Rule:      RuleAttributeAllowedValues,
+		Message:   catalogMessage("attribute-allowed-values.shadowrootmode", params),
+		MessageID: "attribute-allowed-values.shadowrootmode",
+		Params:    params,
+		Filename:  doc.Filename,
+		Line:      line,
+		Col:       col,
+		Severity:  Error,
+	}}
+}
+
 func (r *AttributeAllowedValues) checkInputType(n *parser.Node, doc *parser.Document) []Result {
 	val := n.GetAttr("type")
+	line, col := n.AttrPos("type")
 	if val == "" {
 		return nil
 	}
 	val = strings.ToLower(val)
 	if !ValidInputTypes[val] {
+		params := map[string]any{"value": val}
 		return []Result{{
-			Rule:     RuleAttributeAllowedValues,
-			Message:  "invalid input type: " + val,
-			Filename: doc.Filename,
-			Line:     n.Line,
-			Col:      n.Col,
-			Severity: Error,
+			Rule:      RuleAttributeAllowedValues,
+			Message:   catalogMessage("attribute-allowed-values.input-type", params),
+			MessageID: "attribute-allowed-values.input-type",
+			Params:    params,
+			Filename:  doc.Filename,
+			Line:      line,
+			Col:       col,
+			Severity:  Error,
 		}}
 	}
 	return nil
@@ -79,18 +107,22 @@ func (r *AttributeAllowedValues) checkInputType(n *parser.Node, doc *parser.Docu
 
 func (r *AttributeAllowedValues) checkButtonType(n *parser.Node, doc *parser.Document) []Result {
 	val := n.GetAttr("type")
+	line, col := n.AttrPos("type")
 	if val == "" {
 		return nil
 	}
 	val = strings.ToLower(val)
 	if !ValidButtonTypes[val] {
+		params := map[string]any{"value": val}
 		return []Result{{
-			Rule:     RuleAttributeAllowedValues,
-			Message:  "invalid button type: " + val,
-			Filename: doc.Filename,
-			Line:     n.Line,
-			Col:      n.Col,
-			Severity: Error,
+			Rule:      RuleAttributeAllowedValues,
+			Message:   catalogMessage("attribute-allowed-values.button-type", params),
+			MessageID: "attribute-allowed-values.button-type",
+			Params:    params,
+			Filename:  doc.Filename,
+			Line:      line,
+			Col:       col,
+			Severity:  Error,
 		}}
 	}
 	return nil
@@ -101,28 +133,36 @@ func (r *AttributeAllowedValues) checkFormAttrs(n *parser.Node, doc *parser.Docu
 
 	// Check method
 	if method := n.GetAttr("method"); method != "" {
+		line, col := n.AttrPos("method")
 		if !ValidFormMethods[strings.ToLower(method)] {
+			params := map[string]any{"value": method}
 			results = append(results, Result{
-				Rule:     RuleAttributeAllowedValues,
-				Message:  "invalid form method: " + method,
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Error,
+				Rule:      RuleAttributeAllowedValues,
+				Message:   catalogMessage("attribute-allowed-values.form-method", params),
+				MessageID: "attribute-allowed-values.form-method",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      line,
+				Col:       col,
+				Severity:  Error,
 			})
 		}
 	}
 
 	// Check enctype
 	if enctype := n.GetAttr("enctype"); enctype != "" {
+		line, col := n.AttrPos("enctype")
 		if !ValidFormEnctypes[strings.ToLower(enctype)] {
+			params := map[string]any{"value": enctype}
 			results = append(results, Result{
-				Rule:     RuleAttributeAllowedValues,
-				Message:  "invalid form enctype: " + enctype,
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Error,
+				Rule:      RuleAttributeAllowedValues,
+				Message:   catalogMessage("attribute-allowed-values.form-enctype", params),
+				MessageID: "attribute-allowed-values.form-enctype",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      line,
+				Col:       col,
+				Severity:  Error,
 			})
 		}
 	}
@@ -132,6 +172,7 @@ func (r *AttributeAllowedValues) checkFormAttrs(n *parser.Node, doc *parser.Docu
 
 func (r *AttributeAllowedValues) checkAnchorRel(n *parser.Node, doc *parser.Document) []Result {
 	val := n.GetAttr("rel")
+	line, col := n.AttrPos("rel")
 	if val == "" {
 		return nil
 	}
@@ -141,13 +182,16 @@ func (r *AttributeAllowedValues) checkAnchorRel(n *parser.Node, doc *parser.Docu
 	for rel := range strings.FieldsSeq(val) {
 		rel = strings.ToLower(rel)
 		if !ValidAnchorRels[rel] {
+			params := map[string]any{"value": rel}
 			results = append(results, Result{
-				Rule:     RuleAttributeAllowedValues,
-				Message:  "invalid anchor rel value: " + rel,
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Warning,
+				Rule:      RuleAttributeAllowedValues,
+				Message:   catalogMessage("attribute-allowed-values.a-rel", params),
+				MessageID: "attribute-allowed-values.a-rel",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      line,
+				Col:       col,
+				Severity:  Warning,
 			})
 		}
 	}
@@ -156,6 +200,7 @@ func (r *AttributeAllowedValues) checkAnchorRel(n *parser.Node, doc *parser.Docu
 
 func (r *AttributeAllowedValues) checkLinkRel(n *parser.Node, doc *parser.Document) []Result {
 	val := n.GetAttr("rel")
+	line, col := n.AttrPos("rel")
 	if val == "" {
 		return nil
 	}
@@ -165,13 +210,16 @@ func (r *AttributeAllowedValues) checkLinkRel(n *parser.Node, doc *parser.Docume
 	for rel := range strings.FieldsSeq(val) {
 		rel = strings.ToLower(rel)
 		if !ValidLinkRels[rel] {
+			params := map[string]any{"value": rel}
 			results = append(results, Result{
-				Rule:     RuleAttributeAllowedValues,
-				Message:  "invalid link rel value: " + rel,
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Warning,
+				Rule:      RuleAttributeAllowedValues,
+				Message:   catalogMessage("attribute-allowed-values.link-rel", params),
+				MessageID: "attribute-allowed-values.link-rel",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      line,
+				Col:       col,
+				Severity:  Warning,
 			})
 		}
 	}
@@ -180,18 +228,22 @@ func (r *AttributeAllowedValues) checkLinkRel(n *parser.Node, doc *parser.Docume
 
 func (r *AttributeAllowedValues) checkThScope(n *parser.Node, doc *parser.Document) []Result {
 	val := n.GetAttr("scope")
+	line, col := n.AttrPos("scope")
 	if val == "" {
 		return nil
 	}
 	val = strings.ToLower(val)
 	if !ValidScopeValues[val] {
+		params := map[string]any{"value": val}
 		return []Result{{
-			Rule:     RuleAttributeAllowedValues,
-			Message:  "invalid th scope value: " + val,
-			Filename: doc.Filename,
-			Line:     n.Line,
-			Col:      n.Col,
-			Severity: Error,
+			Rule:      RuleAttributeAllowedValues,
+			Message:   catalogMessage("attribute-allowed-values.th-scope", params),
+			MessageID: "attribute-allowed-values.th-scope",
+			Params:    params,
+			Filename:  doc.Filename,
+			Line:      line,
+			Col:       col,
+			Severity:  Error,
 		}}
 	}
 	return nil
@@ -201,27 +253,35 @@ func (r *AttributeAllowedValues) checkLoadingDecoding(n *parser.Node, doc *parse
 	var results []Result
 
 	if loading := n.GetAttr("loading"); loading != "" {
+		line, col := n.AttrPos("loading")
 		if !ValidLoadingValues[strings.ToLower(loading)] {
+			params := map[string]any{"value": loading}
 			results = append(results, Result{
-				Rule:     RuleAttributeAllowedValues,
-				Message:  "invalid loading value: " + loading,
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Error,
+				Rule:      RuleAttributeAllowedValues,
+				Message:   catalogMessage("attribute-allowed-values.loading", params),
+				MessageID: "attribute-allowed-values.loading",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      line,
+				Col:       col,
+				Severity:  Error,
 			})
 		}
 	}
 
 	if decoding := n.GetAttr("decoding"); decoding != "" {
+		line, col := n.AttrPos("decoding")
 		if !ValidDecodingValues[strings.ToLower(decoding)] {
+			params := map[string]any{"value": decoding}
 			results = append(results, Result{
-				Rule:     RuleAttributeAllowedValues,
-				Message:  "invalid decoding value: " + decoding,
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Error,
+				Rule:      RuleAttributeAllowedValues,
+				Message:   catalogMessage("attribute-allowed-values.decoding", params),
+				MessageID: "attribute-allowed-values.decoding",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      line,
+				Col:       col,
+				Severity:  Error,
 			})
 		}
 	}
@@ -231,18 +291,22 @@ func (r *AttributeAllowedValues) checkLoadingDecoding(n *parser.Node, doc *parse
 
 func (r *AttributeAllowedValues) checkDirAttr(n *parser.Node, doc *parser.Document) []Result {
 	val := n.GetAttr("dir")
+	line, col := n.AttrPos("dir")
 	if val == "" {
 		return nil
 	}
 	val = strings.ToLower(val)
 	if !ValidDirValues[val] {
+		params := map[string]any{"value": val}
 		return []Result{{
-			Rule:     RuleAttributeAllowedValues,
-			Message:  "invalid dir value: " + val,
-			Filename: doc.Filename,
-			Line:     n.Line,
-			Col:      n.Col,
-			Severity: Error,
+			Rule:      RuleAttributeAllowedValues,
+			Message:   catalogMessage("attribute-allowed-values.dir", params),
+			MessageID: "attribute-allowed-values.dir",
+			Params:    params,
+			Filename:  doc.Filename,
+			Line:      line,
+			Col:       col,
+			Severity:  Error,
 		}}
 	}
 	return nil
@@ -253,14 +317,18 @@ func (r *AttributeAllowedValues) checkCrossOrigin(n *parser.Node, doc *parser.Do
 		return nil
 	}
 	val := strings.ToLower(n.GetAttr("crossorigin"))
+	line, col := n.AttrPos("crossorigin")
 	if !ValidCrossOriginValues[val] {
+		params := map[string]any{"value": val}
 		return []Result{{
-			Rule:     RuleAttributeAllowedValues,
-			Message:  "invalid crossorigin value: " + val,
-			Filename: doc.Filename,
-			Line:     n.Line,
-			Col:      n.Col,
-			Severity: Error,
+			Rule:      RuleAttributeAllowedValues,
+			Message:   catalogMessage("attribute-allowed-values.crossorigin", params),
+			MessageID: "attribute-allowed-values.crossorigin",
+			Params:    params,
+			Filename:  doc.Filename,
+			Line:      line,
+			Col:       col,
+			Severity:  Error,
 		}}
 	}
 	return nil
@@ -271,14 +339,18 @@ func (r *AttributeAllowedValues) checkReferrerPolicy(n *parser.Node, doc *parser
 		return nil
 	}
 	val := strings.ToLower(n.GetAttr("referrerpolicy"))
+	line, col := n.AttrPos("referrerpolicy")
 	if !ValidReferrerPolicies[val] {
+		params := map[string]any{"value": val}
 		return []Result{{
-			Rule:     RuleAttributeAllowedValues,
-			Message:  "invalid referrerpolicy value: " + val,
-			Filename: doc.Filename,
-			Line:     n.Line,
-			Col:      n.Col,
-			Severity: Error,
+			Rule:      RuleAttributeAllowedValues,
+			Message:   catalogMessage("attribute-allowed-values.referrerpolicy", params),
+			MessageID: "attribute-allowed-values.referrerpolicy",
+			Params:    params,
+			Filename:  doc.Filename,
+			Line:      line,
+			Col:       col,
+			Severity:  Error,
 		}}
 	}
 	return nil
@@ -178,13 +178,16 @@ func (r *AttributeMisuse) Check(doc *parser.Document) []Result {
 
 			// Check if element is in valid list
 			if !slices.Contains(validElements, tag) {
+				params := map[string]any{"attr": attrName, "element": tag}
 				results = append(results, Result{
-					Rule:     RuleAttributeMisuse,
-					Message:  "attribute '" + attrName + "' is not valid on <" + tag + ">",
-					Filename: doc.Filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Error,
+					Rule:      RuleAttributeMisuse,
+					Message:   catalogMessage("attribute-misuse.invalid", params),
+					MessageID: "attribute-misuse.invalid",
+					Params:    params,
+					Filename:  doc.Filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Error,
 				})
 			}
 		}
@@ -244,6 +244,41 @@ var ValidReferrerPolicies = map[string]bool{
 	"unsafe-url":                      true,
 }
 
+// ARIAAttributeValues lists the allowed tokens of enumerated ARIA
+// attributes, in the order of the WAI-ARIA 1.2 specification.
+var ARIAAttributeValues = map[string][]string{
+	"aria-atomic":          {"true", "false"},
+	"aria-autocomplete":    {"inline", "list", "both", "none"},
+	"aria-busy":            {"true", "false"},
+	"aria-checked":         {"true", "false", "mixed", "undefined"},
+	"aria-current":         {"page", "step", "location", "date", "time", "true", "false"},
+	"aria-disabled":        {"true", "false"},
+	"aria-dropeffect":      {"copy", "execute", "link", "move", "none", "popup"},
+	"aria-expanded":        {"true", "false", "undefined"},
+	"aria-grabbed":         {"true", "false", "undefined"},
+	"aria-haspopup":        {"menu", "listbox", "tree", "grid", "dialog", "true", "false"},
+	"aria-hidden":          {"true", "false", "undefined"},
+	"aria-invalid":         {"grammar", "spelling", "true", "false"},
+	"aria-live":            {"assertive", "polite", "off"},
+	"aria-modal":           {"true", "false"},
+	"aria-multiline":       {"true", "false"},
+	"aria-multiselectable": {"true", "false"},
+	"aria-orientation":     {"horizontal", "vertical", "undefined"},
+	"aria-pressed":         {"true", "false", "mixed", "undefined"},
+	"aria-readonly":        {"true", "false"},
+	"aria-relevant":        {"additions", "removals", "text", "all"},
+	"aria-required":        {"true", "false"},
+	"aria-selected":        {"true", "false", "undefined"},
+	"aria-sort":            {"ascending", "descending", "none", "other"},
+}
+
+// ARIATokenListAttributes lists ARIA attributes whose value is a
+// space-separated list of tokens from ARIAAttributeValues.
+var ARIATokenListAttributes = map[string]bool{
+	"aria-dropeffect": true,
+	"aria-relevant":   true,
+}
+
 // ValidSandboxTokens lists valid tokens for iframe sandbox="" attribute.
 var ValidSandboxTokens = map[string]bool{
 	"allow-downloads":                          true,
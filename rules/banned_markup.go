@@ -0,0 +1,143 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// Ban is a team policy against an element, an attribute, or an attribute
+// value, such as no <b>, no style attributes, or no target="_blank".
+type Ban struct {
+	// Element bans the tag, or limits Attribute to the tag when both are set
+	Element string
+	// Attribute bans the attribute, or only Value of it when Value is set
+	Attribute string
+	// Value is matched case-insensitively after trimming space
+	Value string
+	// Message explains the policy and is appended to the finding
+	Message string
+	// Exclude lists glob patterns, relative to the config file, of files
+	// where the ban does not apply
+	Exclude []string
+}
+
+// Validate reports a ban that names nothing to ban.
+func (b Ban) Validate() error {
+	switch {
+	case b.Element == "" && b.Attribute == "":
+		return fmt.Errorf("banned entries need an element or an attribute")
+	case b.Value != "" && b.Attribute == "":
+		return fmt.Errorf("banned value %q needs an attribute", b.Value)
+	}
+	return nil
+}
+
+// BansConfigurable is implemented by rules that enforce configured bans.
+// match reports whether a linted file matches a ban's Exclude pattern.
+type BansConfigurable interface {
+	ConfigureBans(bans []Ban, match func(filename, pattern string) bool)
+}
+
+// BannedMarkup reports elements, attributes, and attribute values banned
+// by the "banned" config, so simple house policies need no custom rule.
+// Without bans it reports nothing.
+type BannedMarkup struct {
+	bans  []Ban
+	match func(filename, pattern string) bool
+}
+
+// Name returns the rule identifier.
+func (r *BannedMarkup) Name() string { return RuleBannedMarkup }
+
+// Description returns what this rule checks.
+func (r *BannedMarkup) Description() string {
+	return "markup must not use elements, attributes, or values banned by config"
+}
+
+// ConfigureBans sets the bans to enforce.
+func (r *BannedMarkup) ConfigureBans(bans []Ban, match func(filename, pattern string) bool) {
+	r.bans = bans
+	r.match = match
+}
+
+// Check reports each use of a ban that applies to the document.
+func (r *BannedMarkup) Check(doc *parser.Document) []Result {
+	bans := r.applicable(doc.Filename)
+	if len(bans) == 0 {
+		return nil
+	}
+
+	var results []Result
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+		for _, b := range bans {
+			if b.Element != "" && !TagEquals(n, b.Element) {
+				continue
+			}
+			line, col := n.Line, n.Col
+			params := map[string]any{"element": Tag(n), "message": b.Message}
+			var id string
+			switch {
+			case b.Attribute == "":
+				id = "banned-markup.element"
+			case !n.HasAttr(b.Attribute):
+				continue
+			case b.Value == "":
+				line, col = n.AttrPos(b.Attribute)
+				id = "banned-markup.attribute"
+				params["attr"] = strings.ToLower(b.Attribute)
+			default:
+				value := n.GetAttr(b.Attribute)
+				if !strings.EqualFold(strings.TrimSpace(value), b.Value) {
+					continue
+				}
+				line, col = n.AttrPos(b.Attribute)
+				id = "banned-markup.value"
+				params["attr"] = strings.ToLower(b.Attribute)
+				params["value"] = value
+			}
+			results = append(results, Result{
+				Rule:      RuleBannedMarkup,
+				Message:   catalogMessage(id, params),
+				MessageID: id,
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      line,
+				Col:       col,
+				Severity:  Error,
+			})
+		}
+		return true
+	})
+
+	return results
+}
+
+// applicable returns the bans not excluded for filename.
+func (r *BannedMarkup) applicable(filename string) []Ban {
+	var bans []Ban
+	for _, b := range r.bans {
+		if !excluded(r.match, filename, b.Exclude) {
+			bans = append(bans, b)
+		}
+	}
+	return bans
+}
+
+// excluded reports whether filename matches any of patterns.
+func excluded(match func(filename, pattern string) bool, filename string, patterns []string) bool {
+	if match == nil {
+		return false
+	}
+	for _, pattern := range patterns {
+		if match(filename, pattern) {
+			return true
+		}
+	}
+	return false
+}
@@ -24,12 +24,13 @@ func (r *ButtonName) Check(doc *parser.Document) []Result {
 
 		if !HasAccessibleName(n) {
 			results = append(results, Result{
-				Rule:     r.Name(),
-				Message:  "button element missing accessible name",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Error,
+				Rule:      r.Name(),
+				Message:   catalogMessage("button-name.missing", nil),
+				MessageID: "button-name.missing",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Error,
 			})
 		}
 
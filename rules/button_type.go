@@ -25,12 +25,13 @@ func (r *ButtonType) Check(doc *parser.Document) []Result {
 		// Check if button has type attribute
 		if !n.HasAttr("type") {
 			results = append(results, Result{
-				Rule:     r.Name(),
-				Message:  "button missing type attribute (defaults to submit)",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Warning,
+				Rule:      r.Name(),
+				Message:   catalogMessage("button-type.missing", nil),
+				MessageID: "button-type.missing",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
 			})
 		}
 
@@ -0,0 +1,116 @@
+package rules
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// CommentSyntax reports markup the HTML parser silently turns into
+// comments or that ends comments early, which the repaired DOM never
+// shows: an unclosed <!-- that swallows the rest of the file, <!-- nested
+// inside a comment (the first --> closes both), comments closed with --!>
+// or opened as <!--> , "--" inside comment text (invalid in XML and in
+// older HTML), CDATA sections outside SVG and MathML, which become bogus
+// comments hiding their content, and XML processing instructions such as
+// <?xml ...?>. Template actions are masked before scanning.
+type CommentSyntax struct{}
+
+// Name returns the rule identifier.
+func (r *CommentSyntax) Name() string { return RuleCommentSyntax }
+
+// Description returns what this rule checks.
+func (r *CommentSyntax) Description() string {
+	return "comments must be well-formed; no CDATA or processing instructions in HTML"
+}
+
+// Check implements Rule but returns nil - this rule uses CheckRaw instead.
+func (r *CommentSyntax) Check(_ *parser.Document) []Result {
+	return nil
+}
+
+// CheckRaw tokenizes the original source to see comments as written.
+func (r *CommentSyntax) CheckRaw(filename string, content []byte) []Result {
+	var results []Result
+	report := func(offset int, id string, params map[string]any, sev Severity) {
+		line, col := offsetPosition(content, offset)
+		results = append(results, Result{
+			Rule:      r.Name(),
+			Message:   catalogMessage(id, params),
+			MessageID: id,
+			Params:    params,
+			Filename:  filename,
+			Line:      line,
+			Col:       col,
+			Severity:  sev,
+		})
+	}
+
+	z := html.NewTokenizer(bytes.NewReader(maskTemplateActions(content)))
+	offset := 0
+	foreign := 0 // depth of open svg and math elements, where CDATA is allowed
+	for {
+		z.AllowCDATA(foreign > 0)
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		raw := string(z.Raw())
+		start := offset
+		offset += len(raw)
+
+		switch tt {
+		case html.StartTagToken:
+			if name, _ := z.TagName(); string(name) == "svg" || string(name) == "math" {
+				foreign++
+			}
+		case html.EndTagToken:
+			if name, _ := z.TagName(); (string(name) == "svg" || string(name) == "math") && foreign > 0 {
+				foreign--
+			}
+		case html.CommentToken:
+			r.checkComment(raw, start, report)
+		}
+	}
+
+	return results
+}
+
+// checkComment reports problems with one comment token at offset.
+func (r *CommentSyntax) checkComment(raw string, offset int, report func(int, string, map[string]any, Severity)) {
+	switch {
+	case strings.HasPrefix(raw, "<![CDATA["):
+		report(offset, "comment-syntax.cdata", nil, Error)
+		return
+	case strings.HasPrefix(raw, "<?"):
+		report(offset, "comment-syntax.processing-instruction", nil, Warning)
+		return
+	case !strings.HasPrefix(raw, "<!--"):
+		return // other bogus comments, e.g. <![if !IE]>, belong to no-conditional-comment
+	}
+
+	if raw == "<!-->" || raw == "<!--->" {
+		report(offset, "comment-syntax.abrupt", map[string]any{"raw": raw}, Error)
+		return
+	}
+
+	var body string
+	switch {
+	case strings.HasSuffix(raw, "-->"):
+		body = raw[4 : len(raw)-3]
+	case strings.HasSuffix(raw, "--!>"):
+		body = raw[4 : len(raw)-4]
+		report(offset+len(raw)-4, "comment-syntax.bang-close", nil, Warning)
+	default:
+		report(offset, "comment-syntax.unclosed", nil, Error)
+		return
+	}
+
+	if i := strings.Index(body, "<!--"); i >= 0 {
+		report(offset+4+i, "comment-syntax.nested", nil, Error)
+	} else if i := strings.Index(body, "--"); i >= 0 {
+		report(offset+4+i, "comment-syntax.double-dash", nil, Warning)
+	}
+}
@@ -0,0 +1,166 @@
+package rules
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// compositeItemRoles maps composite widget roles to the roles of the items
+// they manage.
+var compositeItemRoles = map[string][]string{
+	"tablist":    {"tab"},
+	"menu":       {"menuitem", "menuitemcheckbox", "menuitemradio"},
+	"menubar":    {"menuitem", "menuitemcheckbox", "menuitemradio"},
+	"listbox":    {"option"},
+	"radiogroup": {"radio"},
+	"tree":       {"treeitem"},
+	"grid":       {"row"},
+	"treegrid":   {"row"},
+}
+
+// CompositeWidget catches half-implemented ARIA composite widgets: a
+// tablist, menu, listbox, radiogroup, tree, or grid without any of the
+// items its role requires, or with more than one tabbable item. Composite
+// widgets are a single tab stop; arrow keys move between items using a
+// roving tabindex or aria-activedescendant.
+type CompositeWidget struct{}
+
+// Name returns the rule identifier.
+func (r *CompositeWidget) Name() string { return RuleCompositeWidget }
+
+// Description returns what this rule checks.
+func (r *CompositeWidget) Description() string {
+	return "composite ARIA widgets must contain their item roles and be a single tab stop"
+}
+
+// Check examines elements with an explicit composite role. Widgets using
+// aria-owns are skipped since their items may live elsewhere.
+func (r *CompositeWidget) Check(doc *parser.Document) []Result {
+	var results []Result
+
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.ElementNode || n.HasAttr("aria-owns") {
+			return true
+		}
+		role := explicitRole(n)
+		itemRoles, ok := compositeItemRoles[role]
+		if !ok {
+			return true
+		}
+
+		hasItem := false
+		var tabbable []*parser.Node
+		for _, c := range n.Children {
+			collectWidgetItems(c, itemRoles, &hasItem, &tabbable)
+		}
+
+		if !hasItem {
+			params := map[string]any{"role": role, "items": quoteJoin(itemRoles)}
+			results = append(results, Result{
+				Rule:      RuleCompositeWidget,
+				Message:   catalogMessage("composite-widget.no-items", params),
+				MessageID: "composite-widget.no-items",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
+			})
+		}
+		if len(tabbable) > 1 {
+			params := map[string]any{"role": role, "count": len(tabbable)}
+			results = append(results, Result{
+				Rule:      RuleCompositeWidget,
+				Message:   catalogMessage("composite-widget.tab-stops", params),
+				MessageID: "composite-widget.tab-stops",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      tabbable[1].Line,
+				Col:       tabbable[1].Col,
+				Severity:  Warning,
+			})
+		}
+		return true
+	})
+
+	return results
+}
+
+// collectWidgetItems walks a composite widget's subtree, noting whether it
+// holds an item with one of itemRoles and which elements are tabbable.
+// Nested composite widgets and hidden subtrees are not descended into.
+func collectWidgetItems(n *parser.Node, itemRoles []string, hasItem *bool, tabbable *[]*parser.Node) {
+	if n.Type != html.ElementNode || n.HasAttr("hidden") {
+		return
+	}
+	role := explicitRole(n)
+	if role == "" {
+		role = GetImplicitRole(strings.ToLower(n.Data), n)
+	}
+	if _, nested := compositeItemRoles[role]; nested && n.HasAttr("role") {
+		return
+	}
+	for _, item := range itemRoles {
+		if role == item {
+			*hasItem = true
+		}
+	}
+	if isTabbable(n) {
+		*tabbable = append(*tabbable, n)
+	}
+	for _, c := range n.Children {
+		collectWidgetItems(c, itemRoles, hasItem, tabbable)
+	}
+}
+
+// explicitRole returns the first token of n's role attribute, lowercased.
+func explicitRole(n *parser.Node) string {
+	fields := strings.Fields(strings.ToLower(n.GetAttr("role")))
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+// isTabbable reports whether n is in the sequential focus order. A
+// template tabindex is assumed to be managed. Native radio buttons are
+// excluded because browsers make a radio group a single tab stop.
+func isTabbable(n *parser.Node) bool {
+	if n.HasAttr("tabindex") {
+		v := strings.TrimSpace(n.GetAttr("tabindex"))
+		if IsTemplateExpr(v) {
+			return false
+		}
+		if i, err := strconv.Atoi(v); err == nil {
+			return i >= 0
+		}
+	}
+	if n.HasAttr("disabled") {
+		return false
+	}
+	switch strings.ToLower(n.Data) {
+	case "a", "area":
+		return n.HasAttr("href")
+	case "button", "select", "textarea":
+		return true
+	case "input":
+		t := strings.ToLower(n.GetAttr("type"))
+		return t != "hidden" && t != "radio"
+	}
+	return strings.EqualFold(n.GetAttr("contenteditable"), "true")
+}
+
+// quoteJoin formats names as "a", "b", or "c".
+func quoteJoin(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = strconv.Quote(name)
+	}
+	if len(quoted) == 1 {
+		return quoted[0]
+	}
+	return strings.Join(quoted[:len(quoted)-1], ", ") + ", or " + quoted[len(quoted)-1]
+}
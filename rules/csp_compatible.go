@@ -0,0 +1,288 @@
+package rules
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// CSPCompatible checks markup against a configured Content-Security-Policy:
+// inline scripts, styles, event handlers, and style attributes the policy
+// would block, external resources from origins it does not list, and
+// javascript: URLs. Without a "policy" option the rule reports nothing.
+//
+// Relative URLs are treated as same-origin and match 'self'.
+type CSPCompatible struct {
+	Policy string
+
+	directives map[string][]string
+}
+
+// Name returns the rule identifier.
+func (r *CSPCompatible) Name() string { return RuleCSPCompatible }
+
+// Description returns what this rule checks.
+func (r *CSPCompatible) Description() string {
+	return "markup should not violate the configured Content-Security-Policy"
+}
+
+// ConfigureOptions applies the policy option.
+func (r *CSPCompatible) ConfigureOptions(opts map[string]any) {
+	r.Policy = StringOption(opts, "policy", r.Policy)
+	r.directives = parseCSP(r.Policy)
+}
+
+// parseCSP splits a policy into its directives. Directive names and
+// sources are lowercased; the first occurrence of a directive wins.
+func parseCSP(policy string) map[string][]string {
+	directives := make(map[string][]string)
+	for _, part := range strings.Split(policy, ";") {
+		fields := strings.Fields(strings.ToLower(part))
+		if len(fields) == 0 {
+			continue
+		}
+		if _, ok := directives[fields[0]]; !ok {
+			directives[fields[0]] = fields[1:]
+		}
+	}
+	return directives
+}
+
+// cspFallbacks lists, for each directive checked, the directives consulted
+// in order when it is absent.
+var cspFallbacks = map[string][]string{
+	"script-src-elem": {"script-src-elem", "script-src", "default-src"},
+	"script-src-attr": {"script-src-attr", "script-src", "default-src"},
+	"style-src-elem":  {"style-src-elem", "style-src", "default-src"},
+	"style-src-attr":  {"style-src-attr", "style-src", "default-src"},
+	"img-src":         {"img-src", "default-src"},
+	"media-src":       {"media-src", "default-src"},
+	"frame-src":       {"frame-src", "child-src", "default-src"},
+	"object-src":      {"object-src", "default-src"},
+}
+
+// sources returns the effective directive name and source list for a
+// directive, or ok false when the policy does not restrict it.
+func (r *CSPCompatible) sources(directive string) (name string, sources []string, ok bool) {
+	for _, name := range cspFallbacks[directive] {
+		if sources, ok := r.directives[name]; ok {
+			return name, sources, true
+		}
+	}
+	return "", nil, false
+}
+
+// Check examines the document for markup the policy would block.
+func (r *CSPCompatible) Check(doc *parser.Document) []Result {
+	if len(r.directives) == 0 {
+		return nil
+	}
+
+	var results []Result
+	report := func(line, col int, id string, params map[string]any) {
+		results = append(results, Result{
+			Rule:      RuleCSPCompatible,
+			Message:   catalogMessage(id, params),
+			MessageID: id,
+			Params:    params,
+			Filename:  doc.Filename,
+			Line:      line,
+			Col:       col,
+			Severity:  Error,
+		})
+	}
+	checkInline := func(n *parser.Node, directive, id string, nonce bool) {
+		name, sources, ok := r.sources(directive)
+		if ok && !allowsInline(sources, nonce) {
+			report(n.Line, n.Col, id, map[string]any{"directive": name})
+		}
+	}
+	checkURL := func(n *parser.Node, attr, directive string) {
+		value := n.GetAttr(attr)
+		if value == "" || IsTemplateExpr(value) {
+			return
+		}
+		name, sources, ok := r.sources(directive)
+		if ok && !allowsURL(sources, value) {
+			line, col := n.AttrPos(attr)
+			report(line, col, "csp-compatible.url", map[string]any{"url": value, "directive": name})
+		}
+	}
+
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+
+		for _, attr := range n.Attr {
+			key := strings.ToLower(attr.Key)
+			switch {
+			case key == "style":
+				if name, sources, ok := r.sources("style-src-attr"); ok && !allowsInline(sources, false) {
+					line, col := n.AttrPos(key)
+					report(line, col, "csp-compatible.style-attr", map[string]any{"directive": name})
+				}
+			case strings.HasPrefix(key, "on"):
+				if name, sources, ok := r.sources("script-src-attr"); ok && !allowsInline(sources, false) {
+					line, col := n.AttrPos(key)
+					report(line, col, "csp-compatible.handler", map[string]any{"attr": key, "directive": name})
+				}
+			}
+		}
+
+		switch Tag(n) {
+		case "script":
+			if n.HasAttr("src") {
+				checkURL(n, "src", "script-src-elem")
+			} else if isJavaScriptType(strings.ToLower(n.GetAttr("type"))) && hasInlineContent(n) {
+				checkInline(n, "script-src-elem", "csp-compatible.inline-script", n.HasAttr("nonce"))
+			}
+		case "style":
+			checkInline(n, "style-src-elem", "csp-compatible.inline-style", n.HasAttr("nonce"))
+		case "link":
+			if strings.EqualFold(strings.TrimSpace(n.GetAttr("rel")), "stylesheet") {
+				checkURL(n, "href", "style-src-elem")
+			}
+		case "a", "area":
+			r.checkJavaScriptURL(n, "href", report)
+		case "iframe":
+			if !r.checkJavaScriptURL(n, "src", report) {
+				checkURL(n, "src", "frame-src")
+			}
+		case "img":
+			checkURL(n, "src", "img-src")
+		case "audio", "video", "track":
+			checkURL(n, "src", "media-src")
+		case "source":
+			if n.Parent != nil && TagEquals(n.Parent, "picture") {
+				checkURL(n, "srcset", "img-src")
+			} else {
+				checkURL(n, "src", "media-src")
+			}
+		case "object":
+			checkURL(n, "data", "object-src")
+		case "embed":
+			checkURL(n, "src", "object-src")
+		}
+
+		return true
+	})
+
+	return results
+}
+
+// checkJavaScriptURL reports a javascript: URL in attr, which runs as
+// inline script, and returns whether the attribute held one.
+func (r *CSPCompatible) checkJavaScriptURL(n *parser.Node, attr string, report func(line, col int, id string, params map[string]any)) bool {
+	if !strings.HasPrefix(strings.ToLower(strings.TrimSpace(n.GetAttr(attr))), "javascript:") {
+		return false
+	}
+	if name, sources, ok := r.sources("script-src-elem"); ok && !allowsInline(sources, false) {
+		line, col := n.AttrPos(attr)
+		report(line, col, "csp-compatible.javascript-url", map[string]any{"directive": name})
+	}
+	return true
+}
+
+// allowsInline reports whether sources permit inline content. A nonce is
+// honored when the policy lists one; 'unsafe-inline' is ignored when the
+// policy lists a nonce or hash, as browsers do.
+func allowsInline(sources []string, nonce bool) bool {
+	unsafeInline, nonceOrHash := false, false
+	for _, s := range sources {
+		switch {
+		case s == "'unsafe-inline'":
+			unsafeInline = true
+		case strings.HasPrefix(s, "'nonce-"):
+			if nonce {
+				return true
+			}
+			nonceOrHash = true
+		case strings.HasPrefix(s, "'sha256-"), strings.HasPrefix(s, "'sha384-"), strings.HasPrefix(s, "'sha512-"):
+			nonceOrHash = true
+		}
+	}
+	return unsafeInline && !nonceOrHash
+}
+
+// allowsURL reports whether sources permit loading rawURL. srcset values
+// are checked by their first candidate.
+func allowsURL(sources []string, rawURL string) bool {
+	rawURL, _, _ = strings.Cut(strings.TrimSpace(rawURL), " ")
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return true
+	}
+	scheme := strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Hostname())
+	if scheme == "" && host != "" {
+		scheme = "https" // scheme-relative; assume a secure page
+	}
+
+	for _, s := range sources {
+		switch {
+		case s == "'self'":
+			if scheme == "" {
+				return true
+			}
+		case s == "*":
+			if scheme == "" || scheme == "http" || scheme == "https" || scheme == "ws" || scheme == "wss" {
+				return true
+			}
+		case strings.HasPrefix(s, "'"):
+			// 'none', nonces, hashes, and other keywords do not match URLs
+		case strings.HasSuffix(s, ":"):
+			if schemeMatches(strings.TrimSuffix(s, ":"), scheme) {
+				return true
+			}
+		default:
+			if host != "" && hostSourceMatches(s, scheme, host, u.EscapedPath()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hostSourceMatches matches a host source such as "cdn.example.com",
+// "https://*.example.com", or "example.com/static/" against a URL.
+func hostSourceMatches(source, scheme, host, path string) bool {
+	if s, rest, ok := strings.Cut(source, "://"); ok {
+		if !schemeMatches(s, scheme) {
+			return false
+		}
+		source = rest
+	} else if scheme != "http" && scheme != "https" {
+		return false
+	}
+
+	sourceHost, sourcePath := source, ""
+	if i := strings.IndexByte(source, '/'); i >= 0 {
+		sourceHost, sourcePath = source[:i], source[i:]
+	}
+	if i := strings.LastIndexByte(sourceHost, ':'); i >= 0 {
+		sourceHost = sourceHost[:i] // ports are not checked
+	}
+	if sourceHost != "*" && !matchHost(host, []string{sourceHost}) {
+		return false
+	}
+
+	switch {
+	case sourcePath == "":
+		return true
+	case strings.HasSuffix(sourcePath, "/"):
+		return strings.HasPrefix(path, sourcePath)
+	default:
+		return path == sourcePath
+	}
+}
+
+// schemeMatches reports whether a source scheme allows a URL scheme;
+// http sources also allow their secure upgrade.
+func schemeMatches(source, scheme string) bool {
+	return source == scheme ||
+		(source == "http" && scheme == "https") ||
+		(source == "ws" && scheme == "wss")
+}
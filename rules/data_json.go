@@ -0,0 +1,75 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// DataJSON checks that data-* attributes which client-side components read
+// with JSON.parse, such as data-config or data-props, contain valid JSON.
+// The attributes are listed in the "attributes" option; without it the
+// rule reports nothing.
+type DataJSON struct {
+	Attributes []string
+}
+
+// Name returns the rule identifier.
+func (r *DataJSON) Name() string { return RuleDataJSON }
+
+// Description returns what this rule checks.
+func (r *DataJSON) Description() string {
+	return "data attributes listed as JSON must contain valid JSON"
+}
+
+// ConfigureOptions applies the attributes option.
+func (r *DataJSON) ConfigureOptions(opts map[string]any) {
+	r.Attributes = StringsOption(opts, "attributes", r.Attributes)
+}
+
+// Check parses the value of each listed attribute.
+func (r *DataJSON) Check(doc *parser.Document) []Result {
+	if len(r.Attributes) == 0 {
+		return nil
+	}
+	names := make(map[string]bool, len(r.Attributes))
+	for _, name := range r.Attributes {
+		names[strings.ToLower(name)] = true
+	}
+
+	var results []Result
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+		for _, attr := range n.Attr {
+			name := strings.ToLower(attr.Key)
+			if !names[name] || IsTemplateExpr(attr.Val) {
+				continue
+			}
+			m := message{"data-json.empty", map[string]any{"attr": name}}
+			if strings.TrimSpace(attr.Val) != "" {
+				err := jsonSyntaxError(attr.Val)
+				if err == "" {
+					continue
+				}
+				m = message{"data-json.invalid", map[string]any{"attr": name, "error": err}}
+			}
+			line, col := n.AttrPos(attr.Key)
+			results = append(results, Result{
+				Rule:      r.Name(),
+				Message:   m.text(),
+				MessageID: m.id,
+				Params:    m.params,
+				Filename:  doc.Filename,
+				Line:      line,
+				Col:       col,
+				Severity:  Error,
+			})
+		}
+		return true
+	})
+
+	return results
+}
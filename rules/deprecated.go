@@ -31,13 +31,16 @@ func (r *Deprecated) Check(doc *parser.Document) []Result {
 
 		// Check if element is deprecated
 		if suggestion, deprecated := DeprecatedElements[tag]; deprecated {
+			params := map[string]any{"element": tag, "suggestion": suggestion}
 			results = append(results, Result{
-				Rule:     RuleDeprecated,
-				Message:  "element <" + tag + "> is deprecated; " + suggestion,
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Warning,
+				Rule:      RuleDeprecated,
+				Message:   catalogMessage("deprecated.element", params),
+				MessageID: "deprecated.element",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
 			})
 		}
 
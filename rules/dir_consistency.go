@@ -0,0 +1,87 @@
+package rules
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// physicalStylePattern matches inline CSS that hard-codes a left or right
+// side instead of using logical properties such as margin-inline-start.
+var physicalStylePattern = regexp.MustCompile(`(?i)(?:^|[;\s])(?:(?:margin|padding|border)-(?:left|right)|left|right)\s*:|(?:^|[;\s])(?:float|clear|text-align)\s*:\s*(?:left|right)\b`)
+
+// directionalArrows are glyphs that point a fixed way and read backwards
+// in right-to-left text.
+const directionalArrows = "←→⇐⇒⇦⇨➔➜➙➛➝➞▶◀►◄"
+
+// DirConsistency checks markup for multilingual sites: bdo must set an
+// explicit ltr or rtl dir, and right-to-left content should not hard-code
+// left/right inline styles or arrow glyphs that assume left-to-right.
+type DirConsistency struct{}
+
+// Name returns the rule identifier.
+func (r *DirConsistency) Name() string { return RuleDirConsistency }
+
+// Description returns what this rule checks.
+func (r *DirConsistency) Description() string {
+	return "text direction should be explicit and consistent"
+}
+
+// Check examines bdo elements and right-to-left content.
+func (r *DirConsistency) Check(doc *parser.Document) []Result {
+	var results []Result
+	report := func(line, col int, id string, sev Severity) {
+		results = append(results, Result{
+			Rule:      RuleDirConsistency,
+			Message:   catalogMessage(id, nil),
+			MessageID: id,
+			Filename:  doc.Filename,
+			Line:      line,
+			Col:       col,
+			Severity:  sev,
+		})
+	}
+
+	doc.Walk(func(n *parser.Node) bool {
+		switch n.Type {
+		case html.ElementNode:
+			if TagEquals(n, "bdo") {
+				switch dir := strings.ToLower(strings.TrimSpace(n.GetAttr("dir"))); {
+				case !n.HasAttr("dir"):
+					report(n.Line, n.Col, "dir-consistency.bdo-missing", Error)
+				case dir != "ltr" && dir != "rtl" && !IsTemplateExpr(dir):
+					report(n.Line, n.Col, "dir-consistency.bdo-invalid", Error)
+				}
+			}
+			if style := n.GetAttr("style"); style != "" && isRTL(n) && physicalStylePattern.MatchString(style) {
+				line, col := n.AttrPos("style")
+				report(line, col, "dir-consistency.physical-style", Warning)
+			}
+		case html.TextNode:
+			if n.Parent != nil && strings.ContainsAny(n.Data, directionalArrows) && isRTL(n.Parent) {
+				report(n.Parent.Line, n.Parent.Col, "dir-consistency.arrow", Warning)
+			}
+		}
+		return true
+	})
+
+	return results
+}
+
+// isRTL reports whether n's nearest explicit dir is rtl.
+func isRTL(n *parser.Node) bool {
+	for p := n; p != nil; p = p.Parent {
+		if p.Type != html.ElementNode || !p.HasAttr("dir") {
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(p.GetAttr("dir"))) {
+		case "rtl":
+			return true
+		case "ltr", "auto":
+			return false
+		}
+	}
+	return false
+}
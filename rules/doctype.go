@@ -11,6 +11,7 @@ import (
 const (
 	RuleDoctypeHTML    = "doctype-html"
 	RuleMissingDoctype = "missing-doctype"
+	RuleNoQuirksMode   = "no-quirks-mode"
 )
 
 // DoctypeHTML checks that DOCTYPE is the HTML5 doctype.
@@ -36,12 +37,13 @@ func (r *DoctypeHTML) Check(doc *parser.Document) []Result {
 		// HTML5 doctype should be just "html" with no public/system identifiers
 		if strings.ToLower(n.Data) != "html" {
 			results = append(results, Result{
-				Rule:     RuleDoctypeHTML,
-				Message:  "DOCTYPE should be html (HTML5)",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Warning,
+				Rule:      RuleDoctypeHTML,
+				Message:   catalogMessage("doctype.not-html5", nil),
+				MessageID: "doctype.not-html5",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
 			})
 		}
 
@@ -82,14 +84,56 @@ func (r *MissingDoctype) Check(doc *parser.Document) []Result {
 	// Only report if this looks like a full document (has <html>)
 	if hasHTML && !hasDoctype {
 		return []Result{{
-			Rule:     RuleMissingDoctype,
-			Message:  "document is missing DOCTYPE declaration",
-			Filename: doc.Filename,
-			Line:     1,
-			Col:      1,
-			Severity: Warning,
+			Rule:      RuleMissingDoctype,
+			Message:   catalogMessage("doctype.missing", nil),
+			MessageID: "doctype.missing",
+			Filename:  doc.Filename,
+			Line:      1,
+			Col:       1,
+			Severity:  Warning,
 		}}
 	}
 
 	return nil
 }
+
+// NoQuirksMode checks that a document's DOCTYPE selects standards mode.
+// Legacy doctypes such as HTML 4.01 Transitional without a system
+// identifier switch browsers to quirks mode, which changes the box model,
+// table layout, and CSS parsing; XHTML 1.0 Transitional and similar select
+// limited-quirks mode, which only changes line heights around images.
+// Documents without a DOCTYPE are left to missing-doctype.
+type NoQuirksMode struct{}
+
+// Name returns the rule identifier.
+func (r *NoQuirksMode) Name() string { return RuleNoQuirksMode }
+
+// Description returns what this rule checks.
+func (r *NoQuirksMode) Description() string {
+	return "DOCTYPE must not put the document in quirks mode"
+}
+
+// Check examines the document's DOCTYPE for the rendering mode it selects.
+func (r *NoQuirksMode) Check(doc *parser.Document) []Result {
+	dt := doc.Doctype()
+	if dt == nil || !doc.IsFullDocument {
+		return nil
+	}
+
+	id, severity := "doctype.quirks", Warning
+	switch dt.QuirksMode() {
+	case parser.NoQuirks:
+		return nil
+	case parser.LimitedQuirks:
+		id, severity = "doctype.limited-quirks", Info
+	}
+	return []Result{{
+		Rule:      RuleNoQuirksMode,
+		Message:   catalogMessage(id, nil),
+		MessageID: id,
+		Filename:  doc.Filename,
+		Line:      dt.Line,
+		Col:       dt.Col,
+		Severity:  severity,
+	}}
+}
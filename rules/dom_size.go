@@ -0,0 +1,112 @@
+package rules
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// Defaults for DOMSize, following common rendering performance guidance.
+const (
+	DefaultMaxDOMDepth    = 32
+	DefaultMaxDOMElements = 1400
+)
+
+// DOMSize warns about deeply nested or very large documents, which slow
+// down style recalculation, layout, and htmx swaps. It is opt-in and
+// accepts "max-depth" and "max-elements" options.
+type DOMSize struct {
+	MaxDepth    int
+	MaxElements int
+}
+
+// Name returns the rule identifier.
+func (r *DOMSize) Name() string { return RuleDOMSize }
+
+// Description returns what this rule checks.
+func (r *DOMSize) Description() string {
+	return "element nesting depth and element count should stay within limits"
+}
+
+// OptIn marks the rule as disabled unless explicitly enabled.
+func (r *DOMSize) OptIn() {}
+
+// ConfigureOptions applies max-depth and max-elements options.
+func (r *DOMSize) ConfigureOptions(opts map[string]any) {
+	r.MaxDepth = IntOption(opts, "max-depth", r.MaxDepth)
+	r.MaxElements = IntOption(opts, "max-elements", r.MaxElements)
+}
+
+// Check examines the document's element depth and count.
+func (r *DOMSize) Check(doc *parser.Document) []Result {
+	var results []Result
+
+	maxDepth := r.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = DefaultMaxDOMDepth
+	}
+	maxElements := r.MaxElements
+	if maxElements <= 0 {
+		maxElements = DefaultMaxDOMElements
+	}
+
+	var deepest *parser.Node
+	deepestDepth, count := 0, 0
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+		count++
+		if depth := elementDepth(n); depth > deepestDepth {
+			deepest, deepestDepth = n, depth
+		}
+		return true
+	})
+
+	if deepestDepth > maxDepth {
+		params := map[string]any{"depth": deepestDepth, "max": maxDepth, "chain": elementChain(deepest)}
+		results = append(results, Result{
+			Rule:      RuleDOMSize,
+			Message:   catalogMessage("dom-size.depth", params),
+			MessageID: "dom-size.depth",
+			Params:    params,
+			Filename:  doc.Filename,
+			Line:      deepest.Line,
+			Col:       deepest.Col,
+			Severity:  Warning,
+		})
+	}
+
+	if count > maxElements {
+		params := map[string]any{"count": count, "max": maxElements}
+		results = append(results, Result{
+			Rule:      RuleDOMSize,
+			Message:   catalogMessage("dom-size.elements", params),
+			MessageID: "dom-size.elements",
+			Params:    params,
+			Filename:  doc.Filename,
+			Line:      1,
+			Col:       1,
+			Severity:  Warning,
+		})
+	}
+
+	return results
+}
+
+// elementDepth counts n and its element ancestors.
+func elementDepth(n *parser.Node) int {
+	return len(n.Ancestors()) + 1
+}
+
+// elementChain renders the tag path from the root element to n.
+func elementChain(n *parser.Node) string {
+	tags := []string{Tag(n)}
+	for _, p := range n.Ancestors() {
+		tags = append(tags, Tag(p))
+	}
+	slices.Reverse(tags)
+	return strings.Join(tags, " > ")
+}
@@ -1,13 +1,14 @@
 package rules
 
 import (
-	"fmt"
-
 	"github.com/toba/go-html-validate/parser"
 	"golang.org/x/net/html"
 )
 
 // DuplicateID checks that id attributes are unique within a document.
+// The content of each <template>, including declarative shadow roots, is
+// a separate scope: it is not rendered in place, and each stamped copy or
+// shadow tree has its own IDs.
 type DuplicateID struct{}
 
 func (r *DuplicateID) Name() string { return RuleDuplicateID }
@@ -23,7 +24,7 @@ type idLocation struct {
 
 func (r *DuplicateID) Check(doc *parser.Document) []Result {
 	var results []Result
-	seenIDs := make(map[string]idLocation)
+	scopes := make(map[*parser.Node]map[string]idLocation) // keyed by enclosing template
 
 	doc.Walk(func(n *parser.Node) bool {
 		if n.Type != html.ElementNode {
@@ -40,14 +41,24 @@ func (r *DuplicateID) Check(doc *parser.Document) []Result {
 			return true
 		}
 
+		scope := n.ClosestAncestor("template")
+		seenIDs := scopes[scope]
+		if seenIDs == nil {
+			seenIDs = make(map[string]idLocation)
+			scopes[scope] = seenIDs
+		}
+
 		if first, exists := seenIDs[id]; exists {
+			params := map[string]any{"id": id, "line": first.line}
 			results = append(results, Result{
-				Rule:     r.Name(),
-				Message:  fmt.Sprintf("duplicate id %q (first defined at line %d)", id, first.line),
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Error,
+				Rule:      r.Name(),
+				Message:   catalogMessage("duplicate-id.repeat", params),
+				MessageID: "duplicate-id.repeat",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Error,
 			})
 		} else {
 			seenIDs[id] = idLocation{line: n.Line, col: n.Col}
@@ -58,3 +69,57 @@ func (r *DuplicateID) Check(doc *parser.Document) []Result {
 
 	return results
 }
+
+// NewTokenChecker returns a checker for streamed files. Template scopes
+// are tracked from <template> start and end tags.
+func (r *DuplicateID) NewTokenChecker(filename string) TokenChecker {
+	return &duplicateIDChecker{
+		filename: filename,
+		scopes:   []map[string]idLocation{make(map[string]idLocation)},
+	}
+}
+
+type duplicateIDChecker struct {
+	filename string
+	scopes   []map[string]idLocation // document scope, then open templates
+	results  []Result
+}
+
+func (c *duplicateIDChecker) Token(tok *parser.Token) {
+	switch tok.Type {
+	case html.EndTagToken:
+		if tok.Data == "template" && len(c.scopes) > 1 {
+			c.scopes = c.scopes[:len(c.scopes)-1]
+		}
+		return
+	case html.StartTagToken, html.SelfClosingTagToken:
+	default:
+		return
+	}
+
+	// The template's own id belongs to the enclosing scope.
+	if id, _ := tok.Attr("id"); id != "" {
+		seenIDs := c.scopes[len(c.scopes)-1]
+		if first, exists := seenIDs[id]; exists {
+			params := map[string]any{"id": id, "line": first.line}
+			c.results = append(c.results, Result{
+				Rule:      RuleDuplicateID,
+				Message:   catalogMessage("duplicate-id.repeat", params),
+				MessageID: "duplicate-id.repeat",
+				Params:    params,
+				Filename:  c.filename,
+				Line:      tok.Line,
+				Col:       tok.Col,
+				Severity:  Error,
+			})
+		} else {
+			seenIDs[id] = idLocation{line: tok.Line, col: tok.Col}
+		}
+	}
+
+	if tok.Type == html.StartTagToken && tok.Data == "template" {
+		c.scopes = append(c.scopes, make(map[string]idLocation))
+	}
+}
+
+func (c *duplicateIDChecker) Results() []Result { return c.results }
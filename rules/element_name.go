@@ -35,6 +35,26 @@ func (r *ElementName) Check(doc *parser.Document) []Result {
 			return true
 		}
 
+		// MathML elements are checked against the MathML vocabulary;
+		// mathml-structure reports MathML misplaced inside token elements
+		// and annotations hold other vocabularies
+		if n.Namespace == "math" || (MathMLElements[tagName] && n.ClosestAncestor("math") != nil) {
+			if !MathMLElements[tagName] && n.ClosestAncestor("annotation-xml") == nil {
+				params := map[string]any{"element": tagName}
+				results = append(results, Result{
+					Rule:      RuleElementName,
+					Message:   catalogMessage("element-name.unknown-mathml", params),
+					MessageID: "element-name.unknown-mathml",
+					Params:    params,
+					Filename:  doc.Filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Warning,
+				})
+			}
+			return true
+		}
+
 		// Check if it's a known valid element
 		if ValidElements[tagName] {
 			return true
@@ -47,25 +67,31 @@ func (r *ElementName) Check(doc *parser.Document) []Result {
 
 		// Check for common typos or invalid characters
 		if !isValidElementName(tagName) {
+			params := map[string]any{"element": tagName}
 			results = append(results, Result{
-				Rule:     RuleElementName,
-				Message:  "invalid element name: " + tagName,
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Error,
+				Rule:      RuleElementName,
+				Message:   catalogMessage("element-name.invalid", params),
+				MessageID: "element-name.invalid",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Error,
 			})
 			return true
 		}
 
 		// Unknown element (not in valid list, not custom, but syntactically valid)
+		params := map[string]any{"element": tagName}
 		results = append(results, Result{
-			Rule:     RuleElementName,
-			Message:  "unknown element: " + tagName,
-			Filename: doc.Filename,
-			Line:     n.Line,
-			Col:      n.Col,
-			Severity: Warning,
+			Rule:      RuleElementName,
+			Message:   catalogMessage("element-name.unknown", params),
+			MessageID: "element-name.unknown",
+			Params:    params,
+			Filename:  doc.Filename,
+			Line:      n.Line,
+			Col:       n.Col,
+			Severity:  Warning,
 		})
 
 		return true
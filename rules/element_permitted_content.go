@@ -37,20 +37,20 @@ func (r *ElementPermittedContent) Check(doc *parser.Document) []Result {
 
 		// Check for forbidden descendants
 		if len(spec.ForbiddenContent) > 0 {
-			for _, child := range n.Children {
-				if child.Type != html.ElementNode {
-					continue
-				}
+			for _, child := range n.ChildElements() {
 				childTag := strings.ToLower(child.Data)
 				for _, forbidden := range spec.ForbiddenContent {
 					if childTag == forbidden {
+						params := map[string]any{"element": tag, "forbidden": forbidden}
 						results = append(results, Result{
-							Rule:     RuleElementPermittedContent,
-							Message:  "<" + tag + "> must not contain <" + forbidden + ">",
-							Filename: doc.Filename,
-							Line:     child.Line,
-							Col:      child.Col,
-							Severity: Error,
+							Rule:      RuleElementPermittedContent,
+							Message:   catalogMessage("element-permitted-content.descendant", params),
+							MessageID: "element-permitted-content.descendant",
+							Params:    params,
+							Filename:  doc.Filename,
+							Line:      child.Line,
+							Col:       child.Col,
+							Severity:  Error,
 						})
 					}
 				}
@@ -69,10 +69,7 @@ func (r *ElementPermittedContent) Check(doc *parser.Document) []Result {
 		}
 
 		// Check each child element
-		for _, child := range n.Children {
-			if child.Type != html.ElementNode {
-				continue
-			}
+		for _, child := range n.ChildElements() {
 
 			childTag := strings.ToLower(child.Data)
 
@@ -82,13 +79,16 @@ func (r *ElementPermittedContent) Check(doc *parser.Document) []Result {
 			}
 
 			if !permitted[childTag] {
+				params := map[string]any{"child": childTag, "element": tag}
 				results = append(results, Result{
-					Rule:     RuleElementPermittedContent,
-					Message:  "<" + childTag + "> is not permitted as child of <" + tag + ">",
-					Filename: doc.Filename,
-					Line:     child.Line,
-					Col:      child.Col,
-					Severity: Error,
+					Rule:      RuleElementPermittedContent,
+					Message:   catalogMessage("element-permitted-content.child", params),
+					MessageID: "element-permitted-content.child",
+					Params:    params,
+					Filename:  doc.Filename,
+					Line:      child.Line,
+					Col:       child.Col,
+					Severity:  Error,
 				})
 			}
 		}
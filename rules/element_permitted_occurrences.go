@@ -65,13 +65,16 @@ func (r *ElementPermittedOccurrences) Check(doc *parser.Document) []Result {
 
 		// Report error only on second and subsequent occurrences
 		if count > 1 && n != firstOccurrence {
+			params := map[string]any{"element": tag, "context": contextTag}
 			results = append(results, Result{
-				Rule:     RuleElementPermittedOccurrences,
-				Message:  "<" + tag + "> must only appear once within <" + contextTag + ">",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Error,
+				Rule:      RuleElementPermittedOccurrences,
+				Message:   catalogMessage("element-permitted-occurrences.repeat", params),
+				MessageID: "element-permitted-occurrences.repeat",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Error,
 			})
 		}
 
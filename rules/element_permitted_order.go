@@ -52,10 +52,7 @@ func (r *ElementPermittedOrder) checkHTMLOrder(n *parser.Node, doc *parser.Docum
 	var headSeen, bodySeen bool
 	var bodyNode *parser.Node
 
-	for _, child := range n.Children {
-		if child.Type != html.ElementNode {
-			continue
-		}
+	for _, child := range n.ChildElements() {
 		childTag := strings.ToLower(child.Data)
 
 		if childTag == "body" {
@@ -66,12 +63,13 @@ func (r *ElementPermittedOrder) checkHTMLOrder(n *parser.Node, doc *parser.Docum
 			headSeen = true
 			if bodySeen {
 				results = append(results, Result{
-					Rule:     RuleElementPermittedOrder,
-					Message:  "<head> must come before <body>",
-					Filename: doc.Filename,
-					Line:     child.Line,
-					Col:      child.Col,
-					Severity: Error,
+					Rule:      RuleElementPermittedOrder,
+					Message:   catalogMessage("element-permitted-order.head", nil),
+					MessageID: "element-permitted-order.head",
+					Filename:  doc.Filename,
+					Line:      child.Line,
+					Col:       child.Col,
+					Severity:  Error,
 				})
 			}
 		}
@@ -93,10 +91,7 @@ func (r *ElementPermittedOrder) checkTableOrder(n *parser.Node, doc *parser.Docu
 	// Track what we've seen
 	var captionSeen, colgroupSeen, theadSeen, tbodySeen, trSeen bool
 
-	for _, child := range n.Children {
-		if child.Type != html.ElementNode {
-			continue
-		}
+	for _, child := range n.ChildElements() {
 		childTag := strings.ToLower(child.Data)
 
 		switch childTag {
@@ -104,36 +99,39 @@ func (r *ElementPermittedOrder) checkTableOrder(n *parser.Node, doc *parser.Docu
 			captionSeen = true
 			if colgroupSeen || theadSeen || tbodySeen || trSeen {
 				results = append(results, Result{
-					Rule:     RuleElementPermittedOrder,
-					Message:  "<caption> must be first child of <table>",
-					Filename: doc.Filename,
-					Line:     child.Line,
-					Col:      child.Col,
-					Severity: Error,
+					Rule:      RuleElementPermittedOrder,
+					Message:   catalogMessage("element-permitted-order.caption", nil),
+					MessageID: "element-permitted-order.caption",
+					Filename:  doc.Filename,
+					Line:      child.Line,
+					Col:       child.Col,
+					Severity:  Error,
 				})
 			}
 		case "colgroup":
 			colgroupSeen = true
 			if theadSeen || tbodySeen || trSeen {
 				results = append(results, Result{
-					Rule:     RuleElementPermittedOrder,
-					Message:  "<colgroup> must come before <thead>, <tbody>, <tfoot>, and <tr>",
-					Filename: doc.Filename,
-					Line:     child.Line,
-					Col:      child.Col,
-					Severity: Error,
+					Rule:      RuleElementPermittedOrder,
+					Message:   catalogMessage("element-permitted-order.colgroup", nil),
+					MessageID: "element-permitted-order.colgroup",
+					Filename:  doc.Filename,
+					Line:      child.Line,
+					Col:       child.Col,
+					Severity:  Error,
 				})
 			}
 		case "thead":
 			theadSeen = true
 			if tbodySeen || trSeen {
 				results = append(results, Result{
-					Rule:     RuleElementPermittedOrder,
-					Message:  "<thead> must come before <tbody> and <tr>",
-					Filename: doc.Filename,
-					Line:     child.Line,
-					Col:      child.Col,
-					Severity: Error,
+					Rule:      RuleElementPermittedOrder,
+					Message:   catalogMessage("element-permitted-order.thead", nil),
+					MessageID: "element-permitted-order.thead",
+					Filename:  doc.Filename,
+					Line:      child.Line,
+					Col:       child.Col,
+					Severity:  Error,
 				})
 			}
 		case "tbody", "tfoot":
@@ -153,21 +151,19 @@ func (r *ElementPermittedOrder) checkDetailsOrder(n *parser.Node, doc *parser.Do
 	var results []Result
 
 	var otherSeen bool
-	for _, child := range n.Children {
-		if child.Type != html.ElementNode {
-			continue
-		}
+	for _, child := range n.ChildElements() {
 		childTag := strings.ToLower(child.Data)
 
 		if childTag == "summary" {
 			if otherSeen {
 				results = append(results, Result{
-					Rule:     RuleElementPermittedOrder,
-					Message:  "<summary> must be first child of <details>",
-					Filename: doc.Filename,
-					Line:     child.Line,
-					Col:      child.Col,
-					Severity: Error,
+					Rule:      RuleElementPermittedOrder,
+					Message:   catalogMessage("element-permitted-order.summary", nil),
+					MessageID: "element-permitted-order.summary",
+					Filename:  doc.Filename,
+					Line:      child.Line,
+					Col:       child.Col,
+					Severity:  Error,
 				})
 			}
 			break // Only check first summary
@@ -183,21 +179,19 @@ func (r *ElementPermittedOrder) checkFieldsetOrder(n *parser.Node, doc *parser.D
 	var results []Result
 
 	var otherSeen bool
-	for _, child := range n.Children {
-		if child.Type != html.ElementNode {
-			continue
-		}
+	for _, child := range n.ChildElements() {
 		childTag := strings.ToLower(child.Data)
 
 		if childTag == "legend" {
 			if otherSeen {
 				results = append(results, Result{
-					Rule:     RuleElementPermittedOrder,
-					Message:  "<legend> must be first child of <fieldset>",
-					Filename: doc.Filename,
-					Line:     child.Line,
-					Col:      child.Col,
-					Severity: Error,
+					Rule:      RuleElementPermittedOrder,
+					Message:   catalogMessage("element-permitted-order.legend", nil),
+					MessageID: "element-permitted-order.legend",
+					Filename:  doc.Filename,
+					Line:      child.Line,
+					Col:       child.Col,
+					Severity:  Error,
 				})
 			}
 			break // Only check first legend
@@ -50,13 +50,16 @@ func (r *ElementPermittedParent) Check(doc *parser.Document) []Result {
 
 		// Parent not permitted
 		parentList := strings.Join(spec.PermittedParents, ", ")
+		params := map[string]any{"element": tag, "parents": parentList, "parent": parentTag}
 		results = append(results, Result{
-			Rule:     RuleElementPermittedParent,
-			Message:  "<" + tag + "> must be child of " + parentList + ", not <" + parentTag + ">",
-			Filename: doc.Filename,
-			Line:     n.Line,
-			Col:      n.Col,
-			Severity: Error,
+			Rule:      RuleElementPermittedParent,
+			Message:   catalogMessage("element-permitted-parent.parent", params),
+			MessageID: "element-permitted-parent.parent",
+			Params:    params,
+			Filename:  doc.Filename,
+			Line:      n.Line,
+			Col:       n.Col,
+			Severity:  Error,
 		})
 
 		return true
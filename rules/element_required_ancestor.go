@@ -40,21 +40,24 @@ func (r *ElementRequiredAncestor) Check(doc *parser.Document) []Result {
 			return true
 		}
 
-		// For template fragments, skip errors for top-level orphaned elements.
+		// For template fragments and htmx partials, skip errors for top-level orphaned elements.
 		// These are meant to be included into parent templates that provide ancestors.
-		if doc.IsTemplateFragment && isTopLevel(n) {
+		if (doc.IsTemplateFragment || doc.IsPartial) && isTopLevel(n) {
 			return true
 		}
 
 		// Missing required ancestor
 		ancestorList := strings.Join(requiredAncestors, ", ")
+		params := map[string]any{"element": tag, "ancestors": ancestorList}
 		results = append(results, Result{
-			Rule:     RuleElementRequiredAncestor,
-			Message:  "<" + tag + "> requires ancestor: " + ancestorList,
-			Filename: doc.Filename,
-			Line:     n.Line,
-			Col:      n.Col,
-			Severity: Error,
+			Rule:      RuleElementRequiredAncestor,
+			Message:   catalogMessage("element-required-ancestor.missing", params),
+			MessageID: "element-required-ancestor.missing",
+			Params:    params,
+			Filename:  doc.Filename,
+			Line:      n.Line,
+			Col:       n.Col,
+			Severity:  Error,
 		})
 
 		return true
@@ -68,12 +71,5 @@ func (r *ElementRequiredAncestor) Check(doc *parser.Document) []Result {
 // because they're meant to be included in parent templates.
 func isTopLevel(n *parser.Node) bool {
 	// Check parent chain depth - if within 2-3 levels of root, consider top-level
-	depth := 0
-	for p := n.Parent; p != nil; p = p.Parent {
-		depth++
-		if depth > 3 {
-			return false
-		}
-	}
-	return true
+	return n.Depth() <= 3
 }
@@ -38,13 +38,16 @@ func (r *ElementRequiredAttributes) Check(doc *parser.Document) []Result {
 		// Check for each required attribute
 		for _, attr := range spec.RequiredAttributes {
 			if !n.HasAttr(attr) {
+				params := map[string]any{"element": tag, "attr": attr}
 				results = append(results, Result{
-					Rule:     RuleElementRequiredAttributes,
-					Message:  "<" + tag + "> requires attribute: " + attr,
-					Filename: doc.Filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Error,
+					Rule:      RuleElementRequiredAttributes,
+					Message:   catalogMessage("element-required-attributes.missing", params),
+					MessageID: "element-required-attributes.missing",
+					Params:    params,
+					Filename:  doc.Filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Error,
 				})
 			}
 		}
@@ -37,22 +37,23 @@ func (r *ElementRequiredContent) Check(doc *parser.Document) []Result {
 
 		// Build set of child element tags
 		childTags := make(map[string]bool)
-		for _, child := range n.Children {
-			if child.Type == html.ElementNode {
-				childTags[strings.ToLower(child.Data)] = true
-			}
+		for _, child := range n.ChildElements() {
+			childTags[strings.ToLower(child.Data)] = true
 		}
 
 		// Check for each required child
 		for _, required := range spec.RequiredChildren {
 			if !childTags[required] {
+				params := map[string]any{"element": tag, "child": required}
 				results = append(results, Result{
-					Rule:     RuleElementRequiredContent,
-					Message:  "<" + tag + "> requires child element: <" + required + ">",
-					Filename: doc.Filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Error,
+					Rule:      RuleElementRequiredContent,
+					Message:   catalogMessage("element-required-content.missing", params),
+					MessageID: "element-required-content.missing",
+					Params:    params,
+					Filename:  doc.Filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Error,
 				})
 			}
 		}
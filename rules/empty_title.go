@@ -31,12 +31,13 @@ func (r *EmptyTitle) Check(doc *parser.Document) []Result {
 		text := strings.TrimSpace(n.TextContent())
 		if text == "" {
 			results = append(results, Result{
-				Rule:     r.Name(),
-				Message:  "<title> cannot be empty, must have text content",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Error,
+				Rule:      r.Name(),
+				Message:   catalogMessage("empty-title.empty", nil),
+				MessageID: "empty-title.empty",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Error,
 			})
 		}
 
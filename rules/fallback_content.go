@@ -0,0 +1,75 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// FallbackContent warns about <canvas> and <object> elements without
+// fallback content or an accessible name, and <embed> elements, which
+// cannot have fallback content, without an accessible name. Screen readers
+// get nothing from the rendered pixels or plugin content, and the fallback
+// is often lost when a component is copied. Elements hidden with
+// aria-hidden="true" or role="presentation" are skipped, as is an <embed>
+// that is itself the fallback of an <object>.
+type FallbackContent struct{}
+
+// Name returns the rule identifier.
+func (r *FallbackContent) Name() string { return RuleFallbackContent }
+
+// Description returns what this rule checks.
+func (r *FallbackContent) Description() string {
+	return "canvas, object, and embed elements need fallback content or an accessible name"
+}
+
+// Check examines canvas, object, and embed elements.
+func (r *FallbackContent) Check(doc *parser.Document) []Result {
+	var results []Result
+
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.ElementNode || !TagIn(n, "canvas", "object", "embed") || isDecorative(n) {
+			return true
+		}
+
+		var id string
+		switch Tag(n) {
+		case "canvas":
+			if !HasAccessibleName(n) {
+				id = "fallback-content.canvas"
+			}
+		case "object":
+			if !HasAccessibleName(n) {
+				id = "fallback-content.object"
+			}
+		case "embed":
+			if !HasAncestor(n, "object") && n.GetAttr("aria-label") == "" && !n.HasAttr("aria-labelledby") && n.GetAttr("title") == "" {
+				id = "fallback-content.embed"
+			}
+		}
+		if id != "" {
+			results = append(results, Result{
+				Rule:      RuleFallbackContent,
+				Message:   catalogMessage(id, nil),
+				MessageID: id,
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
+			})
+		}
+		return true
+	})
+
+	return results
+}
+
+// isDecorative reports whether n is hidden from assistive technology.
+func isDecorative(n *parser.Node) bool {
+	if strings.EqualFold(n.GetAttr("aria-hidden"), "true") {
+		return true
+	}
+	role := strings.ToLower(strings.TrimSpace(n.GetAttr("role")))
+	return role == "presentation" || role == "none"
+}
@@ -0,0 +1,151 @@
+package rules
+
+import (
+	"bytes"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// defaultCSRFPattern matches common CSRF token field and header names,
+// e.g. csrf_token, X-CSRF-Token, gorilla.csrf.Token, authenticity_token.
+var defaultCSRFPattern = regexp.MustCompile(`(?i)csrf|xsrf|authenticity_token`)
+
+// FormCSRFToken warns when a state-changing form or htmx request carries
+// no CSRF token. A POST form passes with a hidden input, or a template
+// action such as {{ .CSRFField }}, whose name matches the token pattern.
+// An hx-post, hx-put, hx-patch, or hx-delete element passes with a matching
+// hx-headers or hx-vals entry on itself or an ancestor, an enclosing form
+// that passes, or a matching <meta> tag for script-configured headers.
+//
+// The rule is opt-in; the "token-pattern" option replaces the default
+// regular expression.
+type FormCSRFToken struct {
+	Pattern *regexp.Regexp
+}
+
+// Name returns the rule identifier.
+func (r *FormCSRFToken) Name() string { return RuleFormCSRFToken }
+
+// Description returns what this rule checks.
+func (r *FormCSRFToken) Description() string {
+	return "POST forms and htmx requests should include a CSRF token"
+}
+
+// OptIn marks the rule as disabled unless explicitly enabled.
+func (r *FormCSRFToken) OptIn() {}
+
+// ConfigureOptions applies the token-pattern option. An invalid pattern
+// leaves the current one in place.
+func (r *FormCSRFToken) ConfigureOptions(opts map[string]any) {
+	if p := StringOption(opts, "token-pattern", ""); p != "" {
+		if re, err := regexp.Compile(p); err == nil {
+			r.Pattern = re
+		}
+	}
+}
+
+// htmxWriteAttrs are htmx request attributes for state-changing methods.
+var htmxWriteAttrs = []string{"hx-post", "hx-put", "hx-patch", "hx-delete"}
+
+// Check examines POST forms and htmx write requests for a CSRF token.
+func (r *FormCSRFToken) Check(doc *parser.Document) []Result {
+	var results []Result
+
+	pattern := r.Pattern
+	if pattern == nil {
+		pattern = defaultCSRFPattern
+	}
+
+	metaToken := false
+	for _, meta := range doc.QuerySelectorAll("meta[name]") {
+		if pattern.MatchString(meta.GetAttr("name")) {
+			metaToken = true
+		}
+	}
+
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+
+		postForm := TagEquals(n, "form") && strings.EqualFold(strings.TrimSpace(n.GetAttr("method")), "post")
+		if postForm && !formHasToken(doc, n, pattern) {
+			results = append(results, Result{
+				Rule:      RuleFormCSRFToken,
+				Message:   catalogMessage("form-csrf-token.form", nil),
+				MessageID: "form-csrf-token.form",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
+			})
+		}
+
+		for _, attr := range htmxWriteAttrs {
+			if postForm || !n.HasAttr(attr) {
+				continue
+			}
+			if !metaToken && !htmxHasToken(doc, n, pattern) {
+				line, col := n.AttrPos(attr)
+				params := map[string]any{"attr": attr}
+				results = append(results, Result{
+					Rule:      RuleFormCSRFToken,
+					Message:   catalogMessage("form-csrf-token.htmx", params),
+					MessageID: "form-csrf-token.htmx",
+					Params:    params,
+					Filename:  doc.Filename,
+					Line:      line,
+					Col:       col,
+					Severity:  Warning,
+				})
+			}
+			break
+		}
+
+		return true
+	})
+
+	return results
+}
+
+// formHasToken reports whether a form contains a hidden token input, or a
+// template action naming the token in its original source.
+func formHasToken(doc *parser.Document, form *parser.Node, pattern *regexp.Regexp) bool {
+	for _, input := range form.QuerySelectorAll("input") {
+		if strings.EqualFold(input.GetAttr("type"), "hidden") && pattern.MatchString(input.GetAttr("name")) {
+			return true
+		}
+	}
+
+	src := doc.SourceRange(form.Line, form.Col, math.MaxInt, 1)
+	if end := bytes.Index(bytes.ToLower(src), []byte("</form")); end >= 0 {
+		src = src[:end]
+	}
+	for _, action := range templateActionBounds.FindAll(src, -1) {
+		if pattern.Match(action) {
+			return true
+		}
+	}
+	return false
+}
+
+// htmxHasToken reports whether an htmx request from n sends a token in
+// inherited hx-headers or hx-vals, or includes a form that has one.
+func htmxHasToken(doc *parser.Document, n *parser.Node, pattern *regexp.Regexp) bool {
+	for p := n; p != nil; p = p.Parent {
+		if p.Type != html.ElementNode {
+			continue
+		}
+		if pattern.MatchString(p.GetAttr("hx-headers")) || pattern.MatchString(p.GetAttr("hx-vals")) {
+			return true
+		}
+		if TagEquals(p, "form") {
+			return formHasToken(doc, p, pattern)
+		}
+	}
+	return false
+}
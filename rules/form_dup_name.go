@@ -86,13 +86,16 @@ func (r *FormDupName) Check(doc *parser.Document) []Result {
 				// Report duplicate for non-radio/checkbox controls
 				for i := 1; i < len(controls); i++ {
 					ctrl := controls[i]
+					params := map[string]any{"name": name}
 					results = append(results, Result{
-						Rule:     RuleFormDupName,
-						Message:  "duplicate form control name: " + name,
-						Filename: doc.Filename,
-						Line:     ctrl.node.Line,
-						Col:      ctrl.node.Col,
-						Severity: Warning,
+						Rule:      RuleFormDupName,
+						Message:   catalogMessage("form-dup-name.repeat", params),
+						MessageID: "form-dup-name.repeat",
+						Params:    params,
+						Filename:  doc.Filename,
+						Line:      ctrl.node.Line,
+						Col:       ctrl.node.Col,
+						Severity:  Warning,
 					})
 				}
 			}
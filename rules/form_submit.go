@@ -30,12 +30,13 @@ func (r *FormSubmit) Check(doc *parser.Document) []Result {
 		// Check if form has a submit button
 		if !HasDescendant(n, isSubmitButton) {
 			results = append(results, Result{
-				Rule:     r.Name(),
-				Message:  "form has no submit button",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Error,
+				Rule:      r.Name(),
+				Message:   catalogMessage("form-submit.missing", nil),
+				MessageID: "form-submit.missing",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Error,
 			})
 		}
 
@@ -0,0 +1,155 @@
+package rules
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// FrameworkRemnants flags attributes of frontend frameworks the project
+// has not declared, such as ng-* from AngularJS, v-* and :/@ shorthands
+// from Vue, x-* from Alpine.js, data-turbo-* from Turbo, and hx-* from
+// htmx. Markup copied from an older codebase often carries them along,
+// and since nothing on the page interprets them they fail silently.
+// Frameworks are declared with frameworks.allowed in config, and htmx
+// also with frameworks.htmx. The rule is opt-in.
+type FrameworkRemnants struct {
+	htmxEnabled bool
+	allowed     []string
+}
+
+// Name returns the rule identifier.
+func (r *FrameworkRemnants) Name() string { return RuleFrameworkRemnants }
+
+// Description returns what this rule checks.
+func (r *FrameworkRemnants) Description() string {
+	return "attributes of frontend frameworks must belong to a configured framework"
+}
+
+// OptIn marks the rule as disabled unless explicitly enabled.
+func (r *FrameworkRemnants) OptIn() {}
+
+// Configure implements HTMXConfigurable.
+func (r *FrameworkRemnants) Configure(htmxEnabled bool, _ string) {
+	r.htmxEnabled = htmxEnabled
+}
+
+// ConfigureFrameworks implements FrameworksConfigurable.
+func (r *FrameworkRemnants) ConfigureFrameworks(allowed []string) {
+	r.allowed = allowed
+}
+
+// Framework names accepted in frameworks.allowed.
+const (
+	FrameworkHTMX    = "htmx"
+	FrameworkAngular = "angular"
+	FrameworkVue     = "vue"
+	FrameworkAlpine  = "alpine"
+	FrameworkTurbo   = "turbo"
+)
+
+// frameworkTitles are the display names of the known frameworks.
+var frameworkTitles = map[string]string{
+	FrameworkHTMX:    "htmx",
+	FrameworkAngular: "Angular",
+	FrameworkVue:     "Vue",
+	FrameworkAlpine:  "Alpine.js",
+	FrameworkTurbo:   "Turbo",
+}
+
+// frameworkPrefixes maps attribute name prefixes to the frameworks using
+// them. Vue and Alpine.js share the : and @ shorthands.
+var frameworkPrefixes = []struct {
+	prefix     string
+	frameworks []string
+}{
+	{"hx-", []string{FrameworkHTMX}},
+	{"data-hx-", []string{FrameworkHTMX}},
+	{"ng-", []string{FrameworkAngular}},
+	{"data-ng-", []string{FrameworkAngular}},
+	{"*ng", []string{FrameworkAngular}},
+	{"[", []string{FrameworkAngular}},
+	{"(", []string{FrameworkAngular}},
+	{"v-", []string{FrameworkVue}},
+	{"x-", []string{FrameworkAlpine}},
+	{":", []string{FrameworkVue, FrameworkAlpine}},
+	{"@", []string{FrameworkVue, FrameworkAlpine}},
+	{"data-turbo", []string{FrameworkTurbo}},
+}
+
+// Check examines element attributes for undeclared frameworks.
+func (r *FrameworkRemnants) Check(doc *parser.Document) []Result {
+	var results []Result
+
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+
+		// attributes by the frameworks they may belong to, in order
+		var groups [][]string
+		attrs := make(map[string][]string)
+		for _, attr := range n.Attr {
+			name := strings.ToLower(attr.Key)
+			frameworks := r.undeclared(name)
+			if frameworks == nil || IsTemplateExpr(name) {
+				continue
+			}
+			key := strings.Join(frameworks, " ")
+			if attrs[key] == nil {
+				groups = append(groups, frameworks)
+			}
+			attrs[key] = append(attrs[key], name)
+		}
+
+		for _, frameworks := range groups {
+			names := attrs[strings.Join(frameworks, " ")]
+			titles := make([]string, len(frameworks))
+			for i, f := range frameworks {
+				titles[i] = frameworkTitles[f]
+			}
+			kind := strings.Join(titles, " or ")
+			line, col := n.AttrPos(names[0])
+			params := map[string]any{"element": Tag(n), "framework": kind, "count": len(frameworks), "attrs": strings.Join(names, ", ")}
+			results = append(results, Result{
+				Rule:      RuleFrameworkRemnants,
+				Message:   catalogMessage("framework-remnants.undeclared", params),
+				MessageID: "framework-remnants.undeclared",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      line,
+				Col:       col,
+				Severity:  Warning,
+			})
+		}
+
+		return true
+	})
+
+	return results
+}
+
+// undeclared returns the frameworks an attribute name may belong to, or
+// nil if it belongs to none or to a declared one.
+func (r *FrameworkRemnants) undeclared(name string) []string {
+	for _, p := range frameworkPrefixes {
+		if !strings.HasPrefix(name, p.prefix) || len(name) == len(p.prefix) {
+			continue
+		}
+		if slices.ContainsFunc(p.frameworks, r.declared) {
+			return nil
+		}
+		return p.frameworks
+	}
+	return nil
+}
+
+// declared reports whether config declares the framework.
+func (r *FrameworkRemnants) declared(framework string) bool {
+	if framework == FrameworkHTMX && r.htmxEnabled {
+		return true
+	}
+	return slices.ContainsFunc(r.allowed, func(a string) bool { return strings.EqualFold(a, framework) })
+}
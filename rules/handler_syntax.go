@@ -0,0 +1,286 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// HandlerSyntax scans the JavaScript in event handler attributes (onclick,
+// hx-on:click, hx-on::after-request) for syntax errors browsers only report
+// in the console when the handler first runs: unbalanced parentheses,
+// brackets, and braces, and unterminated strings, template literals,
+// comments, and regular expressions. It is a lightweight scan, not a full
+// parser, so it reports structural mistakes rather than every invalid
+// program. This rule is opt-in.
+type HandlerSyntax struct{}
+
+// Name returns the rule identifier.
+func (r *HandlerSyntax) Name() string { return RuleHandlerSyntax }
+
+// Description returns what this rule checks.
+func (r *HandlerSyntax) Description() string {
+	return "event handler attributes must contain well-formed JavaScript"
+}
+
+// OptIn marks the rule as disabled unless explicitly enabled.
+func (r *HandlerSyntax) OptIn() {}
+
+// Check scans each event handler attribute value.
+func (r *HandlerSyntax) Check(doc *parser.Document) []Result {
+	var results []Result
+
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+		for _, attr := range n.Attr {
+			name := strings.ToLower(attr.Key)
+			if !isHandlerAttr(name) || strings.TrimSpace(attr.Val) == "" {
+				continue
+			}
+			// template syntax the preprocessor left in place is not JavaScript
+			if strings.Contains(attr.Val, "{{") || strings.Contains(attr.Val, "{%") {
+				continue
+			}
+			if err := scanJS(attr.Val); err != nil {
+				line, col := n.AttrPos(attr.Key)
+				params := map[string]any{"attr": name, "error": err.Error()}
+				results = append(results, Result{
+					Rule:      r.Name(),
+					Message:   catalogMessage("handler-syntax.error", params),
+					MessageID: "handler-syntax.error",
+					Params:    params,
+					Filename:  doc.Filename,
+					Line:      line,
+					Col:       col,
+					Severity:  Error,
+				})
+			}
+		}
+		return true
+	})
+
+	return results
+}
+
+// isHandlerAttr reports whether name is an inline event handler: on
+// followed by an event name, or an htmx hx-on:* or hx-on-* attribute.
+func isHandlerAttr(name string) bool {
+	if strings.HasPrefix(name, "hx-on:") || strings.HasPrefix(name, "hx-on-") {
+		return true
+	}
+	if len(name) <= 2 || !strings.HasPrefix(name, "on") {
+		return false
+	}
+	for _, c := range name[2:] {
+		if c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// regexKeywords are the keywords after which a slash starts a regular
+// expression rather than a division.
+var regexKeywords = map[string]bool{
+	"return": true, "typeof": true, "instanceof": true, "in": true, "of": true,
+	"new": true, "delete": true, "void": true, "throw": true, "case": true,
+	"do": true, "else": true, "yield": true, "await": true,
+}
+
+var (
+	errUnterminatedString   = errors.New("unterminated string")
+	errUnterminatedTemplate = errors.New("unterminated template literal")
+	errUnterminatedComment  = errors.New("unterminated comment")
+	errUnterminatedRegexp   = errors.New("unterminated regular expression")
+)
+
+// scanJS tokenizes src just enough to check that its strings, comments,
+// template literals, and regular expressions are terminated and its
+// brackets balance. Inside a template literal, "${" is kept on the
+// bracket stack so the "}" closing the substitution resumes the literal.
+func scanJS(src string) error {
+	var stack []byte // open brackets; '$' marks a template substitution
+	// division is true when a slash after the last token divides, so a
+	// slash elsewhere starts a regular expression
+	division := false
+
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f':
+			i++
+		case c == '/' && i+1 < len(src) && src[i+1] == '/':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(src) && src[i+1] == '*':
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return errUnterminatedComment
+			}
+			i += end + 4
+		case c == '/' && !division:
+			end, err := scanRegexp(src, i+1)
+			if err != nil {
+				return err
+			}
+			i, division = end, true
+		case c == '\'' || c == '"':
+			end, err := scanString(src, i+1, c)
+			if err != nil {
+				return err
+			}
+			i, division = end, true
+		case c == '`':
+			end, open, err := scanTemplate(src, i+1)
+			if err != nil {
+				return err
+			}
+			if open {
+				stack = append(stack, '$')
+				division = false
+			} else {
+				division = true
+			}
+			i = end
+		case c == '(' || c == '[' || c == '{':
+			stack = append(stack, c)
+			i++
+			division = false
+		case c == ')' || c == ']' || c == '}':
+			if len(stack) == 0 {
+				return fmt.Errorf("unexpected '%c'", c)
+			}
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if top == '$' && c == '}' {
+				end, open, err := scanTemplate(src, i+1)
+				if err != nil {
+					return err
+				}
+				if open {
+					stack = append(stack, '$')
+					division = false
+				} else {
+					division = true
+				}
+				i = end
+				continue
+			}
+			if want := closerOf(top); want != c {
+				return fmt.Errorf("unexpected '%c', expected '%c'", c, want)
+			}
+			i++
+			division = c != '}'
+		case isIdentByte(c):
+			start := i
+			for i < len(src) && isIdentByte(src[i]) {
+				i++
+			}
+			division = !regexKeywords[src[start:i]]
+		default:
+			i++
+			division = false
+		}
+	}
+
+	if len(stack) > 0 {
+		top := stack[len(stack)-1]
+		if top == '$' {
+			return errUnterminatedTemplate
+		}
+		return fmt.Errorf("unclosed '%c'", top)
+	}
+	return nil
+}
+
+// closerOf returns the bracket that closes open.
+func closerOf(open byte) byte {
+	switch open {
+	case '(':
+		return ')'
+	case '[':
+		return ']'
+	}
+	return '}'
+}
+
+// isIdentByte reports whether c continues an identifier or number; bytes
+// of non-ASCII identifiers count as well.
+func isIdentByte(c byte) bool {
+	return c == '_' || c == '$' || c == '.' || c >= 0x80 ||
+		'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
+
+// scanString returns the offset after the string literal whose body starts
+// at i and is closed by quote. A line break ends a string unterminated
+// unless escaped.
+func scanString(src string, i int, quote byte) (int, error) {
+	for i < len(src) {
+		switch src[i] {
+		case '\\':
+			i += 2
+		case quote:
+			return i + 1, nil
+		case '\n', '\r':
+			return 0, errUnterminatedString
+		default:
+			i++
+		}
+	}
+	return 0, errUnterminatedString
+}
+
+// scanTemplate returns the offset after the template literal text starting
+// at i, and whether it stopped at a "${" substitution rather than the
+// closing backtick.
+func scanTemplate(src string, i int) (end int, open bool, err error) {
+	for i < len(src) {
+		switch {
+		case src[i] == '\\':
+			i += 2
+		case src[i] == '`':
+			return i + 1, false, nil
+		case src[i] == '$' && i+1 < len(src) && src[i+1] == '{':
+			return i + 2, true, nil
+		default:
+			i++
+		}
+	}
+	return 0, false, errUnterminatedTemplate
+}
+
+// scanRegexp returns the offset after the regular expression literal whose
+// body starts at i, skipping its flags. A slash inside a character class
+// does not end the literal.
+func scanRegexp(src string, i int) (int, error) {
+	inClass := false
+	for i < len(src) {
+		switch src[i] {
+		case '\\':
+			i += 2
+			continue
+		case '[':
+			inClass = true
+		case ']':
+			inClass = false
+		case '/':
+			if !inClass {
+				i++
+				for i < len(src) && isIdentByte(src[i]) {
+					i++
+				}
+				return i, nil
+			}
+		case '\n', '\r':
+			return 0, errUnterminatedRegexp
+		}
+		i++
+	}
+	return 0, errUnterminatedRegexp
+}
@@ -0,0 +1,113 @@
+package rules
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// defaultAnchorPattern matches lowercase, hyphen-separated slugs such as
+// "getting-started" or "step-2".
+var defaultAnchorPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// HeadingAnchor requires headings within <article> and <main> to carry an
+// id, so a table of contents or a shared link can point at the section.
+// Ids must match the slug pattern and be unique within the page. Files
+// that define or call named templates are checked in the pages composed
+// from them, so a heading in a partial may not reuse an id from the layout.
+//
+// The rule is opt-in; the "slug-pattern" option replaces the default
+// regular expression.
+type HeadingAnchor struct {
+	Pattern *regexp.Regexp
+}
+
+// Name returns the rule identifier.
+func (r *HeadingAnchor) Name() string { return RuleHeadingAnchor }
+
+// Description returns what this rule checks.
+func (r *HeadingAnchor) Description() string {
+	return "headings in article and main content need unique slug ids for deep linking"
+}
+
+// OptIn marks the rule as disabled unless explicitly enabled.
+func (r *HeadingAnchor) OptIn() {}
+
+// ConfigureOptions applies the slug-pattern option. An invalid pattern
+// leaves the current one in place.
+func (r *HeadingAnchor) ConfigureOptions(opts map[string]any) {
+	if p := StringOption(opts, "slug-pattern", ""); p != "" {
+		if re, err := regexp.Compile(p); err == nil {
+			r.Pattern = re
+		}
+	}
+}
+
+// Check examines headings of files that take no part in composition.
+func (r *HeadingAnchor) Check(doc *parser.Document) []Result {
+	if composes(doc.Source()) {
+		return nil
+	}
+	return r.check(doc)
+}
+
+// CheckProject examines headings in the pages composed from files.
+func (r *HeadingAnchor) CheckProject(files []SourceFile) []Result {
+	return checkComposed(files, r.check)
+}
+
+func (r *HeadingAnchor) check(doc *parser.Document) []Result {
+	var results []Result
+
+	pattern := r.Pattern
+	if pattern == nil {
+		pattern = defaultAnchorPattern
+	}
+
+	report := func(line, col int, msgID string, params map[string]any) {
+		results = append(results, Result{
+			Rule:      RuleHeadingAnchor,
+			Message:   catalogMessage(msgID, params),
+			MessageID: msgID,
+			Params:    params,
+			Filename:  doc.Filename,
+			Line:      line,
+			Col:       col,
+			Severity:  Warning,
+		})
+	}
+
+	seen := make(map[string]bool)
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+
+		id := strings.TrimSpace(n.GetAttr("id"))
+		heading := HeadingRank(n.Data) > 0 && HasAncestor(n, "article", "main")
+		switch {
+		case heading && !n.HasAttr("id"):
+			report(n.Line, n.Col, "heading-anchor.missing", map[string]any{"element": n.Data})
+		case heading && id == "":
+			line, col := n.AttrPos("id")
+			report(line, col, "heading-anchor.empty", map[string]any{"element": n.Data})
+		case heading && IsTemplateExpr(id):
+			// rendered ids can't be checked for format or uniqueness
+		case heading && !pattern.MatchString(id):
+			line, col := n.AttrPos("id")
+			report(line, col, "heading-anchor.slug", map[string]any{"id": id})
+		case heading && seen[id]:
+			line, col := n.AttrPos("id")
+			report(line, col, "heading-anchor.duplicate", map[string]any{"id": id})
+		}
+		if id != "" && !IsTemplateExpr(id) {
+			seen[id] = true
+		}
+
+		return true
+	})
+
+	return results
+}
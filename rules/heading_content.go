@@ -1,7 +1,6 @@
 package rules
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/toba/go-html-validate/parser"
@@ -30,13 +29,16 @@ func (r *HeadingContent) Check(doc *parser.Document) []Result {
 		}
 
 		if !HasAccessibleName(n) {
+			params := map[string]any{"element": n.Data}
 			results = append(results, Result{
-				Rule:     r.Name(),
-				Message:  fmt.Sprintf("<%s> element has no text content", n.Data),
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Error,
+				Rule:      r.Name(),
+				Message:   catalogMessage("heading-content.empty", params),
+				MessageID: "heading-content.empty",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Error,
 			})
 		}
 
@@ -1,13 +1,13 @@
 package rules
 
 import (
-	"fmt"
-
 	"github.com/toba/go-html-validate/parser"
 	"golang.org/x/net/html"
 )
 
 // HeadingLevel checks that heading levels don't skip (e.g., h1 to h3).
+// Files that define or call named templates are checked as part of the
+// pages composed from them, so a partial's headings follow the layout's.
 type HeadingLevel struct{}
 
 func (r *HeadingLevel) Name() string { return RuleHeadingLevel }
@@ -17,6 +17,17 @@ func (r *HeadingLevel) Description() string {
 }
 
 func (r *HeadingLevel) Check(doc *parser.Document) []Result {
+	if composes(doc.Source()) {
+		return nil
+	}
+	return r.check(doc)
+}
+
+func (r *HeadingLevel) CheckProject(files []SourceFile) []Result {
+	return checkComposed(files, r.check)
+}
+
+func (r *HeadingLevel) check(doc *parser.Document) []Result {
 	var results []Result
 	lastRank := 0
 
@@ -34,13 +45,16 @@ func (r *HeadingLevel) Check(doc *parser.Document) []Result {
 		// First heading can be any level (templates may be partials)
 		// But subsequent headings must not skip more than one level
 		if lastRank > 0 && rank > lastRank+1 {
+			params := map[string]any{"from": lastRank, "to": rank}
 			results = append(results, Result{
-				Rule:     r.Name(),
-				Message:  fmt.Sprintf("heading level skipped from h%d to h%d", lastRank, rank),
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Warning,
+				Rule:      r.Name(),
+				Message:   catalogMessage("heading-level.skip", params),
+				MessageID: "heading-level.skip",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
 			})
 		}
 
@@ -1,8 +1,11 @@
 package rules
 
 import (
+	"bytes"
+	"regexp"
 	"strings"
 
+	"github.com/toba/go-html-validate/messages"
 	"github.com/toba/go-html-validate/parser"
 	"golang.org/x/net/html"
 )
@@ -157,34 +160,17 @@ func GetImplicitRole(tagName string, n *parser.Node) string {
 // AncestorWithTag walks up the tree looking for an ancestor with the given tag.
 // Returns the first matching ancestor or nil if none found.
 func AncestorWithTag(n *parser.Node, tag string) *parser.Node {
-	tag = strings.ToLower(tag)
-	for p := n.Parent; p != nil; p = p.Parent {
-		if p.Type == html.ElementNode && strings.ToLower(p.Data) == tag {
-			return p
-		}
-	}
-	return nil
+	return n.ClosestAncestor(tag)
 }
 
 // HasAncestor returns true if the node has an ancestor matching any of the given tags.
 func HasAncestor(n *parser.Node, tags ...string) bool {
-	for _, tag := range tags {
-		if AncestorWithTag(n, tag) != nil {
-			return true
-		}
-	}
-	return false
+	return n.ClosestAncestor(tags...) != nil
 }
 
 // ChildElements returns only element node children (excludes text, comments, etc.).
 func ChildElements(n *parser.Node) []*parser.Node {
-	var children []*parser.Node
-	for _, child := range n.Children {
-		if child.Type == html.ElementNode {
-			children = append(children, child)
-		}
-	}
-	return children
+	return n.ChildElements()
 }
 
 // FirstChildElement returns the first element child, or nil if none.
@@ -228,3 +214,100 @@ func IsCustomElement(tagName string) bool {
 	// Must contain a hyphen
 	return strings.Contains(tagName, "-")
 }
+
+// catalogMessage renders the English text of a message catalog entry, for
+// use as Result.Message alongside Result.MessageID and Result.Params.
+func catalogMessage(id string, params map[string]any) string {
+	msg, _ := messages.Render(messages.DefaultLocale, id, params)
+	return msg
+}
+
+// message is a catalog entry with its parameters, returned by helpers
+// that pick a finding's message before its Result is built. The zero
+// value means no finding.
+type message struct {
+	id     string
+	params map[string]any
+}
+
+// text renders the message in English.
+func (m message) text() string {
+	return catalogMessage(m.id, m.params)
+}
+
+// IntOption reads a numeric rule option, returning def when the option is
+// missing or not a whole number. JSON numbers decode as float64.
+func IntOption(opts map[string]any, key string, def int) int {
+	switch v := opts[key].(type) {
+	case int:
+		return v
+	case float64:
+		if v == float64(int(v)) {
+			return int(v)
+		}
+	}
+	return def
+}
+
+// BoolOption reads a boolean rule option, returning def when the option
+// is missing or not a boolean.
+func BoolOption(opts map[string]any, key string, def bool) bool {
+	if v, ok := opts[key].(bool); ok {
+		return v
+	}
+	return def
+}
+
+// StringOption reads a string rule option, returning def when the option
+// is missing or not a string.
+func StringOption(opts map[string]any, key, def string) string {
+	if v, ok := opts[key].(string); ok {
+		return v
+	}
+	return def
+}
+
+// StringsOption reads a string list rule option, returning def when the
+// option is missing. JSON arrays decode as []any; non-string items are skipped.
+func StringsOption(opts map[string]any, key string, def []string) []string {
+	switch v := opts[key].(type) {
+	case []string:
+		return v
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return def
+}
+
+// maskTemplateActions returns a copy of content with every template action
+// replaced by filler of the same length, so byte offsets still match the
+// original but quotes, brackets, and ampersands inside actions are hidden
+// from the HTML tokenizer. Newlines are kept.
+func maskTemplateActions(content []byte) []byte {
+	masked := bytes.Clone(content)
+	for _, m := range templateActionBounds.FindAllIndex(content, -1) {
+		for i := m[0]; i < m[1]; i++ {
+			if masked[i] != '\n' {
+				masked[i] = 'x'
+			}
+		}
+	}
+	return masked
+}
+
+// templateActionBounds matches a complete template action, including
+// Pongo2 {% tags %}.
+var templateActionBounds = regexp.MustCompile(`\{\{[\s\S]*?\}\}|\{%[\s\S]*?%\}`)
+
+// offsetPosition converts a byte offset in content to a 1-indexed line and column.
+func offsetPosition(content []byte, offset int) (line, col int) {
+	line = 1 + bytes.Count(content[:offset], []byte("\n"))
+	col = offset - bytes.LastIndexByte(content[:offset], '\n')
+	return line, col
+}
@@ -46,13 +46,16 @@ func (r *HiddenFocusable) findFocusable(n *parser.Node, filename string, results
 		}
 
 		if r.isFocusable(child) {
+			params := map[string]any{"element": child.Data}
 			*results = append(*results, Result{
-				Rule:     r.Name(),
-				Message:  child.Data + " is focusable but inside aria-hidden container",
-				Filename: filename,
-				Line:     child.Line,
-				Col:      child.Col,
-				Severity: Error,
+				Rule:      r.Name(),
+				Message:   catalogMessage("hidden-focusable.focusable", params),
+				MessageID: "hidden-focusable.focusable",
+				Params:    params,
+				Filename:  filename,
+				Line:      child.Line,
+				Col:       child.Col,
+				Severity:  Error,
 			})
 		}
 
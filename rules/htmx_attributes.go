@@ -231,6 +231,8 @@ func (r *HTMXAttributes) Check(doc *parser.Document) []Result {
 				continue
 			}
 
+			line, col := n.AttrPos(attr.Key)
+
 			// Handle :inherited and :append suffixes (htmx 4 only)
 			baseAttrName := attrName
 			switch {
@@ -238,36 +240,39 @@ func (r *HTMXAttributes) Check(doc *parser.Document) []Result {
 				baseAttrName = strings.TrimSuffix(attrName, ":inherited:append")
 				if r.htmxVersion != "4" {
 					results = append(results, Result{
-						Rule:     RuleHTMXAttributes,
-						Message:  ":inherited:append suffix is only available in htmx 4",
-						Filename: doc.Filename,
-						Line:     n.Line,
-						Col:      n.Col,
-						Severity: Warning,
+						Rule:      RuleHTMXAttributes,
+						Message:   catalogMessage("htmx-attributes.inherited-append-v4", nil),
+						MessageID: "htmx-attributes.inherited-append-v4",
+						Filename:  doc.Filename,
+						Line:      line,
+						Col:       col,
+						Severity:  Warning,
 					})
 				}
 			case strings.HasSuffix(attrName, ":inherited"):
 				baseAttrName = strings.TrimSuffix(attrName, ":inherited")
 				if r.htmxVersion != "4" {
 					results = append(results, Result{
-						Rule:     RuleHTMXAttributes,
-						Message:  ":inherited suffix is only available in htmx 4",
-						Filename: doc.Filename,
-						Line:     n.Line,
-						Col:      n.Col,
-						Severity: Warning,
+						Rule:      RuleHTMXAttributes,
+						Message:   catalogMessage("htmx-attributes.inherited-v4", nil),
+						MessageID: "htmx-attributes.inherited-v4",
+						Filename:  doc.Filename,
+						Line:      line,
+						Col:       col,
+						Severity:  Warning,
 					})
 				}
 			case strings.HasSuffix(attrName, ":append"):
 				baseAttrName = strings.TrimSuffix(attrName, ":append")
 				if r.htmxVersion != "4" {
 					results = append(results, Result{
-						Rule:     RuleHTMXAttributes,
-						Message:  ":append suffix is only available in htmx 4",
-						Filename: doc.Filename,
-						Line:     n.Line,
-						Col:      n.Col,
-						Severity: Warning,
+						Rule:      RuleHTMXAttributes,
+						Message:   catalogMessage("htmx-attributes.append-v4", nil),
+						MessageID: "htmx-attributes.append-v4",
+						Filename:  doc.Filename,
+						Line:      line,
+						Col:       col,
+						Severity:  Warning,
 					})
 				}
 			}
@@ -291,6 +296,11 @@ func (r *HTMXAttributes) Check(doc *parser.Document) []Result {
 				validationResults = r.validateHxStatus(doc.Filename, n, attr.Key)
 			}
 
+			// Point findings at the attribute rather than the element start
+			for i := range validationResults {
+				validationResults[i].Line = line
+				validationResults[i].Col = col
+			}
 			results = append(results, validationResults...)
 		}
 
@@ -337,25 +347,31 @@ func (r *HTMXAttributes) validateSwap(filename string, n *parser.Node, value str
 
 	// Check for htmx 4 only values when using v2
 	if r.htmxVersion != "4" && v4OnlySwapValues[baseValue] {
+		params := map[string]any{"value": baseValue}
 		results = append(results, Result{
-			Rule:     RuleHTMXAttributes,
-			Message:  "hx-swap value '" + baseValue + "' is only available in htmx 4",
-			Filename: filename,
-			Line:     n.Line,
-			Col:      n.Col,
-			Severity: Warning,
+			Rule:      RuleHTMXAttributes,
+			Message:   catalogMessage("htmx-attributes.swap-v4", params),
+			MessageID: "htmx-attributes.swap-v4",
+			Params:    params,
+			Filename:  filename,
+			Line:      n.Line,
+			Col:       n.Col,
+			Severity:  Warning,
 		})
 		return results
 	}
 
 	if !validSwapValues[baseValue] {
+		params := map[string]any{"value": parts[0]}
 		results = append(results, Result{
-			Rule:     RuleHTMXAttributes,
-			Message:  "invalid hx-swap value '" + parts[0] + "'",
-			Filename: filename,
-			Line:     n.Line,
-			Col:      n.Col,
-			Severity: Error,
+			Rule:      RuleHTMXAttributes,
+			Message:   catalogMessage("htmx-attributes.swap-invalid", params),
+			MessageID: "htmx-attributes.swap-invalid",
+			Params:    params,
+			Filename:  filename,
+			Line:      n.Line,
+			Col:       n.Col,
+			Severity:  Error,
 		})
 		return results
 	}
@@ -365,13 +381,16 @@ func (r *HTMXAttributes) validateSwap(filename string, n *parser.Node, value str
 		modifier := parts[i]
 		colonIdx := strings.Index(modifier, ":")
 		if colonIdx == -1 {
+			params := map[string]any{"modifier": modifier}
 			results = append(results, Result{
-				Rule:     RuleHTMXAttributes,
-				Message:  "invalid hx-swap modifier '" + modifier + "' (missing colon)",
-				Filename: filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Error,
+				Rule:      RuleHTMXAttributes,
+				Message:   catalogMessage("htmx-attributes.swap-modifier-colon", params),
+				MessageID: "htmx-attributes.swap-modifier-colon",
+				Params:    params,
+				Filename:  filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Error,
 			})
 			continue
 		}
@@ -380,13 +399,16 @@ func (r *HTMXAttributes) validateSwap(filename string, n *parser.Node, value str
 		modValue := modifier[colonIdx+1:]
 
 		if !validSwapModifiers[modName] {
+			params := map[string]any{"modifier": modName}
 			results = append(results, Result{
-				Rule:     RuleHTMXAttributes,
-				Message:  "unknown hx-swap modifier '" + modName + "'",
-				Filename: filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Warning,
+				Rule:      RuleHTMXAttributes,
+				Message:   catalogMessage("htmx-attributes.swap-modifier-unknown", params),
+				MessageID: "htmx-attributes.swap-modifier-unknown",
+				Params:    params,
+				Filename:  filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
 			})
 			continue
 		}
@@ -395,58 +417,67 @@ func (r *HTMXAttributes) validateSwap(filename string, n *parser.Node, value str
 		switch modName {
 		case "swap", "settle":
 			if !timePattern.MatchString(modValue) {
+				params := map[string]any{"modifier": modName}
 				results = append(results, Result{
-					Rule:     RuleHTMXAttributes,
-					Message:  "hx-swap " + modName + " modifier requires a time value (e.g., '1s', '500ms')",
-					Filename: filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Error,
+					Rule:      RuleHTMXAttributes,
+					Message:   catalogMessage("htmx-attributes.swap-modifier-time", params),
+					MessageID: "htmx-attributes.swap-modifier-time",
+					Params:    params,
+					Filename:  filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Error,
 				})
 			}
 		case "scroll", "show":
 			validPositions := map[string]bool{"top": true, "bottom": true}
 			if !validPositions[strings.ToLower(modValue)] && !strings.HasPrefix(modValue, "#") {
+				params := map[string]any{"modifier": modName}
 				results = append(results, Result{
-					Rule:     RuleHTMXAttributes,
-					Message:  "hx-swap " + modName + " modifier value should be 'top', 'bottom', or a selector",
-					Filename: filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Warning,
+					Rule:      RuleHTMXAttributes,
+					Message:   catalogMessage("htmx-attributes.swap-modifier-scroll", params),
+					MessageID: "htmx-attributes.swap-modifier-scroll",
+					Params:    params,
+					Filename:  filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Warning,
 				})
 			}
 		case "focus-scroll":
 			if modValue != "true" && modValue != "false" {
 				results = append(results, Result{
-					Rule:     RuleHTMXAttributes,
-					Message:  "hx-swap focus-scroll modifier should be 'true' or 'false'",
-					Filename: filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Error,
+					Rule:      RuleHTMXAttributes,
+					Message:   catalogMessage("htmx-attributes.swap-focus-scroll", nil),
+					MessageID: "htmx-attributes.swap-focus-scroll",
+					Filename:  filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Error,
 				})
 			}
 		case "transition":
 			if modValue != "true" && modValue != "false" {
 				results = append(results, Result{
-					Rule:     RuleHTMXAttributes,
-					Message:  "hx-swap transition modifier should be 'true' or 'false'",
-					Filename: filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Error,
+					Rule:      RuleHTMXAttributes,
+					Message:   catalogMessage("htmx-attributes.swap-transition", nil),
+					MessageID: "htmx-attributes.swap-transition",
+					Filename:  filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Error,
 				})
 			}
 		case "strip":
 			if modValue != "true" && modValue != "false" {
 				results = append(results, Result{
-					Rule:     RuleHTMXAttributes,
-					Message:  "hx-swap strip modifier should be 'true' or 'false'",
-					Filename: filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Error,
+					Rule:      RuleHTMXAttributes,
+					Message:   catalogMessage("htmx-attributes.swap-strip", nil),
+					MessageID: "htmx-attributes.swap-strip",
+					Filename:  filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Error,
 				})
 			}
 		}
@@ -502,23 +533,25 @@ func (r *HTMXAttributes) validateSingleTrigger(filename string, n *parser.Node,
 	if eventName == "every" {
 		if len(parts) < 2 {
 			results = append(results, Result{
-				Rule:     RuleHTMXAttributes,
-				Message:  "hx-trigger 'every' requires a time value (e.g., 'every 1s')",
-				Filename: filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Error,
+				Rule:      RuleHTMXAttributes,
+				Message:   catalogMessage("htmx-attributes.trigger-every-missing", nil),
+				MessageID: "htmx-attributes.trigger-every-missing",
+				Filename:  filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Error,
 			})
 			return results
 		}
 		if !timePattern.MatchString(parts[1]) {
 			results = append(results, Result{
-				Rule:     RuleHTMXAttributes,
-				Message:  "hx-trigger 'every' requires a valid time value (e.g., '1s', '500ms')",
-				Filename: filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Error,
+				Rule:      RuleHTMXAttributes,
+				Message:   catalogMessage("htmx-attributes.trigger-every-invalid", nil),
+				MessageID: "htmx-attributes.trigger-every-invalid",
+				Filename:  filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Error,
 			})
 		}
 		return results
@@ -536,13 +569,16 @@ func (r *HTMXAttributes) validateSingleTrigger(filename string, n *parser.Node,
 				continue
 			}
 			// Unknown modifier for intersect
+			params := map[string]any{"modifier": mod}
 			results = append(results, Result{
-				Rule:     RuleHTMXAttributes,
-				Message:  "unknown intersect modifier '" + mod + "'",
-				Filename: filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Warning,
+				Rule:      RuleHTMXAttributes,
+				Message:   catalogMessage("htmx-attributes.trigger-intersect-unknown", params),
+				MessageID: "htmx-attributes.trigger-intersect-unknown",
+				Params:    params,
+				Filename:  filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
 			})
 		}
 		return results
@@ -555,13 +591,16 @@ func (r *HTMXAttributes) validateSingleTrigger(filename string, n *parser.Node,
 			if mod == "once" {
 				continue
 			}
+			params := map[string]any{"modifier": mod}
 			results = append(results, Result{
-				Rule:     RuleHTMXAttributes,
-				Message:  "unknown revealed modifier '" + mod + "'",
-				Filename: filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Warning,
+				Rule:      RuleHTMXAttributes,
+				Message:   catalogMessage("htmx-attributes.trigger-revealed-unknown", params),
+				MessageID: "htmx-attributes.trigger-revealed-unknown",
+				Params:    params,
+				Filename:  filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
 			})
 		}
 		return results
@@ -582,13 +621,16 @@ func (r *HTMXAttributes) validateSingleTrigger(filename string, n *parser.Node,
 			// Standalone modifier (e.g., "once", "changed", "consume")
 			modName := strings.ToLower(modifier)
 			if !validTriggerModifiers[modName] {
+				params := map[string]any{"modifier": modifier}
 				results = append(results, Result{
-					Rule:     RuleHTMXAttributes,
-					Message:  "unknown hx-trigger modifier '" + modifier + "'",
-					Filename: filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Warning,
+					Rule:      RuleHTMXAttributes,
+					Message:   catalogMessage("htmx-attributes.trigger-modifier-unknown", params),
+					MessageID: "htmx-attributes.trigger-modifier-unknown",
+					Params:    params,
+					Filename:  filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Warning,
 				})
 			}
 			continue
@@ -598,13 +640,16 @@ func (r *HTMXAttributes) validateSingleTrigger(filename string, n *parser.Node,
 		modValue := modifier[colonIdx+1:]
 
 		if !validTriggerModifiers[modName] {
+			params := map[string]any{"modifier": modName}
 			results = append(results, Result{
-				Rule:     RuleHTMXAttributes,
-				Message:  "unknown hx-trigger modifier '" + modName + "'",
-				Filename: filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Warning,
+				Rule:      RuleHTMXAttributes,
+				Message:   catalogMessage("htmx-attributes.trigger-modifier-unknown", params),
+				MessageID: "htmx-attributes.trigger-modifier-unknown",
+				Params:    params,
+				Filename:  filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
 			})
 			continue
 		}
@@ -613,24 +658,28 @@ func (r *HTMXAttributes) validateSingleTrigger(filename string, n *parser.Node,
 		switch modName {
 		case "delay", "throttle":
 			if !timePattern.MatchString(modValue) {
+				params := map[string]any{"modifier": modName}
 				results = append(results, Result{
-					Rule:     RuleHTMXAttributes,
-					Message:  "hx-trigger " + modName + " requires a time value (e.g., '1s', '500ms')",
-					Filename: filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Error,
+					Rule:      RuleHTMXAttributes,
+					Message:   catalogMessage("htmx-attributes.trigger-modifier-time", params),
+					MessageID: "htmx-attributes.trigger-modifier-time",
+					Params:    params,
+					Filename:  filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Error,
 				})
 			}
 		case "queue":
 			if !queueModes[strings.ToLower(modValue)] {
 				results = append(results, Result{
-					Rule:     RuleHTMXAttributes,
-					Message:  "hx-trigger queue mode should be 'first', 'last', 'all', or 'none'",
-					Filename: filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Error,
+					Rule:      RuleHTMXAttributes,
+					Message:   catalogMessage("htmx-attributes.trigger-queue", nil),
+					MessageID: "htmx-attributes.trigger-queue",
+					Filename:  filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Error,
 				})
 			}
 		}
@@ -672,11 +721,19 @@ func (r *HTMXAttributes) validateTarget(filename string, n *parser.Node, value s
 		if specialValues[lower] {
 			return nil
 		}
-		// Assume it's a CSS selector - basic validation
-		if strings.HasPrefix(value, "#") || strings.HasPrefix(value, ".") || isValidElement(lower) {
-			return nil
+		if err := validateSelector(value); err != nil {
+			params := map[string]any{"error": err.Error()}
+			return []Result{{
+				Rule:      RuleHTMXAttributes,
+				Message:   catalogMessage("htmx-attributes.target-selector", params),
+				MessageID: "htmx-attributes.target-selector",
+				Params:    params,
+				Filename:  filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
+			}}
 		}
-		// Could be a valid selector, warn but don't error
 		return nil
 	}
 
@@ -693,44 +750,36 @@ func (r *HTMXAttributes) validateTarget(filename string, n *parser.Node, value s
 	}
 
 	if !validKeywords[keyword] && !specialValues[keyword] {
+		params := map[string]any{"keyword": parts[0]}
 		results = append(results, Result{
-			Rule:     RuleHTMXAttributes,
-			Message:  "invalid hx-target keyword '" + parts[0] + "'; expected 'this', 'closest', 'find', 'findAll', 'next', 'previous', or a CSS selector",
-			Filename: filename,
-			Line:     n.Line,
-			Col:      n.Col,
-			Severity: Warning,
+			Rule:      RuleHTMXAttributes,
+			Message:   catalogMessage("htmx-attributes.target-keyword", params),
+			MessageID: "htmx-attributes.target-keyword",
+			Params:    params,
+			Filename:  filename,
+			Line:      n.Line,
+			Col:       n.Col,
+			Severity:  Warning,
 		})
+	} else if validKeywords[keyword] {
+		if err := validateSelector(strings.TrimSpace(parts[1])); err != nil {
+			params := map[string]any{"error": err.Error()}
+			results = append(results, Result{
+				Rule:      RuleHTMXAttributes,
+				Message:   catalogMessage("htmx-attributes.target-selector", params),
+				MessageID: "htmx-attributes.target-selector",
+				Params:    params,
+				Filename:  filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
+			})
+		}
 	}
 
 	return results
 }
 
-// isValidElement checks if the name is a known HTML element.
-func isValidElement(name string) bool {
-	// Common HTML elements
-	elements := map[string]bool{
-		"a": true, "abbr": true, "address": true, "area": true, "article": true, "aside": true, "audio": true,
-		"b": true, "base": true, "bdi": true, "bdo": true, "blockquote": true, "body": true, "br": true, "button": true,
-		"canvas": true, "caption": true, "cite": true, "code": true, "col": true, "colgroup": true,
-		"data": true, "datalist": true, "dd": true, "del": true, "details": true, "dfn": true, "dialog": true, "div": true, "dl": true, "dt": true,
-		"em": true, "embed": true,
-		"fieldset": true, "figcaption": true, "figure": true, "footer": true, "form": true,
-		"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true, "head": true, "header": true, "hgroup": true, "hr": true, "html": true,
-		"i": true, "iframe": true, "img": true, "input": true, "ins": true,
-		"kbd": true, "label": true, "legend": true, "li": true, "link": true,
-		"main": true, "map": true, "mark": true, "menu": true, "meta": true, "meter": true,
-		"nav": true, "noscript": true,
-		"object": true, "ol": true, "optgroup": true, "option": true, "output": true,
-		"p": true, "picture": true, "pre": true, "progress": true,
-		"q": true, "rp": true, "rt": true, "ruby": true,
-		"s": true, "samp": true, "script": true, "search": true, "section": true, "select": true, "slot": true, "small": true, "source": true, "span": true, "strong": true, "style": true, "sub": true, "summary": true, "sup": true,
-		"table": true, "tbody": true, "td": true, "template": true, "textarea": true, "tfoot": true, "th": true, "thead": true, "time": true, "title": true, "tr": true, "track": true,
-		"u": true, "ul": true, "var": true, "video": true, "wbr": true,
-	}
-	return elements[name]
-}
-
 // validateHxOn checks hx-on:* attribute event names.
 // Validates that the event name is a known DOM event or htmx event.
 func (r *HTMXAttributes) validateHxOn(filename string, n *parser.Node, attrKey string) []Result {
@@ -748,12 +797,13 @@ func (r *HTMXAttributes) validateHxOn(filename string, n *parser.Node, attrKey s
 
 	if eventName == "" {
 		return []Result{{
-			Rule:     RuleHTMXAttributes,
-			Message:  "hx-on:* requires an event name",
-			Filename: filename,
-			Line:     n.Line,
-			Col:      n.Col,
-			Severity: Error,
+			Rule:      RuleHTMXAttributes,
+			Message:   catalogMessage("htmx-attributes.on-no-event", nil),
+			MessageID: "htmx-attributes.on-no-event",
+			Filename:  filename,
+			Line:      n.Line,
+			Col:       n.Col,
+			Severity:  Error,
 		}}
 	}
 
@@ -780,13 +830,16 @@ func (r *HTMXAttributes) validateHxOn(filename string, n *parser.Node, attrKey s
 	}
 
 	// Unknown event - could be a custom event, warn
+	params := map[string]any{"event": eventName}
 	return []Result{{
-		Rule:     RuleHTMXAttributes,
-		Message:  "unknown event '" + eventName + "' in hx-on:*; if this is a custom event, ignore this warning",
-		Filename: filename,
-		Line:     n.Line,
-		Col:      n.Col,
-		Severity: Warning,
+		Rule:      RuleHTMXAttributes,
+		Message:   catalogMessage("htmx-attributes.on-unknown-event", params),
+		MessageID: "htmx-attributes.on-unknown-event",
+		Params:    params,
+		Filename:  filename,
+		Line:      n.Line,
+		Col:       n.Col,
+		Severity:  Warning,
 	}}
 }
 
@@ -808,13 +861,16 @@ func (r *HTMXAttributes) validateHTMXEvent(filename string, n *parser.Node, even
 		}
 	}
 
+	params := map[string]any{"event": eventName}
 	return []Result{{
-		Rule:     RuleHTMXAttributes,
-		Message:  "unknown htmx event '" + eventName + "'",
-		Filename: filename,
-		Line:     n.Line,
-		Col:      n.Col,
-		Severity: Warning,
+		Rule:      RuleHTMXAttributes,
+		Message:   catalogMessage("htmx-attributes.event-unknown", params),
+		MessageID: "htmx-attributes.event-unknown",
+		Params:    params,
+		Filename:  filename,
+		Line:      n.Line,
+		Col:       n.Col,
+		Severity:  Warning,
 	}}
 }
 
@@ -828,13 +884,16 @@ func (r *HTMXAttributes) validateHTMXv4Event(filename string, n *parser.Node, ev
 	parts := strings.SplitN(remainder, ":", 2)
 
 	if len(parts) == 0 || parts[0] == "" {
+		params := map[string]any{"event": eventName}
 		return []Result{{
-			Rule:     RuleHTMXAttributes,
-			Message:  "invalid htmx event format '" + eventName + "'",
-			Filename: filename,
-			Line:     n.Line,
-			Col:      n.Col,
-			Severity: Error,
+			Rule:      RuleHTMXAttributes,
+			Message:   catalogMessage("htmx-attributes.event-format", params),
+			MessageID: "htmx-attributes.event-format",
+			Params:    params,
+			Filename:  filename,
+			Line:      n.Line,
+			Col:       n.Col,
+			Severity:  Error,
 		}}
 	}
 
@@ -850,13 +909,16 @@ func (r *HTMXAttributes) validateHTMXv4Event(filename string, n *parser.Node, ev
 			return nil // Valid standalone event
 		}
 
+		params := map[string]any{"phase": parts[0], "event": eventName}
 		return []Result{{
-			Rule:     RuleHTMXAttributes,
-			Message:  "unknown htmx 4 event phase '" + parts[0] + "' in '" + eventName + "'",
-			Filename: filename,
-			Line:     n.Line,
-			Col:      n.Col,
-			Severity: Warning,
+			Rule:      RuleHTMXAttributes,
+			Message:   catalogMessage("htmx-attributes.event-phase", params),
+			MessageID: "htmx-attributes.event-phase",
+			Params:    params,
+			Filename:  filename,
+			Line:      n.Line,
+			Col:       n.Col,
+			Severity:  Warning,
 		}}
 	}
 
@@ -867,13 +929,16 @@ func (r *HTMXAttributes) validateHTMXv4Event(filename string, n *parser.Node, ev
 		action := strings.ToLower(actionParts[0])
 
 		if !knownHTMXv4Actions[action] {
+			params := map[string]any{"action": actionParts[0], "event": eventName}
 			return []Result{{
-				Rule:     RuleHTMXAttributes,
-				Message:  "unknown htmx 4 event action '" + actionParts[0] + "' in '" + eventName + "'",
-				Filename: filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Warning,
+				Rule:      RuleHTMXAttributes,
+				Message:   catalogMessage("htmx-attributes.event-action", params),
+				MessageID: "htmx-attributes.event-action",
+				Params:    params,
+				Filename:  filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
 			}}
 		}
 		// Any remaining sub-action parts (actionParts[1]) are action-specific
@@ -907,17 +972,20 @@ func (r *HTMXAttributes) checkSubmitButtonInForm(filename string, n *parser.Node
 	}
 
 	// Check if it's inside a form
-	if !r.isInsideForm(n) {
+	if n.ClosestAncestor("form") == nil {
 		return nil
 	}
 
+	params := map[string]any{"attr": requestAttr}
 	return &Result{
-		Rule:     RuleHTMXAttributes,
-		Message:  requestAttr + " on submit button inside form may bypass form validation; consider moving to the form element",
-		Filename: filename,
-		Line:     n.Line,
-		Col:      n.Col,
-		Severity: Warning,
+		Rule:      RuleHTMXAttributes,
+		Message:   catalogMessage("htmx-attributes.submit-bypass", params),
+		MessageID: "htmx-attributes.submit-bypass",
+		Params:    params,
+		Filename:  filename,
+		Line:      n.Line,
+		Col:       n.Col,
+		Severity:  Warning,
 	}
 }
 
@@ -948,18 +1016,6 @@ func (r *HTMXAttributes) isSubmitButton(n *parser.Node, nodeName string) bool {
 	return false
 }
 
-// isInsideForm checks if the node is inside a form element.
-func (r *HTMXAttributes) isInsideForm(n *parser.Node) bool {
-	parent := n.Parent
-	for parent != nil {
-		if parent.Type == html.ElementNode && strings.ToLower(parent.Data) == "form" {
-			return true
-		}
-		parent = parent.Parent
-	}
-	return false
-}
-
 // validateJSON checks hx-vals and hx-headers attribute values for valid JSON syntax.
 func (r *HTMXAttributes) validateJSON(filename string, n *parser.Node, attrName, value string) []Result {
 	if value == "" {
@@ -981,23 +1037,33 @@ func (r *HTMXAttributes) validateJSON(filename string, n *parser.Node, attrName,
 		return nil // JavaScript expression, can't validate
 	}
 
-	// Try to parse as JSON
-	var js json.RawMessage
-	if err := json.Unmarshal([]byte(value), &js); err != nil {
-		// Provide a helpful error message
+	if msg := jsonSyntaxError(value); msg != "" {
+		params := map[string]any{"attr": attrName, "error": msg}
 		return []Result{{
-			Rule:     RuleHTMXAttributes,
-			Message:  attrName + " contains invalid JSON: " + simplifyJSONError(err),
-			Filename: filename,
-			Line:     n.Line,
-			Col:      n.Col,
-			Severity: Error,
+			Rule:      RuleHTMXAttributes,
+			Message:   catalogMessage("htmx-attributes.json", params),
+			MessageID: "htmx-attributes.json",
+			Params:    params,
+			Filename:  filename,
+			Line:      n.Line,
+			Col:       n.Col,
+			Severity:  Error,
 		}}
 	}
 
 	return nil
 }
 
+// jsonSyntaxError parses value as JSON and returns a user-friendly
+// message describing why it is invalid, or "" when it parses.
+func jsonSyntaxError(value string) string {
+	var js json.RawMessage
+	if err := json.Unmarshal([]byte(value), &js); err != nil {
+		return simplifyJSONError(err)
+	}
+	return ""
+}
+
 // simplifyJSONError extracts a user-friendly message from a JSON parse error.
 func simplifyJSONError(err error) string {
 	// json.SyntaxError has Offset field, extract position info
@@ -1040,84 +1106,30 @@ func (r *HTMXAttributes) validateInclude(filename string, n *parser.Node, value
 		}
 	}
 
-	// Handle comma-separated selectors
-	selectors := strings.Split(value, ",")
-	for _, selector := range selectors {
-		selector = strings.TrimSpace(selector)
-		if selector == "" {
-			continue
-		}
-
-		if err := validateCSSSelector(selector); err != nil {
-			return []Result{{
-				Rule:     RuleHTMXAttributes,
-				Message:  "hx-include contains invalid CSS selector: " + err.Error(),
-				Filename: filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Error,
-			}}
-		}
+	if err := validateSelector(value); err != nil {
+		params := map[string]any{"error": err.Error()}
+		return []Result{{
+			Rule:      RuleHTMXAttributes,
+			Message:   catalogMessage("htmx-attributes.include-selector", params),
+			MessageID: "htmx-attributes.include-selector",
+			Params:    params,
+			Filename:  filename,
+			Line:      n.Line,
+			Col:       n.Col,
+			Severity:  Error,
+		}}
 	}
 
 	return nil
 }
 
-// validateCSSSelector performs basic validation on a CSS selector.
-// Returns an error if the selector has obvious syntax issues.
-func validateCSSSelector(selector string) error {
-	if selector == "" {
-		return nil
-	}
-
-	// Check for unbalanced brackets
-	brackets := map[rune]rune{'[': ']', '(': ')'}
-	var stack []rune
-	for _, ch := range selector {
-		if closer, isOpener := brackets[ch]; isOpener {
-			stack = append(stack, closer)
-		} else if ch == ']' || ch == ')' {
-			if len(stack) == 0 || stack[len(stack)-1] != ch {
-				return errors.New("unbalanced brackets in '" + selector + "'")
-			}
-			stack = stack[:len(stack)-1]
-		}
-	}
-	if len(stack) > 0 {
-		return errors.New("unclosed bracket in '" + selector + "'")
+// validateSelector checks a CSS selector list htmx passes to
+// querySelectorAll. Selectors the engine cannot match statically, such as
+// :hover, are valid to the browser and accepted.
+func validateSelector(selector string) error {
+	if _, err := parser.CompileSelector(selector); err != nil && !errors.Is(err, parser.ErrUnsupportedSelector) {
+		return err
 	}
-
-	// Check for empty selectors after combinators
-	combinators := []string{" > ", " + ", " ~ "}
-	for _, comb := range combinators {
-		if strings.HasSuffix(selector, strings.TrimSpace(comb)) {
-			return errors.New("selector ends with combinator '" + strings.TrimSpace(comb) + "'")
-		}
-		if strings.HasPrefix(selector, strings.TrimSpace(comb)) {
-			return errors.New("selector starts with combinator '" + strings.TrimSpace(comb) + "'")
-		}
-	}
-
-	// Check for invalid characters at start
-	if len(selector) > 0 {
-		first := selector[0]
-		// Valid starts: letter, #, ., *, [, :
-		validStarts := "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ#.*[:_-"
-		if !strings.ContainsRune(validStarts, rune(first)) {
-			return errors.New("selector starts with invalid character '" + string(first) + "'")
-		}
-	}
-
-	// Check for empty attribute selector
-	if strings.Contains(selector, "[]") {
-		return errors.New("empty attribute selector '[]'")
-	}
-
-	// Check for double combinators
-	if strings.Contains(selector, ">>") || strings.Contains(selector, "++") || strings.Contains(selector, "~~") {
-		return errors.New("invalid double combinator")
-	}
-
 	return nil
 }
 
@@ -1127,12 +1139,13 @@ func (r *HTMXAttributes) validateHxStatus(filename string, n *parser.Node, attrK
 	// hx-status:* is htmx 4 only
 	if r.htmxVersion != "4" {
 		return []Result{{
-			Rule:     RuleHTMXAttributes,
-			Message:  "hx-status:* attributes are only available in htmx 4",
-			Filename: filename,
-			Line:     n.Line,
-			Col:      n.Col,
-			Severity: Warning,
+			Rule:      RuleHTMXAttributes,
+			Message:   catalogMessage("htmx-attributes.status-v4", nil),
+			MessageID: "htmx-attributes.status-v4",
+			Filename:  filename,
+			Line:      n.Line,
+			Col:       n.Col,
+			Severity:  Warning,
 		}}
 	}
 
@@ -1146,24 +1159,28 @@ func (r *HTMXAttributes) validateHxStatus(filename string, n *parser.Node, attrK
 
 	if statusCode == "" {
 		return []Result{{
-			Rule:     RuleHTMXAttributes,
-			Message:  "hx-status:* requires a status code (e.g., hx-status:404, hx-status:2xx)",
-			Filename: filename,
-			Line:     n.Line,
-			Col:      n.Col,
-			Severity: Error,
+			Rule:      RuleHTMXAttributes,
+			Message:   catalogMessage("htmx-attributes.status-no-code", nil),
+			MessageID: "htmx-attributes.status-no-code",
+			Filename:  filename,
+			Line:      n.Line,
+			Col:       n.Col,
+			Severity:  Error,
 		}}
 	}
 
 	// Validate the status code pattern
 	if err := validateHTTPStatusCode(statusCode); err != nil {
+		params := map[string]any{"error": err.Error()}
 		return []Result{{
-			Rule:     RuleHTMXAttributes,
-			Message:  "invalid hx-status pattern: " + err.Error(),
-			Filename: filename,
-			Line:     n.Line,
-			Col:      n.Col,
-			Severity: Error,
+			Rule:      RuleHTMXAttributes,
+			Message:   catalogMessage("htmx-attributes.status-pattern", params),
+			MessageID: "htmx-attributes.status-pattern",
+			Params:    params,
+			Filename:  filename,
+			Line:      n.Line,
+			Col:       n.Col,
+			Severity:  Error,
 		}}
 	}
 
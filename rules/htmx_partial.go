@@ -0,0 +1,83 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// HTMXPartial checks files configured as htmx partial responses. htmx swaps
+// the whole response into the target, so a partial should have a single
+// root element besides out-of-band ones, and each hx-swap-oob element needs
+// an id (or an explicit selector) to find the element it replaces.
+type HTMXPartial struct{}
+
+// Name returns the rule identifier.
+func (r *HTMXPartial) Name() string { return RuleHTMXPartial }
+
+// Description returns what this rule checks.
+func (r *HTMXPartial) Description() string {
+	return "htmx partial responses need a single root and ids on out-of-band elements"
+}
+
+// Check examines the top-level elements of a partial.
+func (r *HTMXPartial) Check(doc *parser.Document) []Result {
+	if !doc.IsPartial || doc.Root == nil {
+		return nil
+	}
+
+	var results []Result
+	var root *parser.Node
+	for _, n := range doc.Root.Children {
+		if n.Type != html.ElementNode || TagIn(n, "script", "style", "template") {
+			continue
+		}
+
+		oob, isOOB := swapOOB(n)
+		if !isOOB {
+			if root == nil {
+				root = n
+				continue
+			}
+			results = append(results, Result{
+				Rule:      RuleHTMXPartial,
+				Message:   catalogMessage("htmx-partial.multiple-roots", nil),
+				MessageID: "htmx-partial.multiple-roots",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
+			})
+			continue
+		}
+
+		// "true" or a bare strategy targets the element with the same id;
+		// "strategy:selector" names its target explicitly.
+		if !strings.Contains(oob, ":") && !IsTemplateExpr(oob) && strings.TrimSpace(n.GetAttr("id")) == "" {
+			params := map[string]any{"element": n.Data}
+			results = append(results, Result{
+				Rule:      RuleHTMXPartial,
+				Message:   catalogMessage("htmx-partial.oob-id", params),
+				MessageID: "htmx-partial.oob-id",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
+			})
+		}
+	}
+
+	return results
+}
+
+// swapOOB returns the hx-swap-oob value of n and whether it is set.
+func swapOOB(n *parser.Node) (string, bool) {
+	for _, name := range []string{"hx-swap-oob", "data-hx-swap-oob"} {
+		if n.HasAttr(name) {
+			return n.GetAttr(name), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,232 @@
+package rules
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// IframeSandbox validates iframe sandbox and allow attributes: sandbox
+// tokens and their combinations, and the allow attribute's Permissions
+// Policy syntax and feature names.
+type IframeSandbox struct{}
+
+// Name returns the rule identifier.
+func (r *IframeSandbox) Name() string { return RuleIframeSandbox }
+
+// Description returns what this rule checks.
+func (r *IframeSandbox) Description() string {
+	return "iframe sandbox and allow attributes must be valid"
+}
+
+// KnownPermissionsFeatures lists Permissions Policy features accepted in
+// the iframe allow attribute.
+var KnownPermissionsFeatures = map[string]bool{
+	"accelerometer":                   true,
+	"ambient-light-sensor":            true,
+	"attribution-reporting":           true,
+	"autoplay":                        true,
+	"bluetooth":                       true,
+	"browsing-topics":                 true,
+	"camera":                          true,
+	"clipboard-read":                  true,
+	"clipboard-write":                 true,
+	"compute-pressure":                true,
+	"cross-origin-isolated":           true,
+	"deferred-fetch":                  true,
+	"digital-credentials-get":         true,
+	"display-capture":                 true,
+	"document-domain":                 true,
+	"encrypted-media":                 true,
+	"execution-while-not-rendered":    true,
+	"execution-while-out-of-viewport": true,
+	"fullscreen":                      true,
+	"gamepad":                         true,
+	"geolocation":                     true,
+	"gyroscope":                       true,
+	"hid":                             true,
+	"identity-credentials-get":        true,
+	"idle-detection":                  true,
+	"keyboard-map":                    true,
+	"local-fonts":                     true,
+	"magnetometer":                    true,
+	"microphone":                      true,
+	"midi":                            true,
+	"otp-credentials":                 true,
+	"payment":                         true,
+	"picture-in-picture":              true,
+	"publickey-credentials-create":    true,
+	"publickey-credentials-get":       true,
+	"screen-wake-lock":                true,
+	"serial":                          true,
+	"speaker-selection":               true,
+	"storage-access":                  true,
+	"sync-xhr":                        true,
+	"usb":                             true,
+	"web-share":                       true,
+	"window-management":               true,
+	"xr-spatial-tracking":             true,
+}
+
+// featurePattern matches a Permissions Policy feature identifier.
+var featurePattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// Check examines iframe sandbox and allow attributes.
+func (r *IframeSandbox) Check(doc *parser.Document) []Result {
+	var results []Result
+
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.ElementNode || !TagEquals(n, "iframe") {
+			return true
+		}
+		report := func(attr string, m message, sev Severity) {
+			line, col := n.AttrPos(attr)
+			results = append(results, Result{
+				Rule:      RuleIframeSandbox,
+				Message:   m.text(),
+				MessageID: m.id,
+				Params:    m.params,
+				Filename:  doc.Filename,
+				Line:      line,
+				Col:       col,
+				Severity:  sev,
+			})
+		}
+
+		if n.HasAttr("sandbox") {
+			for _, p := range sandboxProblems(n.GetAttr("sandbox")) {
+				report("sandbox", p.message, p.severity)
+			}
+		}
+		if allow := n.GetAttr("allow"); allow != "" && !IsTemplateExpr(allow) {
+			for _, p := range allowProblems(allow) {
+				report("allow", p.message, p.severity)
+			}
+		}
+
+		return true
+	})
+
+	return results
+}
+
+// attrProblem is a finding in an attribute value.
+type attrProblem struct {
+	message  message
+	severity Severity
+}
+
+// sandboxProblems validates a sandbox token list.
+func sandboxProblems(value string) []attrProblem {
+	var problems []attrProblem
+	tokens := make(map[string]bool)
+	for _, token := range strings.Fields(strings.ToLower(value)) {
+		if IsTemplateExpr(token) {
+			continue
+		}
+		if !ValidSandboxTokens[token] {
+			problems = append(problems, attrProblem{message{"iframe-sandbox.unknown-token", map[string]any{"token": token}}, Error})
+		}
+		tokens[token] = true
+	}
+
+	if tokens["allow-scripts"] && tokens["allow-same-origin"] {
+		problems = append(problems, attrProblem{
+			message{"iframe-sandbox.escape", nil},
+			Warning,
+		})
+	}
+	if tokens["allow-top-navigation"] && tokens["allow-top-navigation-by-user-activation"] {
+		problems = append(problems, attrProblem{
+			message{"iframe-sandbox.top-navigation", nil},
+			Error,
+		})
+	}
+	return problems
+}
+
+// allowProblems validates an allow attribute: directives separated by
+// semicolons, each a feature name followed by an optional allowlist of
+// '*', 'self', 'src', 'none', or origins.
+func allowProblems(value string) []attrProblem {
+	if strings.ContainsAny(value, "=()") {
+		return []attrProblem{{
+			message{"iframe-sandbox.header-syntax", nil},
+			Error,
+		}}
+	}
+
+	var problems []attrProblem
+	for directive := range strings.SplitSeq(value, ";") {
+		fields := strings.Fields(directive)
+		if len(fields) == 0 {
+			continue
+		}
+		feature := fields[0]
+		switch {
+		case !featurePattern.MatchString(feature):
+			problems = append(problems, attrProblem{message{"iframe-sandbox.feature-name", map[string]any{"feature": feature}}, Error})
+		case !KnownPermissionsFeatures[feature]:
+			problems = append(problems, attrProblem{message{"iframe-sandbox.unknown-feature", map[string]any{"feature": feature}}, Warning})
+		}
+		for _, origin := range fields[1:] {
+			if !validAllowlistItem(origin) {
+				problems = append(problems, attrProblem{message{"iframe-sandbox.allowlist", map[string]any{"origin": origin, "feature": feature}}, Error})
+			}
+		}
+	}
+	return problems
+}
+
+// validAllowlistItem reports whether an allowlist entry is a keyword or a
+// serialized origin.
+func validAllowlistItem(item string) bool {
+	switch strings.ToLower(item) {
+	case "*", "'self'", "'src'", "'none'":
+		return true
+	}
+	if IsTemplateExpr(item) {
+		return true
+	}
+	scheme, host, ok := strings.Cut(item, "://")
+	return ok && scheme != "" && host != "" && !strings.Contains(strings.TrimSuffix(host, "/"), "/")
+}
+
+// IframeRequireSandbox warns about iframes without a sandbox attribute.
+// It is opt-in; the builtin "strict" profile enables it.
+type IframeRequireSandbox struct{}
+
+// Name returns the rule identifier.
+func (r *IframeRequireSandbox) Name() string { return RuleIframeRequireSandbox }
+
+// Description returns what this rule checks.
+func (r *IframeRequireSandbox) Description() string {
+	return "iframes should have a sandbox attribute"
+}
+
+// OptIn marks the rule as disabled unless explicitly enabled.
+func (r *IframeRequireSandbox) OptIn() {}
+
+// Check examines the document for iframes without sandbox.
+func (r *IframeRequireSandbox) Check(doc *parser.Document) []Result {
+	var results []Result
+
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type == html.ElementNode && TagEquals(n, "iframe") && !n.HasAttr("sandbox") {
+			results = append(results, Result{
+				Rule:      RuleIframeRequireSandbox,
+				Message:   catalogMessage("iframe-require-sandbox.missing", nil),
+				MessageID: "iframe-require-sandbox.missing",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
+			})
+		}
+		return true
+	})
+
+	return results
+}
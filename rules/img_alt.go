@@ -21,12 +21,13 @@ func (r *ImgAlt) Check(doc *parser.Document) []Result {
 		if n.Type == html.ElementNode && n.IsElement("img") {
 			if !n.HasAttr("alt") {
 				results = append(results, Result{
-					Rule:     r.Name(),
-					Message:  "img element missing alt attribute",
-					Filename: doc.Filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Error,
+					Rule:      r.Name(),
+					Message:   catalogMessage("img-alt.missing", nil),
+					MessageID: "img-alt.missing",
+					Filename:  doc.Filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Error,
 				})
 			}
 		}
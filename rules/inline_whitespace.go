@@ -0,0 +1,132 @@
+package rules
+
+import (
+	"bytes"
+	"regexp"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// InlineWhitespace checks for inline elements whose text runs together
+// because nothing separates them: adjacent links and buttons with no
+// whitespace between them, and template trim markers ({{- -}}) that delete
+// the only whitespace between two inline elements. Both read as one word
+// to screen readers and in copied text, and usually look glued together.
+type InlineWhitespace struct{}
+
+// Name returns the rule identifier.
+func (r *InlineWhitespace) Name() string { return RuleInlineWhitespace }
+
+// Description returns what this rule checks.
+func (r *InlineWhitespace) Description() string {
+	return "adjacent inline links and buttons need whitespace between them"
+}
+
+// Check reports links and buttons with text that directly follow another.
+func (r *InlineWhitespace) Check(doc *parser.Document) []Result {
+	var results []Result
+
+	doc.Walk(func(n *parser.Node) bool {
+		var prev *parser.Node // last link or button with nothing after it
+		for _, c := range n.Children {
+			switch {
+			case c.Type == html.CommentNode:
+				continue
+			case c.Type != html.ElementNode || !TagIn(c, "a", "button") || c.TextContent() == "":
+				prev = nil
+				continue
+			}
+			if prev != nil {
+				params := map[string]any{"element": Tag(c), "previous": Tag(prev)}
+				results = append(results, Result{
+					Rule:      RuleInlineWhitespace,
+					Message:   catalogMessage("inline-whitespace.adjacent", params),
+					MessageID: "inline-whitespace.adjacent",
+					Params:    params,
+					Filename:  doc.Filename,
+					Line:      c.Line,
+					Col:       c.Col,
+					Severity:  Warning,
+				})
+			}
+			prev = c
+		}
+		return true
+	})
+
+	return results
+}
+
+// inlineTags are the phrasing elements whose separating whitespace matters.
+const inlineTags = `a|abbr|b|bdi|bdo|button|cite|code|data|dfn|em|i|kbd|label|mark|q|s|samp|small|span|strong|sub|sup|time|u|var`
+
+// inlineGapPattern matches an inline end tag followed by whitespace and
+// template actions and then an inline start tag; group 1 is the gap.
+var inlineGapPattern = regexp.MustCompile(`(?i)</(?:` + inlineTags + `)\s*>((?:\s|\{\{[\s\S]*?\}\})*?)<(?:` + inlineTags + `)[\s/>]`)
+
+// CheckRaw reports trim markers that remove all whitespace between two
+// inline elements.
+func (r *InlineWhitespace) CheckRaw(filename string, content []byte) []Result {
+	var results []Result
+
+	for _, m := range inlineGapPattern.FindAllSubmatchIndex(content, -1) {
+		start, gap := m[2], content[m[2]:m[3]]
+		actions := parser.DialectGo.Actions(gap)
+		if len(actions) == 0 {
+			continue
+		}
+
+		// whitespace survives unless the action before it ends with -}} or
+		// the action after it starts with {{-
+		survives, trimmer := false, -1
+		prev := 0
+		for i := 0; i <= len(actions); i++ {
+			end := len(gap)
+			if i < len(actions) {
+				if actions[i].Kind == parser.ActionOutput {
+					survives = true // renders text of its own
+					break
+				}
+				end = actions[i].Start
+			}
+			if prev < end {
+				switch {
+				case i > 0 && bytes.HasSuffix(gap[:prev], []byte("-}}")):
+					trimmer = firstOffset(trimmer, actions[i-1].Start)
+				case i < len(actions) && bytes.HasPrefix(gap[end:], []byte("{{-")):
+					trimmer = firstOffset(trimmer, actions[i].Start)
+				default:
+					survives = true
+				}
+			}
+			if i < len(actions) {
+				prev = actions[i].End
+			}
+		}
+		if survives || trimmer < 0 {
+			continue
+		}
+
+		line, col := offsetPosition(content, start+trimmer)
+		results = append(results, Result{
+			Rule:      RuleInlineWhitespace,
+			Message:   catalogMessage("inline-whitespace.trimmed", nil),
+			MessageID: "inline-whitespace.trimmed",
+			Filename:  filename,
+			Line:      line,
+			Col:       col,
+			Severity:  Warning,
+		})
+	}
+
+	return results
+}
+
+// firstOffset returns offset unless first is already set.
+func firstOffset(first, offset int) int {
+	if first < 0 {
+		return offset
+	}
+	return first
+}
@@ -82,13 +82,16 @@ func (r *InputAttributes) Check(doc *parser.Document) []Result {
 			// Handle htmx attributes
 			if IsHTMXAttribute(attrName) {
 				if !r.config.HTMXEnabled {
+					params := map[string]any{"attr": attrName}
 					results = append(results, Result{
-						Rule:     RuleInputAttributes,
-						Message:  "htmx attribute '" + attrName + "' used but htmx not enabled in config",
-						Filename: doc.Filename,
-						Line:     n.Line,
-						Col:      n.Col,
-						Severity: Warning,
+						Rule:      RuleInputAttributes,
+						Message:   catalogMessage("input-attributes.htmx-disabled", params),
+						MessageID: "input-attributes.htmx-disabled",
+						Params:    params,
+						Filename:  doc.Filename,
+						Line:      n.Line,
+						Col:       n.Col,
+						Severity:  Warning,
 					})
 					continue
 				}
@@ -102,32 +105,41 @@ func (r *InputAttributes) Check(doc *parser.Document) []Result {
 
 				if !valid {
 					if v4Only {
+						params := map[string]any{"attr": attrName}
 						results = append(results, Result{
-							Rule:     RuleInputAttributes,
-							Message:  "htmx attribute '" + attrName + "' is only available in htmx 4",
-							Filename: doc.Filename,
-							Line:     n.Line,
-							Col:      n.Col,
-							Severity: Warning,
+							Rule:      RuleInputAttributes,
+							Message:   catalogMessage("input-attributes.htmx-v4", params),
+							MessageID: "input-attributes.htmx-v4",
+							Params:    params,
+							Filename:  doc.Filename,
+							Line:      n.Line,
+							Col:       n.Col,
+							Severity:  Warning,
 						})
 					} else {
+						params := map[string]any{"attr": attrName}
 						results = append(results, Result{
-							Rule:     RuleInputAttributes,
-							Message:  "unknown htmx attribute '" + attrName + "'",
-							Filename: doc.Filename,
-							Line:     n.Line,
-							Col:      n.Col,
-							Severity: Warning,
+							Rule:      RuleInputAttributes,
+							Message:   catalogMessage("input-attributes.htmx-unknown", params),
+							MessageID: "input-attributes.htmx-unknown",
+							Params:    params,
+							Filename:  doc.Filename,
+							Line:      n.Line,
+							Col:       n.Col,
+							Severity:  Warning,
 						})
 					}
 				} else if deprecated {
+					params := map[string]any{"attr": attrName}
 					results = append(results, Result{
-						Rule:     RuleInputAttributes,
-						Message:  "htmx attribute '" + attrName + "' is deprecated in htmx 4",
-						Filename: doc.Filename,
-						Line:     n.Line,
-						Col:      n.Col,
-						Severity: Warning,
+						Rule:      RuleInputAttributes,
+						Message:   catalogMessage("input-attributes.htmx-deprecated", params),
+						MessageID: "input-attributes.htmx-deprecated",
+						Params:    params,
+						Filename:  doc.Filename,
+						Line:      n.Line,
+						Col:       n.Col,
+						Severity:  Warning,
 					})
 				}
 				continue
@@ -135,13 +147,16 @@ func (r *InputAttributes) Check(doc *parser.Document) []Result {
 
 			// Check if attribute is valid for this input type
 			if !allowedAttrs[attrName] {
+				params := map[string]any{"attr": attrName, "type": inputType}
 				results = append(results, Result{
-					Rule:     RuleInputAttributes,
-					Message:  "attribute '" + attrName + "' not valid for input type=\"" + inputType + "\"",
-					Filename: doc.Filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Warning,
+					Rule:      RuleInputAttributes,
+					Message:   catalogMessage("input-attributes.invalid", params),
+					MessageID: "input-attributes.invalid",
+					Params:    params,
+					Filename:  doc.Filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Warning,
 				})
 			}
 		}
@@ -63,11 +63,8 @@ func (r *InputLabel) Check(doc *parser.Document) []Result {
 		}
 
 		// Check if input is inside a label
-		for p := n.Parent; p != nil; p = p.Parent {
-			if p.IsElement("label") {
-				hasLabel = true
-				break
-			}
+		if n.ClosestAncestor("label") != nil {
+			hasLabel = true
 		}
 
 		// Check title attribute as fallback
@@ -76,13 +73,16 @@ func (r *InputLabel) Check(doc *parser.Document) []Result {
 		}
 
 		if !hasLabel {
+			params := map[string]any{"element": n.Data}
 			results = append(results, Result{
-				Rule:     r.Name(),
-				Message:  n.Data + " element missing accessible label",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Error,
+				Rule:      r.Name(),
+				Message:   catalogMessage("input-label.missing", params),
+				MessageID: "input-label.missing",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Error,
 			})
 		}
 
@@ -0,0 +1,122 @@
+package rules
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// inputValueFormat describes the machine-readable format an input type
+// requires for its value, min, and max attributes.
+type inputValueFormat struct {
+	pattern *regexp.Regexp
+	layout  string // time.Parse layout confirming the value is a real date, if any
+	example string
+}
+
+// floatPattern matches a valid floating-point number per HTML.
+var floatPattern = regexp.MustCompile(`^-?(?:[0-9]+(?:\.[0-9]+)?|\.[0-9]+)(?:[eE][-+]?[0-9]+)?$`)
+
+// inputValueFormats maps input types to their value formats.
+var inputValueFormats = map[string]inputValueFormat{
+	"date": {
+		pattern: regexp.MustCompile(`^[0-9]{4,}-[0-9]{2}-[0-9]{2}$`),
+		layout:  "2006-01-02",
+		example: "YYYY-MM-DD, e.g. 2024-12-31",
+	},
+	"datetime-local": {
+		pattern: regexp.MustCompile(`^[0-9]{4,}-[0-9]{2}-[0-9]{2}[T ][0-9]{2}:[0-9]{2}(?::[0-9]{2}(?:\.[0-9]{1,3})?)?$`),
+		layout:  "2006-01-02",
+		example: "YYYY-MM-DDThh:mm, e.g. 2024-12-31T18:30",
+	},
+	"month": {
+		pattern: regexp.MustCompile(`^[0-9]{4,}-[0-9]{2}$`),
+		layout:  "2006-01",
+		example: "YYYY-MM, e.g. 2024-12",
+	},
+	"week": {
+		pattern: regexp.MustCompile(`^[0-9]{4,}-W(?:0[1-9]|[1-4][0-9]|5[0-3])$`),
+		example: "YYYY-Www, e.g. 2024-W52",
+	},
+	"time": {
+		pattern: regexp.MustCompile(`^(?:[01][0-9]|2[0-3]):[0-5][0-9](?::[0-5][0-9](?:\.[0-9]{1,3})?)?$`),
+		example: "hh:mm in 24-hour time, e.g. 18:30",
+	},
+	"number": {
+		pattern: floatPattern,
+		example: "a plain number with a . decimal separator, e.g. 1234.5",
+	},
+	"range": {
+		pattern: floatPattern,
+		example: "a plain number with a . decimal separator, e.g. 1234.5",
+	},
+}
+
+// InputValueFormat checks that literal value, min, and max attributes of
+// date, time, and number inputs use the formats browsers parse. A locale
+// format such as 31/12/2024 or 1,5 is silently dropped, leaving the field
+// empty or unbounded.
+type InputValueFormat struct{}
+
+// Name returns the rule identifier.
+func (r *InputValueFormat) Name() string { return RuleInputValueFormat }
+
+// Description returns what this rule checks.
+func (r *InputValueFormat) Description() string {
+	return "date, time, and number input values must use machine-readable formats"
+}
+
+// Check examines input value, min, and max attributes.
+func (r *InputValueFormat) Check(doc *parser.Document) []Result {
+	var results []Result
+
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.ElementNode || !TagEquals(n, "input") {
+			return true
+		}
+		inputType := strings.ToLower(strings.TrimSpace(n.GetAttr("type")))
+		format, ok := inputValueFormats[inputType]
+		if !ok {
+			return true
+		}
+
+		for _, attr := range []string{"value", "min", "max"} {
+			value := n.GetAttr(attr)
+			if value == "" || IsTemplateExpr(value) || validInputValue(format, value) {
+				continue
+			}
+			line, col := n.AttrPos(attr)
+			params := map[string]any{"attr": attr, "value": value, "type": inputType, "example": format.example}
+			results = append(results, Result{
+				Rule:      RuleInputValueFormat,
+				Message:   catalogMessage("input-value-format.invalid", params),
+				MessageID: "input-value-format.invalid",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      line,
+				Col:       col,
+				Severity:  Error,
+			})
+		}
+
+		return true
+	})
+
+	return results
+}
+
+// validInputValue reports whether value matches format and, for dates,
+// names a real calendar date.
+func validInputValue(format inputValueFormat, value string) bool {
+	if !format.pattern.MatchString(value) {
+		return false
+	}
+	if format.layout == "" || value[4] != '-' {
+		return true // no calendar check for years past 9999
+	}
+	_, err := time.Parse(format.layout, value[:len(format.layout)])
+	return err == nil
+}
@@ -29,12 +29,13 @@ func (r *LinkName) Check(doc *parser.Document) []Result {
 
 		if !HasAccessibleName(n) {
 			results = append(results, Result{
-				Rule:     r.Name(),
-				Message:  "link element missing accessible name",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Error,
+				Rule:      r.Name(),
+				Message:   catalogMessage("link-name.missing", nil),
+				MessageID: "link-name.missing",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Error,
 			})
 		}
 
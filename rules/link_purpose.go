@@ -0,0 +1,123 @@
+package rules
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// DefaultGenericLinkText lists link text that says nothing about where the
+// link goes.
+var DefaultGenericLinkText = []string{
+	"click", "click here", "details", "here", "learn more", "link", "more",
+	"more info", "read more", "this", "this link",
+}
+
+// LinkPurpose checks that a link's href and text tell users where it goes
+// (WCAG 2.4.4). It reports links whose href is "#" or empty, which are
+// usually click handlers that should be buttons; links whose text is in
+// the "generic-text" list ("click here", "read more", ...); and links that
+// share text with an earlier link to a different URL, which screen reader
+// users browsing a list of links cannot tell apart. An aria-label replaces
+// the text content; links named by aria-labelledby are not compared.
+type LinkPurpose struct {
+	GenericText []string
+}
+
+// Name returns the rule identifier.
+func (r *LinkPurpose) Name() string { return RuleLinkPurpose }
+
+// Description returns what this rule checks.
+func (r *LinkPurpose) Description() string {
+	return "links need a real destination and text that describes it"
+}
+
+// ConfigureOptions applies the generic-text option.
+func (r *LinkPurpose) ConfigureOptions(opts map[string]any) {
+	r.GenericText = StringsOption(opts, "generic-text", r.GenericText)
+}
+
+// Check examines every link in the document.
+func (r *LinkPurpose) Check(doc *parser.Document) []Result {
+	generic := make(map[string]bool)
+	words := r.GenericText
+	if words == nil {
+		words = DefaultGenericLinkText
+	}
+	for _, w := range words {
+		generic[linkText(w)] = true
+	}
+
+	type firstLink struct {
+		href string
+		line int
+	}
+	seen := make(map[string]firstLink) // link text -> first link with it
+
+	var results []Result
+	report := func(n *parser.Node, attr, id string, params map[string]any) {
+		line, col := n.AttrPos(attr)
+		results = append(results, Result{
+			Rule:      RuleLinkPurpose,
+			Message:   catalogMessage(id, params),
+			MessageID: id,
+			Params:    params,
+			Filename:  doc.Filename,
+			Line:      line,
+			Col:       col,
+			Severity:  Warning,
+		})
+	}
+
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.ElementNode || !n.IsElement("a") || !n.HasAttr("href") {
+			return true
+		}
+		href := strings.TrimSpace(n.GetAttr("href"))
+		if IsTemplateExpr(href) {
+			return true
+		}
+		if href == "" || href == "#" {
+			report(n, "href", "link-purpose.nowhere", map[string]any{"href": href})
+			return true
+		}
+		if n.HasAttr("aria-labelledby") {
+			return true
+		}
+
+		raw := n.TextContent()
+		if label := n.GetAttr("aria-label"); label != "" {
+			raw = label
+		}
+		if IsTemplateExpr(raw) {
+			return true
+		}
+		text := linkText(raw)
+		switch {
+		case text == "":
+			// link-name reports links without a name.
+		case generic[text]:
+			report(n, "", "link-purpose.generic", map[string]any{"text": strings.Join(strings.Fields(raw), " ")})
+		default:
+			first, ok := seen[text]
+			if !ok {
+				seen[text] = firstLink{href: href, line: n.Line}
+			} else if first.href != href {
+				report(n, "", "link-purpose.ambiguous", map[string]any{"text": strings.Join(strings.Fields(raw), " "), "href": first.href, "line": first.line})
+			}
+		}
+		return true
+	})
+
+	return results
+}
+
+// linkText normalizes link text for comparison, dropping surrounding
+// punctuation and symbols such as "»" and "…".
+func linkText(s string) string {
+	return strings.TrimFunc(NormalizeText(s), func(c rune) bool {
+		return unicode.IsPunct(c) || unicode.IsSymbol(c) || unicode.IsSpace(c)
+	})
+}
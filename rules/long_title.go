@@ -1,7 +1,6 @@
 package rules
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/toba/go-html-validate/parser"
@@ -34,13 +33,16 @@ func (r *LongTitle) Check(doc *parser.Document) []Result {
 
 		text := strings.TrimSpace(n.TextContent())
 		if len(text) > MaxTitleLength {
+			params := map[string]any{"length": len(text), "max": MaxTitleLength}
 			results = append(results, Result{
-				Rule:     r.Name(),
-				Message:  fmt.Sprintf("title text is %d characters, should be at most %d", len(text), MaxTitleLength),
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Warning,
+				Rule:      r.Name(),
+				Message:   catalogMessage("long-title.long", params),
+				MessageID: "long-title.long",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
 			})
 		}
 
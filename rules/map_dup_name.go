@@ -34,13 +34,16 @@ func (r *MapDupName) Check(doc *parser.Document) []Result {
 					continue
 				}
 				if first, exists := names[name]; exists {
+					params := map[string]any{"name": name}
 					results = append(results, Result{
-						Rule:     RuleMapDupName,
-						Message:  "duplicate area name: " + name,
-						Filename: doc.Filename,
-						Line:     child.Line,
-						Col:      child.Col,
-						Severity: Warning,
+						Rule:      RuleMapDupName,
+						Message:   catalogMessage("map-dup-name.repeat", params),
+						MessageID: "map-dup-name.repeat",
+						Params:    params,
+						Filename:  doc.Filename,
+						Line:      child.Line,
+						Col:       child.Col,
+						Severity:  Warning,
 					})
 					_ = first // First occurrence tracked but not reported
 				} else {
@@ -36,25 +36,29 @@ func (r *MapIDName) Check(doc *parser.Document) []Result {
 		// Map must have name attribute
 		if name == "" {
 			results = append(results, Result{
-				Rule:     RuleMapIDName,
-				Message:  "map element must have name attribute",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Error,
+				Rule:      RuleMapIDName,
+				Message:   catalogMessage("map-id-name.missing-name", nil),
+				MessageID: "map-id-name.missing-name",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Error,
 			})
 			return true
 		}
 
 		// If both present, they should match
 		if id != "" && id != name {
+			params := map[string]any{"id": id, "name": name}
 			results = append(results, Result{
-				Rule:     RuleMapIDName,
-				Message:  "map id=\"" + id + "\" and name=\"" + name + "\" should match",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Warning,
+				Rule:      RuleMapIDName,
+				Message:   catalogMessage("map-id-name.mismatch", params),
+				MessageID: "map-id-name.mismatch",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
 			})
 		}
 
@@ -0,0 +1,43 @@
+package rules
+
+// MathMLElements lists MathML element names, from MathML Core plus the
+// MathML 3 presentation elements browsers still parse.
+var MathMLElements = map[string]bool{
+	// MathML Core
+	"math": true, "annotation": true, "annotation-xml": true, "semantics": true,
+	"maction": true, "merror": true, "mfrac": true, "mi": true,
+	"mmultiscripts": true, "mn": true, "mo": true, "mover": true,
+	"mpadded": true, "mphantom": true, "mprescripts": true, "mroot": true,
+	"mrow": true, "ms": true, "mspace": true, "msqrt": true, "mstyle": true,
+	"msub": true, "msubsup": true, "msup": true, "mtable": true, "mtd": true,
+	"mtext": true, "mtr": true, "munder": true, "munderover": true, "none": true,
+	// MathML 3
+	"menclose": true, "mfenced": true, "mglyph": true, "malignmark": true,
+	"mlabeledtr": true, "mlongdiv": true, "mscarries": true, "mscarry": true,
+	"msgroup": true, "msline": true, "msrow": true, "mstack": true,
+}
+
+// MathMLTokenElements hold text rather than other MathML elements.
+var MathMLTokenElements = map[string]bool{
+	"mi": true, "mn": true, "mo": true, "ms": true, "mtext": true,
+}
+
+// MathMLChildCounts gives the exact number of child elements MathML
+// scripted and fraction elements require.
+var MathMLChildCounts = map[string]int{
+	"mfrac":      2,
+	"mroot":      2,
+	"msub":       2,
+	"msup":       2,
+	"msubsup":    3,
+	"munder":     2,
+	"mover":      2,
+	"munderover": 3,
+}
+
+// MathMLHTMLEncodings are annotation-xml encodings whose content the HTML
+// parser treats as HTML.
+var MathMLHTMLEncodings = map[string]bool{
+	"text/html":             true,
+	"application/xhtml+xml": true,
+}
@@ -0,0 +1,128 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// MathMLStructure validates the structure of embedded MathML: child counts
+// of fractions, roots, and scripts; token elements holding text rather
+// than MathML layout; bare text outside token elements; table rows and
+// cells; and annotations inside semantics with a usable encoding.
+// The rule is opt-in.
+type MathMLStructure struct{}
+
+// Name returns the rule identifier.
+func (r *MathMLStructure) Name() string { return RuleMathMLStructure }
+
+// Description returns what this rule checks.
+func (r *MathMLStructure) Description() string {
+	return "embedded MathML must be well structured"
+}
+
+// OptIn marks the rule as disabled unless explicitly enabled.
+func (r *MathMLStructure) OptIn() {}
+
+// mathParents lists MathML elements allowed only inside specific parents.
+var mathParents = map[string][]string{
+	"mtr":            {"mtable"},
+	"mlabeledtr":     {"mtable"},
+	"mtd":            {"mtr", "mlabeledtr"},
+	"annotation":     {"semantics"},
+	"annotation-xml": {"semantics"},
+	"mprescripts":    {"mmultiscripts"},
+	"none":           {"mmultiscripts"},
+}
+
+// Check examines MathML elements.
+func (r *MathMLStructure) Check(doc *parser.Document) []Result {
+	var results []Result
+	report := func(n *parser.Node, m message, sev Severity) {
+		results = append(results, Result{
+			Rule:      RuleMathMLStructure,
+			Message:   m.text(),
+			MessageID: m.id,
+			Params:    m.params,
+			Filename:  doc.Filename,
+			Line:      n.Line,
+			Col:       n.Col,
+			Severity:  sev,
+		})
+	}
+
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.ElementNode || n.Namespace != "math" || inMathAnnotation(n) {
+			return true
+		}
+		tag := Tag(n)
+
+		if parents, ok := mathParents[tag]; ok && (n.Parent == nil || !TagIn(n.Parent, parents...)) {
+			report(n, message{"mathml-structure.parent", map[string]any{"element": tag, "parents": strings.Join(parents, "> or <")}}, Error)
+		}
+
+		children := n.ChildElements()
+		if want, ok := MathMLChildCounts[tag]; ok && len(children) != want {
+			report(n, message{"mathml-structure.child-count", map[string]any{"element": tag, "want": want, "count": len(children)}}, Error)
+		}
+
+		switch {
+		case MathMLTokenElements[tag]:
+			for _, c := range children {
+				// the parser puts children of token elements in the HTML namespace
+				if MathMLElements[Tag(c)] && !TagIn(c, "mglyph", "malignmark") {
+					report(c, message{"mathml-structure.token-child", map[string]any{"element": tag, "child": Tag(c)}}, Error)
+				}
+			}
+			return true
+		case tag == "annotation", tag == "annotation-xml":
+			r.checkAnnotation(n, report)
+			return true
+		case tag == "mtable":
+			for _, c := range children {
+				if !TagIn(c, "mtr", "mlabeledtr") {
+					report(c, message{"mathml-structure.mtable-child", nil}, Error)
+				}
+			}
+		}
+
+		for _, c := range n.Children {
+			if c.Type == html.TextNode && strings.TrimSpace(c.Data) != "" && !IsTemplateExpr(c.Data) {
+				report(n, message{"mathml-structure.bare-text", map[string]any{"element": tag}}, Warning)
+				break
+			}
+		}
+
+		return true
+	})
+
+	return results
+}
+
+// checkAnnotation validates an annotation's encoding attribute.
+func (r *MathMLStructure) checkAnnotation(n *parser.Node, report func(*parser.Node, message, Severity)) {
+	if Tag(n) != "annotation-xml" {
+		return
+	}
+	encoding := strings.ToLower(strings.TrimSpace(n.GetAttr("encoding")))
+	if encoding == "" {
+		report(n, message{"mathml-structure.encoding-missing", nil}, Warning)
+		return
+	}
+	if MathMLHTMLEncodings[encoding] {
+		return
+	}
+	for _, c := range n.ChildElements() {
+		if c.Namespace == "" {
+			report(n, message{"mathml-structure.encoding-html", nil}, Error)
+			return
+		}
+	}
+}
+
+// inMathAnnotation reports whether n is inside an annotation, whose
+// content (e.g. Content MathML) is not presentation markup.
+func inMathAnnotation(n *parser.Node) bool {
+	return n.ClosestAncestor("annotation", "annotation-xml") != nil
+}
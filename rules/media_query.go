@@ -0,0 +1,132 @@
+package rules
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// MediaQuery checks media attributes on <link>, <style>, and <source>
+// outside <picture> (picture-source covers those). A query that fails to
+// parse never matches, so the resource is fetched but never applied; an
+// unknown or deprecated media type or feature matches nothing either.
+// It also flags print stylesheets in <head> that are made render-blocking
+// with blocking="render", fetchpriority="high", or a matching style
+// preload: browsers load print-only CSS in the background unless told
+// otherwise, and it is only needed when printing.
+type MediaQuery struct{}
+
+// Name returns the rule identifier.
+func (r *MediaQuery) Name() string { return RuleMediaQuery }
+
+// Description returns what this rule checks.
+func (r *MediaQuery) Description() string {
+	return "media attributes must be valid media queries, and print stylesheets must not block rendering"
+}
+
+// deprecatedMediaTypes are media types from CSS 2 that Media Queries
+// Level 4 deprecates; they never match.
+var deprecatedMediaTypes = map[string]bool{
+	"aural": true, "braille": true, "embossed": true, "handheld": true,
+	"projection": true, "speech": true, "tty": true, "tv": true,
+}
+
+// Check examines media attributes and print stylesheets in the document.
+func (r *MediaQuery) Check(doc *parser.Document) []Result {
+	var results []Result
+
+	preloads := make(map[string]bool) // hrefs of style preloads in <head>
+	for _, n := range doc.QuerySelectorAll("head link") {
+		rels := strings.Fields(strings.ToLower(n.GetAttr("rel")))
+		href := strings.TrimSpace(n.GetAttr("href"))
+		if href != "" && slices.Contains(rels, "preload") && strings.EqualFold(strings.TrimSpace(n.GetAttr("as")), "style") {
+			preloads[href] = true
+		}
+	}
+
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.ElementNode || !TagIn(n, "link", "style", "source") || !n.HasAttr("media") {
+			return true
+		}
+		if n.IsElement("source") && n.Parent != nil && n.Parent.IsElement("picture") {
+			return true
+		}
+		raw := n.GetAttr("media")
+		if IsTemplateExpr(raw) {
+			return true
+		}
+		media := strings.Join(strings.Fields(strings.ToLower(raw)), " ")
+
+		if problem := mediaQueryProblem(media); problem != "" {
+			severity := Error
+			if strings.HasPrefix(problem, "unknown ") || strings.HasPrefix(problem, "deprecated ") {
+				severity = Warning
+			}
+			line, col := n.AttrPos("media")
+			params := map[string]any{"media": raw, "element": Tag(n), "problem": problem}
+			results = append(results, Result{
+				Rule:      RuleMediaQuery,
+				Message:   catalogMessage("media-query.invalid", params),
+				MessageID: "media-query.invalid",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      line,
+				Col:       col,
+				Severity:  severity,
+			})
+			return true
+		}
+
+		rels := strings.Fields(strings.ToLower(n.GetAttr("rel")))
+		if !n.IsElement("link") || !slices.Contains(rels, "stylesheet") || slices.Contains(rels, "alternate") ||
+			!printOnlyMedia(media) || n.ClosestAncestor("head") == nil {
+			return true
+		}
+		var how string
+		switch {
+		case slices.Contains(strings.Fields(strings.ToLower(n.GetAttr("blocking"))), "render"):
+			how = "blocking=\"render\""
+		case strings.EqualFold(strings.TrimSpace(n.GetAttr("fetchpriority")), "high"):
+			how = "fetchpriority=\"high\""
+		case preloads[strings.TrimSpace(n.GetAttr("href"))]:
+			how = "a style preload"
+		default:
+			return true
+		}
+		params := map[string]any{"by": how}
+		results = append(results, Result{
+			Rule:      RuleMediaQuery,
+			Message:   catalogMessage("media-query.print-blocking", params),
+			MessageID: "media-query.print-blocking",
+			Params:    params,
+			Filename:  doc.Filename,
+			Line:      n.Line,
+			Col:       n.Col,
+			Severity:  Warning,
+		})
+
+		return true
+	})
+
+	return results
+}
+
+// printOnlyMedia reports whether every query of a normalized media query
+// list applies to the print media type only.
+func printOnlyMedia(media string) bool {
+	for _, query := range splitTopLevel(media, ',') {
+		tokens, problem := mediaTokens(strings.TrimSpace(query))
+		if problem != "" {
+			return false
+		}
+		if tokens[0] == "only" {
+			tokens = tokens[1:]
+		}
+		if len(tokens) == 0 || tokens[0] != "print" {
+			return false
+		}
+	}
+	return true
+}
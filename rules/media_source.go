@@ -0,0 +1,174 @@
+package rules
+
+import (
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// MediaSource checks how <audio> and <video> name their media. An element
+// with a src attribute ignores its <source> children, so having both is a
+// mistake; each <source> needs a src and, if it has a type, an audio,
+// video, or streaming MIME type. An element whose only candidates are
+// legacy plugin formats such as Flash or Windows Media plays nowhere.
+// Elements without any source are skipped, since scripts often attach a
+// stream or set src later.
+type MediaSource struct{}
+
+// Name returns the rule identifier.
+func (r *MediaSource) Name() string { return RuleMediaSource }
+
+// Description returns what this rule checks.
+func (r *MediaSource) Description() string {
+	return "audio and video need consistent src and source elements with valid types"
+}
+
+// KnownMediaTypes lists MIME types accepted on <source type> in <audio>
+// and <video>, besides which any audio/ or video/ type is only warned
+// about.
+var KnownMediaTypes = map[string]bool{
+	"application/dash+xml":          true,
+	"application/ogg":               true,
+	"application/vnd.apple.mpegurl": true,
+	"application/x-mpegurl":         true,
+	"audio/aac":                     true,
+	"audio/flac":                    true,
+	"audio/mp4":                     true,
+	"audio/mpeg":                    true,
+	"audio/ogg":                     true,
+	"audio/opus":                    true,
+	"audio/wav":                     true,
+	"audio/wave":                    true,
+	"audio/webm":                    true,
+	"audio/x-m4a":                   true,
+	"audio/x-wav":                   true,
+	"video/mp2t":                    true,
+	"video/mp4":                     true,
+	"video/ogg":                     true,
+	"video/quicktime":               true,
+	"video/webm":                    true,
+}
+
+// legacyMediaTypes and legacyMediaExts identify formats that need a
+// browser plugin.
+var (
+	legacyMediaTypes = map[string]bool{
+		"application/x-shockwave-flash": true,
+		"audio/x-ms-wma":                true,
+		"audio/x-pn-realaudio":          true,
+		"audio/x-realaudio":             true,
+		"video/x-flv":                   true,
+		"video/x-ms-asf":                true,
+		"video/x-ms-wmv":                true,
+		"video/x-msvideo":               true,
+	}
+	legacyMediaExts = map[string]bool{
+		".asf": true, ".avi": true, ".flv": true, ".ra": true, ".ram": true,
+		".rm": true, ".rmvb": true, ".swf": true, ".wma": true, ".wmv": true,
+	}
+)
+
+// Check examines each audio and video element in the document.
+func (r *MediaSource) Check(doc *parser.Document) []Result {
+	var results []Result
+
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.ElementNode || !TagIn(n, "audio", "video") {
+			return true
+		}
+		tag := Tag(n)
+
+		var sources []*parser.Node
+		for _, child := range n.Children {
+			if child.Type == html.ElementNode && child.IsElement("source") {
+				sources = append(sources, child)
+			}
+		}
+
+		report := func(el *parser.Node, attr string, m message, sev Severity) {
+			line, col := el.AttrPos(attr)
+			results = append(results, Result{
+				Rule:      RuleMediaSource,
+				Message:   m.text(),
+				MessageID: m.id,
+				Params:    m.params,
+				Filename:  doc.Filename,
+				Line:      line,
+				Col:       col,
+				Severity:  sev,
+			})
+		}
+
+		if n.HasAttr("src") && len(sources) > 0 {
+			report(n, "src", message{"media-source.src-and-source", map[string]any{"element": tag}}, Error)
+			return true
+		}
+
+		legacy := 0
+		for _, s := range sources {
+			if !s.HasAttr("src") {
+				report(s, "", message{"media-source.source-src", map[string]any{"element": tag}}, Error)
+			}
+			typ := s.GetAttr("type")
+			if s.HasAttr("type") && !IsTemplateExpr(typ) {
+				if m, sev := mediaTypeProblem(typ); m.id != "" {
+					report(s, "type", m, sev)
+				}
+			}
+			if legacyMedia(typ, s.GetAttr("src")) {
+				legacy++
+			}
+		}
+
+		switch {
+		case len(sources) > 0 && legacy == len(sources):
+			report(n, "", message{"media-source.legacy-sources", map[string]any{"element": tag}}, Warning)
+		case len(sources) == 0 && legacyMedia("", n.GetAttr("src")):
+			report(n, "src", message{"media-source.legacy-src", map[string]any{"element": tag}}, Warning)
+		}
+
+		return true
+	})
+
+	return results
+}
+
+// mediaTypeProblem returns a message and severity for an invalid <source>
+// type, ignoring codecs and other parameters, or the zero message if it is
+// acceptable.
+func mediaTypeProblem(typ string) (message, Severity) {
+	essence, _, _ := strings.Cut(typ, ";")
+	essence = strings.ToLower(strings.TrimSpace(essence))
+	switch {
+	case essence == "":
+		return message{"media-source.type-empty", nil}, Error
+	case KnownMediaTypes[essence], legacyMediaTypes[essence]:
+		return message{}, 0
+	case !strings.Contains(essence, "/"):
+		return message{"media-source.type-syntax", map[string]any{"type": typ}}, Error
+	case strings.HasPrefix(essence, "audio/"), strings.HasPrefix(essence, "video/"):
+		return message{"media-source.type-unknown", map[string]any{"type": essence}}, Warning
+	default:
+		return message{"media-source.type-not-media", map[string]any{"type": essence}}, Error
+	}
+}
+
+// legacyMedia reports whether a candidate's type, or failing that its URL
+// extension, is a plugin format.
+func legacyMedia(typ, src string) bool {
+	if essence, _, _ := strings.Cut(typ, ";"); strings.TrimSpace(essence) != "" {
+		return legacyMediaTypes[strings.ToLower(strings.TrimSpace(essence))]
+	}
+	src = strings.TrimSpace(src)
+	if src == "" || IsTemplateExpr(src) {
+		return false
+	}
+	u, err := url.Parse(src)
+	if err != nil {
+		return false
+	}
+	return legacyMediaExts[strings.ToLower(path.Ext(u.Path))]
+}
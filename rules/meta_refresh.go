@@ -37,12 +37,13 @@ func (r *MetaRefresh) Check(doc *parser.Document) []Result {
 		// Any refresh is problematic for accessibility
 		// Immediate redirects (0 seconds) are less bad but still flagged
 		results = append(results, Result{
-			Rule:     r.Name(),
-			Message:  "meta refresh causes automatic page change, disorienting users",
-			Filename: doc.Filename,
-			Line:     n.Line,
-			Col:      n.Col,
-			Severity: Error,
+			Rule:      r.Name(),
+			Message:   catalogMessage("meta-refresh.refresh", nil),
+			MessageID: "meta-refresh.refresh",
+			Filename:  doc.Filename,
+			Line:      n.Line,
+			Col:       n.Col,
+			Severity:  Error,
 		})
 
 		return true
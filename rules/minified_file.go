@@ -0,0 +1,89 @@
+package rules
+
+import (
+	"bytes"
+
+	"github.com/toba/go-html-validate/parser"
+)
+
+// DefaultMaxLineKB is the line length, in KB, above which MinifiedFile
+// treats a file as minified.
+const DefaultMaxLineKB = 10
+
+// MinifiedFile reports files that look minified: a single line longer than
+// "max-line-kb" (default 10). Minified output is usually a build artifact,
+// and its findings are better fixed in the source it was built from. With
+// "skip-style-rules" the linter also skips rules about formatting and
+// optional markup, which minifiers strip on purpose, for such files.
+type MinifiedFile struct {
+	MaxLineKB      int
+	SkipStyleRules bool
+}
+
+// Name returns the rule identifier.
+func (r *MinifiedFile) Name() string { return RuleMinifiedFile }
+
+// Description returns what this rule checks.
+func (r *MinifiedFile) Description() string {
+	return "minified files should be linted from their unminified source"
+}
+
+// ConfigureOptions applies the max-line-kb and skip-style-rules options.
+func (r *MinifiedFile) ConfigureOptions(opts map[string]any) {
+	r.MaxLineKB = IntOption(opts, "max-line-kb", r.MaxLineKB)
+	r.SkipStyleRules = BoolOption(opts, "skip-style-rules", r.SkipStyleRules)
+}
+
+// Check implements Rule but returns nil - this rule uses CheckRaw instead.
+func (r *MinifiedFile) Check(_ *parser.Document) []Result {
+	return nil
+}
+
+// CheckRaw reports the first over-long line.
+func (r *MinifiedFile) CheckRaw(filename string, content []byte) []Result {
+	line, length := r.longLine(content)
+	if line == 0 {
+		return nil
+	}
+	params := map[string]any{"line": line, "kb": (length + 1023) >> 10}
+	return []Result{{
+		Rule:      r.Name(),
+		Message:   catalogMessage("minified-file.minified", params),
+		MessageID: "minified-file.minified",
+		Params:    params,
+		Filename:  filename,
+		Line:      line,
+		Col:       1,
+		Severity:  Info,
+	}}
+}
+
+// Minified reports whether content has a line longer than the limit.
+func (r *MinifiedFile) Minified(content []byte) bool {
+	line, _ := r.longLine(content)
+	return line > 0
+}
+
+// longLine returns the number and length of the first line longer than
+// the limit, or 0, 0.
+func (r *MinifiedFile) longLine(content []byte) (line, length int) {
+	limit := r.MaxLineKB
+	if limit <= 0 {
+		limit = DefaultMaxLineKB
+	}
+	limit <<= 10
+	if len(content) <= limit {
+		return 0, 0
+	}
+	for n := 1; len(content) > 0; n++ {
+		end := bytes.IndexByte(content, '\n')
+		if end < 0 {
+			end = len(content)
+		}
+		if end > limit {
+			return n, end
+		}
+		content = content[min(end+1, len(content)):]
+	}
+	return 0, 0
+}
@@ -0,0 +1,210 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// WCAG success criteria reported by MotionSafety.
+const (
+	wcagPauseStopHide         = "2.2.2"
+	wcagAnimationInteractions = "2.3.3"
+)
+
+// MotionSafety flags motion that users who are sensitive to it cannot turn
+// off: endlessly repeating animations (WCAG 2.2.2 Pause, Stop, Hide),
+// autoplaying video, and smooth scrolling of the whole page (WCAG 2.3.3
+// Animation from Interactions). Style sheets in the document count as
+// guarded once any of them has a prefers-reduced-motion media query;
+// inline style attributes cannot be guarded. Pages whose guard lives in an
+// external style sheet or layout will be reported, so the rule is opt-in.
+// Results carry the criterion in Meta[MetaWCAG].
+type MotionSafety struct{}
+
+// Name returns the rule identifier.
+func (r *MotionSafety) Name() string { return RuleMotionSafety }
+
+// Description returns what this rule checks.
+func (r *MotionSafety) Description() string {
+	return "infinite animations, autoplaying video, and global smooth scrolling must respect prefers-reduced-motion"
+}
+
+// OptIn marks the rule as disabled unless explicitly enabled.
+func (r *MotionSafety) OptIn() {}
+
+// Check examines style attributes, style elements, and video elements.
+func (r *MotionSafety) Check(doc *parser.Document) []Result {
+	var results []Result
+	report := func(line, col int, wcag, id string, params map[string]any) {
+		results = append(results, Result{
+			Rule:      RuleMotionSafety,
+			Message:   catalogMessage(id, params),
+			MessageID: id,
+			Params:    params,
+			Filename:  doc.Filename,
+			Line:      line,
+			Col:       col,
+			Severity:  Warning,
+			Meta:      map[string]string{MetaWCAG: wcag},
+		})
+	}
+
+	var styles []*parser.Node
+	var videos []*parser.Node
+	guarded := false
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+		switch {
+		case n.IsElement("style"):
+			styles = append(styles, n)
+			if strings.Contains(strings.ToLower(n.TextContent()), "prefers-reduced-motion") {
+				guarded = true
+			}
+		case n.IsElement("video") && n.HasAttr("autoplay"):
+			videos = append(videos, n)
+		}
+
+		if style := n.GetAttr("style"); style != "" {
+			line, col := n.AttrPos("style")
+			for _, d := range styleDeclarations(style) {
+				switch {
+				case isInfiniteAnimation(d):
+					report(line, col, wcagPauseStopHide, "motion-safety.inline-animation", nil)
+				case isSmoothScroll(d) && TagIn(n, "html", "body"):
+					report(line, col, wcagAnimationInteractions, "motion-safety.inline-smooth-scroll", map[string]any{"element": n.Data})
+				}
+			}
+		}
+		return true
+	})
+
+	if guarded {
+		return results
+	}
+
+	for _, n := range styles {
+		for _, rule := range cssRules(n.TextContent()) {
+			for _, d := range styleDeclarations(rule.body) {
+				switch {
+				case isInfiniteAnimation(d):
+					report(n.Line, n.Col, wcagPauseStopHide, "motion-safety.animation", map[string]any{"selector": rule.selector})
+				case isSmoothScroll(d) && isGlobalSelector(rule.selector):
+					report(n.Line, n.Col, wcagAnimationInteractions, "motion-safety.smooth-scroll", map[string]any{"selector": rule.selector})
+				}
+			}
+		}
+	}
+	for _, n := range videos {
+		line, col := n.AttrPos("autoplay")
+		report(line, col, wcagPauseStopHide, "motion-safety.autoplay", nil)
+	}
+
+	return results
+}
+
+// cssDeclaration is a property and its value, both trimmed and lowercased.
+type cssDeclaration struct {
+	prop, value string
+}
+
+// styleDeclarations splits a declaration block such as a style attribute.
+func styleDeclarations(block string) []cssDeclaration {
+	var decls []cssDeclaration
+	for part := range strings.SplitSeq(block, ";") {
+		prop, value, ok := strings.Cut(part, ":")
+		if !ok {
+			continue
+		}
+		decls = append(decls, cssDeclaration{
+			prop:  strings.ToLower(strings.TrimSpace(prop)),
+			value: strings.ToLower(strings.TrimSpace(value)),
+		})
+	}
+	return decls
+}
+
+// cssRule is a style rule of a style sheet with its declaration block.
+type cssRule struct {
+	selector string
+	body     string
+}
+
+// cssRules returns the style rules of css, including those nested in
+// at-rules such as @media; at-rule blocks themselves are not returned.
+// Comments are removed first; strings are not parsed.
+func cssRules(css string) []cssRule {
+	for {
+		start := strings.Index(css, "/*")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(css[start+2:], "*/")
+		if end < 0 {
+			css = css[:start]
+			break
+		}
+		css = css[:start] + css[start+2+end+2:]
+	}
+
+	var found []cssRule
+	var stack []string // preludes of the open blocks
+	mark := 0          // start of the current prelude or declaration text
+	for i := 0; i < len(css); i++ {
+		switch css[i] {
+		case '{':
+			stack = append(stack, strings.TrimSpace(css[mark:i]))
+			mark = i + 1
+		case '}':
+			if len(stack) == 0 {
+				mark = i + 1
+				continue
+			}
+			prelude := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !strings.HasPrefix(prelude, "@") {
+				found = append(found, cssRule{selector: prelude, body: css[mark:i]})
+			}
+			mark = i + 1
+		case ';':
+			// A statement at-rule such as @import ends its prelude.
+			if len(stack) == 0 || strings.HasPrefix(stack[len(stack)-1], "@") {
+				mark = i + 1
+			}
+		}
+	}
+	return found
+}
+
+// isInfiniteAnimation reports whether d makes an animation repeat forever.
+func isInfiniteAnimation(d cssDeclaration) bool {
+	switch d.prop {
+	case "animation", "animation-iteration-count":
+		for tok := range strings.FieldsFuncSeq(d.value, func(r rune) bool { return r == ' ' || r == ',' }) {
+			if tok == "infinite" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isSmoothScroll reports whether d enables smooth scrolling.
+func isSmoothScroll(d cssDeclaration) bool {
+	return d.prop == "scroll-behavior" && strings.HasPrefix(d.value, "smooth")
+}
+
+// isGlobalSelector reports whether a selector list applies to the page's
+// scrolling element.
+func isGlobalSelector(selectors string) bool {
+	for sel := range strings.SplitSeq(selectors, ",") {
+		switch strings.ToLower(strings.TrimSpace(sel)) {
+		case "html", ":root", "*", "body":
+			return true
+		}
+	}
+	return false
+}
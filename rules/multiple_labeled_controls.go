@@ -31,12 +31,13 @@ func (r *MultipleLabeledControls) Check(doc *parser.Document) []Result {
 		controlCount := countLabeledControls(n)
 		if controlCount > 1 {
 			results = append(results, Result{
-				Rule:     r.Name(),
-				Message:  "label is associated with multiple controls",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Error,
+				Rule:      r.Name(),
+				Message:   catalogMessage("multiple-labeled-controls.multiple", nil),
+				MessageID: "multiple-labeled-controls.multiple",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Error,
 			})
 		}
 
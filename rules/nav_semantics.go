@@ -0,0 +1,150 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// NavSemantics checks breadcrumb and pagination navigation against the
+// common design-system pattern. Breadcrumbs, recognized by a class or
+// aria-label containing "breadcrumb", must be a labelled <nav> holding an
+// <ol> whose last item carries aria-current="page". Pagination, recognized
+// by a "pagination" or "pager" class or label, must be a labelled <nav>
+// that marks the current page with aria-current. The rule is opt-in.
+type NavSemantics struct{}
+
+// Name returns the rule identifier.
+func (r *NavSemantics) Name() string { return RuleNavSemantics }
+
+// Description returns what this rule checks.
+func (r *NavSemantics) Description() string {
+	return "breadcrumb and pagination navigation must use nav, list, and aria-current semantics"
+}
+
+// OptIn marks the rule as disabled unless explicitly enabled.
+func (r *NavSemantics) OptIn() {}
+
+// Check finds breadcrumb and pagination components and checks each once,
+// at its outermost element.
+func (r *NavSemantics) Check(doc *parser.Document) []Result {
+	var results []Result
+	report := func(n *parser.Node, id string) {
+		results = append(results, Result{
+			Rule:      RuleNavSemantics,
+			Message:   catalogMessage(id, nil),
+			MessageID: id,
+			Filename:  doc.Filename,
+			Line:      n.Line,
+			Col:       n.Col,
+			Severity:  Warning,
+		})
+	}
+
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+		switch navKind(n) {
+		case "breadcrumb":
+			r.checkBreadcrumb(n, report)
+			return false
+		case "pagination":
+			r.checkPagination(n, report)
+			return false
+		}
+		return true
+	})
+
+	return results
+}
+
+// navKind classifies n as "breadcrumb", "pagination", or "" by its class
+// tokens and aria-label.
+func navKind(n *parser.Node) string {
+	names := strings.Fields(strings.ToLower(n.GetAttr("class")))
+	names = append(names, strings.ToLower(n.GetAttr("aria-label")))
+	for _, name := range names {
+		switch {
+		case strings.Contains(name, "breadcrumb"):
+			return "breadcrumb"
+		case strings.Contains(name, "pagination"), strings.Contains(name, "pager"):
+			return "pagination"
+		}
+	}
+	return ""
+}
+
+func (r *NavSemantics) checkBreadcrumb(n *parser.Node, report func(*parser.Node, string)) {
+	if !labelledNav(n) {
+		report(n, "nav-semantics.breadcrumb-nav")
+		return
+	}
+
+	list := n
+	if !TagIn(n, "ol", "ul") {
+		list = FindDescendant(n, func(c *parser.Node) bool { return TagIn(c, "ol", "ul") })
+	}
+	if list == nil {
+		report(n, "nav-semantics.breadcrumb-list")
+		return
+	}
+	if list.IsElement("ul") {
+		report(list, "nav-semantics.breadcrumb-ol")
+	}
+
+	var items []*parser.Node
+	for _, c := range list.Children {
+		if c.Type == html.ElementNode && c.IsElement("li") {
+			items = append(items, c)
+		}
+	}
+	if len(items) == 0 {
+		return
+	}
+	for _, item := range items[:len(items)-1] {
+		if current := currentMarker(item); current != nil {
+			report(current, "nav-semantics.breadcrumb-current-last")
+		}
+	}
+	last := items[len(items)-1]
+	if current := currentMarker(last); current == nil {
+		report(last, "nav-semantics.breadcrumb-current")
+	}
+}
+
+func (r *NavSemantics) checkPagination(n *parser.Node, report func(*parser.Node, string)) {
+	if !labelledNav(n) {
+		report(n, "nav-semantics.pagination-nav")
+		return
+	}
+	if currentMarker(n) == nil {
+		report(n, "nav-semantics.pagination-current")
+	}
+}
+
+// labelledNav reports whether n is a <nav> with an accessible label, or a
+// list or container directly inside one.
+func labelledNav(n *parser.Node) bool {
+	if !n.IsElement("nav") {
+		if n.Parent == nil || n.Parent.Type != html.ElementNode || !n.Parent.IsElement("nav") {
+			return false
+		}
+		n = n.Parent
+	}
+	return n.GetAttr("aria-label") != "" || n.HasAttr("aria-labelledby")
+}
+
+// currentMarker returns n or its first descendant with an aria-current
+// value other than "false", or nil. Template values count as set.
+func currentMarker(n *parser.Node) *parser.Node {
+	isCurrent := func(c *parser.Node) bool {
+		v := strings.TrimSpace(c.GetAttr("aria-current"))
+		return c.Type == html.ElementNode && c.HasAttr("aria-current") && v != "" && !strings.EqualFold(v, "false")
+	}
+	if isCurrent(n) {
+		return n
+	}
+	return FindDescendant(n, isCurrent)
+}
@@ -1,7 +1,6 @@
 package rules
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/toba/go-html-validate/parser"
@@ -34,13 +33,16 @@ func (r *NoAbstractRole) Check(doc *parser.Document) []Result {
 		for role := range strings.FieldsSeq(roleAttr) {
 			role = strings.ToLower(role)
 			if AbstractRoles[role] {
+				params := map[string]any{"role": role}
 				results = append(results, Result{
-					Rule:     r.Name(),
-					Message:  fmt.Sprintf("abstract role %q must not be used", role),
-					Filename: doc.Filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Error,
+					Rule:      r.Name(),
+					Message:   catalogMessage("no-abstract-role.abstract", params),
+					MessageID: "no-abstract-role.abstract",
+					Params:    params,
+					Filename:  doc.Filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Error,
 				})
 			}
 		}
@@ -30,13 +30,16 @@ func (r *NoAutoplay) Check(doc *parser.Document) []Result {
 		if n.HasAttr("autoplay") {
 			// Muted video autoplay is more acceptable (no audio disruption)
 			// but still flag it as a warning
+			params := map[string]any{"element": n.Data}
 			results = append(results, Result{
-				Rule:     r.Name(),
-				Message:  n.Data + " element has autoplay attribute",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Warning,
+				Rule:      r.Name(),
+				Message:   catalogMessage("no-autoplay.autoplay", params),
+				MessageID: "no-autoplay.autoplay",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
 			})
 		}
 
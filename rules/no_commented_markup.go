@@ -0,0 +1,90 @@
+package rules
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// Defaults for NoCommentedMarkup.
+const (
+	DefaultMaxCommentedLines = 3
+	DefaultMinCommentedTags  = 2
+)
+
+// commentedTagPattern matches start and end tags inside a comment.
+var commentedTagPattern = regexp.MustCompile(`</?[a-zA-Z][a-zA-Z0-9-]*(?:\s[^<>]*)?/?>`)
+
+// NoCommentedMarkup warns about HTML comments holding blocks of
+// commented-out markup. They are sent with every response and leave later
+// editors guessing whether the code still matters; version control keeps
+// the history. A comment is reported when it spans more than "max-lines"
+// non-blank lines (default 3) and contains at least "min-tags" tags
+// (default 2). IE conditional comments are left to no-conditional-comment.
+type NoCommentedMarkup struct {
+	MaxLines int
+	MinTags  int
+}
+
+// Name returns the rule identifier.
+func (r *NoCommentedMarkup) Name() string { return RuleNoCommentedMarkup }
+
+// Description returns what this rule checks.
+func (r *NoCommentedMarkup) Description() string {
+	return "comments should not hold blocks of commented-out markup"
+}
+
+// ConfigureOptions applies the max-lines and min-tags options.
+func (r *NoCommentedMarkup) ConfigureOptions(opts map[string]any) {
+	r.MaxLines = IntOption(opts, "max-lines", r.MaxLines)
+	r.MinTags = IntOption(opts, "min-tags", r.MinTags)
+}
+
+// Check examines each comment for commented-out markup.
+func (r *NoCommentedMarkup) Check(doc *parser.Document) []Result {
+	var results []Result
+
+	maxLines := r.MaxLines
+	if maxLines <= 0 {
+		maxLines = DefaultMaxCommentedLines
+	}
+	minTags := r.MinTags
+	if minTags <= 0 {
+		minTags = DefaultMinCommentedTags
+	}
+
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.CommentNode || conditionalCommentMessage(n.Data) != "" {
+			return true
+		}
+		lines := 0
+		for line := range strings.SplitSeq(n.Data, "\n") {
+			if strings.TrimSpace(line) != "" {
+				lines++
+			}
+		}
+		if lines <= maxLines {
+			return true
+		}
+		tags := len(commentedTagPattern.FindAllStringIndex(n.Data, -1))
+		if tags < minTags {
+			return true
+		}
+		params := map[string]any{"lines": lines, "tags": tags}
+		results = append(results, Result{
+			Rule:      RuleNoCommentedMarkup,
+			Message:   catalogMessage("no-commented-markup.markup", params),
+			MessageID: "no-commented-markup.markup",
+			Params:    params,
+			Filename:  doc.Filename,
+			Line:      n.Line,
+			Col:       n.Col,
+			Severity:  Warning,
+		})
+		return true
+	})
+
+	return results
+}
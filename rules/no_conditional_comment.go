@@ -1,13 +1,20 @@
 package rules
 
 import (
+	"regexp"
 	"strings"
 
 	"github.com/toba/go-html-validate/parser"
 	"golang.org/x/net/html"
 )
 
-// NoConditionalComment checks for IE conditional comments.
+// documentAllPattern matches the document.all browser sniff used to detect
+// legacy Internet Explorer.
+var documentAllPattern = regexp.MustCompile(`\bdocument\.all\b`)
+
+// NoConditionalComment checks for IE conditional comments and other legacy
+// IE hacks: downlevel-revealed conditionals, X-UA-Compatible metas, and
+// document.all sniffs in inline scripts.
 type NoConditionalComment struct{}
 
 // Name returns the rule identifier.
@@ -15,32 +22,51 @@ func (r *NoConditionalComment) Name() string { return RuleNoConditionalComment }
 
 // Description returns what this rule checks.
 func (r *NoConditionalComment) Description() string {
-	return "IE conditional comments should not be used"
+	return "IE conditional comments and legacy IE hacks should not be used"
 }
 
-// Check examines the document for IE conditional comments.
+// Check examines the document for IE conditional comments and IE hacks.
 func (r *NoConditionalComment) Check(doc *parser.Document) []Result {
 	var results []Result
 
 	doc.Walk(func(n *parser.Node) bool {
-		// Check for HTML comments containing IE conditionals
-		if n.Type != html.CommentNode {
-			return true
-		}
-
-		comment := n.Data
-
-		// Check for common IE conditional patterns
-		// <!--[if IE]>, <!--[if lt IE 9]>, etc.
-		if strings.Contains(comment, "[if ") && strings.Contains(comment, "]>") {
-			results = append(results, Result{
-				Rule:     RuleNoConditionalComment,
-				Message:  "IE conditional comments are deprecated and not supported in modern browsers",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Warning,
-			})
+		switch n.Type {
+		case html.CommentNode:
+			if id := conditionalCommentMessage(n.Data); id != "" {
+				results = append(results, Result{
+					Rule:      RuleNoConditionalComment,
+					Message:   catalogMessage(id, nil),
+					MessageID: id,
+					Filename:  doc.Filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Warning,
+				})
+			}
+		case html.ElementNode:
+			if n.IsElement("meta") && strings.EqualFold(n.GetAttr("http-equiv"), "x-ua-compatible") {
+				line, col := n.AttrPos("http-equiv")
+				results = append(results, Result{
+					Rule:      RuleNoConditionalComment,
+					Message:   catalogMessage("no-conditional-comment.x-ua-compatible", nil),
+					MessageID: "no-conditional-comment.x-ua-compatible",
+					Filename:  doc.Filename,
+					Line:      line,
+					Col:       col,
+					Severity:  Warning,
+				})
+			}
+			if n.IsElement("script") && !n.HasAttr("src") && documentAllPattern.MatchString(n.TextContent()) {
+				results = append(results, Result{
+					Rule:      RuleNoConditionalComment,
+					Message:   catalogMessage("no-conditional-comment.document-all", nil),
+					MessageID: "no-conditional-comment.document-all",
+					Filename:  doc.Filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Warning,
+				})
+			}
 		}
 
 		return true
@@ -48,3 +74,21 @@ func (r *NoConditionalComment) Check(doc *parser.Document) []Result {
 
 	return results
 }
+
+// conditionalCommentMessage returns the message ID for the IE conditional in
+// a comment, or "" if the comment is not a conditional.
+func conditionalCommentMessage(comment string) string {
+	// Downlevel-hidden: <!--[if IE]> ... <![endif]-->, <!--[if lt IE 9]>, etc.
+	if strings.Contains(comment, "[if ") && strings.Contains(comment, "]>") {
+		return "no-conditional-comment.conditional"
+	}
+
+	// Downlevel-revealed: <![if !IE]> ... <![endif]> parse as bogus comments
+	// holding just the bracketed condition.
+	trimmed := strings.TrimSpace(comment)
+	if strings.HasPrefix(trimmed, "[if ") && strings.HasSuffix(trimmed, "]") {
+		return "no-conditional-comment.downlevel-revealed"
+	}
+
+	return ""
+}
@@ -0,0 +1,140 @@
+package rules
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// defaultDebugMarkers are words that flag unfinished work in comments.
+var defaultDebugMarkers = []string{"TODO", "FIXME", "HACK"}
+
+// defaultDebugClasses are class patterns of elements meant only for
+// development or tests.
+var defaultDebugClasses = []string{"debug", "debug-*", "test-only"}
+
+// debugCommentPattern matches comments delimiting debug blocks, such as
+// <!-- debug -->, <!-- begin debug -->, and <!-- /debug -->.
+var debugCommentPattern = regexp.MustCompile(`(?i)^(?:/|(?:begin|start|end)\s+)?debug\b`)
+
+// debugPrintfPattern matches a printf call with the Go-syntax verb, which
+// dumps a value's structure into the page.
+var debugPrintfPattern = regexp.MustCompile("\\bprintf\\s+(?:\"%#v\"|`%#v`)")
+
+// NoDebugArtifacts reports leftovers of development that ship to users:
+// HTML comments with TODO, FIXME, or HACK markers (comments are sent to
+// the browser, unlike {{/* */}} template comments), <!-- debug --> block
+// delimiters, elements with debug or test-only classes, and
+// {{printf "%#v" ...}} dumps. The rule is opt-in. Options: "markers"
+// replaces the comment words and "classes" the class patterns, which may
+// use glob wildcards.
+type NoDebugArtifacts struct {
+	Markers []string
+	Classes []string
+}
+
+// Name returns the rule identifier.
+func (r *NoDebugArtifacts) Name() string { return RuleNoDebugArtifacts }
+
+// Description returns what this rule checks.
+func (r *NoDebugArtifacts) Description() string {
+	return "TODO comments and debug output should not ship to production"
+}
+
+// OptIn marks the rule as disabled unless explicitly enabled.
+func (r *NoDebugArtifacts) OptIn() {}
+
+// ConfigureOptions applies the markers and classes options.
+func (r *NoDebugArtifacts) ConfigureOptions(opts map[string]any) {
+	r.Markers = StringsOption(opts, "markers", r.Markers)
+	r.Classes = StringsOption(opts, "classes", r.Classes)
+}
+
+// Check examines comments, classes, and template actions.
+func (r *NoDebugArtifacts) Check(doc *parser.Document) []Result {
+	var results []Result
+	report := func(line, col int, id string, params map[string]any) {
+		results = append(results, Result{
+			Rule:      RuleNoDebugArtifacts,
+			Message:   catalogMessage(id, params),
+			MessageID: id,
+			Params:    params,
+			Filename:  doc.Filename,
+			Line:      line,
+			Col:       col,
+			Severity:  Warning,
+		})
+	}
+
+	markers := r.Markers
+	if markers == nil {
+		markers = defaultDebugMarkers
+	}
+	var markerPattern *regexp.Regexp
+	if len(markers) > 0 {
+		quoted := make([]string, len(markers))
+		for i, m := range markers {
+			quoted[i] = regexp.QuoteMeta(m)
+		}
+		markerPattern = regexp.MustCompile(`\b(?:` + strings.Join(quoted, "|") + `)\b`)
+	}
+	classes := r.Classes
+	if classes == nil {
+		classes = defaultDebugClasses
+	}
+
+	doc.Walk(func(n *parser.Node) bool {
+		switch n.Type {
+		case html.CommentNode:
+			text := strings.TrimSpace(n.Data)
+			if markerPattern != nil {
+				if m := markerPattern.FindString(text); m != "" {
+					report(n.Line, n.Col, "no-debug-artifacts.marker", map[string]any{"marker": m})
+					return true
+				}
+			}
+			if debugCommentPattern.MatchString(text) {
+				report(n.Line, n.Col, "no-debug-artifacts.comment", nil)
+			}
+		case html.ElementNode:
+			for class := range strings.FieldsSeq(n.GetAttr("class")) {
+				if IsTemplateExpr(class) {
+					continue
+				}
+				if matchClassPattern(class, classes) {
+					line, col := n.AttrPos("class")
+					report(line, col, "no-debug-artifacts.class", map[string]any{"class": class})
+					break
+				}
+			}
+		}
+		return true
+	})
+
+	src := doc.Source()
+	for _, m := range templateActionBounds.FindAllIndex(src, -1) {
+		action := strings.TrimPrefix(string(src[m[0]+2:m[1]-2]), "-")
+		if strings.HasPrefix(strings.TrimSpace(action), "/*") {
+			continue
+		}
+		if debugPrintfPattern.MatchString(action) {
+			line, col := offsetPosition(src, m[0])
+			report(line, col, "no-debug-artifacts.printf", nil)
+		}
+	}
+
+	return results
+}
+
+// matchClassPattern reports whether class matches one of the glob patterns.
+func matchClassPattern(class string, patterns []string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, class); ok {
+			return true
+		}
+	}
+	return false
+}
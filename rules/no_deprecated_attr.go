@@ -35,13 +35,16 @@ func (r *NoDeprecatedAttr) Check(doc *parser.Document) []Result {
 			// Check element-specific deprecated attributes
 			if elemAttrs, ok := DeprecatedAttributes[tag]; ok {
 				if suggestion, deprecated := elemAttrs[attrName]; deprecated {
+					params := map[string]any{"attr": attrName, "element": tag, "suggestion": suggestion}
 					results = append(results, Result{
-						Rule:     RuleNoDeprecatedAttr,
-						Message:  "attribute \"" + attrName + "\" on <" + tag + "> is deprecated; " + suggestion,
-						Filename: doc.Filename,
-						Line:     n.Line,
-						Col:      n.Col,
-						Severity: Warning,
+						Rule:      RuleNoDeprecatedAttr,
+						Message:   catalogMessage("no-deprecated-attr.element", params),
+						MessageID: "no-deprecated-attr.element",
+						Params:    params,
+						Filename:  doc.Filename,
+						Line:      n.Line,
+						Col:       n.Col,
+						Severity:  Warning,
 					})
 					continue
 				}
@@ -54,13 +57,16 @@ func (r *NoDeprecatedAttr) Check(doc *parser.Document) []Result {
 					if (attrName == "width" || attrName == "height") && NonDeprecatedSizeAttrs[tag] {
 						continue
 					}
+					params := map[string]any{"attr": attrName, "suggestion": suggestion}
 					results = append(results, Result{
-						Rule:     RuleNoDeprecatedAttr,
-						Message:  "attribute \"" + attrName + "\" is deprecated; " + suggestion,
-						Filename: doc.Filename,
-						Line:     n.Line,
-						Col:      n.Col,
-						Severity: Warning,
+						Rule:      RuleNoDeprecatedAttr,
+						Message:   catalogMessage("no-deprecated-attr.global", params),
+						MessageID: "no-deprecated-attr.global",
+						Params:    params,
+						Filename:  doc.Filename,
+						Line:      n.Line,
+						Col:       n.Col,
+						Severity:  Warning,
 					})
 				}
 			}
@@ -34,13 +34,16 @@ func (r *NoDupAttr) Check(doc *parser.Document) []Result {
 		for _, attr := range n.Attr {
 			key := strings.ToLower(attr.Key)
 			if seen[key] {
+				params := map[string]any{"attr": attr.Key}
 				results = append(results, Result{
-					Rule:     RuleNoDupAttr,
-					Message:  "duplicate attribute: " + attr.Key,
-					Filename: doc.Filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Error,
+					Rule:      RuleNoDupAttr,
+					Message:   catalogMessage("no-dup-attr.repeat", params),
+					MessageID: "no-dup-attr.repeat",
+					Params:    params,
+					Filename:  doc.Filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Error,
 				})
 			}
 			seen[key] = true
@@ -51,3 +54,40 @@ func (r *NoDupAttr) Check(doc *parser.Document) []Result {
 
 	return results
 }
+
+// NewTokenChecker returns a checker for streamed files.
+func (r *NoDupAttr) NewTokenChecker(filename string) TokenChecker {
+	return &noDupAttrChecker{filename: filename}
+}
+
+type noDupAttrChecker struct {
+	filename string
+	results  []Result
+}
+
+func (c *noDupAttrChecker) Token(tok *parser.Token) {
+	if tok.Type != html.StartTagToken && tok.Type != html.SelfClosingTagToken {
+		return
+	}
+	for i, attr := range tok.Attrs {
+		for _, prev := range tok.Attrs[:i] {
+			if prev.Name != attr.Name {
+				continue
+			}
+			params := map[string]any{"attr": attr.Name}
+			c.results = append(c.results, Result{
+				Rule:      RuleNoDupAttr,
+				Message:   catalogMessage("no-dup-attr.repeat", params),
+				MessageID: "no-dup-attr.repeat",
+				Params:    params,
+				Filename:  c.filename,
+				Line:      tok.Line,
+				Col:       tok.Col,
+				Severity:  Error,
+			})
+			break
+		}
+	}
+}
+
+func (c *noDupAttrChecker) Results() []Result { return c.results }
@@ -41,13 +41,16 @@ func (r *NoDupClass) Check(doc *parser.Document) []Result {
 				continue
 			}
 			if seen[class] {
+				params := map[string]any{"class": class}
 				results = append(results, Result{
-					Rule:     RuleNoDupClass,
-					Message:  "duplicate class name: " + class,
-					Filename: doc.Filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Warning,
+					Rule:      RuleNoDupClass,
+					Message:   catalogMessage("no-dup-class.repeat", params),
+					MessageID: "no-dup-class.repeat",
+					Params:    params,
+					Filename:  doc.Filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Warning,
 				})
 			}
 			seen[class] = true
@@ -0,0 +1,190 @@
+package rules
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// NoDupScript warns when the same external script is loaded more than once
+// in a page composed from templates, typically because several partials
+// each include their own copy of htmx or an analytics snippet. Pages are
+// followed through {{template}} and {{block}} calls across all linted
+// files, so it runs as a project rule.
+type NoDupScript struct{}
+
+// Name returns the rule identifier.
+func (r *NoDupScript) Name() string { return RuleNoDupScript }
+
+// Description returns what this rule checks.
+func (r *NoDupScript) Description() string {
+	return "an external script should be included once per composed page"
+}
+
+// Check is a no-op; duplicates are found by CheckProject.
+func (r *NoDupScript) Check(_ *parser.Document) []Result {
+	return nil
+}
+
+// scriptRef is an external script tag in a source file.
+type scriptRef struct {
+	src    string
+	offset int
+}
+
+// CheckProject composes each page of the template graph and reports
+// repeated script sources.
+func (r *NoDupScript) CheckProject(files []SourceFile) []Result {
+	graph := NewTemplateGraph(files)
+
+	scripts := make(map[string][]scriptRef) // filename -> scripts in source order
+	for _, f := range files {
+		scripts[f.Filename] = f.scripts()
+	}
+
+	var results []Result
+	reported := make(map[string]bool) // filename:offset of reported duplicates
+
+	for _, entry := range graph.Entries() {
+		// A named entry such as a base layout is composed with the slots
+		// each file defines; a file entry is composed from its own file.
+		contexts := []string{entry.Filename}
+		if !slices.Contains(graph.Files, entry) {
+			contexts = contexts[:0]
+			for _, f := range files {
+				contexts = append(contexts, f.Filename)
+			}
+		}
+
+		for _, ctx := range contexts {
+			type occurrence struct {
+				tmpl *Template
+				ref  scriptRef
+				via  string // name of the template call that included it
+			}
+			first := make(map[string]occurrence)
+			active := make(map[*Template]bool)
+
+			var visit func(t *Template, via string)
+			visit = func(t *Template, via string) {
+				if active[t] {
+					return // recursive template
+				}
+				active[t] = true
+				defer delete(active, t)
+
+				type event struct {
+					offset int
+					call   *TemplateCall
+					ref    scriptRef
+				}
+				var events []event
+				for _, ref := range scripts[t.Filename] {
+					if inSpans(t.Spans, ref.offset) {
+						events = append(events, event{offset: ref.offset, ref: ref})
+					}
+				}
+				for i := range t.Calls {
+					events = append(events, event{offset: t.Calls[i].Offset, call: &t.Calls[i]})
+				}
+				sort.SliceStable(events, func(i, j int) bool { return events[i].offset < events[j].offset })
+
+				for _, e := range events {
+					if e.call != nil {
+						if def := graph.Resolve(e.call.Name, ctx); def != nil {
+							visit(def, e.call.Name)
+						}
+						continue
+					}
+					prev, seen := first[e.ref.src]
+					if !seen {
+						first[e.ref.src] = occurrence{tmpl: t, ref: e.ref, via: via}
+						continue
+					}
+					key := fmt.Sprintf("%s:%d", t.Filename, e.ref.offset)
+					if reported[key] {
+						continue
+					}
+					reported[key] = true
+
+					line, col := t.Position(e.ref.offset)
+					m := message{"no-dup-script.repeated-call", map[string]any{"src": e.ref.src, "template": via}}
+					if prev.tmpl != t || prev.ref.offset != e.ref.offset {
+						prevLine, _ := prev.tmpl.Position(prev.ref.offset)
+						m = message{"no-dup-script.duplicate", map[string]any{"src": e.ref.src, "file": filepath.ToSlash(prev.tmpl.Filename), "line": prevLine}}
+					}
+					results = append(results, Result{
+						Rule:      RuleNoDupScript,
+						Message:   m.text(),
+						MessageID: m.id,
+						Params:    m.params,
+						Filename:  t.Filename,
+						Line:      line,
+						Col:       col,
+						Severity:  Warning,
+					})
+				}
+			}
+			visit(entry, entry.Name)
+		}
+	}
+
+	return results
+}
+
+// scripts returns the external scripts of f.
+func (f SourceFile) scripts() []scriptRef {
+	if f.index != nil {
+		return f.index.scripts
+	}
+	return externalScripts(f.Content)
+}
+
+// externalScripts returns the script elements with a literal src in
+// content. Template actions are masked so offsets match the source.
+func externalScripts(content []byte) []scriptRef {
+	var refs []scriptRef
+	masked := maskTemplateActions(content)
+	z := html.NewTokenizer(bytes.NewReader(masked))
+	offset := 0
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		raw := z.Raw()
+		if tt == html.StartTagToken {
+			if name, _ := z.TagName(); string(name) == "script" {
+				_, attrs := parser.ScanTagAttrs(raw)
+				for _, a := range attrs {
+					if a.Name != "src" || a.ValueStart < 0 {
+						continue
+					}
+					src := strings.TrimSpace(string(content[offset+a.ValueStart : offset+a.ValueEnd]))
+					if src != "" && !IsTemplateExpr(src) {
+						refs = append(refs, scriptRef{src: src, offset: offset})
+					}
+					break
+				}
+			}
+		}
+		offset += len(raw)
+	}
+	return refs
+}
+
+// inSpans reports whether offset falls within one of spans.
+func inSpans(spans [][2]int, offset int) bool {
+	for _, s := range spans {
+		if offset >= s[0] && offset < s[1] {
+			return true
+		}
+	}
+	return false
+}
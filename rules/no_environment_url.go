@@ -0,0 +1,163 @@
+package rules
+
+import (
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// NoEnvironmentURL flags URLs that only work in a development or staging
+// environment: localhost, loopback and private network addresses, internal
+// TLDs such as .internal and .local, and hostnames with a staging label
+// ("staging", "stg", "preprod", "uat"). Hard-coded in a template, they ship
+// to production and break there or leak infrastructure names. The "hosts"
+// option adds host patterns to flag and "allow-hosts" exempts hosts;
+// "*.example.com" matches subdomains.
+type NoEnvironmentURL struct {
+	Hosts      []string
+	AllowHosts []string
+}
+
+// Name returns the rule identifier.
+func (r *NoEnvironmentURL) Name() string { return RuleNoEnvironmentURL }
+
+// Description returns what this rule checks.
+func (r *NoEnvironmentURL) Description() string {
+	return "URLs must not point to local, internal, or staging hosts"
+}
+
+// ConfigureOptions applies the hosts and allow-hosts options.
+func (r *NoEnvironmentURL) ConfigureOptions(opts map[string]any) {
+	r.Hosts = StringsOption(opts, "hosts", r.Hosts)
+	r.AllowHosts = StringsOption(opts, "allow-hosts", r.AllowHosts)
+}
+
+// environmentURLAttrs are attributes holding URLs; hx-* attributes are
+// checked as well.
+var environmentURLAttrs = map[string]bool{
+	"action":     true,
+	"cite":       true,
+	"data":       true,
+	"formaction": true,
+	"href":       true,
+	"manifest":   true,
+	"ping":       true,
+	"poster":     true,
+	"src":        true,
+	"srcset":     true,
+}
+
+// environmentURLHost matches the authority of absolute and
+// protocol-relative URLs, including URLs embedded in hx-vals JSON and
+// srcset candidates.
+var environmentURLHost = regexp.MustCompile(`(?i)(?:[a-z][a-z0-9+.-]*:|^|[\s,])//([^/\s"'?#\\,]+)`)
+
+// internalTLDs are suffixes of hosts that do not resolve on the internet.
+var internalTLDs = []string{".local", ".internal", ".lan", ".localdomain", ".home.arpa", ".test", ".corp"}
+
+// stagingLabels mark a hostname as a non-production environment when they
+// appear as a label or a hyphen-separated part of one.
+var stagingLabels = map[string]bool{
+	"staging": true,
+	"stg":     true,
+	"preprod": true,
+	"uat":     true,
+}
+
+// Check examines URL attributes for environment-specific hosts.
+func (r *NoEnvironmentURL) Check(doc *parser.Document) []Result {
+	var results []Result
+
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+		for _, attr := range n.Attr {
+			if !environmentURLAttrs[attr.Key] && !strings.HasPrefix(attr.Key, "hx-") && !strings.HasPrefix(attr.Key, "data-hx-") {
+				continue
+			}
+			for _, m := range environmentURLHost.FindAllStringSubmatch(attr.Val, -1) {
+				if IsTemplateExpr(m[1]) {
+					continue
+				}
+				host := urlHost(m[1])
+				if host == "" {
+					continue
+				}
+				reason := r.classify(host)
+				if reason == "" {
+					continue
+				}
+				line, col := n.AttrPos(attr.Key)
+				params := map[string]any{"attr": attr.Key, "reason": reason, "host": host}
+				results = append(results, Result{
+					Rule:      RuleNoEnvironmentURL,
+					Message:   catalogMessage("no-environment-url.host", params),
+					MessageID: "no-environment-url.host",
+					Params:    params,
+					Filename:  doc.Filename,
+					Line:      line,
+					Col:       col,
+					Severity:  Warning,
+				})
+				break
+			}
+		}
+		return true
+	})
+
+	return results
+}
+
+// urlHost returns the lowercase host of a URL authority, without user
+// info, port, or IPv6 brackets.
+func urlHost(authority string) string {
+	if i := strings.LastIndexByte(authority, '@'); i >= 0 {
+		authority = authority[i+1:]
+	}
+	if rest, ok := strings.CutPrefix(authority, "["); ok {
+		host, _, _ := strings.Cut(rest, "]")
+		return strings.ToLower(host)
+	}
+	host, _, _ := strings.Cut(authority, ":")
+	return strings.TrimSuffix(strings.ToLower(host), ".")
+}
+
+// classify describes why host is environment-specific, or returns "".
+func (r *NoEnvironmentURL) classify(host string) string {
+	if matchHost(host, r.AllowHosts) {
+		return ""
+	}
+	if matchHost(host, r.Hosts) {
+		return "the non-production host"
+	}
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return "the local host"
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		switch {
+		case ip.IsLoopback(), ip.IsUnspecified():
+			return "the loopback address"
+		case ip.IsPrivate(), ip.IsLinkLocalUnicast():
+			return "the private network address"
+		}
+		return ""
+	}
+	for _, tld := range internalTLDs {
+		if strings.HasSuffix(host, tld) {
+			return "the internal host"
+		}
+	}
+	labels := strings.Split(host, ".")
+	for _, label := range labels[:len(labels)-1] {
+		for part := range strings.SplitSeq(label, "-") {
+			if stagingLabels[part] {
+				return "the staging host"
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,210 @@
+package rules
+
+import (
+	"bytes"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// defaultTranslatePattern matches template actions that call a common
+// translation function, e.g. {{T "nav.home"}}, {{i18n "Save"}}, or
+// {{"Save" | tr}}.
+var defaultTranslatePattern = regexp.MustCompile(`\b(T|i18n|tr|translate|localize)\b`)
+
+// templateStringLiteral matches a quoted or raw string literal in an action.
+var templateStringLiteral = regexp.MustCompile("\"(?:[^\"\\\\]|\\\\.)*\"|`[^`]*`")
+
+// defaultTextAttrs are attributes whose values are shown to users.
+var defaultTextAttrs = []string{"alt", "title", "placeholder", "aria-label"}
+
+// untranslatedElements hold text that is code or data rather than prose.
+var untranslatedElements = map[string]bool{
+	"script": true, "style": true, "code": true, "pre": true,
+	"kbd": true, "samp": true, "var": true,
+}
+
+// NoHardcodedText flags user-facing text written literally in templates
+// instead of through a translation function, for projects that localize
+// every string. It checks text and the alt, title, placeholder, and
+// aria-label attributes, reporting literal words outside template actions
+// and string literals inside actions that do not call a translation
+// function. Data actions such as {{.Name}} are fine.
+//
+// The rule is opt-in. Options: "translate-pattern" is a regular expression
+// matching translation actions, and "attributes" replaces the attribute list.
+type NoHardcodedText struct {
+	Pattern    *regexp.Regexp
+	Attributes []string
+}
+
+// Name returns the rule identifier.
+func (r *NoHardcodedText) Name() string { return RuleNoHardcodedText }
+
+// Description returns what this rule checks.
+func (r *NoHardcodedText) Description() string {
+	return "user-facing text should come from translation functions"
+}
+
+// OptIn marks the rule as disabled unless explicitly enabled.
+func (r *NoHardcodedText) OptIn() {}
+
+// ConfigureOptions applies the translate-pattern and attributes options.
+// An invalid pattern leaves the current one in place.
+func (r *NoHardcodedText) ConfigureOptions(opts map[string]any) {
+	if p := StringOption(opts, "translate-pattern", ""); p != "" {
+		if re, err := regexp.Compile(p); err == nil {
+			r.Pattern = re
+		}
+	}
+	r.Attributes = StringsOption(opts, "attributes", r.Attributes)
+}
+
+// Check implements Rule but returns nil - this rule uses CheckRaw instead.
+func (r *NoHardcodedText) Check(_ *parser.Document) []Result {
+	return nil
+}
+
+// CheckRaw scans text and attribute values in the original source, where
+// template actions are still visible.
+func (r *NoHardcodedText) CheckRaw(filename string, content []byte) []Result {
+	pattern := r.Pattern
+	if pattern == nil {
+		pattern = defaultTranslatePattern
+	}
+	attrs := r.Attributes
+	if attrs == nil {
+		attrs = defaultTextAttrs
+	}
+
+	var results []Result
+	// check reports value when attr is "" and the attribute attr otherwise
+	check := func(start, end int, attr string) {
+		offset, kind := hardcodedText(content[start:end], pattern)
+		if offset < 0 {
+			return
+		}
+		id, params := "no-hardcoded-text."+kind+"-text", map[string]any(nil)
+		if attr != "" {
+			id, params = "no-hardcoded-text."+kind+"-attr", map[string]any{"attr": attr}
+		}
+		line, col := offsetPosition(content, start+offset)
+		results = append(results, Result{
+			Rule:      r.Name(),
+			Message:   catalogMessage(id, params),
+			MessageID: id,
+			Params:    params,
+			Filename:  filename,
+			Line:      line,
+			Col:       col,
+			Severity:  Warning,
+		})
+	}
+
+	z := html.NewTokenizer(bytes.NewReader(maskTemplateActions(content)))
+	offset := 0
+	skipDepth := 0
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		raw := z.Raw()
+		start := offset
+		offset += len(raw)
+
+		switch tt {
+		case html.TextToken:
+			if skipDepth == 0 {
+				check(start, offset, "")
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			if untranslatedElements[string(name)] && skipDepth > 0 {
+				skipDepth--
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			nameEnd, tagAttrs := parser.ScanTagAttrs(raw)
+			if tt == html.StartTagToken && untranslatedElements[strings.ToLower(string(raw[1:nameEnd]))] {
+				skipDepth++
+			}
+			for _, attr := range tagAttrs {
+				if attr.ValueStart >= 0 && slices.Contains(attrs, attr.Name) {
+					check(start+attr.ValueStart, start+attr.ValueEnd, attr.Name)
+				}
+			}
+		}
+	}
+
+	return results
+}
+
+// hardcodedText finds the first untranslated prose in value, returning its
+// offset and its kind, "literal" for text or "string" for a quoted string
+// in a template action, or -1 if there is none.
+func hardcodedText(value []byte, pattern *regexp.Regexp) (int, string) {
+	prev := 0
+	for _, m := range templateActionBounds.FindAllIndex(value, -1) {
+		if i := proseOffset(value[prev:m[0]]); i >= 0 {
+			return prev + i, "literal"
+		}
+		prev = m[1]
+
+		action := value[m[0]:m[1]]
+		if pattern.Match(action) {
+			continue
+		}
+		for _, lit := range templateStringLiteral.FindAll(action, -1) {
+			if proseOffset(lit) >= 0 {
+				return m[0], "string"
+			}
+		}
+	}
+	if i := proseOffset(value[prev:]); i >= 0 {
+		return prev + i, "literal"
+	}
+	return -1, ""
+}
+
+// proseOffset returns the byte offset of the first word of two or more
+// letters in text, or -1. Character references such as &nbsp; and &copy;
+// are not letters.
+func proseOffset(text []byte) int {
+	run, runStart := 0, 0
+	for i := 0; i < len(text); {
+		if text[i] == '&' {
+			if m := charRefPattern.Find(text[i:]); m != nil {
+				run = 0
+				i += len(m)
+				continue
+			}
+		}
+		c, size := utf8.DecodeRune(text[i:])
+		switch {
+		case !unicode.IsLetter(c):
+			run = 0
+		case isIdeograph(c):
+			return i
+		default:
+			if run == 0 {
+				runStart = i
+			}
+			if run++; run >= 2 {
+				return runStart
+			}
+		}
+		i += size
+	}
+	return -1
+}
+
+// isIdeograph reports whether c is a letter that forms a word on its own,
+// as in Chinese and Japanese text.
+func isIdeograph(c rune) bool {
+	return unicode.In(c, unicode.Han, unicode.Hiragana, unicode.Katakana)
+}
@@ -33,12 +33,13 @@ func (r *NoImplicitInputType) Check(doc *parser.Document) []Result {
 
 		if !n.HasAttr("type") {
 			results = append(results, Result{
-				Rule:     RuleNoImplicitInputType,
-				Message:  "input should have explicit type attribute (defaults to \"text\")",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Info,
+				Rule:      RuleNoImplicitInputType,
+				Message:   catalogMessage("no-implicit-input-type.missing", nil),
+				MessageID: "no-implicit-input-type.missing",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Info,
 			})
 		}
 
@@ -24,12 +24,13 @@ func (r *NoInlineStyle) Check(doc *parser.Document) []Result {
 
 		if n.GetAttr("style") != "" {
 			results = append(results, Result{
-				Rule:     r.Name(),
-				Message:  "avoid inline style attribute; use CSS classes instead",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Info,
+				Rule:      r.Name(),
+				Message:   catalogMessage("no-inline-style.style", nil),
+				MessageID: "no-inline-style.style",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Info,
 			})
 		}
 
@@ -38,3 +39,32 @@ func (r *NoInlineStyle) Check(doc *parser.Document) []Result {
 
 	return results
 }
+
+// NewTokenChecker returns a checker for streamed files.
+func (r *NoInlineStyle) NewTokenChecker(filename string) TokenChecker {
+	return &noInlineStyleChecker{filename: filename}
+}
+
+type noInlineStyleChecker struct {
+	filename string
+	results  []Result
+}
+
+func (c *noInlineStyleChecker) Token(tok *parser.Token) {
+	if tok.Type != html.StartTagToken && tok.Type != html.SelfClosingTagToken {
+		return
+	}
+	if style, _ := tok.Attr("style"); style != "" {
+		c.results = append(c.results, Result{
+			Rule:      RuleNoInlineStyle,
+			Message:   catalogMessage("no-inline-style.style", nil),
+			MessageID: "no-inline-style.style",
+			Filename:  c.filename,
+			Line:      tok.Line,
+			Col:       tok.Col,
+			Severity:  Info,
+		})
+	}
+}
+
+func (c *noInlineStyleChecker) Results() []Result { return c.results }
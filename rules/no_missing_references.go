@@ -8,6 +8,9 @@ import (
 )
 
 // NoMissingReferences checks that ID references point to existing elements.
+// Files that define or call named templates are checked as part of the
+// pages composed from them, so a label in one partial may refer to an
+// input in another; see CheckProject.
 type NoMissingReferences struct{}
 
 // Name returns the rule identifier.
@@ -18,8 +21,21 @@ func (r *NoMissingReferences) Description() string {
 	return "ID references must point to existing elements"
 }
 
-// Check examines the document for broken ID references.
+// Check examines the document for broken ID references, unless it takes
+// part in template composition.
 func (r *NoMissingReferences) Check(doc *parser.Document) []Result {
+	if composes(doc.Source()) {
+		return nil
+	}
+	return r.check(doc)
+}
+
+// CheckProject examines the pages composed from files.
+func (r *NoMissingReferences) CheckProject(files []SourceFile) []Result {
+	return checkComposed(files, r.check)
+}
+
+func (r *NoMissingReferences) check(doc *parser.Document) []Result {
 	var results []Result
 
 	// First pass: collect all IDs
@@ -43,13 +59,16 @@ func (r *NoMissingReferences) Check(doc *parser.Document) []Result {
 		// Check for attribute
 		if forID := n.GetAttr("for"); forID != "" {
 			if !ids[forID] && !IsTemplateExpr(forID) {
+				params := map[string]any{"id": forID}
 				results = append(results, Result{
-					Rule:     RuleNoMissingReferences,
-					Message:  "for=\"" + forID + "\" references non-existent id",
-					Filename: doc.Filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Error,
+					Rule:      RuleNoMissingReferences,
+					Message:   catalogMessage("no-missing-references.for", params),
+					MessageID: "no-missing-references.for",
+					Params:    params,
+					Filename:  doc.Filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Error,
 				})
 			}
 		}
@@ -58,13 +77,16 @@ func (r *NoMissingReferences) Check(doc *parser.Document) []Result {
 		if labelledby := n.GetAttr("aria-labelledby"); labelledby != "" {
 			for id := range strings.FieldsSeq(labelledby) {
 				if !ids[id] && !IsTemplateExpr(id) {
+					params := map[string]any{"id": id}
 					results = append(results, Result{
-						Rule:     RuleNoMissingReferences,
-						Message:  "aria-labelledby references non-existent id: " + id,
-						Filename: doc.Filename,
-						Line:     n.Line,
-						Col:      n.Col,
-						Severity: Error,
+						Rule:      RuleNoMissingReferences,
+						Message:   catalogMessage("no-missing-references.aria-labelledby", params),
+						MessageID: "no-missing-references.aria-labelledby",
+						Params:    params,
+						Filename:  doc.Filename,
+						Line:      n.Line,
+						Col:       n.Col,
+						Severity:  Error,
 					})
 				}
 			}
@@ -74,13 +96,16 @@ func (r *NoMissingReferences) Check(doc *parser.Document) []Result {
 		if describedby := n.GetAttr("aria-describedby"); describedby != "" {
 			for id := range strings.FieldsSeq(describedby) {
 				if !ids[id] && !IsTemplateExpr(id) {
+					params := map[string]any{"id": id}
 					results = append(results, Result{
-						Rule:     RuleNoMissingReferences,
-						Message:  "aria-describedby references non-existent id: " + id,
-						Filename: doc.Filename,
-						Line:     n.Line,
-						Col:      n.Col,
-						Severity: Error,
+						Rule:      RuleNoMissingReferences,
+						Message:   catalogMessage("no-missing-references.aria-describedby", params),
+						MessageID: "no-missing-references.aria-describedby",
+						Params:    params,
+						Filename:  doc.Filename,
+						Line:      n.Line,
+						Col:       n.Col,
+						Severity:  Error,
 					})
 				}
 			}
@@ -90,13 +115,16 @@ func (r *NoMissingReferences) Check(doc *parser.Document) []Result {
 		if controls := n.GetAttr("aria-controls"); controls != "" {
 			for id := range strings.FieldsSeq(controls) {
 				if !ids[id] && !IsTemplateExpr(id) {
+					params := map[string]any{"id": id}
 					results = append(results, Result{
-						Rule:     RuleNoMissingReferences,
-						Message:  "aria-controls references non-existent id: " + id,
-						Filename: doc.Filename,
-						Line:     n.Line,
-						Col:      n.Col,
-						Severity: Warning,
+						Rule:      RuleNoMissingReferences,
+						Message:   catalogMessage("no-missing-references.aria-controls", params),
+						MessageID: "no-missing-references.aria-controls",
+						Params:    params,
+						Filename:  doc.Filename,
+						Line:      n.Line,
+						Col:       n.Col,
+						Severity:  Warning,
 					})
 				}
 			}
@@ -106,13 +134,16 @@ func (r *NoMissingReferences) Check(doc *parser.Document) []Result {
 		if owns := n.GetAttr("aria-owns"); owns != "" {
 			for id := range strings.FieldsSeq(owns) {
 				if !ids[id] && !IsTemplateExpr(id) {
+					params := map[string]any{"id": id}
 					results = append(results, Result{
-						Rule:     RuleNoMissingReferences,
-						Message:  "aria-owns references non-existent id: " + id,
-						Filename: doc.Filename,
-						Line:     n.Line,
-						Col:      n.Col,
-						Severity: Warning,
+						Rule:      RuleNoMissingReferences,
+						Message:   catalogMessage("no-missing-references.aria-owns", params),
+						MessageID: "no-missing-references.aria-owns",
+						Params:    params,
+						Filename:  doc.Filename,
+						Line:      n.Line,
+						Col:       n.Col,
+						Severity:  Warning,
 					})
 				}
 			}
@@ -121,13 +152,16 @@ func (r *NoMissingReferences) Check(doc *parser.Document) []Result {
 		// Check list attribute on input
 		if list := n.GetAttr("list"); list != "" {
 			if !ids[list] && !IsTemplateExpr(list) {
+				params := map[string]any{"id": list}
 				results = append(results, Result{
-					Rule:     RuleNoMissingReferences,
-					Message:  "list=\"" + list + "\" references non-existent datalist",
-					Filename: doc.Filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Error,
+					Rule:      RuleNoMissingReferences,
+					Message:   catalogMessage("no-missing-references.list", params),
+					MessageID: "no-missing-references.list",
+					Params:    params,
+					Filename:  doc.Filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Error,
 				})
 			}
 		}
@@ -136,13 +170,16 @@ func (r *NoMissingReferences) Check(doc *parser.Document) []Result {
 		if headers := n.GetAttr("headers"); headers != "" {
 			for id := range strings.FieldsSeq(headers) {
 				if !ids[id] && !IsTemplateExpr(id) {
+					params := map[string]any{"id": id}
 					results = append(results, Result{
-						Rule:     RuleNoMissingReferences,
-						Message:  "headers references non-existent id: " + id,
-						Filename: doc.Filename,
-						Line:     n.Line,
-						Col:      n.Col,
-						Severity: Error,
+						Rule:      RuleNoMissingReferences,
+						Message:   catalogMessage("no-missing-references.headers", params),
+						MessageID: "no-missing-references.headers",
+						Params:    params,
+						Filename:  doc.Filename,
+						Line:      n.Line,
+						Col:       n.Col,
+						Severity:  Error,
 					})
 				}
 			}
@@ -154,13 +191,16 @@ func (r *NoMissingReferences) Check(doc *parser.Document) []Result {
 			if !ids[mapName] && !IsTemplateExpr(mapName) {
 				// usemap references name attribute, not id, but often they match
 				// This is a simplified check
+				params := map[string]any{"usemap": usemap}
 				results = append(results, Result{
-					Rule:     RuleNoMissingReferences,
-					Message:  "usemap=\"" + usemap + "\" may reference non-existent map",
-					Filename: doc.Filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Warning,
+					Rule:      RuleNoMissingReferences,
+					Message:   catalogMessage("no-missing-references.usemap", params),
+					MessageID: "no-missing-references.usemap",
+					Params:    params,
+					Filename:  doc.Filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Warning,
 				})
 			}
 		}
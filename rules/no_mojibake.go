@@ -0,0 +1,123 @@
+package rules
+
+import (
+	"bytes"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// cp1252 maps Windows-1252 characters in the 0x80-0x9F range back to their
+// byte. Unassigned bytes decode to the C1 control with the same value.
+var cp1252 = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87,
+	'ˆ': 0x88, '‰': 0x89, 'Š': 0x8A, '‹': 0x8B, 'Œ': 0x8C, 'Ž': 0x8E, '‘': 0x91,
+	'’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98,
+	'™': 0x99, 'š': 0x9A, '›': 0x9B, 'œ': 0x9C, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+// mojibakePattern matches a UTF-8 sequence decoded as Windows-1252 or
+// Latin-1 (a lead byte character such as "Ã" or "â" followed by
+// continuation byte characters) and the U+FFFD replacement character.
+var mojibakePattern = func() *regexp.Regexp {
+	cont := `[\x{80}-\x{BF}€‚ƒ„…†‡ˆ‰Š‹ŒŽ‘’“”•–—˜™š›œžŸ]`
+	return regexp.MustCompile(`[\x{C2}-\x{DF}]` + cont +
+		`|[\x{E0}-\x{EF}]` + cont + cont +
+		`|[\x{F0}-\x{F4}]` + cont + cont + cont +
+		`|\x{FFFD}`)
+}()
+
+// NoMojibake reports text garbled by an encoding mistake, usually UTF-8
+// that was read as Windows-1252 and saved again ("cafÃ©" for "café",
+// "donâ€™t" for "don't"), and U+FFFD replacement characters left where
+// bytes could not be decoded. Such text often arrives in templates by copy
+// and paste. A sequence is only reported if it decodes back to a single
+// UTF-8 character, which keeps legitimate accented text from matching.
+type NoMojibake struct{}
+
+// Name returns the rule identifier.
+func (r *NoMojibake) Name() string { return RuleNoMojibake }
+
+// Description returns what this rule checks.
+func (r *NoMojibake) Description() string {
+	return "text must not contain double-encoded characters or replacement characters"
+}
+
+// Check implements Rule but returns nil - this rule uses CheckRaw instead.
+func (r *NoMojibake) Check(_ *parser.Document) []Result {
+	return nil
+}
+
+// CheckRaw scans text in the original source so positions point at each
+// garbled sequence.
+func (r *NoMojibake) CheckRaw(filename string, content []byte) []Result {
+	var results []Result
+	masked := maskTemplateActions(content)
+	z := html.NewTokenizer(bytes.NewReader(masked))
+	offset := 0
+	inRawText := false
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		raw := z.Raw()
+		start := offset
+		offset += len(raw)
+
+		switch tt {
+		case html.StartTagToken:
+			name, _ := z.TagName()
+			inRawText = string(name) == "script" || string(name) == "style"
+		case html.EndTagToken:
+			inRawText = false
+		case html.TextToken:
+			if inRawText {
+				continue
+			}
+			for _, m := range mojibakePattern.FindAllIndex(masked[start:offset], -1) {
+				msg, ok := mojibakeMessage(string(masked[start+m[0] : start+m[1]]))
+				if !ok {
+					continue
+				}
+				line, col := offsetPosition(content, start+m[0])
+				results = append(results, Result{
+					Rule:      r.Name(),
+					Message:   msg.text(),
+					MessageID: msg.id,
+					Params:    msg.params,
+					Filename:  filename,
+					Line:      line,
+					Col:       col,
+					Severity:  Warning,
+				})
+			}
+		}
+	}
+	return results
+}
+
+// mojibakeMessage returns the message for a mojibakePattern match, or false if
+// the match does not decode to a UTF-8 character.
+func mojibakeMessage(match string) (message, bool) {
+	if match == "�" {
+		return message{"no-mojibake.replacement", nil}, true
+	}
+	var b []byte
+	for _, c := range match {
+		switch {
+		case c < 0x100:
+			b = append(b, byte(c))
+		case cp1252[c] != 0:
+			b = append(b, cp1252[c])
+		default:
+			return message{}, false
+		}
+	}
+	if c, size := utf8.DecodeRune(b); c == utf8.RuneError || size != len(b) {
+		return message{}, false
+	}
+	return message{"no-mojibake.garbled", map[string]any{"text": match, "original": string(b)}}, true
+}
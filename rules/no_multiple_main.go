@@ -40,12 +40,13 @@ func (r *NoMultipleMain) Check(doc *parser.Document) []Result {
 	for i := 1; i < len(visibleMains); i++ {
 		n := visibleMains[i]
 		results = append(results, Result{
-			Rule:     r.Name(),
-			Message:  "document has multiple visible <main> elements; remove this <main> or add hidden attribute",
-			Filename: doc.Filename,
-			Line:     n.Line,
-			Col:      n.Col,
-			Severity: Error,
+			Rule:      r.Name(),
+			Message:   catalogMessage("no-multiple-main.multiple", nil),
+			MessageID: "no-multiple-main.multiple",
+			Filename:  doc.Filename,
+			Line:      n.Line,
+			Col:       n.Col,
+			Severity:  Error,
 		})
 	}
 
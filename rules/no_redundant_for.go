@@ -52,13 +52,16 @@ func (r *NoRedundantFor) Check(doc *parser.Document) []Result {
 		checkChildren(n)
 
 		if hasMatchingChild {
+			params := map[string]any{"id": forAttr}
 			results = append(results, Result{
-				Rule:     RuleNoRedundantFor,
-				Message:  "label for=\"" + forAttr + "\" is redundant when label wraps the control",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Info,
+				Rule:      RuleNoRedundantFor,
+				Message:   catalogMessage("no-redundant-for.redundant", params),
+				MessageID: "no-redundant-for.redundant",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Info,
 			})
 		}
 
@@ -1,7 +1,6 @@
 package rules
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/toba/go-html-validate/parser"
@@ -34,13 +33,16 @@ func (r *NoRedundantRole) Check(doc *parser.Document) []Result {
 		implicitRole := GetImplicitRole(tagName, n)
 
 		if role == implicitRole {
+			params := map[string]any{"element": tagName, "role": role}
 			results = append(results, Result{
-				Rule:     r.Name(),
-				Message:  fmt.Sprintf("<%s> has implicit role %q, explicit role is redundant", tagName, role),
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Warning,
+				Rule:      r.Name(),
+				Message:   catalogMessage("no-redundant-role.redundant", params),
+				MessageID: "no-redundant-role.redundant",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
 			})
 		}
 
@@ -29,12 +29,13 @@ func (r *NoStyleTag) Check(doc *parser.Document) []Result {
 
 		if strings.ToLower(n.Data) == "style" {
 			results = append(results, Result{
-				Rule:     RuleNoStyleTag,
-				Message:  "inline <style> tags are discouraged; use external stylesheets",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Info,
+				Rule:      RuleNoStyleTag,
+				Message:   catalogMessage("no-style-tag.style", nil),
+				MessageID: "no-style-tag.style",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Info,
 			})
 		}
 
@@ -0,0 +1,89 @@
+package rules
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// leakPattern matches text left behind by broken rendering: template
+// actions ({{ or the TMPL placeholder the preprocessor puts in their
+// place), JavaScript's undefined and [object Object], and Go fmt verb
+// errors such as %!s(int=3) and %!(EXTRA string=x).
+var leakPattern = regexp.MustCompile(TemplateExprPlaceholder + `|\{\{|\bundefined\b|\[object Object\]|%![a-zA-Z]?\(`)
+
+// NoUnrenderedPlaceholder reports placeholder leakage in user-visible
+// text: text content, including <title>, and meta content. It is meant for
+// rendered output, since every template action in a template source would
+// be reported, and is opt-in; enable it for a build directory or with a
+// profile when linting generated pages.
+type NoUnrenderedPlaceholder struct{}
+
+// Name returns the rule identifier.
+func (r *NoUnrenderedPlaceholder) Name() string { return RuleNoUnrenderedPlaceholder }
+
+// Description returns what this rule checks.
+func (r *NoUnrenderedPlaceholder) Description() string {
+	return "rendered pages should not contain template or formatting placeholders"
+}
+
+// OptIn marks the rule as disabled unless explicitly enabled.
+func (r *NoUnrenderedPlaceholder) OptIn() {}
+
+// Check examines text nodes and meta content.
+func (r *NoUnrenderedPlaceholder) Check(doc *parser.Document) []Result {
+	var results []Result
+	report := func(line, col int, where, match string) {
+		m := leakMessage(where, match)
+		results = append(results, Result{
+			Rule:      RuleNoUnrenderedPlaceholder,
+			Message:   m.text(),
+			MessageID: m.id,
+			Params:    m.params,
+			Filename:  doc.Filename,
+			Line:      line,
+			Col:       col,
+			Severity:  Warning,
+		})
+	}
+
+	doc.Walk(func(n *parser.Node) bool {
+		switch n.Type {
+		case html.ElementNode:
+			if TagIn(n, "script", "style", "template") {
+				return false
+			}
+			if n.IsElement("meta") {
+				if m := leakPattern.FindString(n.GetAttr("content")); m != "" {
+					line, col := n.AttrPos("content")
+					report(line, col, "meta content", m)
+				}
+			}
+		case html.TextNode:
+			if m := leakPattern.FindString(n.Data); m != "" {
+				where := "text"
+				if n.Parent != nil && n.Parent.IsElement("title") {
+					where = "<title>"
+				}
+				report(n.Line, n.Col, where, m)
+			}
+		}
+		return true
+	})
+
+	return results
+}
+
+// leakMessage describes a leakPattern match found in where.
+func leakMessage(where, match string) message {
+	switch {
+	case match == TemplateExprPlaceholder, match == "{{":
+		return message{"no-unrendered-placeholder.action", map[string]any{"where": where}}
+	case strings.HasPrefix(match, "%!"):
+		return message{"no-unrendered-placeholder.format", map[string]any{"where": where, "match": match}}
+	default:
+		return message{"no-unrendered-placeholder.placeholder", map[string]any{"where": where, "match": match}}
+	}
+}
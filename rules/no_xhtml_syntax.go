@@ -0,0 +1,134 @@
+package rules
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// NoXHTMLSyntax checks for obsolete doctypes and XHTML-only syntax.
+// Self-closing syntax on non-void elements is ignored by HTML parsers, so
+// <div /> opens an element that swallows its following siblings.
+type NoXHTMLSyntax struct{}
+
+// Name returns the rule identifier.
+func (r *NoXHTMLSyntax) Name() string { return RuleNoXHTMLSyntax }
+
+// Description returns what this rule checks.
+func (r *NoXHTMLSyntax) Description() string {
+	return "legacy doctypes and XHTML syntax should not be used"
+}
+
+// Check examines the document for legacy doctypes and xmlns attributes.
+func (r *NoXHTMLSyntax) Check(doc *parser.Document) []Result {
+	var results []Result
+
+	doc.Walk(func(n *parser.Node) bool {
+		switch n.Type {
+		case html.DoctypeNode:
+			if legacy := legacyDoctype(n); legacy != "" {
+				params := map[string]any{"doctype": legacy}
+				results = append(results, Result{
+					Rule:      RuleNoXHTMLSyntax,
+					Message:   catalogMessage("no-xhtml-syntax.doctype", params),
+					MessageID: "no-xhtml-syntax.doctype",
+					Params:    params,
+					Filename:  doc.Filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Warning,
+				})
+			}
+		case html.ElementNode:
+			if n.IsElement("html") && n.HasAttr("xmlns") {
+				line, col := n.AttrPos("xmlns")
+				results = append(results, Result{
+					Rule:      RuleNoXHTMLSyntax,
+					Message:   catalogMessage("no-xhtml-syntax.xmlns", nil),
+					MessageID: "no-xhtml-syntax.xmlns",
+					Filename:  doc.Filename,
+					Line:      line,
+					Col:       col,
+					Severity:  Info,
+				})
+			}
+		}
+		return true
+	})
+
+	return results
+}
+
+// legacyDoctype names the legacy doctype family, or returns "" for the HTML5 doctype.
+func legacyDoctype(n *parser.Node) string {
+	public := strings.ToUpper(n.GetAttr("public"))
+	system := strings.ToUpper(n.GetAttr("system"))
+	if public == "" && system == "" {
+		return ""
+	}
+	switch {
+	case strings.Contains(public, "XHTML") || strings.Contains(system, "XHTML"):
+		return "XHTML"
+	case strings.Contains(public, "HTML 4"):
+		return "HTML 4"
+	case strings.Contains(public, "HTML 3"), strings.Contains(public, "HTML 2"):
+		return "HTML 3/2"
+	case system == "ABOUT:LEGACY-COMPAT":
+		return "" // permitted legacy-compat doctype
+	default:
+		return "legacy"
+	}
+}
+
+// CheckRaw examines the raw content for self-closing non-void elements.
+func (r *NoXHTMLSyntax) CheckRaw(filename string, content []byte) []Result {
+	var results []Result
+
+	z := html.NewTokenizer(bytes.NewReader(content))
+	offset := 0
+	foreignDepth := 0 // inside <svg> or <math>, where self-closing is valid
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		raw := z.Raw()
+		start := offset
+		offset += len(raw)
+
+		name, _ := z.TagName()
+		tag := strings.ToLower(string(name))
+		isForeign := tag == "svg" || tag == "math"
+
+		switch tt {
+		case html.StartTagToken:
+			if isForeign {
+				foreignDepth++
+			}
+		case html.EndTagToken:
+			if isForeign && foreignDepth > 0 {
+				foreignDepth--
+			}
+		case html.SelfClosingTagToken:
+			if foreignDepth > 0 || isForeign || VoidElements[tag] {
+				continue
+			}
+			line, col := offsetPosition(content, start)
+			params := map[string]any{"element": tag}
+			results = append(results, Result{
+				Rule:      RuleNoXHTMLSyntax,
+				Message:   catalogMessage("no-xhtml-syntax.self-closing", params),
+				MessageID: "no-xhtml-syntax.self-closing",
+				Params:    params,
+				Filename:  filename,
+				Line:      line,
+				Col:       col,
+				Severity:  Error,
+			})
+		}
+	}
+
+	return results
+}
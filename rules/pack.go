@@ -0,0 +1,33 @@
+package rules
+
+import "strings"
+
+// PackMetadata describes a rule pack.
+type PackMetadata struct {
+	Name        string // short identifier, also the prefix of the pack's rule names
+	Description string
+	URL         string // documentation or source repository
+}
+
+// Pack is a set of rules distributed as a Go module, so organizations can
+// ship their own rules without forking htmlint. By convention a pack
+// package exports
+//
+//	var Metadata = rules.PackMetadata{Name: "acme", ...}
+//	func Rules() []rules.Rule
+//
+// and names its rules "<pack>/<rule>" (e.g. "acme/brand-colors") so they
+// cannot collide with built-in rules. Packs are bundled into a custom
+// binary with "htmlint custom", which fills in Version from the module
+// version it was built with.
+type Pack struct {
+	PackMetadata
+	Version string
+	Rules   func() []Rule
+}
+
+// PackRuleName reports whether name follows the "<pack>/<rule>" naming
+// convention for pack p.
+func (p Pack) PackRuleName(name string) bool {
+	return strings.HasPrefix(name, p.Name+"/") && len(name) > len(p.Name)+1
+}
@@ -0,0 +1,108 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/toba/go-html-validate/parser"
+)
+
+// PageType declares the structure required of pages matching Files.
+// A page type without requirements exempts its files from page-structure.
+type PageType struct {
+	Name string
+	// Files lists glob patterns, relative to the config file, of the pages
+	// of this type; the linter assigns each file the first matching type
+	Files []string
+	// Require lists selectors that must match at least one element
+	Require []string
+	// ExactlyOne lists selectors that must match exactly one element
+	ExactlyOne []string
+	// Forbid lists selectors that must not match any element
+	Forbid []string
+}
+
+// Validate reports an invalid selector in p.
+func (p PageType) Validate() error {
+	for _, list := range [][]string{p.Require, p.ExactlyOne, p.Forbid} {
+		for _, sel := range list {
+			if _, err := parser.CompileSelector(sel); err != nil {
+				return fmt.Errorf("page type %q: %w", p.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// PageTypesConfigurable is implemented by rules that check configured
+// page types.
+type PageTypesConfigurable interface {
+	ConfigurePageTypes(types []PageType)
+}
+
+// PageStructure enforces the structural requirements of configured page
+// types, such as every page under pages/ having a <main>, a <nav>, and
+// exactly one <h1>. The linter sets parser.Document.PageType; files
+// without a page type are not checked.
+type PageStructure struct {
+	types map[string]PageType
+}
+
+// Name returns the rule identifier.
+func (r *PageStructure) Name() string { return RulePageStructure }
+
+// Description returns what this rule checks.
+func (r *PageStructure) Description() string {
+	return "pages must have the structure required by their configured page type"
+}
+
+// ConfigurePageTypes sets the page types to enforce.
+func (r *PageStructure) ConfigurePageTypes(types []PageType) {
+	r.types = make(map[string]PageType, len(types))
+	for _, t := range types {
+		r.types[t.Name] = t
+	}
+}
+
+// Check verifies the document against its page type.
+func (r *PageStructure) Check(doc *parser.Document) []Result {
+	t, ok := r.types[doc.PageType]
+	if !ok {
+		return nil
+	}
+
+	var results []Result
+	report := func(line, col int, id string, params map[string]any) {
+		params["page"] = t.Name
+		results = append(results, Result{
+			Rule:      RulePageStructure,
+			Message:   catalogMessage(id, params),
+			MessageID: id,
+			Params:    params,
+			Filename:  doc.Filename,
+			Line:      line,
+			Col:       col,
+			Severity:  Error,
+		})
+	}
+
+	for _, sel := range t.Require {
+		if len(doc.QuerySelectorAll(sel)) == 0 {
+			report(1, 1, "page-structure.require", map[string]any{"selector": sel})
+		}
+	}
+	for _, sel := range t.ExactlyOne {
+		switch matches := doc.QuerySelectorAll(sel); {
+		case len(matches) == 0:
+			report(1, 1, "page-structure.exactly-one-missing", map[string]any{"selector": sel})
+		case len(matches) > 1:
+			report(matches[1].Line, matches[1].Col, "page-structure.exactly-one-many", map[string]any{"selector": sel, "count": len(matches)})
+		}
+	}
+	for _, sel := range t.Forbid {
+		for _, n := range doc.QuerySelectorAll(sel) {
+			report(n.Line, n.Col, "page-structure.forbid", map[string]any{"selector": sel})
+		}
+	}
+
+	return results
+}
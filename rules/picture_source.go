@@ -0,0 +1,380 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// PictureSource checks the <source> and <img> children of <picture>.
+// Browsers use the first source whose media and type match and fall back
+// to the img, so each source needs a srcset, a parseable media query, and
+// an image type they recognize, a later source repeating an earlier
+// condition can never be chosen, and the img must be present and last.
+type PictureSource struct{}
+
+// Name returns the rule identifier.
+func (r *PictureSource) Name() string { return RulePictureSource }
+
+// Description returns what this rule checks.
+func (r *PictureSource) Description() string {
+	return "picture sources need srcset, valid media and type, and a trailing img fallback"
+}
+
+// KnownImageTypes lists image MIME types accepted on <source type>.
+var KnownImageTypes = map[string]bool{
+	"image/apng":               true,
+	"image/avif":               true,
+	"image/bmp":                true,
+	"image/gif":                true,
+	"image/heic":               true,
+	"image/heif":               true,
+	"image/jpeg":               true,
+	"image/jxl":                true,
+	"image/png":                true,
+	"image/svg+xml":            true,
+	"image/tiff":               true,
+	"image/vnd.microsoft.icon": true,
+	"image/webp":               true,
+	"image/x-icon":             true,
+}
+
+// Check examines each picture element in the document.
+func (r *PictureSource) Check(doc *parser.Document) []Result {
+	var results []Result
+
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.ElementNode || !n.IsElement("picture") {
+			return true
+		}
+
+		var img *parser.Node
+		seen := make(map[string]bool) // normalized media + type
+		for _, child := range n.ChildElements() {
+			switch {
+			case child.IsElement("img"):
+				if img == nil {
+					img = child
+				}
+			case child.IsElement("source"):
+				results = append(results, r.checkSource(child, img, seen, doc)...)
+			}
+		}
+
+		if img == nil {
+			results = append(results, Result{
+				Rule:      RulePictureSource,
+				Message:   catalogMessage("picture-source.no-img", nil),
+				MessageID: "picture-source.no-img",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Error,
+			})
+		}
+
+		return true
+	})
+
+	return results
+}
+
+func (r *PictureSource) checkSource(n, img *parser.Node, seen map[string]bool, doc *parser.Document) []Result {
+	var results []Result
+	report := func(attr string, m message, sev Severity) {
+		line, col := n.AttrPos(attr)
+		results = append(results, Result{
+			Rule:      RulePictureSource,
+			Message:   m.text(),
+			MessageID: m.id,
+			Params:    m.params,
+			Filename:  doc.Filename,
+			Line:      line,
+			Col:       col,
+			Severity:  sev,
+		})
+	}
+
+	if img != nil {
+		report("", message{"picture-source.after-img", nil}, Error)
+	}
+	if !n.HasAttr("srcset") {
+		report("", message{"picture-source.srcset", nil}, Error)
+	}
+
+	if IsTemplateExpr(n.GetAttr("media")) || IsTemplateExpr(n.GetAttr("type")) {
+		return results
+	}
+	media := strings.Join(strings.Fields(strings.ToLower(n.GetAttr("media"))), " ")
+	typ := strings.ToLower(strings.TrimSpace(n.GetAttr("type")))
+
+	if n.HasAttr("media") {
+		if problem := mediaQueryProblem(media); problem != "" {
+			report("media", message{"picture-source.media-invalid", map[string]any{"media": n.GetAttr("media"), "problem": problem}}, Error)
+		}
+	}
+	if n.HasAttr("type") {
+		switch {
+		case typ == "":
+			report("type", message{"picture-source.type-empty", nil}, Error)
+		case !strings.HasPrefix(typ, "image/"):
+			report("type", message{"picture-source.type-not-image", map[string]any{"type": typ}}, Error)
+		case !KnownImageTypes[typ]:
+			report("type", message{"picture-source.type-unknown", map[string]any{"type": typ}}, Warning)
+		}
+	}
+
+	// A source without media or type always matches, so every source
+	// after it is unreachable.
+	key := media + "\x00" + typ
+	switch {
+	case seen["\x00"]:
+		report("", message{"picture-source.unreachable", nil}, Warning)
+	case seen[key]:
+		report("media", message{"picture-source.media-duplicate", map[string]any{"media": media}}, Warning)
+	}
+	seen[key] = true
+
+	return results
+}
+
+// knownMediaTypes lists media types from Media Queries Level 4. Other
+// types are deprecated and never match.
+var knownMediaTypes = map[string]bool{"all": true, "print": true, "screen": true}
+
+// knownMediaFeatures lists media feature names from Media Queries Levels 4
+// and 5. Range features also accept min- and max- prefixes.
+var knownMediaFeatures = map[string]bool{
+	"any-hover": true, "any-pointer": true, "aspect-ratio": true, "color": true,
+	"color-gamut": true, "color-index": true, "device-aspect-ratio": true,
+	"device-height": true, "device-width": true, "display-mode": true,
+	"dynamic-range": true, "forced-colors": true, "grid": true, "height": true,
+	"hover": true, "inverted-colors": true, "monochrome": true, "orientation": true,
+	"overflow-block": true, "overflow-inline": true, "pointer": true,
+	"prefers-color-scheme": true, "prefers-contrast": true,
+	"prefers-reduced-data": true, "prefers-reduced-motion": true,
+	"prefers-reduced-transparency": true, "resolution": true, "scan": true,
+	"scripting": true, "update": true, "video-dynamic-range": true, "width": true,
+}
+
+// mediaQueryProblem returns a description of the first syntax problem in a
+// normalized (lowercase, single-spaced) media query list, or "" if it
+// parses. Feature values are not validated.
+func mediaQueryProblem(list string) string {
+	if strings.TrimSpace(list) == "" {
+		return "empty media query"
+	}
+	for _, query := range splitTopLevel(list, ',') {
+		if problem := mediaQueryItemProblem(strings.TrimSpace(query)); problem != "" {
+			return problem
+		}
+	}
+	return ""
+}
+
+// mediaQueryItemProblem checks one query: [not|only] type [and condition]
+// or a bare condition.
+func mediaQueryItemProblem(query string) string {
+	if query == "" {
+		return "empty query in list"
+	}
+	tokens, problem := mediaTokens(query)
+	if problem != "" {
+		return problem
+	}
+	i := 0
+	if tokens[0] == "only" || (tokens[0] == "not" && len(tokens) > 1 && !strings.HasPrefix(tokens[1], "(")) {
+		i++
+	}
+	if i >= len(tokens) || strings.HasPrefix(tokens[i], "(") {
+		if i > 0 {
+			return "expected media type after \"" + tokens[0] + "\""
+		}
+		return mediaConditionProblem(tokens, true)
+	}
+	if tokens[i] == "not" {
+		return mediaConditionProblem(tokens, true)
+	}
+	if deprecatedMediaTypes[tokens[i]] {
+		return "deprecated media type \"" + tokens[i] + "\" never matches"
+	}
+	if !knownMediaTypes[tokens[i]] {
+		return "unknown media type \"" + tokens[i] + "\""
+	}
+	rest := tokens[i+1:]
+	if len(rest) == 0 {
+		return ""
+	}
+	if rest[0] != "and" || len(rest) == 1 {
+		return "expected \"and\" and a condition after media type"
+	}
+	return mediaConditionProblem(rest[1:], false)
+}
+
+// mediaConditionProblem checks a sequence of parenthesized terms joined by
+// a single kind of combinator. allowOr is false after a media type, where
+// only "and" may follow.
+func mediaConditionProblem(tokens []string, allowOr bool) string {
+	if len(tokens) == 0 {
+		return "missing condition"
+	}
+	if tokens[0] == "not" {
+		if len(tokens) != 2 {
+			return "\"not\" applies to a single condition"
+		}
+		return mediaInParensProblem(tokens[1])
+	}
+	combinator := ""
+	for i, tok := range tokens {
+		if i%2 == 1 {
+			if tok != "and" && (tok != "or" || !allowOr) {
+				return "unexpected \"" + tok + "\""
+			}
+			if combinator != "" && tok != combinator {
+				return "mixing \"and\" and \"or\" requires parentheses"
+			}
+			combinator = tok
+			continue
+		}
+		if problem := mediaInParensProblem(tok); problem != "" {
+			return problem
+		}
+	}
+	if len(tokens)%2 == 0 {
+		return "missing condition after \"" + tokens[len(tokens)-1] + "\""
+	}
+	return ""
+}
+
+// mediaInParensProblem checks a parenthesized feature or nested condition.
+func mediaInParensProblem(tok string) string {
+	if !strings.HasPrefix(tok, "(") {
+		return "expected \"(\" before \"" + tok + "\""
+	}
+	inner := strings.TrimSpace(tok[1 : len(tok)-1])
+	if inner == "" {
+		return "empty parentheses"
+	}
+	if strings.HasPrefix(inner, "(") || strings.HasPrefix(inner, "not ") {
+		tokens, problem := mediaTokens(inner)
+		if problem != "" {
+			return problem
+		}
+		return mediaConditionProblem(tokens, true)
+	}
+
+	// (name: value) or boolean (name)
+	if name, value, ok := strings.Cut(inner, ":"); ok {
+		if strings.TrimSpace(value) == "" {
+			return "missing value for \"" + strings.TrimSpace(name) + "\""
+		}
+		return mediaFeatureProblem(strings.TrimSpace(name), true)
+	}
+	if !strings.ContainsAny(inner, "<>=") {
+		return mediaFeatureProblem(inner, false)
+	}
+
+	// Range syntax: (400px <= width < 800px) or (width >= 600px)
+	parts := strings.FieldsFunc(inner, func(c rune) bool { return c == '<' || c == '>' || c == '=' })
+	if len(parts) < 2 || len(parts) > 3 {
+		return "malformed range \"" + inner + "\""
+	}
+	var feature string
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			return "malformed range \"" + inner + "\""
+		}
+		if feature == "" && (knownMediaFeatures[p] || strings.HasPrefix(p, "-")) {
+			feature = p
+		}
+	}
+	if feature == "" {
+		return "no media feature in range \"" + inner + "\""
+	}
+	return ""
+}
+
+// mediaFeatureProblem checks a feature name. Vendor-prefixed names are
+// accepted as-is; min-/max- prefixes require a value.
+func mediaFeatureProblem(name string, hasValue bool) string {
+	if strings.HasPrefix(name, "-") {
+		return ""
+	}
+	base := name
+	if b, ok := strings.CutPrefix(name, "min-"); ok {
+		base = b
+	} else if b, ok := strings.CutPrefix(name, "max-"); ok {
+		base = b
+	}
+	if !knownMediaFeatures[base] {
+		return "unknown media feature \"" + name + "\""
+	}
+	if base != name && !hasValue {
+		return "\"" + name + "\" requires a value"
+	}
+	return ""
+}
+
+// mediaTokens splits a query into words and balanced parenthesized groups.
+func mediaTokens(s string) ([]string, string) {
+	var tokens []string
+	i := 0
+	for i < len(s) {
+		switch c := s[i]; {
+		case c == ' ':
+			i++
+		case c == '(':
+			depth, j := 0, i
+			for ; j < len(s); j++ {
+				if s[j] == '(' {
+					depth++
+				} else if s[j] == ')' {
+					depth--
+					if depth == 0 {
+						break
+					}
+				}
+			}
+			if j == len(s) {
+				return nil, "unbalanced parentheses"
+			}
+			tokens = append(tokens, s[i:j+1])
+			i = j + 1
+		case c == ')':
+			return nil, "unbalanced parentheses"
+		default:
+			j := i
+			for j < len(s) && s[j] != ' ' && s[j] != '(' && s[j] != ')' {
+				j++
+			}
+			tokens = append(tokens, s[i:j])
+			i = j
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, "empty media query"
+	}
+	return tokens, ""
+}
+
+// splitTopLevel splits s on sep outside parentheses.
+func splitTopLevel(s string, sep byte) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case sep:
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
@@ -65,13 +65,16 @@ func (r *PreferAria) Check(doc *parser.Document) []Result {
 
 			// Check exact matches
 			if ariaAttr, ok := ariaEquivalents[attr.Key]; ok {
+				params := map[string]any{"attr": attr.Key, "aria": ariaAttr}
 				results = append(results, Result{
-					Rule:     r.Name(),
-					Message:  attr.Key + " should use " + ariaAttr + " instead",
-					Filename: doc.Filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Warning,
+					Rule:      r.Name(),
+					Message:   catalogMessage("prefer-aria.replace", params),
+					MessageID: "prefer-aria.replace",
+					Params:    params,
+					Filename:  doc.Filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Warning,
 				})
 				continue
 			}
@@ -82,13 +85,16 @@ func (r *PreferAria) Check(doc *parser.Document) []Result {
 				// Check variations: data-is-expanded, data-isexpanded
 				baseName := strings.TrimPrefix(dataAttr, "data-")
 				if strings.Contains(lowerKey, baseName) {
+					params := map[string]any{"attr": attr.Key, "aria": ariaAttr}
 					results = append(results, Result{
-						Rule:     r.Name(),
-						Message:  attr.Key + " appears to indicate state; consider using " + ariaAttr,
-						Filename: doc.Filename,
-						Line:     n.Line,
-						Col:      n.Col,
-						Severity: Info,
+						Rule:      r.Name(),
+						Message:   catalogMessage("prefer-aria.state", params),
+						MessageID: "prefer-aria.state",
+						Params:    params,
+						Filename:  doc.Filename,
+						Line:      n.Line,
+						Col:       n.Col,
+						Severity:  Info,
 					})
 					break
 				}
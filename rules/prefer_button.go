@@ -1,7 +1,6 @@
 package rules
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/toba/go-html-validate/parser"
@@ -39,13 +38,16 @@ func (r *PreferButton) Check(doc *parser.Document) []Result {
 
 		inputType := strings.ToLower(n.GetAttr("type"))
 		if buttonInputTypes[inputType] {
+			params := map[string]any{"type": inputType}
 			results = append(results, Result{
-				Rule:     r.Name(),
-				Message:  fmt.Sprintf("prefer <button> over <input type=%q>", inputType),
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Info,
+				Rule:      r.Name(),
+				Message:   catalogMessage("prefer-button.input", params),
+				MessageID: "prefer-button.input",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Info,
 			})
 		}
 
@@ -0,0 +1,143 @@
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+)
+
+// Component names a design-system template that should be used instead of
+// hand-written markup matching Selector, such as {{template "ui/button"}}
+// for <button class="btn">.
+type Component struct {
+	// Selector matches the markup the component replaces
+	Selector string
+	// Template is the component's template name
+	Template string
+	// Message explains the preference and is appended to the finding
+	Message string
+	// Exclude lists glob patterns, relative to the config file, of files
+	// where the markup is allowed
+	Exclude []string
+}
+
+// compile compiles the selector of c.
+func (c Component) compile() (*parser.Selector, error) {
+	if strings.TrimSpace(c.Selector) == "" || c.Template == "" {
+		return nil, fmt.Errorf("components need a selector and a template")
+	}
+	sel, err := parser.CompileSelector(c.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("component %q: %w", c.Template, err)
+	}
+	return sel, nil
+}
+
+// Validate reports a component without a selector or template, or with a
+// selector that does not compile.
+func (c Component) Validate() error {
+	_, err := c.compile()
+	return err
+}
+
+// ComponentsConfigurable is implemented by rules that check configured
+// components. match reports whether a linted file matches an Exclude
+// pattern.
+type ComponentsConfigurable interface {
+	ConfigureComponents(comps []Component, match func(filename, pattern string) bool)
+}
+
+// compiledComponent is a Component with its selector compiled.
+type compiledComponent struct {
+	Component
+	selector *parser.Selector
+	// defines matches a {{define}} or {{block}} of the component
+	defines *regexp.Regexp
+}
+
+// PreferComponent advises using a design-system component template
+// instead of raw markup the component renders. The component's own
+// template is exempt: a file that defines it with {{define}} or {{block}},
+// or whose path without extension ends with its name (ui/button.gohtml for
+// "ui/button"). Without components it reports nothing.
+type PreferComponent struct {
+	comps   []compiledComponent
+	match   func(filename, pattern string) bool
+	dialect parser.Dialect
+}
+
+// Name returns the rule identifier.
+func (r *PreferComponent) Name() string { return RulePreferComponent }
+
+// Description returns what this rule checks.
+func (r *PreferComponent) Description() string {
+	return "markup a configured component renders should use the component"
+}
+
+// ConfigureComponents sets the components to suggest. Components that do
+// not compile are skipped; config validates them when loading.
+func (r *PreferComponent) ConfigureComponents(comps []Component, match func(filename, pattern string) bool) {
+	r.comps = r.comps[:0]
+	for _, c := range comps {
+		if sel, err := c.compile(); err == nil {
+			defines := regexp.MustCompile(`\{\{-?\s*(?:define|block)\s+"` + regexp.QuoteMeta(c.Template) + `"`)
+			r.comps = append(r.comps, compiledComponent{Component: c, selector: sel, defines: defines})
+		}
+	}
+	r.match = match
+}
+
+// ConfigureDialect sets the template dialect used to spell suggestions.
+func (r *PreferComponent) ConfigureDialect(d parser.Dialect) {
+	r.dialect = d
+}
+
+// Check reports markup that a component applying to the document renders.
+func (r *PreferComponent) Check(doc *parser.Document) []Result {
+	var comps []compiledComponent
+	for _, c := range r.comps {
+		if !excluded(r.match, doc.Filename, c.Exclude) && !definesComponent(doc, c) {
+			comps = append(comps, c)
+		}
+	}
+	if len(comps) == 0 {
+		return nil
+	}
+
+	dialect := r.dialect.ForFile(doc.Filename)
+	var results []Result
+	doc.Walk(func(n *parser.Node) bool {
+		for _, c := range comps {
+			if !c.selector.Match(n) {
+				continue
+			}
+			params := map[string]any{"element": Tag(n), "selector": c.Selector, "call": dialect.IncludeCall(c.Template), "message": c.Message}
+			results = append(results, Result{
+				Rule:      RulePreferComponent,
+				Message:   catalogMessage("prefer-component.component", params),
+				MessageID: "prefer-component.component",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Info,
+			})
+			break
+		}
+		return true
+	})
+
+	return results
+}
+
+// definesComponent reports whether doc is the template of c.
+func definesComponent(doc *parser.Document, c compiledComponent) bool {
+	path := filepath.ToSlash(strings.TrimSuffix(doc.Filename, filepath.Ext(doc.Filename)))
+	if path == c.Template || strings.HasSuffix(path, "/"+c.Template) {
+		return true
+	}
+	return c.defines.Match(doc.Source())
+}
@@ -45,13 +45,16 @@ func (r *PreferNativeElement) Check(doc *parser.Document) []Result {
 			return true
 		}
 
+		params := map[string]any{"native": native, "element": n.Data, "role": role}
 		results = append(results, Result{
-			Rule:     r.Name(),
-			Message:  "use " + native + " element instead of <" + n.Data + " role=\"" + role + "\">; native elements have better accessibility support",
-			Filename: doc.Filename,
-			Line:     n.Line,
-			Col:      n.Col,
-			Severity: Warning,
+			Rule:      r.Name(),
+			Message:   catalogMessage("prefer-native-element.role", params),
+			MessageID: "prefer-native-element.role",
+			Params:    params,
+			Filename:  doc.Filename,
+			Line:      n.Line,
+			Col:       n.Col,
+			Severity:  Warning,
 		})
 
 		return true
@@ -47,22 +47,28 @@ func (r *PreferSemantic) Check(doc *parser.Document) []Result {
 		hasTabindex := n.HasAttr("tabindex")
 
 		if hasClickHandler || (hasHTMXClick && hasButtonRole) {
+			params := map[string]any{"element": n.Data}
 			results = append(results, Result{
-				Rule:     r.Name(),
-				Message:  n.Data + " with click handler should be a <button> element",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Warning,
+				Rule:      r.Name(),
+				Message:   catalogMessage("prefer-semantic.click", params),
+				MessageID: "prefer-semantic.click",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
 			})
 		} else if hasButtonRole && hasTabindex {
+			params := map[string]any{"element": n.Data}
 			results = append(results, Result{
-				Rule:     r.Name(),
-				Message:  n.Data + " with role=\"button\" should be a <button> element",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Warning,
+				Rule:      r.Name(),
+				Message:   catalogMessage("prefer-semantic.role", params),
+				MessageID: "prefer-semantic.role",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
 			})
 		}
 
@@ -35,10 +35,7 @@ func (r *PreferTbody) Check(doc *parser.Document) []Result {
 		hasTbody := false
 		hasTr := false
 
-		for _, child := range n.Children {
-			if child.Type != html.ElementNode {
-				continue
-			}
+		for _, child := range n.ChildElements() {
 			childTag := strings.ToLower(child.Data)
 			if childTag == "tbody" {
 				hasTbody = true
@@ -51,12 +48,13 @@ func (r *PreferTbody) Check(doc *parser.Document) []Result {
 		// Only report if table has direct tr children without tbody
 		if hasTr && !hasTbody {
 			results = append(results, Result{
-				Rule:     RulePreferTbody,
-				Message:  "table should use explicit <tbody> element",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Info,
+				Rule:      RulePreferTbody,
+				Message:   catalogMessage("prefer-tbody.missing", nil),
+				MessageID: "prefer-tbody.missing",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Info,
 			})
 		}
 
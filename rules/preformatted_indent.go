@@ -0,0 +1,103 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// PreformattedIndent checks for uniformly indented content inside elements
+// that render whitespace verbatim. Formatters that reindent templates push
+// the content of <pre>, <textarea>, and <script type="text/plain"> to the
+// markup's nesting level, and that indentation becomes visible to users.
+type PreformattedIndent struct{}
+
+// Name returns the rule identifier.
+func (r *PreformattedIndent) Name() string { return RulePreformattedIndent }
+
+// Description returns what this rule checks.
+func (r *PreformattedIndent) Description() string {
+	return "content of pre, textarea, and plain-text scripts should not be reindented"
+}
+
+// Check examines whitespace-preserving elements for shared leading indentation.
+func (r *PreformattedIndent) Check(doc *parser.Document) []Result {
+	var results []Result
+
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+
+		tag := Tag(n)
+		switch tag {
+		case "pre", "textarea":
+		case "script":
+			if !strings.EqualFold(strings.TrimSpace(n.GetAttr("type")), "text/plain") {
+				return true
+			}
+		default:
+			return true
+		}
+
+		if indent := commonIndent(rawText(n)); indent != "" {
+			params := map[string]any{"element": tag, "count": len(indent)}
+			results = append(results, Result{
+				Rule:      RulePreformattedIndent,
+				Message:   catalogMessage("preformatted-indent.indented", params),
+				MessageID: "preformatted-indent.indented",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
+			})
+		}
+
+		// Nested <pre> content is covered by the outer element
+		return tag != "pre"
+	})
+
+	return results
+}
+
+// rawText concatenates the descendant text of n without trimming it.
+func rawText(n *parser.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var buf strings.Builder
+	for _, child := range n.Children {
+		buf.WriteString(rawText(child))
+	}
+	return buf.String()
+}
+
+// commonIndent returns the leading whitespace shared by every non-blank
+// line of multi-line text, or "" if the lines share none.
+func commonIndent(text string) string {
+	if !strings.Contains(strings.TrimSpace(text), "\n") {
+		return ""
+	}
+
+	indent := ""
+	first := true
+	for line := range strings.SplitSeq(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		lead := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if first {
+			indent, first = lead, false
+			continue
+		}
+		for !strings.HasPrefix(lead, indent) {
+			indent = indent[:len(indent)-1]
+		}
+		if indent == "" {
+			return ""
+		}
+	}
+	return indent
+}
@@ -38,12 +38,13 @@ func (r *RedundantAriaLabel) Check(doc *parser.Document) []Result {
 		if normalizedAriaLabel != "" && normalizedText != "" &&
 			normalizedAriaLabel == normalizedText {
 			results = append(results, Result{
-				Rule:     r.Name(),
-				Message:  "aria-label duplicates visible text content",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Warning,
+				Rule:      r.Name(),
+				Message:   catalogMessage("no-redundant-aria-label.duplicate", nil),
+				MessageID: "no-redundant-aria-label.duplicate",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
 			})
 		}
 
@@ -43,12 +43,13 @@ func (r *RequireCSPNonce) Check(doc *parser.Document) []Result {
 			// Check for nonce attribute
 			if !n.HasAttr("nonce") {
 				results = append(results, Result{
-					Rule:     RuleRequireCSPNonce,
-					Message:  "inline script should have nonce attribute for CSP",
-					Filename: doc.Filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Info, // Info level since not all sites use CSP
+					Rule:      RuleRequireCSPNonce,
+					Message:   catalogMessage("require-csp-nonce.script", nil),
+					MessageID: "require-csp-nonce.script",
+					Filename:  doc.Filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Info, // Info level since not all sites use CSP
 				})
 			}
 
@@ -56,12 +57,13 @@ func (r *RequireCSPNonce) Check(doc *parser.Document) []Result {
 			// Check for nonce attribute
 			if !n.HasAttr("nonce") {
 				results = append(results, Result{
-					Rule:     RuleRequireCSPNonce,
-					Message:  "inline style should have nonce attribute for CSP",
-					Filename: doc.Filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Info, // Info level since not all sites use CSP
+					Rule:      RuleRequireCSPNonce,
+					Message:   catalogMessage("require-csp-nonce.style", nil),
+					MessageID: "require-csp-nonce.style",
+					Filename:  doc.Filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Info, // Info level since not all sites use CSP
 				})
 			}
 		}
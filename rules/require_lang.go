@@ -24,21 +24,23 @@ func (r *RequireLang) Check(doc *parser.Document) []Result {
 
 		if !n.HasAttr("lang") {
 			results = append(results, Result{
-				Rule:     r.Name(),
-				Message:  "<html> element must have a lang attribute; add lang=\"en\" for English content",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Error,
+				Rule:      r.Name(),
+				Message:   catalogMessage("require-lang.missing", nil),
+				MessageID: "require-lang.missing",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Error,
 			})
 		} else if n.GetAttr("lang") == "" {
 			results = append(results, Result{
-				Rule:     r.Name(),
-				Message:  "lang attribute must not be empty; use BCP 47 code like \"en\" or \"en-US\"",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Error,
+				Rule:      r.Name(),
+				Message:   catalogMessage("require-lang.empty", nil),
+				MessageID: "require-lang.empty",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Error,
 			})
 		}
 
@@ -37,12 +37,13 @@ func (r *RequireSRI) Check(doc *parser.Document) []Result {
 			}
 			if n.GetAttr("integrity") == "" {
 				results = append(results, Result{
-					Rule:     r.Name(),
-					Message:  "external script missing integrity attribute for SRI",
-					Filename: doc.Filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Warning,
+					Rule:      r.Name(),
+					Message:   catalogMessage("require-sri.script", nil),
+					MessageID: "require-sri.script",
+					Filename:  doc.Filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Warning,
 				})
 			}
 
@@ -61,12 +62,13 @@ func (r *RequireSRI) Check(doc *parser.Document) []Result {
 			}
 			if n.GetAttr("integrity") == "" {
 				results = append(results, Result{
-					Rule:     r.Name(),
-					Message:  "external stylesheet missing integrity attribute for SRI",
-					Filename: doc.Filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Warning,
+					Rule:      r.Name(),
+					Message:   catalogMessage("require-sri.stylesheet", nil),
+					MessageID: "require-sri.stylesheet",
+					Filename:  doc.Filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Warning,
 				})
 			}
 		}
@@ -0,0 +1,58 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+)
+
+// RequireUTF8 warns when a file is not encoded in UTF-8, either because it
+// starts with a UTF-16 byte order mark or because a <meta> tag declares a
+// legacy charset such as windows-1252. The linter decodes UTF-16 and
+// windows-1252 before checking the file; other encodings are checked as if
+// they were UTF-8, so text in them may be reported as garbled.
+type RequireUTF8 struct{}
+
+// Name returns the rule identifier.
+func (r *RequireUTF8) Name() string { return RuleRequireUTF8 }
+
+// Description returns what this rule checks.
+func (r *RequireUTF8) Description() string {
+	return "files should be encoded in UTF-8"
+}
+
+// Check reports the encoding the document was decoded from unless it is
+// UTF-8.
+func (r *RequireUTF8) Check(doc *parser.Document) []Result {
+	if doc.Encoding == "" || doc.Encoding == parser.EncodingUTF8 {
+		return nil
+	}
+
+	line, col := 1, 1
+	for _, meta := range doc.QuerySelectorAll("meta") {
+		if meta.HasAttr("charset") {
+			line, col = meta.AttrPos("charset")
+			break
+		}
+		if strings.EqualFold(meta.GetAttr("http-equiv"), "content-type") {
+			line, col = meta.AttrPos("content")
+			break
+		}
+	}
+
+	id := "require-utf8.declare"
+	if doc.Encoding == parser.EncodingUTF16LE || doc.Encoding == parser.EncodingUTF16BE {
+		id = "require-utf8.bom"
+	}
+	params := map[string]any{"encoding": doc.Encoding}
+	return []Result{{
+		Rule:      RuleRequireUTF8,
+		Message:   catalogMessage(id, params),
+		MessageID: id,
+		Params:    params,
+		Filename:  doc.Filename,
+		Line:      line,
+		Col:       col,
+		Severity:  Warning,
+	}}
+}
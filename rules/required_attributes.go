@@ -0,0 +1,149 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+)
+
+// Requirement is a team policy that elements matching Selector carry
+// attributes, such as rel~=noopener on external links. It mirrors Ban.
+type Requirement struct {
+	// Selector chooses the elements the requirement applies to
+	Selector string
+	// Attributes lists attribute conditions in CSS attribute selector
+	// syntax without brackets: "data-src-set-by" requires the attribute,
+	// "rel~=noopener" requires a value token, and "type=button" a value
+	Attributes []string
+	// Message explains the policy and is appended to the finding
+	Message string
+	// Exclude lists glob patterns, relative to the config file, of files
+	// where the requirement does not apply
+	Exclude []string
+}
+
+// compiledRequirement is a Requirement with its selectors compiled.
+type compiledRequirement struct {
+	Requirement
+	selector   *parser.Selector
+	conditions []*parser.Selector // one [attr] selector per Attributes entry
+}
+
+// compile compiles the selector and attribute conditions of q.
+func (q Requirement) compile() (compiledRequirement, error) {
+	c := compiledRequirement{Requirement: q}
+	if strings.TrimSpace(q.Selector) == "" || len(q.Attributes) == 0 {
+		return c, fmt.Errorf("required entries need a selector and attributes")
+	}
+	sel, err := parser.CompileSelector(q.Selector)
+	if err != nil {
+		return c, fmt.Errorf("required %q: %w", q.Selector, err)
+	}
+	c.selector = sel
+	for _, attr := range q.Attributes {
+		cond, err := parser.CompileSelector("[" + attr + "]")
+		if err != nil {
+			return c, fmt.Errorf("required %q: attribute %q: %w", q.Selector, attr, err)
+		}
+		c.conditions = append(c.conditions, cond)
+	}
+	return c, nil
+}
+
+// Validate reports a requirement without a selector or attributes, or
+// with one that does not compile.
+func (q Requirement) Validate() error {
+	_, err := q.compile()
+	return err
+}
+
+// RequirementsConfigurable is implemented by rules that enforce configured
+// requirements. match reports whether a linted file matches an Exclude
+// pattern.
+type RequirementsConfigurable interface {
+	ConfigureRequirements(reqs []Requirement, match func(filename, pattern string) bool)
+}
+
+// RequiredAttributes reports elements matching a configured selector that
+// lack a required attribute or value, the counterpart of BannedMarkup.
+// Without requirements it reports nothing.
+type RequiredAttributes struct {
+	reqs  []compiledRequirement
+	match func(filename, pattern string) bool
+}
+
+// Name returns the rule identifier.
+func (r *RequiredAttributes) Name() string { return RuleRequiredAttributes }
+
+// Description returns what this rule checks.
+func (r *RequiredAttributes) Description() string {
+	return "elements must carry the attributes required by config"
+}
+
+// ConfigureRequirements sets the requirements to enforce. Requirements
+// that do not compile are skipped; config validates them when loading.
+func (r *RequiredAttributes) ConfigureRequirements(reqs []Requirement, match func(filename, pattern string) bool) {
+	r.reqs = r.reqs[:0]
+	for _, q := range reqs {
+		if c, err := q.compile(); err == nil {
+			r.reqs = append(r.reqs, c)
+		}
+	}
+	r.match = match
+}
+
+// Check reports each matching element missing a required attribute.
+func (r *RequiredAttributes) Check(doc *parser.Document) []Result {
+	var reqs []compiledRequirement
+	for _, q := range r.reqs {
+		if !excluded(r.match, doc.Filename, q.Exclude) {
+			reqs = append(reqs, q)
+		}
+	}
+	if len(reqs) == 0 {
+		return nil
+	}
+
+	var results []Result
+	doc.Walk(func(n *parser.Node) bool {
+		for _, q := range reqs {
+			if !q.selector.Match(n) {
+				continue
+			}
+			for i, cond := range q.conditions {
+				if cond.Match(n) {
+					continue
+				}
+				attr := q.Attributes[i]
+				line, col := n.Line, n.Col
+				// point at the attribute when it is present with the wrong value
+				if name := attrName(attr); n.HasAttr(name) {
+					line, col = n.AttrPos(name)
+				}
+				params := map[string]any{"element": Tag(n), "selector": q.Selector, "attr": attr, "message": q.Message}
+				results = append(results, Result{
+					Rule:      RuleRequiredAttributes,
+					Message:   catalogMessage("required-attributes.missing", params),
+					MessageID: "required-attributes.missing",
+					Params:    params,
+					Filename:  doc.Filename,
+					Line:      line,
+					Col:       col,
+					Severity:  Error,
+				})
+			}
+		}
+		return true
+	})
+
+	return results
+}
+
+// attrName returns the attribute name of a condition such as "rel~=noopener".
+func attrName(cond string) string {
+	if i := strings.IndexAny(cond, "~|^$*="); i >= 0 {
+		cond = cond[:i]
+	}
+	return strings.TrimSpace(cond)
+}
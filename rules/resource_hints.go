@@ -0,0 +1,191 @@
+package rules
+
+import (
+	"bytes"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// DefaultMaxBlockingStylesheets is the default limit on render-blocking
+// stylesheets in <head> for ResourceHints.
+const DefaultMaxBlockingStylesheets = 3
+
+// ResourceHints warns about resource hints and stylesheets that slow down
+// first render: font preloads without crossorigin (fonts are always fetched
+// in CORS mode, so the preloaded response is discarded and fetched again),
+// too many render-blocking stylesheets in <head>, and preconnect or
+// dns-prefetch hints for origins the document never uses. It is opt-in
+// and accepts a "max-blocking-stylesheets" option.
+type ResourceHints struct {
+	MaxBlockingStylesheets int
+}
+
+// Name returns the rule identifier.
+func (r *ResourceHints) Name() string { return RuleResourceHints }
+
+// Description returns what this rule checks.
+func (r *ResourceHints) Description() string {
+	return "font hints need crossorigin, head stylesheets should be few, and hinted origins should be used"
+}
+
+// OptIn marks the rule as disabled unless explicitly enabled.
+func (r *ResourceHints) OptIn() {}
+
+// ConfigureOptions applies the max-blocking-stylesheets option.
+func (r *ResourceHints) ConfigureOptions(opts map[string]any) {
+	r.MaxBlockingStylesheets = IntOption(opts, "max-blocking-stylesheets", r.MaxBlockingStylesheets)
+}
+
+// fontFileExts are file extensions of web font formats.
+var fontFileExts = map[string]bool{".woff2": true, ".woff": true, ".ttf": true, ".otf": true, ".eot": true}
+
+// indirectOrigins maps font file hosts to the stylesheet hosts whose CSS
+// loads from them, so a preconnect to the former is used by the latter.
+var indirectOrigins = map[string]string{
+	"fonts.gstatic.com": "fonts.googleapis.com",
+}
+
+// Check examines link elements in the document.
+func (r *ResourceHints) Check(doc *parser.Document) []Result {
+	var results []Result
+
+	maxBlocking := r.MaxBlockingStylesheets
+	if maxBlocking <= 0 {
+		maxBlocking = DefaultMaxBlockingStylesheets
+	}
+
+	blocking := 0
+	var hints []*parser.Node
+	hintCount := make(map[string]int) // host -> hint links naming it
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.ElementNode || !n.IsElement("link") {
+			return true
+		}
+		rels := strings.Fields(strings.ToLower(n.GetAttr("rel")))
+		href := strings.TrimSpace(n.GetAttr("href"))
+		preload := slices.Contains(rels, "preload") || slices.Contains(rels, "prefetch")
+		preconnect := slices.Contains(rels, "preconnect")
+
+		if preload && !n.HasAttr("crossorigin") && isFontHint(n, href) {
+			results = append(results, Result{
+				Rule:      RuleResourceHints,
+				Message:   catalogMessage("resource-hints.font-crossorigin", nil),
+				MessageID: "resource-hints.font-crossorigin",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
+			})
+		}
+
+		if preconnect || slices.Contains(rels, "dns-prefetch") {
+			if host := hintHost(href); host != "" {
+				hints = append(hints, n)
+				hintCount[host]++
+				if preconnect && !n.HasAttr("crossorigin") && indirectOrigins[host] != "" {
+					params := map[string]any{"host": host}
+					results = append(results, Result{
+						Rule:      RuleResourceHints,
+						Message:   catalogMessage("resource-hints.preconnect-crossorigin", params),
+						MessageID: "resource-hints.preconnect-crossorigin",
+						Params:    params,
+						Filename:  doc.Filename,
+						Line:      n.Line,
+						Col:       n.Col,
+						Severity:  Warning,
+					})
+				}
+			}
+		}
+
+		if slices.Contains(rels, "stylesheet") && !slices.Contains(rels, "alternate") && !n.HasAttr("disabled") &&
+			isBlockingMedia(n.GetAttr("media")) && n.ClosestAncestor("head") != nil {
+			blocking++
+			if blocking == maxBlocking+1 {
+				params := map[string]any{"max": maxBlocking}
+				results = append(results, Result{
+					Rule:      RuleResourceHints,
+					Message:   catalogMessage("resource-hints.blocking", params),
+					MessageID: "resource-hints.blocking",
+					Params:    params,
+					Filename:  doc.Filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Warning,
+				})
+			}
+		}
+
+		return true
+	})
+
+	source := bytes.ToLower(doc.Source())
+	used := func(host string) bool {
+		return bytes.Count(source, []byte("//"+host)) > hintCount[host]
+	}
+	for _, n := range hints {
+		host := hintHost(n.GetAttr("href"))
+		if used(host) || (indirectOrigins[host] != "" && used(indirectOrigins[host])) {
+			continue
+		}
+		rel := "preconnect"
+		if !strings.Contains(strings.ToLower(n.GetAttr("rel")), "preconnect") {
+			rel = "dns-prefetch"
+		}
+		line, col := n.AttrPos("href")
+		params := map[string]any{"rel": rel, "host": host}
+		results = append(results, Result{
+			Rule:      RuleResourceHints,
+			Message:   catalogMessage("resource-hints.unused", params),
+			MessageID: "resource-hints.unused",
+			Params:    params,
+			Filename:  doc.Filename,
+			Line:      line,
+			Col:       col,
+			Severity:  Warning,
+		})
+	}
+
+	return results
+}
+
+// isFontHint reports whether a preload or prefetch link fetches a font.
+func isFontHint(n *parser.Node, href string) bool {
+	if strings.EqualFold(strings.TrimSpace(n.GetAttr("as")), "font") {
+		return true
+	}
+	if u, err := url.Parse(href); err == nil {
+		return fontFileExts[strings.ToLower(path.Ext(u.Path))]
+	}
+	return false
+}
+
+// hintHost returns the lowercase host of an absolute or scheme-relative
+// hint URL, or "" for relative or template URLs.
+func hintHost(href string) string {
+	href = strings.TrimSpace(href)
+	if IsTemplateExpr(href) {
+		return ""
+	}
+	u, err := url.Parse(href)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Host)
+}
+
+// isBlockingMedia reports whether a stylesheet with this media attribute
+// blocks rendering on screens. Conditional queries are treated as
+// non-blocking since they may not match.
+func isBlockingMedia(media string) bool {
+	switch strings.ToLower(strings.TrimSpace(media)) {
+	case "", "all", "screen":
+		return true
+	}
+	return false
+}
@@ -36,14 +36,18 @@ const (
 	RuleInputLabel                  = "input-label"
 	RuleButtonName                  = "button-name"
 	RuleLinkName                    = "link-name"
+	RuleLinkPurpose                 = "link-purpose"
+	RuleNavSemantics                = "nav-semantics"
 	RuleHeadingContent              = "heading-content"
 	RuleHeadingLevel                = "heading-level"
+	RuleHeadingAnchor               = "heading-anchor"
 	RuleTextContent                 = "text-content"
 	RuleEmptyTitle                  = "empty-title"
 	RuleLongTitle                   = "long-title"
 	RulePreferAria                  = "prefer-aria"
 	RuleAriaHiddenBody              = "aria-hidden-body"
 	RuleHiddenFocusable             = "hidden-focusable"
+	RuleCompositeWidget             = "composite-widget"
 	RuleRedundantAriaLabel          = "no-redundant-aria-label"
 	RuleNoRedundantRole             = "no-redundant-role"
 	RuleNoAbstractRole              = "no-abstract-role"
@@ -54,11 +58,15 @@ const (
 	RuleMultipleLabeledControls     = "multiple-labeled-controls"
 	RuleFormDupName                 = "form-dup-name"
 	RuleNoRedundantFor              = "no-redundant-for"
+	RuleInputValueFormat            = "input-value-format"
+	RuleHandlerSyntax               = "handler-syntax"
+	RuleDataJSON                    = "data-json"
 	RuleValidAutocomplete           = "valid-autocomplete"
 	RuleNoImplicitInputType         = "no-implicit-input-type"
 	RuleInputAttributes             = "input-attributes"
 	RuleTabindexNoPositive          = "tabindex-no-positive"
 	RuleSVGFocusable                = "svg-focusable"
+	RuleTargetSize                  = "target-size"
 	RuleNoAutoplay                  = "no-autoplay"
 	RuleMetaRefresh                 = "meta-refresh"
 	RuleWcagH36                     = "wcag/h36"
@@ -69,20 +77,26 @@ const (
 	RuleDuplicateID                 = "duplicate-id"
 	RulePreferButton                = "prefer-button"
 	RuleNoInlineStyle               = "no-inline-style"
+	RuleBannedMarkup                = "banned-markup"
+	RuleRequiredAttributes          = "required-attributes"
+	RulePreferComponent             = "prefer-component"
 	RulePreferNativeElement         = "prefer-native-element"
 	RulePreferTbody                 = "prefer-tbody"
 	RuleNoDupAttr                   = "no-dup-attr"
 	RuleNoDupClass                  = "no-dup-class"
+	RuleNoDupScript                 = "no-dup-script"
 	RuleMapIDName                   = "map-id-name"
 	RuleMapDupName                  = "map-dup-name"
 	RuleElementName                 = "element-name"
 	RuleScriptType                  = "script-type"
 	RuleScriptElement               = "script-element"
 	RuleAttributeAllowedValues      = "attribute-allowed-values"
+	RuleAriaAllowedValues           = "aria-allowed-values"
 	RuleAttributeMisuse             = "attribute-misuse"
 	RuleDeprecated                  = "deprecated"
 	RuleNoDeprecatedAttr            = "no-deprecated-attr"
 	RuleNoConditionalComment        = "no-conditional-comment"
+	RuleNoXHTMLSyntax               = "no-xhtml-syntax"
 	RuleVoidContent                 = "void-content"
 	RuleElementRequiredAncestor     = "element-required-ancestor"
 	RuleElementPermittedParent      = "element-permitted-parent"
@@ -95,20 +109,61 @@ const (
 	RuleValidID                     = "valid-id"
 	RuleRequireLang                 = "require-lang"
 	RuleNoMissingReferences         = "no-missing-references"
+	RuleAriaRelationship            = "aria-relationship"
 	RuleAllowedLinks                = "allowed-links"
+	RuleValidContactLink            = "valid-contact-link"
+	RuleURLEncoding                 = "url-encoding"
 	RuleNoUTF8BOM                   = "no-utf8-bom"
+	RuleRequireUTF8                 = "require-utf8"
 	RuleTelNonBreaking              = "tel-non-breaking"
+	RulePreformattedIndent          = "preformatted-indent"
+	RuleInlineWhitespace            = "inline-whitespace"
+	RuleDirConsistency              = "dir-consistency"
 	RuleRequireSRI                  = "require-sri"
 	RuleRequireCSPNonce             = "require-csp-nonce"
+	RuleCSPCompatible               = "csp-compatible"
+	RuleFormCSRFToken               = "form-csrf-token"
+	RuleSensitiveURLData            = "sensitive-url-data"
+	RuleNoEnvironmentURL            = "no-environment-url"
+	RuleIframeSandbox               = "iframe-sandbox"
+	RuleIframeRequireSandbox        = "iframe-require-sandbox"
+	RuleAssetExists                 = "asset-exists"
 	RuleNoStyleTag                  = "no-style-tag"
 	RuleClassPattern                = "class-pattern"
 	RuleIDPattern                   = "id-pattern"
 	RuleNamePattern                 = "name-pattern"
 	RuleValidFor                    = "valid-for"
 	RuleUnrecognizedCharRef         = "unrecognized-char-ref"
+	RuleTypographicChars            = "typographic-chars"
 	RuleHTMXAttributes              = "htmx-attributes"
+	RuleHTMXPartial                 = "htmx-partial"
+	RuleFrameworkRemnants           = "framework-remnants"
 	RuleTemplateWhitespaceTrim      = "template-whitespace-trim"
 	RuleTemplateSyntaxValid         = "template-syntax-valid"
+	RuleTemplateActionPlacement     = "template-action-placement"
+	RuleTemplateEscapingContext     = "template-escaping-context"
+	RuleTemplateReferences          = "template-references"
+	RuleTemplateCallData            = "template-call-data"
+	RuleNoHardcodedText             = "no-hardcoded-text"
+	RuleNoDebugArtifacts            = "no-debug-artifacts"
+	RuleNoUnrenderedPlaceholder     = "no-unrendered-placeholder"
+	RuleNoMojibake                  = "no-mojibake"
+	RuleNoCommentedMarkup           = "no-commented-markup"
+	RuleCommentSyntax               = "comment-syntax"
+	RuleMinifiedFile                = "minified-file"
+	RuleSVGUseReference             = "svg-use-reference"
+	RuleFallbackContent             = "fallback-content"
+	RuleMotionSafety                = "motion-safety"
+	RuleTextResize                  = "text-resize"
+	RuleDOMSize                     = "dom-size"
+	RuleResourceHints               = "resource-hints"
+	RuleMathMLStructure             = "mathml-structure"
+	RuleSlotName                    = "slot-name"
+	RulePictureSource               = "picture-source"
+	RuleMediaSource                 = "media-source"
+	RuleMediaQuery                  = "media-query"
+	RulePageStructure               = "page-structure"
+	RuleSrcsetDescriptors           = "srcset-descriptors"
 )
 
 // Result represents a single lint finding.
@@ -119,6 +174,27 @@ type Result struct {
 	Line     int      // 1-indexed line number
 	Col      int      // 1-indexed column number
 	Severity Severity // Error, Warning, or Info
+	Fix      *Fix     // Optional automatic fix, nil if none
+
+	MessageID string         // Catalog ID of Message (see package messages), empty if not cataloged
+	Params    map[string]any // Parameters rendered into the MessageID template
+
+	Meta map[string]string // Extra data, e.g. WCAG criteria set by rules or owners set by linter middleware
+}
+
+// MetaOwner is the Result.Meta key for the owners of the result's file,
+// separated by spaces.
+const MetaOwner = "owner"
+
+// MetaWCAG is the Result.Meta key for the WCAG success criterion a result
+// relates to, e.g. "2.2.2".
+const MetaWCAG = "wcag"
+
+// Fix replaces a byte range of the original file content.
+type Fix struct {
+	Start int    // offset of the first replaced byte
+	End   int    // offset after the last replaced byte
+	Text  string // replacement text
 }
 
 // Rule defines the interface for accessibility rules.
@@ -141,13 +217,77 @@ type HTMXCustomEventsConfigurable interface {
 	ConfigureCustomEvents(events []string)
 }
 
+// FrameworksConfigurable is implemented by rules that need to know which
+// frontend frameworks besides htmx the project uses.
+type FrameworksConfigurable interface {
+	ConfigureFrameworks(allowed []string)
+}
+
+// OptInRule is implemented by rules that are disabled by default. They run
+// only when listed in the enabled rules or given a severity in config.
+type OptInRule interface {
+	Rule
+	OptIn()
+}
+
+// OptionsConfigurable is implemented by rules that accept options from the
+// array rule config format (e.g. ["warn", {"max-depth": 40}]).
+type OptionsConfigurable interface {
+	ConfigureOptions(opts map[string]any)
+}
+
+// DialectConfigurable is implemented by rules that check template syntax
+// and need to know which template dialect the files use.
+type DialectConfigurable interface {
+	ConfigureDialect(d parser.Dialect)
+}
+
+// URLRewriter maps a local URL path as written in markup to the path of
+// the file it is served from, e.g. stripping a CDN prefix or turning
+// "app.js" into the glob "app.*.js" for fingerprinted bundles.
+type URLRewriter func(path string) string
+
+// URLRewriterConfigurable is implemented by rules that resolve local URLs
+// and accept rewriters from Go code.
+type URLRewriterConfigurable interface {
+	ConfigureRewriters(rewriters []URLRewriter)
+}
+
 // RawRule is implemented by rules that need access to the raw file content
 // before template preprocessing. This allows linting template syntax itself.
+// The linter dispatches CheckRaw before parsing, then Check as usual; raw-only
+// rules return nil from Check. Rules that need both the tree and the original
+// text can use Document.Source, SourceLine, and SourceRange instead.
 type RawRule interface {
 	Rule
 	CheckRaw(filename string, content []byte) []Result
 }
 
+// TokenRule is implemented by rules that can check a file from its token
+// stream alone. Files larger than the linter's StreamThreshold are not
+// parsed into a tree; only token rules check them, through a
+// TokenChecker created for each file and fed by parser.Stream.
+type TokenRule interface {
+	Rule
+	NewTokenChecker(filename string) TokenChecker
+}
+
+// TokenChecker checks the tokens of one file in source order. The token
+// passed to Token is reused afterwards and must not be retained.
+type TokenChecker interface {
+	Token(tok *parser.Token)
+	Results() []Result
+}
+
+// ProjectRule is implemented by rules that check relationships between
+// files, such as pages composed from layouts and partials. The linter calls
+// CheckProject once per LintFiles run with every linted file; Check may
+// return nil.
+type ProjectRule interface {
+	Rule
+	CheckProject(files []SourceFile) []Result
+}
+
 // Registry holds all available rules.
 type Registry struct {
 	rules []Rule
@@ -162,8 +302,10 @@ func NewRegistry() *Registry {
 			&InputLabel{},
 			&ButtonName{},
 			&LinkName{},
+			&LinkPurpose{},
 			&HeadingContent{},
 			&HeadingLevel{},
+			&HeadingAnchor{},
 			&EmptyTitle{},
 			// Accessibility - ARIA
 			&PreferAria{},
@@ -181,20 +323,36 @@ func NewRegistry() *Registry {
 			// Accessibility - focus/navigation
 			&TabindexNoPositive{},
 			&SVGFocusable{},
+			&CompositeWidget{},
+			&NavSemantics{},
+			&TargetSize{},
 			// Accessibility - media
 			&NoAutoplay{},
 			&MetaRefresh{},
+			&FallbackContent{},
+			&MotionSafety{},
+			&TextResize{},
 			// Best practices
 			&PreferSemantic{},
 			&DuplicateID{},
 			&PreferButton{},
 			&NoInlineStyle{},
+			&BannedMarkup{},
+			&RequiredAttributes{},
+			&PreferComponent{},
+			&PreformattedIndent{},
+			&InlineWhitespace{},
+			&DirConsistency{},
+			&SrcsetDescriptors{},
+			&NoCommentedMarkup{},
+			&MinifiedFile{},
 			// SEO
 			&LongTitle{},
 			// Security
 			&RequireSRI{},
 			// Document structure
 			&NoMultipleMain{},
+			&PageStructure{},
 			&ValidID{},
 			&RequireLang{},
 			&PreferNativeElement{},
@@ -213,15 +371,22 @@ func NewRegistry() *Registry {
 			&FormDupName{},
 			&MapDupName{},
 			&MapIDName{},
+			&SlotName{},
 			&ElementName{},
 			&ScriptType{},
 			&ValidAutocomplete{},
+			&InputValueFormat{},
+			&HandlerSyntax{},
+			&DataJSON{},
 			&ValidFor{},
 			&UnrecognizedCharRef{},
+			&TypographicChars{},
 			// Deprecated rules
 			&Deprecated{},
 			&NoDeprecatedAttr{},
 			&NoConditionalComment{},
+			&NoXHTMLSyntax{},
+			&CommentSyntax{},
 			// Content model rules
 			&VoidContent{},
 			&ElementRequiredAncestor{},
@@ -231,18 +396,37 @@ func NewRegistry() *Registry {
 			&ElementPermittedOccurrences{},
 			&ElementRequiredContent{},
 			&ElementPermittedOrder{},
+			&PictureSource{},
+			&MediaSource{},
+			&MediaQuery{},
+			&MathMLStructure{},
 			&AttributeAllowedValues{},
+			&AriaAllowedValues{},
 			&AttributeMisuse{},
 			&InputAttributes{},
 			&ScriptElement{},
 			// Document structure rules
 			&DoctypeHTML{},
 			&MissingDoctype{},
+			&NoQuirksMode{},
 			&NoUTF8BOM{},
+			&RequireUTF8{},
+			&NoMojibake{},
 			&NoMissingReferences{},
+			&AriaRelationship{},
 			&AllowedLinks{},
+			&ValidContactLink{},
+			&URLEncoding{},
+			&AssetExists{},
+			&SVGUseReference{},
 			// Security rules
 			&RequireCSPNonce{},
+			&CSPCompatible{},
+			&FormCSRFToken{},
+			&SensitiveURLData{},
+			&NoEnvironmentURL{},
+			&IframeSandbox{},
+			&IframeRequireSandbox{},
 			// Style rules
 			&NoStyleTag{},
 			&PreferTbody{},
@@ -252,9 +436,22 @@ func NewRegistry() *Registry {
 			&NamePattern{},
 			// htmx rules
 			&HTMXAttributes{},
+			&HTMXPartial{},
+			&FrameworkRemnants{},
 			// Template rules
 			&TemplateWhitespaceTrim{},
 			&TemplateSyntaxValid{},
+			&TemplateActionPlacement{},
+			&TemplateEscapingContext{},
+			&NoDupScript{},
+			&TemplateReferences{},
+			&TemplateCallData{},
+			&NoHardcodedText{},
+			// Maintainability rules (opt-in)
+			&DOMSize{},
+			&ResourceHints{},
+			&NoDebugArtifacts{},
+			&NoUnrenderedPlaceholder{},
 		},
 	}
 }
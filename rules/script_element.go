@@ -41,12 +41,13 @@ func (r *ScriptElement) Check(doc *parser.Document) []Result {
 		// For module scripts, async is allowed but defer is ignored
 		if hasAsync && hasDefer && scriptType != "module" {
 			results = append(results, Result{
-				Rule:     RuleScriptElement,
-				Message:  "script should not have both async and defer",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Warning,
+				Rule:      RuleScriptElement,
+				Message:   catalogMessage("script-element.async-defer", nil),
+				MessageID: "script-element.async-defer",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
 			})
 		}
 
@@ -54,22 +55,24 @@ func (r *ScriptElement) Check(doc *parser.Document) []Result {
 		if !hasSrc {
 			if hasAsync {
 				results = append(results, Result{
-					Rule:     RuleScriptElement,
-					Message:  "async attribute requires src attribute",
-					Filename: doc.Filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Error,
+					Rule:      RuleScriptElement,
+					Message:   catalogMessage("script-element.async-no-src", nil),
+					MessageID: "script-element.async-no-src",
+					Filename:  doc.Filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Error,
 				})
 			}
 			if hasDefer {
 				results = append(results, Result{
-					Rule:     RuleScriptElement,
-					Message:  "defer attribute requires src attribute",
-					Filename: doc.Filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Error,
+					Rule:      RuleScriptElement,
+					Message:   catalogMessage("script-element.defer-no-src", nil),
+					MessageID: "script-element.defer-no-src",
+					Filename:  doc.Filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Error,
 				})
 			}
 		}
@@ -77,12 +80,13 @@ func (r *ScriptElement) Check(doc *parser.Document) []Result {
 		// nomodule only makes sense for classic scripts
 		if hasNomodule && scriptType == "module" {
 			results = append(results, Result{
-				Rule:     RuleScriptElement,
-				Message:  "nomodule attribute should not be on module scripts",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Warning,
+				Rule:      RuleScriptElement,
+				Message:   catalogMessage("script-element.nomodule-module", nil),
+				MessageID: "script-element.nomodule-module",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
 			})
 		}
 
@@ -92,12 +96,13 @@ func (r *ScriptElement) Check(doc *parser.Document) []Result {
 			for _, child := range n.Children {
 				if child.Type == html.TextNode && strings.TrimSpace(child.Data) != "" {
 					results = append(results, Result{
-						Rule:     RuleScriptElement,
-						Message:  "script with src should not have inline content",
-						Filename: doc.Filename,
-						Line:     n.Line,
-						Col:      n.Col,
-						Severity: Warning,
+						Rule:      RuleScriptElement,
+						Message:   catalogMessage("script-element.src-content", nil),
+						MessageID: "script-element.src-content",
+						Filename:  doc.Filename,
+						Line:      n.Line,
+						Col:       n.Col,
+						Severity:  Warning,
 					})
 					break
 				}
@@ -109,13 +114,16 @@ func (r *ScriptElement) Check(doc *parser.Document) []Result {
 			// Unknown type acts as data block (valid but unusual)
 			// Only warn for non-MIME type values that might be mistakes
 			if !strings.Contains(scriptType, "/") {
+				params := map[string]any{"type": scriptType}
 				results = append(results, Result{
-					Rule:     RuleScriptElement,
-					Message:  "unknown script type: " + scriptType,
-					Filename: doc.Filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Info,
+					Rule:      RuleScriptElement,
+					Message:   catalogMessage("script-element.unknown-type", params),
+					MessageID: "script-element.unknown-type",
+					Params:    params,
+					Filename:  doc.Filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Info,
 				})
 			}
 		}
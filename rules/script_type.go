@@ -41,13 +41,16 @@ func (r *ScriptType) Check(doc *parser.Document) []Result {
 
 		// Check if it's a valid type
 		if !ValidScriptTypes[scriptType] {
+			params := map[string]any{"type": n.GetAttr("type")}
 			results = append(results, Result{
-				Rule:     RuleScriptType,
-				Message:  "invalid script type: " + n.GetAttr("type"),
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Warning,
+				Rule:      RuleScriptType,
+				Message:   catalogMessage("script-type.invalid", params),
+				MessageID: "script-type.invalid",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
 			})
 		}
 
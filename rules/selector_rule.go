@@ -0,0 +1,90 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+)
+
+// SelectorRule is a declarative rule defined in configuration rather than
+// Go code. Elements matching the selector are reported; when Require or
+// Forbid list attributes, only matching elements missing a required
+// attribute or carrying a forbidden one are.
+type SelectorRule struct {
+	name     string
+	selector *parser.Selector
+	source   string // selector text, for messages
+
+	Require  []string
+	Forbid   []string
+	Message  string // reported instead of the generated message when set
+	Severity Severity
+}
+
+// NewSelectorRule compiles a declarative rule named name.
+func NewSelectorRule(name, selector string) (*SelectorRule, error) {
+	if strings.TrimSpace(selector) == "" {
+		return nil, fmt.Errorf("custom rule %q: selector is required", name)
+	}
+	sel, err := parser.CompileSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("custom rule %q: %w", name, err)
+	}
+	return &SelectorRule{name: name, selector: sel, source: selector, Severity: Error}, nil
+}
+
+// Name returns the rule identifier.
+func (r *SelectorRule) Name() string { return r.name }
+
+// Description returns what this rule checks.
+func (r *SelectorRule) Description() string {
+	if r.Message != "" {
+		return r.Message
+	}
+	return "custom rule for " + r.source
+}
+
+// Check reports elements matching the selector that fail the attribute
+// assertions.
+func (r *SelectorRule) Check(doc *parser.Document) []Result {
+	var results []Result
+	report := func(line, col int, id string, params map[string]any) {
+		if r.Message != "" {
+			id, params = "selector-rule.message", map[string]any{"message": r.Message}
+		}
+		results = append(results, Result{
+			Rule:      r.name,
+			Message:   catalogMessage(id, params),
+			MessageID: id,
+			Params:    params,
+			Line:      line,
+			Col:       col,
+			Severity:  r.Severity,
+		})
+	}
+
+	doc.Walk(func(n *parser.Node) bool {
+		if !r.selector.Match(n) {
+			return true
+		}
+		if len(r.Require) == 0 && len(r.Forbid) == 0 {
+			report(n.Line, n.Col, "selector-rule.match", map[string]any{"element": n.Data, "selector": r.source})
+			return true
+		}
+		for _, attr := range r.Require {
+			if !n.HasAttr(attr) {
+				report(n.Line, n.Col, "selector-rule.require", map[string]any{"element": n.Data, "attr": attr})
+			}
+		}
+		for _, attr := range r.Forbid {
+			if n.HasAttr(attr) {
+				line, col := n.AttrPos(attr)
+				report(line, col, "selector-rule.forbid", map[string]any{"element": n.Data, "attr": attr})
+			}
+		}
+		return true
+	})
+
+	return results
+}
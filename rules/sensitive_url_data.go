@@ -0,0 +1,109 @@
+package rules
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// defaultSensitivePattern matches field names that usually carry secrets.
+var defaultSensitivePattern = regexp.MustCompile(`(?i)passw|passcode|token|secret|ssn|api.?key|credit.?card|card.?number|cvv|cvc`)
+
+// SensitiveURLData warns about sensitive fields submitted with GET, whose
+// values end up in the URL, browser history, and server logs. A form is
+// sent with GET when its method is missing or "get" and htmx does not
+// submit it with another method; an element with hx-get sends its own
+// value. Password inputs are always sensitive; other fields are matched
+// by name against the "pattern" option.
+type SensitiveURLData struct {
+	Pattern *regexp.Regexp
+}
+
+// Name returns the rule identifier.
+func (r *SensitiveURLData) Name() string { return RuleSensitiveURLData }
+
+// Description returns what this rule checks.
+func (r *SensitiveURLData) Description() string {
+	return "sensitive fields should not be submitted in URLs"
+}
+
+// ConfigureOptions applies the pattern option. An invalid pattern leaves
+// the current one in place.
+func (r *SensitiveURLData) ConfigureOptions(opts map[string]any) {
+	if p := StringOption(opts, "pattern", ""); p != "" {
+		if re, err := regexp.Compile(p); err == nil {
+			r.Pattern = re
+		}
+	}
+}
+
+// Check examines GET forms and hx-get controls for sensitive fields.
+func (r *SensitiveURLData) Check(doc *parser.Document) []Result {
+	var results []Result
+
+	pattern := r.Pattern
+	if pattern == nil {
+		pattern = defaultSensitivePattern
+	}
+
+	report := func(n *parser.Node, how string) {
+		params := map[string]any{"name": n.GetAttr("name"), "by": how}
+		results = append(results, Result{
+			Rule:      RuleSensitiveURLData,
+			Message:   catalogMessage("sensitive-url-data.get", params),
+			MessageID: "sensitive-url-data.get",
+			Params:    params,
+			Filename:  doc.Filename,
+			Line:      n.Line,
+			Col:       n.Col,
+			Severity:  Warning,
+		})
+	}
+
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+
+		if TagEquals(n, "form") && isGetForm(n) {
+			for _, field := range n.QuerySelectorAll("input, select, textarea") {
+				if isSensitiveField(field, pattern) {
+					report(field, "a GET form")
+				}
+			}
+			return true
+		}
+
+		if n.HasAttr("hx-get") && TagIn(n, "input", "select", "textarea") && isSensitiveField(n, pattern) {
+			report(n, "hx-get")
+		}
+		return true
+	})
+
+	return results
+}
+
+// isGetForm reports whether a form is submitted with GET.
+func isGetForm(form *parser.Node) bool {
+	for _, attr := range htmxWriteAttrs {
+		if form.HasAttr(attr) {
+			return false
+		}
+	}
+	if form.HasAttr("hx-get") {
+		return true
+	}
+	method := strings.ToLower(strings.TrimSpace(form.GetAttr("method")))
+	return method == "" || method == "get"
+}
+
+// isSensitiveField reports whether a named form control holds a secret.
+func isSensitiveField(n *parser.Node, pattern *regexp.Regexp) bool {
+	name := n.GetAttr("name")
+	if name == "" || IsTemplateExpr(name) || n.HasAttr("disabled") {
+		return false
+	}
+	return strings.EqualFold(n.GetAttr("type"), "password") || pattern.MatchString(name)
+}
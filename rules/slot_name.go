@@ -0,0 +1,96 @@
+package rules
+
+import (
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// SlotName checks that slot="" attributes on light DOM children refer to a
+// named <slot> in the host's declarative shadow root, and that slot names
+// within a shadow root are unique. Hosts whose shadow tree is not in the
+// file (e.g. attached by script) are skipped.
+type SlotName struct{}
+
+// Name returns the rule identifier.
+func (r *SlotName) Name() string { return RuleSlotName }
+
+// Description returns what this rule checks.
+func (r *SlotName) Description() string {
+	return "slot attributes must match a named slot in the declarative shadow root"
+}
+
+// Check examines hosts with a <template shadowrootmode> child.
+func (r *SlotName) Check(doc *parser.Document) []Result {
+	var results []Result
+
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+		root := shadowRoot(n)
+		if root == nil {
+			return true
+		}
+
+		slots := make(map[string]bool)
+		for _, slot := range root.QuerySelectorAll("slot") {
+			name := slot.GetAttr("name")
+			if name == "" || IsTemplateExpr(name) {
+				continue
+			}
+			if slots[name] {
+				line, col := slot.AttrPos("name")
+				params := map[string]any{"name": name}
+				results = append(results, Result{
+					Rule:      RuleSlotName,
+					Message:   catalogMessage("slot-name.repeat", params),
+					MessageID: "slot-name.repeat",
+					Params:    params,
+					Filename:  doc.Filename,
+					Line:      line,
+					Col:       col,
+					Severity:  Warning,
+				})
+				continue
+			}
+			slots[name] = true
+		}
+
+		for _, child := range n.Children {
+			if child.Type != html.ElementNode || child == root || !child.HasAttr("slot") {
+				continue
+			}
+			name := child.GetAttr("slot")
+			if name == "" || IsTemplateExpr(name) || slots[name] {
+				continue
+			}
+			line, col := child.AttrPos("slot")
+			params := map[string]any{"name": name, "element": n.Data}
+			results = append(results, Result{
+				Rule:      RuleSlotName,
+				Message:   catalogMessage("slot-name.missing", params),
+				MessageID: "slot-name.missing",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      line,
+				Col:       col,
+				Severity:  Warning,
+			})
+		}
+
+		return true
+	})
+
+	return results
+}
+
+// shadowRoot returns the declarative shadow root template of host, or nil.
+// Only the first such template attaches a shadow root.
+func shadowRoot(host *parser.Node) *parser.Node {
+	for _, child := range host.Children {
+		if child.Type == html.ElementNode && child.IsElement("template") && child.HasAttr("shadowrootmode") {
+			return child
+		}
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package rules
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// SrcsetDescriptors checks that srcset candidates on <img> and <source>
+// use descriptors the browser can select between: one kind per srcset,
+// sizes only alongside width descriptors, and an <img> src that is also
+// one of its candidates so the fallback matches what srcset browsers load.
+type SrcsetDescriptors struct{}
+
+// Name returns the rule identifier.
+func (r *SrcsetDescriptors) Name() string { return RuleSrcsetDescriptors }
+
+// Description returns what this rule checks.
+func (r *SrcsetDescriptors) Description() string {
+	return "srcset must not mix width and density descriptors, and sizes needs width descriptors"
+}
+
+// srcsetCandidate is one image candidate string of a srcset attribute.
+type srcsetCandidate struct {
+	URL        string
+	Descriptor string // e.g. "480w" or "2x"; empty means 1x
+}
+
+// Check examines img and source elements with a srcset attribute.
+func (r *SrcsetDescriptors) Check(doc *parser.Document) []Result {
+	var results []Result
+
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.ElementNode || !TagIn(n, "img", "source") || !n.HasAttr("srcset") {
+			return true
+		}
+		report := func(attr, id string, params map[string]any) {
+			line, col := n.AttrPos(attr)
+			results = append(results, Result{
+				Rule:      RuleSrcsetDescriptors,
+				Message:   catalogMessage(id, params),
+				MessageID: id,
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      line,
+				Col:       col,
+				Severity:  Warning,
+			})
+		}
+
+		candidates := parseSrcset(n.GetAttr("srcset"))
+		var width, density int
+		for _, c := range candidates {
+			switch srcsetDescriptorKind(c.Descriptor) {
+			case 'w':
+				width++
+			case 'x':
+				density++
+			case 0:
+				if !IsTemplateExpr(c.Descriptor) {
+					report("srcset", "srcset-descriptors.invalid", map[string]any{"descriptor": c.Descriptor, "url": c.URL})
+				}
+			}
+		}
+
+		if width > 0 && density > 0 {
+			report("srcset", "srcset-descriptors.mixed", nil)
+		}
+		if n.HasAttr("sizes") && width == 0 && len(candidates) > 0 {
+			report("sizes", "srcset-descriptors.sizes", nil)
+		}
+
+		src := strings.TrimSpace(n.GetAttr("src"))
+		if n.IsElement("img") && src != "" && !IsTemplateExpr(src) && len(candidates) > 0 {
+			found := false
+			for _, c := range candidates {
+				if c.URL == src || IsTemplateExpr(c.URL) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				report("src", "srcset-descriptors.src", map[string]any{"src": src})
+			}
+		}
+
+		return true
+	})
+
+	return results
+}
+
+// srcsetDescriptorKind returns 'w' or 'x' for a valid width or density
+// descriptor (an empty descriptor is 1x), 'h' for a height descriptor, or
+// 0 if it is malformed.
+func srcsetDescriptorKind(d string) byte {
+	if d == "" {
+		return 'x'
+	}
+	d = strings.ToLower(d)
+	unit := d[len(d)-1]
+	num := d[:len(d)-1]
+	switch unit {
+	case 'w', 'h':
+		if n, err := strconv.Atoi(num); err == nil && n > 0 {
+			return unit
+		}
+	case 'x':
+		if f, err := strconv.ParseFloat(num, 64); err == nil && f > 0 {
+			return unit
+		}
+	}
+	return 0
+}
+
+// parseSrcset splits a srcset value into candidates following the HTML
+// parsing algorithm: URLs end at whitespace, so commas inside a URL (as
+// in data: URLs) are kept, while trailing commas end the candidate.
+func parseSrcset(value string) []srcsetCandidate {
+	var candidates []srcsetCandidate
+	i := 0
+	for i < len(value) {
+		for i < len(value) && (parser.IsSpace(value[i]) || value[i] == ',') {
+			i++
+		}
+		if i >= len(value) {
+			break
+		}
+		start := i
+		for i < len(value) && !parser.IsSpace(value[i]) {
+			i++
+		}
+		url := value[start:i]
+		if trimmed := strings.TrimRight(url, ","); trimmed != url {
+			candidates = append(candidates, srcsetCandidate{URL: trimmed})
+			continue
+		}
+
+		start = i
+		depth := 0
+		for i < len(value) && (depth > 0 || value[i] != ',') {
+			switch value[i] {
+			case '(':
+				depth++
+			case ')':
+				depth--
+			}
+			i++
+		}
+		candidates = append(candidates, srcsetCandidate{
+			URL:        url,
+			Descriptor: strings.TrimSpace(value[start:i]),
+		})
+	}
+	return candidates
+}
@@ -59,12 +59,13 @@ func (r *SVGFocusable) checkSVG(n *parser.Node, filename string, results *[]Resu
 		focusable := n.GetAttr("focusable")
 		if focusable != "false" {
 			*results = append(*results, Result{
-				Rule:     r.Name(),
-				Message:  "SVG inside interactive element should have focusable=\"false\"",
-				Filename: filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Warning,
+				Rule:      r.Name(),
+				Message:   catalogMessage("svg-focusable.missing", nil),
+				MessageID: "svg-focusable.missing",
+				Filename:  filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
 			})
 		}
 		return
@@ -0,0 +1,174 @@
+package rules
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// SVGUseReference checks that <use href="#icon-x"> points at an element,
+// usually a <symbol>, that exists, catching missing icons after a sprite
+// is regenerated. Same-document references are checked against the
+// document's ids and the sprite files listed in the "sprites" option,
+// which covers sprites inlined by a layout; without sprites they are only
+// checked in documents that define a <symbol> themselves. References into
+// a sprite file ("/img/icons.svg#icon-x") are checked against that file
+// when it exists; asset-exists reports sprite files that do not.
+//
+// Sprite URLs resolve like asset-exists URLs, with the same "root" and
+// "rewrites" options.
+type SVGUseReference struct {
+	Sprites []string
+
+	assets    AssetExists
+	spriteIDs map[string]map[string]bool // file path -> ids; nil for unreadable files
+}
+
+// Name returns the rule identifier.
+func (r *SVGUseReference) Name() string { return RuleSVGUseReference }
+
+// Description returns what this rule checks.
+func (r *SVGUseReference) Description() string {
+	return "<use> must reference a defined symbol"
+}
+
+// ConfigureOptions applies the sprites, root, and rewrites options.
+func (r *SVGUseReference) ConfigureOptions(opts map[string]any) {
+	r.Sprites = StringsOption(opts, "sprites", r.Sprites)
+	r.assets.ConfigureOptions(opts)
+}
+
+// ConfigureRewriters sets rewriters applied after those from options.
+func (r *SVGUseReference) ConfigureRewriters(rewriters []URLRewriter) {
+	r.assets.ConfigureRewriters(rewriters)
+}
+
+// Check examines <use> references.
+func (r *SVGUseReference) Check(doc *parser.Document) []Result {
+	var uses []*parser.Node
+	ids := make(map[string]bool)
+	hasSymbol := false
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+		if id := n.GetAttr("id"); id != "" {
+			ids[id] = true
+		}
+		switch Tag(n) {
+		case "symbol":
+			hasSymbol = true
+		case "use":
+			uses = append(uses, n)
+		}
+		return true
+	})
+	if len(uses) == 0 {
+		return nil
+	}
+
+	// Ids of the configured sprites count as defined in every document.
+	var spriteIDs []map[string]bool
+	for _, sprite := range r.Sprites {
+		if path, ok := r.assets.localPath(doc.Filename, sprite); ok {
+			spriteIDs = append(spriteIDs, r.idsIn(path))
+		}
+	}
+	checkLocal := hasSymbol || len(r.Sprites) > 0
+
+	var results []Result
+	for _, n := range uses {
+		// The parser stores xlink:href under the key href too.
+		ref := strings.TrimSpace(n.GetAttr("href"))
+		file, id, ok := strings.Cut(ref, "#")
+		if !ok || id == "" || IsTemplateExpr(ref) {
+			continue
+		}
+
+		var m message
+		if file == "" {
+			if !checkLocal || ids[id] || definedIn(spriteIDs, id) {
+				continue
+			}
+			m = message{"svg-use-reference.local", map[string]any{"id": id}}
+			if len(r.Sprites) > 0 {
+				m.id = "svg-use-reference.local-sprites"
+			}
+		} else {
+			path, ok := r.assets.localPath(doc.Filename, file)
+			if !ok {
+				continue
+			}
+			fileIDs := r.idsIn(path)
+			if fileIDs == nil || fileIDs[id] {
+				continue // missing sprites are reported by asset-exists
+			}
+			m = message{"svg-use-reference.file", map[string]any{"id": id, "file": file}}
+		}
+
+		line, col := n.AttrPos("href")
+		if line == n.Line && col == n.Col {
+			line, col = n.AttrPos("xlink:href")
+		}
+		results = append(results, Result{
+			Rule:      RuleSVGUseReference,
+			Message:   m.text(),
+			MessageID: m.id,
+			Params:    m.params,
+			Filename:  doc.Filename,
+			Line:      line,
+			Col:       col,
+			Severity:  Error,
+		})
+	}
+	return results
+}
+
+func definedIn(sets []map[string]bool, id string) bool {
+	for _, ids := range sets {
+		if ids[id] {
+			return true
+		}
+	}
+	return false
+}
+
+// idsIn returns the element ids of the SVG or HTML file at path, or nil
+// if it cannot be read. Files are read once per rule instance.
+func (r *SVGUseReference) idsIn(path string) map[string]bool {
+	if ids, ok := r.spriteIDs[path]; ok {
+		return ids
+	}
+	if r.spriteIDs == nil {
+		r.spriteIDs = make(map[string]map[string]bool)
+	}
+
+	var ids map[string]bool
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err == nil {
+		ids = make(map[string]bool)
+		z := html.NewTokenizer(bytes.NewReader(content))
+		for {
+			tt := z.Next()
+			if tt == html.ErrorToken {
+				break
+			}
+			if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+				continue
+			}
+			for _, more := z.TagName(); more; {
+				var key, val []byte
+				key, val, more = z.TagAttr()
+				if string(key) == "id" {
+					ids[string(val)] = true
+				}
+			}
+		}
+	}
+	r.spriteIDs[path] = ids
+	return ids
+}
@@ -25,6 +25,7 @@ func (r *TabindexNoPositive) Check(doc *parser.Document) []Result {
 		}
 
 		tabindex := n.GetAttr("tabindex")
+		line, col := n.AttrPos("tabindex")
 		if tabindex == "" {
 			return true
 		}
@@ -38,12 +39,13 @@ func (r *TabindexNoPositive) Check(doc *parser.Document) []Result {
 
 		if val > 0 {
 			results = append(results, Result{
-				Rule:     r.Name(),
-				Message:  "positive tabindex disrupts natural tab order; use 0 or -1",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Error,
+				Rule:      r.Name(),
+				Message:   catalogMessage("tabindex-no-positive.positive", nil),
+				MessageID: "tabindex-no-positive.positive",
+				Filename:  doc.Filename,
+				Line:      line,
+				Col:       col,
+				Severity:  Error,
 			})
 		}
 
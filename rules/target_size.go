@@ -0,0 +1,147 @@
+package rules
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// minTargetSize is the minimum target size of WCAG 2.5.8 in CSS pixels.
+const minTargetSize = 24
+
+// wcagTargetSize is the WCAG success criterion reported by TargetSize.
+const wcagTargetSize = "2.5.8"
+
+// interactiveRoles are widget roles whose elements are pointer targets.
+var interactiveRoles = map[string]bool{
+	"button": true, "link": true, "checkbox": true, "radio": true,
+	"switch": true, "tab": true, "menuitem": true, "menuitemcheckbox": true,
+	"menuitemradio": true, "option": true, "slider": true, "spinbutton": true,
+}
+
+// TargetSize warns when the width or height an interactive element is
+// given directly, by attribute or inline style, is below 24 CSS pixels
+// (WCAG 2.5.8 Target Size (Minimum)). Sizes from style sheets, padding,
+// and content are unknown, so only explicit dimensions are checked; a
+// min-width or min-height of at least 24px satisfies its axis.
+type TargetSize struct{}
+
+// Name returns the rule identifier.
+func (r *TargetSize) Name() string { return RuleTargetSize }
+
+// Description returns what this rule checks.
+func (r *TargetSize) Description() string {
+	return "interactive elements with explicit dimensions must be at least 24×24 CSS pixels"
+}
+
+// Check examines interactive elements with width or height set inline.
+func (r *TargetSize) Check(doc *parser.Document) []Result {
+	var results []Result
+
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.ElementNode || !isPointerTarget(n) {
+			return true
+		}
+
+		width, widthAttr := explicitSize(n, "width")
+		height, heightAttr := explicitSize(n, "height")
+		var size, attr string
+		switch {
+		case width >= 0 && width < minTargetSize && height >= 0 && height < minTargetSize:
+			size, attr = fmt.Sprintf("%g×%g CSS pixels", width, height), widthAttr
+		case width >= 0 && width < minTargetSize:
+			size, attr = fmt.Sprintf("%g CSS pixels wide", width), widthAttr
+		case height >= 0 && height < minTargetSize:
+			size, attr = fmt.Sprintf("%g CSS pixels tall", height), heightAttr
+		default:
+			return true
+		}
+
+		line, col := n.AttrPos(attr)
+		params := map[string]any{"element": n.Data, "size": size, "min": minTargetSize}
+		results = append(results, Result{
+			Rule:      RuleTargetSize,
+			Message:   catalogMessage("target-size.small", params),
+			MessageID: "target-size.small",
+			Params:    params,
+			Filename:  doc.Filename,
+			Line:      line,
+			Col:       col,
+			Severity:  Warning,
+			Meta:      map[string]string{MetaWCAG: wcagTargetSize},
+		})
+		return true
+	})
+
+	return results
+}
+
+// isPointerTarget reports whether n is an interactive element users click
+// or tap.
+func isPointerTarget(n *parser.Node) bool {
+	if n.HasAttr("disabled") || n.GetAttr("aria-hidden") == "true" {
+		return false
+	}
+	if interactiveRoles[explicitRole(n)] {
+		return true
+	}
+	switch strings.ToLower(n.Data) {
+	case "a":
+		return n.HasAttr("href")
+	case "button", "select", "textarea", "summary":
+		return true
+	case "input":
+		return !strings.EqualFold(n.GetAttr("type"), "hidden")
+	}
+	return false
+}
+
+// explicitSize returns n's size along dim ("width" or "height") in CSS
+// pixels and the attribute setting it, or -1 when it is not set to a
+// known length or a min-dimension of at least minTargetSize overrides it.
+// The inline style wins over the presentational attribute.
+func explicitSize(n *parser.Node, dim string) (float64, string) {
+	size, attr := -1.0, ""
+	if v := n.GetAttr(dim); v != "" {
+		if px, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+			size, attr = px, dim
+		}
+	}
+	for _, d := range styleDeclarations(n.GetAttr("style")) {
+		switch d.prop {
+		case dim:
+			if px, ok := cssPixels(d.value); ok {
+				size, attr = px, "style"
+			} else {
+				size = -1
+			}
+		case "min-" + dim:
+			if px, ok := cssPixels(d.value); ok && px >= minTargetSize {
+				return -1, ""
+			}
+		}
+	}
+	return size, attr
+}
+
+// cssPixels converts a CSS length in px, rem, or em to pixels, taking the
+// default 16px font size; other units and template values are unknown.
+func cssPixels(value string) (float64, bool) {
+	value = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(value), "!important"))
+	for _, u := range []struct {
+		suffix string
+		scale  float64
+	}{{"px", 1}, {"rem", 16}, {"em", 16}} {
+		if num, ok := strings.CutSuffix(value, u.suffix); ok {
+			f, err := strconv.ParseFloat(strings.TrimSpace(num), 64)
+			return f * u.scale, err == nil
+		}
+	}
+	if value == "0" {
+		return 0, true
+	}
+	return 0, false
+}
@@ -44,12 +44,13 @@ func (r *TelNonBreaking) Check(doc *parser.Document) []Result {
 		// Non-breaking space is \u00A0, regular space is \u0020
 		if strings.Contains(text, " ") {
 			results = append(results, Result{
-				Rule:     RuleTelNonBreaking,
-				Message:  "tel: link text contains regular spaces; use &nbsp; or CSS white-space: nowrap",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Info,
+				Rule:      RuleTelNonBreaking,
+				Message:   catalogMessage("tel-non-breaking.spaces", nil),
+				MessageID: "tel-non-breaking.spaces",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Info,
 			})
 		}
 
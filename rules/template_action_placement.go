@@ -0,0 +1,199 @@
+package rules
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// TemplateActionPlacement checks for Go template actions placed where the
+// HTML parser will mangle them: block actions that open inside one tag and
+// continue outside it, and actions that split a tag name. The preprocessor
+// assumes actions sit inside a single attribute, tag, or text run, so such
+// templates are linted against a structure they never render.
+type TemplateActionPlacement struct {
+	dialect parser.Dialect
+}
+
+// Name returns the rule identifier.
+func (r *TemplateActionPlacement) Name() string { return RuleTemplateActionPlacement }
+
+// Description returns what this rule checks.
+func (r *TemplateActionPlacement) Description() string {
+	return "template actions should not break HTML structure"
+}
+
+// Check implements Rule but returns nil - this rule uses CheckRaw instead.
+func (r *TemplateActionPlacement) Check(_ *parser.Document) []Result {
+	return nil
+}
+
+// ConfigureDialect sets the template dialect whose blocks are matched.
+func (r *TemplateActionPlacement) ConfigureDialect(d parser.Dialect) {
+	r.dialect = d
+}
+
+// htmlContext identifies where an offset falls in the HTML token stream:
+// the index of the enclosing tag or comment token, or -1 for text.
+type htmlContext int
+
+const textContext htmlContext = -1
+
+// htmlToken is a tag or comment token in the masked content.
+type htmlToken struct {
+	start, end int
+	// nameStart and nameEnd bound the tag name, which ends at the first
+	// action; both are start for comments
+	nameStart, nameEnd int
+}
+
+// CheckRaw compares each action's position with the HTML token stream.
+func (r *TemplateActionPlacement) CheckRaw(filename string, content []byte) []Result {
+	actions := r.dialect.ForFile(filename).Actions(content)
+	if len(actions) == 0 {
+		return nil
+	}
+
+	tokens := maskedTokens(content, actions)
+	contextAt := func(offset int) htmlContext {
+		i := sort.Search(len(tokens), func(i int) bool { return tokens[i].end > offset })
+		if i < len(tokens) && tokens[i].start <= offset {
+			return htmlContext(i)
+		}
+		return textContext
+	}
+
+	var results []Result
+	report := func(offset int, id string, params map[string]any) {
+		line, col := offsetPosition(content, offset)
+		results = append(results, Result{
+			Rule:      r.Name(),
+			Message:   catalogMessage(id, params),
+			MessageID: id,
+			Params:    params,
+			Filename:  filename,
+			Line:      line,
+			Col:       col,
+			Severity:  Error,
+		})
+	}
+
+	type openAction struct {
+		label string
+		ctx   htmlContext
+	}
+	var stack []openAction
+
+	for _, a := range actions {
+		ctx := contextAt(a.Start)
+
+		if ctx != textContext {
+			tok := tokens[ctx]
+			if a.Start > tok.start && a.Start == tok.nameEnd && splitsName(content, a, tok) {
+				report(a.Start, "template-action-placement.split-name", nil)
+				continue
+			}
+		}
+
+		switch a.Kind {
+		case parser.ActionOpen:
+			stack = append(stack, openAction{label: actionLabel(content, a), ctx: ctx})
+		case parser.ActionElse, parser.ActionEnd:
+			if len(stack) == 0 {
+				continue // reported by template-syntax-valid
+			}
+			opened := stack[len(stack)-1]
+			if a.Kind == parser.ActionEnd {
+				stack = stack[:len(stack)-1]
+			}
+			if opened.ctx != ctx {
+				report(a.Start, "template-action-placement.split-"+contextName(opened.ctx, tokens), map[string]any{"action": actionLabel(content, a), "open": opened.label})
+			}
+		}
+	}
+
+	return results
+}
+
+// actionLabel names an action for messages by its delimiters and first
+// word, such as {{if}}, {%endfor%}, or {{#each}}.
+func actionLabel(content []byte, a parser.Action) string {
+	word := ""
+	if f := bytes.Fields(a.Body); len(f) > 0 {
+		word = string(f[0])
+	}
+	return string(content[a.Start:a.Start+2]) + word + string(content[a.End-2:a.End])
+}
+
+// splitsName reports whether an action at the end of a tag name becomes
+// part of it: it replaces the whole name, renders a value onto it, or is
+// followed by more name characters. A block action followed by a space,
+// as in <li{{if .Active}} class="active"{{end}}>, only wraps attributes.
+func splitsName(content []byte, a parser.Action, tok htmlToken) bool {
+	if a.Start == tok.nameStart || a.Kind == parser.ActionOutput {
+		return true
+	}
+	return a.End < len(content) && !parser.IsSpace(content[a.End]) && content[a.End] != '/' && content[a.End] != '>'
+}
+
+// maskedTokens tokenizes content with every action replaced by filler of
+// the same length, so quotes and angle brackets inside actions do not
+// affect tokenization, and returns its tag and comment tokens. Tag names
+// end where the first action inside them starts.
+func maskedTokens(content []byte, actions []parser.Action) []htmlToken {
+	masked := bytes.Clone(content)
+	for _, a := range actions {
+		for i := a.Start; i < a.End; i++ {
+			if masked[i] != '\n' {
+				masked[i] = 'x'
+			}
+		}
+	}
+
+	var tokens []htmlToken
+	z := html.NewTokenizer(bytes.NewReader(masked))
+	offset := 0
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		raw := z.Raw()
+		start := offset
+		offset += len(raw)
+
+		switch tt {
+		case html.StartTagToken, html.SelfClosingTagToken, html.EndTagToken:
+			nameStart := start + 1
+			if tt == html.EndTagToken {
+				nameStart++
+			}
+			limit := offset
+			if i := sort.Search(len(actions), func(i int) bool { return actions[i].Start >= nameStart }); i < len(actions) && actions[i].Start < limit {
+				limit = actions[i].Start
+			}
+			nameEnd := nameStart
+			for nameEnd < limit && !parser.IsSpace(masked[nameEnd]) && masked[nameEnd] != '/' && masked[nameEnd] != '>' {
+				nameEnd++
+			}
+			tokens = append(tokens, htmlToken{start: start, end: offset, nameStart: nameStart, nameEnd: nameEnd})
+		case html.CommentToken, html.DoctypeToken:
+			tokens = append(tokens, htmlToken{start: start, end: offset, nameStart: start, nameEnd: start})
+		}
+	}
+	return tokens
+}
+
+// contextName names the kind of a context, "text", "comment", or "tag",
+// for message IDs.
+func contextName(ctx htmlContext, tokens []htmlToken) string {
+	if ctx == textContext {
+		return "text"
+	}
+	if tokens[ctx].nameEnd == tokens[ctx].start {
+		return "comment"
+	}
+	return "tag"
+}
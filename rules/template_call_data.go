@@ -0,0 +1,141 @@
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+)
+
+// TemplateCallData compares the data passed to each named template across
+// the linted files. A call without data runs the template with nil dot,
+// which panics or renders empty output on the first field access, so it is
+// reported when other calls of the same template pass data. Calls passing
+// "." where most pass a field (or the reverse) are reported as hints,
+// since the template likely expects one specific type.
+type TemplateCallData struct{}
+
+// Name returns the rule identifier.
+func (r *TemplateCallData) Name() string { return RuleTemplateCallData }
+
+// Description returns what this rule checks.
+func (r *TemplateCallData) Description() string {
+	return "calls of the same template should pass data of the same shape"
+}
+
+// Check is a no-op; calls are compared by CheckProject.
+func (r *TemplateCallData) Check(_ *parser.Document) []Result {
+	return nil
+}
+
+// callShape classifies the data argument of a template call.
+type callShape int
+
+const (
+	shapeNone  callShape = iota // no argument: dot is nil
+	shapeDot                    // "."
+	shapeField                  // ".Field", "$.Field", "$x.Field"
+	shapeOther                  // variables, function calls, literals
+)
+
+func shapeOf(arg string) callShape {
+	switch {
+	case arg == "":
+		return shapeNone
+	case arg == ".":
+		return shapeDot
+	case len(arg) > 1 && arg[0] == '.' && isIdentStart(arg[1]),
+		arg[0] == '$' && strings.Contains(arg, ".") && !strings.ContainsAny(arg, " ("):
+		return shapeField
+	default:
+		return shapeOther
+	}
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// CheckProject reports calls whose data shape differs from the others.
+func (r *TemplateCallData) CheckProject(files []SourceFile) []Result {
+	var results []Result
+	graph := NewTemplateGraph(files)
+
+	type call struct {
+		tmpl *Template
+		TemplateCall
+	}
+	byName := make(map[string][]call)
+	var names []string
+	for _, t := range graph.all() {
+		for _, c := range t.Calls {
+			if _, ok := byName[c.Name]; !ok {
+				names = append(names, c.Name)
+			}
+			byName[c.Name] = append(byName[c.Name], call{t, c})
+		}
+	}
+
+	where := func(c call) string {
+		line, _ := c.tmpl.Position(c.Offset)
+		return fmt.Sprintf("%s:%d", filepath.ToSlash(c.tmpl.Filename), line)
+	}
+	report := func(c call, id string, params map[string]any, sev Severity) {
+		line, col := c.tmpl.Position(c.Offset)
+		results = append(results, Result{
+			Rule:      RuleTemplateCallData,
+			Message:   catalogMessage(id, params),
+			MessageID: id,
+			Params:    params,
+			Filename:  c.tmpl.Filename,
+			Line:      line,
+			Col:       col,
+			Severity:  sev,
+		})
+	}
+
+	for _, name := range names {
+		calls := byName[name]
+
+		var withData *call
+		counts := make(map[callShape]int)
+		for i := range calls {
+			shape := shapeOf(calls[i].Arg)
+			counts[shape]++
+			if shape != shapeNone && withData == nil {
+				withData = &calls[i]
+			}
+		}
+		if withData == nil {
+			continue
+		}
+
+		// Prefer the majority of dot and field calls; on a tie, the shape
+		// of the first call with data.
+		want := shapeOf(withData.Arg)
+		if counts[shapeDot] > counts[shapeField] {
+			want = shapeDot
+		} else if counts[shapeField] > counts[shapeDot] {
+			want = shapeField
+		}
+
+		for _, c := range calls {
+			switch shape := shapeOf(c.Arg); {
+			case shape == shapeNone:
+				report(c, "template-call-data.no-data", map[string]any{"template": name, "arg": withData.Arg, "where": where(*withData)}, Warning)
+			case (shape == shapeDot || shape == shapeField) && (want == shapeDot || want == shapeField) && shape != want:
+				var other call
+				for _, o := range calls {
+					if shapeOf(o.Arg) == want {
+						other = o
+						break
+					}
+				}
+				report(c, "template-call-data.shape", map[string]any{"template": name, "arg": c.Arg, "other": other.Arg, "where": where(other)}, Info)
+			}
+		}
+	}
+
+	return results
+}
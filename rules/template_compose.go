@@ -0,0 +1,210 @@
+package rules
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/toba/go-html-validate/parser"
+)
+
+// ComposedPage is the source of a page assembled from templates: the
+// entry's body with each {{template}} and {{block}} call replaced by the
+// body of the definition it resolves to.
+type ComposedPage struct {
+	Entry  *Template
+	Source []byte
+	// Complete is false when a call could not be resolved, so part of
+	// the page is missing
+	Complete bool
+
+	pieces []composedPiece // in Source order
+}
+
+// composedPiece maps Source from offset up to the next piece to tmpl's
+// source starting at orig.
+type composedPiece struct {
+	offset, orig int
+	tmpl         *Template
+}
+
+// Compose assembles the page entry renders when composed from the file
+// ctx (see Resolve). Recursive calls are cut short. It returns nil when
+// entry's file was compacted; calls into compacted files leave the page
+// incomplete.
+func (g *TemplateGraph) Compose(entry *Template, ctx string) *ComposedPage {
+	if entry.Source == nil {
+		return nil
+	}
+	page := &ComposedPage{Entry: entry, Complete: true}
+	active := make(map[*Template]bool)
+
+	var visit func(t *Template)
+	visit = func(t *Template) {
+		active[t] = true
+		defer delete(active, t)
+
+		calls := t.Calls
+		for _, span := range t.Spans {
+			pos := span[0]
+			// a {{block}} call sits at the end of the span it interrupts
+			for len(calls) > 0 && calls[0].Offset <= span[1] {
+				call := calls[0]
+				calls = calls[1:]
+				if call.Offset < pos {
+					continue
+				}
+				page.copy(t, pos, call.Offset)
+				pos = call.Offset
+				if m := templateActionBounds.FindIndex(t.Source[call.Offset:]); m != nil && m[0] == 0 {
+					pos += m[1]
+				}
+				def := g.Resolve(call.Name, ctx)
+				switch {
+				case def == nil || def.Source == nil:
+					page.Complete = false
+				case !active[def]:
+					visit(def)
+				}
+			}
+			page.copy(t, pos, span[1])
+		}
+	}
+	visit(entry)
+
+	return page
+}
+
+// copy appends t.Source[start:end] to the page.
+func (p *ComposedPage) copy(t *Template, start, end int) {
+	if start >= end {
+		return
+	}
+	p.pieces = append(p.pieces, composedPiece{offset: len(p.Source), orig: start, tmpl: t})
+	p.Source = append(p.Source, t.Source[start:end]...)
+}
+
+// Position returns the file, line, and column that offset in Source was
+// copied from.
+func (p *ComposedPage) Position(offset int) (filename string, line, col int) {
+	i := sort.Search(len(p.pieces), func(i int) bool { return p.pieces[i].offset > offset }) - 1
+	if i < 0 {
+		return p.Entry.Filename, 1, 1
+	}
+	piece := p.pieces[i]
+	line, col = piece.tmpl.Position(piece.orig + offset - piece.offset)
+	return piece.tmpl.Filename, line, col
+}
+
+// Files returns the files the page was composed from.
+func (p *ComposedPage) Files() []string {
+	var files []string
+	for _, piece := range p.pieces {
+		if !slices.Contains(files, piece.tmpl.Filename) {
+			files = append(files, piece.tmpl.Filename)
+		}
+	}
+	return files
+}
+
+// Pages composes every entry of the graph (see Entries), as well as files
+// that only call templates, such as a page rendering {{template "base" .}}.
+// A named entry such as a base layout is composed once per file of the
+// graph, since each page fills shared slots such as "content" itself;
+// pages with identical source are returned once.
+func (g *TemplateGraph) Pages() []*ComposedPage {
+	entries := g.Entries()
+	for _, t := range g.Files {
+		if len(t.Calls) > 0 && !slices.Contains(entries, t) {
+			entries = append(entries, t)
+		}
+	}
+
+	var pages []*ComposedPage
+	seen := make(map[string]bool)
+	for _, entry := range entries {
+		contexts := []string{entry.Filename}
+		if !slices.Contains(g.Files, entry) {
+			contexts = contexts[:0]
+			for _, f := range g.Files {
+				contexts = append(contexts, f.Filename)
+			}
+		}
+		for _, ctx := range contexts {
+			page := g.Compose(entry, ctx)
+			if page == nil || seen[string(page.Source)] {
+				continue
+			}
+			seen[string(page.Source)] = true
+			pages = append(pages, page)
+		}
+	}
+	return pages
+}
+
+// composes reports whether content takes part in template composition:
+// it defines, declares, or calls a named template.
+func composes(content []byte) bool {
+	templates := scanTemplates(SourceFile{Content: content})
+	return len(templates) > 1 || len(templates[0].Calls) > 0
+}
+
+// checkComposed runs check over each complete page composed from files,
+// so rules that look across a page, such as ID references and heading
+// order, see partials in the page that includes them. Files that take
+// part in composition but are in no complete page, such as partials of
+// an ambiguous slot, are checked alone. Findings are mapped back to the
+// file each comes from and reported once.
+func checkComposed(files []SourceFile, check func(*parser.Document) []Result) []Result {
+	graph := NewTemplateGraph(files)
+	covered := make(map[string]bool)
+	type finding struct {
+		filename, message string
+		line, col         int
+	}
+	seen := make(map[finding]bool)
+	var results []Result
+
+	report := func(r Result) {
+		key := finding{r.Filename, r.Message, r.Line, r.Col}
+		if !seen[key] {
+			seen[key] = true
+			results = append(results, r)
+		}
+	}
+
+	for _, page := range graph.Pages() {
+		// a page of one file without calls is checked with that file
+		if !page.Complete || !composes(page.Entry.Source) {
+			continue
+		}
+		doc, err := parser.ParseWithDialect(page.Entry.Filename, page.Source, parser.ModeAuto, parser.DialectGo)
+		if err != nil {
+			continue
+		}
+		lines := lineStarts(page.Source)
+		for _, r := range check(doc) {
+			offset := int(lines[min(max(r.Line, 1), len(lines))-1]) + r.Col - 1
+			r.Filename, r.Line, r.Col = page.Position(min(offset, len(page.Source)))
+			r.Fix = nil // offsets are into the composed page
+			report(r)
+		}
+		for _, f := range page.Files() {
+			covered[f] = true
+		}
+	}
+
+	for _, f := range files {
+		if covered[f.Filename] || f.Content == nil || !composes(f.Content) {
+			continue
+		}
+		doc, err := parser.ParseWithDialect(f.Filename, f.Content, parser.ModeAuto, parser.DialectGo)
+		if err != nil {
+			continue
+		}
+		for _, r := range check(doc) {
+			report(r)
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,189 @@
+package rules
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// TemplateEscapingContext flags template values rendered into script,
+// event handler, and style contexts. html/template escapes these as
+// JavaScript or CSS rather than HTML, so a value that is safe in text can
+// break the script, be replaced with ZgotmplZ, or open an injection hole
+// when the template is rendered with text/template or a trusted type.
+// With declared template functions, it also notes html, url, and attr
+// helpers rendered into attribute values, where html/template treats
+// their trusted types differently than their authors may expect.
+// This rule is advisory and opt-in.
+type TemplateEscapingContext struct {
+	funcs map[string]TemplateFunc
+}
+
+// Name returns the rule identifier.
+func (r *TemplateEscapingContext) Name() string { return RuleTemplateEscapingContext }
+
+// Description returns what this rule checks.
+func (r *TemplateEscapingContext) Description() string {
+	return "template values in script, event handler, and style contexts need care"
+}
+
+// OptIn marks the rule as disabled unless explicitly enabled.
+func (r *TemplateEscapingContext) OptIn() {}
+
+// Check implements Rule but returns nil - this rule uses CheckRaw instead.
+func (r *TemplateEscapingContext) Check(_ *parser.Document) []Result {
+	return nil
+}
+
+// ConfigureTemplateFuncs sets the declared template functions.
+func (r *TemplateEscapingContext) ConfigureTemplateFuncs(funcs map[string]TemplateFunc) {
+	r.funcs = funcs
+}
+
+// escapingAdvice is the message ID describing the safe pattern for each
+// sensitive context.
+var escapingAdvice = map[string]string{
+	"script":  "template-escaping-context.script",
+	"handler": "template-escaping-context.handler",
+	"style":   "template-escaping-context.style",
+}
+
+// CheckRaw locates output actions and reports those in a script, event
+// handler, or style context.
+func (r *TemplateEscapingContext) CheckRaw(filename string, content []byte) []Result {
+	if !bytes.Contains(content, []byte("{{")) {
+		return nil
+	}
+
+	var results []Result
+	report := func(start, end int, context string) {
+		for _, m := range templateActionBounds.FindAllIndex(content[start:end], -1) {
+			if !isOutputAction(string(content[start+m[0] : start+m[1]])) {
+				continue
+			}
+			line, col := offsetPosition(content, start+m[0])
+			results = append(results, Result{
+				Rule:      r.Name(),
+				Message:   catalogMessage(escapingAdvice[context], nil),
+				MessageID: escapingAdvice[context],
+				Filename:  filename,
+				Line:      line,
+				Col:       col,
+				Severity:  Info,
+			})
+		}
+	}
+
+	// reportHelpers notes declared helpers whose trusted result type does
+	// not apply in the attribute value content[start:end]
+	reportHelpers := func(start, end int, attr string) {
+		for _, m := range templateActionBounds.FindAllIndex(content[start:end], -1) {
+			action := string(content[start+m[0] : start+m[1]])
+			if !isOutputAction(action) {
+				continue
+			}
+			name := outputFunc(action)
+			var msg message
+			switch r.funcs[name].Returns {
+			case FuncReturnsURL:
+				if !environmentURLAttrs[attr] {
+					continue
+				}
+				msg = message{"template-escaping-context.url-func", map[string]any{"func": name}}
+			case FuncReturnsAttr:
+				msg = message{"template-escaping-context.attr-func", map[string]any{"func": name}}
+			case FuncReturnsHTML:
+				msg = message{"template-escaping-context.html-func", map[string]any{"func": name}}
+			default:
+				continue
+			}
+			line, col := offsetPosition(content, start+m[0])
+			results = append(results, Result{
+				Rule:      r.Name(),
+				Message:   msg.text(),
+				MessageID: msg.id,
+				Params:    msg.params,
+				Filename:  filename,
+				Line:      line,
+				Col:       col,
+				Severity:  Info,
+			})
+		}
+	}
+
+	z := html.NewTokenizer(bytes.NewReader(maskTemplateActions(content)))
+	offset := 0
+	rawContext := "" // context of the raw text element being read, if any
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		raw := z.Raw()
+		start := offset
+		offset += len(raw)
+
+		switch tt {
+		case html.TextToken:
+			if rawContext != "" {
+				report(start, offset, rawContext)
+			}
+		case html.EndTagToken:
+			rawContext = ""
+		case html.StartTagToken, html.SelfClosingTagToken:
+			nameEnd, attrs := parser.ScanTagAttrs(raw)
+			name := strings.ToLower(string(raw[1:nameEnd]))
+			rawContext = ""
+			if tt == html.StartTagToken {
+				rawContext = rawTextContext(name, attrs, raw)
+			}
+			for _, attr := range attrs {
+				if attr.ValueStart < 0 {
+					continue
+				}
+				if context := attrContext(attr.Name); context != "" {
+					report(start+attr.ValueStart, start+attr.ValueEnd, context)
+				} else if len(r.funcs) > 0 {
+					reportHelpers(start+attr.ValueStart, start+attr.ValueEnd, attr.Name)
+				}
+			}
+		}
+	}
+
+	return results
+}
+
+// rawTextContext returns the context of a script or style element's
+// content, or "" when it is not executed as JavaScript or CSS. JSON data
+// blocks are the recommended pattern and are not reported.
+func rawTextContext(name string, attrs []parser.RawAttr, raw []byte) string {
+	switch name {
+	case "style":
+		return "style"
+	case "script":
+		for _, attr := range attrs {
+			if attr.Name != "type" || attr.ValueStart < 0 {
+				continue
+			}
+			typ := strings.ToLower(strings.TrimSpace(string(raw[attr.ValueStart:attr.ValueEnd])))
+			if typ != "" && typ != "module" && !strings.Contains(typ, "javascript") && !strings.Contains(typ, "ecmascript") {
+				return ""
+			}
+		}
+		return "script"
+	}
+	return ""
+}
+
+// attrContext returns the escaping context of an attribute's value.
+func attrContext(name string) string {
+	switch {
+	case name == "style":
+		return "style"
+	case strings.HasPrefix(name, "on"), strings.HasPrefix(name, "hx-on"):
+		return "handler"
+	}
+	return ""
+}
@@ -0,0 +1,170 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+	"text/template/parse"
+)
+
+// Kinds of value a template function returns. html/template trusts the
+// html, url, and attr kinds (template.HTML, template.URL, and
+// template.HTMLAttr) only in their own context.
+const (
+	FuncReturnsString = "string"
+	FuncReturnsHTML   = "html"
+	FuncReturnsURL    = "url"
+	FuncReturnsAttr   = "attr"
+)
+
+// TemplateFunc declares a function a project registers in its Go template
+// FuncMap, such as {"args": 1, "returns": "url"} for a safeURL helper.
+type TemplateFunc struct {
+	// Args is the number of arguments, counting a piped value; with
+	// Variadic it is the minimum
+	Args     int
+	Variadic bool
+	// Returns is the kind of value returned: string (default), html, url,
+	// or attr
+	Returns string
+}
+
+// Validate reports a negative argument count or an unknown return kind.
+func (f TemplateFunc) Validate() error {
+	if f.Args < 0 {
+		return fmt.Errorf("args must not be negative, got %d", f.Args)
+	}
+	switch f.Returns {
+	case "", FuncReturnsString, FuncReturnsHTML, FuncReturnsURL, FuncReturnsAttr:
+		return nil
+	}
+	return fmt.Errorf("unknown return kind %q (expected string, html, url, or attr)", f.Returns)
+}
+
+// TemplateFuncsConfigurable is implemented by rules that use the declared
+// template functions, keyed by name.
+type TemplateFuncsConfigurable interface {
+	ConfigureTemplateFuncs(funcs map[string]TemplateFunc)
+}
+
+// templateBuiltins are the functions Go's text/template and html/template
+// define for every template.
+var templateBuiltins = map[string]bool{
+	"and": true, "call": true, "html": true, "index": true, "slice": true, "js": true,
+	"len": true, "not": true, "or": true, "print": true, "printf": true, "println": true,
+	"urlquery": true, "eq": true, "ge": true, "gt": true, "le": true, "lt": true, "ne": true,
+}
+
+// IsTemplateBuiltin reports whether name is a function Go templates
+// define without a FuncMap.
+func IsTemplateBuiltin(name string) bool {
+	return templateBuiltins[name]
+}
+
+// funcCall is a call of a function in a Go template.
+type funcCall struct {
+	name string
+	args int // counting a piped value
+	pos  int // byte offset of the name
+}
+
+// TemplateFuncNames returns the functions other than builtins that Go
+// template text calls, in order of first call.
+func TemplateFuncNames(text string) ([]string, error) {
+	calls, err := templateFuncCalls(text)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var names []string
+	for _, c := range calls {
+		if !templateBuiltins[c.name] && !seen[c.name] {
+			seen[c.name] = true
+			names = append(names, c.name)
+		}
+	}
+	return names, nil
+}
+
+// templateFuncCalls returns the function calls in Go template text.
+func templateFuncCalls(text string) ([]funcCall, error) {
+	tree := parse.New("")
+	tree.Mode = parse.SkipFuncCheck
+	trees := make(map[string]*parse.Tree)
+	if _, err := tree.Parse(text, "", "", trees); err != nil {
+		return nil, err
+	}
+
+	var calls []funcCall
+	var walk func(parse.Node)
+	walkPipe := func(pipe *parse.PipeNode) {
+		if pipe == nil {
+			return
+		}
+		for i, cmd := range pipe.Cmds {
+			if ident, ok := cmd.Args[0].(*parse.IdentifierNode); ok {
+				args := len(cmd.Args) - 1
+				if i > 0 {
+					args++
+				}
+				calls = append(calls, funcCall{name: ident.Ident, args: args, pos: int(ident.Pos)})
+			}
+			for _, arg := range cmd.Args {
+				walk(arg)
+			}
+		}
+	}
+	walk = func(node parse.Node) {
+		switch n := node.(type) {
+		case *parse.ListNode:
+			if n != nil {
+				for _, child := range n.Nodes {
+					walk(child)
+				}
+			}
+		case *parse.ActionNode:
+			walkPipe(n.Pipe)
+		case *parse.IfNode:
+			walkPipe(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.RangeNode:
+			walkPipe(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.WithNode:
+			walkPipe(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.TemplateNode:
+			walkPipe(n.Pipe)
+		case *parse.PipeNode:
+			walkPipe(n)
+		case *parse.ChainNode:
+			walk(n.Node)
+		}
+	}
+	for _, t := range trees {
+		walk(t.Root)
+	}
+	return calls, nil
+}
+
+// outputFunc returns the function whose result an output action renders,
+// the last command of its pipeline, or "" when that is not a function.
+func outputFunc(action string) string {
+	body := strings.TrimSpace(strings.Trim(action, "{}"))
+	body = strings.TrimSpace(strings.Trim(body, "-"))
+	tree := parse.New("")
+	tree.Mode = parse.SkipFuncCheck
+	if _, err := tree.Parse("{{"+body+"}}", "", "", make(map[string]*parse.Tree)); err != nil || len(tree.Root.Nodes) != 1 {
+		return ""
+	}
+	a, ok := tree.Root.Nodes[0].(*parse.ActionNode)
+	if !ok || len(a.Pipe.Decl) > 0 {
+		return ""
+	}
+	if ident, ok := a.Pipe.Cmds[len(a.Pipe.Cmds)-1].Args[0].(*parse.IdentifierNode); ok {
+		return ident.Ident
+	}
+	return ""
+}
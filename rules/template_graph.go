@@ -0,0 +1,282 @@
+package rules
+
+import (
+	"bytes"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// SourceFile is a file passed to project rules.
+type SourceFile struct {
+	Filename string
+	// Content is nil for a file reduced by Compact.
+	Content []byte
+
+	index *sourceIndex
+}
+
+// sourceIndex is what the built-in project rules read from a compacted
+// file.
+type sourceIndex struct {
+	templates []*Template // the file template, then its definitions in source order
+	scripts   []scriptRef
+	aria      []ariaElement
+}
+
+// Compact returns f reduced to what the built-in project rules need: its
+// templates, external script references, elements with ids or
+// aria-controls, and line offsets. The linter compacts files once a memory
+// limit is set and the retained sources outgrow their share of it, so
+// large projects can be checked without keeping every file in memory.
+// Project rules from packs see nil Content for compacted files.
+func (f SourceFile) Compact() SourceFile {
+	if f.index != nil {
+		return f
+	}
+	lines := lineStarts(f.Content)
+	templates := scanTemplates(f)
+	for _, t := range templates {
+		t.markup = t.HasMarkup()
+		t.lines = lines
+		t.Source = nil
+	}
+	return SourceFile{
+		Filename: f.Filename,
+		index: &sourceIndex{
+			templates: templates,
+			scripts:   externalScripts(f.Content),
+			aria:      scanAriaElements(f.Content),
+		},
+	}
+}
+
+// lineStarts returns the offset of each line in content.
+func lineStarts(content []byte) []int32 {
+	starts := []int32{0}
+	for i, c := range content {
+		if c == '\n' {
+			starts = append(starts, int32(i+1)) //nolint:gosec // files over 2 GiB are not linted
+		}
+	}
+	return starts
+}
+
+// Template is a named Go template located in a source file. A file's
+// top-level content is a template named after its base name, as with
+// template.ParseFiles; {{define}} and {{block}} bodies are templates of
+// their own and are excluded from the enclosing one.
+type Template struct {
+	Name     string
+	Filename string
+	Source   []byte         // content of the whole file
+	Offset   int            // offset of the {{define}} or {{block}} action; 0 for a file
+	Spans    [][2]int       // byte ranges of the body within Source
+	Calls    []TemplateCall // {{template}} and {{block}} invocations in source order
+
+	// Set instead of Source for templates of compacted files.
+	lines  []int32
+	markup bool
+}
+
+// Position returns the line and column of offset in the template's file.
+func (t *Template) Position(offset int) (line, col int) {
+	if t.lines == nil {
+		return offsetPosition(t.Source, offset)
+	}
+	line = sort.Search(len(t.lines), func(i int) bool { return int(t.lines[i]) > offset })
+	return line, offset - int(t.lines[line-1]) + 1
+}
+
+// TemplateCall is an invocation of a named template.
+type TemplateCall struct {
+	Name   string
+	Arg    string // pipeline passed as data, e.g. "." or ".User"; empty if none
+	Offset int    // offset of the action within Source
+}
+
+// TemplateGraph indexes the templates defined across a set of files and
+// the calls between them, so rules can inspect pages as they are composed
+// from layouts and partials.
+type TemplateGraph struct {
+	Files []*Template            // file-level templates, in input order
+	Defs  map[string][]*Template // {{define}}/{{block}} templates by name
+}
+
+// templateNamePattern extracts the quoted name of a define, block, or
+// template action body.
+var templateNamePattern = regexp.MustCompile(`^(define|block|template)\s+("(?:[^"\\]|\\.)*"|` + "`[^`]*`" + `)`)
+
+// NewTemplateGraph scans files for template definitions and calls.
+func NewTemplateGraph(files []SourceFile) *TemplateGraph {
+	g := &TemplateGraph{Defs: make(map[string][]*Template)}
+	for _, f := range files {
+		templates := scanTemplates(f)
+		if f.index != nil {
+			templates = f.index.templates
+		}
+		g.Files = append(g.Files, templates[0])
+		for _, def := range templates[1:] {
+			g.Defs[def.Name] = append(g.Defs[def.Name], def)
+		}
+	}
+	return g
+}
+
+// scanTemplates returns the file template of f followed by its
+// {{define}} and {{block}} templates in source order.
+func scanTemplates(f SourceFile) []*Template {
+	root := &Template{Name: filepath.Base(f.Filename), Filename: f.Filename, Source: f.Content}
+	templates := []*Template{root}
+
+	current := root
+	bodyStart := 0
+	var stack []*Template   // open blocks: the define/block template, nil for if/range/with
+	var parents []*Template // enclosing templates of open define/block bodies
+
+	for _, m := range templateActionBounds.FindAllIndex(f.Content, -1) {
+		body := bytes.TrimSpace(f.Content[m[0]+2 : m[1]-2])
+		body = bytes.TrimSpace(bytes.TrimSuffix(bytes.TrimPrefix(body, []byte("-")), []byte("-")))
+		keyword := string(actionKeyword(body))
+
+		switch keyword {
+		case "define", "block":
+			name, arg, ok := templateName(body)
+			if !ok {
+				stack = append(stack, nil)
+				continue
+			}
+			if keyword == "block" {
+				current.Calls = append(current.Calls, TemplateCall{Name: name, Arg: arg, Offset: m[0]})
+			}
+			current.Spans = append(current.Spans, [2]int{bodyStart, m[0]})
+			def := &Template{Name: name, Filename: f.Filename, Source: f.Content, Offset: m[0]}
+			templates = append(templates, def)
+			parents = append(parents, current)
+			stack = append(stack, def)
+			current, bodyStart = def, m[1]
+		case "if", "range", "with":
+			stack = append(stack, nil)
+		case "end":
+			if len(stack) == 0 {
+				continue
+			}
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if top != nil {
+				current.Spans = append(current.Spans, [2]int{bodyStart, m[0]})
+				current = parents[len(parents)-1]
+				parents = parents[:len(parents)-1]
+				bodyStart = m[1]
+			}
+		case "template":
+			if name, arg, ok := templateName(body); ok {
+				current.Calls = append(current.Calls, TemplateCall{Name: name, Arg: arg, Offset: m[0]})
+			}
+		}
+	}
+
+	// A body left open by a missing {{end}} runs to the end of the file.
+	current.Spans = append(current.Spans, [2]int{bodyStart, len(f.Content)})
+	return templates
+}
+
+// actionKeyword returns the leading lowercase word of an action body.
+func actionKeyword(body []byte) []byte {
+	i := 0
+	for i < len(body) && body[i] >= 'a' && body[i] <= 'z' {
+		i++
+	}
+	return body[:i]
+}
+
+// templateName returns the literal name of a define, block, or template
+// action and the pipeline following it, or false if the name is not a
+// string literal.
+func templateName(body []byte) (name, arg string, ok bool) {
+	m := templateNamePattern.FindSubmatchIndex(body)
+	if m == nil {
+		return "", "", false
+	}
+	name, err := strconv.Unquote(string(body[m[4]:m[5]]))
+	if err != nil {
+		return "", "", false
+	}
+	return name, string(bytes.TrimSpace(body[m[1]:])), true
+}
+
+// HasMarkup reports whether t contains anything besides whitespace and
+// template actions, i.e. whether it renders a page or fragment itself.
+func (t *Template) HasMarkup() bool {
+	if t.lines != nil {
+		return t.markup
+	}
+	for _, span := range t.Spans {
+		text := templateActionBounds.ReplaceAll(t.Source[span[0]:span[1]], nil)
+		if len(bytes.TrimSpace(text)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Entries returns the templates that start a composed page: file
+// templates with markup of their own and named templates never called by
+// another template, such as base layouts.
+func (g *TemplateGraph) Entries() []*Template {
+	called := make(map[string]bool)
+	for _, t := range g.all() {
+		for _, c := range t.Calls {
+			called[c.Name] = true
+		}
+	}
+	var entries []*Template
+	for _, t := range g.Files {
+		if t.HasMarkup() {
+			entries = append(entries, t)
+		}
+	}
+	for _, name := range g.Names() {
+		if !called[name] {
+			entries = append(entries, g.Defs[name]...)
+		}
+	}
+	return entries
+}
+
+// Resolve returns the definition a call to name refers to when composing
+// a page from a file: a definition in that file wins, since each page
+// typically fills shared slots such as "content" itself; otherwise the
+// name must be defined exactly once. Ambiguous names resolve to nil.
+func (g *TemplateGraph) Resolve(name, fromFile string) *Template {
+	defs := g.Defs[name]
+	for _, d := range defs {
+		if d.Filename == fromFile {
+			return d
+		}
+	}
+	if len(defs) == 1 {
+		return defs[0]
+	}
+	return nil
+}
+
+// Names returns the defined template names in sorted order.
+func (g *TemplateGraph) Names() []string {
+	names := make([]string, 0, len(g.Defs))
+	for name := range g.Defs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// all returns every template: files first, then definitions by name.
+func (g *TemplateGraph) all() []*Template {
+	all := append([]*Template(nil), g.Files...)
+	for _, name := range g.Names() {
+		all = append(all, g.Defs[name]...)
+	}
+	return all
+}
@@ -0,0 +1,97 @@
+package rules
+
+import (
+	"path"
+
+	"github.com/toba/go-html-validate/parser"
+)
+
+// TemplateReferences reports dead code in a template tree: {{define}}
+// blocks that no {{template}} or {{block}} call in the linted files
+// references, and calls to names that are never defined. Templates
+// executed directly from Go, such as base layouts, are listed in the
+// "entrypoints" option (glob patterns); names may also be defined in Go
+// code, which the rule cannot see, so it is opt-in.
+type TemplateReferences struct {
+	Entrypoints []string
+}
+
+// Name returns the rule identifier.
+func (r *TemplateReferences) Name() string { return RuleTemplateReferences }
+
+// Description returns what this rule checks.
+func (r *TemplateReferences) Description() string {
+	return "defined templates should be used and called templates should be defined"
+}
+
+// OptIn marks the rule as disabled unless explicitly enabled.
+func (r *TemplateReferences) OptIn() {}
+
+// ConfigureOptions applies the entrypoints option.
+func (r *TemplateReferences) ConfigureOptions(opts map[string]any) {
+	r.Entrypoints = StringsOption(opts, "entrypoints", r.Entrypoints)
+}
+
+// Check is a no-op; references are resolved by CheckProject.
+func (r *TemplateReferences) Check(_ *parser.Document) []Result {
+	return nil
+}
+
+// CheckProject reports unused definitions and undefined calls.
+func (r *TemplateReferences) CheckProject(files []SourceFile) []Result {
+	var results []Result
+	graph := NewTemplateGraph(files)
+
+	called := make(map[string]bool)
+	for _, t := range graph.all() {
+		for _, c := range t.Calls {
+			called[c.Name] = true
+			if len(graph.Defs[c.Name]) > 0 {
+				continue
+			}
+			line, col := t.Position(c.Offset)
+			params := map[string]any{"name": c.Name}
+			results = append(results, Result{
+				Rule:      RuleTemplateReferences,
+				Message:   catalogMessage("template-references.undefined", params),
+				MessageID: "template-references.undefined",
+				Params:    params,
+				Filename:  t.Filename,
+				Line:      line,
+				Col:       col,
+				Severity:  Warning,
+			})
+		}
+	}
+
+	for _, name := range graph.Names() {
+		if called[name] || r.isEntrypoint(name) {
+			continue
+		}
+		for _, def := range graph.Defs[name] {
+			line, col := def.Position(def.Offset)
+			params := map[string]any{"name": name}
+			results = append(results, Result{
+				Rule:      RuleTemplateReferences,
+				Message:   catalogMessage("template-references.unused", params),
+				MessageID: "template-references.unused",
+				Params:    params,
+				Filename:  def.Filename,
+				Line:      line,
+				Col:       col,
+				Severity:  Warning,
+			})
+		}
+	}
+
+	return results
+}
+
+func (r *TemplateReferences) isEntrypoint(name string) bool {
+	for _, pattern := range r.Entrypoints {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
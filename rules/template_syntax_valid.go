@@ -3,12 +3,19 @@ package rules
 import (
 	"bytes"
 	"regexp"
+	"strings"
 
 	"github.com/toba/go-html-validate/parser"
 )
 
-// TemplateSyntaxValid checks for basic Go template syntax errors.
-type TemplateSyntaxValid struct{}
+// TemplateSyntaxValid checks for basic Go template syntax errors. Once a
+// project declares its template functions, calls of undeclared functions
+// and calls with the wrong number of arguments are errors too, as they are
+// when Go parses the template.
+type TemplateSyntaxValid struct {
+	dialect parser.Dialect
+	funcs   map[string]TemplateFunc
+}
 
 func (r *TemplateSyntaxValid) Name() string { return RuleTemplateSyntaxValid }
 
@@ -21,13 +28,20 @@ func (r *TemplateSyntaxValid) Check(_ *parser.Document) []Result {
 	return nil
 }
 
-// controlStructures that require matching end.
-var controlStructures = map[string]bool{
-	"if":     true,
-	"range":  true,
-	"with":   true,
-	"block":  true,
-	"define": true,
+// ConfigureDialect sets the template dialect whose blocks are matched.
+func (r *TemplateSyntaxValid) ConfigureDialect(d parser.Dialect) {
+	r.dialect = d
+}
+
+// ConfigureTemplateFuncs sets the declared template functions.
+func (r *TemplateSyntaxValid) ConfigureTemplateFuncs(funcs map[string]TemplateFunc) {
+	r.funcs = funcs
+}
+
+// actionBody returns the body of the action starting at a regexp match's
+// keyword, up to the first } and without a trailing trim marker.
+func actionBody(b []byte) []byte {
+	return bytes.TrimSpace(bytes.TrimSuffix(bytes.TrimSpace(b), []byte("-")))
 }
 
 // CheckRaw examines the raw template content for syntax errors.
@@ -36,16 +50,29 @@ func (r *TemplateSyntaxValid) CheckRaw(filename string, content []byte) []Result
 	braceResults := r.checkBalancedBraces(filename, content)
 
 	// Check for unbalanced control structures
-	controlResults := r.checkBalancedControlStructures(filename, content)
+	var controlResults []Result
+	dialect := r.dialect.ForFile(filename)
+	if syntax, ok := namedBlockDialects[dialect]; ok {
+		controlResults = r.checkBalancedBlocks(dialect, syntax, filename, content)
+	} else {
+		controlResults = r.checkBalancedControlStructures(dialect, filename, content)
+	}
 
 	// Check for invalid trim marker syntax
 	trimResults := r.checkTrimMarkerSyntax(filename, content)
 
+	// Check calls against the declared functions
+	var funcResults []Result
+	if len(r.funcs) > 0 && dialect == parser.DialectGo {
+		funcResults = r.checkFuncCalls(filename, content)
+	}
+
 	// Combine all results
-	results := make([]Result, 0, len(braceResults)+len(controlResults)+len(trimResults))
+	results := make([]Result, 0, len(braceResults)+len(controlResults)+len(trimResults)+len(funcResults))
 	results = append(results, braceResults...)
 	results = append(results, controlResults...)
 	results = append(results, trimResults...)
+	results = append(results, funcResults...)
 
 	return results
 }
@@ -60,24 +87,30 @@ func (r *TemplateSyntaxValid) checkBalancedBraces(filename string, content []byt
 	if openCount > closeCount {
 		// Find the first unmatched {{
 		line, col := r.findUnmatchedOpen(content)
+		params := map[string]any{"open": "{{", "close": "}}"}
 		results = append(results, Result{
-			Rule:     r.Name(),
-			Message:  "unmatched '{{' - missing closing '}}'",
-			Filename: filename,
-			Line:     line,
-			Col:      col,
-			Severity: Error,
+			Rule:      r.Name(),
+			Message:   catalogMessage("template-syntax-valid.unmatched-open", params),
+			MessageID: "template-syntax-valid.unmatched-open",
+			Params:    params,
+			Filename:  filename,
+			Line:      line,
+			Col:       col,
+			Severity:  Error,
 		})
 	} else if closeCount > openCount {
 		// Find the first unmatched }}
 		line, col := r.findUnmatchedClose(content)
+		params := map[string]any{"open": "{{", "close": "}}"}
 		results = append(results, Result{
-			Rule:     r.Name(),
-			Message:  "unmatched '}}' - missing opening '{{'",
-			Filename: filename,
-			Line:     line,
-			Col:      col,
-			Severity: Error,
+			Rule:      r.Name(),
+			Message:   catalogMessage("template-syntax-valid.unmatched-close", params),
+			MessageID: "template-syntax-valid.unmatched-close",
+			Params:    params,
+			Filename:  filename,
+			Line:      line,
+			Col:       col,
+			Severity:  Error,
 		})
 	}
 
@@ -156,7 +189,7 @@ func (r *TemplateSyntaxValid) findUnmatchedClose(content []byte) (line, col int)
 }
 
 // checkBalancedControlStructures verifies that if/range/with/block have matching end.
-func (r *TemplateSyntaxValid) checkBalancedControlStructures(filename string, content []byte) []Result {
+func (r *TemplateSyntaxValid) checkBalancedControlStructures(dialect parser.Dialect, filename string, content []byte) []Result {
 	var results []Result
 
 	// Stack to track open control structures
@@ -170,7 +203,7 @@ func (r *TemplateSyntaxValid) checkBalancedControlStructures(filename string, co
 	lines := bytes.Split(content, []byte("\n"))
 
 	// Pattern to extract template actions
-	actionRegex := regexp.MustCompile(`\{\{-?\s*(\w+)`)
+	actionRegex := regexp.MustCompile(`\{\{-?\s*((\w+)[^}]*)`)
 
 	for lineNum, line := range lines {
 		matches := actionRegex.FindAllSubmatchIndex(line, -1)
@@ -178,38 +211,47 @@ func (r *TemplateSyntaxValid) checkBalancedControlStructures(filename string, co
 			if match[2] < 0 || match[3] < 0 {
 				continue
 			}
-			keyword := string(line[match[2]:match[3]])
+			keyword := string(line[match[4]:match[5]])
 
-			switch {
-			case controlStructures[keyword]:
+			switch kind := dialect.Classify(actionBody(line[match[2]:match[3]])); {
+			case kind == parser.ActionOpen:
 				stack = append(stack, openStruct{
 					keyword: keyword,
 					line:    lineNum + 1,
 					col:     match[0] + 1,
 				})
-			case keyword == "end":
+			case kind == parser.ActionEnd:
 				if len(stack) > 0 {
 					stack = stack[:len(stack)-1]
 				} else {
+					params := map[string]any{"action": "{{ end }}"}
 					results = append(results, Result{
-						Rule:     r.Name(),
-						Message:  "unexpected '{{ end }}' - no matching control structure",
-						Filename: filename,
-						Line:     lineNum + 1,
-						Col:      match[0] + 1,
-						Severity: Error,
+						Rule:      r.Name(),
+						Message:   catalogMessage("template-syntax-valid.unexpected-end", params),
+						MessageID: "template-syntax-valid.unexpected-end",
+						Params:    params,
+						Filename:  filename,
+						Line:      lineNum + 1,
+						Col:       match[0] + 1,
+						Severity:  Error,
 					})
 				}
-			case keyword == "else":
+			case kind == parser.ActionElse:
 				// else doesn't pop the stack, it's part of an if/with
 				if len(stack) == 0 {
+					id, params := "template-syntax-valid.unexpected-else", map[string]any{"action": "{{ else }}"}
+					if keyword == "catch" {
+						id, params = "template-syntax-valid.unexpected-catch", map[string]any{"action": "{{ catch }}"}
+					}
 					results = append(results, Result{
-						Rule:     r.Name(),
-						Message:  "unexpected '{{ else }}' - no matching 'if' or 'with'",
-						Filename: filename,
-						Line:     lineNum + 1,
-						Col:      match[0] + 1,
-						Severity: Error,
+						Rule:      r.Name(),
+						Message:   catalogMessage(id, params),
+						MessageID: id,
+						Params:    params,
+						Filename:  filename,
+						Line:      lineNum + 1,
+						Col:       match[0] + 1,
+						Severity:  Error,
 					})
 				}
 			}
@@ -218,16 +260,149 @@ func (r *TemplateSyntaxValid) checkBalancedControlStructures(filename string, co
 
 	// Report any unclosed control structures
 	for _, open := range stack {
+		params := map[string]any{"action": "{{ " + open.keyword + " }}", "end": "{{ end }}"}
+		results = append(results, Result{
+			Rule:      r.Name(),
+			Message:   catalogMessage("template-syntax-valid.unclosed", params),
+			MessageID: "template-syntax-valid.unclosed",
+			Params:    params,
+			Filename:  filename,
+			Line:      open.line,
+			Col:       open.col,
+			Severity:  Error,
+		})
+	}
+
+	return results
+}
+
+// namedBlocks describes dialects whose end actions name the block they
+// close, such as {% endfor %} in Pongo2 and {{/each}} in Handlebars.
+type namedBlocks struct {
+	// name returns the block an open or end action belongs to
+	name func(body []byte) string
+	// label shows an action's first word in the dialect's delimiters
+	label func(word string) string
+	// end returns the end action expected for a block name
+	end func(name string) string
+}
+
+var namedBlockDialects = map[parser.Dialect]namedBlocks{
+	parser.DialectPongo2: {
+		name:  func(body []byte) string { return strings.TrimPrefix(firstWord(body), "end") },
+		label: func(word string) string { return "{% " + word + " %}" },
+		end:   func(name string) string { return "{% end" + name + " %}" },
+	},
+	parser.DialectHandlebars: {
+		name: func(body []byte) string {
+			return firstWord(bytes.TrimLeft(bytes.TrimLeft(body, "#^/"), ">* "))
+		},
+		label: func(word string) string { return "{{" + word + "}}" },
+		end:   func(name string) string { return "{{/" + name + "}}" },
+	},
+}
+
+// firstWord returns the first whitespace-separated word of body.
+func firstWord(body []byte) string {
+	if f := bytes.Fields(body); len(f) > 0 {
+		return string(f[0])
+	}
+	return ""
+}
+
+// checkBalancedBlocks verifies that blocks of a dialect in
+// namedBlockDialects are closed by their own end action, and that else
+// actions ({% elif %}, {{^}}, ...) appear inside a block.
+func (r *TemplateSyntaxValid) checkBalancedBlocks(dialect parser.Dialect, syntax namedBlocks, filename string, content []byte) []Result {
+	var results []Result
+	report := func(offset int, id string, params map[string]any) {
+		line, col := offsetPosition(content, offset)
 		results = append(results, Result{
-			Rule:     r.Name(),
-			Message:  "unclosed '{{ " + open.keyword + " }}' - missing '{{ end }}'",
-			Filename: filename,
-			Line:     open.line,
-			Col:      open.col,
-			Severity: Error,
+			Rule:      r.Name(),
+			Message:   catalogMessage(id, params),
+			MessageID: id,
+			Params:    params,
+			Filename:  filename,
+			Line:      line,
+			Col:       col,
+			Severity:  Error,
 		})
 	}
 
+	type openBlock struct {
+		name, label string
+		offset      int
+	}
+	var stack []openBlock
+
+	for _, a := range dialect.Actions(content) {
+		label := syntax.label(firstWord(a.Body))
+		switch a.Kind {
+		case parser.ActionOpen:
+			stack = append(stack, openBlock{name: syntax.name(a.Body), label: label, offset: a.Start})
+		case parser.ActionEnd:
+			name := syntax.name(a.Body)
+			if len(stack) == 0 {
+				report(a.Start, "template-syntax-valid.unexpected-block-end", map[string]any{"action": label})
+				continue
+			}
+			open := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if name != open.name {
+				report(a.Start, "template-syntax-valid.mismatched-end", map[string]any{"action": label, "open": open.label, "end": syntax.end(open.name)})
+			}
+		case parser.ActionElse:
+			if len(stack) == 0 {
+				report(a.Start, "template-syntax-valid.unexpected-block-else", map[string]any{"action": label})
+			}
+		}
+	}
+
+	for _, open := range stack {
+		report(open.offset, "template-syntax-valid.unclosed", map[string]any{"action": open.label, "end": syntax.end(open.name)})
+	}
+
+	return results
+}
+
+// checkFuncCalls reports calls of functions that are neither builtins nor
+// declared, and calls of declared functions with the wrong number of
+// arguments. Templates that do not parse are left to the other checks.
+func (r *TemplateSyntaxValid) checkFuncCalls(filename string, content []byte) []Result {
+	calls, err := templateFuncCalls(string(content))
+	if err != nil {
+		return nil
+	}
+
+	var results []Result
+	for _, c := range calls {
+		var id string
+		fn, declared := r.funcs[c.name]
+		switch {
+		case templateBuiltins[c.name]:
+			continue
+		case !declared:
+			id = "template-syntax-valid.undeclared-func"
+		case fn.Variadic && c.args < fn.Args:
+			id = "template-syntax-valid.func-min-args"
+		case !fn.Variadic && c.args != fn.Args:
+			id = "template-syntax-valid.func-args"
+		default:
+			continue
+		}
+		params := map[string]any{"func": c.name, "args": fn.Args, "count": c.args}
+		line, col := offsetPosition(content, c.pos)
+		results = append(results, Result{
+			Rule:      r.Name(),
+			Message:   catalogMessage(id, params),
+			MessageID: id,
+			Params:    params,
+			Filename:  filename,
+			Line:      line,
+			Col:       col,
+			Severity:  Error,
+		})
+	}
 	return results
 }
 
@@ -248,13 +423,16 @@ func (r *TemplateSyntaxValid) checkTrimMarkerSyntax(filename string, content []b
 		if match := leadingTrimNoSpace.FindIndex(line); match != nil {
 			// Make sure it's not {{--}} (double dash edge case)
 			if match[0]+3 < len(line) && line[match[0]+3] != '-' {
+				params := map[string]any{"marker": "{{-"}
 				results = append(results, Result{
-					Rule:     r.Name(),
-					Message:  "trim marker '{{-' must be followed by whitespace",
-					Filename: filename,
-					Line:     lineNum + 1,
-					Col:      match[0] + 1,
-					Severity: Error,
+					Rule:      r.Name(),
+					Message:   catalogMessage("template-syntax-valid.trim-open", params),
+					MessageID: "template-syntax-valid.trim-open",
+					Params:    params,
+					Filename:  filename,
+					Line:      lineNum + 1,
+					Col:       match[0] + 1,
+					Severity:  Error,
 				})
 			}
 		}
@@ -265,13 +443,16 @@ func (r *TemplateSyntaxValid) checkTrimMarkerSyntax(filename string, content []b
 			charBefore := line[match[0]]
 			// Skip if it's part of a comment or string (heuristic: skip if dash follows a dash)
 			if charBefore != '-' {
+				params := map[string]any{"marker": "-}}"}
 				results = append(results, Result{
-					Rule:     r.Name(),
-					Message:  "trim marker '-}}' must be preceded by whitespace",
-					Filename: filename,
-					Line:     lineNum + 1,
-					Col:      match[0] + 1,
-					Severity: Error,
+					Rule:      r.Name(),
+					Message:   catalogMessage("template-syntax-valid.trim-close", params),
+					MessageID: "template-syntax-valid.trim-close",
+					Params:    params,
+					Filename:  filename,
+					Line:      lineNum + 1,
+					Col:       match[0] + 1,
+					Severity:  Error,
 				})
 			}
 		}
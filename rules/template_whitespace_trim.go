@@ -75,12 +75,13 @@ func (r *TemplateWhitespaceTrim) CheckRaw(filename string, content []byte) []Res
 
 			// Report a warning
 			results = append(results, Result{
-				Rule:     r.Name(),
-				Message:  "control flow action alone on line should use trailing trim marker (-}}) to prevent blank lines",
-				Filename: filename,
-				Line:     lineNum + 1,
-				Col:      match[0] + 1,
-				Severity: Warning,
+				Rule:      r.Name(),
+				Message:   catalogMessage("template-whitespace-trim.blank-line", nil),
+				MessageID: "template-whitespace-trim.blank-line",
+				Filename:  filename,
+				Line:      lineNum + 1,
+				Col:       match[0] + 1,
+				Severity:  Warning,
 			})
 		}
 	}
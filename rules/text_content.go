@@ -34,12 +34,13 @@ func (r *TextContent) Check(doc *parser.Document) []Result {
 		if tag == "summary" {
 			if !HasAccessibleName(n) {
 				results = append(results, Result{
-					Rule:     RuleTextContent,
-					Message:  "summary element must have accessible text content",
-					Filename: doc.Filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Error,
+					Rule:      RuleTextContent,
+					Message:   catalogMessage("text-content.summary", nil),
+					MessageID: "text-content.summary",
+					Filename:  doc.Filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Error,
 				})
 			}
 			return true
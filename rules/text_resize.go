@@ -0,0 +1,138 @@
+package rules
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+// wcagResizeText is the WCAG success criterion reported by TextResize.
+const wcagResizeText = "1.4.4"
+
+// absoluteLengthUnits are CSS units that do not scale with the font size.
+var absoluteLengthUnits = []string{"px", "pt", "pc", "cm", "mm", "in"}
+
+// TextResize flags markup that keeps text from being resized to 200%
+// (WCAG 1.4.4 Resize Text): a viewport meta tag that limits zooming with
+// maximum-scale below 2 or user-scalable=no, and an inline style giving an
+// element with text a fixed height (in px or another absolute unit) while
+// hiding its overflow, so enlarged text is cut off. Style sheets are not
+// checked, and such a box may be sized for content that never grows, so
+// the rule is opt-in; the html-validate:a11y-strict preset enables it.
+// Results carry the criterion in Meta[MetaWCAG].
+type TextResize struct{}
+
+// Name returns the rule identifier.
+func (r *TextResize) Name() string { return RuleTextResize }
+
+// Description returns what this rule checks.
+func (r *TextResize) Description() string {
+	return "text must not be clipped by fixed heights or kept from zooming by the viewport"
+}
+
+// OptIn marks the rule as disabled unless explicitly enabled.
+func (r *TextResize) OptIn() {}
+
+// Check examines viewport meta tags and inline styles.
+func (r *TextResize) Check(doc *parser.Document) []Result {
+	var results []Result
+	report := func(n *parser.Node, attr string, m message) {
+		line, col := n.AttrPos(attr)
+		results = append(results, Result{
+			Rule:      RuleTextResize,
+			Message:   m.text(),
+			MessageID: m.id,
+			Params:    m.params,
+			Filename:  doc.Filename,
+			Line:      line,
+			Col:       col,
+			Severity:  Warning,
+			Meta:      map[string]string{MetaWCAG: wcagResizeText},
+		})
+	}
+
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+		if TagEquals(n, "meta") && strings.EqualFold(n.GetAttr("name"), "viewport") {
+			if m := viewportZoomLimit(n.GetAttr("content")); m.id != "" {
+				report(n, "content", m)
+			}
+			return true
+		}
+		if style := n.GetAttr("style"); style != "" && !IsTemplateExpr(style) {
+			if height := clippedHeight(style); height != "" && strings.TrimSpace(n.TextContent()) != "" {
+				report(n, "style", message{"text-resize.clipped", map[string]any{"element": Tag(n), "height": height}})
+			}
+		}
+		return true
+	})
+
+	return results
+}
+
+// viewportZoomLimit describes how a viewport meta content value keeps
+// users from zooming to 200%, or returns the zero message if it does not.
+func viewportZoomLimit(content string) message {
+	for part := range strings.FieldsFuncSeq(content, func(r rune) bool { return r == ',' || r == ';' }) {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.ToLower(strings.TrimSpace(value))
+		switch key {
+		case "user-scalable":
+			if value == "no" || value == "0" {
+				return message{"text-resize.user-scalable", map[string]any{"value": value}}
+			}
+		case "maximum-scale":
+			if scale, err := strconv.ParseFloat(value, 64); err == nil && scale < 2 {
+				return message{"text-resize.maximum-scale", map[string]any{"value": value}}
+			}
+		}
+	}
+	return message{}
+}
+
+// clippedHeight returns the fixed height set by a style attribute that
+// also hides overflow, or "" if the style does not clip that way.
+func clippedHeight(style string) string {
+	var height string
+	hidden := false
+	for _, d := range styleDeclarations(style) {
+		value := strings.ToLower(strings.TrimSpace(strings.TrimSuffix(d.value, "!important")))
+		switch d.prop {
+		case "height", "max-height":
+			if isAbsoluteLength(value) {
+				height = value
+			}
+		case "overflow", "overflow-y":
+			// the second value of overflow is overflow-y
+			values := strings.Fields(value)
+			if len(values) > 0 {
+				y := values[len(values)-1]
+				hidden = y == "hidden" || y == "clip"
+			}
+		}
+	}
+	if !hidden {
+		return ""
+	}
+	return height
+}
+
+// isAbsoluteLength reports whether value is a non-zero CSS length in a
+// unit that does not scale with text.
+func isAbsoluteLength(value string) bool {
+	for _, unit := range absoluteLengthUnits {
+		if num, ok := strings.CutSuffix(value, unit); ok {
+			f, err := strconv.ParseFloat(strings.TrimSpace(num), 64)
+			return err == nil && f > 0
+		}
+	}
+	return false
+}
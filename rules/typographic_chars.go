@@ -0,0 +1,152 @@
+package rules
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/toba/go-html-validate/parser"
+	xhtml "golang.org/x/net/html"
+)
+
+// Styles accepted by the typographic-chars style option.
+const (
+	TypographicLiteral = "literal"
+	TypographicEntity  = "entity"
+)
+
+// typographicChars names the typographic characters the rule keeps
+// consistent, with their named references.
+var typographicChars = map[rune]struct{ entity, name string }{
+	'‘':      {"&lsquo;", "left single quotation mark"},
+	'’':      {"&rsquo;", "right single quotation mark"},
+	'“':      {"&ldquo;", "left double quotation mark"},
+	'”':      {"&rdquo;", "right double quotation mark"},
+	'‚':      {"&sbquo;", "single low quotation mark"},
+	'„':      {"&bdquo;", "double low quotation mark"},
+	'‹':      {"&lsaquo;", "single left angle quotation mark"},
+	'›':      {"&rsaquo;", "single right angle quotation mark"},
+	'«':      {"&laquo;", "left guillemet"},
+	'»':      {"&raquo;", "right guillemet"},
+	'–':      {"&ndash;", "en dash"},
+	'—':      {"&mdash;", "em dash"},
+	'…':      {"&hellip;", "ellipsis"},
+	'\u00a0': {"&nbsp;", "no-break space"},
+}
+
+// typographicRefPattern matches a character reference at the start of its
+// input that may stand for a typographic character.
+var typographicRefPattern = regexp.MustCompile(`^&(?:#[0-9]+|#[xX][0-9a-fA-F]+|[a-zA-Z][a-zA-Z0-9]*);`)
+
+// TypographicChars keeps typographic characters (curly quotes, dashes,
+// ellipses, and no-break spaces) in text and attribute values written one
+// way, either as literal UTF-8 characters or as named references such as
+// &rsquo;, so edits from different editors do not flip between the two
+// and clutter template diffs. Numeric references like &#8217; are
+// reported under either style. Findings carry a fix converting to the
+// configured style. The rule is opt-in.
+//
+// Options:
+//   - "style": "literal" (default) or "entity"
+type TypographicChars struct {
+	Style string
+}
+
+// Name returns the rule identifier.
+func (r *TypographicChars) Name() string { return RuleTypographicChars }
+
+// Description returns what this rule checks.
+func (r *TypographicChars) Description() string {
+	return "typographic characters must consistently be literal characters or named references"
+}
+
+// OptIn marks the rule as disabled unless explicitly enabled.
+func (r *TypographicChars) OptIn() {}
+
+// ConfigureOptions applies the style option.
+func (r *TypographicChars) ConfigureOptions(opts map[string]any) {
+	r.Style = StringOption(opts, "style", TypographicLiteral)
+}
+
+// Check implements Rule but returns nil - this rule uses CheckRaw instead.
+func (r *TypographicChars) Check(_ *parser.Document) []Result {
+	return nil
+}
+
+// CheckRaw examines text and attribute values in the raw content. Template
+// actions, comments, and script/style content are skipped.
+func (r *TypographicChars) CheckRaw(filename string, content []byte) []Result {
+	var results []Result
+	rawText := false // inside script or style
+	for _, tok := range parser.Tokens(filename, content) {
+		switch tok.Type {
+		case xhtml.StartTagToken, xhtml.SelfClosingTagToken:
+			name := strings.ToLower(tok.Name)
+			rawText = tok.Type == xhtml.StartTagToken && (name == "script" || name == "style")
+			for _, a := range tok.Attrs {
+				if a.HasValue {
+					results = r.checkSpan(filename, content, a.ValueStart, a.ValueEnd, results)
+				}
+			}
+		case xhtml.EndTagToken:
+			rawText = false
+		case xhtml.TextToken:
+			if !rawText {
+				results = r.checkSpan(filename, content, tok.Start, tok.End, results)
+			}
+		}
+	}
+	return results
+}
+
+// checkSpan appends findings for content[start:end], outside template
+// actions, to results.
+func (r *TypographicChars) checkSpan(filename string, content []byte, start, end int, results []Result) []Result {
+	span := content[start:end]
+	actions := templateActionBounds.FindAllIndex(span, -1)
+	report := func(offset, size int, id string, params map[string]any, replacement string) {
+		line, col := offsetPosition(content, offset)
+		results = append(results, Result{
+			Rule:      RuleTypographicChars,
+			Message:   catalogMessage(id, params),
+			MessageID: id,
+			Params:    params,
+			Filename:  filename,
+			Line:      line,
+			Col:       col,
+			Severity:  Warning,
+			Fix:       &Fix{Start: offset, End: offset + size, Text: replacement},
+		})
+	}
+
+	for i := 0; i < len(span); {
+		if len(actions) > 0 && i >= actions[0][0] {
+			i = actions[0][1]
+			actions = actions[1:]
+			continue
+		}
+		if span[i] == '&' {
+			if ref := typographicRefPattern.Find(span[i:]); ref != nil {
+				decoded := html.UnescapeString(string(ref))
+				c, size := utf8.DecodeRuneInString(decoded)
+				if t, ok := typographicChars[c]; ok && size == len(decoded) {
+					switch {
+					case r.Style != TypographicEntity:
+						report(start+i, len(ref), "typographic-chars.literal", map[string]any{"name": t.name, "ref": string(ref)}, decoded)
+					case string(ref) != t.entity:
+						report(start+i, len(ref), "typographic-chars.entity-ref", map[string]any{"name": t.name, "entity": t.entity, "ref": string(ref)}, t.entity)
+					}
+				}
+				i += len(ref)
+				continue
+			}
+		}
+		c, size := utf8.DecodeRune(span[i:])
+		if t, ok := typographicChars[c]; ok && r.Style == TypographicEntity {
+			report(start+i, size, "typographic-chars.entity", map[string]any{"name": t.name, "entity": t.entity}, t.entity)
+		}
+		i += size
+	}
+	return results
+}
@@ -1,7 +1,6 @@
 package rules
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/toba/go-html-validate/parser"
@@ -75,26 +74,32 @@ func (r *UniqueLandmark) Check(doc *parser.Document) []Result {
 		seenNames := make(map[string]*parser.Node)
 		for _, info := range infos {
 			if info.name == "" {
+				params := map[string]any{"role": role}
 				results = append(results, Result{
-					Rule:     r.Name(),
-					Message:  fmt.Sprintf("multiple %q landmarks, this one needs aria-label to distinguish it", role),
-					Filename: doc.Filename,
-					Line:     info.node.Line,
-					Col:      info.node.Col,
-					Severity: Warning,
+					Rule:      r.Name(),
+					Message:   catalogMessage("unique-landmark.unlabeled", params),
+					MessageID: "unique-landmark.unlabeled",
+					Params:    params,
+					Filename:  doc.Filename,
+					Line:      info.node.Line,
+					Col:       info.node.Col,
+					Severity:  Warning,
 				})
 				continue
 			}
 
 			normalizedName := strings.ToLower(strings.TrimSpace(info.name))
 			if prev, exists := seenNames[normalizedName]; exists {
+				params := map[string]any{"role": role, "name": info.name, "line": prev.Line}
 				results = append(results, Result{
-					Rule:     r.Name(),
-					Message:  fmt.Sprintf("duplicate %q landmark name %q (first at line %d)", role, info.name, prev.Line),
-					Filename: doc.Filename,
-					Line:     info.node.Line,
-					Col:      info.node.Col,
-					Severity: Warning,
+					Rule:      r.Name(),
+					Message:   catalogMessage("unique-landmark.duplicate", params),
+					MessageID: "unique-landmark.duplicate",
+					Params:    params,
+					Filename:  doc.Filename,
+					Line:      info.node.Line,
+					Col:       info.node.Col,
+					Severity:  Warning,
 				})
 			} else {
 				seenNames[normalizedName] = info.node
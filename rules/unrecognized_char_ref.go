@@ -6,14 +6,25 @@ import (
 	"regexp"
 
 	"github.com/toba/go-html-validate/parser"
+	xhtml "golang.org/x/net/html"
 )
 
-// namedCharRefPattern matches named character references like &amp; or &aacute;
-// Excludes numeric references (&#123; &#x1F;).
-var namedCharRefPattern = regexp.MustCompile(`&([a-zA-Z][a-zA-Z0-9]*);`)
+// charRefPattern matches a character reference at the start of its input:
+// a decimal or hex numeric reference, or a named reference like &amp;.
+// Group 1 captures the name of a named reference.
+var charRefPattern = regexp.MustCompile(`^&(?:#[0-9]+;|#[xX][0-9a-fA-F]+;|([a-zA-Z][a-zA-Z0-9]*);)`)
 
 // UnrecognizedCharRef checks that named character references are valid HTML5 entities.
-type UnrecognizedCharRef struct{}
+// Findings carry a fix that escapes the ampersand as &amp;.
+//
+// Options:
+//   - "bare-ampersand": also report & that does not start a character
+//     reference (e.g. "Tom & Jerry", "?a=1&b=2")
+//   - "bare-less-than": also report < in text content that does not start a tag
+type UnrecognizedCharRef struct {
+	BareAmpersand bool
+	BareLessThan  bool
+}
 
 func (r *UnrecognizedCharRef) Name() string { return RuleUnrecognizedCharRef }
 
@@ -21,44 +32,71 @@ func (r *UnrecognizedCharRef) Description() string {
 	return "character references must be valid HTML5 entities"
 }
 
+// ConfigureOptions applies the bare-ampersand and bare-less-than options.
+func (r *UnrecognizedCharRef) ConfigureOptions(opts map[string]any) {
+	r.BareAmpersand = BoolOption(opts, "bare-ampersand", r.BareAmpersand)
+	r.BareLessThan = BoolOption(opts, "bare-less-than", r.BareLessThan)
+}
+
 // Check implements Rule but returns nil - this rule uses CheckRaw instead.
 func (r *UnrecognizedCharRef) Check(_ *parser.Document) []Result {
 	return nil
 }
 
-// CheckRaw examines the raw content for unrecognized named character references.
+// CheckRaw examines text and attribute values in the raw content for
+// unrecognized character references and, when enabled, bare & and <.
+// Template actions, comments, and script/style content are skipped.
 func (r *UnrecognizedCharRef) CheckRaw(filename string, content []byte) []Result {
 	var results []Result
+	report := func(offset int, id string, params map[string]any, replacement string) {
+		line, col := offsetPosition(content, offset)
+		results = append(results, Result{
+			Rule:      r.Name(),
+			Message:   catalogMessage(id, params),
+			MessageID: id,
+			Params:    params,
+			Filename:  filename,
+			Line:      line,
+			Col:       col,
+			Severity:  Warning,
+			Fix:       &Fix{Start: offset, End: offset + 1, Text: replacement},
+		})
+	}
 
-	lines := bytes.Split(content, []byte("\n"))
-	for lineNum, line := range lines {
-		// Skip lines inside Go template expressions
-		lineStr := string(line)
+	masked := maskTemplateActions(content)
+	z := xhtml.NewTokenizer(bytes.NewReader(masked))
+	offset := 0
+	rawText := false // inside script or style, where & and < are literal
+	for {
+		tt := z.Next()
+		if tt == xhtml.ErrorToken {
+			break
+		}
+		raw := z.Raw()
+		start := offset
+		offset += len(raw)
 
-		matches := namedCharRefPattern.FindAllStringSubmatchIndex(lineStr, -1)
-		for _, match := range matches {
-			// match[0]:match[1] is the full match &name;
-			// match[2]:match[3] is the captured name
-			fullRef := lineStr[match[0]:match[1]]
-			name := lineStr[match[2]:match[3]]
+		switch tt {
+		case xhtml.StartTagToken:
+			name, _ := z.TagName()
+			rawText = string(name) == "script" || string(name) == "style"
+		case xhtml.EndTagToken, xhtml.SelfClosingTagToken:
+			rawText = false
+		}
 
-			// Skip if inside a Go template expression
-			if isInsideTemplateExpr(lineStr, match[0]) {
-				continue
-			}
+		if tt != xhtml.TextToken && tt != xhtml.StartTagToken && tt != xhtml.SelfClosingTagToken {
+			continue
+		}
+		if tt == xhtml.TextToken && rawText {
+			continue
+		}
 
-			// Use html.UnescapeString to check validity:
-			// if the result equals the input, the entity is unrecognized
-			unescaped := html.UnescapeString(fullRef)
-			if unescaped == fullRef {
-				results = append(results, Result{
-					Rule:     r.Name(),
-					Message:  "unrecognized character reference &" + name + ";",
-					Filename: filename,
-					Line:     lineNum + 1,
-					Col:      match[0] + 1,
-					Severity: Warning,
-				})
+		for i, c := range raw {
+			switch {
+			case c == '&':
+				r.checkAmpersand(masked[start+i:], start+i, report)
+			case c == '<' && tt == xhtml.TextToken && r.BareLessThan:
+				report(start+i, "unrecognized-char-ref.bare-less-than", nil, "&lt;")
 			}
 		}
 	}
@@ -66,18 +104,20 @@ func (r *UnrecognizedCharRef) CheckRaw(filename string, content []byte) []Result
 	return results
 }
 
-// isInsideTemplateExpr checks if a position is within a {{ ... }} template expression.
-func isInsideTemplateExpr(line string, pos int) bool {
-	// Find all {{ and }} positions and check if pos falls inside one
-	depth := 0
-	for i := 0; i < len(line)-1 && i < pos; i++ {
-		if line[i] == '{' && line[i+1] == '{' {
-			depth++
-			i++ // skip second brace
-		} else if line[i] == '}' && line[i+1] == '}' {
-			depth--
-			i++
+// checkAmpersand reports an & at offset that does not start a valid
+// character reference. rest is the content from the & onwards.
+func (r *UnrecognizedCharRef) checkAmpersand(rest []byte, offset int, report func(offset int, id string, params map[string]any, replacement string)) {
+	m := charRefPattern.FindSubmatch(rest)
+	switch {
+	case m == nil:
+		if r.BareAmpersand {
+			report(offset, "unrecognized-char-ref.bare-ampersand", nil, "&amp;")
+		}
+	case m[1] != nil:
+		// Use html.UnescapeString to check validity:
+		// if the result equals the input, the entity is unrecognized
+		if html.UnescapeString(string(m[0])) == string(m[0]) {
+			report(offset, "unrecognized-char-ref.unrecognized", map[string]any{"name": string(m[1])}, "&amp;")
 		}
 	}
-	return depth > 0
 }
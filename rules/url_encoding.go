@@ -0,0 +1,134 @@
+package rules
+
+import (
+	"bytes"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	xhtml "golang.org/x/net/html"
+)
+
+// urlAttrs are the attributes whose values are URLs.
+var urlAttrs = map[string]bool{
+	"href": true,
+	"src":  true,
+}
+
+// urlEntityPattern matches an & followed by a name, and whether a ; follows.
+var urlEntityPattern = regexp.MustCompile(`&([a-zA-Z][a-zA-Z0-9]*)(;?)`)
+
+// URLEncoding checks href and src values for characters that must be
+// encoded: spaces, raw double quotes, and & followed by a character
+// reference name (e.g. ?a=1&copy=2). It also notes template values placed
+// in a query string without a urlquery pipeline.
+type URLEncoding struct{}
+
+// Name returns the rule identifier.
+func (r *URLEncoding) Name() string { return RuleURLEncoding }
+
+// Description returns what this rule checks.
+func (r *URLEncoding) Description() string {
+	return "URLs in href and src should be properly encoded"
+}
+
+// Check implements Rule but returns nil - this rule uses CheckRaw instead.
+func (r *URLEncoding) Check(_ *parser.Document) []Result {
+	return nil
+}
+
+// CheckRaw examines raw href and src values, so template actions and
+// character references are seen as written.
+func (r *URLEncoding) CheckRaw(filename string, content []byte) []Result {
+	var results []Result
+	report := func(offset int, id string, params map[string]any, sev Severity, fix *Fix) {
+		line, col := offsetPosition(content, offset)
+		results = append(results, Result{
+			Rule:      r.Name(),
+			Message:   catalogMessage(id, params),
+			MessageID: id,
+			Params:    params,
+			Filename:  filename,
+			Line:      line,
+			Col:       col,
+			Severity:  sev,
+			Fix:       fix,
+		})
+	}
+
+	masked := maskTemplateActions(content)
+	z := xhtml.NewTokenizer(bytes.NewReader(masked))
+	offset := 0
+	for {
+		tt := z.Next()
+		if tt == xhtml.ErrorToken {
+			break
+		}
+		raw := z.Raw()
+		start := offset
+		offset += len(raw)
+		if tt != xhtml.StartTagToken && tt != xhtml.SelfClosingTagToken {
+			continue
+		}
+
+		_, attrs := parser.ScanTagAttrs(raw)
+		for _, attr := range attrs {
+			if !urlAttrs[attr.Name] || attr.ValueStart < 0 {
+				continue
+			}
+			valStart, valEnd := start+attr.ValueStart, start+attr.ValueEnd
+			r.checkValue(content[valStart:valEnd], masked[valStart:valEnd], valStart, attr.Name, report)
+		}
+	}
+
+	return results
+}
+
+// checkValue checks one raw URL value. value is the original text and
+// masked the same text with template actions hidden; base is its offset.
+func (r *URLEncoding) checkValue(value, masked []byte, base int, attr string, report func(int, string, map[string]any, Severity, *Fix)) {
+	trimmed := bytes.TrimSpace(masked) // browsers strip surrounding whitespace
+	lead := len(masked) - len(bytes.TrimLeft(masked, " \t\n\r\f"))
+	if i := bytes.IndexAny(trimmed, " \t\n"); i >= 0 {
+		report(base+lead+i, "url-encoding.whitespace", map[string]any{"attr": attr}, Warning, nil)
+	}
+	if i := bytes.IndexByte(masked, '"'); i >= 0 {
+		report(base+i, "url-encoding.quote", map[string]any{"attr": attr}, Warning, nil)
+	}
+
+	for _, m := range urlEntityPattern.FindAllSubmatchIndex(masked, -1) {
+		if m[5] > m[4] {
+			continue // a terminated reference such as &amp;
+		}
+		name := string(masked[m[2]:m[3]])
+		if html.UnescapeString("&"+name+";") == "&"+name+";" {
+			continue
+		}
+		report(base+m[0], "url-encoding.ampersand", map[string]any{"name": name, "attr": attr}, Warning, &Fix{Start: base + m[0], End: base + m[0] + 1, Text: "&amp;"})
+	}
+
+	query := bytes.IndexByte(masked, '?')
+	if query < 0 {
+		return
+	}
+	for _, m := range templateActionBounds.FindAllIndex(value[query:], -1) {
+		action := string(value[query+m[0] : query+m[1]])
+		if !isOutputAction(action) || strings.Contains(action, "urlquery") {
+			continue
+		}
+		report(base+query+m[0], "url-encoding.urlquery", map[string]any{"attr": attr}, Info, nil)
+	}
+}
+
+// isOutputAction reports whether a template action renders a value, as
+// opposed to control flow, comments, and variable declarations.
+func isOutputAction(action string) bool {
+	body := strings.TrimSpace(strings.Trim(action, "{}"))
+	body = strings.TrimSpace(strings.Trim(body, "-"))
+	keyword, _, _ := strings.Cut(body, " ")
+	if controlFlowKeywords[keyword] || keyword == "break" || keyword == "continue" || strings.HasPrefix(body, "/*") {
+		return false
+	}
+	return !strings.Contains(body, ":=")
+}
@@ -37,6 +37,7 @@ func (r *ValidAutocomplete) Check(doc *parser.Document) []Result {
 		}
 
 		autocomplete := n.GetAttr("autocomplete")
+		line, col := n.AttrPos("autocomplete")
 		if autocomplete == "" || autocomplete == TemplateExprPlaceholder {
 			return true
 		}
@@ -65,13 +66,16 @@ func (r *ValidAutocomplete) Check(doc *parser.Document) []Result {
 
 			// Check if it's a valid autocomplete token
 			if !AutocompleteTokens[token] {
+				params := map[string]any{"token": token}
 				results = append(results, Result{
-					Rule:     RuleValidAutocomplete,
-					Message:  "invalid autocomplete token: " + token,
-					Filename: doc.Filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Warning,
+					Rule:      RuleValidAutocomplete,
+					Message:   catalogMessage("valid-autocomplete.invalid", params),
+					MessageID: "valid-autocomplete.invalid",
+					Params:    params,
+					Filename:  doc.Filename,
+					Line:      line,
+					Col:       col,
+					Severity:  Warning,
 				})
 			}
 		}
@@ -0,0 +1,135 @@
+package rules
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/toba/go-html-validate/parser"
+	"golang.org/x/net/html"
+)
+
+var (
+	// telNumberPattern matches an RFC 3966 number: digits with - and .
+	// separators, optionally global (+), plus the DTMF characters * # and
+	// the letters a-d, p, w used for pauses.
+	telNumberPattern = regexp.MustCompile(`^\+?[0-9*#a-dA-DpPwW.\-]+$`)
+	// mailtoAddrPattern is a loose addr-spec check: local@domain without spaces.
+	mailtoAddrPattern = regexp.MustCompile(`^[^\s@<>()\[\],;:"]+@[^\s@<>()\[\],;:"]+\.[^\s@<>()\[\],;:"]+$`)
+	// mailtoHeaderPattern matches a header field name in a mailto query.
+	mailtoHeaderPattern = regexp.MustCompile(`^[A-Za-z0-9\-]+$`)
+	// badPercentPattern matches % not followed by two hex digits.
+	badPercentPattern = regexp.MustCompile(`%(?:[^0-9A-Fa-f]|[0-9A-Fa-f][^0-9A-Fa-f]|[0-9A-Fa-f]?$)`)
+)
+
+// ValidContactLink checks the syntax of tel: (RFC 3966) and mailto:
+// (RFC 6068) links, which are often malformed in hand-written templates.
+type ValidContactLink struct{}
+
+// Name returns the rule identifier.
+func (r *ValidContactLink) Name() string { return RuleValidContactLink }
+
+// Description returns what this rule checks.
+func (r *ValidContactLink) Description() string {
+	return "tel: and mailto: links must be well formed"
+}
+
+// Check examines a and area hrefs with tel: or mailto: schemes.
+func (r *ValidContactLink) Check(doc *parser.Document) []Result {
+	var results []Result
+
+	doc.Walk(func(n *parser.Node) bool {
+		if n.Type != html.ElementNode || !TagIn(n, "a", "area") {
+			return true
+		}
+
+		href := strings.TrimSpace(n.GetAttr("href"))
+		if IsTemplateExpr(href) {
+			return true
+		}
+
+		var m message
+		scheme, rest, _ := strings.Cut(href, ":")
+		switch strings.ToLower(scheme) {
+		case "tel":
+			m = telProblem(rest)
+		case "mailto":
+			m = mailtoProblem(rest)
+		}
+
+		if m.id != "" {
+			line, col := n.AttrPos("href")
+			results = append(results, Result{
+				Rule:      RuleValidContactLink,
+				Message:   m.text(),
+				MessageID: m.id,
+				Params:    m.params,
+				Filename:  doc.Filename,
+				Line:      line,
+				Col:       col,
+				Severity:  Warning,
+			})
+		}
+
+		return true
+	})
+
+	return results
+}
+
+// telProblem describes what is wrong with the part of a tel: URI after the
+// scheme, or returns the zero message.
+func telProblem(rest string) message {
+	number, params, _ := strings.Cut(rest, ";")
+	switch {
+	case number == "":
+		return message{"valid-contact-link.tel-empty", nil}
+	case strings.ContainsAny(number, " \t") || strings.Contains(number, "%20"):
+		return message{"valid-contact-link.tel-spaces", nil}
+	case strings.ContainsAny(number, "()"):
+		return message{"valid-contact-link.tel-parentheses", nil}
+	case !telNumberPattern.MatchString(number):
+		return message{"valid-contact-link.tel-chars", map[string]any{"number": number}}
+	case !strings.HasPrefix(number, "+") && !strings.Contains(strings.ToLower(params), "phone-context="):
+		return message{"valid-contact-link.tel-global", nil}
+	}
+	return message{}
+}
+
+// mailtoProblem describes what is wrong with the part of a mailto: URI
+// after the scheme, or returns the zero message.
+func mailtoProblem(rest string) message {
+	to, query, hasQuery := strings.Cut(rest, "?")
+
+	if strings.ContainsAny(rest, " \t") {
+		return message{"valid-contact-link.mailto-spaces", nil}
+	}
+	if badPercentPattern.MatchString(rest) {
+		return message{"valid-contact-link.mailto-percent", nil}
+	}
+
+	if hasQuery {
+		for field := range strings.SplitSeq(query, "&") {
+			name, _, ok := strings.Cut(field, "=")
+			if !ok || !mailtoHeaderPattern.MatchString(name) {
+				return message{"valid-contact-link.mailto-query", map[string]any{"field": field}}
+			}
+		}
+	}
+
+	// RFC 6068 makes the address optional: a share link such as
+	// mailto:?subject=...&body=... lets the user pick the recipient
+	if to == "" {
+		if !hasQuery {
+			return message{"valid-contact-link.mailto-empty", nil}
+		}
+		return message{}
+	}
+	for addr := range strings.SplitSeq(to, ",") {
+		decoded, err := url.PathUnescape(addr)
+		if err != nil || !mailtoAddrPattern.MatchString(decoded) {
+			return message{"valid-contact-link.mailto-address", map[string]any{"address": addr}}
+		}
+	}
+	return message{}
+}
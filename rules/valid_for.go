@@ -65,13 +65,16 @@ func (r *ValidFor) Check(doc *parser.Document) []Result {
 
 		tag := strings.ToLower(target.Data)
 		if !labelableElements[tag] {
+			params := map[string]any{"element": tag}
 			results = append(results, Result{
-				Rule:     r.Name(),
-				Message:  "label for attribute references non-labelable element <" + tag + ">",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Error,
+				Rule:      r.Name(),
+				Message:   catalogMessage("valid-for.non-labelable", params),
+				MessageID: "valid-for.non-labelable",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Error,
 			})
 			return true
 		}
@@ -81,12 +84,13 @@ func (r *ValidFor) Check(doc *parser.Document) []Result {
 			inputType := strings.ToLower(target.GetAttr("type"))
 			if inputType == "hidden" {
 				results = append(results, Result{
-					Rule:     r.Name(),
-					Message:  "label for attribute references hidden input",
-					Filename: doc.Filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Error,
+					Rule:      r.Name(),
+					Message:   catalogMessage("valid-for.hidden-input", nil),
+					MessageID: "valid-for.hidden-input",
+					Filename:  doc.Filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Error,
 				})
 			}
 		}
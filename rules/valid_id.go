@@ -30,42 +30,50 @@ func (r *ValidID) Check(doc *parser.Document) []Result {
 		}
 
 		id := n.GetAttr("id")
+		line, col := n.AttrPos("id")
 
 		// Check for empty ID
 		if id == "" {
 			results = append(results, Result{
-				Rule:     r.Name(),
-				Message:  "id attribute must not be empty; provide a unique identifier or remove the attribute",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Error,
+				Rule:      r.Name(),
+				Message:   catalogMessage("valid-id.empty", nil),
+				MessageID: "valid-id.empty",
+				Filename:  doc.Filename,
+				Line:      line,
+				Col:       col,
+				Severity:  Error,
 			})
 			return true
 		}
 
 		// Check for whitespace
 		if strings.ContainsAny(id, " \t\n\r") {
+			params := map[string]any{"id": id}
 			results = append(results, Result{
-				Rule:     r.Name(),
-				Message:  "id \"" + id + "\" contains whitespace; use hyphens or underscores instead of spaces",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Error,
+				Rule:      r.Name(),
+				Message:   catalogMessage("valid-id.whitespace", params),
+				MessageID: "valid-id.whitespace",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      line,
+				Col:       col,
+				Severity:  Error,
 			})
 			return true
 		}
 
 		// Warn if starts with a digit (CSS selector issues)
 		if id != "" && unicode.IsDigit(rune(id[0])) {
+			params := map[string]any{"id": id}
 			results = append(results, Result{
-				Rule:     r.Name(),
-				Message:  "id \"" + id + "\" starts with digit; prefix with letter to avoid CSS selector issues",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Warning,
+				Rule:      r.Name(),
+				Message:   catalogMessage("valid-id.digit", params),
+				MessageID: "valid-id.digit",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      line,
+				Col:       col,
+				Severity:  Warning,
 			})
 		}
 
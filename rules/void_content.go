@@ -37,25 +37,31 @@ func (r *VoidContent) Check(doc *parser.Document) []Result {
 		// Check for child elements (text nodes are also invalid)
 		for _, child := range n.Children {
 			if child.Type == html.ElementNode {
+				params := map[string]any{"element": tag}
 				results = append(results, Result{
-					Rule:     RuleVoidContent,
-					Message:  "void element <" + tag + "> must not have child elements",
-					Filename: doc.Filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Error,
+					Rule:      RuleVoidContent,
+					Message:   catalogMessage("void-content.children", params),
+					MessageID: "void-content.children",
+					Params:    params,
+					Filename:  doc.Filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Error,
 				})
 				break
 			}
 			// Check for non-whitespace text content
 			if child.Type == html.TextNode && strings.TrimSpace(child.Data) != "" {
+				params := map[string]any{"element": tag}
 				results = append(results, Result{
-					Rule:     RuleVoidContent,
-					Message:  "void element <" + tag + "> must not have text content",
-					Filename: doc.Filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Error,
+					Rule:      RuleVoidContent,
+					Message:   catalogMessage("void-content.text", params),
+					MessageID: "void-content.text",
+					Params:    params,
+					Filename:  doc.Filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Error,
 				})
 				break
 			}
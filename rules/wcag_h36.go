@@ -37,12 +37,13 @@ func (r *WcagH36) Check(doc *parser.Document) []Result {
 		// Check for alt attribute
 		if !n.HasAttr("alt") {
 			results = append(results, Result{
-				Rule:     RuleWcagH36,
-				Message:  "input type=\"image\" must have alt attribute",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Error,
+				Rule:      RuleWcagH36,
+				Message:   catalogMessage("wcag/h36.missing", nil),
+				MessageID: "wcag/h36.missing",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Error,
 			})
 			return true
 		}
@@ -51,12 +52,13 @@ func (r *WcagH36) Check(doc *parser.Document) []Result {
 		alt := n.GetAttr("alt")
 		if alt == "" {
 			results = append(results, Result{
-				Rule:     RuleWcagH36,
-				Message:  "input type=\"image\" has empty alt attribute",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Error,
+				Rule:      RuleWcagH36,
+				Message:   catalogMessage("wcag/h36.empty", nil),
+				MessageID: "wcag/h36.empty",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Error,
 			})
 		}
 
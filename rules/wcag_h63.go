@@ -29,12 +29,13 @@ func (r *WcagH63) Check(doc *parser.Document) []Result {
 		// Check for scope attribute
 		if !n.HasAttr("scope") {
 			results = append(results, Result{
-				Rule:     RuleWcagH63,
-				Message:  "th element should have scope attribute (col, row, colgroup, or rowgroup)",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Warning,
+				Rule:      RuleWcagH63,
+				Message:   catalogMessage("wcag/h63.missing", nil),
+				MessageID: "wcag/h63.missing",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
 			})
 			return true
 		}
@@ -42,13 +43,16 @@ func (r *WcagH63) Check(doc *parser.Document) []Result {
 		// Validate scope value
 		scope := n.GetAttr("scope")
 		if !ValidScopeValues[scope] && scope != TemplateExprPlaceholder {
+			params := map[string]any{"scope": scope}
 			results = append(results, Result{
-				Rule:     RuleWcagH63,
-				Message:  "th scope attribute has invalid value: " + scope,
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Error,
+				Rule:      RuleWcagH63,
+				Message:   catalogMessage("wcag/h63.invalid", params),
+				MessageID: "wcag/h63.invalid",
+				Params:    params,
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Error,
 			})
 		}
 
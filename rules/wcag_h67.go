@@ -40,12 +40,13 @@ func (r *WcagH67) Check(doc *parser.Document) []Result {
 			title := n.GetAttr("title")
 			if title != "" && title != TemplateExprPlaceholder {
 				results = append(results, Result{
-					Rule:     RuleWcagH67,
-					Message:  "decorative image (alt=\"\") should not have title attribute",
-					Filename: doc.Filename,
-					Line:     n.Line,
-					Col:      n.Col,
-					Severity: Warning,
+					Rule:      RuleWcagH67,
+					Message:   catalogMessage("wcag/h67.title", nil),
+					MessageID: "wcag/h67.title",
+					Filename:  doc.Filename,
+					Line:      n.Line,
+					Col:       n.Col,
+					Severity:  Warning,
 				})
 			}
 		}
@@ -54,12 +55,13 @@ func (r *WcagH67) Check(doc *parser.Document) []Result {
 		role := strings.ToLower(n.GetAttr("role"))
 		if role == "img" {
 			results = append(results, Result{
-				Rule:     RuleWcagH67,
-				Message:  "decorative image (alt=\"\") should have role=\"presentation\" or role=\"none\"",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Warning,
+				Rule:      RuleWcagH67,
+				Message:   catalogMessage("wcag/h67.role", nil),
+				MessageID: "wcag/h67.role",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Warning,
 			})
 		}
 
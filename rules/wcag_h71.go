@@ -37,12 +37,13 @@ func (r *WcagH71) Check(doc *parser.Document) []Result {
 
 		if !hasLegend {
 			results = append(results, Result{
-				Rule:     RuleWcagH71,
-				Message:  "fieldset element must contain a legend element",
-				Filename: doc.Filename,
-				Line:     n.Line,
-				Col:      n.Col,
-				Severity: Error,
+				Rule:      RuleWcagH71,
+				Message:   catalogMessage("wcag/h71.missing", nil),
+				MessageID: "wcag/h71.missing",
+				Filename:  doc.Filename,
+				Line:      n.Line,
+				Col:       n.Col,
+				Severity:  Error,
 			})
 		}
 